@@ -0,0 +1,172 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/mitchellh/cli"
+	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/debug/v1"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/diagnostics"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/version"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/agent/diagnostics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultConfigPath = "conf/agent/agent.conf"
+
+func NewDumpCommand() cli.Command {
+	return newDumpCommand(common_cli.DefaultEnv)
+}
+
+func newDumpCommand(env *common_cli.Env) *dumpCommand {
+	return &dumpCommand{
+		env: env,
+	}
+}
+
+type dumpCommand struct {
+	dumpCommandOS // os specific
+
+	env *common_cli.Env
+
+	configPath string
+	outPath    string
+}
+
+func (c *dumpCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *dumpCommand) Synopsis() string {
+	return "Collects agent diagnostics into an archive to troubleshoot it"
+}
+
+func (c *dumpCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+	if err := c.run(context.Background()); err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *dumpCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("debug dump", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.configPath, "config", defaultConfigPath, "Path to the SPIRE Agent config file. Plugin data and secrets are redacted from the archived copy")
+	fs.StringVar(&c.outPath, "out", "", "Path to write the gzip compressed tar archive to. Defaults to spire-agent-debug-<timestamp>.tar.gz in the current directory")
+	c.addOSFlags(fs)
+	return fs.Parse(args)
+}
+
+// run collects the agent diagnostics into an archive. Failing to collect a
+// file does not fail the command; the failure is recorded in the archive.
+func (c *dumpCommand) run(ctx context.Context) error {
+	now := time.Now().UTC()
+	outPath := c.outPath
+	if outPath == "" {
+		outPath = fmt.Sprintf("spire-agent-debug-%s.tar.gz", now.Format("20060102T150405Z"))
+	}
+	outPath = c.env.JoinPath(outPath)
+
+	addr, err := c.getAddr()
+	if err != nil {
+		return err
+	}
+	conn, err := dial(ctx, addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The debug and diagnostics APIs are only served by the admin API, which
+	// is optional, so the dump goes on without them.
+	var adminConn *grpc.ClientConn
+	adminAddr, adminErr := c.getAdminAddr()
+	if adminErr == nil {
+		adminConn, adminErr = dial(ctx, adminAddr)
+	}
+	if adminErr == nil {
+		defer adminConn.Close()
+	}
+
+	var diagnosticsResp *diagnosticsv1.GetDiagnosticsResponse
+	diagnosticsErr := adminErr
+	if adminErr == nil {
+		diagnosticsResp, diagnosticsErr = diagnosticsv1.NewDiagnosticsClient(adminConn).GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+	}
+
+	archive := diagnostics.NewArchive(now)
+
+	versions := fmt.Sprintf("CLI: %s\n", version.Version())
+	if diagnosticsErr == nil {
+		versions += fmt.Sprintf("Agent: %s\n", diagnosticsResp.Version)
+	}
+	archive.AddFile("version.txt", []byte(versions))
+
+	archive.CollectProto("info.json", func() (proto.Message, error) {
+		if adminErr != nil {
+			return nil, adminErr
+		}
+		return debugv1.NewDebugClient(adminConn).GetInfo(ctx, &debugv1.GetInfoRequest{})
+	})
+	archive.CollectProto("health.json", func() (proto.Message, error) {
+		return grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	})
+	archive.Collect("config.conf", func() ([]byte, error) {
+		return diagnostics.ReadRedactedConfig(c.env.JoinPath(c.configPath))
+	})
+	archive.Collect("goroutines.txt", func() ([]byte, error) {
+		if diagnosticsErr != nil {
+			return nil, diagnosticsErr
+		}
+		return diagnosticsResp.GoroutineProfile, nil
+	})
+	archive.Collect("metrics.json", func() ([]byte, error) {
+		switch {
+		case diagnosticsErr != nil:
+			return nil, diagnosticsErr
+		case len(diagnosticsResp.InmemMetrics) == 0:
+			return nil, errors.New("the InMem telemetry sink is not configured")
+		}
+		return diagnosticsResp.InmemMetrics, nil
+	})
+
+	data, err := archive.Close()
+	if err != nil {
+		return err
+	}
+	if err := diskutil.WritePrivateFile(outPath, data); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+
+	for _, collectErr := range archive.Errors() {
+		if err := c.env.ErrPrintf("Unable to collect %s\n", collectErr); err != nil {
+			return err
+		}
+	}
+	return c.env.Printf("Debug dump written to %s\n", outPath)
+}
+
+func dial(ctx context.Context, addr net.Addr) (*grpc.ClientConn, error) {
+	target, err := util.GetTargetName(addr)
+	if err != nil {
+		return nil, err
+	}
+	return util.GRPCDialContext(ctx, target)
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+// +build !windows
+
+package debug
+
+import (
+	"errors"
+	"flag"
+	"net"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
+	"github.com/spiffe/spire/pkg/common/util"
+)
+
+// dumpCommandOS has posix specific implementation
+// that complements dumpCommand
+type dumpCommandOS struct {
+	socketPath      string
+	adminSocketPath string
+}
+
+func (c *dumpCommandOS) addOSFlags(flags *flag.FlagSet) {
+	flags.StringVar(&c.socketPath, "socketPath", common.DefaultSocketPath, "Path to the SPIRE Agent API socket")
+	flags.StringVar(&c.adminSocketPath, "adminSocketPath", "", "Path to the SPIRE Agent admin API socket. Agent debug information and runtime diagnostics are only collected if set")
+}
+
+func (c *dumpCommandOS) getAddr() (net.Addr, error) {
+	return util.GetUnixAddrWithAbsPath(c.socketPath)
+}
+
+func (c *dumpCommandOS) getAdminAddr() (net.Addr, error) {
+	if c.adminSocketPath == "" {
+		return nil, errors.New("the admin API socket is not set (-adminSocketPath)")
+	}
+	return util.GetUnixAddrWithAbsPath(c.adminSocketPath)
+}
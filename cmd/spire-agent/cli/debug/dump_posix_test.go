@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package debug
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"google.golang.org/grpc"
+)
+
+var (
+	usage = `Usage of debug dump:
+  -adminSocketPath string
+    	Path to the SPIRE Agent admin API socket. Agent debug information and runtime diagnostics are only collected if set
+  -config string
+    	Path to the SPIRE Agent config file. Plugin data and secrets are redacted from the archived copy (default "conf/agent/agent.conf")
+  -out string
+    	Path to write the gzip compressed tar archive to. Defaults to spire-agent-debug-<timestamp>.tar.gz in the current directory
+  -socketPath string
+    	Path to the SPIRE Agent API socket (default "/tmp/spire-agent/public/api.sock")
+`
+	socketAddrArg      = "-socketPath"
+	adminAddrArg       = "-adminSocketPath"
+	adminAddrUnsetErr  = "the admin API socket is not set (-adminSocketPath)"
+	socketAddrNotFound = "/tmp/doesnotexist.sock"
+)
+
+func startGRPCSocketServer(t *testing.T, registerFn func(srv *grpc.Server)) string {
+	return spiretest.StartGRPCServer(t, registerFn).String()
+}
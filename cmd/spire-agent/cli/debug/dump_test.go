@@ -0,0 +1,251 @@
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/agent/debug/v1"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/version"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/agent/diagnostics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const testConfig = `agent {
+    trust_domain = "example.org"
+}
+
+plugins {
+    NodeAttestor "join_token" {
+        plugin_data {
+            join_token = "secret"
+        }
+    }
+}
+`
+
+type dumpTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	dir    string
+
+	cmd cli.Command
+}
+
+func setupTest(t *testing.T) *dumpTest {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	return &dumpTest{
+		stdout: stdout,
+		stderr: stderr,
+		dir:    t.TempDir(),
+		cmd: newDumpCommand(&common_cli.Env{
+			Stdin:  new(bytes.Buffer),
+			Stdout: stdout,
+			Stderr: stderr,
+		}),
+	}
+}
+
+func TestSynopsis(t *testing.T) {
+	test := setupTest(t)
+	require.Equal(t, "Collects agent diagnostics into an archive to troubleshoot it", test.cmd.Synopsis())
+}
+
+func TestHelp(t *testing.T) {
+	test := setupTest(t)
+
+	require.Empty(t, test.cmd.Help())
+	require.Equal(t, usage, test.stderr.String(), "stderr")
+}
+
+func TestBadFlags(t *testing.T) {
+	test := setupTest(t)
+
+	code := test.cmd.Run([]string{"-badflag"})
+	require.NotEqual(t, 0, code, "exit code")
+	require.Empty(t, test.stdout.String(), "stdout")
+	require.Equal(t, "flag provided but not defined: -badflag\n"+usage, test.stderr.String(), "stderr")
+}
+
+func TestDump(t *testing.T) {
+	test := setupTest(t)
+	socketAddr := startGRPCSocketServer(t, func(s *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(s, &fakeHealthServer{})
+	})
+	adminAddr := startGRPCSocketServer(t, func(s *grpc.Server) {
+		debugv1.RegisterDebugServer(s, &fakeDebugServer{})
+		diagnosticsv1.RegisterDiagnosticsServer(s, &fakeDiagnosticsServer{
+			resp: &diagnosticsv1.GetDiagnosticsResponse{
+				Version:          "1.2.3",
+				GoroutineProfile: []byte("goroutine 1 [running]:"),
+				InmemMetrics:     []byte(`{"Counters":[]}`),
+			},
+		})
+	})
+	configPath := filepath.Join(test.dir, "agent.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	code := test.cmd.Run([]string{socketAddrArg, socketAddr, adminAddrArg, adminAddr, "-config", configPath, "-out", outPath})
+
+	require.Equal(t, 0, code, test.stderr.String())
+	require.Empty(t, test.stderr.String())
+	require.Equal(t, fmt.Sprintf("Debug dump written to %s\n", outPath), test.stdout.String())
+
+	files := readArchive(t, outPath)
+	require.ElementsMatch(t, []string{
+		"version.txt",
+		"info.json",
+		"health.json",
+		"config.conf",
+		"goroutines.txt",
+		"metrics.json",
+	}, keys(files))
+	assert.Equal(t, fmt.Sprintf("CLI: %s\nAgent: 1.2.3\n", version.Version()), files["version.txt"])
+	assert.JSONEq(t, `{"svidsCount":2,"uptime":10}`, files["info.json"])
+	assert.JSONEq(t, `{"status":"SERVING"}`, files["health.json"])
+	assert.Equal(t, `agent {
+  trust_domain = "example.org"
+}
+
+plugins {
+  NodeAttestor "join_token" {
+    plugin_data = "[REDACTED]"
+  }
+}
+`, files["config.conf"])
+	assert.Equal(t, "goroutine 1 [running]:", files["goroutines.txt"])
+	assert.Equal(t, `{"Counters":[]}`, files["metrics.json"])
+}
+
+func TestDumpWithoutAdminAPI(t *testing.T) {
+	test := setupTest(t)
+	socketAddr := startGRPCSocketServer(t, func(s *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(s, &fakeHealthServer{})
+	})
+	configPath := filepath.Join(test.dir, "agent.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	code := test.cmd.Run([]string{socketAddrArg, socketAddr, "-config", configPath, "-out", outPath})
+
+	require.Equal(t, 0, code, test.stderr.String())
+	require.Equal(t, fmt.Sprintf("Debug dump written to %s\n", outPath), test.stdout.String())
+
+	expectErrors := fmt.Sprintf(`info.json: %[1]s
+goroutines.txt: %[1]s
+metrics.json: %[1]s
+`, adminAddrUnsetErr)
+
+	files := readArchive(t, outPath)
+	require.ElementsMatch(t, []string{"version.txt", "health.json", "config.conf", "errors.txt"}, keys(files))
+	assert.Equal(t, fmt.Sprintf("CLI: %s\n", version.Version()), files["version.txt"])
+	assert.Equal(t, expectErrors, files["errors.txt"])
+	assert.Equal(t, expectErrors, strings.ReplaceAll(test.stderr.String(), "Unable to collect ", ""))
+}
+
+func TestDumpWithCollectionErrors(t *testing.T) {
+	test := setupTest(t)
+	adminAddr := startGRPCSocketServer(t, func(s *grpc.Server) {
+		debugv1.RegisterDebugServer(s, &fakeDebugServer{err: status.Error(codes.Internal, "debug failed")})
+		diagnosticsv1.RegisterDiagnosticsServer(s, &fakeDiagnosticsServer{
+			resp: &diagnosticsv1.GetDiagnosticsResponse{Version: "1.2.3"},
+		})
+	})
+	configPath := filepath.Join(test.dir, "agent.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte("agent {"), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	code := test.cmd.Run([]string{socketAddrArg, socketAddrNotFound, adminAddrArg, adminAddr, "-config", configPath, "-out", outPath})
+
+	require.Equal(t, 0, code, test.stderr.String())
+	require.Equal(t, fmt.Sprintf("Debug dump written to %s\n", outPath), test.stdout.String())
+
+	files := readArchive(t, outPath)
+	require.ElementsMatch(t, []string{"version.txt", "goroutines.txt", "errors.txt"}, keys(files))
+	assert.Equal(t, fmt.Sprintf("CLI: %s\nAgent: 1.2.3\n", version.Version()), files["version.txt"])
+	errorLines := strings.Split(strings.TrimSuffix(files["errors.txt"], "\n"), "\n")
+	require.Len(t, errorLines, 4)
+	assert.Equal(t, "info.json: rpc error: code = Internal desc = debug failed", errorLines[0])
+	assert.True(t, strings.HasPrefix(errorLines[1], "health.json: rpc error: code = Unavailable desc = connection error"), errorLines[1])
+	assert.Equal(t, "config.conf: At 1:9: object expected closing RBRACE got: EOF", errorLines[2])
+	assert.Equal(t, "metrics.json: the InMem telemetry sink is not configured", errorLines[3])
+}
+
+func readArchive(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func keys(files map[string]string) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	return names
+}
+
+type fakeDebugServer struct {
+	debugv1.UnimplementedDebugServer
+
+	err error
+}
+
+func (s *fakeDebugServer) GetInfo(context.Context, *debugv1.GetInfoRequest) (*debugv1.GetInfoResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &debugv1.GetInfoResponse{SvidsCount: 2, Uptime: 10}, nil
+}
+
+type fakeDiagnosticsServer struct {
+	diagnosticsv1.UnimplementedDiagnosticsServer
+
+	resp *diagnosticsv1.GetDiagnosticsResponse
+}
+
+func (s *fakeDiagnosticsServer) GetDiagnostics(context.Context, *diagnosticsv1.GetDiagnosticsRequest) (*diagnosticsv1.GetDiagnosticsResponse, error) {
+	return s.resp, nil
+}
+
+type fakeHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (s *fakeHealthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
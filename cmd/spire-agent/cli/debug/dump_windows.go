@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package debug
+
+import (
+	"errors"
+	"flag"
+	"net"
+
+	"github.com/spiffe/spire/cmd/spire-agent/cli/common"
+	"github.com/spiffe/spire/pkg/common/namedpipe"
+)
+
+// dumpCommandOS has windows specific implementation
+// that complements dumpCommand
+type dumpCommandOS struct {
+	namedPipeName      string
+	adminNamedPipeName string
+}
+
+func (c *dumpCommandOS) addOSFlags(flags *flag.FlagSet) {
+	flags.StringVar(&c.namedPipeName, "namedPipeName", common.DefaultNamedPipeName, "Pipe name of the SPIRE Agent API named pipe")
+	flags.StringVar(&c.adminNamedPipeName, "adminNamedPipeName", "", "Pipe name of the SPIRE Agent admin API named pipe. Agent debug information and runtime diagnostics are only collected if set")
+}
+
+func (c *dumpCommandOS) getAddr() (net.Addr, error) {
+	return namedpipe.AddrFromName(c.namedPipeName), nil
+}
+
+func (c *dumpCommandOS) getAdminAddr() (net.Addr, error) {
+	if c.adminNamedPipeName == "" {
+		return nil, errors.New("the admin API named pipe is not set (-adminNamedPipeName)")
+	}
+	return namedpipe.AddrFromName(c.adminNamedPipeName), nil
+}
@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+package debug
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/namedpipe"
+	"github.com/spiffe/spire/test/spiretest"
+	"google.golang.org/grpc"
+)
+
+var (
+	usage = `Usage of debug dump:
+  -adminNamedPipeName string
+    	Pipe name of the SPIRE Agent admin API named pipe. Agent debug information and runtime diagnostics are only collected if set
+  -config string
+    	Path to the SPIRE Agent config file. Plugin data and secrets are redacted from the archived copy (default "conf/agent/agent.conf")
+  -namedPipeName string
+    	Pipe name of the SPIRE Agent API named pipe (default "\\spire-agent\\public\\api")
+  -out string
+    	Path to write the gzip compressed tar archive to. Defaults to spire-agent-debug-<timestamp>.tar.gz in the current directory
+`
+	socketAddrArg      = "-namedPipeName"
+	adminAddrArg       = "-adminNamedPipeName"
+	adminAddrUnsetErr  = "the admin API named pipe is not set (-adminNamedPipeName)"
+	socketAddrNotFound = "doesnotexist"
+)
+
+func startGRPCSocketServer(t *testing.T, registerFn func(srv *grpc.Server)) string {
+	return namedpipe.GetPipeName(spiretest.StartGRPCServer(t, registerFn).String())
+}
@@ -5,43 +5,241 @@ package agent_test
 
 var (
 	listUsage = `Usage of agent list:
+  -agentVersion string
+    	Filters agents to those that last reported running the given SPIRE version
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -lastSeenBefore string
+    	Filters agents to those last seen before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z), including agents never seen
   -matchSelectorsOn string
     	The match mode used when filtering by selectors. Options: exact, any, superset and subset (default "superset")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
   -selector value
     	A colon-delimited type:value selector. Can be used more than once
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	banUsage = `Usage of agent ban:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
   -spiffeID string
     	The SPIFFE ID of the agent to ban (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	evictUsage = `Usage of agent evict:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
   -spiffeID string
     	The SPIFFE ID of the agent to evict (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	countUsage = `Usage of agent count:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	showUsage = `Usage of agent show:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
   -socketPath string
     	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
   -spiffeID string
     	The SPIFFE ID of the agent to show (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	batchEvictUsage = `Usage of agent batch-evict:
+  -agentVersion string
+    	Selects agents that last reported running the given SPIRE version
+  -attestationType string
+    	Selects agents that attested with the given node attestor type
+  -banned
+    	Selects agents that are banned, or with -banned=false, agents that are not
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -canReattest
+    	Selects agents that can reattest, or with -canReattest=false, agents that cannot
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -dryRun
+    	Lists the agents that would be evicted without evicting them
+  -expiresBefore string
+    	Selects agents whose X509-SVID expires before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z)
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -lastSeenBefore string
+    	Selects agents last seen before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z), including agents never seen
+  -matchSelectorsOn string
+    	The match mode used when selecting agents by selectors. Options: exact, any, superset and subset (default "superset")
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -selector value
+    	A colon-delimited type:value selector the agents are selected by. Can be used more than once
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	batchBanUsage = `Usage of agent batch-ban:
+  -agentVersion string
+    	Selects agents that last reported running the given SPIRE version
+  -attestationType string
+    	Selects agents that attested with the given node attestor type
+  -banned
+    	Selects agents that are banned, or with -banned=false, agents that are not
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -canReattest
+    	Selects agents that can reattest, or with -canReattest=false, agents that cannot
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -dryRun
+    	Lists the agents that would be banned without banning them
+  -expiresBefore string
+    	Selects agents whose X509-SVID expires before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z)
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -lastSeenBefore string
+    	Selects agents last seen before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z), including agents never seen
+  -matchSelectorsOn string
+    	The match mode used when selecting agents by selectors. Options: exact, any, superset and subset (default "superset")
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -selector value
+    	A colon-delimited type:value selector the agents are selected by. Can be used more than once
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	reattestUsage = `Usage of agent reattest:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -spiffeID string
+    	The SPIFFE ID of the agent to reattest (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	selectorsListUsage = `Usage of agent selectors list:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -spiffeID string
+    	The SPIFFE ID of the agent (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	selectorsAddUsage = `Usage of agent selectors add:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -selector value
+    	A colon-delimited type:value selector. Can be used more than once
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -spiffeID string
+    	The SPIFFE ID of the agent (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+	selectorsRemoveUsage = `Usage of agent selectors remove:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -selector value
+    	A colon-delimited type:value selector. Can be used more than once
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -spiffeID string
+    	The SPIFFE ID of the agent (agent identity)
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 )
@@ -12,18 +12,28 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/common"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 var (
 	testAgents = []*types.Agent{
 		{Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent1"}},
 	}
+	testAgentStatuses = []*agentadminv1.AgentStatus{
+		{
+			Agent:        testAgents[0],
+			AgentVersion: "1.5.0",
+			Health:       agentadminv1.AgentHealth_NEVER_SEEN,
+		},
+	}
 	testAgentsWithBanned = []*types.Agent{
 		{
 			Id:     &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/banned"},
@@ -49,6 +59,7 @@ type agentTest struct {
 	stderr *bytes.Buffer
 	args   []string
 	server *fakeAgentServer
+	admin  *fakeAgentAdminServer
 	client cli.Command
 }
 
@@ -119,6 +130,216 @@ func TestBan(t *testing.T) {
 	}
 }
 
+func TestReattestHelp(t *testing.T) {
+	test := setupTest(t, agent.NewReattestCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, reattestUsage, test.stderr.String())
+}
+
+func TestReattest(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		args               []string
+		expectReturnCode   int
+		expectStdoutPretty string
+		expectStdoutJSON   string
+		expectStderr       string
+		expectRequest      *agentadminv1.ReattestAgentRequest
+		serverErr          error
+	}{
+		{
+			name:               "success",
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			expectReturnCode:   0,
+			expectStdoutPretty: "Agent reattestation requested successfully\n",
+			expectStdoutJSON:   "{}",
+			expectRequest: &agentadminv1.ReattestAgentRequest{
+				Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent1"},
+			},
+		},
+		{
+			name:             "no spiffe id",
+			expectReturnCode: 1,
+			expectStderr:     "Error: a SPIFFE ID is required\n",
+		},
+		{
+			name:             "wrong UDS path",
+			args:             []string{common.AddrArg, common.AddrValue},
+			expectReturnCode: 1,
+			expectStderr:     common.AddrError,
+		},
+		{
+			name:             "server error",
+			args:             []string{"-spiffeID", "spiffe://example.org/spire/agent/foo"},
+			serverErr:        status.Error(codes.FailedPrecondition, "agent cannot reattest"),
+			expectReturnCode: 1,
+			expectStderr:     "Error: rpc error: code = FailedPrecondition desc = agent cannot reattest\n",
+			expectRequest: &agentadminv1.ReattestAgentRequest{
+				Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/foo"},
+			},
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, agent.NewReattestCommandWithEnv)
+				test.admin.err = tt.serverErr
+				args := tt.args
+				args = append(args, "-output", format)
+
+				returnCode := test.client.Run(append(test.args, args...))
+
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectStdoutPretty, tt.expectStdoutJSON)
+				require.Equal(t, tt.expectStderr, test.stderr.String())
+				require.Equal(t, tt.expectReturnCode, returnCode)
+				spiretest.AssertProtoEqual(t, tt.expectRequest, test.admin.gotReattestRequest)
+			})
+		}
+	}
+}
+
+func TestSelectorsHelp(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		newCmd      func(*commoncli.Env) cli.Command
+		expectUsage string
+	}{
+		{name: "list", newCmd: agent.NewSelectorsListCommandWithEnv, expectUsage: selectorsListUsage},
+		{name: "add", newCmd: agent.NewSelectorsAddCommandWithEnv, expectUsage: selectorsAddUsage},
+		{name: "remove", newCmd: agent.NewSelectorsRemoveCommandWithEnv, expectUsage: selectorsRemoveUsage},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, tt.newCmd)
+
+			test.client.Help()
+			require.Equal(t, tt.expectUsage, test.stderr.String())
+		})
+	}
+}
+
+func TestSelectors(t *testing.T) {
+	agentID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/agent1"}
+	rack := &types.Selector{Type: "rack", Value: "r1"}
+	env := &types.Selector{Type: "environment", Value: "prod"}
+
+	for _, tt := range []struct {
+		name               string
+		newCmd             func(*commoncli.Env) cli.Command
+		args               []string
+		selectors          *agentadminv1.AgentSelectors
+		serverErr          error
+		expectReturnCode   int
+		expectStdoutPretty string
+		expectStdoutJSON   string
+		expectStderr       string
+		expectRequest      proto.Message
+	}{
+		{
+			name:   "list",
+			newCmd: agent.NewSelectorsListCommandWithEnv,
+			args:   []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			selectors: &agentadminv1.AgentSelectors{
+				NodeSelectors:     []*types.Selector{{Type: "join_token", Value: "token"}},
+				OperatorSelectors: []*types.Selector{rack},
+			},
+			expectStdoutPretty: "Node selector     : join_token:token\nOperator selector : rack:r1\n",
+			expectStdoutJSON:   `{"node_selectors":[{"type":"join_token","value":"token"}],"operator_selectors":[{"type":"rack","value":"r1"}]}`,
+			expectRequest:      &agentadminv1.GetAgentSelectorsRequest{Id: agentID},
+		},
+		{
+			name:               "list without selectors",
+			newCmd:             agent.NewSelectorsListCommandWithEnv,
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			selectors:          &agentadminv1.AgentSelectors{},
+			expectStdoutPretty: "No selectors found\n",
+			expectStdoutJSON:   `{"node_selectors":[],"operator_selectors":[]}`,
+			expectRequest:      &agentadminv1.GetAgentSelectorsRequest{Id: agentID},
+		},
+		{
+			name:   "add",
+			newCmd: agent.NewSelectorsAddCommandWithEnv,
+			args:   []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-selector", "rack:r1", "-selector", "environment:prod"},
+			selectors: &agentadminv1.AgentSelectors{
+				OperatorSelectors: []*types.Selector{env, rack},
+			},
+			expectStdoutPretty: "Operator selector : environment:prod\nOperator selector : rack:r1\n",
+			expectStdoutJSON:   `{"node_selectors":[],"operator_selectors":[{"type":"environment","value":"prod"},{"type":"rack","value":"r1"}]}`,
+			expectRequest: &agentadminv1.AddAgentSelectorsRequest{
+				Id:        agentID,
+				Selectors: []*types.Selector{rack, env},
+			},
+		},
+		{
+			name:               "remove",
+			newCmd:             agent.NewSelectorsRemoveCommandWithEnv,
+			args:               []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-selector", "rack:r1"},
+			selectors:          &agentadminv1.AgentSelectors{},
+			expectStdoutPretty: "No selectors found\n",
+			expectStdoutJSON:   `{"node_selectors":[],"operator_selectors":[]}`,
+			expectRequest: &agentadminv1.RemoveAgentSelectorsRequest{
+				Id:        agentID,
+				Selectors: []*types.Selector{rack},
+			},
+		},
+		{
+			name:             "no spiffe id",
+			newCmd:           agent.NewSelectorsListCommandWithEnv,
+			expectReturnCode: 1,
+			expectStderr:     "Error: a SPIFFE ID is required\n",
+		},
+		{
+			name:             "add without selectors",
+			newCmd:           agent.NewSelectorsAddCommandWithEnv,
+			args:             []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			expectReturnCode: 1,
+			expectStderr:     "Error: at least one selector is required\n",
+		},
+		{
+			name:             "remove with malformed selector",
+			newCmd:           agent.NewSelectorsRemoveCommandWithEnv,
+			args:             []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-selector", "rack"},
+			expectReturnCode: 1,
+			expectStderr:     "Error: error parsing selector \"rack\": selector \"rack\" must be formatted as type:value\n",
+		},
+		{
+			name:             "wrong UDS path",
+			newCmd:           agent.NewSelectorsListCommandWithEnv,
+			args:             []string{common.AddrArg, common.AddrValue},
+			expectReturnCode: 1,
+			expectStderr:     common.AddrError,
+		},
+		{
+			name:             "server error",
+			newCmd:           agent.NewSelectorsAddCommandWithEnv,
+			args:             []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1", "-selector", "rack:r1"},
+			serverErr:        status.Error(codes.NotFound, "agent not found"),
+			expectReturnCode: 1,
+			expectStderr:     "Error: rpc error: code = NotFound desc = agent not found\n",
+			expectRequest: &agentadminv1.AddAgentSelectorsRequest{
+				Id:        agentID,
+				Selectors: []*types.Selector{rack},
+			},
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, tt.newCmd)
+				test.admin.selectors = tt.selectors
+				test.admin.err = tt.serverErr
+				args := tt.args
+				args = append(args, "-output", format)
+
+				returnCode := test.client.Run(append(test.args, args...))
+
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectStdoutPretty, tt.expectStdoutJSON)
+				require.Equal(t, tt.expectStderr, test.stderr.String())
+				require.Equal(t, tt.expectReturnCode, returnCode)
+				spiretest.AssertProtoEqual(t, tt.expectRequest, test.admin.gotSelectorsRequest)
+			})
+		}
+	}
+}
+
 func TestEvictHelp(t *testing.T) {
 	test := setupTest(t, agent.NewEvictCommandWithEnv)
 
@@ -179,6 +400,229 @@ func TestEvict(t *testing.T) {
 	}
 }
 
+func TestBatchEvictHelp(t *testing.T) {
+	test := setupTest(t, agent.NewBatchEvictCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, batchEvictUsage, test.stderr.String())
+}
+
+func TestBatchEvict(t *testing.T) {
+	for _, tt := range []struct {
+		name                 string
+		args                 []string
+		pages                [][]*types.Agent
+		expectedReturnCode   int
+		expectedStdoutPretty string
+		expectedStdoutJSON   string
+		expectedStderr       string
+		expectedFilter       *agentadminv1.AgentFilter
+		expectedDryRun       bool
+		serverErr            error
+	}{
+		{
+			name:                 "success",
+			args:                 []string{"-attestationType", "join_token", "-canReattest=false", "-expiresBefore", "2022-10-20T00:00:00Z"},
+			pages:                [][]*types.Agent{testAgents, testAgentsWithBanned},
+			expectedStdoutPretty: "2 agents evicted:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedStdoutJSON:   `{"agents":[{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false},{"id":{"trust_domain":"example.org","path":"/spire/agent/banned"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":true}],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByAttestationType: "join_token",
+				ByCanReattest:     wrapperspb.Bool(false),
+				ByExpiresBefore:   1666224000,
+			},
+		},
+		{
+			name:                 "dry run",
+			args:                 []string{"-selector", "k8s_psat:cluster:demo-cluster", "-matchSelectorsOn", "any", "-dryRun"},
+			pages:                [][]*types.Agent{testAgentsWithSelectors},
+			expectedStdoutPretty: "1 agent would be evicted:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent2",
+			expectedStdoutJSON:   `{"agents":[{"id":{"trust_domain":"example.org","path":"/spire/agent/agent2"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[{"type":"k8s_psat","value":"agent_ns:spire"},{"type":"k8s_psat","value":"agent_sa:spire-agent"},{"type":"k8s_psat","value":"cluster:demo-cluster"}],"banned":false}],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				BySelectorMatch: &types.SelectorMatch{
+					Selectors: []*types.Selector{{Type: "k8s_psat", Value: "cluster:demo-cluster"}},
+					Match:     types.SelectorMatch_MATCH_ANY,
+				},
+			},
+			expectedDryRun: true,
+		},
+		{
+			name:                 "no agents",
+			args:                 []string{"-banned"},
+			expectedStdoutPretty: "No agents evicted\n",
+			expectedStdoutJSON:   `{"agents":[],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByBanned: wrapperspb.Bool(true),
+			},
+		},
+		{
+			name:                 "by agent status",
+			args:                 []string{"-agentVersion", "1.5.0", "-lastSeenBefore", "2022-10-20T00:00:00Z"},
+			pages:                [][]*types.Agent{testAgents},
+			expectedStdoutPretty: "1 agent evicted:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedStdoutJSON:   `{"agents":[{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false}],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByAgentVersion:   "1.5.0",
+				ByLastSeenBefore: 1666224000,
+			},
+		},
+		{
+			name:               "invalid lastSeenBefore",
+			args:               []string{"-lastSeenBefore", "yesterday"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: invalid lastSeenBefore timestamp: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"\n",
+		},
+		{
+			name:               "no filter",
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: at least one of -agentVersion, -attestationType, -banned, -canReattest, -expiresBefore, -lastSeenBefore or -selector is required\n",
+		},
+		{
+			name:               "invalid expiresBefore",
+			args:               []string{"-expiresBefore", "tomorrow"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: invalid expiresBefore timestamp: parsing time \"tomorrow\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"tomorrow\" as \"2006\"\n",
+		},
+		{
+			name:               "invalid selector",
+			args:               []string{"-selector", "foo"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: error parsing selector \"foo\": selector \"foo\" must be formatted as type:value\n",
+		},
+		{
+			name:               "server error",
+			args:               []string{"-attestationType", "join_token"},
+			serverErr:          status.Error(codes.Internal, "internal server error"),
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: rpc error: code = Internal desc = internal server error\n",
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByAttestationType: "join_token",
+			},
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, agent.NewBatchEvictCommandWithEnv)
+				test.admin.pages = tt.pages
+				test.admin.err = tt.serverErr
+				args := tt.args
+				args = append(args, "-output", format)
+
+				returnCode := test.client.Run(append(test.args, args...))
+
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectedStdoutPretty, tt.expectedStdoutJSON)
+				require.Equal(t, tt.expectedStderr, test.stderr.String())
+				require.Equal(t, tt.expectedReturnCode, returnCode)
+				if tt.expectedFilter == nil {
+					require.Empty(t, test.admin.gotEvictRequests)
+					return
+				}
+				for i, req := range test.admin.gotEvictRequests {
+					spiretest.AssertProtoEqual(t, tt.expectedFilter, req.Filter)
+					require.Equal(t, tt.expectedDryRun, req.DryRun)
+					require.Equal(t, int32(500), req.PageSize)
+					if i > 0 {
+						require.Equal(t, fmt.Sprint(i), req.PageToken)
+					}
+				}
+				if tt.serverErr == nil {
+					require.Len(t, test.admin.gotEvictRequests, len(tt.pages)+1)
+				}
+			})
+		}
+	}
+}
+
+func TestBatchBanHelp(t *testing.T) {
+	test := setupTest(t, agent.NewBatchBanCommandWithEnv)
+
+	test.client.Help()
+	require.Equal(t, batchBanUsage, test.stderr.String())
+}
+
+func TestBatchBan(t *testing.T) {
+	for _, tt := range []struct {
+		name                 string
+		args                 []string
+		pages                [][]*types.Agent
+		expectedReturnCode   int
+		expectedStdoutPretty string
+		expectedStdoutJSON   string
+		expectedStderr       string
+		expectedFilter       *agentadminv1.AgentFilter
+		expectedDryRun       bool
+		serverErr            error
+	}{
+		{
+			name:                 "success",
+			args:                 []string{"-attestationType", "join_token"},
+			pages:                [][]*types.Agent{testAgentsWithBanned},
+			expectedStdoutPretty: "1 agent banned:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/banned",
+			expectedStdoutJSON:   `{"agents":[{"id":{"trust_domain":"example.org","path":"/spire/agent/banned"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":true}],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByAttestationType: "join_token",
+			},
+		},
+		{
+			name:                 "dry run",
+			args:                 []string{"-canReattest", "-dryRun"},
+			pages:                [][]*types.Agent{testAgents},
+			expectedStdoutPretty: "1 agent would be banned:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedStdoutJSON:   `{"agents":[{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false}],"next_page_token":""}`,
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByCanReattest: wrapperspb.Bool(true),
+			},
+			expectedDryRun: true,
+		},
+		{
+			name:               "no filter",
+			args:               []string{"-dryRun"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: at least one of -agentVersion, -attestationType, -banned, -canReattest, -expiresBefore, -lastSeenBefore or -selector is required\n",
+		},
+		{
+			name:               "unsupported match behavior",
+			args:               []string{"-selector", "foo:bar", "-matchSelectorsOn", "none"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: unsupported match behavior\n",
+		},
+		{
+			name:               "server error",
+			args:               []string{"-attestationType", "join_token"},
+			serverErr:          status.Error(codes.Internal, "internal server error"),
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: rpc error: code = Internal desc = internal server error\n",
+			expectedFilter: &agentadminv1.AgentFilter{
+				ByAttestationType: "join_token",
+			},
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, agent.NewBatchBanCommandWithEnv)
+				test.admin.pages = tt.pages
+				test.admin.err = tt.serverErr
+				args := tt.args
+				args = append(args, "-output", format)
+
+				returnCode := test.client.Run(append(test.args, args...))
+
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectedStdoutPretty, tt.expectedStdoutJSON)
+				require.Equal(t, tt.expectedStderr, test.stderr.String())
+				require.Equal(t, tt.expectedReturnCode, returnCode)
+				if tt.expectedFilter == nil {
+					require.Empty(t, test.admin.gotBanRequests)
+					return
+				}
+				for _, req := range test.admin.gotBanRequests {
+					spiretest.AssertProtoEqual(t, tt.expectedFilter, req.Filter)
+					require.Equal(t, tt.expectedDryRun, req.DryRun)
+				}
+			})
+		}
+	}
+}
+
 func TestCountHelp(t *testing.T) {
 	test := setupTest(t, agent.NewCountCommandWithEnv)
 
@@ -257,7 +701,9 @@ func TestList(t *testing.T) {
 		expectedStdoutJSON   string
 		expectedStderr       string
 		expectReq            *agentv1.ListAgentsRequest
+		expectStatusesReq    *agentadminv1.ListAgentStatusesRequest
 		existentAgents       []*types.Agent
+		existentStatuses     []*agentadminv1.AgentStatus
 		expectedFormat       string
 		serverErr            error
 	}{
@@ -392,6 +838,52 @@ func TestList(t *testing.T) {
 			expectedReturnCode: 1,
 			expectedStderr:     "Error: unsupported match behavior\n",
 		},
+		{
+			name:             "by agent status",
+			args:             []string{"-agentVersion", "1.5.0", "-lastSeenBefore", "2022-10-20T00:00:00Z", "-selector", "foo:bar"},
+			existentStatuses: testAgentStatuses,
+			expectStatusesReq: &agentadminv1.ListAgentStatusesRequest{
+				Filter: &agentadminv1.AgentFilter{
+					ByAgentVersion:   "1.5.0",
+					ByLastSeenBefore: 1666224000,
+					BySelectorMatch: &types.SelectorMatch{
+						Selectors: []*types.Selector{{Type: "foo", Value: "bar"}},
+						Match:     types.SelectorMatch_MATCH_SUPERSET,
+					},
+				},
+				PageSize: 1000,
+			},
+			expectedStdoutPretty: "Found 1 attested agent:\n\nSPIFFE ID         : spiffe://example.org/spire/agent/agent1",
+			expectedStdoutJSON:   `{"statuses":[{"agent":{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false},"agent_version":"1.5.0","last_seen_at":"0","health":"NEVER_SEEN"}],"next_page_token":""}`,
+		},
+		{
+			name:                 "by agent status: status output",
+			args:                 []string{"-agentVersion", "1.5.0"},
+			existentStatuses:     testAgentStatuses,
+			expectedStdoutPretty: "Agent version     : 1.5.0\nLast seen         : never\nHealth            : NEVER_SEEN\n\n",
+			expectedStdoutJSON:   `{"statuses":[{"agent":{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false},"agent_version":"1.5.0","last_seen_at":"0","health":"NEVER_SEEN"}],"next_page_token":""}`,
+			expectStatusesReq: &agentadminv1.ListAgentStatusesRequest{
+				Filter:   &agentadminv1.AgentFilter{ByAgentVersion: "1.5.0"},
+				PageSize: 1000,
+			},
+		},
+		{
+			name:               "by agent status: invalid lastSeenBefore",
+			args:               []string{"-lastSeenBefore", "yesterday"},
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: invalid lastSeenBefore timestamp: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"\n",
+		},
+		{
+			name:               "by agent status: server error",
+			args:               []string{"-agentVersion", "1.5.0"},
+			serverErr:          status.Error(codes.Internal, "internal server error"),
+			expectedReturnCode: 1,
+			expectedStderr:     "Error: rpc error: code = Internal desc = internal server error\n",
+			expectStatusesReq: &agentadminv1.ListAgentStatusesRequest{
+				Filter:   &agentadminv1.AgentFilter{ByAgentVersion: "1.5.0"},
+				PageSize: 1000,
+			},
+		},
 		{
 			name:               "List by selector using invalid selector",
 			args:               []string{"-selector", "invalid-selector"},
@@ -410,6 +902,8 @@ func TestList(t *testing.T) {
 				test := setupTest(t, agent.NewListCommandWithEnv)
 				test.server.agents = tt.existentAgents
 				test.server.err = tt.serverErr
+				test.admin.statuses = tt.existentStatuses
+				test.admin.err = tt.serverErr
 				args := tt.args
 				args = append(args, "-output", format)
 
@@ -417,6 +911,7 @@ func TestList(t *testing.T) {
 
 				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectedStdoutPretty, tt.expectedStdoutJSON)
 				spiretest.RequireProtoEqual(t, tt.expectReq, test.server.gotListAgentRequest)
+				spiretest.RequireProtoEqual(t, tt.expectStatusesReq, test.admin.gotListStatusesRequest)
 				require.Equal(t, tt.expectedStderr, test.stderr.String())
 				require.Equal(t, tt.expectedReturnCode, returnCode)
 			})
@@ -424,6 +919,72 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListWithOutputFormats(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		output         string
+		serverErr      error
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:   "yaml",
+			output: "yaml",
+			expectedStdout: `agents:
+  - attestation_type: ""
+    banned: false
+    id:
+      path: /spire/agent/agent2
+      trust_domain: example.org
+    selectors:
+      - type: k8s_psat
+        value: agent_ns:spire
+      - type: k8s_psat
+        value: agent_sa:spire-agent
+      - type: k8s_psat
+        value: cluster:demo-cluster
+    x509svid_expires_at: "0"
+    x509svid_serial_number: ""
+next_page_token: ""
+`,
+		},
+		{
+			name:   "table",
+			output: "table=id,selectors,banned",
+			expectedStdout: "" +
+				"ID                                        SELECTORS                                                                             BANNED\n" +
+				"spiffe://example.org/spire/agent/agent2   k8s_psat:agent_ns:spire,k8s_psat:agent_sa:spire-agent,k8s_psat:cluster:demo-cluster   false\n",
+		},
+		{
+			name:           "template",
+			output:         `template={{range .agents}}{{.id.path}} {{len .selectors}}{{"\n"}}{{end}}`,
+			expectedStdout: "/spire/agent/agent2 3\n",
+		},
+		{
+			name:           "server error",
+			output:         "yaml",
+			serverErr:      status.Error(codes.Internal, "internal server error"),
+			expectedStderr: "Error: rpc error: code = Internal desc = internal server error\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupTest(t, agent.NewListCommandWithEnv)
+			test.server.agents = testAgentsWithSelectors
+			test.server.err = tt.serverErr
+
+			returnCode := test.client.Run(append(test.args, "-output", tt.output))
+
+			require.Equal(t, tt.expectedStdout, test.stdout.String())
+			require.Equal(t, tt.expectedStderr, test.stderr.String())
+			if tt.expectedStderr != "" {
+				require.Equal(t, 1, returnCode)
+			} else {
+				require.Equal(t, 0, returnCode)
+			}
+		})
+	}
+}
+
 func TestShowHelp(t *testing.T) {
 	test := setupTest(t, agent.NewShowCommandWithEnv)
 
@@ -440,6 +1001,7 @@ func TestShow(t *testing.T) {
 		expectedStdoutJSON   string
 		expectedStderr       string
 		existentAgents       []*types.Agent
+		existentStatuses     []*agentadminv1.AgentStatus
 		serverErr            error
 	}{
 		{
@@ -485,12 +1047,22 @@ func TestShow(t *testing.T) {
 			expectedStdoutPretty: "Banned            : true",
 			expectedStdoutJSON:   `{"id":{"trust_domain":"example.org","path":"/spire/agent/banned"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":true}`,
 		},
+		{
+			name:                 "show status",
+			args:                 []string{"-spiffeID", "spiffe://example.org/spire/agent/agent1"},
+			existentAgents:       testAgents,
+			existentStatuses:     testAgentStatuses,
+			expectedReturnCode:   0,
+			expectedStdoutPretty: "Serial number     : \nAgent version     : 1.5.0\nLast seen         : never\nHealth            : NEVER_SEEN\n\n",
+			expectedStdoutJSON:   `{"id":{"trust_domain":"example.org","path":"/spire/agent/agent1"},"attestation_type":"","x509svid_serial_number":"","x509svid_expires_at":"0","selectors":[],"banned":false}`,
+		},
 	} {
 		for _, format := range availableFormats {
 			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
 				test := setupTest(t, agent.NewShowCommandWithEnv)
 				test.server.err = tt.serverErr
 				test.server.agents = tt.existentAgents
+				test.admin.statuses = tt.existentStatuses
 				args := tt.args
 				args = append(args, "-output", format)
 
@@ -506,9 +1078,11 @@ func TestShow(t *testing.T) {
 
 func setupTest(t *testing.T, newClient func(*commoncli.Env) cli.Command) *agentTest {
 	server := &fakeAgentServer{}
+	admin := &fakeAgentAdminServer{}
 
 	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
 		agentv1.RegisterAgentServer(s, server)
+		agentadminv1.RegisterAgentAdminServer(s, admin)
 	})
 
 	stdin := new(bytes.Buffer)
@@ -527,6 +1101,7 @@ func setupTest(t *testing.T, newClient func(*commoncli.Env) cli.Command) *agentT
 		stderr: stderr,
 		args:   []string{common.AddrArg, common.GetAddr(addr)},
 		server: server,
+		admin:  admin,
 		client: client,
 	}
 
@@ -574,6 +1149,112 @@ func (s *fakeAgentServer) GetAgent(ctx context.Context, req *agentv1.GetAgentReq
 	return nil, s.err
 }
 
+type fakeAgentAdminServer struct {
+	agentadminv1.UnimplementedAgentAdminServer
+
+	// pages are the agents returned by each page of a batch operation
+	pages            [][]*types.Agent
+	gotEvictRequests []*agentadminv1.BatchEvictAgentsRequest
+	gotBanRequests   []*agentadminv1.BatchBanAgentsRequest
+	// statuses are the agent statuses returned by the status RPCs, which
+	// are unimplemented when empty
+	statuses               []*agentadminv1.AgentStatus
+	gotListStatusesRequest *agentadminv1.ListAgentStatusesRequest
+	gotReattestRequest     *agentadminv1.ReattestAgentRequest
+	// gotSelectorsRequest is the last request received by the selectors RPCs
+	gotSelectorsRequest proto.Message
+	selectors           *agentadminv1.AgentSelectors
+	err                 error
+}
+
+func (s *fakeAgentAdminServer) BatchEvictAgents(ctx context.Context, req *agentadminv1.BatchEvictAgentsRequest) (*agentadminv1.BatchEvictAgentsResponse, error) {
+	s.gotEvictRequests = append(s.gotEvictRequests, req)
+	agents, nextPageToken, err := s.page(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	return &agentadminv1.BatchEvictAgentsResponse{
+		Agents:        agents,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *fakeAgentAdminServer) BatchBanAgents(ctx context.Context, req *agentadminv1.BatchBanAgentsRequest) (*agentadminv1.BatchBanAgentsResponse, error) {
+	s.gotBanRequests = append(s.gotBanRequests, req)
+	agents, nextPageToken, err := s.page(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	return &agentadminv1.BatchBanAgentsResponse{
+		Agents:        agents,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *fakeAgentAdminServer) GetAgentStatus(ctx context.Context, req *agentadminv1.GetAgentStatusRequest) (*agentadminv1.AgentStatus, error) {
+	if len(s.statuses) == 0 {
+		return s.UnimplementedAgentAdminServer.GetAgentStatus(ctx, req)
+	}
+	return s.statuses[0], s.err
+}
+
+func (s *fakeAgentAdminServer) ListAgentStatuses(ctx context.Context, req *agentadminv1.ListAgentStatusesRequest) (*agentadminv1.ListAgentStatusesResponse, error) {
+	s.gotListStatusesRequest = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &agentadminv1.ListAgentStatusesResponse{
+		Statuses: s.statuses,
+	}, nil
+}
+
+func (s *fakeAgentAdminServer) ReattestAgent(ctx context.Context, req *agentadminv1.ReattestAgentRequest) (*emptypb.Empty, error) {
+	s.gotReattestRequest = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *fakeAgentAdminServer) GetAgentSelectors(ctx context.Context, req *agentadminv1.GetAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	s.gotSelectorsRequest = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.selectors, nil
+}
+
+func (s *fakeAgentAdminServer) AddAgentSelectors(ctx context.Context, req *agentadminv1.AddAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	s.gotSelectorsRequest = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.selectors, nil
+}
+
+func (s *fakeAgentAdminServer) RemoveAgentSelectors(ctx context.Context, req *agentadminv1.RemoveAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	s.gotSelectorsRequest = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.selectors, nil
+}
+
+func (s *fakeAgentAdminServer) page(pageToken string) ([]*types.Agent, string, error) {
+	if s.err != nil {
+		return nil, "", s.err
+	}
+
+	page := 0
+	if pageToken != "" {
+		fmt.Sscanf(pageToken, "%d", &page)
+	}
+	if page >= len(s.pages) {
+		return nil, "", nil
+	}
+	return s.pages[page], fmt.Sprint(page + 1), nil
+}
+
 func requireOutputBasedOnFormat(t *testing.T, format, stdoutString string, expectedStdoutPretty, expectedStdoutJSON string) {
 	switch format {
 	case "pretty":
@@ -0,0 +1,262 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// batchPageSize is the number of agents evicted or banned per request.
+const batchPageSize = 500
+
+// agentFilterFlags are the flags used to select the agents of a batch
+// operation.
+type agentFilterFlags struct {
+	attestationType string
+	agentVersion    string
+	// Type and value are delimited by a colon (:)
+	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
+	selectors commoncli.StringsFlag
+	// Match used when filtering agents by selectors
+	matchSelectorsOn string
+	// RFC 3339 timestamp
+	expiresBefore string
+	// RFC 3339 timestamp
+	lastSeenBefore string
+	banned         commoncli.BoolFlag
+	canReattest    commoncli.BoolFlag
+}
+
+func (f *agentFilterFlags) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&f.agentVersion, "agentVersion", "", "Selects agents that last reported running the given SPIRE version")
+	fs.StringVar(&f.attestationType, "attestationType", "", "Selects agents that attested with the given node attestor type")
+	fs.Var(&f.banned, "banned", "Selects agents that are banned, or with -banned=false, agents that are not")
+	fs.Var(&f.canReattest, "canReattest", "Selects agents that can reattest, or with -canReattest=false, agents that cannot")
+	fs.StringVar(&f.expiresBefore, "expiresBefore", "", "Selects agents whose X509-SVID expires before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z)")
+	fs.StringVar(&f.lastSeenBefore, "lastSeenBefore", "", "Selects agents last seen before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z), including agents never seen")
+	fs.StringVar(&f.matchSelectorsOn, "matchSelectorsOn", "superset", "The match mode used when selecting agents by selectors. Options: exact, any, superset and subset")
+	fs.Var(&f.selectors, "selector", "A colon-delimited type:value selector the agents are selected by. Can be used more than once")
+}
+
+func (f *agentFilterFlags) filter() (*agentadminv1.AgentFilter, error) {
+	filter := &agentadminv1.AgentFilter{
+		ByAttestationType: f.attestationType,
+		ByAgentVersion:    f.agentVersion,
+	}
+
+	if banned, ok := f.banned.Get(); ok {
+		filter.ByBanned = wrapperspb.Bool(banned)
+	}
+	if canReattest, ok := f.canReattest.Get(); ok {
+		filter.ByCanReattest = wrapperspb.Bool(canReattest)
+	}
+
+	if f.expiresBefore != "" {
+		expiresBefore, err := time.Parse(time.RFC3339, f.expiresBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresBefore timestamp: %w", err)
+		}
+		filter.ByExpiresBefore = expiresBefore.Unix()
+	}
+
+	if f.lastSeenBefore != "" {
+		lastSeenBefore, err := time.Parse(time.RFC3339, f.lastSeenBefore)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lastSeenBefore timestamp: %w", err)
+		}
+		filter.ByLastSeenBefore = lastSeenBefore.Unix()
+	}
+
+	if len(f.selectors) > 0 {
+		matchBehavior, err := parseToSelectorMatch(f.matchSelectorsOn)
+		if err != nil {
+			return nil, err
+		}
+
+		selectors := make([]*types.Selector, len(f.selectors))
+		for i, sel := range f.selectors {
+			selector, err := util.ParseSelector(sel)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing selector %q: %w", sel, err)
+			}
+			selectors[i] = selector
+		}
+		filter.BySelectorMatch = &types.SelectorMatch{
+			Selectors: selectors,
+			Match:     matchBehavior,
+		}
+	}
+
+	if filter.ByAttestationType == "" && filter.ByAgentVersion == "" && filter.ByBanned == nil && filter.ByCanReattest == nil &&
+		filter.ByExpiresBefore == 0 && filter.ByLastSeenBefore == 0 && filter.BySelectorMatch == nil {
+		return nil, errors.New("at least one of -agentVersion, -attestationType, -banned, -canReattest, -expiresBefore, -lastSeenBefore or -selector is required")
+	}
+
+	return filter, nil
+}
+
+type batchEvictCommand struct {
+	env *commoncli.Env
+	agentFilterFlags
+	dryRun  bool
+	printer cliprinter.Printer
+}
+
+// NewBatchEvictCommand creates a new "batch-evict" subcommand for "agent"
+// command.
+func NewBatchEvictCommand() cli.Command {
+	return NewBatchEvictCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewBatchEvictCommandWithEnv creates a new "batch-evict" subcommand for
+// "agent" command using the environment specified
+func NewBatchEvictCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &batchEvictCommand{env: env})
+}
+
+func (*batchEvictCommand) Name() string {
+	return "agent batch-evict"
+}
+
+func (*batchEvictCommand) Synopsis() string {
+	return "Evicts the attested agents matching a filter"
+}
+
+// Run evicts the agents matching the filter
+func (c *batchEvictCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient util.ServerClient) error {
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+
+	agentAdminClient := serverClient.NewAgentAdminClient()
+
+	pageToken := ""
+	response := new(agentadminv1.BatchEvictAgentsResponse)
+	for {
+		resp, err := agentAdminClient.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+			Filter:    filter,
+			DryRun:    c.dryRun,
+			PageSize:  batchPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		response.Agents = append(response.Agents, resp.Agents...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	return c.printer.PrintProto(response)
+}
+
+func (c *batchEvictCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+	fs.BoolVar(&c.dryRun, "dryRun", false, "Lists the agents that would be evicted without evicting them")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, c.prettyPrintBatchEvictResult)
+}
+
+func (c *batchEvictCommand) prettyPrintBatchEvictResult(env *commoncli.Env, results ...interface{}) error {
+	resp, ok := results[0].(*agentadminv1.BatchEvictAgentsResponse)
+	if !ok {
+		return errors.New("internal error: cli printer; please report this bug")
+	}
+	return printBatchResult(env, resp.Agents, c.dryRun, "evicted")
+}
+
+type batchBanCommand struct {
+	env *commoncli.Env
+	agentFilterFlags
+	dryRun  bool
+	printer cliprinter.Printer
+}
+
+// NewBatchBanCommand creates a new "batch-ban" subcommand for "agent"
+// command.
+func NewBatchBanCommand() cli.Command {
+	return NewBatchBanCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewBatchBanCommandWithEnv creates a new "batch-ban" subcommand for "agent"
+// command using the environment specified
+func NewBatchBanCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &batchBanCommand{env: env})
+}
+
+func (*batchBanCommand) Name() string {
+	return "agent batch-ban"
+}
+
+func (*batchBanCommand) Synopsis() string {
+	return "Bans the attested agents matching a filter"
+}
+
+// Run bans the agents matching the filter
+func (c *batchBanCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient util.ServerClient) error {
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+
+	agentAdminClient := serverClient.NewAgentAdminClient()
+
+	pageToken := ""
+	response := new(agentadminv1.BatchBanAgentsResponse)
+	for {
+		resp, err := agentAdminClient.BatchBanAgents(ctx, &agentadminv1.BatchBanAgentsRequest{
+			Filter:    filter,
+			DryRun:    c.dryRun,
+			PageSize:  batchPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		response.Agents = append(response.Agents, resp.Agents...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	return c.printer.PrintProto(response)
+}
+
+func (c *batchBanCommand) AppendFlags(fs *flag.FlagSet) {
+	c.appendFlags(fs)
+	fs.BoolVar(&c.dryRun, "dryRun", false, "Lists the agents that would be banned without banning them")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, c.prettyPrintBatchBanResult)
+}
+
+func (c *batchBanCommand) prettyPrintBatchBanResult(env *commoncli.Env, results ...interface{}) error {
+	resp, ok := results[0].(*agentadminv1.BatchBanAgentsResponse)
+	if !ok {
+		return errors.New("internal error: cli printer; please report this bug")
+	}
+	return printBatchResult(env, resp.Agents, c.dryRun, "banned")
+}
+
+func printBatchResult(env *commoncli.Env, agents []*types.Agent, dryRun bool, verb string) error {
+	if dryRun {
+		verb = "would be " + verb
+	}
+
+	if len(agents) == 0 {
+		return env.Printf("No agents %s\n", verb)
+	}
+
+	msg := util.Pluralizer(fmt.Sprintf("%d ", len(agents)), "agent", "agents", len(agents))
+	env.Printf("%s %s:\n\n", msg, verb)
+	return printAgents(env, agents...)
+}
@@ -13,6 +13,7 @@ import (
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
 	"golang.org/x/net/context"
 )
 
@@ -23,7 +24,11 @@ type listCommand struct {
 	selectors commoncli.StringsFlag
 	// Match used when filtering agents by selectors
 	matchSelectorsOn string
-	printer          cliprinter.Printer
+	// SPIRE version last reported by the agents
+	agentVersion string
+	// RFC 3339 timestamp
+	lastSeenBefore string
+	printer        cliprinter.Printer
 }
 
 // NewListCommand creates a new "list" subcommand for "agent" command.
@@ -68,6 +73,11 @@ func (c *listCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient ut
 		}
 	}
 
+	// The agent status is only known to the agent admin API
+	if c.agentVersion != "" || c.lastSeenBefore != "" {
+		return c.listAgentStatuses(ctx, serverClient, filter.BySelectorMatch)
+	}
+
 	agentClient := serverClient.NewAgentClient()
 
 	pageToken := ""
@@ -90,55 +100,90 @@ func (c *listCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient ut
 	return c.printer.PrintProto(response)
 }
 
+func (c *listCommand) listAgentStatuses(ctx context.Context, serverClient util.ServerClient, selectorMatch *types.SelectorMatch) error {
+	filter := &agentadminv1.AgentFilter{
+		ByAgentVersion:  c.agentVersion,
+		BySelectorMatch: selectorMatch,
+	}
+	if c.lastSeenBefore != "" {
+		lastSeenBefore, err := time.Parse(time.RFC3339, c.lastSeenBefore)
+		if err != nil {
+			return fmt.Errorf("invalid lastSeenBefore timestamp: %w", err)
+		}
+		filter.ByLastSeenBefore = lastSeenBefore.Unix()
+	}
+
+	agentAdminClient := serverClient.NewAgentAdminClient()
+
+	pageToken := ""
+	response := new(agentadminv1.ListAgentStatusesResponse)
+	for {
+		listResponse, err := agentAdminClient.ListAgentStatuses(ctx, &agentadminv1.ListAgentStatusesRequest{
+			PageSize:  1000,
+			PageToken: pageToken,
+			Filter:    filter,
+		})
+		if err != nil {
+			return err
+		}
+		response.Statuses = append(response.Statuses, listResponse.Statuses...)
+		if pageToken = listResponse.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+
+	return c.printer.PrintProto(response)
+}
+
 func (c *listCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.agentVersion, "agentVersion", "", "Filters agents to those that last reported running the given SPIRE version")
+	fs.StringVar(&c.lastSeenBefore, "lastSeenBefore", "", "Filters agents to those last seen before the given RFC 3339 timestamp (e.g. 2022-10-20T00:00:00Z), including agents never seen")
 	fs.StringVar(&c.matchSelectorsOn, "matchSelectorsOn", "superset", "The match mode used when filtering by selectors. Options: exact, any, superset and subset")
 	fs.Var(&c.selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
 	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintAgents)
 }
 
 func prettyPrintAgents(env *commoncli.Env, results ...interface{}) error {
-	listResp, ok := results[0].(*agentv1.ListAgentsResponse)
-	if !ok {
+	switch listResp := results[0].(type) {
+	case *agentv1.ListAgentsResponse:
+		if len(listResp.Agents) == 0 {
+			return env.Printf("No attested agents found\n")
+		}
+		printFoundAgents(env, len(listResp.Agents))
+		return printAgents(env, listResp.Agents...)
+	case *agentadminv1.ListAgentStatusesResponse:
+		if len(listResp.Statuses) == 0 {
+			return env.Printf("No attested agents found\n")
+		}
+		printFoundAgents(env, len(listResp.Statuses))
+		for _, agentStatus := range listResp.Statuses {
+			if err := printAgent(env, agentStatus.Agent); err != nil {
+				return err
+			}
+			if err := printAgentStatus(env, agentStatus); err != nil {
+				return err
+			}
+			if err := env.Println(); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
 		return errors.New("internal error: cli printer; please report this bug")
 	}
-	agents := listResp.Agents
-
-	if len(agents) == 0 {
-		return env.Printf("No attested agents found\n")
-	}
+}
 
-	msg := fmt.Sprintf("Found %d attested ", len(agents))
-	msg = util.Pluralizer(msg, "agent", "agents", len(agents))
+func printFoundAgents(env *commoncli.Env, count int) {
+	msg := fmt.Sprintf("Found %d attested ", count)
+	msg = util.Pluralizer(msg, "agent", "agents", count)
 	env.Printf("%s:\n\n", msg)
-	return printAgents(env, agents...)
 }
 
 func printAgents(env *commoncli.Env, agents ...*types.Agent) error {
 	for _, agent := range agents {
-		id, err := idutil.IDFromProto(agent.Id)
-		if err != nil {
-			return err
-		}
-
-		if err := env.Printf("SPIFFE ID         : %s\n", id.String()); err != nil {
+		if err := printAgent(env, agent); err != nil {
 			return err
 		}
-		if err := env.Printf("Attestation type  : %s\n", agent.AttestationType); err != nil {
-			return err
-		}
-		if err := env.Printf("Expiration time   : %s\n", time.Unix(agent.X509SvidExpiresAt, 0)); err != nil {
-			return err
-		}
-		// Banned agents will have an empty serial number
-		if agent.Banned {
-			if err := env.Printf("Banned            : %t\n", agent.Banned); err != nil {
-				return err
-			}
-		} else {
-			if err := env.Printf("Serial number     : %s\n", agent.X509SvidSerialNumber); err != nil {
-				return err
-			}
-		}
 		if err := env.Println(); err != nil {
 			return err
 		}
@@ -147,6 +192,47 @@ func printAgents(env *commoncli.Env, agents ...*types.Agent) error {
 	return nil
 }
 
+func printAgent(env *commoncli.Env, agent *types.Agent) error {
+	id, err := idutil.IDFromProto(agent.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := env.Printf("SPIFFE ID         : %s\n", id.String()); err != nil {
+		return err
+	}
+	if err := env.Printf("Attestation type  : %s\n", agent.AttestationType); err != nil {
+		return err
+	}
+	if err := env.Printf("Expiration time   : %s\n", time.Unix(agent.X509SvidExpiresAt, 0)); err != nil {
+		return err
+	}
+	// Banned agents will have an empty serial number
+	if agent.Banned {
+		return env.Printf("Banned            : %t\n", agent.Banned)
+	}
+	return env.Printf("Serial number     : %s\n", agent.X509SvidSerialNumber)
+}
+
+func printAgentStatus(env *commoncli.Env, agentStatus *agentadminv1.AgentStatus) error {
+	agentVersion := agentStatus.AgentVersion
+	if agentVersion == "" {
+		agentVersion = "unknown"
+	}
+	lastSeen := "never"
+	if agentStatus.LastSeenAt != 0 {
+		lastSeen = time.Unix(agentStatus.LastSeenAt, 0).String()
+	}
+
+	if err := env.Printf("Agent version     : %s\n", agentVersion); err != nil {
+		return err
+	}
+	if err := env.Printf("Last seen         : %s\n", lastSeen); err != nil {
+		return err
+	}
+	return env.Printf("Health            : %s\n", agentStatus.Health)
+}
+
 func parseToSelectorMatch(match string) (types.SelectorMatch_MatchBehavior, error) {
 	switch match {
 	case "exact":
@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server/api"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+type reattestCommand struct {
+	env *commoncli.Env
+	// SPIFFE ID of the agent that must reattest
+	spiffeID string
+	printer  cliprinter.Printer
+}
+
+// NewReattestCommand creates a new "reattest" subcommand for "agent" command.
+func NewReattestCommand() cli.Command {
+	return NewReattestCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewReattestCommandWithEnv creates a new "reattest" subcommand for "agent"
+// command using the environment specified
+func NewReattestCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &reattestCommand{env: env})
+}
+
+func (*reattestCommand) Name() string {
+	return "agent reattest"
+}
+
+func (*reattestCommand) Synopsis() string {
+	return "Force an attested agent to reattest given its SPIFFE ID"
+}
+
+// Run requests an agent to reattest given its SPIFFE ID
+func (c *reattestCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient util.ServerClient) error {
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	id, err := spiffeid.FromString(c.spiffeID)
+	if err != nil {
+		return err
+	}
+
+	agentAdminClient := serverClient.NewAgentAdminClient()
+	reattestResponse, err := agentAdminClient.ReattestAgent(ctx, &agentadminv1.ReattestAgentRequest{
+		Id: api.ProtoFromID(id),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.PrintProto(reattestResponse)
+}
+
+func (c *reattestCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to reattest (agent identity)")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintReattestResult)
+}
+
+func prettyPrintReattestResult(env *commoncli.Env, _ ...interface{}) error {
+	env.Println("Agent reattestation requested successfully")
+	return nil
+}
@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server/api"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+type selectorsOperation int
+
+const (
+	selectorsList selectorsOperation = iota
+	selectorsAdd
+	selectorsRemove
+)
+
+type selectorsCommand struct {
+	env *commoncli.Env
+	op  selectorsOperation
+	// SPIFFE ID of the agent whose selectors are managed
+	spiffeID string
+	// Operator selectors to add or remove
+	selectors commoncli.StringsFlag
+	printer   cliprinter.Printer
+}
+
+// NewSelectorsListCommand creates a new "selectors list" subcommand for
+// "agent" command.
+func NewSelectorsListCommand() cli.Command {
+	return NewSelectorsListCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewSelectorsListCommandWithEnv creates a new "selectors list" subcommand
+// for "agent" command using the environment specified
+func NewSelectorsListCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &selectorsCommand{env: env, op: selectorsList})
+}
+
+// NewSelectorsAddCommand creates a new "selectors add" subcommand for
+// "agent" command.
+func NewSelectorsAddCommand() cli.Command {
+	return NewSelectorsAddCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewSelectorsAddCommandWithEnv creates a new "selectors add" subcommand
+// for "agent" command using the environment specified
+func NewSelectorsAddCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &selectorsCommand{env: env, op: selectorsAdd})
+}
+
+// NewSelectorsRemoveCommand creates a new "selectors remove" subcommand for
+// "agent" command.
+func NewSelectorsRemoveCommand() cli.Command {
+	return NewSelectorsRemoveCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewSelectorsRemoveCommandWithEnv creates a new "selectors remove"
+// subcommand for "agent" command using the environment specified
+func NewSelectorsRemoveCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &selectorsCommand{env: env, op: selectorsRemove})
+}
+
+func (c *selectorsCommand) Name() string {
+	switch c.op {
+	case selectorsAdd:
+		return "agent selectors add"
+	case selectorsRemove:
+		return "agent selectors remove"
+	default:
+		return "agent selectors list"
+	}
+}
+
+func (c *selectorsCommand) Synopsis() string {
+	switch c.op {
+	case selectorsAdd:
+		return "Adds operator-managed selectors to an agent given its SPIFFE ID"
+	case selectorsRemove:
+		return "Removes operator-managed selectors from an agent given its SPIFFE ID"
+	default:
+		return "Lists the node and operator-managed selectors of an agent given its SPIFFE ID"
+	}
+}
+
+// Run lists, adds or removes the operator selectors of an agent
+func (c *selectorsCommand) Run(ctx context.Context, _ *commoncli.Env, serverClient util.ServerClient) error {
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	id, err := spiffeid.FromString(c.spiffeID)
+	if err != nil {
+		return err
+	}
+
+	var selectors []*types.Selector
+	if c.op != selectorsList {
+		if len(c.selectors) == 0 {
+			return errors.New("at least one selector is required")
+		}
+		for _, sel := range c.selectors {
+			selector, err := util.ParseSelector(sel)
+			if err != nil {
+				return fmt.Errorf("error parsing selector %q: %w", sel, err)
+			}
+			selectors = append(selectors, selector)
+		}
+	}
+
+	agentAdminClient := serverClient.NewAgentAdminClient()
+	var resp *agentadminv1.AgentSelectors
+	switch c.op {
+	case selectorsAdd:
+		resp, err = agentAdminClient.AddAgentSelectors(ctx, &agentadminv1.AddAgentSelectorsRequest{
+			Id:        api.ProtoFromID(id),
+			Selectors: selectors,
+		})
+	case selectorsRemove:
+		resp, err = agentAdminClient.RemoveAgentSelectors(ctx, &agentadminv1.RemoveAgentSelectorsRequest{
+			Id:        api.ProtoFromID(id),
+			Selectors: selectors,
+		})
+	default:
+		resp, err = agentAdminClient.GetAgentSelectors(ctx, &agentadminv1.GetAgentSelectorsRequest{
+			Id: api.ProtoFromID(id),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func (c *selectorsCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent (agent identity)")
+	if c.op != selectorsList {
+		fs.Var(&c.selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
+	}
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintAgentSelectors)
+}
+
+func prettyPrintAgentSelectors(env *commoncli.Env, results ...interface{}) error {
+	resp, ok := results[0].(*agentadminv1.AgentSelectors)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	for _, s := range resp.NodeSelectors {
+		env.Printf("Node selector     : %s:%s\n", s.Type, s.Value)
+	}
+	for _, s := range resp.OperatorSelectors {
+		env.Printf("Operator selector : %s:%s\n", s.Type, s.Value)
+	}
+	if len(resp.NodeSelectors) == 0 && len(resp.OperatorSelectors) == 0 {
+		env.Println("No selectors found")
+	}
+	return nil
+}
@@ -11,8 +11,11 @@ import (
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/pkg/server/api"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type showCommand struct {
@@ -20,6 +23,8 @@ type showCommand struct {
 	// SPIFFE ID of the agent being showed
 	spiffeID string
 	printer  cliprinter.Printer
+	// Status of the agent being showed, nil if the server does not report it
+	agentStatus *agentadminv1.AgentStatus
 }
 
 // NewShowCommand creates a new "show" subcommand for "agent" command.
@@ -58,22 +63,37 @@ func (c *showCommand) Run(ctx context.Context, env *commoncli.Env, serverClient
 		return err
 	}
 
+	agentAdminClient := serverClient.NewAgentAdminClient()
+	c.agentStatus, err = agentAdminClient.GetAgentStatus(ctx, &agentadminv1.GetAgentStatusRequest{Id: api.ProtoFromID(id)})
+	// Servers predating the agent status do not implement the RPC
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return err
+	}
+
 	return c.printer.PrintProto(agent)
 }
 
 func (c *showCommand) AppendFlags(fs *flag.FlagSet) {
 	fs.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show (agent identity)")
-	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintAgent)
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, c.prettyPrintAgent)
 }
 
-func prettyPrintAgent(env *commoncli.Env, results ...interface{}) error {
+func (c *showCommand) prettyPrintAgent(env *commoncli.Env, results ...interface{}) error {
 	agent, ok := results[0].(*types.Agent)
 	if !ok {
 		return errors.New("internal error: cli printer; please report this bug")
 	}
 
 	env.Printf("Found an attested agent given its SPIFFE ID\n\n")
-	if err := printAgents(env, agent); err != nil {
+	if err := printAgent(env, agent); err != nil {
+		return err
+	}
+	if c.agentStatus != nil {
+		if err := printAgentStatus(env, c.agentStatus); err != nil {
+			return err
+		}
+	}
+	if err := env.Println(); err != nil {
 		return err
 	}
 
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mitchellh/cli"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api/audit"
+)
+
+func NewVerifyCommand() cli.Command {
+	return newVerifyCommand(common_cli.DefaultEnv)
+}
+
+func newVerifyCommand(env *common_cli.Env) *verifyCommand {
+	return &verifyCommand{
+		env: env,
+	}
+}
+
+type verifyCommand struct {
+	env *common_cli.Env
+
+	// Path to the audit log file
+	path string
+}
+
+func (c *verifyCommand) Help() string {
+	// ignoring parsing errors since "-h" is always supported by the flags package
+	_ = c.parseFlags([]string{"-h"})
+	return ""
+}
+
+func (c *verifyCommand) Synopsis() string {
+	return "Verifies the hash chain of an audit log file"
+}
+
+func (c *verifyCommand) Run(args []string) int {
+	if err := c.parseFlags(args); err != nil {
+		return 1
+	}
+
+	result, err := c.verify()
+	if err != nil {
+		// Ignore error since a failure to write to stderr cannot very well be
+		// reported
+		_ = c.env.ErrPrintf("Audit log verification failed: %v\n", err)
+		return 1
+	}
+
+	start := "continues a chain from a rotated file"
+	if result.ChainStart {
+		start = "starts the chain"
+	}
+	if err := c.env.Printf("Audit log hash chain is valid.\nRecords:   %d\nFirst seq: %d (%s)\nLast seq:  %d\nLast hash: %s\n",
+		result.Records, result.FirstSeq, start, result.LastSeq, result.LastHash); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func (c *verifyCommand) parseFlags(args []string) error {
+	fs := flag.NewFlagSet("audit verify", flag.ContinueOnError)
+	fs.SetOutput(c.env.Stderr)
+	fs.StringVar(&c.path, "path", "", "Path to the audit log file")
+	return fs.Parse(args)
+}
+
+func (c *verifyCommand) verify() (*audit.VerifyResult, error) {
+	if c.path == "" {
+		return nil, errors.New("a path to the audit log file is required")
+	}
+
+	f, err := os.Open(c.env.JoinPath(c.path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log file: %w", err)
+	}
+	defer f.Close()
+
+	return audit.VerifyChain(f)
+}
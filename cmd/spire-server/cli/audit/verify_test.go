@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/server/api/audit"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHelp(t *testing.T) {
+	cmd, _, stderr := setupTest(t)
+
+	require.Equal(t, "", cmd.Help())
+	require.Equal(t, `Usage of audit verify:
+  -path string
+    	Path to the audit log file
+`, stderr.String())
+}
+
+func TestVerifySynopsis(t *testing.T) {
+	cmd, _, _ := setupTest(t)
+	require.Equal(t, "Verifies the hash chain of an audit log file", cmd.Synopsis())
+}
+
+func TestVerify(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	path := filepath.Join(dir, "audit.log")
+
+	log, _ := test.NewNullLogger()
+	recorder, err := audit.NewRecorder(audit.RecorderConfig{
+		Sinks: audit.SinksConfig{
+			File:      &audit.FileSinkConfig{Path: path},
+			HashChain: true,
+		},
+		Log: log,
+	})
+	require.NoError(t, err)
+	recorder.New(log).Audit()
+	recorder.New(log).Audit()
+	require.NoError(t, recorder.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(content), "\n")
+	tamperedPath := filepath.Join(dir, "tampered.log")
+	require.NoError(t, os.WriteFile(tamperedPath, []byte(lines[1]), 0600))
+
+	for _, tt := range []struct {
+		name         string
+		args         []string
+		expectCode   int
+		expectStdout string
+		expectStderr string
+	}{
+		{
+			name:         "missing path",
+			expectCode:   1,
+			expectStderr: "Audit log verification failed: a path to the audit log file is required\n",
+		},
+		{
+			name:         "file does not exist",
+			args:         []string{"-path", filepath.Join(dir, "missing.log")},
+			expectCode:   1,
+			expectStderr: "Audit log verification failed: unable to open audit log file: open " + filepath.Join(dir, "missing.log") + ":",
+		},
+		{
+			name:         "valid log",
+			args:         []string{"-path", path},
+			expectStdout: "Audit log hash chain is valid.\nRecords:   2\nFirst seq: 1 (starts the chain)\nLast seq:  2\nLast hash: ",
+		},
+		{
+			name:         "log with first records removed",
+			args:         []string{"-path", tamperedPath},
+			expectStdout: "Audit log hash chain is valid.\nRecords:   1\nFirst seq: 2 (continues a chain from a rotated file)\nLast seq:  2\nLast hash: ",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, stdout, stderr := setupTest(t)
+			code := cmd.Run(tt.args)
+			require.Equal(t, tt.expectCode, code)
+			require.True(t, strings.HasPrefix(stderr.String(), tt.expectStderr), "unexpected error output: %s", stderr.String())
+			require.True(t, strings.HasPrefix(stdout.String(), tt.expectStdout), "unexpected output: %s", stdout.String())
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*verifyCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newVerifyCommand(&common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	return cmd, stdout, stderr
+}
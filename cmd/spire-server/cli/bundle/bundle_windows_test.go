@@ -5,49 +5,99 @@ package bundle
 
 var (
 	setUsage = `Usage of bundle set:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
   -format string
     	The format of the bundle data. Either "pem" or "spiffe". (default "pem")
   -id string
     	SPIFFE ID of the trust domain
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
   -path string
     	Path to the bundle data
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	showUsage = `Usage of bundle show:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
   -format string
     	The format to show the bundle (only pretty output format supports this flag). Either "pem" or "spiffe". (default "pem")
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	countUsage = `Usage of bundle count:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	listUsage = `Usage of bundle list:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
   -format string
     	The format to list federated bundles (only pretty output format supports this flag). Either "pem" or "spiffe". (default "pem")
   -id string
     	SPIFFE ID of the trust domain
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 	deleteUsage = `Usage of bundle delete:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
   -id string
     	SPIFFE ID of the trust domain
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
   -mode string
     	Deletion mode: one of restrict, delete, or dissociate (default "restrict")
   -namedPipeName string
     	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
   -output value
-    	Desired output format (pretty, json); default: pretty.
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
 `
 )
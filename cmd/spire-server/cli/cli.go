@@ -6,8 +6,11 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
+	"github.com/spiffe/spire/cmd/spire-server/cli/audit"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/debug"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
+	"github.com/spiffe/spire/cmd/spire-server/cli/events"
 	"github.com/spiffe/spire/cmd/spire-server/cli/federation"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
@@ -33,6 +36,12 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"agent ban": func() (cli.Command, error) {
 			return agent.NewBanCommand(), nil
 		},
+		"agent batch-ban": func() (cli.Command, error) {
+			return agent.NewBatchBanCommand(), nil
+		},
+		"agent batch-evict": func() (cli.Command, error) {
+			return agent.NewBatchEvictCommand(), nil
+		},
 		"agent count": func() (cli.Command, error) {
 			return agent.NewCountCommand(), nil
 		},
@@ -42,9 +51,24 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"agent list": func() (cli.Command, error) {
 			return agent.NewListCommand(), nil
 		},
+		"agent reattest": func() (cli.Command, error) {
+			return agent.NewReattestCommand(), nil
+		},
+		"agent selectors add": func() (cli.Command, error) {
+			return agent.NewSelectorsAddCommand(), nil
+		},
+		"agent selectors list": func() (cli.Command, error) {
+			return agent.NewSelectorsListCommand(), nil
+		},
+		"agent selectors remove": func() (cli.Command, error) {
+			return agent.NewSelectorsRemoveCommand(), nil
+		},
 		"agent show": func() (cli.Command, error) {
 			return agent.NewShowCommand(), nil
 		},
+		"audit verify": func() (cli.Command, error) {
+			return audit.NewVerifyCommand(), nil
+		},
 		"bundle count": func() (cli.Command, error) {
 			return bundle.NewCountCommand(), nil
 		},
@@ -60,6 +84,9 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"debug dump": func() (cli.Command, error) {
+			return debug.NewDumpCommand(), nil
+		},
 		"entry count": func() (cli.Command, error) {
 			return entry.NewCountCommand(), nil
 		},
@@ -75,6 +102,21 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return entry.NewShowCommand(), nil
 		},
+		"entry check": func() (cli.Command, error) {
+			return entry.NewCheckCommand(), nil
+		},
+		"entry explain": func() (cli.Command, error) {
+			return entry.NewExplainCommand(), nil
+		},
+		"entry history": func() (cli.Command, error) {
+			return entry.NewHistoryCommand(), nil
+		},
+		"entry restore": func() (cli.Command, error) {
+			return entry.NewRestoreCommand(), nil
+		},
+		"events watch": func() (cli.Command, error) {
+			return events.NewWatchCommand(), nil
+		},
 		"federation create": func() (cli.Command, error) {
 			return federation.NewCreateCommand(), nil
 		},
@@ -90,6 +132,9 @@ func (cc *CLI) Run(ctx context.Context, args []string) int {
 		"federation refresh": func() (cli.Command, error) {
 			return federation.NewRefreshCommand(), nil
 		},
+		"federation status": func() (cli.Command, error) {
+			return federation.NewStatusCommand(), nil
+		},
 		"federation update": func() (cli.Command, error) {
 			return federation.NewUpdateCommand(), nil
 		},
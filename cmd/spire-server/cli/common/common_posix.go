@@ -6,14 +6,8 @@ package common
 import "net"
 
 var (
-	AddrArg         = "-socketPath"
-	AddrError       = "Error: connection error: desc = \"transport: error while dialing: dial unix /does-not-exist.sock: connect: no such file or directory\"\n"
-	AddrOutputUsage = `
-  -output value
-    	Desired output format (pretty, json); default: pretty.
-  -socketPath string
-    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
-`
+	AddrArg   = "-socketPath"
+	AddrError = "Error: connection error: desc = \"transport: error while dialing: dial unix /does-not-exist.sock: connect: no such file or directory\"\n"
 	AddrValue = "/does-not-exist.sock"
 )
 
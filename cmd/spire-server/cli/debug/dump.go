@@ -0,0 +1,120 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/diagnostics"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/version"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultConfigPath = "conf/server/server.conf"
+
+type dumpCommand struct {
+	// Path to the server configuration file
+	configPath string
+	// Path to write the archive to
+	outPath string
+}
+
+// NewDumpCommand creates a new "dump" subcommand for "debug" command.
+func NewDumpCommand() cli.Command {
+	return NewDumpCommandWithEnv(commoncli.DefaultEnv)
+}
+
+// NewDumpCommandWithEnv creates a new "dump" subcommand for "debug" command
+// using the environment specified
+func NewDumpCommandWithEnv(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &dumpCommand{})
+}
+
+func (*dumpCommand) Name() string {
+	return "debug dump"
+}
+
+func (*dumpCommand) Synopsis() string {
+	return "Collects server diagnostics into an archive to troubleshoot it"
+}
+
+func (c *dumpCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.configPath, "config", defaultConfigPath, "Path to the SPIRE Server config file. Plugin data and secrets are redacted from the archived copy")
+	fs.StringVar(&c.outPath, "out", "", "Path to write the gzip compressed tar archive to. Defaults to spire-server-debug-<timestamp>.tar.gz in the current directory")
+}
+
+// Run collects the server diagnostics into an archive. Failing to collect a
+// file does not fail the command; the failure is recorded in the archive.
+func (c *dumpCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	now := time.Now().UTC()
+	outPath := c.outPath
+	if outPath == "" {
+		outPath = fmt.Sprintf("spire-server-debug-%s.tar.gz", now.Format("20060102T150405Z"))
+	}
+	outPath = env.JoinPath(outPath)
+
+	archive := diagnostics.NewArchive(now)
+
+	diagnosticsResp, diagnosticsErr := serverClient.NewDiagnosticsClient().GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+
+	versions := fmt.Sprintf("CLI: %s\n", version.Version())
+	if diagnosticsErr == nil {
+		versions += fmt.Sprintf("Server: %s\n", diagnosticsResp.Version)
+	}
+	archive.AddFile("version.txt", []byte(versions))
+
+	archive.CollectProto("info.json", func() (proto.Message, error) {
+		return serverClient.NewDebugClient().GetInfo(ctx, &debugv1.GetInfoRequest{})
+	})
+	archive.CollectProto("health.json", func() (proto.Message, error) {
+		return serverClient.NewHealthClient().Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	})
+	archive.Collect("config.conf", func() ([]byte, error) {
+		return diagnostics.ReadRedactedConfig(env.JoinPath(c.configPath))
+	})
+	archive.Collect("goroutines.txt", func() ([]byte, error) {
+		if diagnosticsErr != nil {
+			return nil, diagnosticsErr
+		}
+		return diagnosticsResp.GoroutineProfile, nil
+	})
+	archive.Collect("metrics.json", func() ([]byte, error) {
+		switch {
+		case diagnosticsErr != nil:
+			return nil, diagnosticsErr
+		case len(diagnosticsResp.InmemMetrics) == 0:
+			return nil, errors.New("the InMem telemetry sink is not configured")
+		}
+		return diagnosticsResp.InmemMetrics, nil
+	})
+	archive.CollectProto("ca_journal.json", func() (proto.Message, error) {
+		if diagnosticsErr != nil {
+			return nil, diagnosticsErr
+		}
+		return diagnosticsResp.CaJournal, nil
+	})
+
+	data, err := archive.Close()
+	if err != nil {
+		return err
+	}
+	if err := diskutil.WritePrivateFile(outPath, data); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+
+	for _, collectErr := range archive.Errors() {
+		if err := env.ErrPrintf("Unable to collect %s\n", collectErr); err != nil {
+			return err
+		}
+	}
+	return env.Printf("Debug dump written to %s\n", outPath)
+}
@@ -0,0 +1,273 @@
+package debug_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/debug/v1"
+	"github.com/spiffe/spire/cmd/spire-server/cli/common"
+	"github.com/spiffe/spire/cmd/spire-server/cli/debug"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/version"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const testConfig = `server {
+    trust_domain = "example.org"
+}
+
+plugins {
+    DataStore "sql" {
+        plugin_data {
+            connection_string = "password"
+        }
+    }
+}
+`
+
+func TestDumpHelp(t *testing.T) {
+	test := setupTest(t)
+
+	test.client.Help()
+	require.Equal(t, dumpUsage, test.stderr.String())
+}
+
+func TestDumpSynopsis(t *testing.T) {
+	test := setupTest(t)
+	require.Equal(t, "Collects server diagnostics into an archive to troubleshoot it", test.client.Synopsis())
+}
+
+func TestDump(t *testing.T) {
+	test := setupTest(t)
+	test.diagnostics.resp = &diagnosticsv1.GetDiagnosticsResponse{
+		Version:          "1.2.3",
+		GoroutineProfile: []byte("goroutine 1 [running]:"),
+		InmemMetrics:     []byte(`{"Counters":[]}`),
+		CaJournal: &diagnosticsv1.CAJournal{
+			JwtKeys: []*diagnosticsv1.JWTKey{{SlotId: "A", Kid: "kid"}},
+		},
+	}
+	configPath := filepath.Join(test.dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	returnCode := test.client.Run(append(test.args, "-config", configPath, "-out", outPath))
+
+	require.Equal(t, 0, returnCode, test.stderr.String())
+	require.Empty(t, test.stderr.String())
+	require.Equal(t, fmt.Sprintf("Debug dump written to %s\n", outPath), test.stdout.String())
+
+	files := readArchive(t, outPath)
+	require.ElementsMatch(t, []string{
+		"version.txt",
+		"info.json",
+		"health.json",
+		"config.conf",
+		"goroutines.txt",
+		"metrics.json",
+		"ca_journal.json",
+	}, keys(files))
+	assert.Equal(t, fmt.Sprintf("CLI: %s\nServer: 1.2.3\n", version.Version()), files["version.txt"])
+	assert.JSONEq(t, `{"agentsCount":3,"uptime":10}`, files["info.json"])
+	assert.JSONEq(t, `{"status":"SERVING"}`, files["health.json"])
+	assert.Equal(t, `server {
+  trust_domain = "example.org"
+}
+
+plugins {
+  DataStore "sql" {
+    plugin_data = "[REDACTED]"
+  }
+}
+`, files["config.conf"])
+	assert.Equal(t, "goroutine 1 [running]:", files["goroutines.txt"])
+	assert.Equal(t, `{"Counters":[]}`, files["metrics.json"])
+	assert.JSONEq(t, `{"jwtKeys":[{"slotId":"A","kid":"kid"}]}`, files["ca_journal.json"])
+}
+
+func TestDumpWithCollectionErrors(t *testing.T) {
+	test := setupTest(t)
+	test.debug.err = status.Error(codes.Internal, "debug failed")
+	test.diagnostics.err = status.Error(codes.Unavailable, "diagnostics failed")
+	test.health.err = errors.New("health failed")
+	configPath := filepath.Join(test.dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte("server {"), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	returnCode := test.client.Run(append(test.args, "-config", configPath, "-out", outPath))
+
+	require.Equal(t, 0, returnCode, test.stderr.String())
+	require.Equal(t, fmt.Sprintf("Debug dump written to %s\n", outPath), test.stdout.String())
+
+	expectErrors := `info.json: rpc error: code = Internal desc = debug failed
+health.json: rpc error: code = Unknown desc = health failed
+config.conf: At 1:10: object expected closing RBRACE got: EOF
+goroutines.txt: rpc error: code = Unavailable desc = diagnostics failed
+metrics.json: rpc error: code = Unavailable desc = diagnostics failed
+ca_journal.json: rpc error: code = Unavailable desc = diagnostics failed
+`
+
+	files := readArchive(t, outPath)
+	require.ElementsMatch(t, []string{"version.txt", "errors.txt"}, keys(files))
+	assert.Equal(t, fmt.Sprintf("CLI: %s\n", version.Version()), files["version.txt"])
+	assert.Equal(t, expectErrors, files["errors.txt"])
+	assert.Equal(t, expectErrors, strings.ReplaceAll(test.stderr.String(), "Unable to collect ", ""))
+}
+
+func TestDumpWithoutInmemMetrics(t *testing.T) {
+	test := setupTest(t)
+	test.diagnostics.resp = &diagnosticsv1.GetDiagnosticsResponse{
+		Version:   "1.2.3",
+		CaJournal: &diagnosticsv1.CAJournal{},
+	}
+	configPath := filepath.Join(test.dir, "server.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0600))
+	outPath := filepath.Join(test.dir, "dump.tar.gz")
+
+	returnCode := test.client.Run(append(test.args, "-config", configPath, "-out", outPath))
+
+	require.Equal(t, 0, returnCode, test.stderr.String())
+	require.Equal(t, "Unable to collect metrics.json: the InMem telemetry sink is not configured\n", test.stderr.String())
+	files := readArchive(t, outPath)
+	assert.NotContains(t, files, "metrics.json")
+	assert.Equal(t, "metrics.json: the InMem telemetry sink is not configured\n", files["errors.txt"])
+}
+
+func TestDumpWrongUDSPath(t *testing.T) {
+	test := setupTest(t)
+
+	returnCode := test.client.Run([]string{common.AddrArg, common.AddrValue, "-out", filepath.Join(test.dir, "dump.tar.gz")})
+
+	require.Equal(t, 1, returnCode)
+	require.Equal(t, common.AddrError, test.stderr.String())
+	require.NoFileExists(t, filepath.Join(test.dir, "dump.tar.gz"))
+}
+
+type dumpTest struct {
+	stdout      *bytes.Buffer
+	stderr      *bytes.Buffer
+	args        []string
+	dir         string
+	debug       *fakeDebugServer
+	diagnostics *fakeDiagnosticsServer
+	health      *fakeHealthServer
+	client      cli.Command
+}
+
+func setupTest(t *testing.T) *dumpTest {
+	debugServer := &fakeDebugServer{}
+	diagnosticsServer := &fakeDiagnosticsServer{}
+	healthServer := &fakeHealthServer{}
+
+	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
+		debugv1.RegisterDebugServer(s, debugServer)
+		diagnosticsv1.RegisterDiagnosticsServer(s, diagnosticsServer)
+		grpc_health_v1.RegisterHealthServer(s, healthServer)
+	})
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	client := debug.NewDumpCommandWithEnv(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return &dumpTest{
+		stdout:      stdout,
+		stderr:      stderr,
+		args:        []string{common.AddrArg, common.GetAddr(addr)},
+		dir:         t.TempDir(),
+		debug:       debugServer,
+		diagnostics: diagnosticsServer,
+		health:      healthServer,
+		client:      client,
+	}
+}
+
+func readArchive(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
+
+func keys(files map[string]string) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	return names
+}
+
+type fakeDebugServer struct {
+	debugv1.UnimplementedDebugServer
+
+	err error
+}
+
+func (s *fakeDebugServer) GetInfo(context.Context, *debugv1.GetInfoRequest) (*debugv1.GetInfoResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &debugv1.GetInfoResponse{AgentsCount: 3, Uptime: 10}, nil
+}
+
+type fakeDiagnosticsServer struct {
+	diagnosticsv1.UnimplementedDiagnosticsServer
+
+	resp *diagnosticsv1.GetDiagnosticsResponse
+	err  error
+}
+
+func (s *fakeDiagnosticsServer) GetDiagnostics(context.Context, *diagnosticsv1.GetDiagnosticsRequest) (*diagnosticsv1.GetDiagnosticsResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.resp, nil
+}
+
+type fakeHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	err error
+}
+
+func (s *fakeHealthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
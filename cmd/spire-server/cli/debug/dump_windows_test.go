@@ -0,0 +1,25 @@
+//go:build windows
+// +build windows
+
+package debug_test
+
+var (
+	dumpUsage = `Usage of debug dump:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -config string
+    	Path to the SPIRE Server config file. Plugin data and secrets are redacted from the archived copy (default "conf/server/server.conf")
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -namedPipeName string
+    	Pipe name of the SPIRE Server API named pipe (default "\\spire-server\\private\\api")
+  -out string
+    	Path to write the gzip compressed tar archive to. Defaults to spire-server-debug-<timestamp>.tar.gz in the current directory
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -workloadAPINamedPipeName string
+    	Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+)
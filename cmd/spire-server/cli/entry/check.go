@@ -0,0 +1,311 @@
+package entry
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+const listBundlesRequestPageSize = 1000
+
+// NewCheckCommand creates a new "check" subcommand for "entry" command.
+func NewCheckCommand() cli.Command {
+	return newCheckCommand(commoncli.DefaultEnv)
+}
+
+func newCheckCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &checkCommand{env: env})
+}
+
+type checkCommand struct {
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+func (*checkCommand) Name() string {
+	return "entry check"
+}
+
+func (*checkCommand) Synopsis() string {
+	return "Reports orphaned, unreachable, overlapping, expired and misfederated registration entries"
+}
+
+func (c *checkCommand) AppendFlags(f *flag.FlagSet) {
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintCheck)
+}
+
+func (c *checkCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	// Entries and agents are loaded the same way the server loads them to
+	// build the cache of authorized entries, so that reachability is
+	// evaluated against the cache itself.
+	entryIter := &entryIteratorAPI{client: serverClient.NewEntryClient()}
+	agentIter := &agentIteratorAPI{client: serverClient.NewAgentClient()}
+	cache, err := entrycache.Build(ctx, entryIter, agentIter)
+	if err != nil {
+		return err
+	}
+
+	trustDomains, err := fetchFederatedTrustDomains(ctx, serverClient.NewBundleClient())
+	if err != nil {
+		return err
+	}
+
+	result := checkEntries(cache, entryIter.entries, agentIter.agents, agentIter.active, trustDomains, time.Now())
+	return c.printer.PrintStruct(result)
+}
+
+type checkResult struct {
+	Orphaned             []*entryFinding     `json:"orphaned"`
+	Unreachable          []*entryFinding     `json:"unreachable"`
+	DuplicateSelectors   []*duplicateFinding `json:"duplicate_selectors"`
+	OverlappingSelectors []*overlapFinding   `json:"overlapping_selectors"`
+	Expired              []*entryFinding     `json:"expired"`
+	UnknownFederatesWith []*entryFinding     `json:"unknown_federates_with"`
+}
+
+type entryFinding struct {
+	EntryID      string   `json:"entry_id"`
+	SPIFFEID     string   `json:"spiffe_id"`
+	ParentID     string   `json:"parent_id"`
+	ExpiresAt    int64    `json:"expires_at,omitempty"`
+	TrustDomains []string `json:"trust_domains,omitempty"`
+}
+
+type duplicateFinding struct {
+	ParentID  string   `json:"parent_id"`
+	Selectors []string `json:"selectors"`
+	EntryIDs  []string `json:"entry_ids"`
+}
+
+// overlapFinding reports an entry whose selectors are a strict subset of the
+// selectors of another entry under the same parent, so that every workload
+// matching the superset entry also matches the entry.
+type overlapFinding struct {
+	ParentID        string `json:"parent_id"`
+	EntryID         string `json:"entry_id"`
+	SupersetEntryID string `json:"superset_entry_id"`
+}
+
+func checkEntries(cache *entrycache.FullEntryCache, entries []*types.Entry, agents []*types.Agent, active []entrycache.Agent, trustDomains map[string]bool, now time.Time) *checkResult {
+	result := &checkResult{
+		Orphaned:             []*entryFinding{},
+		Unreachable:          []*entryFinding{},
+		DuplicateSelectors:   []*duplicateFinding{},
+		OverlappingSelectors: []*overlapFinding{},
+		Expired:              []*entryFinding{},
+		UnknownFederatesWith: []*entryFinding{},
+	}
+
+	// Parents are known if they are attested agents, banned or not, or the
+	// SPIFFE ID of an entry
+	knownParents := make(map[string]bool)
+	for _, agent := range agents {
+		knownParents[protoToIDString(agent.Id)] = true
+	}
+	for _, entry := range entries {
+		knownParents[protoToIDString(entry.SpiffeId)] = true
+	}
+
+	reachable := make(map[string]bool)
+	for _, agent := range active {
+		for _, entry := range cache.GetAuthorizedEntries(agent.ID) {
+			reachable[entry.Id] = true
+		}
+	}
+
+	for _, entry := range entries {
+		parentID := protoToIDString(entry.ParentId)
+		switch {
+		case entry.ParentId.GetPath() != "/spire/server" && !knownParents[parentID]:
+			result.Orphaned = append(result.Orphaned, newEntryFinding(entry))
+		case !reachable[entry.Id]:
+			result.Unreachable = append(result.Unreachable, newEntryFinding(entry))
+		}
+
+		if entry.ExpiresAt != 0 && entry.ExpiresAt < now.Unix() {
+			finding := newEntryFinding(entry)
+			finding.ExpiresAt = entry.ExpiresAt
+			result.Expired = append(result.Expired, finding)
+		}
+
+		var unknown []string
+		for _, federatesWith := range entry.FederatesWith {
+			if td, err := spiffeid.TrustDomainFromString(federatesWith); err != nil || !trustDomains[td.String()] {
+				unknown = append(unknown, federatesWith)
+			}
+		}
+		if len(unknown) > 0 {
+			finding := newEntryFinding(entry)
+			finding.TrustDomains = unknown
+			result.UnknownFederatesWith = append(result.UnknownFederatesWith, finding)
+		}
+	}
+
+	checkSelectorSets(result, entries)
+	return result
+}
+
+// checkSelectorSets reports the entries under the same parent with the same
+// selectors, or with selectors that are a subset of the selectors of another
+// entry.
+func checkSelectorSets(result *checkResult, entries []*types.Entry) {
+	var parents []string
+	byParent := make(map[string][]*types.Entry)
+	for _, entry := range entries {
+		parentID := protoToIDString(entry.ParentId)
+		if _, ok := byParent[parentID]; !ok {
+			parents = append(parents, parentID)
+		}
+		byParent[parentID] = append(byParent[parentID], entry)
+	}
+
+	for _, parentID := range parents {
+		siblings := byParent[parentID]
+
+		var keys []string
+		byKey := make(map[string][]string)
+		bySelector := make(map[string][]int)
+		selectorSets := make([]map[string]bool, len(siblings))
+		for i, entry := range siblings {
+			selectors := selectorStrings(entry.Selectors)
+			key := strings.Join(selectors, "\n")
+			if _, ok := byKey[key]; !ok {
+				keys = append(keys, key)
+			}
+			byKey[key] = append(byKey[key], entry.Id)
+
+			selectorSets[i] = make(map[string]bool, len(selectors))
+			for _, selector := range selectors {
+				selectorSets[i][selector] = true
+				bySelector[selector] = append(bySelector[selector], i)
+			}
+		}
+
+		for _, key := range keys {
+			if entryIDs := byKey[key]; len(entryIDs) > 1 {
+				result.DuplicateSelectors = append(result.DuplicateSelectors, &duplicateFinding{
+					ParentID:  parentID,
+					Selectors: strings.Split(key, "\n"),
+					EntryIDs:  entryIDs,
+				})
+			}
+		}
+
+		for i, entry := range siblings {
+			// Supersets necessarily contain the least common selector of the
+			// entry, so only the entries with that selector are compared.
+			var candidates []int
+			for selector := range selectorSets[i] {
+				if candidates == nil || len(bySelector[selector]) < len(candidates) {
+					candidates = bySelector[selector]
+				}
+			}
+			for _, j := range candidates {
+				if len(selectorSets[j]) > len(selectorSets[i]) && isSelectorSubset(selectorSets[i], selectorSets[j]) {
+					result.OverlappingSelectors = append(result.OverlappingSelectors, &overlapFinding{
+						ParentID:        parentID,
+						EntryID:         entry.Id,
+						SupersetEntryID: siblings[j].Id,
+					})
+				}
+			}
+		}
+	}
+}
+
+func fetchFederatedTrustDomains(ctx context.Context, client bundlev1.BundleClient) (map[string]bool, error) {
+	trustDomains := make(map[string]bool)
+	pageToken := ""
+	for {
+		resp, err := client.ListFederatedBundles(ctx, &bundlev1.ListFederatedBundlesRequest{
+			OutputMask: &types.BundleMask{},
+			PageSize:   listBundlesRequestPageSize,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching federated bundles: %w", err)
+		}
+		for _, bundle := range resp.Bundles {
+			trustDomains[bundle.TrustDomain] = true
+		}
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return trustDomains, nil
+		}
+	}
+}
+
+func newEntryFinding(entry *types.Entry) *entryFinding {
+	return &entryFinding{
+		EntryID:  entry.Id,
+		SPIFFEID: protoToIDString(entry.SpiffeId),
+		ParentID: protoToIDString(entry.ParentId),
+	}
+}
+
+func selectorStrings(selectors []*types.Selector) []string {
+	out := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		out = append(out, selector.Type+":"+selector.Value)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func isSelectorSubset(a, b map[string]bool) bool {
+	for selector := range a {
+		if !b[selector] {
+			return false
+		}
+	}
+	return true
+}
+
+func prettyPrintCheck(env *commoncli.Env, results ...interface{}) error {
+	resultInterface, ok := results[0].([]interface{})
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+	result, ok := resultInterface[0].(*checkResult)
+	if !ok {
+		return errors.New("unexpected type")
+	}
+
+	env.Printf("Orphaned entries (parent ID matches no agent or entry): %d\n", len(result.Orphaned))
+	for _, finding := range result.Orphaned {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Parent ID: %s\n", finding.EntryID, finding.SPIFFEID, finding.ParentID)
+	}
+	env.Printf("\nUnreachable entries (not authorized to any agent): %d\n", len(result.Unreachable))
+	for _, finding := range result.Unreachable {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Parent ID: %s\n", finding.EntryID, finding.SPIFFEID, finding.ParentID)
+	}
+	env.Printf("\nDuplicate selector sets (same selectors under the same parent): %d\n", len(result.DuplicateSelectors))
+	for _, finding := range result.DuplicateSelectors {
+		env.Printf("  Parent ID: %s, Selectors: %s, Entry IDs: %s\n", finding.ParentID, strings.Join(finding.Selectors, " "), strings.Join(finding.EntryIDs, ", "))
+	}
+	env.Printf("\nOverlapping selector sets (selectors are a subset of those of another entry under the same parent): %d\n", len(result.OverlappingSelectors))
+	for _, finding := range result.OverlappingSelectors {
+		env.Printf("  Parent ID: %s, Entry ID: %s, Superset entry ID: %s\n", finding.ParentID, finding.EntryID, finding.SupersetEntryID)
+	}
+	env.Printf("\nExpired entries: %d\n", len(result.Expired))
+	for _, finding := range result.Expired {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Expired at: %s\n", finding.EntryID, finding.SPIFFEID, time.Unix(finding.ExpiresAt, 0).UTC())
+	}
+	env.Printf("\nEntries federating with unknown trust domains: %d\n", len(result.UnknownFederatesWith))
+	for _, finding := range result.UnknownFederatesWith {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Trust domains: %s\n", finding.EntryID, finding.SPIFFEID, strings.Join(finding.TrustDomains, ", "))
+	}
+	return nil
+}
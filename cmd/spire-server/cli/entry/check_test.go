@@ -0,0 +1,180 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHelp(t *testing.T) {
+	test := setupTest(t, newCheckCommand)
+	test.client.Help()
+
+	require.Equal(t, checkUsage, test.stderr.String())
+}
+
+func TestCheckSynopsis(t *testing.T) {
+	test := setupTest(t, newCheckCommand)
+	require.Equal(t, "Reports orphaned, unreachable, overlapping, expired and misfederated registration entries", test.client.Synopsis())
+}
+
+func TestCheck(t *testing.T) {
+	id := func(path string) *types.SPIFFEID {
+		return &types.SPIFFEID{TrustDomain: "example.org", Path: path}
+	}
+	selectors := func(values ...string) []*types.Selector {
+		var out []*types.Selector
+		for _, value := range values {
+			out = append(out, &types.Selector{Type: "unix", Value: value})
+		}
+		return out
+	}
+
+	agents := []*types.Agent{
+		{
+			Id:        id("/spire/agent/a"),
+			Selectors: []*types.Selector{{Type: "k8s_psat", Value: "cluster:demo"}},
+		},
+		{
+			Id:     id("/spire/agent/b"),
+			Banned: true,
+		},
+	}
+	bundles := []*types.Bundle{{TrustDomain: "domain1.org"}}
+	entries := []*types.Entry{
+		// Node alias matched by agent a
+		{Id: "alias", ParentId: id("/spire/server"), SpiffeId: id("/node/demo"), Selectors: []*types.Selector{{Type: "k8s_psat", Value: "cluster:demo"}}},
+		{Id: "workload", ParentId: id("/node/demo"), SpiffeId: id("/workload"), Selectors: selectors("uid:1000")},
+		{Id: "duplicate", ParentId: id("/node/demo"), SpiffeId: id("/duplicate"), Selectors: selectors("uid:1000")},
+		{Id: "superset", ParentId: id("/node/demo"), SpiffeId: id("/superset"), Selectors: selectors("uid:1000", "gid:1000")},
+		{Id: "orphan", ParentId: id("/missing"), SpiffeId: id("/orphan"), Selectors: selectors("uid:1000")},
+		// Node alias matched by no agent
+		{Id: "unmatched-alias", ParentId: id("/spire/server"), SpiffeId: id("/node/other"), Selectors: []*types.Selector{{Type: "k8s_psat", Value: "cluster:other"}}},
+		{Id: "banned-child", ParentId: id("/spire/agent/b"), SpiffeId: id("/banned"), Selectors: selectors("uid:1000")},
+		{Id: "cycle-a", ParentId: id("/cycle-b"), SpiffeId: id("/cycle-a"), Selectors: selectors("uid:1000")},
+		{Id: "cycle-b", ParentId: id("/cycle-a"), SpiffeId: id("/cycle-b"), Selectors: selectors("uid:1000")},
+		{Id: "expired", ParentId: id("/spire/agent/a"), SpiffeId: id("/expired"), Selectors: selectors("uid:1000"), ExpiresAt: 1},
+		{Id: "federated", ParentId: id("/spire/agent/a"), SpiffeId: id("/federated"), Selectors: selectors("uid:1001"), FederatesWith: []string{"domain1.org", "spiffe://domain2.org"}},
+	}
+
+	for _, tt := range []struct {
+		name string
+
+		entryErr  error
+		agentErr  error
+		bundleErr error
+
+		expOutPretty string
+		expOutJSON   string
+		expErr       string
+	}{
+		{
+			name:     "Entries error",
+			entryErr: errors.New("entries-error"),
+			expErr:   "Error: error fetching entries: rpc error: code = Unknown desc = entries-error\n",
+		},
+		{
+			name:     "Agents error",
+			agentErr: errors.New("agents-error"),
+			expErr:   "Error: error fetching agents: rpc error: code = Unknown desc = agents-error\n",
+		},
+		{
+			name:      "Bundles error",
+			bundleErr: errors.New("bundles-error"),
+			expErr:    "Error: error fetching federated bundles: rpc error: code = Unknown desc = bundles-error\n",
+		},
+		{
+			name: "Check succeeds",
+			expOutPretty: `Orphaned entries (parent ID matches no agent or entry): 1
+  Entry ID: orphan, SPIFFE ID: spiffe://example.org/orphan, Parent ID: spiffe://example.org/missing
+
+Unreachable entries (not authorized to any agent): 4
+  Entry ID: unmatched-alias, SPIFFE ID: spiffe://example.org/node/other, Parent ID: spiffe://example.org/spire/server
+  Entry ID: banned-child, SPIFFE ID: spiffe://example.org/banned, Parent ID: spiffe://example.org/spire/agent/b
+  Entry ID: cycle-a, SPIFFE ID: spiffe://example.org/cycle-a, Parent ID: spiffe://example.org/cycle-b
+  Entry ID: cycle-b, SPIFFE ID: spiffe://example.org/cycle-b, Parent ID: spiffe://example.org/cycle-a
+
+Duplicate selector sets (same selectors under the same parent): 1
+  Parent ID: spiffe://example.org/node/demo, Selectors: unix:uid:1000, Entry IDs: workload, duplicate
+
+Overlapping selector sets (selectors are a subset of those of another entry under the same parent): 2
+  Parent ID: spiffe://example.org/node/demo, Entry ID: workload, Superset entry ID: superset
+  Parent ID: spiffe://example.org/node/demo, Entry ID: duplicate, Superset entry ID: superset
+
+Expired entries: 1
+  Entry ID: expired, SPIFFE ID: spiffe://example.org/expired, Expired at: 1970-01-01 00:00:01 +0000 UTC
+
+Entries federating with unknown trust domains: 1
+  Entry ID: federated, SPIFFE ID: spiffe://example.org/federated, Trust domains: spiffe://domain2.org
+`,
+			expOutJSON: `[{
+  "orphaned": [
+    {"entry_id": "orphan", "spiffe_id": "spiffe://example.org/orphan", "parent_id": "spiffe://example.org/missing"}
+  ],
+  "unreachable": [
+    {"entry_id": "unmatched-alias", "spiffe_id": "spiffe://example.org/node/other", "parent_id": "spiffe://example.org/spire/server"},
+    {"entry_id": "banned-child", "spiffe_id": "spiffe://example.org/banned", "parent_id": "spiffe://example.org/spire/agent/b"},
+    {"entry_id": "cycle-a", "spiffe_id": "spiffe://example.org/cycle-a", "parent_id": "spiffe://example.org/cycle-b"},
+    {"entry_id": "cycle-b", "spiffe_id": "spiffe://example.org/cycle-b", "parent_id": "spiffe://example.org/cycle-a"}
+  ],
+  "duplicate_selectors": [
+    {"parent_id": "spiffe://example.org/node/demo", "selectors": ["unix:uid:1000"], "entry_ids": ["workload", "duplicate"]}
+  ],
+  "overlapping_selectors": [
+    {"parent_id": "spiffe://example.org/node/demo", "entry_id": "workload", "superset_entry_id": "superset"},
+    {"parent_id": "spiffe://example.org/node/demo", "entry_id": "duplicate", "superset_entry_id": "superset"}
+  ],
+  "expired": [
+    {"entry_id": "expired", "spiffe_id": "spiffe://example.org/expired", "parent_id": "spiffe://example.org/spire/agent/a", "expires_at": 1}
+  ],
+  "unknown_federates_with": [
+    {"entry_id": "federated", "spiffe_id": "spiffe://example.org/federated", "parent_id": "spiffe://example.org/spire/agent/a", "trust_domains": ["spiffe://domain2.org"]}
+  ]
+}]`,
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newCheckCommand)
+				test.server.err = tt.entryErr
+				test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+				test.server.listEntriesResp = &entryv1.ListEntriesResponse{Entries: entries}
+				test.agentServer.err = tt.agentErr
+				test.agentServer.agents = agents
+				test.bundleServer.err = tt.bundleErr
+				test.bundleServer.bundles = bundles
+
+				rc := test.client.Run(test.args("-output", format))
+
+				if tt.expErr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErr, test.stderr.String())
+					return
+				}
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expOutPretty, tt.expOutJSON)
+				require.Equal(t, 0, rc)
+			})
+		}
+	}
+}
+
+func TestCheckEmpty(t *testing.T) {
+	test := setupTest(t, newCheckCommand)
+	test.server.expListEntriesReq = &entryv1.ListEntriesRequest{PageSize: listEntriesRequestPageSize}
+	test.server.listEntriesResp = &entryv1.ListEntriesResponse{}
+
+	rc := test.client.Run(test.args("-output", "json"))
+	require.Equal(t, 0, rc)
+	require.JSONEq(t, `[{
+		"orphaned": [],
+		"unreachable": [],
+		"duplicate_selectors": [],
+		"overlapping_selectors": [],
+		"expired": [],
+		"unknown_federates_with": []
+	}]`, test.stdout.String())
+}
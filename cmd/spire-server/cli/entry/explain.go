@@ -0,0 +1,141 @@
+package entry
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+)
+
+// NewExplainCommand creates a new "explain" subcommand for "entry" command.
+func NewExplainCommand() cli.Command {
+	return newExplainCommand(commoncli.DefaultEnv)
+}
+
+func newExplainCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &explainCommand{env: env})
+}
+
+type explainCommand struct {
+	// SPIFFE ID of the agent
+	agentID string
+
+	// Node selectors to evaluate the authorization with, instead of the
+	// selectors of the attested agent
+	nodeSelectors StringsFlag
+
+	// Workload selectors to match the authorized entries against
+	selectors StringsFlag
+
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+func (*explainCommand) Name() string {
+	return "entry explain"
+}
+
+func (*explainCommand) Synopsis() string {
+	return "Explains which registration entries an agent is authorized for and which match a workload"
+}
+
+func (c *explainCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.agentID, "agentID", "", "The SPIFFE ID of the agent. Required unless node selectors are provided")
+	f.Var(&c.nodeSelectors, "nodeSelector", "A colon-delimited type:value node selector used instead of the selectors of the attested agent. Can be used more than once")
+	f.Var(&c.selectors, "selector", "A colon-delimited type:value workload selector. Can be used more than once")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintExplain)
+}
+
+func (c *explainCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	if c.agentID == "" && len(c.nodeSelectors) == 0 {
+		return errors.New("an agent ID or at least one node selector is required")
+	}
+
+	req := &explainv1.ExplainAuthorizationRequest{}
+	if c.agentID != "" {
+		agentID, err := idStringToProto(c.agentID)
+		if err != nil {
+			return fmt.Errorf("error parsing agent ID: %w", err)
+		}
+		req.AgentId = agentID
+	}
+
+	var err error
+	req.NodeSelectors, err = parseSelectors(c.nodeSelectors)
+	if err != nil {
+		return fmt.Errorf("error parsing node selectors: %w", err)
+	}
+	req.WorkloadSelectors, err = parseSelectors(c.selectors)
+	if err != nil {
+		return fmt.Errorf("error parsing selectors: %w", err)
+	}
+
+	resp, err := serverClient.NewExplainClient().ExplainAuthorization(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func parseSelectors(in []string) ([]*types.Selector, error) {
+	var selectors []*types.Selector
+	for _, s := range in {
+		selector, err := util.ParseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+func prettyPrintExplain(env *commoncli.Env, results ...interface{}) error {
+	resp, ok := results[0].(*explainv1.ExplainAuthorizationResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	env.Printf("Node selectors: %s\n", selectorsString(resp.NodeSelectors))
+	env.Printf("\nNode aliases matched by the node selectors: %d\n", len(resp.NodeAliases))
+	for _, entry := range resp.NodeAliases {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Selectors: %s\n", printableEntryID(entry.Id), protoToIDString(entry.SpiffeId), selectorsString(entry.Selectors))
+	}
+	env.Printf("\nAuthorized entries: %d\n", len(resp.AuthorizedEntries))
+	for _, entry := range resp.AuthorizedEntries {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Parent ID: %s\n", printableEntryID(entry.Id), protoToIDString(entry.SpiffeId), protoToIDString(entry.ParentId))
+	}
+	env.Printf("\nEntries matching the workload selectors: %d\n", len(resp.MatchingEntries))
+	for _, entry := range resp.MatchingEntries {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Selectors: %s\n", printableEntryID(entry.Id), protoToIDString(entry.SpiffeId), selectorsString(entry.Selectors))
+	}
+	env.Printf("\nNear misses: %d\n", len(resp.NearMisses))
+	for _, nearMiss := range resp.NearMisses {
+		env.Printf("  Entry ID: %s, SPIFFE ID: %s, Parent ID: %s\n", printableEntryID(nearMiss.Entry.Id), protoToIDString(nearMiss.Entry.SpiffeId), protoToIDString(nearMiss.Entry.ParentId))
+		switch nearMiss.Reason {
+		case explainv1.NearMissReason_MISSING_NODE_SELECTORS:
+			env.Printf("    Node selectors missing from the agent: %s\n", selectorsString(nearMiss.MissingSelectors))
+		case explainv1.NearMissReason_MISSING_WORKLOAD_SELECTORS:
+			env.Printf("    Selectors missing from the workload: %s\n", selectorsString(nearMiss.MissingSelectors))
+		case explainv1.NearMissReason_PARENT_NOT_AUTHORIZED:
+			env.Printf("    Parent ID is not authorized for the agent\n")
+		}
+	}
+	return nil
+}
+
+func selectorsString(selectors []*types.Selector) string {
+	s := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		s = append(s, selector.Type+":"+selector.Value)
+	}
+	return strings.Join(s, " ")
+}
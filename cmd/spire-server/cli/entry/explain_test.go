@@ -0,0 +1,213 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainHelp(t *testing.T) {
+	test := setupTest(t, newExplainCommand)
+	test.client.Help()
+
+	require.Equal(t, explainUsage, test.stderr.String())
+}
+
+func TestExplainSynopsis(t *testing.T) {
+	test := setupTest(t, newExplainCommand)
+	require.Equal(t, "Explains which registration entries an agent is authorized for and which match a workload", test.client.Synopsis())
+}
+
+func TestExplain(t *testing.T) {
+	alias := &types.Entry{
+		Id:        "alias",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/alias"},
+		ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/server"},
+		Selectors: []*types.Selector{{Type: "node", Value: "a"}},
+	}
+	workload := &types.Entry{
+		Id:        "workload",
+		SpiffeId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		ParentId:  &types.SPIFFEID{TrustDomain: "example.org", Path: "/alias"},
+		Selectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}, {Type: "unix", Value: "gid:0"}},
+	}
+	fakeResp := &explainv1.ExplainAuthorizationResponse{
+		NodeSelectors:     []*types.Selector{{Type: "node", Value: "a"}},
+		NodeAliases:       []*types.Entry{alias},
+		AuthorizedEntries: []*types.Entry{alias, workload},
+		NearMisses: []*explainv1.NearMiss{
+			{
+				Entry:            workload,
+				Reason:           explainv1.NearMissReason_MISSING_WORKLOAD_SELECTORS,
+				MissingSelectors: []*types.Selector{{Type: "unix", Value: "gid:0"}},
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expReq    *explainv1.ExplainAuthorizationRequest
+		fakeResp  *explainv1.ExplainAuthorizationResponse
+		serverErr error
+
+		expOutPretty string
+		expOutJSON   string
+		expErr       string
+	}{
+		{
+			name:   "Missing agent ID and node selectors",
+			args:   []string{"-selector", "unix:uid:1000"},
+			expErr: "Error: an agent ID or at least one node selector is required\n",
+		},
+		{
+			name:   "Invalid agent ID",
+			args:   []string{"-agentID", "agent"},
+			expErr: "Error: error parsing agent ID: scheme is missing or invalid\n",
+		},
+		{
+			name:   "Invalid node selector",
+			args:   []string{"-nodeSelector", "node"},
+			expErr: "Error: error parsing node selectors: selector \"node\" must be formatted as type:value\n",
+		},
+		{
+			name:   "Invalid workload selector",
+			args:   []string{"-nodeSelector", "node:a", "-selector", "unix"},
+			expErr: "Error: error parsing selectors: selector \"unix\" must be formatted as type:value\n",
+		},
+		{
+			name: "Server error",
+			args: []string{"-agentID", "spiffe://example.org/spire/agent/test/agent"},
+			expReq: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent"},
+			},
+			serverErr: errors.New("server-error"),
+			expErr:    "Error: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name: "Explain succeeds",
+			args: []string{"-agentID", "spiffe://example.org/spire/agent/test/agent", "-nodeSelector", "node:a", "-selector", "unix:uid:1000"},
+			expReq: &explainv1.ExplainAuthorizationRequest{
+				AgentId:           &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent"},
+				NodeSelectors:     []*types.Selector{{Type: "node", Value: "a"}},
+				WorkloadSelectors: []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+			fakeResp: fakeResp,
+			expOutPretty: `Node selectors: node:a
+
+Node aliases matched by the node selectors: 1
+  Entry ID: alias, SPIFFE ID: spiffe://example.org/alias, Selectors: node:a
+
+Authorized entries: 2
+  Entry ID: alias, SPIFFE ID: spiffe://example.org/alias, Parent ID: spiffe://example.org/spire/server
+  Entry ID: workload, SPIFFE ID: spiffe://example.org/workload, Parent ID: spiffe://example.org/alias
+
+Entries matching the workload selectors: 0
+
+Near misses: 1
+  Entry ID: workload, SPIFFE ID: spiffe://example.org/workload, Parent ID: spiffe://example.org/alias
+    Selectors missing from the workload: unix:gid:0
+`,
+			expOutJSON: `{
+  "node_selectors": [{"type": "node", "value": "a"}],
+  "node_aliases": [
+    {
+      "id": "alias",
+      "spiffe_id": {"trust_domain": "example.org", "path": "/alias"},
+      "parent_id": {"trust_domain": "example.org", "path": "/spire/server"},
+      "selectors": [{"type": "node", "value": "a"}],
+      "x509_svid_ttl": 0,
+      "federates_with": [],
+      "admin": false,
+      "downstream": false,
+      "expires_at": "0",
+      "dns_names": [],
+      "revision_number": "0",
+      "store_svid": false,
+      "jwt_svid_ttl": 0
+    }
+  ],
+  "authorized_entries": [
+    {
+      "id": "alias",
+      "spiffe_id": {"trust_domain": "example.org", "path": "/alias"},
+      "parent_id": {"trust_domain": "example.org", "path": "/spire/server"},
+      "selectors": [{"type": "node", "value": "a"}],
+      "x509_svid_ttl": 0,
+      "federates_with": [],
+      "admin": false,
+      "downstream": false,
+      "expires_at": "0",
+      "dns_names": [],
+      "revision_number": "0",
+      "store_svid": false,
+      "jwt_svid_ttl": 0
+    },
+    {
+      "id": "workload",
+      "spiffe_id": {"trust_domain": "example.org", "path": "/workload"},
+      "parent_id": {"trust_domain": "example.org", "path": "/alias"},
+      "selectors": [{"type": "unix", "value": "uid:1000"}, {"type": "unix", "value": "gid:0"}],
+      "x509_svid_ttl": 0,
+      "federates_with": [],
+      "admin": false,
+      "downstream": false,
+      "expires_at": "0",
+      "dns_names": [],
+      "revision_number": "0",
+      "store_svid": false,
+      "jwt_svid_ttl": 0
+    }
+  ],
+  "matching_entries": [],
+  "near_misses": [
+    {
+      "entry": {
+        "id": "workload",
+        "spiffe_id": {"trust_domain": "example.org", "path": "/workload"},
+        "parent_id": {"trust_domain": "example.org", "path": "/alias"},
+        "selectors": [{"type": "unix", "value": "uid:1000"}, {"type": "unix", "value": "gid:0"}],
+        "x509_svid_ttl": 0,
+        "federates_with": [],
+        "admin": false,
+        "downstream": false,
+        "expires_at": "0",
+        "dns_names": [],
+        "revision_number": "0",
+        "store_svid": false,
+        "jwt_svid_ttl": 0
+      },
+      "reason": "MISSING_WORKLOAD_SELECTORS",
+      "missing_selectors": [{"type": "unix", "value": "gid:0"}]
+    }
+  ]
+}`,
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newExplainCommand)
+				test.explainServer.err = tt.serverErr
+				test.explainServer.expExplainAuthorizationReq = tt.expReq
+				test.explainServer.explainAuthorizationResp = tt.fakeResp
+				args := tt.args
+				args = append(args, "-output", format)
+
+				rc := test.client.Run(test.args(args...))
+
+				if tt.expErr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErr, test.stderr.String())
+					return
+				}
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expOutPretty, tt.expOutJSON)
+				require.Equal(t, 0, rc)
+			})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+
+	"golang.org/x/net/context"
+)
+
+// NewHistoryCommand creates a new "history" subcommand for "entry" command.
+func NewHistoryCommand() cli.Command {
+	return newHistoryCommand(commoncli.DefaultEnv)
+}
+
+func newHistoryCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &historyCommand{env: env})
+}
+
+type historyCommand struct {
+	// ID of the entry to show the history of
+	entryID string
+	env     *commoncli.Env
+	printer cliprinter.Printer
+}
+
+func (*historyCommand) Name() string {
+	return "entry history"
+}
+
+func (*historyCommand) Synopsis() string {
+	return "Shows the revisions of a registration entry"
+}
+
+func (c *historyCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.entryID, "entryID", "", "The Registration Entry ID of the record to show the history of")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintHistory)
+}
+
+func (c *historyCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	if c.entryID == "" {
+		return errors.New("an entry ID is required")
+	}
+
+	resp, err := serverClient.NewEntryHistoryClient().GetEntryHistory(ctx, &entryhistoryv1.GetEntryHistoryRequest{
+		EntryId: c.entryID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintHistory(env *commoncli.Env, results ...interface{}) error {
+	historyResp, ok := results[0].(*entryhistoryv1.GetEntryHistoryResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	msg := "Found %d revisions\n\n"
+	if len(historyResp.Revisions) == 1 {
+		msg = "Found %d revision\n\n"
+	}
+	env.Printf(msg, len(historyResp.Revisions))
+
+	for _, revision := range historyResp.Revisions {
+		printRevision(revision, env.Printf)
+	}
+	return nil
+}
+
+func printRevision(r *entryhistoryv1.EntryRevision, printf func(string, ...interface{}) error) {
+	_ = printf("History revision : %d\n", r.Revision)
+	_ = printf("Change type      : %s\n", strings.ToLower(r.ChangeType.String()))
+	if r.ChangedBy == "" {
+		_ = printf("Changed by       : (server)\n")
+	} else {
+		_ = printf("Changed by       : %s\n", r.ChangedBy)
+	}
+	_ = printf("Changed at       : %s\n", time.Unix(r.ChangedAt, 0).UTC())
+	if len(r.ChangedFields) > 0 {
+		_ = printf("Changed fields   : %s\n", strings.Join(r.ChangedFields, ", "))
+	}
+	printEntry(r.Entry, printf)
+}
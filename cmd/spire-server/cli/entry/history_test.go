@@ -0,0 +1,187 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryHelp(t *testing.T) {
+	test := setupTest(t, newHistoryCommand)
+	test.client.Help()
+
+	require.Equal(t, historyUsage, test.stderr.String())
+}
+
+func TestHistorySynopsis(t *testing.T) {
+	test := setupTest(t, newHistoryCommand)
+	require.Equal(t, "Shows the revisions of a registration entry", test.client.Synopsis())
+}
+
+func TestHistory(t *testing.T) {
+	entry := &types.Entry{
+		Id:             "entry-id",
+		SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+		Selectors:      []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		RevisionNumber: 1,
+	}
+	fakeResp := &entryhistoryv1.GetEntryHistoryResponse{
+		Revisions: []*entryhistoryv1.EntryRevision{
+			{
+				Revision:   1,
+				ChangeType: entryhistoryv1.ChangeType_CREATED,
+				ChangedBy:  "spiffe://example.org/admin",
+				ChangedAt:  1234,
+				Entry:      entry,
+			},
+			{
+				Revision:      2,
+				ChangeType:    entryhistoryv1.ChangeType_DELETED,
+				ChangedAt:     5678,
+				ChangedFields: []string{"selectors", "dns_names"},
+				Entry:         entry,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expReq    *entryhistoryv1.GetEntryHistoryRequest
+		fakeResp  *entryhistoryv1.GetEntryHistoryResponse
+		serverErr error
+
+		expOutPretty string
+		expOutJSON   string
+		expErr       string
+	}{
+		{
+			name:   "Empty entry ID",
+			expErr: "Error: an entry ID is required\n",
+		},
+		{
+			name:      "Server error",
+			args:      []string{"-entryID", "entry-id"},
+			expReq:    &entryhistoryv1.GetEntryHistoryRequest{EntryId: "entry-id"},
+			serverErr: errors.New("server-error"),
+			expErr:    "Error: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name:         "No revisions",
+			args:         []string{"-entryID", "entry-id"},
+			expReq:       &entryhistoryv1.GetEntryHistoryRequest{EntryId: "entry-id"},
+			fakeResp:     &entryhistoryv1.GetEntryHistoryResponse{},
+			expOutPretty: "Found 0 revisions\n\n",
+			expOutJSON:   `{"revisions":[]}`,
+		},
+		{
+			name:     "History succeeds",
+			args:     []string{"-entryID", "entry-id"},
+			expReq:   &entryhistoryv1.GetEntryHistoryRequest{EntryId: "entry-id"},
+			fakeResp: fakeResp,
+			expOutPretty: `Found 2 revisions
+
+History revision : 1
+Change type      : created
+Changed by       : spiffe://example.org/admin
+Changed at       : 1970-01-01 00:20:34 +0000 UTC
+Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 1
+X509-SVID TTL    : default
+JWT-SVID TTL     : default
+Selector         : unix:uid:1000
+
+History revision : 2
+Change type      : deleted
+Changed by       : (server)
+Changed at       : 1970-01-01 01:34:38 +0000 UTC
+Changed fields   : selectors, dns_names
+Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 1
+X509-SVID TTL    : default
+JWT-SVID TTL     : default
+Selector         : unix:uid:1000
+
+`,
+			expOutJSON: `{
+  "revisions": [
+    {
+      "revision": "1",
+      "change_type": "CREATED",
+      "changed_by": "spiffe://example.org/admin",
+      "changed_at": "1234",
+      "changed_fields": [],
+      "entry": {
+        "id": "entry-id",
+        "spiffe_id": {"trust_domain": "example.org", "path": "/workload"},
+        "parent_id": {"trust_domain": "example.org", "path": "/parent"},
+        "selectors": [{"type": "unix", "value": "uid:1000"}],
+        "x509_svid_ttl": 0,
+        "federates_with": [],
+        "admin": false,
+        "downstream": false,
+        "expires_at": "0",
+        "dns_names": [],
+        "revision_number": "1",
+        "store_svid": false,
+        "jwt_svid_ttl": 0
+      }
+    },
+    {
+      "revision": "2",
+      "change_type": "DELETED",
+      "changed_by": "",
+      "changed_at": "5678",
+      "changed_fields": ["selectors", "dns_names"],
+      "entry": {
+        "id": "entry-id",
+        "spiffe_id": {"trust_domain": "example.org", "path": "/workload"},
+        "parent_id": {"trust_domain": "example.org", "path": "/parent"},
+        "selectors": [{"type": "unix", "value": "uid:1000"}],
+        "x509_svid_ttl": 0,
+        "federates_with": [],
+        "admin": false,
+        "downstream": false,
+        "expires_at": "0",
+        "dns_names": [],
+        "revision_number": "1",
+        "store_svid": false,
+        "jwt_svid_ttl": 0
+      }
+    }
+  ]
+}`,
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newHistoryCommand)
+				test.historyServer.err = tt.serverErr
+				test.historyServer.expGetEntryHistoryReq = tt.expReq
+				test.historyServer.getEntryHistoryResp = tt.fakeResp
+				args := tt.args
+				args = append(args, "-output", format)
+
+				rc := test.client.Run(test.args(args...))
+
+				if tt.expErr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErr, test.stderr.String())
+					return
+				}
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expOutPretty, tt.expOutJSON)
+				require.Equal(t, 0, rc)
+			})
+		}
+	}
+}
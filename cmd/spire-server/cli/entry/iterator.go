@@ -0,0 +1,143 @@
+package entry
+
+import (
+	"context"
+	"fmt"
+
+	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
+	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+const listAgentsRequestPageSize = 1000
+
+var (
+	_ entrycache.EntryIterator = (*entryIteratorAPI)(nil)
+	_ entrycache.AgentIterator = (*agentIteratorAPI)(nil)
+)
+
+// entryIteratorAPI iterates through the registration entries listed by the
+// server API. The entries are kept once listed, so they can be inspected
+// after the iteration.
+type entryIteratorAPI struct {
+	client  entryv1.EntryClient
+	entries []*types.Entry
+	next    int
+	err     error
+}
+
+func (it *entryIteratorAPI) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.entries == nil {
+		entries, err := it.fetchEntries(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.entries = entries
+	}
+	if it.next >= len(it.entries) {
+		return false
+	}
+	it.next++
+	return true
+}
+
+func (it *entryIteratorAPI) Entry() *types.Entry {
+	return it.entries[it.next-1]
+}
+
+func (it *entryIteratorAPI) Err() error {
+	return it.err
+}
+
+func (it *entryIteratorAPI) fetchEntries(ctx context.Context) ([]*types.Entry, error) {
+	entries := []*types.Entry{}
+	pageToken := ""
+	for {
+		resp, err := it.client.ListEntries(ctx, &entryv1.ListEntriesRequest{
+			PageSize:  listEntriesRequestPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error fetching entries: %w", err)
+		}
+		entries = append(entries, resp.Entries...)
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return entries, nil
+		}
+	}
+}
+
+// agentIteratorAPI iterates through the selectors of the agents listed by
+// the server API. Banned agents are listed but not iterated through, since
+// no entries are authorized to them.
+type agentIteratorAPI struct {
+	client agentv1.AgentClient
+	agents []*types.Agent
+	active []entrycache.Agent
+	next   int
+	err    error
+}
+
+func (it *agentIteratorAPI) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.active == nil {
+		if err := it.fetchAgents(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+	if it.next >= len(it.active) {
+		return false
+	}
+	it.next++
+	return true
+}
+
+func (it *agentIteratorAPI) Agent() entrycache.Agent {
+	return it.active[it.next-1]
+}
+
+func (it *agentIteratorAPI) Err() error {
+	return it.err
+}
+
+func (it *agentIteratorAPI) fetchAgents(ctx context.Context) error {
+	it.agents = []*types.Agent{}
+	it.active = []entrycache.Agent{}
+	pageToken := ""
+	for {
+		resp, err := it.client.ListAgents(ctx, &agentv1.ListAgentsRequest{
+			OutputMask: &types.AgentMask{Selectors: true, Banned: true},
+			PageSize:   listAgentsRequestPageSize,
+			PageToken:  pageToken,
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching agents: %w", err)
+		}
+		for _, agent := range resp.Agents {
+			it.agents = append(it.agents, agent)
+			if agent.Banned {
+				continue
+			}
+			agentID, err := spiffeid.FromString(protoToIDString(agent.Id))
+			if err != nil {
+				return fmt.Errorf("invalid agent ID: %w", err)
+			}
+			it.active = append(it.active, entrycache.Agent{
+				ID:        agentID,
+				Selectors: agent.Selectors,
+			})
+		}
+		if pageToken = resp.NextPageToken; pageToken == "" {
+			return nil
+		}
+	}
+}
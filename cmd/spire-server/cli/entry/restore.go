@@ -0,0 +1,85 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+
+	"golang.org/x/net/context"
+)
+
+// NewRestoreCommand creates a new "restore" subcommand for "entry" command.
+func NewRestoreCommand() cli.Command {
+	return newRestoreCommand(commoncli.DefaultEnv)
+}
+
+func newRestoreCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &restoreCommand{env: env})
+}
+
+type restoreCommand struct {
+	// ID of the entry to restore
+	entryID string
+	// Revision to restore the entry to
+	revision int64
+	env      *commoncli.Env
+	printer  cliprinter.Printer
+}
+
+func (*restoreCommand) Name() string {
+	return "entry restore"
+}
+
+func (*restoreCommand) Synopsis() string {
+	return "Restores a registration entry to a previous revision"
+}
+
+func (c *restoreCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.entryID, "entryID", "", "The Registration Entry ID of the record to restore")
+	f.Int64Var(&c.revision, "revision", 0, "The history revision to restore the record to, as shown by 'entry history'")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintRestore)
+}
+
+func (c *restoreCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	resp, err := serverClient.NewEntryHistoryClient().RestoreEntry(ctx, &entryhistoryv1.RestoreEntryRequest{
+		EntryId:  c.entryID,
+		Revision: c.revision,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.PrintProto(resp)
+}
+
+// Perform basic validation.
+func (c *restoreCommand) validate() error {
+	if c.entryID == "" {
+		return errors.New("an entry ID is required")
+	}
+	if c.revision <= 0 {
+		return errors.New("a revision greater than zero is required")
+	}
+
+	return nil
+}
+
+func prettyPrintRestore(env *commoncli.Env, results ...interface{}) error {
+	restoreResp, ok := results[0].(*entryhistoryv1.RestoreEntryResponse)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	env.Printf("Restored entry:\n\n")
+	printEntry(restoreResp.Entry, env.Printf)
+	return nil
+}
@@ -0,0 +1,126 @@
+package entry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreHelp(t *testing.T) {
+	test := setupTest(t, newRestoreCommand)
+	test.client.Help()
+
+	require.Equal(t, restoreUsage, test.stderr.String())
+}
+
+func TestRestoreSynopsis(t *testing.T) {
+	test := setupTest(t, newRestoreCommand)
+	require.Equal(t, "Restores a registration entry to a previous revision", test.client.Synopsis())
+}
+
+func TestRestore(t *testing.T) {
+	fakeResp := &entryhistoryv1.RestoreEntryResponse{
+		Entry: &types.Entry{
+			Id:             "entry-id",
+			SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"},
+			Selectors:      []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+			RevisionNumber: 3,
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expReq    *entryhistoryv1.RestoreEntryRequest
+		fakeResp  *entryhistoryv1.RestoreEntryResponse
+		serverErr error
+
+		expOutPretty string
+		expOutJSON   string
+		expErr       string
+	}{
+		{
+			name:   "Empty entry ID",
+			args:   []string{"-revision", "1"},
+			expErr: "Error: an entry ID is required\n",
+		},
+		{
+			name:   "Missing revision",
+			args:   []string{"-entryID", "entry-id"},
+			expErr: "Error: a revision greater than zero is required\n",
+		},
+		{
+			name:   "Negative revision",
+			args:   []string{"-entryID", "entry-id", "-revision", "-1"},
+			expErr: "Error: a revision greater than zero is required\n",
+		},
+		{
+			name:      "Server error",
+			args:      []string{"-entryID", "entry-id", "-revision", "1"},
+			expReq:    &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			serverErr: errors.New("server-error"),
+			expErr:    "Error: rpc error: code = Unknown desc = server-error\n",
+		},
+		{
+			name:     "Restore succeeds",
+			args:     []string{"-entryID", "entry-id", "-revision", "1"},
+			expReq:   &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			fakeResp: fakeResp,
+			expOutPretty: `Restored entry:
+
+Entry ID         : entry-id
+SPIFFE ID        : spiffe://example.org/workload
+Parent ID        : spiffe://example.org/parent
+Revision         : 3
+X509-SVID TTL    : default
+JWT-SVID TTL     : default
+Selector         : unix:uid:1000
+
+`,
+			expOutJSON: `{
+  "entry": {
+    "id": "entry-id",
+    "spiffe_id": {"trust_domain": "example.org", "path": "/workload"},
+    "parent_id": {"trust_domain": "example.org", "path": "/parent"},
+    "selectors": [{"type": "unix", "value": "uid:1000"}],
+    "x509_svid_ttl": 0,
+    "federates_with": [],
+    "admin": false,
+    "downstream": false,
+    "expires_at": "0",
+    "dns_names": [],
+    "revision_number": "3",
+    "store_svid": false,
+    "jwt_svid_ttl": 0
+  }
+}`,
+		},
+	} {
+		for _, format := range availableFormats {
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newRestoreCommand)
+				test.historyServer.err = tt.serverErr
+				test.historyServer.expRestoreEntryReq = tt.expReq
+				test.historyServer.restoreEntryResp = tt.fakeResp
+				args := tt.args
+				args = append(args, "-output", format)
+
+				rc := test.client.Run(test.args(args...))
+
+				if tt.expErr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expErr, test.stderr.String())
+					return
+				}
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expOutPretty, tt.expOutJSON)
+				require.Equal(t, 0, rc)
+			})
+		}
+	}
+}
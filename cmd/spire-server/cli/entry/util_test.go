@@ -7,10 +7,14 @@ import (
 	"testing"
 
 	"github.com/mitchellh/cli"
+	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
+	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/cmd/spire-server/cli/common"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
@@ -140,8 +144,12 @@ type entryTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	addr   string
-	server *fakeEntryServer
+	addr          string
+	server        *fakeEntryServer
+	historyServer *fakeEntryHistoryServer
+	explainServer *fakeExplainServer
+	agentServer   *fakeAgentServer
+	bundleServer  *fakeBundleServer
 
 	client cli.Command
 }
@@ -224,6 +232,81 @@ func (f fakeEntryServer) BatchUpdateEntry(ctx context.Context, req *entryv1.Batc
 	return f.batchUpdateEntryResp, nil
 }
 
+type fakeEntryHistoryServer struct {
+	entryhistoryv1.UnimplementedEntryHistoryServer
+
+	t   *testing.T
+	err error
+
+	expGetEntryHistoryReq *entryhistoryv1.GetEntryHistoryRequest
+	expRestoreEntryReq    *entryhistoryv1.RestoreEntryRequest
+
+	getEntryHistoryResp *entryhistoryv1.GetEntryHistoryResponse
+	restoreEntryResp    *entryhistoryv1.RestoreEntryResponse
+}
+
+func (f *fakeEntryHistoryServer) GetEntryHistory(ctx context.Context, req *entryhistoryv1.GetEntryHistoryRequest) (*entryhistoryv1.GetEntryHistoryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expGetEntryHistoryReq, req)
+	return f.getEntryHistoryResp, nil
+}
+
+func (f *fakeEntryHistoryServer) RestoreEntry(ctx context.Context, req *entryhistoryv1.RestoreEntryRequest) (*entryhistoryv1.RestoreEntryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expRestoreEntryReq, req)
+	return f.restoreEntryResp, nil
+}
+
+type fakeExplainServer struct {
+	explainv1.UnimplementedExplainServer
+
+	t   *testing.T
+	err error
+
+	expExplainAuthorizationReq *explainv1.ExplainAuthorizationRequest
+	explainAuthorizationResp   *explainv1.ExplainAuthorizationResponse
+}
+
+func (f *fakeExplainServer) ExplainAuthorization(ctx context.Context, req *explainv1.ExplainAuthorizationRequest) (*explainv1.ExplainAuthorizationResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	spiretest.AssertProtoEqual(f.t, f.expExplainAuthorizationReq, req)
+	return f.explainAuthorizationResp, nil
+}
+
+type fakeAgentServer struct {
+	agentv1.UnimplementedAgentServer
+
+	err    error
+	agents []*types.Agent
+}
+
+func (f *fakeAgentServer) ListAgents(ctx context.Context, req *agentv1.ListAgentsRequest) (*agentv1.ListAgentsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &agentv1.ListAgentsResponse{Agents: f.agents}, nil
+}
+
+type fakeBundleServer struct {
+	bundlev1.UnimplementedBundleServer
+
+	err     error
+	bundles []*types.Bundle
+}
+
+func (f *fakeBundleServer) ListFederatedBundles(ctx context.Context, req *bundlev1.ListFederatedBundlesRequest) (*bundlev1.ListFederatedBundlesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &bundlev1.ListFederatedBundlesResponse{Bundles: f.bundles}, nil
+}
+
 func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entryTest {
 	stdin := new(bytes.Buffer)
 	stdout := new(bytes.Buffer)
@@ -236,17 +319,29 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *entry
 	})
 
 	server := &fakeEntryServer{t: t}
+	historyServer := &fakeEntryHistoryServer{t: t}
+	explainServer := &fakeExplainServer{t: t}
+	agentServer := &fakeAgentServer{}
+	bundleServer := &fakeBundleServer{}
 	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
 		entryv1.RegisterEntryServer(s, server)
+		entryhistoryv1.RegisterEntryHistoryServer(s, historyServer)
+		explainv1.RegisterExplainServer(s, explainServer)
+		agentv1.RegisterAgentServer(s, agentServer)
+		bundlev1.RegisterBundleServer(s, bundleServer)
 	})
 
 	test := &entryTest{
-		addr:   common.GetAddr(addr),
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		server: server,
-		client: client,
+		addr:          common.GetAddr(addr),
+		stdin:         stdin,
+		stdout:        stdout,
+		stderr:        stderr,
+		server:        server,
+		historyServer: historyServer,
+		explainServer: explainServer,
+		agentServer:   agentServer,
+		bundleServer:  bundleServer,
+		client:        client,
 	}
 
 	t.Cleanup(func() {
@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package events
+
+const (
+	watchUsage = `Usage of events watch:
+  -bundlePath string
+    	Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath
+  -certPath string
+    	Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API
+  -cursor string
+    	Cursor of the last observed event. Events recorded after it are printed before new events. If unset, only new events are printed
+  -keyPath string
+    	Path to the PEM-encoded private key of the X509-SVID set with -certPath
+  -output value
+    	Desired output format (pretty, json, yaml, table[=<columns>], template=<template>); default: pretty.
+  -serverAddr string
+    	Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket
+  -socketPath string
+    	Path to the SPIRE Server API socket (default "/tmp/spire-server/private/api.sock")
+  -type value
+    	Resource type to watch (entry, agent, bundle or federation_relationship). Can be used more than once. If unset, all resource types are watched
+  -workloadAPISocket string
+    	Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable
+`
+)
@@ -0,0 +1,110 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+)
+
+var resourceTypes = map[string]eventv1.ResourceType{
+	"entry":                   eventv1.ResourceType_ENTRY,
+	"agent":                   eventv1.ResourceType_AGENT,
+	"bundle":                  eventv1.ResourceType_BUNDLE,
+	"federation_relationship": eventv1.ResourceType_FEDERATION_RELATIONSHIP,
+}
+
+// NewWatchCommand creates a new "events watch" subcommand.
+func NewWatchCommand() cli.Command {
+	return newWatchCommand(commoncli.DefaultEnv)
+}
+
+func newWatchCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &watchCommand{env: env})
+}
+
+type watchCommand struct {
+	env *commoncli.Env
+
+	// Cursor of the last event observed
+	cursor string
+
+	// Resource types to watch
+	types commoncli.StringsFlag
+
+	printer cliprinter.Printer
+}
+
+func (c *watchCommand) Name() string {
+	return "events watch"
+}
+
+func (c *watchCommand) Synopsis() string {
+	return "Watches for changes to entries, agents, bundles and federation relationships"
+}
+
+func (c *watchCommand) AppendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.cursor, "cursor", "", "Cursor of the last observed event. Events recorded after it are printed before new events. If unset, only new events are printed")
+	fs.Var(&c.types, "type", "Resource type to watch (entry, agent, bundle or federation_relationship). Can be used more than once. If unset, all resource types are watched")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, fs, c.env, prettyPrintEvent)
+}
+
+func (c *watchCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	req := &eventv1.WatchRequest{
+		Cursor: c.cursor,
+	}
+	for _, t := range c.types {
+		resourceType, ok := resourceTypes[t]
+		if !ok {
+			return fmt.Errorf("unknown resource type %q", t)
+		}
+		req.ResourceTypes = append(req.ResourceTypes, resourceType)
+	}
+
+	stream, err := serverClient.NewEventClient().Watch(ctx, req)
+	if err != nil {
+		return fmt.Errorf("error watching events: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("error watching events: %w", err)
+		}
+
+		for _, event := range resp.Events {
+			if err := c.printer.PrintProto(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// prettyPrintEvent prints a single line per event, so the output can be
+// easily processed as the events arrive.
+func prettyPrintEvent(env *commoncli.Env, results ...interface{}) error {
+	event, ok := results[0].(*eventv1.ResourceEvent)
+	if !ok {
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+
+	return env.Printf("%s %s %s %s %s\n",
+		event.Cursor,
+		time.Unix(event.CreatedAt, 0).UTC().Format(time.RFC3339),
+		strings.ToLower(event.Type.String()),
+		strings.ToLower(event.ResourceType.String()),
+		event.ResourceId,
+	)
+}
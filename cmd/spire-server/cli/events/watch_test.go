@@ -0,0 +1,195 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/cmd/spire-server/cli/common"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatchHelp(t *testing.T) {
+	test := setupTest(t)
+	test.client.Help()
+
+	require.Equal(t, watchUsage, test.stderr.String())
+}
+
+func TestWatchSynopsis(t *testing.T) {
+	test := setupTest(t)
+	require.Equal(t, "Watches for changes to entries, agents, bundles and federation relationships", test.client.Synopsis())
+}
+
+func TestWatch(t *testing.T) {
+	entryCreated := &eventv1.ResourceEvent{
+		Cursor:       "7",
+		Type:         eventv1.EventType_CREATED,
+		ResourceType: eventv1.ResourceType_ENTRY,
+		ResourceId:   "entry-id",
+		CreatedAt:    1666000000,
+		Resource: &eventv1.ResourceEvent_Entry{
+			Entry: &types.Entry{Id: "entry-id"},
+		},
+	}
+	bundleDeleted := &eventv1.ResourceEvent{
+		Cursor:       "8",
+		Type:         eventv1.EventType_DELETED,
+		ResourceType: eventv1.ResourceType_BUNDLE,
+		ResourceId:   "domain.test",
+		CreatedAt:    1666000001,
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		expectReq *eventv1.WatchRequest
+		responses []*eventv1.WatchResponse
+		serverErr error
+
+		expectOutPretty string
+		expectOutJSON   string
+		expectErr       string
+	}{
+		{
+			name:      "new events",
+			expectReq: &eventv1.WatchRequest{},
+			responses: []*eventv1.WatchResponse{
+				{Events: []*eventv1.ResourceEvent{entryCreated}},
+				{Events: []*eventv1.ResourceEvent{bundleDeleted}},
+			},
+			expectOutPretty: "7 2022-10-17T09:46:40Z created entry entry-id\n" +
+				"8 2022-10-17T09:46:41Z deleted bundle domain.test\n",
+			expectOutJSON: `{"created_at":"1666000000","cursor":"7","entry":{"admin":false,"dns_names":[],"downstream":false,"expires_at":"0","federates_with":[],"id":"entry-id","jwt_svid_ttl":0,"revision_number":"0","selectors":[],"store_svid":false,"x509_svid_ttl":0},"resource_id":"entry-id","resource_type":"ENTRY","type":"CREATED"}` + "\n" +
+				`{"created_at":"1666000001","cursor":"8","resource_id":"domain.test","resource_type":"BUNDLE","type":"DELETED"}` + "\n",
+		},
+		{
+			name: "from cursor and filtered by resource type",
+			args: []string{"-cursor", "6", "-type", "bundle", "-type", "federation_relationship"},
+			expectReq: &eventv1.WatchRequest{
+				Cursor:        "6",
+				ResourceTypes: []eventv1.ResourceType{eventv1.ResourceType_BUNDLE, eventv1.ResourceType_FEDERATION_RELATIONSHIP},
+			},
+			responses: []*eventv1.WatchResponse{
+				{Events: []*eventv1.ResourceEvent{bundleDeleted}},
+			},
+			expectOutPretty: "8 2022-10-17T09:46:41Z deleted bundle domain.test\n",
+			expectOutJSON:   `{"created_at":"1666000001","cursor":"8","resource_id":"domain.test","resource_type":"BUNDLE","type":"DELETED"}` + "\n",
+		},
+		{
+			name:      "unknown resource type",
+			args:      []string{"-type", "node"},
+			expectErr: "Error: unknown resource type \"node\"\n",
+		},
+		{
+			name:      "server error",
+			expectReq: &eventv1.WatchRequest{},
+			serverErr: status.Error(codes.Internal, "oh no"),
+			expectErr: "Error: error watching events: rpc error: code = Internal desc = oh no\n",
+		},
+	} {
+		tt := tt
+		for _, format := range []string{"pretty", "json"} {
+			format := format
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t)
+				test.server.expectReq = tt.expectReq
+				test.server.responses = tt.responses
+				test.server.err = tt.serverErr
+
+				args := append(tt.args, "-output", format)
+				rc := test.client.Run(test.args(args...))
+				if tt.expectErr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expectErr, test.stderr.String())
+					return
+				}
+
+				require.Equal(t, 0, rc)
+				switch format {
+				case "pretty":
+					require.Equal(t, tt.expectOutPretty, test.stdout.String())
+				case "json":
+					require.Equal(t, tt.expectOutJSON, test.stdout.String())
+				}
+			})
+		}
+	}
+}
+
+type cmdTest struct {
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+
+	addr   string
+	server *fakeEventServer
+
+	client cli.Command
+}
+
+func (c *cmdTest) args(extra ...string) []string {
+	return append([]string{common.AddrArg, c.addr}, extra...)
+}
+
+func setupTest(t *testing.T) *cmdTest {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	client := newWatchCommand(&commoncli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	server := &fakeEventServer{t: t}
+	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
+		eventv1.RegisterEventServer(s, server)
+	})
+
+	test := &cmdTest{
+		addr:   common.GetAddr(addr),
+		stdout: stdout,
+		stderr: stderr,
+		server: server,
+		client: client,
+	}
+
+	t.Cleanup(func() {
+		t.Logf("TEST:%s", t.Name())
+		t.Logf("STDOUT:\n%s", stdout.String())
+		t.Logf("STDERR:\n%s", stderr.String())
+	})
+
+	return test
+}
+
+type fakeEventServer struct {
+	eventv1.UnimplementedEventServer
+
+	t   *testing.T
+	err error
+
+	expectReq *eventv1.WatchRequest
+	responses []*eventv1.WatchResponse
+}
+
+// Watch sends the configured responses and then ends the stream, with the
+// configured error, if any.
+func (f *fakeEventServer) Watch(req *eventv1.WatchRequest, stream eventv1.Event_WatchServer) error {
+	spiretest.AssertProtoEqual(f.t, f.expectReq, req)
+	for _, resp := range f.responses {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 )
 
@@ -111,6 +113,10 @@ func jsonToProto(fr *federationRelationshipConfig) (*types.FederationRelationshi
 			},
 		}
 
+	case profileHTTPSJWKS:
+		// The types proto cannot represent the https_jwks profile, so it is
+		// left unset (see isHTTPSJWKS).
+
 	default:
 		return nil, fmt.Errorf("unknown bundle endpoint profile type: %q", fr.BundleEndpointProfile)
 	}
@@ -181,13 +187,39 @@ func printFederationRelationship(fr *types.FederationRelationship, printf func(f
 	case *types.FederationRelationship_HttpsSpiffe:
 		_ = printf("Bundle endpoint profile   : %s\n", "https_spiffe")
 		_ = printf("Endpoint SPIFFE ID        : %s\n", profile.HttpsSpiffe.EndpointSpiffeId)
+
+	case nil:
+		_ = printf("Bundle endpoint profile   : %s\n", "https_jwks")
+	}
+}
+
+// isHTTPSJWKS returns true if the relationship uses the https_jwks profile.
+// The trust domain API returns such relationships without a profile.
+func isHTTPSJWKS(fr *types.FederationRelationship) bool {
+	return fr.BundleEndpointProfile == nil
+}
+
+func printFederationRelationshipStatus(status *federationstatusv1.FederationRelationshipStatus, printf func(format string, args ...interface{}) error) {
+	_ = printf("Last successful refresh   : %s\n", formatStatusTime(status.LastSuccess, "never"))
+	_ = printf("Last refresh attempt      : %s\n", formatStatusTime(status.LastAttempt, "never"))
+	if status.LastError != "" {
+		_ = printf("Last refresh error        : %s\n", status.LastError)
+	}
+	_ = printf("Next scheduled refresh    : %s\n", formatStatusTime(status.NextRefresh, "not scheduled"))
+	_ = printf("Bundle sequence number    : %d\n", status.SequenceNumber)
+}
+
+func formatStatusTime(seconds int64, unset string) string {
+	if seconds == 0 {
+		return unset
 	}
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
 }
 
 func appendConfigFlags(config *federationRelationshipConfig, f *flag.FlagSet) {
 	f.StringVar(&config.TrustDomain, "trustDomain", "", `Name of the trust domain to federate with (e.g., example.org)`)
 	f.StringVar(&config.BundleEndpointURL, "bundleEndpointURL", "", "URL of the SPIFFE bundle endpoint that provides the trust bundle (must use the HTTPS protocol)")
-	f.StringVar(&config.BundleEndpointProfile, "bundleEndpointProfile", "", fmt.Sprintf("Endpoint profile type (either %q or %q)", profileHTTPSWeb, profileHTTPSSPIFFE))
+	f.StringVar(&config.BundleEndpointProfile, "bundleEndpointProfile", "", fmt.Sprintf("Endpoint profile type (either %q, %q or %q)", profileHTTPSWeb, profileHTTPSSPIFFE, profileHTTPSJWKS))
 	f.StringVar(&config.EndpointSPIFFEID, "endpointSpiffeID", "", "SPIFFE ID of the SPIFFE bundle endpoint server. Only used for 'spiffe' profile.")
 	f.StringVar(&config.TrustDomainBundlePath, "trustDomainBundlePath", "", "Path to the trust domain bundle data (optional).")
 	f.StringVar(&config.TrustDomainBundleFormat, "trustDomainBundleFormat", util.FormatPEM, fmt.Sprintf("The format of the bundle data (optional). Either %q or %q.", util.FormatPEM, util.FormatSPIFFE))
@@ -13,6 +13,8 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/cli/common"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
@@ -110,8 +112,9 @@ type cmdTest struct {
 	stdout *bytes.Buffer
 	stderr *bytes.Buffer
 
-	addr   string
-	server *fakeServer
+	addr       string
+	server     *fakeServer
+	jwksServer *fakeJWKSServer
 
 	client cli.Command
 }
@@ -129,6 +132,7 @@ func (c *cmdTest) args(extra ...string) []string {
 
 type fakeServer struct {
 	trustdomainv1.UnimplementedTrustDomainServer
+	federationstatusv1.UnimplementedFederationStatusServer
 
 	t   *testing.T
 	err error
@@ -146,6 +150,9 @@ type fakeServer struct {
 	showResp    *types.FederationRelationship
 	refreshResp *emptypb.Empty
 	updateResp  *trustdomainv1.BatchUpdateFederationRelationshipResponse
+
+	statusResp     *federationstatusv1.FederationRelationshipStatus
+	listStatusResp *federationstatusv1.ListFederationRelationshipStatusesResponse
 }
 
 func (f *fakeServer) BatchCreateFederationRelationship(ctx context.Context, req *trustdomainv1.BatchCreateFederationRelationshipRequest) (*trustdomainv1.BatchCreateFederationRelationshipResponse, error) {
@@ -205,6 +212,48 @@ func (f *fakeServer) BatchUpdateFederationRelationship(ctx context.Context, req
 	return f.updateResp, nil
 }
 
+func (f *fakeServer) ListFederationRelationshipStatuses(ctx context.Context, req *federationstatusv1.ListFederationRelationshipStatusesRequest) (*federationstatusv1.ListFederationRelationshipStatusesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.listStatusResp, nil
+}
+
+func (f *fakeServer) GetFederationRelationshipStatus(ctx context.Context, req *federationstatusv1.GetFederationRelationshipStatusRequest) (*federationstatusv1.FederationRelationshipStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.statusResp != nil {
+		require.Equal(f.t, f.statusResp.TrustDomain, req.TrustDomain)
+		return f.statusResp, nil
+	}
+	return nil, status.Error(codes.NotFound, "federation relationship is not managed by the server")
+}
+
+type fakeJWKSServer struct {
+	jwksfederationv1.UnimplementedJWKSFederationServer
+
+	t *testing.T
+
+	expectCreateReq *jwksfederationv1.BatchCreateFederationRelationshipRequest
+	expectUpdateReq *jwksfederationv1.BatchUpdateFederationRelationshipRequest
+
+	createResp *jwksfederationv1.BatchCreateFederationRelationshipResponse
+	updateResp *jwksfederationv1.BatchUpdateFederationRelationshipResponse
+}
+
+func (f *fakeJWKSServer) BatchCreateFederationRelationship(ctx context.Context, req *jwksfederationv1.BatchCreateFederationRelationshipRequest) (*jwksfederationv1.BatchCreateFederationRelationshipResponse, error) {
+	spiretest.AssertProtoEqual(f.t, f.expectCreateReq, req)
+	return f.createResp, nil
+}
+
+func (f *fakeJWKSServer) BatchUpdateFederationRelationship(ctx context.Context, req *jwksfederationv1.BatchUpdateFederationRelationshipRequest) (*jwksfederationv1.BatchUpdateFederationRelationshipResponse, error) {
+	spiretest.AssertProtoEqual(f.t, f.expectUpdateReq, req)
+	return f.updateResp, nil
+}
+
 func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *cmdTest {
 	stdin := new(bytes.Buffer)
 	stdout := new(bytes.Buffer)
@@ -217,17 +266,21 @@ func setupTest(t *testing.T, newClient func(*common_cli.Env) cli.Command) *cmdTe
 	})
 
 	server := &fakeServer{t: t}
+	jwksServer := &fakeJWKSServer{t: t}
 	addr := spiretest.StartGRPCServer(t, func(s *grpc.Server) {
 		trustdomainv1.RegisterTrustDomainServer(s, server)
+		federationstatusv1.RegisterFederationStatusServer(s, server)
+		jwksfederationv1.RegisterJWKSFederationServer(s, jwksServer)
 	})
 
 	test := &cmdTest{
-		addr:   common.GetAddr(addr),
-		stdin:  stdin,
-		stdout: stdout,
-		stderr: stderr,
-		server: server,
-		client: client,
+		addr:       common.GetAddr(addr),
+		stdin:      stdin,
+		stdout:     stdout,
+		stderr:     stderr,
+		server:     server,
+		jwksServer: jwksServer,
+		client:     client,
 	}
 
 	t.Cleanup(func() {
@@ -12,12 +12,17 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server/api"
+	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 	"google.golang.org/grpc/codes"
 )
 
 const (
 	profileHTTPSWeb    = "https_web"
 	profileHTTPSSPIFFE = "https_spiffe"
+	profileHTTPSJWKS   = "https_jwks"
 )
 
 // NewCreateCommand creates a new "create" subcommand for "federation" command.
@@ -31,6 +36,7 @@ func newCreateCommand(env *commoncli.Env) cli.Command {
 
 type createCommand struct {
 	path                    string
+	bootstrapFingerprint    string
 	config                  *federationRelationshipConfig
 	env                     *commoncli.Env
 	printer                 cliprinter.Printer
@@ -49,21 +55,28 @@ func (c *createCommand) AppendFlags(f *flag.FlagSet) {
 	f.StringVar(&c.path, "data", "", "Path to a file containing federation relationships in JSON format (optional). If set to '-', read the JSON from stdin.")
 	c.config = &federationRelationshipConfig{}
 	appendConfigFlags(c.config, f)
+	f.StringVar(&c.bootstrapFingerprint, "bootstrapFingerprint", "", "SHA-256 fingerprint of the certificate or public key of the SPIFFE bundle endpoint server (optional). If set, the trust domain bundle is fetched from the bundle endpoint, authenticating the server using the fingerprint. Only used for 'https_spiffe' profile.")
 	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, c.prettyPrintCreate)
 }
 
 func (c *createCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	if c.path != "" && c.bootstrapFingerprint != "" {
+		return errors.New("cannot use 'bootstrapFingerprint' flag when 'data' flag is set")
+	}
+
 	federationRelationships, err := getRelationships(c.config, c.path)
 	if err != nil {
 		return err
 	}
-	c.federationRelationships = federationRelationships
 
-	client := serverClient.NewTrustDomainClient()
+	if c.bootstrapFingerprint != "" {
+		if err := bootstrapBundle(ctx, federationRelationships[0], c.bootstrapFingerprint); err != nil {
+			return err
+		}
+	}
+	c.federationRelationships = federationRelationships
 
-	resp, err := client.BatchCreateFederationRelationship(ctx, &trustdomainv1.BatchCreateFederationRelationshipRequest{
-		FederationRelationships: federationRelationships,
-	})
+	resp, err := createFederationRelationships(ctx, serverClient, federationRelationships)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -71,6 +84,113 @@ func (c *createCommand) Run(ctx context.Context, env *commoncli.Env, serverClien
 	return c.printer.PrintProto(resp)
 }
 
+// createFederationRelationships creates the relationships using the
+// https_jwks profile through the JWKS federation API and the rest through
+// the trust domain API. The results are returned in the order of the
+// relationships.
+func createFederationRelationships(ctx context.Context, serverClient util.ServerClient, federationRelationships []*types.FederationRelationship) (*trustdomainv1.BatchCreateFederationRelationshipResponse, error) {
+	var relationships, jwksRelationships []*types.FederationRelationship
+	for _, fr := range federationRelationships {
+		if isHTTPSJWKS(fr) {
+			jwksRelationships = append(jwksRelationships, fr)
+		} else {
+			relationships = append(relationships, fr)
+		}
+	}
+
+	resp := &trustdomainv1.BatchCreateFederationRelationshipResponse{}
+	if len(relationships) > 0 {
+		var err error
+		resp, err = serverClient.NewTrustDomainClient().BatchCreateFederationRelationship(ctx, &trustdomainv1.BatchCreateFederationRelationshipRequest{
+			FederationRelationships: relationships,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(jwksRelationships) == 0 {
+		return resp, nil
+	}
+
+	jwksResp, err := serverClient.NewJWKSFederationClient().BatchCreateFederationRelationship(ctx, &jwksfederationv1.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: jwksRelationships,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(relationships) || len(jwksResp.Results) != len(jwksRelationships) {
+		return nil, errors.New("unexpected number of results")
+	}
+
+	results, jwksResults := resp.Results, jwksResp.Results
+	merged := &trustdomainv1.BatchCreateFederationRelationshipResponse{}
+	for _, fr := range federationRelationships {
+		if isHTTPSJWKS(fr) {
+			merged.Results = append(merged.Results, &trustdomainv1.BatchCreateFederationRelationshipResponse_Result{
+				Status:                 jwksResults[0].Status,
+				FederationRelationship: jwksResults[0].FederationRelationship,
+			})
+			jwksResults = jwksResults[1:]
+		} else {
+			merged.Results = append(merged.Results, results[0])
+			results = results[1:]
+		}
+	}
+	return merged, nil
+}
+
+// bootstrapBundle fetches the bundle of the federated trust domain from its
+// bundle endpoint, authenticating the endpoint server using the given
+// fingerprint, so it does not need to be exchanged out of band.
+func bootstrapBundle(ctx context.Context, fr *types.FederationRelationship, fingerprint string) error {
+	profile, ok := fr.BundleEndpointProfile.(*types.FederationRelationship_HttpsSpiffe)
+	if !ok {
+		return errors.New("bootstrap fingerprint can only be used with the 'https_spiffe' endpoint profile")
+	}
+	if fr.TrustDomainBundle != nil {
+		return errors.New("cannot use 'bootstrapFingerprint' and 'trustDomainBundlePath' flags together")
+	}
+
+	td, err := spiffeid.TrustDomainFromString(fr.TrustDomain)
+	if err != nil {
+		return fmt.Errorf("cannot parse trust domain: %w", err)
+	}
+	endpointID, err := spiffeid.FromString(profile.HttpsSpiffe.EndpointSpiffeId)
+	if err != nil {
+		return fmt.Errorf("cannot parse bundle endpoint SPIFFE ID: %w", err)
+	}
+	if endpointID.TrustDomain() != td {
+		return fmt.Errorf("cannot bootstrap trust domain bundle: endpoint SPIFFE ID %q is not a member of trust domain %q", endpointID, td)
+	}
+
+	fingerprintBytes, err := bundleClient.ParseFingerprint(fingerprint)
+	if err != nil {
+		return fmt.Errorf("cannot parse bootstrap fingerprint: %w", err)
+	}
+
+	client, err := bundleClient.NewClient(bundleClient.ClientConfig{
+		TrustDomain: td,
+		EndpointURL: fr.BundleEndpointUrl,
+		SPIFFEAuth: &bundleClient.SPIFFEAuthConfig{
+			EndpointSpiffeID:     endpointID,
+			BootstrapFingerprint: fingerprintBytes,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot bootstrap trust domain bundle: %w", err)
+	}
+	bundle, err := client.FetchBundle(ctx)
+	if err != nil {
+		return fmt.Errorf("cannot bootstrap trust domain bundle: %w", err)
+	}
+
+	fr.TrustDomainBundle, err = api.BundleToProto(bundle.Proto())
+	if err != nil {
+		return fmt.Errorf("cannot bootstrap trust domain bundle: %w", err)
+	}
+	return nil
+}
+
 func (c *createCommand) prettyPrintCreate(env *commoncli.Env, results ...interface{}) error {
 	createResp, ok := results[0].(*trustdomainv1.BatchCreateFederationRelationshipResponse)
 	if !ok || len(c.federationRelationships) < len(createResp.Results) {
@@ -1,16 +1,27 @@
 package federation
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/server/api"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/vishnusomank/go-spiffe/v2/bundle/spiffebundle"
@@ -30,6 +41,160 @@ func TestCreateSynopsis(t *testing.T) {
 	require.Equal(t, "Creates a dynamic federation relationship with a foreign trust domain", test.client.Synopsis())
 }
 
+func TestCreateBootstrap(t *testing.T) {
+	endpointID := spiffeid.RequireFromString("spiffe://td-5.org/bundle")
+	serverCert, serverKey := spiretest.SelfSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{endpointID.URL()},
+	})
+	fingerprint := sha256.Sum256(serverCert.Raw)
+
+	endpointBundle := bundleutil.BundleFromRootCA(endpointID.TrustDomain(), serverCert)
+	endpointBundle.SetRefreshHint(time.Minute)
+	bundleBytes, err := bundleutil.Marshal(endpointBundle)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write(bundleBytes)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	fr := &types.FederationRelationship{
+		TrustDomain:       "td-5.org",
+		BundleEndpointUrl: server.URL,
+		BundleEndpointProfile: &types.FederationRelationship_HttpsSpiffe{
+			HttpsSpiffe: &types.HTTPSSPIFFEProfile{
+				EndpointSpiffeId: endpointID.String(),
+			},
+		},
+		TrustDomainBundle: &types.Bundle{
+			TrustDomain:     "td-5.org",
+			RefreshHint:     60,
+			X509Authorities: []*types.X509Certificate{{Asn1: serverCert.Raw}},
+		},
+	}
+
+	test := setupTest(t, newCreateCommand)
+	test.server.expectCreateReq = &trustdomainv1.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{fr},
+	}
+	test.server.createResp = &trustdomainv1.BatchCreateFederationRelationshipResponse{
+		Results: []*trustdomainv1.BatchCreateFederationRelationshipResponse_Result{
+			{
+				Status:                 api.OK(),
+				FederationRelationship: fr,
+			},
+		},
+	}
+
+	rc := test.client.Run(test.args(
+		"-trustDomain", "td-5.org",
+		"-bundleEndpointURL", server.URL,
+		"-bundleEndpointProfile", profileHTTPSSPIFFE,
+		"-endpointSpiffeID", endpointID.String(),
+		"-bootstrapFingerprint", hex.EncodeToString(fingerprint[:]),
+	))
+	require.Equal(t, 0, rc, test.stderr.String())
+
+	// Bootstrapping fails if the endpoint server does not match the fingerprint
+	test = setupTest(t, newCreateCommand)
+	rc = test.client.Run(test.args(
+		"-trustDomain", "td-5.org",
+		"-bundleEndpointURL", server.URL,
+		"-bundleEndpointProfile", profileHTTPSSPIFFE,
+		"-endpointSpiffeID", endpointID.String(),
+		"-bootstrapFingerprint", strings.Repeat("ab", sha256.Size),
+	))
+	require.Equal(t, 1, rc)
+	require.Contains(t, test.stderr.String(), "Error: cannot bootstrap trust domain bundle: failed to fetch bundle:")
+	require.Contains(t, test.stderr.String(), "server certificate does not match the bootstrap fingerprint")
+}
+
+func TestCreateHTTPSJWKS(t *testing.T) {
+	frJWKS1 := &types.FederationRelationship{
+		TrustDomain:       "td-1.org",
+		BundleEndpointUrl: "https://oidc.td-1.org",
+	}
+	frWeb := &types.FederationRelationship{
+		TrustDomain:           "td-2.org",
+		BundleEndpointUrl:     "https://td-2.org/bundle",
+		BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{},
+	}
+	frJWKS3 := &types.FederationRelationship{
+		TrustDomain:       "td-3.org",
+		BundleEndpointUrl: "https://oidc.td-3.org",
+	}
+
+	jsonDataFilePath := createJSONDataFile(t, `{
+    "federationRelationships": [
+        {
+            "trustDomain": "td-1.org",
+            "bundleEndpointURL": "https://oidc.td-1.org",
+            "bundleEndpointProfile": "https_jwks"
+        },
+        {
+            "trustDomain": "td-2.org",
+            "bundleEndpointURL": "https://td-2.org/bundle",
+            "bundleEndpointProfile": "https_web"
+        },
+        {
+            "trustDomain": "td-3.org",
+            "bundleEndpointURL": "https://oidc.td-3.org",
+            "bundleEndpointProfile": "https_jwks"
+        }
+    ]
+}`)
+
+	test := setupTest(t, newCreateCommand)
+	test.server.expectCreateReq = &trustdomainv1.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{frWeb},
+	}
+	test.server.createResp = &trustdomainv1.BatchCreateFederationRelationshipResponse{
+		Results: []*trustdomainv1.BatchCreateFederationRelationshipResponse_Result{
+			{Status: api.OK(), FederationRelationship: frWeb},
+		},
+	}
+	test.jwksServer.expectCreateReq = &jwksfederationv1.BatchCreateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{frJWKS1, frJWKS3},
+	}
+	test.jwksServer.createResp = &jwksfederationv1.BatchCreateFederationRelationshipResponse{
+		Results: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+			{Status: api.OK(), FederationRelationship: frJWKS1},
+			{Status: &types.Status{Code: int32(codes.AlreadyExists), Message: "the message"}},
+		},
+	}
+
+	rc := test.client.Run(test.args("-data", jsonDataFilePath))
+	require.Equal(t, 1, rc)
+	require.Equal(t, `
+Trust domain              : td-1.org
+Bundle endpoint URL       : https://oidc.td-1.org
+Bundle endpoint profile   : https_jwks
+
+Trust domain              : td-2.org
+Bundle endpoint URL       : https://td-2.org/bundle
+Bundle endpoint profile   : https_web
+
+`, test.stdout.String())
+	require.Equal(t, `Failed to create the following federation relationship (code: AlreadyExists, msg: "the message"):
+Trust domain              : td-3.org
+Bundle endpoint URL       : https://oidc.td-3.org
+Bundle endpoint profile   : https_jwks
+Error: failed to create one or more federation relationships
+`, test.stderr.String())
+}
+
 func TestCreate(t *testing.T) {
 	frWeb := &types.FederationRelationship{
 		TrustDomain:           "td-1.org",
@@ -61,6 +226,8 @@ func TestCreate(t *testing.T) {
 
 	corruptedBundlePath := createCorruptedBundle(t)
 
+	fingerprint := strings.Repeat("ab", sha256.Size)
+
 	jsonDataFilePath := createJSONDataFile(t, testFile)
 
 	jsonDataInvalidRelationship := createJSONDataFile(t, `
@@ -185,6 +352,36 @@ func TestCreate(t *testing.T) {
 			expErrPretty: "Error: cannot parse bundle file: unable to parse bundle data: no PEM blocks\n",
 			expErrJSON:   "Error: cannot parse bundle file: unable to parse bundle data: no PEM blocks\n",
 		},
+		{
+			name:         "Bootstrap fingerprint with web profile",
+			args:         []string{"-trustDomain", "td.org", "-bundleEndpointURL", "https://td.org/bundle", "-bundleEndpointProfile", profileHTTPSWeb, "-bootstrapFingerprint", fingerprint},
+			expErrPretty: "Error: bootstrap fingerprint can only be used with the 'https_spiffe' endpoint profile\n",
+			expErrJSON:   "Error: bootstrap fingerprint can only be used with the 'https_spiffe' endpoint profile\n",
+		},
+		{
+			name:         "Bootstrap fingerprint and bundle",
+			args:         []string{"-trustDomain", "td-3.org", "-bundleEndpointURL", "https://td-3.org/bundle", "-endpointSpiffeID", "spiffe://td-3.org/bundle", "-trustDomainBundlePath", bundlePath, "-bundleEndpointProfile", profileHTTPSSPIFFE, "-bootstrapFingerprint", fingerprint},
+			expErrPretty: "Error: cannot use 'bootstrapFingerprint' and 'trustDomainBundlePath' flags together\n",
+			expErrJSON:   "Error: cannot use 'bootstrapFingerprint' and 'trustDomainBundlePath' flags together\n",
+		},
+		{
+			name:         "Bootstrap fingerprint for endpoint in another trust domain",
+			args:         []string{"-trustDomain", "td-2.org", "-bundleEndpointURL", "https://td-2.org/bundle", "-endpointSpiffeID", "spiffe://other.org/bundle", "-bundleEndpointProfile", profileHTTPSSPIFFE, "-bootstrapFingerprint", fingerprint},
+			expErrPretty: "Error: cannot bootstrap trust domain bundle: endpoint SPIFFE ID \"spiffe://other.org/bundle\" is not a member of trust domain \"td-2.org\"\n",
+			expErrJSON:   "Error: cannot bootstrap trust domain bundle: endpoint SPIFFE ID \"spiffe://other.org/bundle\" is not a member of trust domain \"td-2.org\"\n",
+		},
+		{
+			name:         "Invalid bootstrap fingerprint",
+			args:         []string{"-trustDomain", "td-3.org", "-bundleEndpointURL", "https://td-3.org/bundle", "-endpointSpiffeID", "spiffe://td-3.org/bundle", "-bundleEndpointProfile", profileHTTPSSPIFFE, "-bootstrapFingerprint", "abcd"},
+			expErrPretty: "Error: cannot parse bootstrap fingerprint: fingerprint \"abcd\" is not a hex encoded SHA-256 hash\n",
+			expErrJSON:   "Error: cannot parse bootstrap fingerprint: fingerprint \"abcd\" is not a hex encoded SHA-256 hash\n",
+		},
+		{
+			name:         "Bootstrap fingerprint with JSON file",
+			args:         []string{"-data", jsonDataFilePath, "-bootstrapFingerprint", fingerprint},
+			expErrPretty: "Error: cannot use 'bootstrapFingerprint' flag when 'data' flag is set\n",
+			expErrJSON:   "Error: cannot use 'bootstrapFingerprint' flag when 'data' flag is set\n",
+		},
 		{
 			name:         "Server error",
 			args:         []string{"-trustDomain", "td.org", "-bundleEndpointURL", "https://td.org/bundle", "-bundleEndpointProfile", "https_web"},
@@ -12,6 +12,9 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func NewShowCommand() cli.Command {
@@ -27,6 +30,9 @@ type showCommand struct {
 	trustDomain string
 	env         *commoncli.Env
 	printer     cliprinter.Printer
+
+	// Bundle refresh status of the federation relationship, if managed
+	status *federationstatusv1.FederationRelationshipStatus
 }
 
 func (c *showCommand) Name() string {
@@ -56,6 +62,18 @@ func (c *showCommand) Run(ctx context.Context, env *commoncli.Env, serverClient
 		return fmt.Errorf("error showing federation relationship: %w", err)
 	}
 
+	c.status, err = serverClient.NewFederationStatusClient().GetFederationRelationshipStatus(ctx, &federationstatusv1.GetFederationRelationshipStatusRequest{
+		TrustDomain: c.trustDomain,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound, codes.Unimplemented:
+		// The relationship is not managed by the server yet, or the server
+		// does not report statuses.
+	default:
+		return fmt.Errorf("error fetching federation relationship status: %w", err)
+	}
+
 	return c.printer.PrintProto(fr)
 }
 
@@ -66,6 +84,9 @@ func (c *showCommand) prettyPrintShow(env *commoncli.Env, results ...interface{}
 	}
 	env.Printf("Found a federation relationship with trust domain %s:\n\n", c.trustDomain)
 	printFederationRelationship(fr, env.Printf)
+	if c.status != nil {
+		printFederationRelationshipStatus(c.status, env.Printf)
+	}
 
 	return nil
 }
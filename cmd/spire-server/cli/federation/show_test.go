@@ -6,6 +6,7 @@ import (
 
 	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -45,9 +46,10 @@ func TestShow(t *testing.T) {
 		name string
 		args []string
 
-		req       *trustdomainv1.GetFederationRelationshipRequest
-		resp      *types.FederationRelationship
-		serverErr error
+		req        *trustdomainv1.GetFederationRelationshipRequest
+		resp       *types.FederationRelationship
+		statusResp *federationstatusv1.FederationRelationshipStatus
+		serverErr  error
 
 		expectedStdoutPretty string
 		expectedStdoutJSON   string
@@ -95,6 +97,36 @@ Endpoint SPIFFE ID        : spiffe://endpoint.test/id
     "refresh_hint": "0",
     "sequence_number": "0"
   }
+}`,
+		},
+		{
+			name: "succeeds with status",
+			req:  &trustdomainv1.GetFederationRelationshipRequest{},
+			resp: fr1,
+			statusResp: &federationstatusv1.FederationRelationshipStatus{
+				TrustDomain:    "example-1.test",
+				LastSuccess:    1666000000,
+				LastAttempt:    1666000300,
+				LastError:      "oh no",
+				NextRefresh:    1666000600,
+				SequenceNumber: 3,
+			},
+			args: []string{"-trustDomain", "example-1.test"},
+			expectedStdoutPretty: `Found a federation relationship with trust domain example-1.test:
+
+Trust domain              : example-1.test
+Bundle endpoint URL       : https://bundle-endpoint-1.test/endpoint
+Bundle endpoint profile   : https_web
+Last successful refresh   : 2022-10-17T09:46:40Z
+Last refresh attempt      : 2022-10-17T09:51:40Z
+Last refresh error        : oh no
+Next scheduled refresh    : 2022-10-17T09:56:40Z
+Bundle sequence number    : 3
+`,
+			expectedStdoutJSON: `{
+  "trust_domain": "example-1.test",
+  "bundle_endpoint_url": "https://bundle-endpoint-1.test/endpoint",
+  "https_web": {}
 }`,
 		},
 		{
@@ -118,6 +150,7 @@ Endpoint SPIFFE ID        : spiffe://endpoint.test/id
 				test.server.err = tt.serverErr
 				test.server.expectShowReq = tt.req
 				test.server.showResp = tt.resp
+				test.server.statusResp = tt.statusResp
 				args := tt.args
 				args = append(args, "-output", format)
 
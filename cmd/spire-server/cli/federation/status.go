@@ -0,0 +1,84 @@
+package federation
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	commoncli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+)
+
+func NewStatusCommand() cli.Command {
+	return newStatusCommand(commoncli.DefaultEnv)
+}
+
+func newStatusCommand(env *commoncli.Env) cli.Command {
+	return util.AdaptCommand(env, &statusCommand{env: env})
+}
+
+type statusCommand struct {
+	// Trust domain name of the federation relationship to show the status of
+	trustDomain string
+	env         *commoncli.Env
+	printer     cliprinter.Printer
+}
+
+func (c *statusCommand) Name() string {
+	return "federation status"
+}
+
+func (c *statusCommand) Synopsis() string {
+	return "Shows the bundle refresh status of federation relationships"
+}
+
+func (c *statusCommand) AppendFlags(f *flag.FlagSet) {
+	f.StringVar(&c.trustDomain, "trustDomain", "", "The trust domain name of the federation relationship to show the status of. If unset, the status of all the federation relationships is shown")
+	cliprinter.AppendFlagWithCustomPretty(&c.printer, f, c.env, prettyPrintStatus)
+}
+
+func (c *statusCommand) Run(ctx context.Context, env *commoncli.Env, serverClient util.ServerClient) error {
+	client := serverClient.NewFederationStatusClient()
+
+	if c.trustDomain != "" {
+		status, err := client.GetFederationRelationshipStatus(ctx, &federationstatusv1.GetFederationRelationshipStatusRequest{
+			TrustDomain: c.trustDomain,
+		})
+		if err != nil {
+			return fmt.Errorf("error showing federation relationship status: %w", err)
+		}
+		return c.printer.PrintProto(status)
+	}
+
+	resp, err := client.ListFederationRelationshipStatuses(ctx, &federationstatusv1.ListFederationRelationshipStatusesRequest{})
+	if err != nil {
+		return fmt.Errorf("error listing federation relationship statuses: %w", err)
+	}
+	return c.printer.PrintProto(resp)
+}
+
+func prettyPrintStatus(env *commoncli.Env, results ...interface{}) error {
+	switch r := results[0].(type) {
+	case *federationstatusv1.FederationRelationshipStatus:
+		printStatus(r, env.Printf)
+	case *federationstatusv1.ListFederationRelationshipStatusesResponse:
+		msg := fmt.Sprintf("Found %v ", len(r.Statuses))
+		msg = util.Pluralizer(msg, "federation relationship status", "federation relationship statuses", len(r.Statuses))
+		env.Println(msg)
+		for _, status := range r.Statuses {
+			env.Println()
+			printStatus(status, env.Printf)
+		}
+	default:
+		return cliprinter.ErrInternalCustomPrettyFunc
+	}
+	return nil
+}
+
+func printStatus(status *federationstatusv1.FederationRelationshipStatus, printf func(format string, args ...interface{}) error) {
+	_ = printf("Trust domain              : %s\n", status.TrustDomain)
+	printFederationRelationshipStatus(status, printf)
+}
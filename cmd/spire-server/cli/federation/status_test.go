@@ -0,0 +1,152 @@
+package federation
+
+import (
+	"fmt"
+	"testing"
+
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusHelp(t *testing.T) {
+	test := setupTest(t, newStatusCommand)
+	test.client.Help()
+
+	require.Equal(t, statusUsage, test.stderr.String())
+}
+
+func TestStatusSynopsis(t *testing.T) {
+	test := setupTest(t, newStatusCommand)
+	require.Equal(t, "Shows the bundle refresh status of federation relationships", test.client.Synopsis())
+}
+
+func TestStatus(t *testing.T) {
+	status1 := &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain:    "example-1.test",
+		LastSuccess:    1666000000,
+		LastAttempt:    1666000300,
+		LastError:      "oh no",
+		NextRefresh:    1666000600,
+		SequenceNumber: 3,
+	}
+	status2 := &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain: "example-2.test",
+	}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+
+		statusResp     *federationstatusv1.FederationRelationshipStatus
+		listStatusResp *federationstatusv1.ListFederationRelationshipStatusesResponse
+		serverErr      error
+
+		expectedStdoutPretty string
+		expectedStdoutJSON   string
+		expectedStderr       string
+	}{
+		{
+			name: "list",
+			listStatusResp: &federationstatusv1.ListFederationRelationshipStatusesResponse{
+				Statuses: []*federationstatusv1.FederationRelationshipStatus{status1, status2},
+			},
+			expectedStdoutPretty: `Found 2 federation relationship statuses
+
+Trust domain              : example-1.test
+Last successful refresh   : 2022-10-17T09:46:40Z
+Last refresh attempt      : 2022-10-17T09:51:40Z
+Last refresh error        : oh no
+Next scheduled refresh    : 2022-10-17T09:56:40Z
+Bundle sequence number    : 3
+
+Trust domain              : example-2.test
+Last successful refresh   : never
+Last refresh attempt      : never
+Next scheduled refresh    : not scheduled
+Bundle sequence number    : 0
+`,
+			expectedStdoutJSON: `{
+  "statuses": [
+    {
+      "trust_domain": "example-1.test",
+      "last_success": "1666000000",
+      "last_attempt": "1666000300",
+      "last_error": "oh no",
+      "next_refresh": "1666000600",
+      "sequence_number": "3"
+    },
+    {
+      "trust_domain": "example-2.test",
+      "last_success": "0",
+      "last_attempt": "0",
+      "last_error": "",
+      "next_refresh": "0",
+      "sequence_number": "0"
+    }
+  ]
+}`,
+		},
+		{
+			name:           "list empty",
+			listStatusResp: &federationstatusv1.ListFederationRelationshipStatusesResponse{},
+			expectedStdoutPretty: `Found 0 federation relationship statuses
+`,
+			expectedStdoutJSON: `{
+  "statuses": []
+}`,
+		},
+		{
+			name:       "single trust domain",
+			args:       []string{"-trustDomain", "example-1.test"},
+			statusResp: status1,
+			expectedStdoutPretty: `Trust domain              : example-1.test
+Last successful refresh   : 2022-10-17T09:46:40Z
+Last refresh attempt      : 2022-10-17T09:51:40Z
+Last refresh error        : oh no
+Next scheduled refresh    : 2022-10-17T09:56:40Z
+Bundle sequence number    : 3
+`,
+			expectedStdoutJSON: `{
+  "trust_domain": "example-1.test",
+  "last_success": "1666000000",
+  "last_attempt": "1666000300",
+  "last_error": "oh no",
+  "next_refresh": "1666000600",
+  "sequence_number": "3"
+}`,
+		},
+		{
+			name:           "single trust domain not managed",
+			args:           []string{"-trustDomain", "example-1.test"},
+			expectedStderr: "Error: error showing federation relationship status: rpc error: code = NotFound desc = federation relationship is not managed by the server\n",
+		},
+		{
+			name:           "list fails",
+			serverErr:      status.Error(codes.Internal, "oh! no"),
+			expectedStderr: "Error: error listing federation relationship statuses: rpc error: code = Internal desc = oh! no\n",
+		},
+	} {
+		tt := tt
+		for _, format := range availableFormats {
+			format := format
+			t.Run(fmt.Sprintf("%s using %s format", tt.name, format), func(t *testing.T) {
+				test := setupTest(t, newStatusCommand)
+				test.server.err = tt.serverErr
+				test.server.statusResp = tt.statusResp
+				test.server.listStatusResp = tt.listStatusResp
+				args := append(tt.args, "-output", format)
+
+				rc := test.client.Run(test.args(args...))
+				if tt.expectedStderr != "" {
+					require.Equal(t, 1, rc)
+					require.Equal(t, tt.expectedStderr, test.stderr.String())
+					return
+				}
+				require.Equal(t, 0, rc)
+				requireOutputBasedOnFormat(t, format, test.stdout.String(), tt.expectedStdoutPretty, tt.expectedStdoutJSON)
+			})
+		}
+	}
+}
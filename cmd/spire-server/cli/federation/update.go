@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"google.golang.org/grpc/codes"
 )
 
@@ -54,11 +55,7 @@ func (c *updateCommand) Run(ctx context.Context, env *commoncli.Env, serverClien
 	}
 	c.federationRelationships = federationRelationships
 
-	client := serverClient.NewTrustDomainClient()
-
-	resp, err := client.BatchUpdateFederationRelationship(ctx, &trustdomainv1.BatchUpdateFederationRelationshipRequest{
-		FederationRelationships: c.federationRelationships,
-	})
+	resp, err := updateFederationRelationships(ctx, serverClient, federationRelationships)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
@@ -66,6 +63,61 @@ func (c *updateCommand) Run(ctx context.Context, env *commoncli.Env, serverClien
 	return c.printer.PrintProto(resp)
 }
 
+// updateFederationRelationships updates the relationships using the
+// https_jwks profile through the JWKS federation API and the rest through
+// the trust domain API. The results are returned in the order of the
+// relationships.
+func updateFederationRelationships(ctx context.Context, serverClient util.ServerClient, federationRelationships []*types.FederationRelationship) (*trustdomainv1.BatchUpdateFederationRelationshipResponse, error) {
+	var relationships, jwksRelationships []*types.FederationRelationship
+	for _, fr := range federationRelationships {
+		if isHTTPSJWKS(fr) {
+			jwksRelationships = append(jwksRelationships, fr)
+		} else {
+			relationships = append(relationships, fr)
+		}
+	}
+
+	resp := &trustdomainv1.BatchUpdateFederationRelationshipResponse{}
+	if len(relationships) > 0 {
+		var err error
+		resp, err = serverClient.NewTrustDomainClient().BatchUpdateFederationRelationship(ctx, &trustdomainv1.BatchUpdateFederationRelationshipRequest{
+			FederationRelationships: relationships,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(jwksRelationships) == 0 {
+		return resp, nil
+	}
+
+	jwksResp, err := serverClient.NewJWKSFederationClient().BatchUpdateFederationRelationship(ctx, &jwksfederationv1.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: jwksRelationships,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(relationships) || len(jwksResp.Results) != len(jwksRelationships) {
+		return nil, errors.New("unexpected number of results")
+	}
+
+	results, jwksResults := resp.Results, jwksResp.Results
+	merged := &trustdomainv1.BatchUpdateFederationRelationshipResponse{}
+	for _, fr := range federationRelationships {
+		if isHTTPSJWKS(fr) {
+			merged.Results = append(merged.Results, &trustdomainv1.BatchUpdateFederationRelationshipResponse_Result{
+				Status:                 jwksResults[0].Status,
+				FederationRelationship: jwksResults[0].FederationRelationship,
+			})
+			jwksResults = jwksResults[1:]
+		} else {
+			merged.Results = append(merged.Results, results[0])
+			results = results[1:]
+		}
+	}
+	return merged, nil
+}
+
 func (c *updateCommand) prettyPrintUpdate(env *commoncli.Env, results ...interface{}) error {
 	updateResp, ok := results[0].(*trustdomainv1.BatchUpdateFederationRelationshipResponse)
 	if !ok || len(c.federationRelationships) < len(updateResp.Results) {
@@ -11,6 +11,7 @@ import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/server/api"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/vishnusomank/go-spiffe/v2/bundle/spiffebundle"
@@ -30,6 +31,35 @@ func TestUpdateSynopsis(t *testing.T) {
 	require.Equal(t, "Updates a dynamic federation relationship with a foreign trust domain", test.client.Synopsis())
 }
 
+func TestUpdateHTTPSJWKS(t *testing.T) {
+	frJWKS := &types.FederationRelationship{
+		TrustDomain:       "td-1.org",
+		BundleEndpointUrl: "https://oidc.td-1.org",
+	}
+
+	test := setupTest(t, newUpdateCommand)
+	test.jwksServer.expectUpdateReq = &jwksfederationv1.BatchUpdateFederationRelationshipRequest{
+		FederationRelationships: []*types.FederationRelationship{frJWKS},
+	}
+	test.jwksServer.updateResp = &jwksfederationv1.BatchUpdateFederationRelationshipResponse{
+		Results: []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+			{Status: api.OK(), FederationRelationship: frJWKS},
+		},
+	}
+
+	rc := test.client.Run(test.args(
+		"-trustDomain", "td-1.org",
+		"-bundleEndpointURL", "https://oidc.td-1.org",
+		"-bundleEndpointProfile", profileHTTPSJWKS,
+	))
+	require.Equal(t, 0, rc, test.stderr.String())
+	require.Equal(t, `
+Trust domain              : td-1.org
+Bundle endpoint URL       : https://oidc.td-1.org
+Bundle endpoint profile   : https_jwks
+`, test.stdout.String())
+}
+
 func TestUpdate(t *testing.T) {
 	frWeb := &types.FederationRelationship{
 		TrustDomain:           "td-1.org",
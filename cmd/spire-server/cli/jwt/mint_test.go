@@ -33,16 +33,6 @@ qNV3lKIL59N7G2B4ojbhfSNneSIIpP448uPxUnaunaQZ+/m7+x9oobIp
 -----END PRIVATE KEY-----
 `))
 	availableFormats = []string{"pretty", "json"}
-	expectedUsage    = `Usage of jwt mint:
-  -audience value
-    	Audience claim that will be included in the SVID. Can be used more than once.` + common.AddrOutputUsage +
-		`  -spiffeID string
-    	SPIFFE ID of the JWT-SVID
-  -ttl duration
-    	TTL of the JWT-SVID
-  -write string
-    	File to write token to instead of stdout
-`
 )
 
 func TestMintSynopsis(t *testing.T) {
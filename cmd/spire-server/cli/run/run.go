@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"errors"
 	"flag"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -29,8 +31,10 @@ import (
 	"github.com/spiffe/spire/pkg/common/fflag"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
@@ -65,27 +69,29 @@ type Config struct {
 }
 
 type serverConfig struct {
-	AdminIDs           []string           `hcl:"admin_ids"`
-	AgentTTL           string             `hcl:"agent_ttl"`
-	AuditLogEnabled    bool               `hcl:"audit_log_enabled"`
-	BindAddress        string             `hcl:"bind_address"`
-	BindPort           int                `hcl:"bind_port"`
-	CAKeyType          string             `hcl:"ca_key_type"`
-	CASubject          *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL              string             `hcl:"ca_ttl"`
-	DataDir            string             `hcl:"data_dir"`
-	DefaultX509SVIDTTL string             `hcl:"default_x509_svid_ttl"`
-	DefaultJWTSVIDTTL  string             `hcl:"default_jwt_svid_ttl"`
-	Experimental       experimentalConfig `hcl:"experimental"`
-	Federation         *federationConfig  `hcl:"federation"`
-	JWTIssuer          string             `hcl:"jwt_issuer"`
-	JWTKeyType         string             `hcl:"jwt_key_type"`
-	LogFile            string             `hcl:"log_file"`
-	LogLevel           string             `hcl:"log_level"`
-	LogFormat          string             `hcl:"log_format"`
-	RateLimit          rateLimitConfig    `hcl:"ratelimit"`
-	SocketPath         string             `hcl:"socket_path"`
-	TrustDomain        string             `hcl:"trust_domain"`
+	AdminIDs           []string                     `hcl:"admin_ids"`
+	AgentTTL           string                       `hcl:"agent_ttl"`
+	AuditLog           *auditLogConfig              `hcl:"audit_log"`
+	AuditLogEnabled    bool                         `hcl:"audit_log_enabled"`
+	BindAddress        string                       `hcl:"bind_address"`
+	BindPort           int                          `hcl:"bind_port"`
+	CAKeyType          string                       `hcl:"ca_key_type"`
+	CASubject          *caSubjectConfig             `hcl:"ca_subject"`
+	CATTL              string                       `hcl:"ca_ttl"`
+	DataDir            string                       `hcl:"data_dir"`
+	DefaultX509SVIDTTL string                       `hcl:"default_x509_svid_ttl"`
+	DefaultJWTSVIDTTL  string                       `hcl:"default_jwt_svid_ttl"`
+	Experimental       experimentalConfig           `hcl:"experimental"`
+	Federation         *federationConfig            `hcl:"federation"`
+	JWTIssuer          string                       `hcl:"jwt_issuer"`
+	JWTKeyType         string                       `hcl:"jwt_key_type"`
+	LogFile            string                       `hcl:"log_file"`
+	LogLevel           string                       `hcl:"log_level"`
+	LogFormat          string                       `hcl:"log_format"`
+	RateLimit          rateLimitConfig              `hcl:"ratelimit"`
+	RoleBindings       map[string]roleBindingConfig `hcl:"role_binding"`
+	SocketPath         string                       `hcl:"socket_path"`
+	TrustDomain        string                       `hcl:"trust_domain"`
 
 	ConfigPath string
 	ExpandEnv  bool
@@ -100,8 +106,13 @@ type serverConfig struct {
 }
 
 type experimentalConfig struct {
-	AuthOpaPolicyEngine *authpolicy.OpaEngineConfig `hcl:"auth_opa_policy_engine"`
-	CacheReloadInterval string                      `hcl:"cache_reload_interval"`
+	AuthOpaPolicyEngine   *authpolicy.OpaEngineConfig `hcl:"auth_opa_policy_engine"`
+	CacheReloadInterval   string                      `hcl:"cache_reload_interval"`
+	EntryHistoryRetention string                      `hcl:"entry_history_retention"`
+	EventsRetention       string                      `hcl:"events_retention"`
+
+	PruneAttestedNodesExpiredFor  string `hcl:"prune_attested_nodes_expired_for"`
+	PruneNonReattestableNodesOnly bool   `hcl:"prune_non_reattestable_nodes_only"`
 
 	Flags fflag.RawConfig `hcl:"feature_flags"`
 
@@ -117,6 +128,42 @@ type caSubjectConfig struct {
 	UnusedKeys   []string `hcl:",unusedKeys"`
 }
 
+type auditLogConfig struct {
+	File       *auditLogFileConfig    `hcl:"file"`
+	HashChain  bool                   `hcl:"hash_chain"`
+	Syslog     *auditLogSyslogConfig  `hcl:"syslog"`
+	Webhook    *auditLogWebhookConfig `hcl:"webhook"`
+	UnusedKeys []string               `hcl:",unusedKeys"`
+}
+
+type auditLogFileConfig struct {
+	Path       string   `hcl:"path"`
+	MaxSizeMB  int      `hcl:"max_size_mb"`
+	MaxBackups int      `hcl:"max_backups"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditLogSyslogConfig struct {
+	Network    string   `hcl:"network"`
+	Address    string   `hcl:"address"`
+	Tag        string   `hcl:"tag"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type auditLogWebhookConfig struct {
+	URL        string   `hcl:"url"`
+	Timeout    string   `hcl:"timeout"`
+	UnusedKeys []string `hcl:",unusedKeys"`
+}
+
+type roleBindingConfig struct {
+	Role               string   `hcl:"role"`
+	SPIFFEID           string   `hcl:"spiffe_id"`
+	EntryID            string   `hcl:"entry_id"`
+	SPIFFEIDPathPrefix string   `hcl:"spiffe_id_path_prefix"`
+	UnusedKeys         []string `hcl:",unusedKeys"`
+}
+
 type federationConfig struct {
 	BundleEndpoint *bundleEndpointConfig          `hcl:"bundle_endpoint"`
 	FederatesWith  map[string]federatesWithConfig `hcl:"federates_with"`
@@ -131,11 +178,14 @@ type bundleEndpointConfig struct {
 }
 
 type bundleEndpointACMEConfig struct {
-	DirectoryURL string   `hcl:"directory_url"`
-	DomainName   string   `hcl:"domain_name"`
-	Email        string   `hcl:"email"`
-	ToSAccepted  bool     `hcl:"tos_accepted"`
-	UnusedKeys   []string `hcl:",unusedKeys"`
+	DirectoryURL          string   `hcl:"directory_url"`
+	DirectoryCABundlePath string   `hcl:"directory_ca_bundle_path"`
+	DomainName            string   `hcl:"domain_name"`
+	Email                 string   `hcl:"email"`
+	ToSAccepted           bool     `hcl:"tos_accepted"`
+	EABKeyID              string   `hcl:"eab_key_id"`
+	EABHMACKey            string   `hcl:"eab_hmac_key"`
+	UnusedKeys            []string `hcl:",unusedKeys"`
 }
 
 type federatesWithConfig struct {
@@ -147,17 +197,22 @@ type federatesWithConfig struct {
 type bundleEndpointProfileConfig struct {
 	HTTPSSPIFFE *httpsSPIFFEProfileConfig `hcl:"https_spiffe"`
 	HTTPSWeb    *httpsWebProfileConfig    `hcl:"https_web"`
+	HTTPSJWKS   *httpsJWKSProfileConfig   `hcl:"https_jwks"`
 	UnusedKeys  []string                  `hcl:",unusedKeys"`
 }
 
 type httpsSPIFFEProfileConfig struct {
-	EndpointSPIFFEID string   `hcl:"endpoint_spiffe_id"`
-	UnusedKeys       []string `hcl:",unusedKeys"`
+	EndpointSPIFFEID     string   `hcl:"endpoint_spiffe_id"`
+	BootstrapFingerprint string   `hcl:"bootstrap_fingerprint"`
+	UnusedKeys           []string `hcl:",unusedKeys"`
 }
 
 type httpsWebProfileConfig struct {
 }
 
+type httpsJWKSProfileConfig struct {
+}
+
 type rateLimitConfig struct {
 	Attestation *bool    `hcl:"attestation"`
 	Signing     *bool    `hcl:"signing"`
@@ -388,6 +443,17 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 	sc.DataDir = c.Server.DataDir
 	sc.AuditLogEnabled = c.Server.AuditLogEnabled
 
+	if c.Server.AuditLog != nil {
+		sinks, err := auditLogSinksFromConfig(c.Server.AuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("could not configure audit_log: %w", err)
+		}
+		if !sc.AuditLogEnabled {
+			logger.Warn("The audit_log section is ignored unless audit_log_enabled is set to true")
+		}
+		sc.AuditLogSinks = sinks
+	}
+
 	td, err := spiffeid.TrustDomainFromString(c.Server.TrustDomain)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse trust_domain %q: %w", c.Server.TrustDomain, err)
@@ -421,6 +487,25 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 					CacheDir:     filepath.Join(sc.DataDir, "bundle-acme"),
 					Email:        acme.Email,
 					ToSAccepted:  acme.ToSAccepted,
+					EABKeyID:     acme.EABKeyID,
+				}
+
+				if acme.EABHMACKey != "" {
+					// The MAC key is handed out by ACME servers base64url
+					// encoded, with or without padding.
+					hmacKey, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(acme.EABHMACKey, "="))
+					if err != nil {
+						return nil, fmt.Errorf("could not decode federation.bundle_endpoint.acme.eab_hmac_key: %w", err)
+					}
+					sc.Federation.BundleEndpoint.ACME.EABHMACKey = hmacKey
+				}
+
+				if acme.DirectoryCABundlePath != "" {
+					rootCAs, err := pemutil.LoadCertificates(acme.DirectoryCABundlePath)
+					if err != nil {
+						return nil, fmt.Errorf("could not load federation.bundle_endpoint.acme.directory_ca_bundle_path: %w", err)
+					}
+					sc.Federation.BundleEndpoint.ACME.DirectoryRootCAs = rootCAs
 				}
 			}
 		}
@@ -461,6 +546,36 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.AdminIDs = append(sc.AdminIDs, id)
 	}
 
+	// Sort the bindings by name so they are evaluated in a stable order
+	roleBindingNames := make([]string, 0, len(c.Server.RoleBindings))
+	for name := range c.Server.RoleBindings {
+		roleBindingNames = append(roleBindingNames, name)
+	}
+	sort.Strings(roleBindingNames)
+
+	var roleBindings []authpolicy.RoleBinding
+	for _, name := range roleBindingNames {
+		rb := c.Server.RoleBindings[name]
+		roleBinding := authpolicy.RoleBinding{
+			Name:               name,
+			Role:               rb.Role,
+			EntryID:            rb.EntryID,
+			SPIFFEIDPathPrefix: rb.SPIFFEIDPathPrefix,
+		}
+		if rb.SPIFFEID != "" {
+			id, err := spiffeid.FromString(rb.SPIFFEID)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse SPIFFE ID %q of role binding %q: %w", rb.SPIFFEID, name, err)
+			}
+			roleBinding.SPIFFEID = id
+		}
+		roleBindings = append(roleBindings, roleBinding)
+	}
+	sc.Roles, err = authpolicy.NewRoles(roleBindings)
+	if err != nil {
+		return nil, fmt.Errorf("could not configure role bindings: %w", err)
+	}
+
 	if c.Server.AgentTTL != "" {
 		ttl, err := time.ParseDuration(c.Server.AgentTTL)
 		if err != nil {
@@ -619,6 +734,31 @@ func NewServerConfig(c *Config, logOptions []log.Option, allowUnknownConfig bool
 		sc.CacheReloadInterval = interval
 	}
 
+	if c.Server.Experimental.EventsRetention != "" {
+		retention, err := time.ParseDuration(c.Server.Experimental.EventsRetention)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse events retention: %w", err)
+		}
+		sc.EventsRetention = retention
+	}
+
+	if c.Server.Experimental.EntryHistoryRetention != "" {
+		retention, err := time.ParseDuration(c.Server.Experimental.EntryHistoryRetention)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse entry history retention: %w", err)
+		}
+		sc.EntryHistoryRetention = retention
+	}
+
+	if c.Server.Experimental.PruneAttestedNodesExpiredFor != "" {
+		expiredFor, err := time.ParseDuration(c.Server.Experimental.PruneAttestedNodesExpiredFor)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse prune attested nodes expired for: %w", err)
+		}
+		sc.PruneAttestedNodesExpiredFor = expiredFor
+	}
+	sc.PruneNonReattestableNodesOnly = c.Server.Experimental.PruneNonReattestableNodesOnly
+
 	sc.AuthOpaPolicyEngineConfig = c.Server.Experimental.AuthOpaPolicyEngine
 
 	for _, f := range c.Server.Experimental.Flags {
@@ -658,9 +798,19 @@ func parseBundleEndpointProfile(config federatesWithConfig) (trustDomainConfig *
 		if err != nil {
 			return nil, fmt.Errorf("could not get endpoint SPIFFE ID: %w", err)
 		}
-		endpointProfile = bundleClient.HTTPSSPIFFEProfile{EndpointSPIFFEID: spiffeID}
+		if profileConfig.HTTPSSPIFFE.BootstrapFingerprint != "" {
+			if _, err := bundleClient.ParseFingerprint(profileConfig.HTTPSSPIFFE.BootstrapFingerprint); err != nil {
+				return nil, fmt.Errorf("could not parse bootstrap fingerprint: %w", err)
+			}
+		}
+		endpointProfile = bundleClient.HTTPSSPIFFEProfile{
+			EndpointSPIFFEID:     spiffeID,
+			BootstrapFingerprint: profileConfig.HTTPSSPIFFE.BootstrapFingerprint,
+		}
+	case profileConfig.HTTPSJWKS != nil:
+		endpointProfile = bundleClient.HTTPSJWKSProfile{}
 	default:
-		return nil, errors.New(`no bundle endpoint profile defined; current supported profiles are "https_spiffe" and 'https_web"`)
+		return nil, errors.New(`no bundle endpoint profile defined; current supported profiles are "https_spiffe", "https_web" and "https_jwks"`)
 	}
 
 	return &bundleClient.TrustDomainConfig{
@@ -669,6 +819,52 @@ func parseBundleEndpointProfile(config federatesWithConfig) (trustDomainConfig *
 	}, nil
 }
 
+func auditLogSinksFromConfig(c *auditLogConfig) (*audit.SinksConfig, error) {
+	sinks := &audit.SinksConfig{
+		HashChain: c.HashChain,
+	}
+
+	if c.File != nil {
+		if c.File.Path == "" {
+			return nil, errors.New("file sink path must be configured")
+		}
+		sinks.File = &audit.FileSinkConfig{
+			Path:       c.File.Path,
+			MaxSizeMB:  c.File.MaxSizeMB,
+			MaxBackups: c.File.MaxBackups,
+		}
+	}
+
+	if c.Syslog != nil {
+		sinks.Syslog = &audit.SyslogSinkConfig{
+			Network: c.Syslog.Network,
+			Address: c.Syslog.Address,
+			Tag:     c.Syslog.Tag,
+		}
+	}
+
+	if c.Webhook != nil {
+		if c.Webhook.URL == "" {
+			return nil, errors.New("webhook sink url must be configured")
+		}
+		sinks.Webhook = &audit.WebhookSinkConfig{
+			URL: c.Webhook.URL,
+		}
+		if c.Webhook.Timeout != "" {
+			timeout, err := time.ParseDuration(c.Webhook.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse webhook sink timeout: %w", err)
+			}
+			sinks.Webhook.Timeout = timeout
+		}
+	}
+
+	if sinks.File == nil && sinks.Syslog == nil && sinks.Webhook == nil {
+		return nil, errors.New("at least one sink must be configured")
+	}
+	return sinks, nil
+}
+
 func validateConfig(c *Config) error {
 	if c.Server == nil {
 		return errors.New("server section must be configured")
@@ -702,6 +898,10 @@ func validateConfig(c *Config) error {
 			if acme.Email == "" {
 				return errors.New("federation.bundle_endpoint.acme.email must be configured")
 			}
+
+			if (acme.EABKeyID == "") != (acme.EABHMACKey == "") {
+				return errors.New("federation.bundle_endpoint.acme.eab_key_id and federation.bundle_endpoint.acme.eab_hmac_key must be configured together")
+			}
 		}
 
 		for td, tdConfig := range c.Server.Federation.FederatesWith {
@@ -743,6 +943,27 @@ func checkForUnknownConfig(c *Config, l logrus.FieldLogger) (err error) {
 			detectedUnknown("ratelimit", rl.UnusedKeys)
 		}
 
+		if al := c.Server.AuditLog; al != nil {
+			if len(al.UnusedKeys) != 0 {
+				detectedUnknown("audit_log", al.UnusedKeys)
+			}
+			if al.File != nil && len(al.File.UnusedKeys) != 0 {
+				detectedUnknown("audit_log file", al.File.UnusedKeys)
+			}
+			if al.Syslog != nil && len(al.Syslog.UnusedKeys) != 0 {
+				detectedUnknown("audit_log syslog", al.Syslog.UnusedKeys)
+			}
+			if al.Webhook != nil && len(al.Webhook.UnusedKeys) != 0 {
+				detectedUnknown("audit_log webhook", al.Webhook.UnusedKeys)
+			}
+		}
+
+		for name, rb := range c.Server.RoleBindings {
+			if len(rb.UnusedKeys) != 0 {
+				detectedUnknown(fmt.Sprintf("role_binding %q", name), rb.UnusedKeys)
+			}
+		}
+
 		// TODO: Re-enable unused key detection for experimental config. See
 		// https://github.com/spiffe/spire/issues/1101 for more information
 		//
@@ -2,6 +2,7 @@ package run
 
 import (
 	"crypto/x509/pkix"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -16,6 +17,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
@@ -63,6 +65,16 @@ func TestParseConfigGood(t *testing.T) {
 	_, ok := trustDomainConfig.EndpointProfile.(bundleClient.HTTPSWebProfile)
 	assert.True(t, ok)
 	assert.True(t, c.Server.AuditLogEnabled)
+	require.NotNil(t, c.Server.AuditLog)
+	assert.True(t, c.Server.AuditLog.HashChain)
+	require.NotNil(t, c.Server.AuditLog.File)
+	assert.Equal(t, 50, c.Server.AuditLog.File.MaxSizeMB)
+	assert.Nil(t, c.Server.AuditLog.Syslog)
+	assert.Equal(t, &auditLogWebhookConfig{URL: "https://audit.example.org/records", Timeout: "10s"}, c.Server.AuditLog.Webhook)
+	assert.Equal(t, map[string]roleBindingConfig{
+		"ci":    {Role: "entry-writer", SPIFFEID: "spiffe://example.org/ci", SPIFFEIDPathPrefix: "/ci"},
+		"audit": {Role: "auditor", EntryID: "auditor-entry"},
+	}, c.Server.RoleBindings)
 	testParseConfigGoodOS(t, c)
 
 	// Parse/reprint cycle trims outer whitespace
@@ -598,6 +610,63 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, 1337, c.Federation.BundleEndpoint.Address.Port)
 			},
 		},
+		{
+			msg: "bundle endpoint ACME external account binding and directory CA bundle are parsed and configured correctly",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						Address: "192.168.1.1",
+						Port:    1337,
+						ACME: &bundleEndpointACMEConfig{
+							DomainName:            "example.org",
+							Email:                 "admin@example.org",
+							EABKeyID:              "kid-1",
+							EABHMACKey:            "aG1hYy1rZXk",
+							DirectoryCABundlePath: "../../../../test/fixture/certs/ca.pem",
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				acme := c.Federation.BundleEndpoint.ACME
+				require.Equal(t, "kid-1", acme.EABKeyID)
+				require.Equal(t, []byte("hmac-key"), acme.EABHMACKey)
+				require.Len(t, acme.DirectoryRootCAs, 1)
+			},
+		},
+		{
+			msg:         "bundle endpoint ACME external account binding MAC key must be base64url encoded",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ACME: &bundleEndpointACMEConfig{
+							EABKeyID:   "kid-1",
+							EABHMACKey: "not base64",
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "bundle endpoint ACME directory CA bundle must exist",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ACME: &bundleEndpointACMEConfig{
+							DirectoryCABundlePath: "non-existent-path",
+						},
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle federates with section is parsed and configured correctly",
 			input: func(c *Config) {
@@ -605,6 +674,7 @@ func TestNewServerConfig(t *testing.T) {
 					FederatesWith: map[string]federatesWithConfig{
 						"domain1.test": httpsSPIFFEConfigTest(t),
 						"domain2.test": webPKIConfigTest(t),
+						"domain3.test": jwksConfigTest(t),
 					},
 				}
 			},
@@ -620,9 +690,48 @@ func TestNewServerConfig(t *testing.T) {
 						EndpointURL:     "https://192.168.1.1:1337",
 						EndpointProfile: bundleClient.HTTPSWebProfile{},
 					},
+					spiffeid.RequireTrustDomainFromString("domain3.test"): {
+						EndpointURL:     "https://issuer.test/.well-known/openid-configuration",
+						EndpointProfile: bundleClient.HTTPSJWKSProfile{},
+					},
+				}, c.Federation.FederatesWith)
+			},
+		},
+		{
+			msg: "bootstrap fingerprint is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": httpsSPIFFEBootstrapConfigTest(t, "95:5e:ab:49:a1:2c:85:8f:5c:2c:f0:68:46:13:3a:bc:25:9a:e8:bc:3d:ac:24:fb:95:3b:df:c2:bc:0d:c0:26"),
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, map[spiffeid.TrustDomain]bundleClient.TrustDomainConfig{
+					spiffeid.RequireTrustDomainFromString("domain1.test"): {
+						EndpointURL: "https://192.168.1.1:1337",
+						EndpointProfile: bundleClient.HTTPSSPIFFEProfile{
+							EndpointSPIFFEID:     spiffeid.RequireFromString("spiffe://domain1.test/bundle/endpoint"),
+							BootstrapFingerprint: "95:5e:ab:49:a1:2c:85:8f:5c:2c:f0:68:46:13:3a:bc:25:9a:e8:bc:3d:ac:24:fb:95:3b:df:c2:bc:0d:c0:26",
+						},
+					},
 				}, c.Federation.FederatesWith)
 			},
 		},
+		{
+			msg:         "invalid bootstrap fingerprint",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					FederatesWith: map[string]federatesWithConfig{
+						"domain1.test": httpsSPIFFEBootstrapConfigTest(t, "abcd"),
+					},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "default_x509_svid_ttl is correctly parsed",
 			input: func(c *Config) {
@@ -927,6 +1036,74 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "events_retention is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Experimental.EventsRetention = "48h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 48*time.Hour, c.EventsRetention)
+			},
+		},
+		{
+			msg:         "invalid events_retention returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.EventsRetention = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "entry_history_retention is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Experimental.EntryHistoryRetention = "168h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 168*time.Hour, c.EntryHistoryRetention)
+			},
+		},
+		{
+			msg:         "invalid entry_history_retention returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.EntryHistoryRetention = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "attested node pruning is correctly parsed",
+			input: func(c *Config) {
+				c.Server.Experimental.PruneAttestedNodesExpiredFor = "720h"
+				c.Server.Experimental.PruneNonReattestableNodesOnly = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 720*time.Hour, c.PruneAttestedNodesExpiredFor)
+				require.True(t, c.PruneNonReattestableNodesOnly)
+			},
+		},
+		{
+			msg: "attested node pruning is disabled by default",
+			input: func(c *Config) {
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Zero(t, c.PruneAttestedNodesExpiredFor)
+				require.False(t, c.PruneNonReattestableNodesOnly)
+			},
+		},
+		{
+			msg:         "invalid prune_attested_nodes_expired_for returns an error",
+			expectError: true,
+			input: func(c *Config) {
+				c.Server.Experimental.PruneAttestedNodesExpiredFor = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "audit_log_enabled is enabled",
 			input: func(c *Config) {
@@ -945,6 +1122,59 @@ func TestNewServerConfig(t *testing.T) {
 				require.False(t, c.AuditLogEnabled)
 			},
 		},
+		{
+			msg: "audit_log sinks are set",
+			input: func(c *Config) {
+				c.Server.AuditLogEnabled = true
+				c.Server.AuditLog = &auditLogConfig{
+					HashChain: true,
+					File:      &auditLogFileConfig{Path: "audit.log", MaxSizeMB: 10, MaxBackups: 3},
+					Syslog:    &auditLogSyslogConfig{Network: "udp", Address: "localhost:514", Tag: "spire"},
+					Webhook:   &auditLogWebhookConfig{URL: "https://audit.example.org", Timeout: "10s"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, &audit.SinksConfig{
+					File:      &audit.FileSinkConfig{Path: "audit.log", MaxSizeMB: 10, MaxBackups: 3},
+					Syslog:    &audit.SyslogSinkConfig{Network: "udp", Address: "localhost:514", Tag: "spire"},
+					Webhook:   &audit.WebhookSinkConfig{URL: "https://audit.example.org", Timeout: 10 * time.Second},
+					HashChain: true,
+				}, c.AuditLogSinks)
+			},
+		},
+		{
+			msg: "audit_log without sinks",
+			input: func(c *Config) {
+				c.Server.AuditLogEnabled = true
+				c.Server.AuditLog = &auditLogConfig{HashChain: true}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "audit_log file sink without path",
+			input: func(c *Config) {
+				c.Server.AuditLogEnabled = true
+				c.Server.AuditLog = &auditLogConfig{File: &auditLogFileConfig{}}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "audit_log webhook sink with invalid timeout",
+			input: func(c *Config) {
+				c.Server.AuditLogEnabled = true
+				c.Server.AuditLog = &auditLogConfig{Webhook: &auditLogWebhookConfig{URL: "https://audit.example.org", Timeout: "soon"}}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "admin IDs are set",
 			input: func(c *Config) {
@@ -974,6 +1204,46 @@ func TestNewServerConfig(t *testing.T) {
 				}, c.AdminIDs)
 			},
 		},
+		{
+			msg: "role bindings are set",
+			input: func(c *Config) {
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"ci":    {Role: "entry-writer", SPIFFEID: "spiffe://example.org/ci", SPIFFEIDPathPrefix: "/ci"},
+					"audit": {Role: "auditor", EntryID: "auditor-entry"},
+				}
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.False(t, c.Roles.Empty())
+				require.True(t, c.Roles.HasEntryBindings())
+				require.Equal(t, []spiffeid.ID{
+					spiffeid.RequireFromString("spiffe://example.org/ci"),
+				}, c.Roles.SPIFFEIDs())
+			},
+		},
+		{
+			msg: "role binding with invalid SPIFFE ID",
+			input: func(c *Config) {
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"audit": {Role: "auditor", SPIFFEID: "not-an-id"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "role binding with unknown role",
+			input: func(c *Config) {
+				c.Server.RoleBindings = map[string]roleBindingConfig{
+					"ci": {Role: "superuser", SPIFFEID: "spiffe://example.org/ci"},
+				}
+			},
+			expectError: true,
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 	}
 	cases = append(cases, newServerConfigCasesOS()...)
 
@@ -1075,6 +1345,21 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: "federation.bundle_endpoint.acme.email must be configured",
 		},
+		{
+			name: "if ACME external account binding is used, both the key ID and the MAC key must be configured",
+			applyConf: func(c *Config) {
+				c.Server.Federation = &federationConfig{
+					BundleEndpoint: &bundleEndpointConfig{
+						ACME: &bundleEndpointACMEConfig{
+							DomainName: "domain-name",
+							Email:      "admin@domain-name",
+							EABKeyID:   "kid-1",
+						},
+					},
+				}
+			},
+			expectedErr: "federation.bundle_endpoint.acme.eab_key_id and federation.bundle_endpoint.acme.eab_hmac_key must be configured together",
+		},
 		{
 			name: "bundle_endpoint_url must be configured if federates_with is configured",
 			applyConf: func(c *Config) {
@@ -1721,6 +2006,18 @@ func httpsSPIFFEConfigTest(t *testing.T) federatesWithConfig {
 	return *httpsSPIFFEConfig
 }
 
+func httpsSPIFFEBootstrapConfigTest(t *testing.T, fingerprint string) federatesWithConfig {
+	configString := fmt.Sprintf(`bundle_endpoint_url = "https://192.168.1.1:1337"
+	bundle_endpoint_profile "https_spiffe" {
+		endpoint_spiffe_id = "spiffe://domain1.test/bundle/endpoint"
+		bootstrap_fingerprint = %q
+	}`, fingerprint)
+	httpsSPIFFEConfig := new(federatesWithConfig)
+	require.NoError(t, hcl.Decode(httpsSPIFFEConfig, configString))
+
+	return *httpsSPIFFEConfig
+}
+
 func webPKIConfigTest(t *testing.T) federatesWithConfig {
 	configString := `bundle_endpoint_url = "https://192.168.1.1:1337"
 		bundle_endpoint_profile "https_web" {}`
@@ -1729,3 +2026,12 @@ func webPKIConfigTest(t *testing.T) federatesWithConfig {
 
 	return *webPKIConfig
 }
+
+func jwksConfigTest(t *testing.T) federatesWithConfig {
+	configString := `bundle_endpoint_url = "https://issuer.test/.well-known/openid-configuration"
+		bundle_endpoint_profile "https_jwks" {}`
+	jwksConfig := new(federatesWithConfig)
+	require.NoError(t, hcl.Decode(jwksConfig, configString))
+
+	return *jwksConfig
+}
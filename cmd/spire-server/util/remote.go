@@ -0,0 +1,143 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/vishnusomank/go-spiffe/v2/bundle/x509bundle"
+	"github.com/vishnusomank/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/vishnusomank/go-spiffe/v2/svid/x509svid"
+	"github.com/vishnusomank/go-spiffe/v2/workloadapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	remoteDialTimeout = 30 * time.Second
+)
+
+// remoteFlags are the flags used to administer a server through its TCP API
+// instead of the local API socket.
+type remoteFlags struct {
+	serverAddr string
+	certPath   string
+	keyPath    string
+	bundlePath string
+}
+
+func (a *Adapter) addRemoteFlags(flags *flag.FlagSet) {
+	flags.StringVar(&a.serverAddr, "serverAddr", "", "Address (host:port) of the SPIRE Server TCP API. If set, the server is dialed over mTLS instead of through the local API socket")
+	flags.StringVar(&a.certPath, "certPath", "", "Path to the PEM-encoded X509-SVID used to authenticate to the server when -serverAddr is set. If unset, the X509-SVID is obtained from the Workload API")
+	flags.StringVar(&a.keyPath, "keyPath", "", "Path to the PEM-encoded private key of the X509-SVID set with -certPath")
+	flags.StringVar(&a.bundlePath, "bundlePath", "", "Path to the PEM-encoded bundle used to authenticate the server when the X509-SVID is set with -certPath")
+}
+
+// newServerClient dials the server through the local API socket or, if a
+// server address is set, through its TCP API.
+func (a *Adapter) newServerClient(ctx context.Context) (ServerClient, error) {
+	if a.serverAddr == "" {
+		if a.certPath != "" || a.keyPath != "" || a.bundlePath != "" {
+			return nil, errors.New("-certPath, -keyPath and -bundlePath can only be used with -serverAddr")
+		}
+
+		addr, err := a.getAddr()
+		if err != nil {
+			return nil, err
+		}
+		return NewServerClient(addr)
+	}
+
+	switch {
+	case a.certPath == "" && a.keyPath == "" && a.bundlePath == "":
+		options, err := a.workloadAPIOptions()
+		if err != nil {
+			return nil, err
+		}
+		x509Context, err := workloadapi.FetchX509Context(ctx, nil, options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch X509-SVID from the Workload API: %w", err)
+		}
+		return NewRemoteServerClient(ctx, a.serverAddr, x509Context.DefaultSVID(), x509Context.Bundles)
+	case a.certPath == "" || a.keyPath == "" || a.bundlePath == "":
+		return nil, errors.New("-certPath, -keyPath and -bundlePath must be set together")
+	default:
+		svid, err := x509svid.Load(a.certPath, a.keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load X509-SVID: %w", err)
+		}
+		bundle, err := x509bundle.Load(svid.ID.TrustDomain(), a.bundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bundle: %w", err)
+		}
+		return NewRemoteServerClient(ctx, a.serverAddr, svid, bundle)
+	}
+}
+
+// NewRemoteServerClient dials the TCP API of the server at addr over mTLS,
+// authenticating with the given X509-SVID. The server is expected to present
+// the SPIRE Server ID of the trust domain of the X509-SVID, which the caller
+// must be an admin of. The dial fails if the server cannot be reached before
+// the context is done or the dial times out.
+func NewRemoteServerClient(ctx context.Context, addr string, svid *x509svid.SVID, bundleSource x509bundle.Source) (ServerClient, error) {
+	serverID, err := idutil.ServerID(svid.ID.TrustDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := tlsconfig.MTLSClientConfig(svid, bundleSource, tlsconfig.AuthorizeID(serverID))
+	ctx, cancel := context.WithTimeout(ctx, remoteDialTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr,
+		grpc.WithTransportCredentials(failFastCredentials{TransportCredentials: credentials.NewTLS(tlsConfig)}),
+		grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithReturnConnectionError())
+	switch {
+	case err == nil:
+		return &serverClient{conn: conn}, nil
+	case errors.Is(err, context.Canceled):
+		return nil, fmt.Errorf("failed to dial %s: canceled", addr)
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, fmt.Errorf("failed to dial %s: timed out", addr)
+	default:
+		return nil, err
+	}
+}
+
+// failFastCredentials fails the dial when the TLS handshake fails, e.g.
+// because the server is not authorized, instead of retrying it until the
+// dial is canceled.
+type failFastCredentials struct {
+	credentials.TransportCredentials
+}
+
+func (c failFastCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, authInfo, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	if err != nil {
+		return nil, nil, handshakeError{err: err}
+	}
+	return conn, authInfo, nil
+}
+
+type handshakeError struct {
+	err error
+}
+
+func (e handshakeError) Error() string {
+	return e.err.Error()
+}
+
+func (e handshakeError) Unwrap() error {
+	return e.err
+}
+
+// Temporary returns false so gRPC does not retry the handshake.
+func (e handshakeError) Temporary() bool {
+	return false
+}
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	common_cli "github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"github.com/vishnusomank/go-spiffe/v2/spiffetls/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var (
+	td       = spiffeid.RequireTrustDomainFromString("example.org")
+	adminID  = spiffeid.RequireFromPath(td, "/admin")
+	serverID = spiffeid.RequireFromPath(td, "/spire/server")
+)
+
+func TestRemoteServerClient(t *testing.T) {
+	ca := testca.New(t, td)
+	certPath, keyPath, bundlePath := writeCredentials(t, ca)
+
+	for _, tt := range []struct {
+		name     string
+		serverID spiffeid.ID
+		args     []string
+		expErr   string
+	}{
+		{
+			name:     "success",
+			serverID: serverID,
+			args:     []string{"-certPath", certPath, "-keyPath", keyPath, "-bundlePath", bundlePath},
+		},
+		{
+			name:     "unexpected server ID",
+			serverID: spiffeid.RequireFromPath(td, "/not-the-server"),
+			args:     []string{"-certPath", certPath, "-keyPath", keyPath, "-bundlePath", bundlePath},
+			expErr:   `Error: connection error: desc = "transport: authentication handshake failed: unexpected ID \"spiffe://example.org/not-the-server\""` + "\n",
+		},
+		{
+			name:     "missing bundle",
+			serverID: serverID,
+			args:     []string{"-certPath", certPath, "-keyPath", keyPath},
+			expErr:   "Error: -certPath, -keyPath and -bundlePath must be set together\n",
+		},
+		{
+			name:     "invalid X509-SVID",
+			serverID: serverID,
+			args:     []string{"-certPath", bundlePath, "-keyPath", keyPath, "-bundlePath", bundlePath},
+			expErr:   "Error: failed to load X509-SVID: x509svid: certificate validation failed: cannot get leaf certificate SPIFFE ID: certificate contains no URI SAN\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTLSServer(t, ca, tt.serverID)
+
+			stderr, rc := runHealthCheck(t, append([]string{"-serverAddr", addr}, tt.args...)...)
+			if tt.expErr != "" {
+				require.Equal(t, 1, rc)
+				require.Equal(t, tt.expErr, stderr)
+				return
+			}
+			require.Empty(t, stderr)
+			require.Equal(t, 0, rc)
+		})
+	}
+}
+
+func TestRemoteServerClientDialTimesOut(t *testing.T) {
+	ca := testca.New(t, td)
+
+	// Connections are never accepted, so the TLS handshake never completes.
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = NewRemoteServerClient(ctx, listener.Addr().String(), ca.CreateX509SVID(adminID), ca.X509Bundle())
+	require.EqualError(t, err, fmt.Sprintf("failed to dial %s: timed out", listener.Addr()))
+}
+
+func TestRemoteFlagsRequireServerAddr(t *testing.T) {
+	stderr, rc := runHealthCheck(t, "-certPath", "cert.pem", "-keyPath", "key.pem", "-bundlePath", "bundle.pem")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "Error: -certPath, -keyPath and -bundlePath can only be used with -serverAddr\n", stderr)
+}
+
+type healthCheckCommand struct{}
+
+func (healthCheckCommand) Name() string { return "healthcheck" }
+
+func (healthCheckCommand) Synopsis() string { return "" }
+
+func (healthCheckCommand) AppendFlags(*flag.FlagSet) {}
+
+func (healthCheckCommand) Run(ctx context.Context, _ *common_cli.Env, serverClient ServerClient) error {
+	_, err := serverClient.NewHealthClient().Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func runHealthCheck(t *testing.T, args ...string) (string, int) {
+	stderr := new(bytes.Buffer)
+	env := &common_cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: new(bytes.Buffer),
+		Stderr: stderr,
+	}
+	rc := AdaptCommand(env, healthCheckCommand{}).Run(args)
+	return stderr.String(), rc
+}
+
+func writeCredentials(t *testing.T, ca *testca.CA) (string, string, string) {
+	certPEM, keyPEM, err := ca.CreateX509SVID(adminID).Marshal()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	bundlePath := filepath.Join(dir, "bundle.pem")
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+	require.NoError(t, os.WriteFile(bundlePath, pemutil.EncodeCertificates(ca.X509Authorities()), 0600))
+	return certPath, keyPath, bundlePath
+}
+
+func startTLSServer(t *testing.T, ca *testca.CA, id spiffeid.ID) string {
+	tlsConfig := tlsconfig.MTLSServerConfig(ca.CreateX509SVID(id), ca.X509Bundle(), tlsconfig.AuthorizeID(adminID))
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
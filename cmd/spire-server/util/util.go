@@ -11,12 +11,20 @@ import (
 
 	agentv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/agent/v1"
 	bundlev1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/bundle/v1"
+	debugv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/debug/v1"
 	entryv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/entry/v1"
 	svidv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/svid/v1"
 	trustdomainv1 "github.com/spiffe/spire-api-sdk/proto/spire/api/server/trustdomain/v1"
 	api_types "github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	common_cli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/vishnusomank/go-spiffe/v2/bundle/spiffebundle"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 	"google.golang.org/grpc"
@@ -43,8 +51,16 @@ func Dial(addr net.Addr) (*grpc.ClientConn, error) {
 type ServerClient interface {
 	Release()
 	NewAgentClient() agentv1.AgentClient
+	NewAgentAdminClient() agentadminv1.AgentAdminClient
 	NewBundleClient() bundlev1.BundleClient
+	NewDebugClient() debugv1.DebugClient
+	NewDiagnosticsClient() diagnosticsv1.DiagnosticsClient
 	NewEntryClient() entryv1.EntryClient
+	NewEntryHistoryClient() entryhistoryv1.EntryHistoryClient
+	NewEventClient() eventv1.EventClient
+	NewExplainClient() explainv1.ExplainClient
+	NewFederationStatusClient() federationstatusv1.FederationStatusClient
+	NewJWKSFederationClient() jwksfederationv1.JWKSFederationClient
 	NewSVIDClient() svidv1.SVIDClient
 	NewTrustDomainClient() trustdomainv1.TrustDomainClient
 	NewHealthClient() grpc_health_v1.HealthClient
@@ -70,14 +86,46 @@ func (c *serverClient) NewAgentClient() agentv1.AgentClient {
 	return agentv1.NewAgentClient(c.conn)
 }
 
+func (c *serverClient) NewAgentAdminClient() agentadminv1.AgentAdminClient {
+	return agentadminv1.NewAgentAdminClient(c.conn)
+}
+
 func (c *serverClient) NewBundleClient() bundlev1.BundleClient {
 	return bundlev1.NewBundleClient(c.conn)
 }
 
+func (c *serverClient) NewDebugClient() debugv1.DebugClient {
+	return debugv1.NewDebugClient(c.conn)
+}
+
+func (c *serverClient) NewDiagnosticsClient() diagnosticsv1.DiagnosticsClient {
+	return diagnosticsv1.NewDiagnosticsClient(c.conn)
+}
+
 func (c *serverClient) NewEntryClient() entryv1.EntryClient {
 	return entryv1.NewEntryClient(c.conn)
 }
 
+func (c *serverClient) NewEntryHistoryClient() entryhistoryv1.EntryHistoryClient {
+	return entryhistoryv1.NewEntryHistoryClient(c.conn)
+}
+
+func (c *serverClient) NewEventClient() eventv1.EventClient {
+	return eventv1.NewEventClient(c.conn)
+}
+
+func (c *serverClient) NewExplainClient() explainv1.ExplainClient {
+	return explainv1.NewExplainClient(c.conn)
+}
+
+func (c *serverClient) NewFederationStatusClient() federationstatusv1.FederationStatusClient {
+	return federationstatusv1.NewFederationStatusClient(c.conn)
+}
+
+func (c *serverClient) NewJWKSFederationClient() jwksfederationv1.JWKSFederationClient {
+	return jwksfederationv1.NewJWKSFederationClient(c.conn)
+}
+
 func (c *serverClient) NewSVIDClient() svidv1.SVIDClient {
 	return svidv1.NewSVIDClient(c.conn)
 }
@@ -119,6 +167,7 @@ type Adapter struct {
 
 	flags *flag.FlagSet
 
+	remoteFlags
 	adapterOS // OS specific
 }
 
@@ -132,6 +181,7 @@ func AdaptCommand(env *common_cli.Env, cmd Command) *Adapter {
 	f := flag.NewFlagSet(cmd.Name(), flag.ContinueOnError)
 	f.SetOutput(env.Stderr)
 	a.addOSFlags(f)
+	a.addRemoteFlags(f)
 	a.cmd.AppendFlags(f)
 	a.flags = f
 
@@ -145,13 +195,7 @@ func (a *Adapter) Run(args []string) int {
 		return 1
 	}
 
-	addr, err := a.getAddr()
-	if err != nil {
-		fmt.Fprintln(a.env.Stderr, "Error: "+err.Error())
-		return 1
-	}
-
-	client, err := NewServerClient(addr)
+	client, err := a.newServerClient(ctx)
 	if err != nil {
 		fmt.Fprintln(a.env.Stderr, "Error: "+err.Error())
 		return 1
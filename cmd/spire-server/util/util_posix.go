@@ -9,14 +9,32 @@ import (
 	"net"
 
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/vishnusomank/go-spiffe/v2/workloadapi"
 )
 
 type adapterOS struct {
-	socketPath string
+	socketPath        string
+	workloadAPISocket string
 }
 
 func (a *Adapter) addOSFlags(flags *flag.FlagSet) {
 	flags.StringVar(&a.socketPath, "socketPath", DefaultSocketPath, "Path to the SPIRE Server API socket")
+	flags.StringVar(&a.workloadAPISocket, "workloadAPISocket", "", "Path to the Workload API socket used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable")
+}
+
+func (a *Adapter) workloadAPIOptions() ([]workloadapi.ClientOption, error) {
+	if a.workloadAPISocket == "" {
+		return nil, nil
+	}
+	addr, err := util.GetUnixAddrWithAbsPath(a.workloadAPISocket)
+	if err != nil {
+		return nil, err
+	}
+	target, err := util.GetTargetName(addr)
+	if err != nil {
+		return nil, err
+	}
+	return []workloadapi.ClientOption{workloadapi.WithAddr(target)}, nil
 }
 
 func (a *Adapter) getAddr() (net.Addr, error) {
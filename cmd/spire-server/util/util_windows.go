@@ -10,14 +10,24 @@ import (
 
 	"github.com/Microsoft/go-winio"
 	"github.com/spiffe/spire/pkg/common/namedpipe"
+	"github.com/vishnusomank/go-spiffe/v2/workloadapi"
 )
 
 type adapterOS struct {
-	namedPipeName string
+	namedPipeName            string
+	workloadAPINamedPipeName string
 }
 
 func (a *Adapter) addOSFlags(flags *flag.FlagSet) {
 	flags.StringVar(&a.namedPipeName, "namedPipeName", DefaultNamedPipeName, "Pipe name of the SPIRE Server API named pipe")
+	flags.StringVar(&a.workloadAPINamedPipeName, "workloadAPINamedPipeName", "", "Pipe name of the Workload API named pipe used to obtain the X509-SVID to authenticate to the server with when -serverAddr is set. Defaults to the SPIFFE_ENDPOINT_SOCKET environment variable")
+}
+
+func (a *Adapter) workloadAPIOptions() ([]workloadapi.ClientOption, error) {
+	if a.workloadAPINamedPipeName == "" {
+		return nil, nil
+	}
+	return []workloadapi.ClientOption{workloadapi.WithNamedPipeName(a.workloadAPINamedPipeName)}, nil
 }
 
 func dialer(ctx context.Context, addr string) (net.Conn, error) {
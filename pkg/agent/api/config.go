@@ -8,6 +8,7 @@ import (
 	attestor "github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 )
 
@@ -18,6 +19,8 @@ type Config struct {
 
 	Log logrus.FieldLogger
 
+	Metrics telemetry.Metrics
+
 	// Agent trust domain
 	TrustDomain spiffeid.TrustDomain
 
@@ -0,0 +1,63 @@
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/diagnostics"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/agent/diagnostics/v1"
+)
+
+// RegisterService registers diagnostics service on provided server
+func RegisterService(s *grpc.Server, service *Service) {
+	diagnosticsv1.RegisterDiagnosticsServer(s, service)
+}
+
+// Config configurations for diagnostics service
+type Config struct {
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+}
+
+// New creates a new diagnostics service
+func New(config Config) *Service {
+	return &Service{
+		log:     config.Log,
+		metrics: config.Metrics,
+	}
+}
+
+// Service implements diagnostics server
+type Service struct {
+	diagnosticsv1.UnsafeDiagnosticsServer
+
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+}
+
+// GetDiagnostics gets SPIRE Agent runtime diagnostics
+func (s *Service) GetDiagnostics(ctx context.Context, req *diagnosticsv1.GetDiagnosticsRequest) (*diagnosticsv1.GetDiagnosticsResponse, error) {
+	goroutineProfile, err := diagnostics.GoroutineProfile()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get goroutine profile")
+		return nil, status.Errorf(codes.Internal, "failed to get goroutine profile: %v", err)
+	}
+
+	inmemMetrics, err := telemetry.InmemMetrics(s.metrics)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get in-memory metrics")
+		return nil, status.Errorf(codes.Internal, "failed to get in-memory metrics: %v", err)
+	}
+
+	return &diagnosticsv1.GetDiagnosticsResponse{
+		Version:          version.Version(),
+		GoroutineProfile: goroutineProfile,
+		InmemMetrics:     inmemMetrics,
+	}, nil
+}
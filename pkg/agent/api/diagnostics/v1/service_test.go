@@ -0,0 +1,74 @@
+package diagnostics_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	diagnostics "github.com/spiffe/spire/pkg/agent/api/diagnostics/v1"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/agent/diagnostics/v1"
+)
+
+var ctx = context.Background()
+
+func TestGetDiagnostics(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	metrics, err := telemetry.NewMetrics(&telemetry.MetricsConfig{
+		Logger:      log,
+		ServiceName: "spire_agent",
+		FileConfig:  telemetry.FileConfig{InMem: &telemetry.InMem{}},
+	})
+	require.NoError(t, err)
+	metrics.IncrCounter([]string{"some", "counter"}, 1)
+
+	client := setupServiceTest(t, metrics)
+
+	resp, err := client.GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, version.Version(), resp.Version)
+	assert.Contains(t, string(resp.GoroutineProfile), "goroutine")
+
+	var summary struct {
+		Counters []struct {
+			Name string
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.InmemMetrics, &summary))
+	require.Len(t, summary.Counters, 1)
+	assert.Equal(t, "spire_agent.some.counter", summary.Counters[0].Name)
+}
+
+func TestGetDiagnosticsWithoutInmemMetrics(t *testing.T) {
+	client := setupServiceTest(t, telemetry.Blackhole{})
+
+	resp, err := client.GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, version.Version(), resp.Version)
+	assert.NotEmpty(t, resp.GoroutineProfile)
+	assert.Empty(t, resp.InmemMetrics)
+}
+
+func setupServiceTest(t *testing.T, metrics telemetry.Metrics) diagnosticsv1.DiagnosticsClient {
+	log, _ := test.NewNullLogger()
+	service := diagnostics.New(diagnostics.Config{
+		Log:     log,
+		Metrics: metrics,
+	})
+
+	registerFn := func(s *grpc.Server) {
+		diagnostics.RegisterService(s, service)
+	}
+	contextFn := func(ctx context.Context) context.Context {
+		return ctx
+	}
+	conn, _ := spiretest.NewAPIServer(t, registerFn, contextFn)
+	return diagnosticsv1.NewDiagnosticsClient(conn)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sirupsen/logrus"
 	debugv1 "github.com/spiffe/spire/pkg/agent/api/debug/v1"
 	delegatedidentityv1 "github.com/spiffe/spire/pkg/agent/api/delegatedidentity/v1"
+	diagnosticsv1 "github.com/spiffe/spire/pkg/agent/api/diagnostics/v1"
 	"github.com/spiffe/spire/pkg/common/api/middleware"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
@@ -36,6 +37,7 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 
 	e.registerDebugAPI(server)
 	e.registerDelegatedIdentityAPI(server)
+	e.registerDiagnosticsAPI(server)
 
 	l, err := e.createListener()
 	if err != nil {
@@ -86,3 +88,12 @@ func (e *Endpoints) registerDelegatedIdentityAPI(server *grpc.Server) {
 
 	delegatedidentityv1.RegisterService(server, service)
 }
+
+func (e *Endpoints) registerDiagnosticsAPI(server *grpc.Server) {
+	service := diagnosticsv1.New(diagnosticsv1.Config{
+		Log:     e.c.Log.WithField(telemetry.SubsystemName, telemetry.DiagnosticsAPI),
+		Metrics: e.c.Metrics,
+	})
+
+	diagnosticsv1.RegisterService(server, service)
+}
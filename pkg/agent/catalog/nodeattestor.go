@@ -9,6 +9,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/k8spsat"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/k8ssat"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/sshpop"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpm"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/x509pop"
 	"github.com/spiffe/spire/pkg/common/catalog"
@@ -41,6 +42,7 @@ func (repo *nodeAttestorRepository) BuiltIns() []catalog.BuiltIn {
 		k8spsat.BuiltIn(),
 		k8ssat.BuiltIn(),
 		sshpop.BuiltIn(),
+		tpm.BuiltIn(),
 		tpmdevid.BuiltIn(),
 		x509pop.BuiltIn(),
 	}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/version"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/vishnusomank/go-spiffe/v2/bundle/x509bundle"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
@@ -17,6 +18,7 @@ import (
 	"github.com/vishnusomank/go-spiffe/v2/svid/x509svid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -70,6 +72,8 @@ func DialServer(ctx context.Context, config DialServerConfig) (*grpc.ClientConn,
 		grpc.WithBlock(),
 		grpc.WithReturnConnectionError(),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithChainUnaryInterceptor(reportVersionUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(reportVersionStreamInterceptor),
 	)
 	switch {
 	case err == nil:
@@ -83,6 +87,22 @@ func DialServer(ctx context.Context, config DialServerConfig) (*grpc.ClientConn,
 	return client, nil
 }
 
+// reportVersionUnaryInterceptor reports the agent version to the server on
+// every unary call.
+func reportVersionUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(withAgentVersion(ctx), method, req, reply, cc, opts...)
+}
+
+// reportVersionStreamInterceptor reports the agent version to the server on
+// every streaming call.
+func reportVersionStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(withAgentVersion(ctx), desc, cc, method, opts...)
+}
+
+func withAgentVersion(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, version.AgentVersionMetadataKey, version.Version())
+}
+
 type bundleSource struct {
 	td     spiffeid.TrustDomain
 	getter func() []*x509.Certificate
@@ -32,6 +32,11 @@ type Config struct {
 	// Generator is an optional key generator.
 	Generator Generator
 
+	// GenerateSigner is an optional callback used to generate keys that are
+	// not held in memory (e.g. keys that reside in a TPM). When set, it is
+	// used instead of the Generator.
+	GenerateSigner func(keyID string, keyType keymanagerv1.KeyType) (crypto.Signer, error)
+
 	// WriteEntries is an optional callback used to persist key entries
 	WriteEntries func(ctx context.Context, allEntries []*KeyEntry, newEntry *KeyEntry) error
 }
@@ -213,6 +218,24 @@ func (m *Base) getPrivateKeyAndFingerprint(id string) (crypto.Signer, string, bo
 
 func (m *Base) generateKeyEntry(keyID string, keyType keymanagerv1.KeyType) (e *KeyEntry, err error) {
 	var privateKey crypto.Signer
+	if m.config.GenerateSigner != nil {
+		privateKey, err = m.config.GenerateSigner(keyID, keyType)
+	} else {
+		privateKey, err = m.generatePrivateKey(keyID, keyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := makeKeyEntry(keyID, keyType, privateKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to make key entry for new key %q: %v", keyID, err)
+	}
+
+	return entry, nil
+}
+
+func (m *Base) generatePrivateKey(keyID string, keyType keymanagerv1.KeyType) (privateKey crypto.Signer, err error) {
 	switch keyType {
 	case keymanagerv1.KeyType_EC_P256:
 		privateKey, err = m.config.Generator.GenerateEC256Key()
@@ -225,16 +248,7 @@ func (m *Base) generateKeyEntry(keyID string, keyType keymanagerv1.KeyType) (e *
 	default:
 		return nil, status.Errorf(codes.InvalidArgument, "unable to generate key %q for unknown key type %q", keyID, keyType)
 	}
-	if err != nil {
-		return nil, err
-	}
-
-	entry, err := makeKeyEntry(keyID, keyType, privateKey)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to make key entry for new key %q: %v", keyID, err)
-	}
-
-	return entry, nil
+	return privateKey, err
 }
 
 func makeKeyEntry(keyID string, keyType keymanagerv1.KeyType, privateKey crypto.Signer) (*KeyEntry, error) {
@@ -254,6 +268,13 @@ func makeKeyEntry(keyID string, keyType keymanagerv1.KeyType, privateKey crypto.
 	}, nil
 }
 
+// MakeKeyEntryFromSigner makes a key entry for a signer of the given key type.
+// It is used by implementations whose private keys cannot be marshaled (e.g.
+// keys that reside in a TPM).
+func MakeKeyEntryFromSigner(id string, keyType keymanagerv1.KeyType, signer crypto.Signer) (*KeyEntry, error) {
+	return makeKeyEntry(id, keyType, signer)
+}
+
 func MakeKeyEntryFromKey(id string, privateKey crypto.PrivateKey) (*KeyEntry, error) {
 	switch privateKey := privateKey.(type) {
 	case *ecdsa.PrivateKey:
@@ -13,6 +13,7 @@ import (
 	keymanagerbase "github.com/spiffe/spire/pkg/agent/plugin/keymanager/base"
 	catalog "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/keyenvelope"
 	keymanagerv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/keymanager/v1"
 	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
 	"google.golang.org/grpc/codes"
@@ -36,7 +37,8 @@ func asBuiltIn(p *KeyManager) catalog.BuiltIn {
 }
 
 type configuration struct {
-	Directory string `hcl:"directory"`
+	Directory  string              `hcl:"directory"`
+	Encryption *keyenvelope.Config `hcl:"encryption"`
 }
 
 type KeyManager struct {
@@ -45,8 +47,9 @@ type KeyManager struct {
 
 	log hclog.Logger
 
-	mu     sync.Mutex
-	config *configuration
+	mu      sync.Mutex
+	config  *configuration
+	wrapper keyenvelope.KeyWrapper
 }
 
 func newKeyManager(generator Generator) *KeyManager {
@@ -72,35 +75,53 @@ func (m *KeyManager) Configure(ctx context.Context, req *configv1.ConfigureReque
 		return nil, status.Error(codes.InvalidArgument, "directory must be configured")
 	}
 
+	var wrapper keyenvelope.KeyWrapper
+	if config.Encryption != nil {
+		var err error
+		wrapper, err = config.Encryption.NewKeyWrapper()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid encryption configuration: %v", err)
+		}
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if err := m.configure(config); err != nil {
+	if err := m.configure(ctx, config, wrapper); err != nil {
 		return nil, err
 	}
 
 	return &configv1.ConfigureResponse{}, nil
 }
 
-func (m *KeyManager) configure(config *configuration) error {
+func (m *KeyManager) configure(ctx context.Context, config *configuration, wrapper keyenvelope.KeyWrapper) error {
 	// Only load entry information on first configure
 	if m.config == nil {
-		if err := m.loadEntries(config.Directory); err != nil {
+		if err := m.loadEntries(ctx, config.Directory, wrapper); err != nil {
 			return err
 		}
 	}
 
 	m.config = config
+	m.wrapper = wrapper
 	return nil
 }
 
-func (m *KeyManager) loadEntries(dir string) error {
+func (m *KeyManager) loadEntries(ctx context.Context, dir string, wrapper keyenvelope.KeyWrapper) error {
 	// Load the entries from the keys file.
-	entries, err := loadEntries(keysPath(dir))
+	entries, plaintext, err := loadEntries(ctx, keysPath(dir), wrapper)
 	if err != nil {
 		return err
 	}
 
+	if plaintext && wrapper != nil {
+		// Encrypt the existing plaintext keys file in place
+		if err := writeEntries(ctx, keysPath(dir), entries, wrapper); err != nil {
+			return err
+		}
+		m.log.Info("Encrypted plaintext keys file", "directory", dir)
+	}
+
 	m.Base.SetEntries(entries)
 	return nil
 }
@@ -108,49 +129,66 @@ func (m *KeyManager) loadEntries(dir string) error {
 func (m *KeyManager) writeEntries(ctx context.Context, allEntries []*keymanagerbase.KeyEntry, newEntry *keymanagerbase.KeyEntry) error {
 	m.mu.Lock()
 	config := m.config
+	wrapper := m.wrapper
 	m.mu.Unlock()
 
 	if config == nil {
 		return status.Error(codes.FailedPrecondition, "not configured")
 	}
 
-	return writeEntries(keysPath(config.Directory), allEntries)
+	return writeEntries(ctx, keysPath(config.Directory), allEntries, wrapper)
 }
 
 type entriesData struct {
 	Keys map[string][]byte `json:"keys"`
 }
 
-func loadEntries(path string) ([]*keymanagerbase.KeyEntry, error) {
+// loadEntries loads the entries from the keys file. If the keys file is
+// encrypted, it is decrypted using the wrapper. It also returns whether or not
+// the keys file was stored in plaintext.
+func loadEntries(ctx context.Context, path string, wrapper keyenvelope.KeyWrapper) ([]*keymanagerbase.KeyEntry, bool, error) {
 	jsonBytes, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	plaintext := !keyenvelope.IsSealed(jsonBytes)
+	if !plaintext {
+		if wrapper == nil {
+			return nil, false, status.Error(codes.FailedPrecondition, "keys file is encrypted but encryption is not configured")
+		}
+		jsonBytes, err = keyenvelope.Open(ctx, wrapper, jsonBytes)
+		if err != nil {
+			return nil, false, status.Errorf(codes.FailedPrecondition, "unable to decrypt keys file: %v", err)
 		}
-		return nil, err
 	}
 
 	data := new(entriesData)
 	if err := json.Unmarshal(jsonBytes, data); err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to decode keys JSON: %v", err)
+		return nil, false, status.Errorf(codes.Internal, "unable to decode keys JSON: %v", err)
 	}
 
 	var entries []*keymanagerbase.KeyEntry
 	for id, keyBytes := range data.Keys {
 		key, err := x509.ParsePKCS8PrivateKey(keyBytes)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to parse key %q: %v", id, err)
+			return nil, false, status.Errorf(codes.Internal, "unable to parse key %q: %v", id, err)
 		}
 		entry, err := keymanagerbase.MakeKeyEntryFromKey(id, key)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to make entry %q: %v", id, err)
+			return nil, false, status.Errorf(codes.Internal, "unable to make entry %q: %v", id, err)
 		}
 		entries = append(entries, entry)
 	}
-	return entries, nil
+	return entries, plaintext, nil
 }
 
-func writeEntries(path string, entries []*keymanagerbase.KeyEntry) error {
+// writeEntries writes the entries to the keys file. If a wrapper is provided,
+// the keys file is encrypted.
+func writeEntries(ctx context.Context, path string, entries []*keymanagerbase.KeyEntry, wrapper keyenvelope.KeyWrapper) error {
 	data := &entriesData{
 		Keys: make(map[string][]byte),
 	}
@@ -167,6 +205,13 @@ func writeEntries(path string, entries []*keymanagerbase.KeyEntry) error {
 		return status.Errorf(codes.Internal, "unable to marshal entries: %v", err)
 	}
 
+	if wrapper != nil {
+		jsonBytes, err = keyenvelope.Seal(ctx, wrapper, jsonBytes)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to encrypt entries: %v", err)
+		}
+	}
+
 	if err := diskutil.AtomicWritePrivateFile(path, jsonBytes); err != nil {
 		return status.Errorf(codes.Internal, "unable to write entries: %v", err)
 	}
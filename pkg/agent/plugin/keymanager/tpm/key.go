@@ -0,0 +1,158 @@
+package tpm
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-tpm/tpm2"
+	tpmutil2 "github.com/google/go-tpm/tpmutil"
+	keymanagerv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/keymanager/v1"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// tpmKey is a crypto.Signer backed by a key that resides in the TPM. The key
+// is loaded under the storage root key on every signing operation so there
+// is no limit on the number of keys managed.
+type tpmKey struct {
+	m *KeyManager
+
+	keyType     keymanagerv1.KeyType
+	public      crypto.PublicKey
+	publicBlob  []byte
+	privateBlob []byte
+}
+
+func (m *KeyManager) newTPMKey(publicBlob, privateBlob []byte) (*tpmKey, error) {
+	pub, err := tpm2.DecodePublic(publicBlob)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode public blob: %w", err)
+	}
+
+	public, err := pub.Key()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get public key: %w", err)
+	}
+
+	keyType, err := publicKeyType(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tpmKey{
+		m:           m,
+		keyType:     keyType,
+		public:      public,
+		publicBlob:  publicBlob,
+		privateBlob: privateBlob,
+	}, nil
+}
+
+func (k *tpmKey) Public() crypto.PublicKey {
+	return k.public
+}
+
+func (k *tpmKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hashAlg, err := tpm2.HashToAlgorithm(opts.HashFunc())
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := &tpm2.SigScheme{Hash: hashAlg}
+	switch k.public.(type) {
+	case *ecdsa.PublicKey:
+		scheme.Alg = tpm2.AlgECDSA
+	case *rsa.PublicKey:
+		scheme.Alg = tpm2.AlgRSASSA
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			// The TPM uses a salt as long as the hash
+			switch pssOpts.SaltLength {
+			case rsa.PSSSaltLengthAuto, rsa.PSSSaltLengthEqualsHash, opts.HashFunc().Size():
+			default:
+				return nil, fmt.Errorf("unsupported PSS salt length %d", pssOpts.SaltLength)
+			}
+			scheme.Alg = tpm2.AlgRSAPSS
+		}
+	}
+
+	k.m.tpmMu.Lock()
+	defer k.m.tpmMu.Unlock()
+
+	if k.m.rwc == nil {
+		return nil, errors.New("TPM is closed")
+	}
+
+	handle, _, err := tpm2.Load(k.m.rwc, k.m.srk, "", k.publicBlob, k.privateBlob)
+	if err != nil {
+		return nil, fmt.Errorf("tpm2.Load failed: %w", err)
+	}
+	defer k.m.flushContext(handle)
+
+	sig, err := tpm2.Sign(k.m.rwc, handle, "", digest, nil, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("tpm2.Sign failed: %w", err)
+	}
+
+	return signatureBytes(sig)
+}
+
+// checkKey verifies the key can be loaded under the storage root key.
+func (m *KeyManager) checkKey(k *tpmKey) error {
+	m.tpmMu.Lock()
+	defer m.tpmMu.Unlock()
+
+	handle, _, err := tpm2.Load(m.rwc, m.srk, "", k.publicBlob, k.privateBlob)
+	if err != nil {
+		return err
+	}
+	m.flushContext(handle)
+	return nil
+}
+
+func (m *KeyManager) flushContext(handle tpmutil2.Handle) {
+	if err := tpm2.FlushContext(m.rwc, handle); err != nil {
+		m.log.Warn("Failed to flush handle", "handle", handle, "error", err)
+	}
+}
+
+func publicKeyType(public crypto.PublicKey) (keymanagerv1.KeyType, error) {
+	switch public := public.(type) {
+	case *ecdsa.PublicKey:
+		switch public.Curve {
+		case elliptic.P256():
+			return keymanagerv1.KeyType_EC_P256, nil
+		case elliptic.P384():
+			return keymanagerv1.KeyType_EC_P384, nil
+		default:
+			return keymanagerv1.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("unsupported EC curve: %s", public.Curve.Params().Name)
+		}
+	case *rsa.PublicKey:
+		if bits := public.N.BitLen(); bits != 2048 {
+			return keymanagerv1.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("unsupported RSA key bit length: %d", bits)
+		}
+		return keymanagerv1.KeyType_RSA_2048, nil
+	default:
+		return keymanagerv1.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("unsupported public key type %T", public)
+	}
+}
+
+func signatureBytes(sig *tpm2.Signature) ([]byte, error) {
+	switch {
+	case sig.RSA != nil:
+		return sig.RSA.Signature, nil
+	case sig.ECC != nil:
+		var b cryptobyte.Builder
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1BigInt(sig.ECC.R)
+			b.AddASN1BigInt(sig.ECC.S)
+		})
+		return b.Bytes()
+	default:
+		return nil, errors.New("unrecognized tpm2.Signature")
+	}
+}
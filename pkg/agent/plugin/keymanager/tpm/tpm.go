@@ -0,0 +1,327 @@
+package tpm
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	tpmutil2 "github.com/google/go-tpm/tpmutil"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	keymanagerbase "github.com/spiffe/spire/pkg/agent/plugin/keymanager/base"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	keymanagerv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/keymanager/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "tpm"
+
+	baseTPMDir = "/dev"
+)
+
+// AutoDetectTPMPath is overridden in test files to facilitate unit testing
+var AutoDetectTPMPath = tpmutil.AutoDetectTPMPath
+
+func BuiltIn() catalog.BuiltIn {
+	return asBuiltIn(newKeyManager())
+}
+
+func asBuiltIn(p *KeyManager) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		keymanagerv1.KeyManagerPluginServer(p),
+		configv1.ConfigServiceServer(p))
+}
+
+type configuration struct {
+	Directory              string `hcl:"directory"`
+	DevicePath             string `hcl:"tpm_device_path"`
+	OwnerHierarchyPassword string `hcl:"owner_hierarchy_password"`
+}
+
+// KeyManager is a key manager whose keys are created by and never leave the
+// TPM. Keys are created under a storage root key (SRK) derived from the owner
+// hierarchy seed, so only the TPM-wrapped private blobs are persisted on
+// disk. Those blobs cannot be loaded by any other TPM.
+type KeyManager struct {
+	*keymanagerbase.Base
+	configv1.UnimplementedConfigServer
+
+	log hclog.Logger
+
+	mu     sync.Mutex
+	config *configuration
+
+	// tpmMu serializes access to the TPM
+	tpmMu sync.Mutex
+	rwc   io.ReadWriteCloser
+	srk   tpmutil2.Handle
+}
+
+func newKeyManager() *KeyManager {
+	m := &KeyManager{}
+	m.Base = keymanagerbase.New(keymanagerbase.Config{
+		GenerateSigner: m.generateSigner,
+		WriteEntries:   m.writeEntries,
+	})
+	return m
+}
+
+func (m *KeyManager) SetLogger(log hclog.Logger) {
+	m.log = log
+}
+
+func (m *KeyManager) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	config := new(configuration)
+	if err := hcl.Decode(config, req.HclConfiguration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if config.Directory == "" {
+		return nil, status.Error(codes.InvalidArgument, "directory must be configured")
+	}
+
+	switch {
+	case runtime.GOOS == "windows" && config.DevicePath != "":
+		return nil, status.Error(codes.InvalidArgument, "device path is not allowed on windows")
+	case runtime.GOOS != "windows" && config.DevicePath == "":
+		tpmPath, err := AutoDetectTPMPath(baseTPMDir)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "tpm autodetection failed: %v", err)
+		}
+		config.DevicePath = tpmPath
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.configure(config); err != nil {
+		return nil, err
+	}
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+// Close flushes the storage root key and closes the connection to the TPM.
+func (m *KeyManager) Close() error {
+	m.tpmMu.Lock()
+	defer m.tpmMu.Unlock()
+
+	if m.rwc == nil {
+		return nil
+	}
+
+	if err := tpm2.FlushContext(m.rwc, m.srk); err != nil {
+		m.log.Warn("Failed to flush storage root key", "error", err)
+	}
+	err := m.rwc.Close()
+	m.rwc = nil
+	return err
+}
+
+func (m *KeyManager) configure(config *configuration) error {
+	// Only open the TPM and load entry information on first configure
+	if m.config == nil {
+		if err := m.openTPM(config); err != nil {
+			return err
+		}
+		if err := m.loadEntries(config.Directory); err != nil {
+			return err
+		}
+	}
+
+	m.config = config
+	return nil
+}
+
+func (m *KeyManager) openTPM(config *configuration) (err error) {
+	m.tpmMu.Lock()
+	defer m.tpmMu.Unlock()
+
+	rwc, err := tpmutil.OpenTPM(config.DevicePath)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to open TPM at %q: %v", config.DevicePath, err)
+	}
+	defer func() {
+		if err != nil {
+			rwc.Close()
+		}
+	}()
+
+	srk, _, err := tpm2.CreatePrimary(rwc, tpm2.HandleOwner, tpm2.PCRSelection{}, config.OwnerHierarchyPassword, "", tpmutil.SRKTemplateHighECC())
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to create storage root key: %v", err)
+	}
+
+	m.rwc = rwc
+	m.srk = srk
+	return nil
+}
+
+func (m *KeyManager) loadEntries(dir string) error {
+	// Load the entries from the keys file.
+	entries, err := m.readEntries(keysPath(dir))
+	if err != nil {
+		return err
+	}
+
+	m.Base.SetEntries(entries)
+	return nil
+}
+
+func (m *KeyManager) generateSigner(keyID string, keyType keymanagerv1.KeyType) (crypto.Signer, error) {
+	template, err := keyTemplate(keyType)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to generate key %q: %v", keyID, err)
+	}
+
+	m.tpmMu.Lock()
+	defer m.tpmMu.Unlock()
+
+	if m.rwc == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	privateBlob, publicBlob, _, _, _, err := tpm2.CreateKey(m.rwc, m.srk, tpm2.PCRSelection{}, "", "", template)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to create key %q in TPM: %v", keyID, err)
+	}
+
+	key, err := m.newTPMKey(publicBlob, privateBlob)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to parse key %q: %v", keyID, err)
+	}
+	return key, nil
+}
+
+func (m *KeyManager) writeEntries(ctx context.Context, allEntries []*keymanagerbase.KeyEntry, newEntry *keymanagerbase.KeyEntry) error {
+	m.mu.Lock()
+	config := m.config
+	m.mu.Unlock()
+
+	if config == nil {
+		return status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	return writeEntries(keysPath(config.Directory), allEntries)
+}
+
+type entriesData struct {
+	Keys map[string]keyBlobs `json:"keys"`
+}
+
+type keyBlobs struct {
+	Public  []byte `json:"public"`
+	Private []byte `json:"private"`
+}
+
+func (m *KeyManager) readEntries(path string) ([]*keymanagerbase.KeyEntry, error) {
+	jsonBytes, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, status.Errorf(codes.Internal, "unable to read keys: %v", err)
+	}
+
+	data := new(entriesData)
+	if err := json.Unmarshal(jsonBytes, data); err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to decode keys JSON: %v", err)
+	}
+
+	var entries []*keymanagerbase.KeyEntry
+	for id, blobs := range data.Keys {
+		key, err := m.newTPMKey(blobs.Public, blobs.Private)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to parse key %q: %v", id, err)
+		}
+
+		// Make sure the key can be loaded by this TPM before accepting it.
+		// This fails if the keys file was copied from another host.
+		if err := m.checkKey(key); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to load key %q in TPM: %v", id, err)
+		}
+
+		entry, err := keymanagerbase.MakeKeyEntryFromSigner(id, key.keyType, key)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to make entry %q: %v", id, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func writeEntries(path string, entries []*keymanagerbase.KeyEntry) error {
+	data := &entriesData{
+		Keys: make(map[string]keyBlobs),
+	}
+	for _, entry := range entries {
+		key, ok := entry.PrivateKey.(*tpmKey)
+		if !ok {
+			return status.Errorf(codes.Internal, "unexpected key type %T for key %q", entry.PrivateKey, entry.Id)
+		}
+		data.Keys[entry.Id] = keyBlobs{
+			Public:  key.publicBlob,
+			Private: key.privateBlob,
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(data, "", "\t")
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal entries: %v", err)
+	}
+
+	if err := diskutil.AtomicWritePrivateFile(path, jsonBytes); err != nil {
+		return status.Errorf(codes.Internal, "unable to write entries: %v", err)
+	}
+
+	return nil
+}
+
+func keyTemplate(keyType keymanagerv1.KeyType) (tpm2.Public, error) {
+	// The signing scheme is not fixed in the template so the same key
+	// can be used with different hash algorithms (and PSS for RSA keys).
+	template := tpm2.Public{
+		NameAlg: tpm2.AlgSHA256,
+		Attributes: tpm2.FlagSign |
+			tpm2.FlagFixedTPM |
+			tpm2.FlagFixedParent |
+			tpm2.FlagSensitiveDataOrigin |
+			tpm2.FlagUserWithAuth |
+			tpm2.FlagNoDA,
+	}
+
+	switch keyType {
+	case keymanagerv1.KeyType_EC_P256:
+		template.Type = tpm2.AlgECC
+		template.ECCParameters = &tpm2.ECCParams{CurveID: tpm2.CurveNISTP256}
+	case keymanagerv1.KeyType_EC_P384:
+		template.Type = tpm2.AlgECC
+		template.ECCParameters = &tpm2.ECCParams{CurveID: tpm2.CurveNISTP384}
+	case keymanagerv1.KeyType_RSA_2048:
+		template.Type = tpm2.AlgRSA
+		template.RSAParameters = &tpm2.RSAParams{KeyBits: 2048}
+	case keymanagerv1.KeyType_RSA_4096:
+		// RSA 4096 keys are not supported by TPM 2.0 devices
+		return tpm2.Public{}, fmt.Errorf("key type %q is not supported by the TPM", keyType)
+	default:
+		return tpm2.Public{}, fmt.Errorf("unknown key type %q", keyType)
+	}
+
+	return template, nil
+}
+
+func keysPath(dir string) string {
+	return filepath.Join(dir, "tpm_keys.json")
+}
@@ -0,0 +1,227 @@
+//go:build !darwin
+// +build !darwin
+
+package tpm_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager"
+	keymanagertest "github.com/spiffe/spire/pkg/agent/plugin/keymanager/test"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/tpm"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	tpmDevicePath          = "/dev/tpmrm0"
+	ownerHierarchyPassword = "owner-hierarchy-pass"
+)
+
+var isWindows = runtime.GOOS == "windows"
+
+func setupSimulator(t *testing.T) *tpmsimulator.TPMSimulator {
+	sim, err := tpmsimulator.New("endorsement-hierarchy-pass", ownerHierarchyPassword)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, sim.Close(), "unexpected error encountered closing simulator")
+	})
+
+	// Override OpenTPM fuction to use a simulator instead of a physical TPM
+	tpmutil.OpenTPM = func(s ...string) (io.ReadWriteCloser, error) {
+		return sim.OpenTPM(s...)
+	}
+
+	return sim
+}
+
+func TestKeyManagerContract(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+	setupSimulator(t)
+
+	keymanagertest.Test(t, keymanagertest.Config{
+		Create: func(t *testing.T) keymanager.KeyManager {
+			km, err := loadPlugin(t, spiretest.TempDir(t))
+			require.NoError(t, err)
+			return km
+		},
+		UnsupportedKeyTypes: []keymanager.KeyType{keymanager.RSA4096},
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+	setupSimulator(t)
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := loadPluginWithConfig(t, "")
+		spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "directory must be configured")
+	})
+
+	t.Run("malformed configuration", func(t *testing.T) {
+		_, err := loadPluginWithConfig(t, "not an HCL configuration")
+		spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, "unable to decode configuration")
+	})
+
+	t.Run("tpm autodetection fails", func(t *testing.T) {
+		tpm.AutoDetectTPMPath = func(string) (string, error) {
+			return "", errors.New("unable to autodetect TPM")
+		}
+		defer func() { tpm.AutoDetectTPMPath = tpmutil.AutoDetectTPMPath }()
+
+		_, err := loadPluginWithConfig(t, `directory = "dir"`)
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "tpm autodetection failed: unable to autodetect TPM")
+	})
+
+	t.Run("tpm autodetection succeeds", func(t *testing.T) {
+		tpm.AutoDetectTPMPath = func(string) (string, error) {
+			return tpmDevicePath, nil
+		}
+		defer func() { tpm.AutoDetectTPMPath = tpmutil.AutoDetectTPMPath }()
+
+		_, err := loadPluginWithConfig(t, `directory = %q owner_hierarchy_password = %q`, spiretest.TempDir(t), ownerHierarchyPassword)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong device path", func(t *testing.T) {
+		_, err := loadPluginWithConfig(t, `directory = "dir" tpm_device_path = "/dev/nope"`)
+		spiretest.RequireGRPCStatusContains(t, err, codes.Internal, `unable to open TPM at "/dev/nope"`)
+	})
+
+	t.Run("wrong owner hierarchy password", func(t *testing.T) {
+		_, err := loadPluginWithConfig(t, `directory = %q tpm_device_path = %q owner_hierarchy_password = "nope"`, spiretest.TempDir(t), tpmDevicePath)
+		spiretest.RequireGRPCStatusContains(t, err, codes.Internal, "unable to create storage root key")
+	})
+
+	t.Run("malformed keys file", func(t *testing.T) {
+		dir := spiretest.TempDir(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tpm_keys.json"), []byte("{"), 0600))
+		_, err := loadPlugin(t, dir)
+		spiretest.RequireGRPCStatusContains(t, err, codes.Internal, "unable to decode keys JSON")
+	})
+}
+
+func TestGenerateKeyBeforeConfigure(t *testing.T) {
+	km := new(keymanager.V1)
+	plugintest.Load(t, tpm.BuiltIn(), km)
+
+	_, err := km.GenerateKey(context.Background(), "id", keymanager.ECP256)
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "keymanager(tpm): failed to generate key: not configured")
+}
+
+func TestGenerateKeyUnsupportedKeyType(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+	setupSimulator(t)
+
+	km, err := loadPlugin(t, spiretest.TempDir(t))
+	require.NoError(t, err)
+
+	_, err = km.GenerateKey(context.Background(), "id", keymanager.RSA4096)
+	spiretest.RequireGRPCStatusContains(t, err, codes.InvalidArgument, `key type "RSA_4096" is not supported by the TPM`)
+}
+
+func TestGenerateKeyPersistence(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+	setupSimulator(t)
+
+	dir := filepath.Join(spiretest.TempDir(t), "no-such-dir")
+
+	km, err := loadPlugin(t, dir)
+	require.NoError(t, err)
+
+	// assert failure to generate key when directory is gone
+	_, err = km.GenerateKey(context.Background(), "id", keymanager.ECP256)
+	spiretest.RequireGRPCStatusContains(t, err, codes.Internal, "failed to generate key: unable to write entries")
+
+	// create the directory and generate the key
+	require.NoError(t, os.Mkdir(dir, 0755))
+	keyIn, err := km.GenerateKey(context.Background(), "id", keymanager.ECP256)
+	require.NoError(t, err)
+
+	// reload the plugin. original key should have persisted and still be
+	// usable for signing.
+	km, err = loadPlugin(t, dir)
+	require.NoError(t, err)
+	keyOut, err := km.GetKey(context.Background(), "id")
+	require.NoError(t, err)
+	require.Equal(t,
+		publicKeyBytes(t, keyIn),
+		publicKeyBytes(t, keyOut),
+	)
+	digest := sha256.Sum256([]byte("DATA"))
+	_, err = keyOut.Sign(rand.Reader, digest[:], crypto.SHA256)
+	require.NoError(t, err)
+}
+
+func TestKeysFromAnotherTPM(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+
+	dir := spiretest.TempDir(t)
+
+	t.Run("generate keys", func(t *testing.T) {
+		// The simulator is closed when the subtest completes, before the
+		// next one is started.
+		setupSimulator(t)
+
+		km, err := loadPlugin(t, dir)
+		require.NoError(t, err)
+		_, err = km.GenerateKey(context.Background(), "id", keymanager.ECP256)
+		require.NoError(t, err)
+	})
+
+	// A new simulator has a different storage seed, so the persisted keys
+	// cannot be loaded by it.
+	setupSimulator(t)
+	_, err := loadPlugin(t, dir)
+	spiretest.RequireGRPCStatusContains(t, err, codes.Internal, `unable to load key "id" in TPM`)
+}
+
+func loadPlugin(t *testing.T, dir string) (keymanager.KeyManager, error) {
+	return loadPluginWithConfig(t, `
+		directory = %q
+		tpm_device_path = %q
+		owner_hierarchy_password = %q
+	`, dir, tpmDevicePath, ownerHierarchyPassword)
+}
+
+func loadPluginWithConfig(t *testing.T, configFmt string, configArgs ...interface{}) (keymanager.KeyManager, error) {
+	km := new(keymanager.V1)
+	var configErr error
+
+	plugintest.Load(t, tpm.BuiltIn(), km,
+		plugintest.Configuref(configFmt, configArgs...),
+		plugintest.CaptureConfigureError(&configErr),
+	)
+	return km, configErr
+}
+
+func publicKeyBytes(t *testing.T, key keymanager.Key) []byte {
+	b, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return b
+}
@@ -0,0 +1,219 @@
+package tpm
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	common_tpm "github.com/spiffe/spire/pkg/common/plugin/tpm"
+	nodeattestorv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/nodeattestor/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const BaseTPMDir = "/dev"
+
+// Functions defined here are overridden in test files to facilitate unit testing
+var (
+	AutoDetectTPMPath func(string) (string, error)                           = tpmutil.AutoDetectTPMPath
+	NewSession        func(*tpmutil.SessionConfig) (*tpmutil.Session, error) = tpmutil.NewSession
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(common_tpm.PluginName,
+		nodeattestorv1.NodeAttestorPluginServer(p),
+		configv1.ConfigServiceServer(p))
+}
+
+type Config struct {
+	OwnerHierarchyPassword       string `hcl:"owner_hierarchy_password"`
+	EndorsementHierarchyPassword string `hcl:"endorsement_hierarchy_password"`
+
+	DevicePath string `hcl:"tpm_device_path"`
+}
+
+type config struct {
+	devicePath string
+	passwords  tpmutil.TPMPasswords
+}
+
+type Plugin struct {
+	nodeattestorv1.UnsafeNodeAttestorServer
+	configv1.UnsafeConfigServer
+	log hclog.Logger
+
+	m sync.Mutex
+	c *config
+}
+
+func New() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) AidAttestation(stream nodeattestorv1.NodeAttestor_AidAttestationServer) error {
+	conf := p.getConfig()
+	if conf == nil {
+		return status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	// Open TPM connection and create an attestation key
+	tpm, err := NewSession(&tpmutil.SessionConfig{
+		DevicePath: conf.devicePath,
+		Passwords:  conf.passwords,
+		Log:        p.log,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to start a new TPM session: %v", err)
+	}
+	defer tpm.Close()
+
+	// Get endorsement certificate from TPM NV index. Not every TPM is
+	// provisioned with one, the server may trust the EK by its hash instead.
+	ekCert, err := tpm.GetEKCert()
+	if err != nil {
+		p.log.Warn("Unable to get endorsement certificate, the server must trust the endorsement key hash", "error", err)
+		ekCert = nil
+	}
+
+	// Get regenerated endorsement public key
+	ekPub, err := tpm.GetEKPublic()
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to get endorsement public key: %v", err)
+	}
+
+	// Marshal attestation data
+	marshaledAttData, err := json.Marshal(common_tpm.AttestationRequest{
+		EKCert: ekCert,
+		EKPub:  ekPub,
+		AKPub:  tpm.GetAKPublic(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal attestation data: %v", err)
+	}
+
+	// Send attestation request
+	err = stream.Send(&nodeattestorv1.PayloadOrChallengeResponse{
+		Data: &nodeattestorv1.PayloadOrChallengeResponse_Payload{
+			Payload: marshaledAttData,
+		},
+	})
+	if err != nil {
+		st := status.Convert(err)
+		return status.Errorf(st.Code(), "unable to send attestation data: %s", st.Message())
+	}
+
+	// Receive challenges
+	marshalledChallenges, err := stream.Recv()
+	if err != nil {
+		st := status.Convert(err)
+		return status.Errorf(st.Code(), "unable to receive challenges: %s", st.Message())
+	}
+
+	challenges := &common_tpm.ChallengeRequest{}
+	if err = json.Unmarshal(marshalledChallenges.Challenge, challenges); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unable to unmarshall challenges: %v", err)
+	}
+
+	// Solve Credential Activation challenge
+	if challenges.CredActivation == nil {
+		return status.Error(codes.Internal, "received empty credential activation challenge from server")
+	}
+
+	credActChallengeResp, err := tpm.SolveCredActivationChallenge(
+		challenges.CredActivation.Credential,
+		challenges.CredActivation.Secret)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to solve proof of residency challenge: %v", err)
+	}
+
+	resp := common_tpm.ChallengeResponse{
+		CredActivation: credActChallengeResp,
+	}
+
+	// Quote the requested PCRs
+	if len(challenges.PCRs) > 0 {
+		resp.PCRValues, err = tpm.ReadPCRs(challenges.PCRs)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to read PCRs: %v", err)
+		}
+
+		resp.Quote, resp.QuoteSignature, err = tpm.Quote(challenges.Nonce, challenges.PCRs)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to quote PCRs: %v", err)
+		}
+	}
+
+	// Marshal challenges responses
+	marshalledChallengeResp, err := json.Marshal(resp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal challenge response: %v", err)
+	}
+
+	// Send challenge response back to the server
+	err = stream.Send(&nodeattestorv1.PayloadOrChallengeResponse{
+		Data: &nodeattestorv1.PayloadOrChallengeResponse_ChallengeResponse{
+			ChallengeResponse: marshalledChallengeResp,
+		},
+	})
+	if err != nil {
+		st := status.Convert(err)
+		return status.Errorf(st.Code(), "unable to send challenge response: %s", st.Message())
+	}
+
+	return nil
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	extConf := new(Config)
+	if err := hcl.Decode(extConf, req.HclConfiguration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	c := &config{
+		passwords: tpmutil.TPMPasswords{
+			OwnerHierarchy:       extConf.OwnerHierarchyPassword,
+			EndorsementHierarchy: extConf.EndorsementHierarchyPassword,
+		},
+	}
+
+	switch {
+	case runtime.GOOS == "windows" && extConf.DevicePath == "":
+		// OK
+	case runtime.GOOS == "windows" && extConf.DevicePath != "":
+		return nil, status.Error(codes.InvalidArgument, "device path is not allowed on windows")
+	case runtime.GOOS != "windows" && extConf.DevicePath != "":
+		c.devicePath = extConf.DevicePath
+	case runtime.GOOS != "windows" && extConf.DevicePath == "":
+		tpmPath, err := AutoDetectTPMPath(BaseTPMDir)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "tpm autodetection failed: %v", err)
+		}
+		c.devicePath = tpmPath
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) getConfig() *config {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
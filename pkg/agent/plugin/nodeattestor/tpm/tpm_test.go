@@ -0,0 +1,296 @@
+//go:build !darwin
+// +build !darwin
+
+package tpm_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor"
+	nodeattestortest "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/test"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpm"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	common_tpm "github.com/spiffe/spire/pkg/common/plugin/tpm"
+	common_devid "github.com/spiffe/spire/pkg/common/plugin/tpmdevid"
+	server_tpm "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpm"
+	server_devid "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+)
+
+var (
+	tpmDevicePath = "/dev/tpmrm0"
+
+	tpmPasswords = tpmutil.TPMPasswords{
+		EndorsementHierarchy: "endorsement-hierarchy-pass",
+		OwnerHierarchy:       "owner-hierarchy-pass",
+	}
+
+	streamBuilder = nodeattestortest.ServerStream("tpm")
+	isWindows     = runtime.GOOS == "windows"
+)
+
+func setupSimulator(t *testing.T) *tpmsimulator.TPMSimulator {
+	// Create a new TPM simulator
+	sim, err := tpmsimulator.New(tpmPasswords.EndorsementHierarchy, tpmPasswords.OwnerHierarchy)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, sim.Close(), "unexpected error encountered closing simulator")
+	})
+
+	// Override OpenTPM fuction to use a simulator instead of a physical TPM
+	tpmutil.OpenTPM = func(s ...string) (io.ReadWriteCloser, error) {
+		return sim.OpenTPM(s...)
+	}
+
+	return sim
+}
+
+func TestConfigure(t *testing.T) {
+	if isWindows {
+		t.Skip()
+	}
+
+	tests := []struct {
+		name               string
+		hclConf            string
+		expErr             string
+		autoDetectTPMFails bool
+	}{
+		{
+			name:    "Configure fails if HCL config cannot be decoded",
+			hclConf: "not an HCL configuration",
+			expErr:  "rpc error: code = InvalidArgument desc = unable to decode configuration",
+		},
+		{
+			name:               "Configure fails if TPM path is not provided and it cannot be auto detected",
+			expErr:             "rpc error: code = Internal desc = tpm autodetection failed: unable to autodetect TPM",
+			autoDetectTPMFails: true,
+		},
+		{
+			name: "Configure succeeds if TPM path is not provided and it can be auto detected",
+		},
+		{
+			name:    "Configure succeeds providing a TPM path",
+			hclConf: `tpm_device_path = "/dev/tpmrm0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tpm.AutoDetectTPMPath = func(string) (string, error) {
+				if tt.autoDetectTPMFails {
+					return "", errors.New("unable to autodetect TPM")
+				}
+				return "/dev/tpmrm0", nil
+			}
+
+			plugin := tpm.New()
+
+			resp, err := plugin.Configure(context.Background(), &configv1.ConfigureRequest{HclConfiguration: tt.hclConf})
+			if tt.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expErr)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestAidAttestationFailures(t *testing.T) {
+	tests := []struct {
+		name                              string
+		wrongOwnerHierarchyPassword       bool
+		wrongEndorsementHierarchyPassword bool
+		expErr                            string
+		serverStream                      nodeattestor.ServerStream
+	}{
+		{
+			name:                        "AidAttestation fails if a new session cannot be started",
+			expErr:                      "rpc error: code = Internal desc = nodeattestor(tpm): unable to start a new TPM session: cannot create attestation key",
+			wrongOwnerHierarchyPassword: true,
+			serverStream:                streamBuilder.Build(),
+		},
+		{
+			name:                              "AidAttestation fails if a wrong endorsement hierarchy password is provided",
+			expErr:                            "rpc error: code = Internal desc = nodeattestor(tpm): unable to start a new TPM session: cannot create endorsement key",
+			wrongEndorsementHierarchyPassword: true,
+			serverStream:                      streamBuilder.Build(),
+		},
+		{
+			name:         "AidAttestation fails if server does not sends a challenge",
+			expErr:       "the error",
+			serverStream: streamBuilder.FailAndBuild(errors.New("the error")),
+		},
+		{
+			name:         "AidAttestation fails if agent cannot unmarshall server challenge",
+			expErr:       "rpc error: code = InvalidArgument desc = nodeattestor(tpm): unable to unmarshall challenges",
+			serverStream: streamBuilder.IgnoreThenChallenge([]byte("not-a-challenge")).Build(),
+		},
+		{
+			name:   "AidAttestation fails if server does not send a proof of residency challenge",
+			expErr: "rpc error: code = Internal desc = nodeattestor(tpm): received empty credential activation challenge from server",
+			serverStream: func() nodeattestor.ServerStream {
+				challenges, err := json.Marshal(common_tpm.ChallengeRequest{})
+				require.NoError(t, err)
+				return streamBuilder.IgnoreThenChallenge(challenges).Build()
+			}(),
+		},
+		{
+			name:   "AidAttestation fails if agent fails to solve proof of residency challenge",
+			expErr: "rpc error: code = Internal desc = nodeattestor(tpm): unable to solve proof of residency challenge",
+			serverStream: func() nodeattestor.ServerStream {
+				challenges, err := json.Marshal(common_tpm.ChallengeRequest{
+					CredActivation: &common_devid.CredActivation{
+						Credential: []byte("wrong formatted credential"),
+						Secret:     []byte("wrong formatted secret"),
+					},
+				})
+				require.NoError(t, err)
+				return streamBuilder.IgnoreThenChallenge(challenges).Build()
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setupSimulator(t)
+
+			passwords := tpmPasswords
+			if tt.wrongEndorsementHierarchyPassword {
+				passwords.EndorsementHierarchy = "wrong-password"
+			}
+			if tt.wrongOwnerHierarchyPassword {
+				passwords.OwnerHierarchy = "wrong-password"
+			}
+
+			p := loadAndConfigurePlugin(t, passwords)
+			err := p.Attest(context.Background(), tt.serverStream)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expErr)
+		})
+	}
+}
+
+func TestAidAttestationSucceeds(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		pcrs      []int
+		noEKCert  bool
+		expEKCert bool
+	}{
+		{
+			name:      "AidAttestation succeeds",
+			expEKCert: true,
+		},
+		{
+			name:      "AidAttestation succeeds quoting PCRs",
+			pcrs:      []int{0, 7},
+			expEKCert: true,
+		},
+		{
+			name:     "AidAttestation succeeds without endorsement certificate",
+			noEKCert: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sim := setupSimulator(t)
+			if tt.noEKCert {
+				// Remove EK cert from TPM
+				require.NoError(t, tpm2.NVUndefineSpace(sim, "", tpm2.HandlePlatform, tpmutil.EKCertificateHandleRSA))
+			}
+
+			var akPub tpm2.Public
+			var credActivationNonce []byte
+			quoteNonce := []byte("quote nonce")
+
+			// Build challenges from the attestation data and verify the
+			// responses in the same way the server does.
+			ss := streamBuilder.Handle(func(payload []byte) ([]byte, error) {
+				attData := new(common_tpm.AttestationRequest)
+				if err := json.Unmarshal(payload, attData); err != nil {
+					return nil, err
+				}
+				if tt.expEKCert != (len(attData.EKCert) > 0) {
+					return nil, fmt.Errorf("unexpected endorsement certificate presence: %t", len(attData.EKCert) > 0)
+				}
+
+				var err error
+				akPub, err = tpm2.DecodePublic(attData.AKPub)
+				if err != nil {
+					return nil, err
+				}
+				ekPub, err := tpm2.DecodePublic(attData.EKPub)
+				if err != nil {
+					return nil, err
+				}
+
+				var credActivation *common_devid.CredActivation
+				credActivation, credActivationNonce, err = server_devid.NewCredActivationChallenge(akPub, ekPub)
+				if err != nil {
+					return nil, err
+				}
+
+				return json.Marshal(common_tpm.ChallengeRequest{
+					CredActivation: credActivation,
+					Nonce:          quoteNonce,
+					PCRs:           tt.pcrs,
+				})
+			}).Handle(func(challengeResponse []byte) ([]byte, error) {
+				response := new(common_tpm.ChallengeResponse)
+				if err := json.Unmarshal(challengeResponse, response); err != nil {
+					return nil, err
+				}
+
+				if err := server_devid.VerifyCredActivationChallenge(credActivationNonce, response.CredActivation); err != nil {
+					return nil, err
+				}
+
+				if len(tt.pcrs) > 0 {
+					return nil, server_tpm.VerifyQuote(&akPub, quoteNonce, tt.pcrs, response.PCRValues, response.Quote, response.QuoteSignature)
+				}
+				return nil, nil
+			}).Build()
+
+			p := loadAndConfigurePlugin(t, tpmPasswords)
+			err := p.Attest(context.Background(), ss)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func loadAndConfigurePlugin(t *testing.T, passwords tpmutil.TPMPasswords) nodeattestor.NodeAttestor {
+	devicePath := tpmDevicePath
+	if isWindows {
+		devicePath = ""
+	}
+	config := fmt.Sprintf(`
+		tpm_device_path = %q
+		owner_hierarchy_password = %q
+		endorsement_hierarchy_password = %q`,
+		devicePath,
+		passwords.OwnerHierarchy,
+		passwords.EndorsementHierarchy,
+	)
+
+	na := new(nodeattestor.V1)
+	plugintest.Load(t, tpm.BuiltIn(), na, plugintest.Configure(config))
+	return na
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/google/go-tpm-tools/client"
 	"github.com/google/go-tpm/tpm2"
@@ -23,7 +24,8 @@ const randomPasswordSize = 32
 
 // Session represents a TPM with loaded DevID credentials and exposes methods
 // to perfom cryptographyc operations relevant to the SPIRE node attestation
-// workflow.
+// workflow. The DevID credentials are optional, a session without them can
+// still be used for EK and AK based attestation.
 type Session struct {
 	devID    *SigningKey
 	ak       *SigningKey
@@ -90,14 +92,16 @@ func NewSession(scfg *SessionConfig) (*Session, error) {
 		return nil, fmt.Errorf("cannot generate random password for storage root key: %w", err)
 	}
 
-	// Load DevID
-	tpm.devID, err = tpm.loadKey(
-		scfg.DevIDPub,
-		scfg.DevIDPriv,
-		srkPassword,
-		scfg.Passwords.DevIDKey)
-	if err != nil {
-		return nil, fmt.Errorf("cannot load DevID key on TPM: %w", err)
+	// Load DevID (if provided)
+	if len(scfg.DevIDPub) != 0 || len(scfg.DevIDPriv) != 0 {
+		tpm.devID, err = tpm.loadKey(
+			scfg.DevIDPub,
+			scfg.DevIDPriv,
+			srkPassword,
+			scfg.Passwords.DevIDKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load DevID key on TPM: %w", err)
+		}
 	}
 
 	// Create Attestation Key
@@ -170,6 +174,10 @@ func (c *Session) Close() {
 // SolveDevIDChallenge requests the TPM to sign the provided nonce using the loaded
 // DevID credentials.
 func (c *Session) SolveDevIDChallenge(nonce []byte) ([]byte, error) {
+	if c.devID == nil {
+		return nil, errors.New("no DevID key loaded")
+	}
+
 	signedNonce, err := c.devID.Sign(nonce)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign nonce: %w", err)
@@ -210,9 +218,56 @@ func (c *Session) SolveCredActivationChallenge(credentialBlob, secret []byte) ([
 // CertifyDevIDKey proves that the DevID Key is in the same TPM than
 // Attestation Key.
 func (c *Session) CertifyDevIDKey() ([]byte, []byte, error) {
+	if c.devID == nil {
+		return nil, nil, errors.New("no DevID key loaded")
+	}
+
 	return c.ak.Certify(c.devID.Handle, c.devID.password)
 }
 
+// Quote requests the TPM to quote the given PCRs (SHA-256 bank) using the
+// attestation key. The nonce is included as qualifying data in the quote.
+// It returns the attestation data and the signature in TPM wire format.
+func (c *Session) Quote(nonce []byte, pcrs []int) ([]byte, []byte, error) {
+	sel := tpm2.PCRSelection{
+		Hash: tpm2.AlgSHA256,
+		PCRs: pcrs,
+	}
+
+	var attestation, signature []byte
+	var err error
+	for i := 1; i <= maxAttempts; i++ {
+		attestation, signature, err = tpm2.QuoteRaw(c.rwc, c.ak.Handle, c.ak.password, "", nonce, sel, tpm2.AlgNull)
+		switch {
+		case err == nil:
+			return attestation, signature, nil
+
+		case isRetry(err):
+			c.log.Warn(fmt.Sprintf("TPM was not able to start the command 'Quote'. Retrying: attempt (%d/%d)", i, maxAttempts))
+			time.Sleep(time.Millisecond * 500)
+
+		default:
+			return nil, nil, fmt.Errorf("tpm2.Quote failed: %w", err)
+		}
+	}
+
+	return nil, nil, fmt.Errorf("max attempts reached while trying to quote PCRs: %w", err)
+}
+
+// ReadPCRs returns the values of the given PCRs from the SHA-256 bank.
+func (c *Session) ReadPCRs(pcrs []int) (map[int][]byte, error) {
+	values := make(map[int][]byte, len(pcrs))
+	for _, pcr := range pcrs {
+		value, err := tpm2.ReadPCR(c.rwc, pcr, tpm2.AlgSHA256)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read PCR %d: %w", pcr, err)
+		}
+		values[pcr] = value
+	}
+
+	return values, nil
+}
+
 // GetEKCert returns TPM endorsement certificate.
 func (c *Session) GetEKCert() ([]byte, error) {
 	ekCertAndTrailingBytes, err := tpm2.NVRead(c.rwc, EKCertificateHandleRSA)
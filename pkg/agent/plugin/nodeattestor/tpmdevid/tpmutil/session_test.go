@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-tpm/tpm2"
 	"github.com/hashicorp/go-hclog"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	server_tpm "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpm"
 	server_devid "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
 	"github.com/spiffe/spire/test/tpmsimulator"
 	"github.com/stretchr/testify/assert"
@@ -84,9 +85,11 @@ func TestNewSession(t *testing.T) {
 		{
 			name:          "NewSession fails if a wrong device path is provided",
 			expErr:        `cannot open TPM at "": unexpected TPM device path "" (expected "/dev/tpmrm0")`,
-			expWindowsErr: "cannot load DevID key on TPM: tpm2.DecodePublic failed: decoding TPMT_PUBLIC: EOF",
+			expWindowsErr: "cannot load DevID key on TPM: tpm2.DecodePublic failed: decoding TPMT_PUBLIC: unexpected EOF",
 			scfg: &tpmutil.SessionConfig{
-				Log: hclog.NewNullLogger(),
+				DevIDPriv: []byte("not a private key blob"),
+				DevIDPub:  []byte("not a public key blob"),
+				Log:       hclog.NewNullLogger(),
 			},
 		},
 		{
@@ -151,6 +154,14 @@ func TestNewSession(t *testing.T) {
 				Passwords:  tpmPasswords,
 			},
 		},
+		{
+			name: "NewSession succeeds without DevID",
+			scfg: &tpmutil.SessionConfig{
+				DevicePath: "/dev/tpmrm0",
+				Log:        hclog.NewNullLogger(),
+				Passwords:  tpmPasswords,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,6 +192,7 @@ func TestNewSession(t *testing.T) {
 
 			require.NoError(t, err)
 			require.NotNil(t, tpm)
+			tpm.Close()
 		})
 	}
 }
@@ -342,6 +354,66 @@ func TestSolveCredActivationChallenge(t *testing.T) {
 	}
 }
 
+func TestSessionWithoutDevID(t *testing.T) {
+	setupSimulator(t)
+
+	var devicePath string
+	if !isWindows {
+		devicePath = "/dev/tpmrm0"
+	}
+	tpm, err := tpmutil.NewSession(&tpmutil.SessionConfig{
+		DevicePath: devicePath,
+		Log:        hclog.NewNullLogger(),
+		Passwords:  tpmPasswords,
+	})
+	require.NoError(t, err)
+	defer tpm.Close()
+
+	signedNonce, err := tpm.SolveDevIDChallenge([]byte("nonce"))
+	require.EqualError(t, err, "no DevID key loaded")
+	require.Nil(t, signedNonce)
+
+	attData, signature, err := tpm.CertifyDevIDKey()
+	require.EqualError(t, err, "no DevID key loaded")
+	require.Nil(t, attData)
+	require.Nil(t, signature)
+}
+
+func TestQuote(t *testing.T) {
+	setupSimulator(t)
+
+	var devicePath string
+	if !isWindows {
+		devicePath = "/dev/tpmrm0"
+	}
+	tpm, err := tpmutil.NewSession(&tpmutil.SessionConfig{
+		DevicePath: devicePath,
+		Log:        hclog.NewNullLogger(),
+		Passwords:  tpmPasswords,
+	})
+	require.NoError(t, err)
+	defer tpm.Close()
+
+	akPub, err := tpm2.DecodePublic(tpm.GetAKPublic())
+	require.NoError(t, err)
+
+	pcrs := []int{0, 1, 7}
+	pcrValues, err := tpm.ReadPCRs(pcrs)
+	require.NoError(t, err)
+	require.Len(t, pcrValues, len(pcrs))
+
+	nonce := []byte("nonce")
+	quote, signature, err := tpm.Quote(nonce, pcrs)
+	require.NoError(t, err)
+	require.NoError(t, server_tpm.VerifyQuote(&akPub, nonce, pcrs, pcrValues, quote, signature))
+
+	_, err = tpm.ReadPCRs([]int{100})
+	require.ErrorContains(t, err, "cannot read PCR 100")
+
+	_, _, err = tpm.Quote(make([]byte, 1025), pcrs)
+	require.ErrorContains(t, err, "tpm2.Quote failed")
+}
+
 func TestCertifyDevIDKey(t *testing.T) {
 	setupSimulator(t)
 
@@ -0,0 +1,313 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	svidstorev1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "disk"
+
+	defaultFileMode = 0600
+
+	svidFileName            = "svid.pem"
+	keyFileName             = "svid_key.pem"
+	bundleFileName          = "bundle.pem"
+	federatedBundleFileName = "federated_bundle.pem"
+)
+
+// storedFiles are the files written for each stored SVID. They are written
+// (and removed) in this order so the key always matches the certificate
+// once the certificate is in place.
+var storedFiles = []string{
+	bundleFileName,
+	federatedBundleFileName,
+	keyFileName,
+	svidFileName,
+}
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+type Configuration struct {
+	// Directory is the base directory under which SVIDs are stored
+	Directory string `hcl:"directory" json:"directory"`
+	// FileMode is the default mode, in octal, of the stored files
+	FileMode string `hcl:"file_mode" json:"file_mode"`
+	// UID is the default user ID owning the stored files
+	UID *int `hcl:"uid" json:"uid"`
+	// GID is the default group ID owning the stored files
+	GID *int `hcl:"gid" json:"gid"`
+
+	UnusedKeys []string `hcl:",unusedKeys" json:",omitempty"`
+}
+
+type config struct {
+	directory string
+	options   fileOptions
+}
+
+// fileOptions are the ownership and mode of the stored files. A negative
+// UID or GID leaves the ownership unchanged.
+type fileOptions struct {
+	mode os.FileMode
+	uid  int
+	gid  int
+}
+
+// Plugin is an SVIDStore that stores SVIDs as PEM files on disk, for
+// workloads that are unable to use the Workload API.
+type Plugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log hclog.Logger
+
+	mtx    sync.RWMutex
+	config *config
+}
+
+func New() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the disk SVIDStore plugin.
+func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	hclConfig := new(Configuration)
+	if err := hcl.Decode(hclConfig, req.HclConfiguration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if len(hclConfig.UnusedKeys) != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown configurations detected: %s", strings.Join(hclConfig.UnusedKeys, ","))
+	}
+
+	if hclConfig.Directory == "" {
+		return nil, status.Error(codes.InvalidArgument, "directory is required")
+	}
+
+	directory, err := filepath.Abs(hclConfig.Directory)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid directory: %v", err)
+	}
+
+	options := fileOptions{
+		mode: defaultFileMode,
+		uid:  -1,
+		gid:  -1,
+	}
+	if hclConfig.FileMode != "" {
+		options.mode, err = parseFileMode(hclConfig.FileMode)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid file_mode: %v", err)
+		}
+	}
+	if hclConfig.UID != nil {
+		options.uid = *hclConfig.UID
+	}
+	if hclConfig.GID != nil {
+		options.gid = *hclConfig.GID
+	}
+	if err := validateOwnership(options); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.config = &config{
+		directory: directory,
+		options:   options,
+	}
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+// PutX509SVID writes the X509-SVID, its key and the bundles to the directory
+// selected by the entry metadata. Each file is atomically replaced.
+func (p *Plugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, options, err := config.optionsFromMetadata(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create directory: %v", err)
+	}
+
+	contents := map[string]string{
+		svidFileName:            data.X509SVID,
+		keyFileName:             data.X509SVIDKey,
+		bundleFileName:          data.Bundle,
+		federatedBundleFileName: svidstore.ConcatFederatedBundles(data.FederatedBundles),
+	}
+	for _, fileName := range storedFiles {
+		if err := atomicWriteFile(filepath.Join(dir, fileName), []byte(contents[fileName]), options); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to write %q: %v", fileName, err)
+		}
+	}
+
+	p.log.With("directory", dir).Debug("SVID stored")
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID removes the files of a stored SVID. The directory is
+// removed as well if it is left empty.
+func (p *Plugin) DeleteX509SVID(ctx context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	config, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, _, err := config.optionsFromMetadata(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(storedFiles) - 1; i >= 0; i-- {
+		if err := os.Remove(filepath.Join(dir, storedFiles[i])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, status.Errorf(codes.Internal, "failed to remove %q: %v", storedFiles[i], err)
+		}
+	}
+
+	// The directory may contain other files, in which case it is kept
+	if err := os.Remove(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		p.log.With("directory", dir).With(telemetry.Error, err).Debug("Directory not removed")
+	}
+
+	p.log.With("directory", dir).Debug("SVID deleted")
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *Plugin) getConfig() (*config, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.config == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+	return p.config, nil
+}
+
+// optionsFromMetadata returns the directory and the file options for an
+// entry. The directory is taken from the "path" metadata, which must be
+// relative to the configured directory. The "mode", "uid" and "gid" metadata
+// override the configured file options.
+func (c *config) optionsFromMetadata(metadata []string) (string, fileOptions, error) {
+	data, err := svidstore.ParseMetadata(metadata)
+	if err != nil {
+		return "", fileOptions{}, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	path, ok := data["path"]
+	if !ok {
+		return "", fileOptions{}, status.Error(codes.InvalidArgument, "path is required")
+	}
+	dir, err := c.resolvePath(path)
+	if err != nil {
+		return "", fileOptions{}, status.Errorf(codes.InvalidArgument, "invalid path %q: %v", path, err)
+	}
+
+	options := c.options
+	if mode, ok := data["mode"]; ok {
+		options.mode, err = parseFileMode(mode)
+		if err != nil {
+			return "", fileOptions{}, status.Errorf(codes.InvalidArgument, "invalid mode: %v", err)
+		}
+	}
+	if uid, ok := data["uid"]; ok {
+		options.uid, err = parseID(uid)
+		if err != nil {
+			return "", fileOptions{}, status.Errorf(codes.InvalidArgument, "invalid uid: %v", err)
+		}
+	}
+	if gid, ok := data["gid"]; ok {
+		options.gid, err = parseID(gid)
+		if err != nil {
+			return "", fileOptions{}, status.Errorf(codes.InvalidArgument, "invalid gid: %v", err)
+		}
+	}
+	if err := validateOwnership(options); err != nil {
+		return "", fileOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return dir, options, nil
+}
+
+// resolvePath resolves a path relative to the configured directory. The path
+// must point to a subdirectory of the configured directory.
+func (c *config) resolvePath(path string) (string, error) {
+	if filepath.IsAbs(path) || filepath.VolumeName(path) != "" {
+		return "", errors.New("path must be relative")
+	}
+
+	dir := filepath.Join(c.directory, path)
+	rel, err := filepath.Rel(c.directory, dir)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("path must be a subdirectory of the configured directory")
+	}
+	return dir, nil
+}
+
+func parseFileMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an octal number", s)
+	}
+	if mode&^0777 != 0 {
+		return 0, fmt.Errorf("%q is not a valid permission mode", s)
+	}
+	if mode&0600 != 0600 {
+		return 0, fmt.Errorf("%q must grant read and write permissions to the owner", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%q is not a valid ID", s)
+	}
+	return id, nil
+}
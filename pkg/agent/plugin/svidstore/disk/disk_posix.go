@@ -0,0 +1,69 @@
+//go:build !windows
+// +build !windows
+
+package disk
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func validateOwnership(fileOptions) error {
+	return nil
+}
+
+// atomicWriteFile writes the data to a temporary file with the requested
+// ownership and mode, fsyncs it, and then renames it over the destination.
+func atomicWriteFile(path string, data []byte, options fileOptions) (err error) {
+	tmpPath := path + ".tmp"
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, options.mode)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(file, data, options); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+	return dir.Close()
+}
+
+func writeFile(file *os.File, data []byte, options fileOptions) error {
+	// The mode is set explicitly since the one given to OpenFile is subject
+	// to the umask and is not applied to existing files.
+	if err := file.Chmod(options.mode); err != nil {
+		return err
+	}
+	if options.uid >= 0 || options.gid >= 0 {
+		if err := file.Chown(options.uid, options.gid); err != nil {
+			return err
+		}
+	}
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+	return file.Sync()
+}
@@ -0,0 +1,341 @@
+package disk
+
+import (
+	"context"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	ctx = context.Background()
+
+	isWindows = runtime.GOOS == "windows"
+)
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		config          string
+		expectCode      codes.Code
+		expectMsgPrefix string
+		expectConfig    *config
+	}{
+		{
+			name:   "defaults",
+			config: `directory = "/svids"`,
+			expectConfig: &config{
+				directory: mustAbs(t, "/svids"),
+				options:   fileOptions{mode: 0600, uid: -1, gid: -1},
+			},
+		},
+		{
+			name:   "file mode",
+			config: `directory = "/svids" file_mode = "0640"`,
+			expectConfig: &config{
+				directory: mustAbs(t, "/svids"),
+				options:   fileOptions{mode: 0640, uid: -1, gid: -1},
+			},
+		},
+		{
+			name:            "missing directory",
+			config:          ``,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "directory is required",
+		},
+		{
+			name:            "malformed configuration",
+			config:          `not HCL`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unable to decode configuration",
+		},
+		{
+			name:            "unknown configuration",
+			config:          `directory = "/svids" foo = "bar"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unknown configurations detected: foo",
+		},
+		{
+			name:            "invalid file mode",
+			config:          `directory = "/svids" file_mode = "rw"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: `invalid file_mode: "rw" is not an octal number`,
+		},
+		{
+			name:            "file mode without owner read and write",
+			config:          `directory = "/svids" file_mode = "0440"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: `invalid file_mode: "0440" must grant read and write permissions to the owner`,
+		},
+		{
+			name:            "file mode with special bits",
+			config:          `directory = "/svids" file_mode = "4600"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: `invalid file_mode: "4600" is not a valid permission mode`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := New()
+
+			var err error
+			plugintest.Load(t, builtin(p), nil,
+				plugintest.CaptureConfigureError(&err),
+				plugintest.Configure(tt.config),
+			)
+			spiretest.RequireGRPCStatusHasPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if tt.expectCode != codes.OK {
+				return
+			}
+			require.Equal(t, tt.expectConfig, p.config)
+		})
+	}
+}
+
+func TestPutX509SVID(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	ss := loadPlugin(t, `directory = %q`, dir)
+
+	svid, federatedBundles := makeSVID(t)
+	err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:             svid,
+		Metadata:         []string{"path:app/one"},
+		FederatedBundles: federatedBundles,
+	})
+	require.NoError(t, err)
+
+	svidDir := filepath.Join(dir, "app", "one")
+	requireCertificates(t, filepath.Join(svidDir, "svid.pem"), svid.CertChain)
+	requireCertificates(t, filepath.Join(svidDir, "bundle.pem"), svid.Bundle)
+	requireCertificates(t, filepath.Join(svidDir, "federated_bundle.pem"), append(federatedBundles["spiffe://a.test"], federatedBundles["spiffe://b.test"]...))
+
+	key, err := pemutil.LoadPrivateKey(filepath.Join(svidDir, "svid_key.pem"))
+	require.NoError(t, err)
+	require.Equal(t, svid.PrivateKey, key)
+
+	if !isWindows {
+		for _, fileName := range storedFiles {
+			requireMode(t, filepath.Join(svidDir, fileName), 0600)
+		}
+	}
+
+	// Store again, replacing the files
+	svid, _ = makeSVID(t)
+	err = ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:     svid,
+		Metadata: []string{"path:app/one"},
+	})
+	require.NoError(t, err)
+	requireCertificates(t, filepath.Join(svidDir, "svid.pem"), svid.CertChain)
+	requireFileContent(t, filepath.Join(svidDir, "federated_bundle.pem"), "")
+}
+
+func TestPutX509SVIDFileMode(t *testing.T) {
+	if isWindows {
+		t.Skip("file modes are not supported on windows")
+	}
+
+	dir := spiretest.TempDir(t)
+	ss := loadPlugin(t, `directory = %q file_mode = "0640"`, dir)
+
+	svid, _ := makeSVID(t)
+
+	// Configured mode
+	err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:     svid,
+		Metadata: []string{"path:configured"},
+	})
+	require.NoError(t, err)
+	requireMode(t, filepath.Join(dir, "configured", "svid_key.pem"), 0640)
+
+	// Mode overridden by the entry and ownership set to the current user
+	err = ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID: svid,
+		Metadata: []string{
+			"path:overridden",
+			"mode:0644",
+			"uid:" + strconv.Itoa(os.Getuid()),
+			"gid:" + strconv.Itoa(os.Getgid()),
+		},
+	})
+	require.NoError(t, err)
+	requireMode(t, filepath.Join(dir, "overridden", "svid_key.pem"), 0644)
+}
+
+func TestPutX509SVIDFailures(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	svid, _ := makeSVID(t)
+
+	for _, tt := range []struct {
+		name      string
+		metadata  []string
+		expectMsg string
+	}{
+		{
+			name:      "missing path",
+			metadata:  []string{"mode:0600"},
+			expectMsg: "svidstore(disk): path is required",
+		},
+		{
+			name:      "invalid metadata",
+			metadata:  []string{"path"},
+			expectMsg: `svidstore(disk): invalid metadata: metadata does not contain a colon: "path"`,
+		},
+		{
+			name:      "absolute path",
+			metadata:  []string{"path:" + filepath.Join(dir, "abs")},
+			expectMsg: "svidstore(disk): invalid path",
+		},
+		{
+			name:      "path escapes directory",
+			metadata:  []string{"path:../escape"},
+			expectMsg: `svidstore(disk): invalid path "../escape": path must be a subdirectory of the configured directory`,
+		},
+		{
+			name:      "path is the directory",
+			metadata:  []string{"path:a/.."},
+			expectMsg: `svidstore(disk): invalid path "a/..": path must be a subdirectory of the configured directory`,
+		},
+		{
+			name:      "invalid mode",
+			metadata:  []string{"path:a", "mode:999"},
+			expectMsg: `svidstore(disk): invalid mode: "999" is not an octal number`,
+		},
+		{
+			name:      "invalid uid",
+			metadata:  []string{"path:a", "uid:-1"},
+			expectMsg: `svidstore(disk): invalid uid: "-1" is not a valid ID`,
+		},
+		{
+			name:      "invalid gid",
+			metadata:  []string{"path:a", "gid:root"},
+			expectMsg: `svidstore(disk): invalid gid: "root" is not a valid ID`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ss := loadPlugin(t, `directory = %q`, dir)
+			err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+				SVID:     svid,
+				Metadata: tt.metadata,
+			})
+			spiretest.RequireGRPCStatusHasPrefix(t, err, codes.InvalidArgument, tt.expectMsg)
+		})
+	}
+}
+
+func TestDeleteX509SVID(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	ss := loadPlugin(t, `directory = %q`, dir)
+
+	svid, _ := makeSVID(t)
+	for _, path := range []string{"one", "two"} {
+		err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+			SVID:     svid,
+			Metadata: []string{"path:" + path},
+		})
+		require.NoError(t, err)
+	}
+
+	// The directory is removed when it is left empty
+	err := ss.DeleteX509SVID(ctx, []string{"path:one"})
+	require.NoError(t, err)
+	require.NoDirExists(t, filepath.Join(dir, "one"))
+
+	// Files not managed by the plugin are kept
+	otherFile := filepath.Join(dir, "two", "other.txt")
+	require.NoError(t, os.WriteFile(otherFile, []byte("other"), 0600))
+	err = ss.DeleteX509SVID(ctx, []string{"path:two"})
+	require.NoError(t, err)
+	require.FileExists(t, otherFile)
+	for _, fileName := range storedFiles {
+		require.NoFileExists(t, filepath.Join(dir, "two", fileName))
+	}
+
+	// Deleting an SVID that does not exist succeeds
+	err = ss.DeleteX509SVID(ctx, []string{"path:three"})
+	require.NoError(t, err)
+
+	err = ss.DeleteX509SVID(ctx, []string{"path:../escape"})
+	spiretest.RequireGRPCStatusHasPrefix(t, err, codes.InvalidArgument, "svidstore(disk): invalid path")
+}
+
+func TestNotConfigured(t *testing.T) {
+	ss := new(svidstore.V1)
+	plugintest.Load(t, BuiltIn(), ss)
+
+	svid, _ := makeSVID(t)
+	err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:     svid,
+		Metadata: []string{"path:one"},
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(disk): not configured")
+
+	err = ss.DeleteX509SVID(ctx, []string{"path:one"})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(disk): not configured")
+}
+
+func loadPlugin(t *testing.T, configFmt string, args ...interface{}) *svidstore.V1 {
+	ss := new(svidstore.V1)
+	plugintest.Load(t, BuiltIn(), ss,
+		plugintest.Configuref(configFmt, args...),
+	)
+	return ss
+}
+
+func makeSVID(t *testing.T) (*svidstore.SVID, map[string][]*x509.Certificate) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	ca := testca.New(t, td)
+	id := spiffeid.RequireFromPath(td, "/workload")
+	x509SVID := ca.CreateX509SVID(id)
+
+	federatedBundles := map[string][]*x509.Certificate{
+		"spiffe://b.test": testca.New(t, spiffeid.RequireTrustDomainFromString("b.test")).X509Authorities(),
+		"spiffe://a.test": testca.New(t, spiffeid.RequireTrustDomainFromString("a.test")).X509Authorities(),
+	}
+
+	return &svidstore.SVID{
+		SPIFFEID:   id,
+		CertChain:  x509SVID.Certificates,
+		PrivateKey: x509SVID.PrivateKey,
+		Bundle:     ca.X509Authorities(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, federatedBundles
+}
+
+func requireCertificates(t *testing.T, path string, expected []*x509.Certificate) {
+	certs, err := pemutil.LoadCertificates(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, certs)
+}
+
+func requireFileContent(t *testing.T, path string, expected string) {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(content))
+}
+
+func requireMode(t *testing.T, path string, mode os.FileMode) {
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, mode, info.Mode().Perm())
+}
+
+func mustAbs(t *testing.T, path string) string {
+	abs, err := filepath.Abs(path)
+	require.NoError(t, err)
+	return abs
+}
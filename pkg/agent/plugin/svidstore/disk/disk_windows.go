@@ -0,0 +1,23 @@
+//go:build windows
+// +build windows
+
+package disk
+
+import (
+	"errors"
+
+	"github.com/spiffe/spire/pkg/common/diskutil"
+)
+
+func validateOwnership(options fileOptions) error {
+	if options.uid >= 0 || options.gid >= 0 {
+		return errors.New("uid and gid are not supported on windows")
+	}
+	return nil
+}
+
+// atomicWriteFile writes the data to a private file. File modes are not
+// supported on windows.
+func atomicWriteFile(path string, data []byte, _ fileOptions) error {
+	return diskutil.AtomicWritePrivateFile(path, data)
+}
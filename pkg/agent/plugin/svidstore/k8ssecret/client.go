@@ -0,0 +1,23 @@
+package k8ssecret
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func newKubeClient(kubeConfigPath string) (kubernetes.Interface, error) {
+	config, err := getKubeConfig(kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+func getKubeConfig(kubeConfigPath string) (*rest.Config, error) {
+	if kubeConfigPath != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeConfigPath)
+	}
+	return rest.InClusterConfig()
+}
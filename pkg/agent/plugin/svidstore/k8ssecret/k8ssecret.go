@@ -0,0 +1,278 @@
+package k8ssecret
+
+import (
+	"context"
+	"crypto/sha1" //nolint: gosec // We use sha1 to hash trust domain names in 40 characters to fit label value restrictions
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	svidstorev1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	pluginName = "k8s_secret"
+
+	// spireSVIDLabel is the label set on the secrets managed by this plugin.
+	// Its value is the hash of the trust domain.
+	spireSVIDLabel = "spiffe.io/spire-svid"
+
+	// Secret data keys. The certificate and key use the kubernetes.io/tls
+	// secret type keys.
+	svidKey            = corev1.TLSCertKey
+	svidPrivateKeyKey  = corev1.TLSPrivateKeyKey
+	bundleKey          = "ca.crt"
+	federatedBundleKey = "federated-ca.crt"
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+func New() *Plugin {
+	return newPlugin(newKubeClient)
+}
+
+func newPlugin(newKubeClient func(string) (kubernetes.Interface, error)) *Plugin {
+	p := &Plugin{}
+	p.hooks.newKubeClient = newKubeClient
+
+	return p
+}
+
+type Configuration struct {
+	// KubeConfigFilePath is the path to a kubeconfig file. If unset, the
+	// in-cluster configuration is used.
+	KubeConfigFilePath string `hcl:"kube_config_file_path" json:"kube_config_file_path"`
+	// Namespace is the namespace used when an entry does not select one
+	Namespace string `hcl:"namespace" json:"namespace"`
+
+	UnusedKeys []string `hcl:",unusedKeys" json:",omitempty"`
+}
+
+// Plugin is an SVIDStore that stores SVIDs in Kubernetes secrets of type
+// kubernetes.io/tls.
+type Plugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log        hclog.Logger
+	mtx        sync.RWMutex
+	kubeClient kubernetes.Interface
+	namespace  string
+	tdHash     string
+
+	hooks struct {
+		newKubeClient func(string) (kubernetes.Interface, error)
+	}
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the k8s_secret plugin.
+func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	config := &Configuration{}
+	if err := hcl.Decode(config, req.HclConfiguration); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if len(config.UnusedKeys) != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown configurations detected: %s", strings.Join(config.UnusedKeys, ","))
+	}
+
+	kubeClient, err := p.hooks.newKubeClient(config.KubeConfigFilePath)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create kubernetes client: %v", err)
+	}
+
+	// Label values are limited to 63 characters, hash td as label value
+	tdHash := sha1.Sum([]byte(req.CoreConfiguration.TrustDomain)) //nolint: gosec // We use sha1 to hash trust domain names in 40 characters to fit label value restrictions
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.kubeClient = kubeClient
+	p.namespace = config.Namespace
+	p.tdHash = hex.EncodeToString(tdHash[:])
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+// PutX509SVID creates or updates the secret selected by the entry metadata
+// with the specified X509-SVID.
+func (p *Plugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	kubeClient, tdHash, opt, err := p.getClientAndOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	secrets := kubeClient.CoreV1().Secrets(opt.namespace)
+	secret, found, err := getSecret(ctx, secrets, opt.name, tdHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      opt.name,
+				Namespace: opt.namespace,
+				Labels: map[string]string{
+					spireSVIDLabel: tdHash,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+		}
+	}
+	secret.Data = map[string][]byte{
+		svidKey:            []byte(secretData.X509SVID),
+		svidPrivateKeyKey:  []byte(secretData.X509SVIDKey),
+		bundleKey:          []byte(secretData.Bundle),
+		federatedBundleKey: []byte(svidstore.ConcatFederatedBundles(secretData.FederatedBundles)),
+	}
+
+	log := p.log.With("namespace", opt.namespace).With("secret_name", opt.name)
+	if !found {
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create secret: %v", err)
+		}
+		log.Debug("Secret created")
+		return &svidstorev1.PutX509SVIDResponse{}, nil
+	}
+
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update secret: %v", err)
+	}
+	log.Debug("Secret updated")
+
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID deletes the secret selected by the entry metadata.
+func (p *Plugin) DeleteX509SVID(ctx context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	kubeClient, tdHash, opt, err := p.getClientAndOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	log := p.log.With("namespace", opt.namespace).With("secret_name", opt.name)
+
+	secrets := kubeClient.CoreV1().Secrets(opt.namespace)
+	secret, found, err := getSecret(ctx, secrets, opt.name, tdHash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		log.Debug("Secret to delete not found")
+		return &svidstorev1.DeleteX509SVIDResponse{}, nil
+	}
+
+	err = secrets.Delete(ctx, opt.name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{
+			UID:             &secret.UID,
+			ResourceVersion: &secret.ResourceVersion,
+		},
+	})
+	switch {
+	case err == nil:
+		log.Debug("Secret deleted")
+	case k8serrors.IsNotFound(err):
+		log.Debug("Secret to delete not found")
+	default:
+		return nil, status.Errorf(codes.Internal, "failed to delete secret: %v", err)
+	}
+
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *Plugin) getClientAndOptions(metadata []string) (kubernetes.Interface, string, *secretOptions, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.kubeClient == nil {
+		return nil, "", nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	opt, err := optionsFromSecretData(metadata, p.namespace)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return p.kubeClient, p.tdHash, opt, nil
+}
+
+type secretGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Secret, error)
+}
+
+// getSecret gets the secret and validates that it is managed by this SPIRE
+// deployment, using the spire-svid label. It returns false if not found.
+func getSecret(ctx context.Context, secrets secretGetter, name string, tdHash string) (*corev1.Secret, bool, error) {
+	secret, err := secrets.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if secret.Labels[spireSVIDLabel] != tdHash {
+			return nil, false, status.Error(codes.InvalidArgument, "secret is not managed by this SPIRE deployment")
+		}
+		return secret, true, nil
+	case k8serrors.IsNotFound(err):
+		return nil, false, nil
+	default:
+		return nil, false, status.Errorf(codes.Internal, "failed to get secret: %v", err)
+	}
+}
+
+type secretOptions struct {
+	name      string
+	namespace string
+}
+
+func optionsFromSecretData(selectorData []string, defaultNamespace string) (*secretOptions, error) {
+	data, err := svidstore.ParseMetadata(selectorData)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	name, ok := data["name"]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	namespace, ok := data["namespace"]
+	if !ok {
+		namespace = defaultNamespace
+	}
+	if namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+
+	return &secretOptions{
+		name:      name,
+		namespace: namespace,
+	}, nil
+}
@@ -0,0 +1,361 @@
+package k8ssecret
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const (
+	// sha1 of "example.org"
+	exampleOrgHash = "20116dfd6774a9e7b32eddfea3f6cb094e38fc3f"
+)
+
+var ctx = context.Background()
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		config          string
+		clientErr       error
+		expectCode      codes.Code
+		expectMsgPrefix string
+		expectPath      string
+	}{
+		{
+			name:   "success",
+			config: `namespace = "spire"`,
+		},
+		{
+			name:       "kubeconfig path",
+			config:     `kube_config_file_path = "/kubeconfig"`,
+			expectPath: "/kubeconfig",
+		},
+		{
+			name:            "malformed configuration",
+			config:          `not HCL`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unable to decode configuration",
+		},
+		{
+			name:            "unknown configuration",
+			config:          `foo = "bar"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unknown configurations detected: foo",
+		},
+		{
+			name:            "failed to create client",
+			clientErr:       errors.New("oh no"),
+			expectCode:      codes.Internal,
+			expectMsgPrefix: "failed to create kubernetes client: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			p := newPlugin(func(path string) (kubernetes.Interface, error) {
+				gotPath = path
+				if tt.clientErr != nil {
+					return nil, tt.clientErr
+				}
+				return fake.NewSimpleClientset(), nil
+			})
+
+			var err error
+			plugintest.Load(t, builtin(p), nil,
+				plugintest.CaptureConfigureError(&err),
+				plugintest.CoreConfig(catalog.CoreConfig{
+					TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+				}),
+				plugintest.Configure(tt.config),
+			)
+			spiretest.RequireGRPCStatusHasPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if tt.expectCode != codes.OK {
+				return
+			}
+			require.Equal(t, tt.expectPath, gotPath)
+			require.NotNil(t, p.kubeClient)
+		})
+	}
+}
+
+func TestPutX509SVID(t *testing.T) {
+	svid, federatedBundles := makeSVID(t)
+
+	for _, tt := range []struct {
+		name           string
+		config         string
+		metadata       []string
+		existingSecret *corev1.Secret
+		reactorErr     error
+		expectCode     codes.Code
+		expectMsg      string
+		expectSecret   *corev1.Secret
+	}{
+		{
+			name:         "secret is created",
+			metadata:     []string{"name:svid", "namespace:ns"},
+			expectSecret: expectedSecret("ns", "svid", svid, federatedBundles),
+		},
+		{
+			name:         "secret is created in the configured namespace",
+			config:       `namespace = "spire"`,
+			metadata:     []string{"name:svid"},
+			expectSecret: expectedSecret("spire", "svid", svid, federatedBundles),
+		},
+		{
+			name:     "secret is updated",
+			metadata: []string{"name:svid", "namespace:ns"},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svid",
+					Namespace: "ns",
+					Labels: map[string]string{
+						spireSVIDLabel: exampleOrgHash,
+						"other":        "label",
+					},
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{"tls.crt": []byte("old")},
+			},
+			expectSecret: func() *corev1.Secret {
+				secret := expectedSecret("ns", "svid", svid, federatedBundles)
+				secret.Labels["other"] = "label"
+				return secret
+			}(),
+		},
+		{
+			name:     "secret not managed by SPIRE",
+			metadata: []string{"name:svid", "namespace:ns"},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svid",
+					Namespace: "ns",
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(k8s_secret): secret is not managed by this SPIRE deployment",
+		},
+		{
+			name:       "missing name",
+			metadata:   []string{"namespace:ns"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(k8s_secret): name is required",
+		},
+		{
+			name:       "missing namespace",
+			metadata:   []string{"name:svid"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(k8s_secret): namespace is required",
+		},
+		{
+			name:       "invalid metadata",
+			metadata:   []string{"name"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `svidstore(k8s_secret): invalid metadata: metadata does not contain a colon: "name"`,
+		},
+		{
+			name:       "failed to get secret",
+			metadata:   []string{"name:svid", "namespace:ns"},
+			reactorErr: errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "svidstore(k8s_secret): failed to get secret: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient(tt.existingSecret, tt.reactorErr)
+			ss := loadPlugin(t, client, tt.config)
+
+			err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+				SVID:             svid,
+				Metadata:         tt.metadata,
+				FederatedBundles: federatedBundles,
+			})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			if tt.expectCode != codes.OK {
+				return
+			}
+
+			secret, err := client.CoreV1().Secrets(tt.expectSecret.Namespace).Get(ctx, tt.expectSecret.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectSecret, secret)
+		})
+	}
+}
+
+func TestDeleteX509SVID(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		metadata       []string
+		existingSecret *corev1.Secret
+		reactorErr     error
+		expectCode     codes.Code
+		expectMsg      string
+		expectDeleted  bool
+	}{
+		{
+			name:     "secret is deleted",
+			metadata: []string{"name:svid", "namespace:ns"},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svid",
+					Namespace: "ns",
+					Labels:    map[string]string{spireSVIDLabel: exampleOrgHash},
+				},
+			},
+			expectDeleted: true,
+		},
+		{
+			name:     "secret not found",
+			metadata: []string{"name:svid", "namespace:ns"},
+		},
+		{
+			name:     "secret not managed by SPIRE",
+			metadata: []string{"name:svid", "namespace:ns"},
+			existingSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "svid",
+					Namespace: "ns",
+					Labels:    map[string]string{spireSVIDLabel: "another-trust-domain"},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(k8s_secret): secret is not managed by this SPIRE deployment",
+		},
+		{
+			name:       "missing name",
+			metadata:   []string{"namespace:ns"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(k8s_secret): name is required",
+		},
+		{
+			name:       "failed to get secret",
+			metadata:   []string{"name:svid", "namespace:ns"},
+			reactorErr: errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "svidstore(k8s_secret): failed to get secret: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient(tt.existingSecret, tt.reactorErr)
+			ss := loadPlugin(t, client, "")
+
+			err := ss.DeleteX509SVID(ctx, tt.metadata)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+
+			if tt.existingSecret == nil {
+				return
+			}
+			_, err = client.CoreV1().Secrets("ns").Get(ctx, "svid", metav1.GetOptions{})
+			if tt.expectDeleted {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	ss := new(svidstore.V1)
+	plugintest.Load(t, BuiltIn(), ss)
+
+	svid, _ := makeSVID(t)
+	err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:     svid,
+		Metadata: []string{"name:svid"},
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(k8s_secret): not configured")
+
+	err = ss.DeleteX509SVID(ctx, []string{"name:svid"})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(k8s_secret): not configured")
+}
+
+func loadPlugin(t *testing.T, client kubernetes.Interface, config string) *svidstore.V1 {
+	p := newPlugin(func(string) (kubernetes.Interface, error) {
+		return client, nil
+	})
+
+	ss := new(svidstore.V1)
+	plugintest.Load(t, builtin(p), ss,
+		plugintest.CoreConfig(catalog.CoreConfig{
+			TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		}),
+		plugintest.Configure(config),
+	)
+	return ss
+}
+
+func newFakeClient(existingSecret *corev1.Secret, getErr error) *fake.Clientset {
+	var objects []runtime.Object
+	if existingSecret != nil {
+		objects = append(objects, existingSecret)
+	}
+	client := fake.NewSimpleClientset(objects...)
+	if getErr != nil {
+		client.PrependReactor("get", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, getErr
+		})
+	}
+	return client
+}
+
+func expectedSecret(namespace, name string, svid *svidstore.SVID, federatedBundles map[string][]*x509.Certificate) *corev1.Secret {
+	keyPEM, _ := pemutil.EncodePKCS8PrivateKey(svid.PrivateKey)
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				spireSVIDLabel: exampleOrgHash,
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt":          pemutil.EncodeCertificates(svid.CertChain),
+			"tls.key":          keyPEM,
+			"ca.crt":           pemutil.EncodeCertificates(svid.Bundle),
+			"federated-ca.crt": pemutil.EncodeCertificates(append(federatedBundles["spiffe://a.test"], federatedBundles["spiffe://b.test"]...)),
+		},
+	}
+}
+
+func makeSVID(t *testing.T) (*svidstore.SVID, map[string][]*x509.Certificate) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	ca := testca.New(t, td)
+	id := spiffeid.RequireFromPath(td, "/workload")
+	x509SVID := ca.CreateX509SVID(id)
+
+	federatedBundles := map[string][]*x509.Certificate{
+		"spiffe://b.test": testca.New(t, spiffeid.RequireTrustDomainFromString("b.test")).X509Authorities(),
+		"spiffe://a.test": testca.New(t, spiffeid.RequireTrustDomainFromString("a.test")).X509Authorities(),
+	}
+
+	return &svidstore.SVID{
+		SPIFFEID:   id,
+		CertChain:  x509SVID.Certificates,
+		PrivateKey: x509SVID.PrivateKey,
+		Bundle:     ca.X509Authorities(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, federatedBundles
+}
@@ -3,6 +3,7 @@ package svidstore
 import (
 	"crypto/x509"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spiffe/spire/pkg/common/pemutil"
@@ -74,6 +75,22 @@ func ParseMetadata(metaData []string) (map[string]string, error) {
 	return data, nil
 }
 
+// ConcatFederatedBundles concatenates PEM encoded federated bundles, keyed by
+// trust domain, in trust domain order
+func ConcatFederatedBundles(federatedBundles map[string]string) string {
+	trustDomains := make([]string, 0, len(federatedBundles))
+	for td := range federatedBundles {
+		trustDomains = append(trustDomains, td)
+	}
+	sort.Strings(trustDomains)
+
+	var b strings.Builder
+	for _, td := range trustDomains {
+		b.WriteString(federatedBundles[td])
+	}
+	return b.String()
+}
+
 func rawKeyToPem(rawKey []byte) (string, error) {
 	key, err := x509.ParsePKCS8PrivateKey(rawKey)
 	if err != nil {
@@ -250,3 +250,12 @@ func TestSecretFromProto(t *testing.T) {
 		})
 	}
 }
+
+func TestConcatFederatedBundles(t *testing.T) {
+	require.Empty(t, svidstore.ConcatFederatedBundles(nil))
+	require.Equal(t, "A\nB\nC\n", svidstore.ConcatFederatedBundles(map[string]string{
+		"spiffe://c.test": "C\n",
+		"spiffe://a.test": "A\n",
+		"spiffe://b.test": "B\n",
+	}))
+}
@@ -0,0 +1,142 @@
+package vaultkv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	vapi "github.com/hashicorp/vault/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginFunc authenticates against Vault, returning the auth secret
+type loginFunc func(ctx context.Context, client *vapi.Client) (*vapi.Secret, error)
+
+// kvClient is a Vault client for the KV v2 secrets engine. When a login
+// function is provided, the client logs in lazily and logs in again when
+// Vault denies a request, which happens when the token expires.
+type kvClient struct {
+	client *vapi.Client
+	login  loginFunc
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+func newKVClient(config *Configuration) (*kvClient, error) {
+	vaultConfig := vapi.DefaultConfig()
+	vaultConfig.Address = config.VaultAddr
+
+	tlsConfig := &vapi.TLSConfig{
+		CACert:   config.CACertPath,
+		Insecure: config.InsecureSkipVerify,
+	}
+	if err := vaultConfig.ConfigureTLS(tlsConfig); err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+
+	client, err := vapi.NewClient(vaultConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Do not pick the token from the environment unless token authentication
+	// is configured
+	client.ClearToken()
+
+	if config.Namespace != "" {
+		client.SetNamespace(config.Namespace)
+	}
+
+	c := &kvClient{client: client}
+	switch {
+	case config.TokenAuth != nil:
+		client.SetToken(config.TokenAuth.Token)
+	case config.AppRoleAuth != nil:
+		c.login = appRoleLogin(config.AppRoleAuth)
+	case config.K8sAuth != nil:
+		c.login = k8sLogin(config.K8sAuth)
+	}
+
+	return c, nil
+}
+
+// kv runs the function with a client for the KV v2 secrets engine mounted on
+// the given mount point. Errors returned by the function that are not gRPC
+// status errors are returned as Internal.
+func (c *kvClient) kv(ctx context.Context, mount string, fn func(kv *vapi.KVv2) error) error {
+	if err := c.ensureLoggedIn(ctx, false); err != nil {
+		return err
+	}
+
+	err := fn(c.client.KVv2(mount))
+	if c.login != nil && isPermissionDenied(err) {
+		// The token may have expired, log in again and retry
+		if err := c.ensureLoggedIn(ctx, true); err != nil {
+			return err
+		}
+		err = fn(c.client.KVv2(mount))
+	}
+	if _, ok := status.FromError(err); !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return err
+}
+
+func (c *kvClient) ensureLoggedIn(ctx context.Context, force bool) error {
+	if c.login == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loggedIn && !force {
+		return nil
+	}
+
+	secret, err := c.login(ctx, c.client)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to authenticate to Vault: %v", err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return status.Error(codes.Internal, "failed to authenticate to Vault: authentication response has no token")
+	}
+
+	c.client.SetToken(secret.Auth.ClientToken)
+	c.loggedIn = true
+	return nil
+}
+
+func appRoleLogin(config *AppRoleAuthConfig) loginFunc {
+	return func(ctx context.Context, client *vapi.Client) (*vapi.Secret, error) {
+		path := fmt.Sprintf("auth/%s/login", config.AppRoleMountPoint)
+		return client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+			"role_id":   config.RoleID,
+			"secret_id": config.SecretID,
+		})
+	}
+}
+
+func k8sLogin(config *K8sAuthConfig) loginFunc {
+	return func(ctx context.Context, client *vapi.Client) (*vapi.Secret, error) {
+		// The token is read on every login since it is rotated by kubelet
+		jwt, err := os.ReadFile(config.TokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account token: %w", err)
+		}
+		path := fmt.Sprintf("auth/%s/login", config.K8sMountPoint)
+		return client.Logical().WriteWithContext(ctx, path, map[string]interface{}{
+			"role": config.RoleName,
+			"jwt":  string(jwt),
+		})
+	}
+}
+
+func isPermissionDenied(err error) bool {
+	var respErr *vapi.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,206 @@
+package vaultkv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	fakeRootToken = "root-token"
+)
+
+type fakeSecret struct {
+	customMetadata map[string]interface{}
+	data           map[string]interface{}
+	version        int
+}
+
+// fakeVaultServer is a minimal in-memory Vault server implementing the
+// AppRole and Kubernetes login endpoints and the KV v2 secrets engine
+// endpoints used by the plugin.
+type fakeVaultServer struct {
+	mu      sync.Mutex
+	secrets map[string]*fakeSecret
+	tokens  map[string]bool
+	logins  int
+
+	// kvErr, if set, is returned as a 500 on any KV request
+	kvErr string
+}
+
+func newFakeVaultServer(t *testing.T) (*fakeVaultServer, string) {
+	f := &fakeVaultServer{
+		secrets: make(map[string]*fakeSecret),
+		tokens:  map[string]bool{fakeRootToken: true},
+	}
+	server := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(server.Close)
+	return f, server.URL
+}
+
+func (f *fakeVaultServer) addSecret(mountAndPath string, customMetadata map[string]interface{}, data map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.secrets[mountAndPath] = &fakeSecret{
+		customMetadata: customMetadata,
+		data:           data,
+		version:        1,
+	}
+}
+
+func (f *fakeVaultServer) getSecret(mountAndPath string) *fakeSecret {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.secrets[mountAndPath]
+}
+
+// revokeTokens revokes all the tokens issued on login
+func (f *fakeVaultServer) revokeTokens() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens = map[string]bool{fakeRootToken: true}
+}
+
+func (f *fakeVaultServer) loginCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.logins
+}
+
+func (f *fakeVaultServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if strings.HasPrefix(path, "auth/") {
+		f.serveLogin(w, r, path)
+		return
+	}
+
+	if !f.tokens[r.Header.Get("X-Vault-Token")] {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	if f.kvErr != "" {
+		writeError(w, http.StatusInternalServerError, f.kvErr)
+		return
+	}
+
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) != 3 {
+		writeError(w, http.StatusNotFound, "unsupported path")
+		return
+	}
+	mount, kind, secretPath := parts[0], parts[1], parts[2]
+	key := mount + "/" + secretPath
+	secret := f.secrets[key]
+
+	switch {
+	case kind == "metadata" && r.Method == http.MethodGet:
+		if secret == nil {
+			writeError(w, http.StatusNotFound)
+			return
+		}
+		writeData(w, map[string]interface{}{
+			"custom_metadata": secret.customMetadata,
+			"current_version": secret.version,
+		})
+	case kind == "metadata" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		var req struct {
+			CustomMetadata map[string]interface{} `json:"custom_metadata"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if secret == nil {
+			secret = &fakeSecret{}
+			f.secrets[key] = secret
+		}
+		secret.customMetadata = req.CustomMetadata
+		w.WriteHeader(http.StatusNoContent)
+	case kind == "metadata" && r.Method == http.MethodDelete:
+		delete(f.secrets, key)
+		w.WriteHeader(http.StatusNoContent)
+	case kind == "data" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		var req struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if secret == nil {
+			secret = &fakeSecret{}
+			f.secrets[key] = secret
+		}
+		secret.data = req.Data
+		secret.version++
+		writeData(w, map[string]interface{}{
+			"created_time":  time.Now().Format(time.RFC3339),
+			"deletion_time": "",
+			"destroyed":     false,
+			"version":       secret.version,
+		})
+	default:
+		writeError(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeVaultServer) serveLogin(w http.ResponseWriter, r *http.Request, path string) {
+	var req map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch path {
+	case "auth/approle/login":
+		if req["role_id"] != "role-id" || req["secret_id"] != "secret-id" {
+			writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+	case "auth/kubernetes/login":
+		if req["role"] != "role" || req["jwt"] != "jwt" {
+			writeError(w, http.StatusBadRequest, "invalid role or jwt")
+			return
+		}
+	default:
+		writeError(w, http.StatusNotFound, "unsupported path")
+		return
+	}
+
+	f.logins++
+	token := fmt.Sprintf("token-%d", f.logins)
+	f.tokens[token] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": 3600,
+			"renewable":      true,
+		},
+	})
+}
+
+func writeData(w http.ResponseWriter, data map[string]interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+func writeError(w http.ResponseWriter, code int, errs ...string) {
+	if errs == nil {
+		errs = []string{}
+	}
+	writeJSON(w, code, map[string]interface{}{"errors": errs})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
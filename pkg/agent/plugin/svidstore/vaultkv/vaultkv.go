@@ -0,0 +1,365 @@
+package vaultkv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	vapi "github.com/hashicorp/vault/api"
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	svidstorev1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/agent/svidstore/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pluginName = "vault_kv"
+
+	// spireSVIDMetadataKey is the custom metadata key set on the secrets
+	// managed by this plugin. Its value is the trust domain name.
+	spireSVIDMetadataKey = "spire-svid"
+
+	envVaultAddr      = "VAULT_ADDR"
+	envVaultToken     = "VAULT_TOKEN"
+	envVaultNamespace = "VAULT_NAMESPACE"
+
+	defaultKVMountPoint      = "secret"
+	defaultAppRoleMountPoint = "approle"
+	defaultK8sMountPoint     = "kubernetes"
+)
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(pluginName,
+		svidstorev1.SVIDStorePluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+func New() *Plugin {
+	return &Plugin{}
+}
+
+type Configuration struct {
+	// A URL of the Vault server. Default: value of VAULT_ADDR.
+	VaultAddr string `hcl:"vault_addr" json:"vault_addr"`
+	// Name of the Vault namespace. Default: value of VAULT_NAMESPACE.
+	Namespace string `hcl:"namespace" json:"namespace"`
+	// Path to a CA certificate file used to verify the Vault server certificate
+	CACertPath string `hcl:"ca_cert_path" json:"ca_cert_path"`
+	// If true, the Vault server certificate is not verified. It should only
+	// be used in test environments.
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify" json:"insecure_skip_verify"`
+	// Mount point of the KV v2 secrets engine used when an entry does not
+	// select one
+	KVMountPoint string `hcl:"kv_mount_point" json:"kv_mount_point"`
+
+	// Configuration for the Token authentication method
+	TokenAuth *TokenAuthConfig `hcl:"token_auth" json:"token_auth,omitempty"`
+	// Configuration for the AppRole authentication method
+	AppRoleAuth *AppRoleAuthConfig `hcl:"approle_auth" json:"approle_auth,omitempty"`
+	// Configuration for the Kubernetes authentication method
+	K8sAuth *K8sAuthConfig `hcl:"k8s_auth" json:"k8s_auth,omitempty"`
+
+	UnusedKeys []string `hcl:",unusedKeys" json:",omitempty"`
+}
+
+// TokenAuthConfig represents parameters for token auth method
+type TokenAuthConfig struct {
+	// Token string to set into "X-Vault-Token" header. Default: value of
+	// VAULT_TOKEN.
+	Token string `hcl:"token" json:"token"`
+}
+
+// AppRoleAuthConfig represents parameters for AppRole auth method.
+type AppRoleAuthConfig struct {
+	// Name of the mount point where AppRole auth method is mounted
+	AppRoleMountPoint string `hcl:"approle_auth_mount_point" json:"approle_auth_mount_point"`
+	// An identifier that selects the AppRole
+	RoleID string `hcl:"approle_id" json:"approle_id"`
+	// A credential that is required for login
+	SecretID string `hcl:"approle_secret_id" json:"approle_secret_id"`
+}
+
+// K8sAuthConfig represents parameters for Kubernetes auth method.
+type K8sAuthConfig struct {
+	// Name of the mount point where Kubernetes auth method is mounted
+	K8sMountPoint string `hcl:"k8s_auth_mount_point" json:"k8s_auth_mount_point"`
+	// Name of the Vault role
+	RoleName string `hcl:"k8s_auth_role_name" json:"k8s_auth_role_name"`
+	// Path to the Kubernetes Service Account Token to use authentication with the Vault
+	TokenPath string `hcl:"token_path" json:"token_path"`
+}
+
+// Plugin is an SVIDStore that stores SVIDs in the HashiCorp Vault KV v2
+// secrets engine.
+type Plugin struct {
+	svidstorev1.UnsafeSVIDStoreServer
+	configv1.UnsafeConfigServer
+
+	log          hclog.Logger
+	mtx          sync.RWMutex
+	client       *kvClient
+	kvMountPoint string
+	trustDomain  string
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+// Configure configures the vault_kv plugin.
+func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	config, err := parseConfig(req.HclConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newKVClient(config)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create Vault client: %v", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.client = client
+	p.kvMountPoint = config.KVMountPoint
+	p.trustDomain = req.CoreConfiguration.TrustDomain
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+// PutX509SVID writes the X509-SVID to the secret selected by the entry
+// metadata, creating a new version of the secret.
+func (p *Plugin) PutX509SVID(ctx context.Context, req *svidstorev1.PutX509SVIDRequest) (*svidstorev1.PutX509SVIDResponse, error) {
+	client, trustDomain, opt, err := p.getClientAndOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	secretData, err := svidstore.SecretFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse request: %v", err)
+	}
+
+	data, err := secretDataToMap(secretData)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal payload: %v", err)
+	}
+
+	log := p.log.With("mount", opt.mount).With("path", opt.path)
+	err = client.kv(ctx, opt.mount, func(kv *vapi.KVv2) error {
+		found, err := getMetadata(ctx, kv, opt.path, trustDomain)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			// Label the secret before writing data, so it is not written
+			// without being marked as managed by SPIRE.
+			if err := kv.PutMetadata(ctx, opt.path, vapi.KVMetadataPutInput{
+				CustomMetadata: map[string]interface{}{
+					spireSVIDMetadataKey: trustDomain,
+				},
+			}); err != nil {
+				return fmt.Errorf("failed to create secret metadata: %w", err)
+			}
+			log.Debug("Secret created")
+		}
+
+		secret, err := kv.Put(ctx, opt.path, data)
+		if err != nil {
+			return fmt.Errorf("failed to put secret: %w", err)
+		}
+		if secret.VersionMetadata != nil {
+			log = log.With("version", secret.VersionMetadata.Version)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Secret payload updated")
+	return &svidstorev1.PutX509SVIDResponse{}, nil
+}
+
+// DeleteX509SVID deletes all the versions and the metadata of the secret
+// selected by the entry metadata.
+func (p *Plugin) DeleteX509SVID(ctx context.Context, req *svidstorev1.DeleteX509SVIDRequest) (*svidstorev1.DeleteX509SVIDResponse, error) {
+	client, trustDomain, opt, err := p.getClientAndOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	log := p.log.With("mount", opt.mount).With("path", opt.path)
+	err = client.kv(ctx, opt.mount, func(kv *vapi.KVv2) error {
+		found, err := getMetadata(ctx, kv, opt.path, trustDomain)
+		if err != nil {
+			return err
+		}
+		if !found {
+			log.Debug("Secret to delete not found")
+			return nil
+		}
+
+		if err := kv.DeleteMetadata(ctx, opt.path); err != nil {
+			return fmt.Errorf("failed to delete secret: %w", err)
+		}
+		log.Debug("Secret deleted")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &svidstorev1.DeleteX509SVIDResponse{}, nil
+}
+
+func (p *Plugin) getClientAndOptions(metadata []string) (*kvClient, string, *secretOptions, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.client == nil {
+		return nil, "", nil, status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	opt, err := optionsFromSecretData(metadata, p.kvMountPoint)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return p.client, p.trustDomain, opt, nil
+}
+
+// getMetadata gets the secret metadata and validates that the secret is
+// managed by this SPIRE deployment. It returns false if not found.
+func getMetadata(ctx context.Context, kv *vapi.KVv2, path, trustDomain string) (bool, error) {
+	metadata, err := kv.GetMetadata(ctx, path)
+	switch {
+	case errors.Is(err, vapi.ErrSecretNotFound):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to get secret metadata: %w", err)
+	}
+
+	if value, ok := metadata.CustomMetadata[spireSVIDMetadataKey]; !ok || value != trustDomain {
+		return false, status.Error(codes.InvalidArgument, "secret is not managed by this SPIRE deployment")
+	}
+	return true, nil
+}
+
+func parseConfig(hclConfig string) (*Configuration, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, hclConfig); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	if len(config.UnusedKeys) != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown configurations detected: %s", strings.Join(config.UnusedKeys, ","))
+	}
+
+	config.VaultAddr = getEnvOrDefault(config.VaultAddr, envVaultAddr)
+	if config.VaultAddr == "" {
+		return nil, status.Error(codes.InvalidArgument, "vault_addr is required")
+	}
+	config.Namespace = getEnvOrDefault(config.Namespace, envVaultNamespace)
+	if config.KVMountPoint == "" {
+		config.KVMountPoint = defaultKVMountPoint
+	}
+
+	authMethods := 0
+	if config.TokenAuth != nil {
+		authMethods++
+		config.TokenAuth.Token = getEnvOrDefault(config.TokenAuth.Token, envVaultToken)
+		if config.TokenAuth.Token == "" {
+			return nil, status.Error(codes.InvalidArgument, "token is required")
+		}
+	}
+	if config.AppRoleAuth != nil {
+		authMethods++
+		if config.AppRoleAuth.RoleID == "" || config.AppRoleAuth.SecretID == "" {
+			return nil, status.Error(codes.InvalidArgument, "approle_id and approle_secret_id are required")
+		}
+		if config.AppRoleAuth.AppRoleMountPoint == "" {
+			config.AppRoleAuth.AppRoleMountPoint = defaultAppRoleMountPoint
+		}
+	}
+	if config.K8sAuth != nil {
+		authMethods++
+		if config.K8sAuth.RoleName == "" {
+			return nil, status.Error(codes.InvalidArgument, "k8s_auth_role_name is required")
+		}
+		if config.K8sAuth.TokenPath == "" {
+			return nil, status.Error(codes.InvalidArgument, "token_path is required")
+		}
+		if config.K8sAuth.K8sMountPoint == "" {
+			config.K8sAuth.K8sMountPoint = defaultK8sMountPoint
+		}
+	}
+	if authMethods != 1 {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of token_auth, approle_auth or k8s_auth must be configured")
+	}
+
+	return config, nil
+}
+
+type secretOptions struct {
+	mount string
+	path  string
+}
+
+func optionsFromSecretData(selectorData []string, defaultMount string) (*secretOptions, error) {
+	data, err := svidstore.ParseMetadata(selectorData)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid metadata: %v", err)
+	}
+
+	path, ok := data["path"]
+	if !ok || path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path is required")
+	}
+
+	mount, ok := data["mount"]
+	if !ok {
+		mount = defaultMount
+	}
+
+	return &secretOptions{
+		mount: mount,
+		path:  path,
+	}, nil
+}
+
+// secretDataToMap converts the secret data into the map stored in Vault,
+// using the same field names as the JSON encoding.
+func secretDataToMap(secretData *svidstore.Data) (map[string]interface{}, error) {
+	b, err := json.Marshal(secretData)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]interface{})
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func getEnvOrDefault(value, envKey string) string {
+	if value != "" {
+		return value
+	}
+	return os.Getenv(envKey)
+}
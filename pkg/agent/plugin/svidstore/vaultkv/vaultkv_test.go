@@ -0,0 +1,443 @@
+package vaultkv
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/plugin/svidstore"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+)
+
+var ctx = context.Background()
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		config          string
+		env             map[string]string
+		expectCode      codes.Code
+		expectMsgPrefix string
+		expectConfig    *Configuration
+	}{
+		{
+			name:   "token auth",
+			config: `vault_addr = "https://vault:8200" token_auth { token = "token" }`,
+			expectConfig: &Configuration{
+				VaultAddr:    "https://vault:8200",
+				KVMountPoint: "secret",
+				TokenAuth:    &TokenAuthConfig{Token: "token"},
+			},
+		},
+		{
+			name:   "token auth from environment",
+			config: `kv_mount_point = "kv" token_auth {}`,
+			env: map[string]string{
+				envVaultAddr:      "https://vault:8200",
+				envVaultToken:     "token",
+				envVaultNamespace: "ns",
+			},
+			expectConfig: &Configuration{
+				VaultAddr:    "https://vault:8200",
+				Namespace:    "ns",
+				KVMountPoint: "kv",
+				TokenAuth:    &TokenAuthConfig{Token: "token"},
+			},
+		},
+		{
+			name:   "approle auth",
+			config: `vault_addr = "https://vault:8200" approle_auth { approle_id = "id" approle_secret_id = "secret" }`,
+			expectConfig: &Configuration{
+				VaultAddr:    "https://vault:8200",
+				KVMountPoint: "secret",
+				AppRoleAuth: &AppRoleAuthConfig{
+					AppRoleMountPoint: "approle",
+					RoleID:            "id",
+					SecretID:          "secret",
+				},
+			},
+		},
+		{
+			name:   "k8s auth",
+			config: `vault_addr = "https://vault:8200" k8s_auth { k8s_auth_role_name = "role" token_path = "/token" }`,
+			expectConfig: &Configuration{
+				VaultAddr:    "https://vault:8200",
+				KVMountPoint: "secret",
+				K8sAuth: &K8sAuthConfig{
+					K8sMountPoint: "kubernetes",
+					RoleName:      "role",
+					TokenPath:     "/token",
+				},
+			},
+		},
+		{
+			name:            "malformed configuration",
+			config:          `not HCL`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unable to decode configuration",
+		},
+		{
+			name:            "unknown configuration",
+			config:          `vault_addr = "https://vault:8200" token_auth { token = "token" } foo = "bar"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "unknown configurations detected: foo",
+		},
+		{
+			name:            "missing vault address",
+			config:          `token_auth { token = "token" }`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "vault_addr is required",
+		},
+		{
+			name:            "missing auth method",
+			config:          `vault_addr = "https://vault:8200"`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "exactly one of token_auth, approle_auth or k8s_auth must be configured",
+		},
+		{
+			name:            "multiple auth methods",
+			config:          `vault_addr = "https://vault:8200" token_auth { token = "token" } approle_auth { approle_id = "id" approle_secret_id = "secret" }`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "exactly one of token_auth, approle_auth or k8s_auth must be configured",
+		},
+		{
+			name:            "missing token",
+			config:          `vault_addr = "https://vault:8200" token_auth {}`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "token is required",
+		},
+		{
+			name:            "missing approle secret ID",
+			config:          `vault_addr = "https://vault:8200" approle_auth { approle_id = "id" }`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "approle_id and approle_secret_id are required",
+		},
+		{
+			name:            "missing k8s role name",
+			config:          `vault_addr = "https://vault:8200" k8s_auth { token_path = "/token" }`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "k8s_auth_role_name is required",
+		},
+		{
+			name:            "missing k8s token path",
+			config:          `vault_addr = "https://vault:8200" k8s_auth { k8s_auth_role_name = "role" }`,
+			expectCode:      codes.InvalidArgument,
+			expectMsgPrefix: "token_path is required",
+		},
+		{
+			name:            "missing CA certificate",
+			config:          `vault_addr = "https://vault:8200" ca_cert_path = "/no/such/file" token_auth { token = "token" }`,
+			expectCode:      codes.Internal,
+			expectMsgPrefix: "failed to create Vault client: failed to configure TLS",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{envVaultAddr, envVaultToken, envVaultNamespace} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			if tt.expectConfig != nil {
+				config, err := parseConfig(tt.config)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectConfig, config)
+			}
+
+			p := New()
+			var err error
+			plugintest.Load(t, builtin(p), nil,
+				plugintest.CaptureConfigureError(&err),
+				plugintest.CoreConfig(catalog.CoreConfig{
+					TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+				}),
+				plugintest.Configure(tt.config),
+			)
+			spiretest.RequireGRPCStatusHasPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if tt.expectCode != codes.OK {
+				return
+			}
+			require.NotNil(t, p.client)
+		})
+	}
+}
+
+func TestPutX509SVID(t *testing.T) {
+	svid, federatedBundles := makeSVID(t)
+	expectData := expectedData(svid, federatedBundles)
+
+	for _, tt := range []struct {
+		name           string
+		metadata       []string
+		existingSecret map[string]interface{}
+		kvErr          string
+		expectCode     codes.Code
+		expectMsg      string
+		expectKey      string
+	}{
+		{
+			name:      "secret is created",
+			metadata:  []string{"path:workload"},
+			expectKey: "secret/workload",
+		},
+		{
+			name:      "secret is created in another mount",
+			metadata:  []string{"path:workload", "mount:kv"},
+			expectKey: "kv/workload",
+		},
+		{
+			name:           "secret is updated",
+			metadata:       []string{"path:workload"},
+			existingSecret: map[string]interface{}{spireSVIDMetadataKey: "example.org"},
+			expectKey:      "secret/workload",
+		},
+		{
+			name:           "secret not managed by SPIRE",
+			metadata:       []string{"path:workload"},
+			existingSecret: map[string]interface{}{"other": "value"},
+			expectCode:     codes.InvalidArgument,
+			expectMsg:      "svidstore(vault_kv): secret is not managed by this SPIRE deployment",
+		},
+		{
+			name:           "secret managed by another trust domain",
+			metadata:       []string{"path:workload"},
+			existingSecret: map[string]interface{}{spireSVIDMetadataKey: "another.org"},
+			expectCode:     codes.InvalidArgument,
+			expectMsg:      "svidstore(vault_kv): secret is not managed by this SPIRE deployment",
+		},
+		{
+			name:       "missing path",
+			metadata:   []string{"mount:kv"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(vault_kv): path is required",
+		},
+		{
+			name:       "invalid metadata",
+			metadata:   []string{"path"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `svidstore(vault_kv): invalid metadata: metadata does not contain a colon: "path"`,
+		},
+		{
+			name:       "failed to get metadata",
+			metadata:   []string{"path:workload"},
+			kvErr:      "oh no",
+			expectCode: codes.Internal,
+			expectMsg:  "svidstore(vault_kv): failed to get secret metadata: Error making API request.",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fake, addr := newFakeVaultServer(t)
+			fake.kvErr = tt.kvErr
+			if tt.existingSecret != nil {
+				fake.addSecret("secret/workload", tt.existingSecret, map[string]interface{}{"x509SVID": "old"})
+			}
+			ss := loadPlugin(t, `vault_addr = %q token_auth { token = %q }`, addr, fakeRootToken)
+
+			err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+				SVID:             svid,
+				Metadata:         tt.metadata,
+				FederatedBundles: federatedBundles,
+			})
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatusHasPrefix(t, err, tt.expectCode, tt.expectMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			secret := fake.getSecret(tt.expectKey)
+			require.NotNil(t, secret)
+			require.Equal(t, map[string]interface{}{spireSVIDMetadataKey: "example.org"}, secret.customMetadata)
+			require.Equal(t, expectData, secret.data)
+		})
+	}
+}
+
+func TestDeleteX509SVID(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		metadata       []string
+		existingSecret map[string]interface{}
+		expectCode     codes.Code
+		expectMsg      string
+		expectDeleted  bool
+	}{
+		{
+			name:           "secret is deleted",
+			metadata:       []string{"path:workload"},
+			existingSecret: map[string]interface{}{spireSVIDMetadataKey: "example.org"},
+			expectDeleted:  true,
+		},
+		{
+			name:     "secret not found",
+			metadata: []string{"path:workload"},
+		},
+		{
+			name:           "secret not managed by SPIRE",
+			metadata:       []string{"path:workload"},
+			existingSecret: map[string]interface{}{spireSVIDMetadataKey: "another.org"},
+			expectCode:     codes.InvalidArgument,
+			expectMsg:      "svidstore(vault_kv): secret is not managed by this SPIRE deployment",
+		},
+		{
+			name:       "missing path",
+			metadata:   []string{"mount:secret"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "svidstore(vault_kv): path is required",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			fake, addr := newFakeVaultServer(t)
+			if tt.existingSecret != nil {
+				fake.addSecret("secret/workload", tt.existingSecret, map[string]interface{}{"x509SVID": "old"})
+			}
+			ss := loadPlugin(t, `vault_addr = %q token_auth { token = %q }`, addr, fakeRootToken)
+
+			err := ss.DeleteX509SVID(ctx, tt.metadata)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+
+			if tt.existingSecret == nil {
+				return
+			}
+			if tt.expectDeleted {
+				require.Nil(t, fake.getSecret("secret/workload"))
+			} else {
+				require.NotNil(t, fake.getSecret("secret/workload"))
+			}
+		})
+	}
+}
+
+func TestLogin(t *testing.T) {
+	tokenPath := filepath.Join(spiretest.TempDir(t), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("jwt"), 0600))
+
+	for _, tt := range []struct {
+		name            string
+		authConfig      string
+		expectCode      codes.Code
+		expectMsgPrefix string
+	}{
+		{
+			name:       "approle",
+			authConfig: `approle_auth { approle_id = "role-id" approle_secret_id = "secret-id" }`,
+		},
+		{
+			name:       "k8s",
+			authConfig: fmt.Sprintf(`k8s_auth { k8s_auth_role_name = "role" token_path = %q }`, tokenPath),
+		},
+		{
+			name:            "approle login fails",
+			authConfig:      `approle_auth { approle_id = "role-id" approle_secret_id = "wrong" }`,
+			expectCode:      codes.Internal,
+			expectMsgPrefix: "svidstore(vault_kv): failed to authenticate to Vault: Error making API request.",
+		},
+		{
+			name:            "k8s token cannot be read",
+			authConfig:      `k8s_auth { k8s_auth_role_name = "role" token_path = "/no/such/file" }`,
+			expectCode:      codes.Internal,
+			expectMsgPrefix: "svidstore(vault_kv): failed to authenticate to Vault: failed to read service account token:",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			svid, _ := makeSVID(t)
+			fake, addr := newFakeVaultServer(t)
+			ss := loadPlugin(t, `vault_addr = %q %s`, addr, tt.authConfig)
+
+			put := func() error {
+				return ss.PutX509SVID(ctx, &svidstore.X509SVID{
+					SVID:     svid,
+					Metadata: []string{"path:workload"},
+				})
+			}
+
+			err := put()
+			spiretest.RequireGRPCStatusHasPrefix(t, err, tt.expectCode, tt.expectMsgPrefix)
+			if tt.expectCode != codes.OK {
+				return
+			}
+			require.Equal(t, 1, fake.loginCount())
+
+			// The token is reused
+			require.NoError(t, put())
+			require.Equal(t, 1, fake.loginCount())
+
+			// The plugin logs in again when the token is no longer valid
+			fake.revokeTokens()
+			require.NoError(t, put())
+			require.Equal(t, 2, fake.loginCount())
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	ss := new(svidstore.V1)
+	plugintest.Load(t, BuiltIn(), ss)
+
+	svid, _ := makeSVID(t)
+	err := ss.PutX509SVID(ctx, &svidstore.X509SVID{
+		SVID:     svid,
+		Metadata: []string{"path:workload"},
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(vault_kv): not configured")
+
+	err = ss.DeleteX509SVID(ctx, []string{"path:workload"})
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "svidstore(vault_kv): not configured")
+}
+
+func loadPlugin(t *testing.T, configFmt string, configArgs ...interface{}) *svidstore.V1 {
+	ss := new(svidstore.V1)
+	plugintest.Load(t, BuiltIn(), ss,
+		plugintest.CoreConfig(catalog.CoreConfig{
+			TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		}),
+		plugintest.Configuref(configFmt, configArgs...),
+	)
+	return ss
+}
+
+func expectedData(svid *svidstore.SVID, federatedBundles map[string][]*x509.Certificate) map[string]interface{} {
+	keyPEM, _ := pemutil.EncodePKCS8PrivateKey(svid.PrivateKey)
+	return map[string]interface{}{
+		"spiffeID":    svid.SPIFFEID.String(),
+		"x509SVID":    string(pemutil.EncodeCertificates(svid.CertChain)),
+		"x509SVIDKey": string(keyPEM),
+		"bundle":      string(pemutil.EncodeCertificates(svid.Bundle)),
+		"federatedBundles": map[string]interface{}{
+			"spiffe://a.test": string(pemutil.EncodeCertificates(federatedBundles["spiffe://a.test"])),
+			"spiffe://b.test": string(pemutil.EncodeCertificates(federatedBundles["spiffe://b.test"])),
+		},
+	}
+}
+
+func makeSVID(t *testing.T) (*svidstore.SVID, map[string][]*x509.Certificate) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	ca := testca.New(t, td)
+	id := spiffeid.RequireFromPath(td, "/workload")
+	x509SVID := ca.CreateX509SVID(id)
+
+	federatedBundles := map[string][]*x509.Certificate{
+		"spiffe://a.test": testca.New(t, spiffeid.RequireTrustDomainFromString("a.test")).X509Authorities(),
+		"spiffe://b.test": testca.New(t, spiffeid.RequireTrustDomainFromString("b.test")).X509Authorities(),
+	}
+
+	return &svidstore.SVID{
+		SPIFFEID:   id,
+		CertChain:  x509SVID.Certificates,
+		PrivateKey: x509SVID.PrivateKey,
+		Bundle:     ca.X509Authorities(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}, federatedBundles
+}
@@ -149,6 +149,11 @@ func (r *rotator) rotateSVIDIfNeeded(ctx context.Context) (err error) {
 			err = r.reattest(ctx)
 		} else {
 			err = r.rotateSVID(ctx)
+			if err != nil && state.Reattestable && nodeutil.MustAgentReattest(err) {
+				// The server requested this agent to prove its identity again
+				r.c.Log.WithError(err).Info("Server requested reattestation, reattesting node")
+				err = r.reattest(ctx)
+			}
 		}
 
 		if err == nil && r.rotationFinishedHook != nil {
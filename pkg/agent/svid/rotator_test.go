@@ -375,6 +375,107 @@ func TestRotationFails(t *testing.T) {
 	}
 }
 
+func TestRotatorReattestRequested(t *testing.T) {
+	// Renewal is attempted first when reattest to renew is not enabled
+	require.NoError(t, fflag.Unload())
+	defer func() {
+		require.NoError(t, fflag.Load(fflag.RawConfig{"reattest_to_renew"}))
+	}()
+
+	caCert, caKey := testca.CreateCACertificate(t, nil, nil)
+	serverCert, serverKey := testca.CreateX509Certificate(t, caCert, caKey, testca.WithID(idutil.RequireServerID(trustDomain)))
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+
+	mustReattestStatus := status.New(codes.PermissionDenied, "agent can't renew SVID, reattestation was requested")
+	mustReattestStatus, err := mustReattestStatus.WithDetails(&types.PermissionDeniedDetails{
+		Reason: types.PermissionDeniedDetails_AGENT_MUST_REATTEST,
+	})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name      string
+		reattest  bool
+		expectErr string
+	}{
+		{
+			name:     "reattestable agent reattests",
+			reattest: true,
+		},
+		{
+			name:      "non reattestable agent fails",
+			expectErr: "client fails: rpc error: code = PermissionDenied desc = agent can't renew SVID, reattestation was requested",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svidKM := keymanager.ForSVID(fakeagentkeymanager.New(t, ""))
+			clk := clock.NewMock(t)
+			log, _ := test.NewNullLogger()
+			mockClient := &fakeClient{
+				clk:      clk,
+				caCert:   caCert,
+				caKey:    caKey,
+				renewErr: fmt.Errorf("client fails: %w", mustReattestStatus.Err()),
+			}
+
+			bundle := make(map[spiffeid.TrustDomain]*bundleutil.Bundle)
+			bundle[trustDomain] = bundleutil.BundleFromRootCA(trustDomain, caCert)
+
+			// Create a starting SVID that must be rotated
+			svidKey, err := svidKM.GenerateKey(context.Background(), nil)
+			require.NoError(t, err)
+			svid, err := createTestSVID(svidKey.Public(), caCert, caKey, clk.Now(), clk.Now().Add(time.Minute))
+			require.NoError(t, err)
+			clk.Add(time.Minute / 2)
+
+			attestor := fakeagentnodeattestor.New(t, fakeagentnodeattestor.Config{})
+			mockAgentService := &fakeAgentService{
+				clk:     clk,
+				svidKM:  svidKM,
+				svidKey: svidKey,
+				caCert:  caCert,
+				caKey:   caKey,
+			}
+			listener := createTestListener(t, mockAgentService, tlsConfig)
+
+			rotator, _ := newRotator(&RotatorConfig{
+				SVIDKeyManager: svidKM,
+				Log:            log,
+				Metrics:        telemetry.Blackhole{},
+				TrustDomain:    trustDomain,
+				BundleStream:   cache.NewBundleStream(observer.NewProperty(bundle).Observe()),
+				Clk:            clk,
+				Reattestable:   tt.reattest,
+				SVID:           svid,
+				SVIDKey:        svidKey,
+				NodeAttestor:   attestor,
+				ServerAddr:     listener.Addr().String(),
+			})
+			rotator.client = mockClient
+
+			err = rotator.rotateSVIDIfNeeded(context.Background())
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.False(t, mockAgentService.attested)
+				require.Equal(t, svid, rotator.State().SVID)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, mockAgentService.attested)
+			require.NotEqual(t, svid, rotator.State().SVID)
+		})
+	}
+}
+
 type fakeClient struct {
 	clk          clock.Clock
 	caCert       *x509.Certificate
@@ -96,6 +96,7 @@ func CommonBundleFromProto(b *types.Bundle) (*common.Bundle, error) {
 	return &common.Bundle{
 		TrustDomainId:  td.IDString(),
 		RefreshHint:    b.RefreshHint,
+		SequenceNumber: b.SequenceNumber,
 		RootCas:        rootCAs,
 		JwtSigningKeys: jwtKeys,
 	}, nil
@@ -113,6 +114,19 @@ func (b *Bundle) Proto() *common.Bundle {
 	return cloneBundle(b.b)
 }
 
+// Clone returns a copy of the bundle that can be modified independently.
+func (b *Bundle) Clone() *Bundle {
+	jwtSigningKeys := make(map[string]crypto.PublicKey, len(b.jwtSigningKeys))
+	for kid, key := range b.jwtSigningKeys {
+		jwtSigningKeys[kid] = key
+	}
+	return &Bundle{
+		b:              cloneBundle(b.b),
+		rootCAs:        append([]*x509.Certificate(nil), b.rootCAs...),
+		jwtSigningKeys: jwtSigningKeys,
+	}
+}
+
 func (b *Bundle) TrustDomainID() string {
 	return b.b.TrustDomainId
 }
@@ -140,6 +154,16 @@ func (b *Bundle) SetRefreshHint(d time.Duration) {
 	b.b.RefreshHint = int64((d + (time.Second - 1)) / time.Second)
 }
 
+// SequenceNumber returns the SPIFFE bundle sequence number.
+func (b *Bundle) SequenceNumber() uint64 {
+	return b.b.SequenceNumber
+}
+
+// SetSequenceNumber sets the SPIFFE bundle sequence number.
+func (b *Bundle) SetSequenceNumber(sequenceNumber uint64) {
+	b.b.SequenceNumber = sequenceNumber
+}
+
 func (b *Bundle) AppendRootCA(rootCA *x509.Certificate) {
 	b.b.RootCas = append(b.b.RootCas, &common.Certificate{
 		DerBytes: rootCA.Raw,
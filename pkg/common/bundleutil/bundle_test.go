@@ -27,6 +27,27 @@ type bundleTest struct {
 	jwtKeyNotExpired *common.PublicKey
 }
 
+func TestClone(t *testing.T) {
+	td := spiffeid.RequireTrustDomainFromString("example.org")
+	rootCA := createCACertificate(t)
+
+	bundle := BundleFromRootCA(td, rootCA)
+	bundle.SetRefreshHint(time.Minute)
+	bundle.SetSequenceNumber(2)
+
+	clone := bundle.Clone()
+	require.Equal(t, bundle, clone)
+
+	// Changes to the clone are not reflected in the original bundle
+	clone.SetRefreshHint(time.Hour)
+	clone.AppendRootCA(rootCA)
+	require.NoError(t, clone.AppendJWTSigningKey("kid", rootCA.PublicKey))
+	require.Equal(t, time.Minute, bundle.RefreshHint())
+	require.Len(t, bundle.RootCAs(), 1)
+	require.Len(t, bundle.Proto().RootCas, 1)
+	require.Empty(t, bundle.JWTSigningKeys())
+}
+
 func TestPruneBundle(t *testing.T) {
 	test := setupTest(t)
 
@@ -120,8 +141,9 @@ func TestCommonBundleFromProto(t *testing.T) {
 		{
 			name: "success",
 			bundle: &types.Bundle{
-				TrustDomain: td.String(),
-				RefreshHint: 10,
+				TrustDomain:    td.String(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
 				X509Authorities: []*types.X509Certificate{
 					{
 						Asn1: rootCA.Raw,
@@ -136,9 +158,10 @@ func TestCommonBundleFromProto(t *testing.T) {
 				},
 			},
 			expectBundle: &common.Bundle{
-				TrustDomainId: td.IDString(),
-				RefreshHint:   10,
-				RootCas:       []*common.Certificate{{DerBytes: rootCA.Raw}},
+				TrustDomainId:  td.IDString(),
+				RefreshHint:    10,
+				SequenceNumber: 42,
+				RootCas:        []*common.Certificate{{DerBytes: rootCA.Raw}},
 				JwtSigningKeys: []*common.PublicKey{
 					{
 						PkixBytes: pkixBytes,
@@ -13,10 +13,11 @@ func TestMarshal(t *testing.T) {
 	rootCA := createCACertificate(t)
 
 	testCases := []struct {
-		name  string
-		empty bool
-		opts  []MarshalOption
-		out   string
+		name           string
+		empty          bool
+		sequenceNumber uint64
+		opts           []MarshalOption
+		out            string
 	}{
 		{
 			name:  "empty bundle",
@@ -31,6 +32,12 @@ func TestMarshal(t *testing.T) {
 			},
 			out: `{"keys":null, "spiffe_refresh_hint": 10}`,
 		},
+		{
+			name:           "with sequence number",
+			empty:          true,
+			sequenceNumber: 42,
+			out:            `{"keys":null, "spiffe_sequence": 42, "spiffe_refresh_hint": 60}`,
+		},
 		{
 			name: "without X509 SVID keys",
 			opts: []MarshalOption{
@@ -133,6 +140,7 @@ func TestMarshal(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			bundle := New(trustDomain)
 			bundle.SetRefreshHint(time.Minute)
+			bundle.SetSequenceNumber(testCase.sequenceNumber)
 			if !testCase.empty {
 				bundle.AppendRootCA(rootCA)
 				require.NoError(t, bundle.AppendJWTSigningKey("FOO", testKey.Public()))
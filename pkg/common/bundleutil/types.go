@@ -7,6 +7,9 @@ import (
 const (
 	x509SVIDUse = "x509-svid"
 	jwtSVIDUse  = "jwt-svid"
+
+	jwksSignatureUse  = "sig"
+	jwksEncryptionUse = "enc"
 )
 
 type bundleDoc struct {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 	"github.com/zeebo/errs"
+	"gopkg.in/square/go-jose.v2"
 )
 
 func Decode(trustDomain spiffeid.TrustDomain, r io.Reader) (*Bundle, error) {
@@ -29,6 +30,7 @@ func Unmarshal(trustDomain spiffeid.TrustDomain, data []byte) (*Bundle, error) {
 func unmarshal(trustDomain spiffeid.TrustDomain, doc *bundleDoc) (*Bundle, error) {
 	bundle := New(trustDomain)
 	bundle.SetRefreshHint(time.Second * time.Duration(doc.RefreshHint))
+	bundle.SetSequenceNumber(doc.Sequence)
 
 	for i, key := range doc.Keys {
 		switch key.Use {
@@ -53,3 +55,32 @@ func unmarshal(trustDomain spiffeid.TrustDomain, doc *bundleDoc) (*Bundle, error
 
 	return bundle, nil
 }
+
+// UnmarshalJWKS converts a standard JWK set, like the ones published by
+// OIDC issuers, into a bundle that only contains JWT signing keys. Keys
+// intended for encryption are ignored.
+func UnmarshalJWKS(trustDomain spiffeid.TrustDomain, data []byte) (*Bundle, error) {
+	jwks := new(jose.JSONWebKeySet)
+	if err := json.Unmarshal(data, jwks); err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	bundle := New(trustDomain)
+	for i, key := range jwks.Keys {
+		switch key.Use {
+		case "", jwksSignatureUse:
+		case jwksEncryptionUse:
+			continue
+		default:
+			return nil, errs.New("unrecognized use %q for key entry %d", key.Use, i)
+		}
+		if key.KeyID == "" {
+			return nil, errs.New("missing key ID in key entry %d", i)
+		}
+		if err := bundle.AppendJWTSigningKey(key.KeyID, key.Public().Key); err != nil {
+			return nil, errs.New("failed to add key entry %d: %v", i, err)
+		}
+	}
+
+	return bundle, nil
+}
@@ -1,11 +1,13 @@
 package bundleutil
 
 import (
+	"crypto"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"gopkg.in/square/go-jose.v2"
 )
 
 func TestUnmarshal(t *testing.T) {
@@ -22,6 +24,15 @@ func TestUnmarshal(t *testing.T) {
 			doc:    "{}",
 			bundle: New(trustDomain),
 		},
+		{
+			name: "with sequence number",
+			doc:  `{"spiffe_sequence": 42}`,
+			bundle: func() *Bundle {
+				b := New(trustDomain)
+				b.SetSequenceNumber(42)
+				return b
+			}(),
+		},
 		{
 			name: "entry missing use",
 			doc: `{
@@ -115,3 +126,81 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalJWKS(t *testing.T) {
+	trustDomain := spiffeid.RequireTrustDomainFromString("domain.test")
+	const ecKey = `"kty": "EC",
+		"crv": "P-256",
+		"x": "kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+		"y": "qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA"`
+
+	key := new(jose.JSONWebKey)
+	require.NoError(t, key.UnmarshalJSON([]byte(`{`+ecKey+`}`)))
+
+	testCases := []struct {
+		name    string
+		doc     string
+		err     string
+		expKeys []string
+	}{
+		{
+			name:    "empty key set",
+			doc:     "{}",
+			expKeys: []string{},
+		},
+		{
+			name: "signing keys",
+			doc: `{
+				"keys": [
+					{"kid": "KID1", "use": "sig", ` + ecKey + `},
+					{"kid": "KID2", ` + ecKey + `},
+					{"kid": "KID3", "use": "enc", ` + ecKey + `}
+				]
+			}`,
+			expKeys: []string{"KID1", "KID2"},
+		},
+		{
+			name: "missing key ID",
+			doc: `{
+				"keys": [
+					{"use": "sig", ` + ecKey + `}
+				]
+			}`,
+			err: "missing key ID in key entry 0",
+		},
+		{
+			name: "unrecognized use",
+			doc: `{
+				"keys": [
+					{"kid": "KID1", "use": "jwt-svid", ` + ecKey + `}
+				]
+			}`,
+			err: `unrecognized use "jwt-svid" for key entry 0`,
+		},
+		{
+			name: "malformed key set",
+			doc:  "[]",
+			err:  "json: cannot unmarshal array into Go value of type jose.JSONWebKeySet",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			bundle, err := UnmarshalJWKS(trustDomain, []byte(testCase.doc))
+			if testCase.err != "" {
+				require.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, trustDomain.IDString(), bundle.TrustDomainID())
+			require.Empty(t, bundle.RootCAs())
+
+			expKeys := make(map[string]crypto.PublicKey)
+			for _, kid := range testCase.expKeys {
+				expKeys[kid] = key.Key
+			}
+			require.Equal(t, expKeys, bundle.JWTSigningKeys())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -18,6 +19,40 @@ func (f *CommaStringsFlag) Set(v string) error {
 	return nil
 }
 
+// BoolFlag facilitates parsing optional boolean flags, telling an unset flag
+// apart from one set to false
+type BoolFlag struct {
+	set   bool
+	value bool
+}
+
+func (f *BoolFlag) String() string {
+	if f == nil || !f.set {
+		return ""
+	}
+	return strconv.FormatBool(f.value)
+}
+
+func (f *BoolFlag) Set(v string) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return err
+	}
+	f.set = true
+	f.value = b
+	return nil
+}
+
+// IsBoolFlag allows the flag to be set without a value, e.g. "-flag"
+func (f *BoolFlag) IsBoolFlag() bool {
+	return true
+}
+
+// Get returns the value of the flag and whether it was set
+func (f *BoolFlag) Get() (value bool, ok bool) {
+	return f.value, f.set
+}
+
 // DurationFlag facilitates parsing flags representing a time.Duration
 type DurationFlag time.Duration
 
@@ -2,11 +2,17 @@ package cliprinter
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	commoncli "github.com/spiffe/spire/pkg/common/cli"
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/errorjson"
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/errorpretty"
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/errortable"
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/erroryaml"
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/jsontable"
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/jsontemplate"
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/jsonyaml"
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/protojson"
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/protopretty"
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/structjson"
@@ -36,6 +42,11 @@ type printer struct {
 	format formatType
 	env    *commoncli.Env
 	cp     CustomPrettyFunc
+
+	// columns of the table format
+	columns []string
+	// tmpl is the template of the template format
+	tmpl *jsontemplate.Template
 }
 
 func newPrinter(f formatType, env *commoncli.Env) *printer {
@@ -67,6 +78,14 @@ func (p *printer) printError(err error) error {
 	switch p.format {
 	case json:
 		return errorjson.Print(err, p.env.Stdout, p.env.Stderr)
+	case yaml:
+		return erroryaml.Print(err, p.env.Stdout, p.env.Stderr)
+	case table:
+		return errortable.Print(err, p.env.Stdout, p.env.Stderr)
+	case template:
+		// Templates are written for the messages being printed, so errors
+		// are printed as is.
+		return errorpretty.Print(err, p.env.Stdout, p.env.Stderr)
 	default:
 		return p.printPrettyError(err, p.env.Stdout, p.env.Stderr)
 	}
@@ -76,6 +95,16 @@ func (p *printer) printProto(msg ...proto.Message) error {
 	switch p.format {
 	case json:
 		return protojson.Print(msg, p.env.Stdout, p.env.Stderr)
+	case yaml, table, template:
+		if len(msg) == 0 {
+			return nil
+		}
+		jb, err := protojson.Marshal(msg)
+		if err != nil {
+			_ = p.printError(err)
+			return err
+		}
+		return p.printJSON(jb)
 	default:
 		return p.printPrettyProto(msg, p.env.Stdout, p.env.Stderr)
 	}
@@ -85,15 +114,54 @@ func (p *printer) printStruct(msg ...interface{}) error {
 	switch p.format {
 	case json:
 		return structjson.Print(msg, p.env.Stdout, p.env.Stderr)
+	case yaml, table, template:
+		if len(msg) == 0 {
+			return nil
+		}
+		jb, err := structjson.Marshal(msg)
+		if err != nil {
+			_ = p.printError(err)
+			return err
+		}
+		return p.printJSON(jb)
 	default:
 		return p.printPrettyStruct(msg, p.env.Stdout, p.env.Stderr)
 	}
 }
 
+// printJSON prints the JSON representation of messages in the formats
+// derived from it.
+func (p *printer) printJSON(jb []byte) error {
+	switch p.format {
+	case yaml:
+		return jsonyaml.Print(jb, p.env.Stdout, p.env.Stderr)
+	case table:
+		return jsontable.Print(jb, p.columns, p.env.Stdout, p.env.Stderr)
+	case template:
+		return p.tmpl.Print(jb, p.env.Stdout, p.env.Stderr)
+	default:
+		return fmt.Errorf("internal error: cli printer: unexpected format %q; please report this bug", formatTypeToStr(p.format))
+	}
+}
+
 func (p *printer) getFormat() formatType {
 	return p.format
 }
 
+func (p *printer) setFormatArg(arg string) error {
+	switch p.format {
+	case table:
+		p.columns = parseColumns(arg)
+	case template:
+		tmpl, err := jsontemplate.Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+		p.tmpl = tmpl
+	}
+	return nil
+}
+
 func (p *printer) setCustomPrettyPrinter(cp CustomPrettyFunc) {
 	p.cp = cp
 }
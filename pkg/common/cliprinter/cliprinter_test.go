@@ -83,6 +83,99 @@ func TestPrintStruct(t *testing.T) {
 	}
 }
 
+func TestPrintFormats(t *testing.T) {
+	msg := &agentapi.CountAgentsResponse{Count: 42}
+	s := struct {
+		Name string `json:"name"`
+	}{
+		Name: "boaty",
+	}
+
+	for _, c := range []struct {
+		name        string
+		format      formatType
+		arg         string
+		expectProto string
+		expectTwo   string
+		expectError string
+		expectS     string
+	}{
+		{
+			name:        "yaml",
+			format:      yaml,
+			expectProto: "count: 42\n",
+			expectTwo:   "- count: 42\n- count: 42\n",
+			expectError: "error: red alert\n",
+			expectS:     "- name: boaty\n",
+		},
+		{
+			name:        "table",
+			format:      table,
+			expectProto: "COUNT\n42\n",
+			expectTwo:   "COUNT\n42\n42\n",
+			expectError: "ERROR\nred alert\n",
+			expectS:     "NAME\nboaty\n",
+		},
+		{
+			name:        "table with columns",
+			format:      table,
+			arg:         "count,name",
+			expectProto: "COUNT   NAME\n42      \n",
+			expectTwo:   "COUNT   NAME\n42      \n42      \n",
+			expectError: "ERROR\nred alert\n",
+			expectS:     "COUNT   NAME\n        boaty\n",
+		},
+		{
+			name:        "template",
+			format:      template,
+			arg:         "{{json .}}",
+			expectProto: `{"count":42}` + "\n",
+			expectTwo:   `[{"count":42},{"count":42}]` + "\n",
+			expectError: "red alert\n",
+			expectS:     `[{"name":"boaty"}]` + "\n",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			p := newPrinter(c.format, &commoncli.Env{Stdout: stdout, Stderr: new(bytes.Buffer)})
+			if err := p.setFormatArg(c.arg); err != nil {
+				t.Fatalf("failed to set format argument: %v", err)
+			}
+
+			if err := p.printProto(msg); err != nil {
+				t.Fatalf("failed to print proto: %v", err)
+			}
+			if stdout.String() != c.expectProto {
+				t.Errorf("expected proto output %q but got %q", c.expectProto, stdout.String())
+			}
+
+			stdout.Reset()
+			if err := p.printProto(msg, msg); err != nil {
+				t.Fatalf("failed to print protos: %v", err)
+			}
+			if stdout.String() != c.expectTwo {
+				t.Errorf("expected protos output %q but got %q", c.expectTwo, stdout.String())
+			}
+
+			stdout.Reset()
+			if err := p.printError(errors.New("red alert")); err != nil {
+				t.Fatalf("failed to print error: %v", err)
+			}
+			if stdout.String() != c.expectError {
+				t.Errorf("expected error output %q but got %q", c.expectError, stdout.String())
+			}
+
+			stdout.Reset()
+			if err := p.PrintStruct(s); err != nil {
+				t.Fatalf("failed to print struct: %v", err)
+			}
+			if stdout.String() != c.expectS {
+				t.Errorf("expected struct output %q but got %q", c.expectS, stdout.String())
+			}
+		})
+	}
+}
+
 func newTestPrinter() (p *printer, stdout, stderr *bytes.Buffer) {
 	stdout = new(bytes.Buffer)
 	stderr = new(bytes.Buffer)
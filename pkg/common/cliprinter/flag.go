@@ -11,9 +11,12 @@ import (
 const defaultFlagName = "output"
 
 var flagDescription = fmt.Sprintf(
-	"Desired output format (%s, %s); default: %s.",
+	"Desired output format (%s, %s, %s, %s[=<columns>], %s=<template>); default: %s.",
 	formatTypeToStr(pretty),
 	formatTypeToStr(json),
+	formatTypeToStr(yaml),
+	formatTypeToStr(table),
+	formatTypeToStr(template),
 	formatTypeToStr(defaultFormatType),
 )
 
@@ -52,6 +55,7 @@ type FormatterFlag struct {
 	// its format type
 	p     *Printer
 	f     formatType
+	arg   string
 	env   *commoncli.Env
 	isSet bool
 }
@@ -61,27 +65,38 @@ func (f *FormatterFlag) String() string {
 		return formatTypeToStr(defaultFormatType)
 	}
 
-	return formatTypeToStr(f.f)
+	return formatString(f.f, f.arg)
 }
 
 func (f *FormatterFlag) Set(formatStr string) error {
-	if f.isSet && formatTypeToStr(f.f) != formatStr {
-		return fmt.Errorf("the output format has already been set to %q", formatTypeToStr(f.f))
-	}
 	if f.p == nil {
 		return errors.New("internal error: formatter flag not correctly invoked; please report this bug")
 	}
 
-	format, err := strToFormatType(formatStr)
+	format, arg, err := parseFormat(formatStr)
 	if err != nil {
 		return fmt.Errorf("bad formatter flag: %w", err)
 	}
+	if f.isSet && formatString(f.f, f.arg) != formatString(format, arg) {
+		return fmt.Errorf("the output format has already been set to %q", formatString(f.f, f.arg))
+	}
 
 	np := newPrinter(format, f.env)
 	np.setCustomPrettyPrinter(f.customPretty)
+	if err := np.setFormatArg(arg); err != nil {
+		return fmt.Errorf("bad formatter flag: %w", err)
+	}
 
 	*f.p = np
 	f.f = format
+	f.arg = arg
 	f.isSet = true
 	return nil
 }
+
+func formatString(f formatType, arg string) string {
+	if arg == "" {
+		return formatTypeToStr(f)
+	}
+	return formatTypeToStr(f) + "=" + arg
+}
@@ -60,6 +60,53 @@ func TestAppendFlag(t *testing.T) {
 			input:          []string{"-output", "jSoN"},
 			expectedFormat: json,
 		},
+		{
+			name:           "works when specifying yaml",
+			input:          []string{"-output", "yaml"},
+			expectedFormat: yaml,
+		},
+		{
+			name:           "works when specifying table",
+			input:          []string{"-output", "table"},
+			expectedFormat: table,
+		},
+		{
+			name:           "works when specifying table columns",
+			input:          []string{"-output", "table=id.path,banned"},
+			expectedFormat: table,
+		},
+		{
+			name:           "works when setting the same table columns more than once",
+			input:          []string{"-output", "table=id", "-format", "table=id"},
+			extraFlags:     []string{"format"},
+			expectedFormat: table,
+		},
+		{
+			name:        "error when setting different table columns more than once",
+			input:       []string{"-output", "table=id", "-format", "table=banned"},
+			extraFlags:  []string{"format"},
+			expectError: true,
+		},
+		{
+			name:           "works when specifying a template",
+			input:          []string{"-output", "template={{.count}}"},
+			expectedFormat: template,
+		},
+		{
+			name:        "requires a template",
+			input:       []string{"-output", "template"},
+			expectError: true,
+		},
+		{
+			name:        "requires a valid template",
+			input:       []string{"-output", "template={{.count"},
+			expectError: true,
+		},
+		{
+			name:        "requires no argument for other formats",
+			input:       []string{"-output", "json=count"},
+			expectError: true,
+		},
 	}
 
 	for _, c := range flagCases {
@@ -1,6 +1,7 @@
 package cliprinter
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -9,6 +10,9 @@ const (
 	_ formatType = iota
 	json
 	pretty
+	yaml
+	table
+	template
 
 	defaultFormatType = pretty
 )
@@ -21,6 +25,12 @@ func strToFormatType(f string) (formatType, error) {
 		return json, nil
 	case "pretty", "prettyprint":
 		return pretty, nil
+	case "yaml":
+		return yaml, nil
+	case "table":
+		return table, nil
+	case "template":
+		return template, nil
 	default:
 		return 0, fmt.Errorf("unknown format option: %q", f)
 	}
@@ -32,7 +42,48 @@ func formatTypeToStr(f formatType) string {
 		return "json"
 	case pretty:
 		return "pretty"
+	case yaml:
+		return "yaml"
+	case table:
+		return "table"
+	case template:
+		return "template"
 	default:
 		return "unknown"
 	}
 }
+
+// parseFormat parses a format option of the form "<format>[=<argument>]".
+// The table format takes an optional comma-separated list of columns, and
+// the template format requires a Go template.
+func parseFormat(f string) (formatType, string, error) {
+	name, arg, hasArg := strings.Cut(f, "=")
+	format, err := strToFormatType(name)
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch format {
+	case table:
+	case template:
+		if arg == "" {
+			return 0, "", errors.New("the template format requires a template, e.g. template={{.id}}")
+		}
+	default:
+		if hasArg {
+			return 0, "", fmt.Errorf("the %s format does not take an argument", formatTypeToStr(format))
+		}
+	}
+	return format, arg, nil
+}
+
+// parseColumns parses the comma-separated columns of the table format.
+func parseColumns(arg string) []string {
+	var columns []string
+	for _, column := range strings.Split(arg, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
@@ -23,6 +23,18 @@ func TestStrFormatType(t *testing.T) {
 			name:  "json should work",
 			input: "json",
 		},
+		{
+			name:  "yaml should work",
+			input: "yaml",
+		},
+		{
+			name:  "table should work",
+			input: "table",
+		},
+		{
+			name:  "template should work",
+			input: "template",
+		},
 	}
 
 	for _, c := range cases {
@@ -48,3 +60,79 @@ func TestStrFormatType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		name           string
+		input          string
+		expectedFormat formatType
+		expectedArg    string
+		expectError    string
+	}{
+		{
+			name:           "format without argument",
+			input:          "yaml",
+			expectedFormat: yaml,
+		},
+		{
+			name:           "table without columns",
+			input:          "table",
+			expectedFormat: table,
+		},
+		{
+			name:           "table with columns",
+			input:          "table=id,banned",
+			expectedFormat: table,
+			expectedArg:    "id,banned",
+		},
+		{
+			name:           "template containing equal signs",
+			input:          "template={{if eq .count 0.0}}none{{end}}",
+			expectedFormat: template,
+			expectedArg:    "{{if eq .count 0.0}}none{{end}}",
+		},
+		{
+			name:        "template without template",
+			input:       "template=",
+			expectError: "the template format requires a template, e.g. template={{.id}}",
+		},
+		{
+			name:        "argument for a format without arguments",
+			input:       "pretty=yes",
+			expectError: "the pretty format does not take an argument",
+		},
+		{
+			name:        "unknown format",
+			input:       "xml=yes",
+			expectError: `unknown format option: "xml"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			format, arg, err := parseFormat(c.input)
+			if c.expectError != "" {
+				if err == nil || err.Error() != c.expectError {
+					t.Fatalf("expected error %q but got %v", c.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error: %v", err)
+			}
+			if format != c.expectedFormat {
+				t.Errorf("expected format type %q but got %q", formatTypeToStr(c.expectedFormat), formatTypeToStr(format))
+			}
+			if arg != c.expectedArg {
+				t.Errorf("expected argument %q but got %q", c.expectedArg, arg)
+			}
+		})
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	columns := parseColumns(" id.path, ,banned,")
+	if len(columns) != 2 || columns[0] != "id.path" || columns[1] != "banned" {
+		t.Errorf("unexpected columns: %q", columns)
+	}
+}
@@ -0,0 +1,26 @@
+package errortable
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/spiffe/spire/pkg/common/cliprinter/internal/jsontable"
+)
+
+func Print(err error, stdout, stderr io.Writer) error {
+	if err == nil {
+		return nil
+	}
+
+	s := struct {
+		E string `json:"error"`
+	}{
+		E: err.Error(),
+	}
+
+	jb, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return jsontable.Print(jb, nil, stdout, stderr)
+}
@@ -0,0 +1,206 @@
+package jsontable
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// valueColumn is the column of rows that are not objects.
+const valueColumn = "value"
+
+// Print prints JSON formatted as a table with one row per object and one
+// column per object key. Nested keys are selected with dot-separated paths,
+// e.g. "id.path". If no columns are given, the top-level keys of the rows
+// are used, in the order they first appear.
+//
+// The rows are the elements of a JSON array or, for a list response, the
+// elements of its array of objects, e.g. the agents of a list agents
+// response. Any other object is printed as a single row.
+func Print(jb []byte, columns []string, stdout, _ io.Writer) error {
+	// JSON is a subset of YAML, so decoding it into a node keeps the order
+	// of the keys.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(jb, &doc); err != nil {
+		return err
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	rows := tableRows(doc.Content[0])
+	if len(columns) == 0 {
+		columns = defaultColumns(rows)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 3, ' ', 0)
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, strings.ToUpper(column))
+	}
+	if _, err := fmt.Fprintln(w, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, formatCell(lookup(row, column)))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func tableRows(node *yaml.Node) []*yaml.Node {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		// A single object, e.g. a struct printed as an array of one, is
+		// handled like the object itself.
+		if len(node.Content) == 1 && node.Content[0].Kind == yaml.MappingNode {
+			return tableRows(node.Content[0])
+		}
+		return node.Content
+	case yaml.MappingNode:
+		if rows, ok := listRows(node); ok {
+			return rows
+		}
+		return []*yaml.Node{node}
+	default:
+		return []*yaml.Node{node}
+	}
+}
+
+// listRows returns the objects of a list response, i.e. an object with a
+// single array of objects and at most one other field, which is not an
+// array or object, e.g. a next page token.
+func listRows(node *yaml.Node) ([]*yaml.Node, bool) {
+	if len(node.Content) > 4 {
+		return nil, false
+	}
+
+	var rows []*yaml.Node
+	found := false
+	for i := 1; i < len(node.Content); i += 2 {
+		value := node.Content[i]
+		switch {
+		case value.Kind == yaml.ScalarNode:
+		case value.Kind == yaml.SequenceNode && !found && isObjectList(value):
+			rows = value.Content
+			found = true
+		default:
+			return nil, false
+		}
+	}
+	return rows, found
+}
+
+// isObjectList returns true if the sequence only holds objects. Empty
+// sequences are considered lists of objects, so that empty list responses
+// print no rows.
+func isObjectList(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		if item.Kind != yaml.MappingNode {
+			return false
+		}
+	}
+	return true
+}
+
+func defaultColumns(rows []*yaml.Node) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		if row.Kind != yaml.MappingNode {
+			if !seen[valueColumn] {
+				seen[valueColumn] = true
+				columns = append(columns, valueColumn)
+			}
+			continue
+		}
+		for i := 0; i < len(row.Content); i += 2 {
+			key := row.Content[i].Value
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	return columns
+}
+
+func lookup(row *yaml.Node, column string) *yaml.Node {
+	if row.Kind != yaml.MappingNode {
+		if column == valueColumn {
+			return row
+		}
+		return nil
+	}
+
+	node := row
+	for _, key := range strings.Split(column, ".") {
+		node = mappingValue(node, key)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func formatCell(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return strings.NewReplacer("\t", " ", "\n", " ").Replace(node.Value)
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			items = append(items, formatCell(item))
+		}
+		return strings.Join(items, ",")
+	case yaml.MappingNode:
+		// SPIFFE IDs and selectors are shown the way they are written
+		// on the command line.
+		trustDomain, path := mappingValue(node, "trust_domain"), mappingValue(node, "path")
+		if len(node.Content) == 4 && trustDomain != nil && path != nil {
+			return "spiffe://" + trustDomain.Value + path.Value
+		}
+		selectorType, selectorValue := mappingValue(node, "type"), mappingValue(node, "value")
+		if len(node.Content) == 4 && selectorType != nil && selectorValue != nil {
+			return selectorType.Value + ":" + selectorValue.Value
+		}
+
+		fields := make([]string, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			fields = append(fields, node.Content[i].Value+"="+formatCell(node.Content[i+1]))
+		}
+		return "{" + strings.Join(fields, " ") + "}"
+	default:
+		return ""
+	}
+}
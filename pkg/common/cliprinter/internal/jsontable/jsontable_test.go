@@ -0,0 +1,117 @@
+package jsontable
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const listAgentsJSON = `{
+	"agents": [
+		{
+			"id": {"trust_domain": "example.org", "path": "/spire/agent/a"},
+			"attestation_type": "join_token",
+			"selectors": [{"type": "k8s", "value": "ns:a"}, {"type": "k8s", "value": "sa:b"}],
+			"banned": false
+		},
+		{
+			"id": {"trust_domain": "example.org", "path": "/spire/agent/b"},
+			"attestation_type": "x509pop",
+			"selectors": [],
+			"banned": true
+		}
+	],
+	"next_page_token": ""
+}`
+
+func TestPrint(t *testing.T) {
+	cases := []struct {
+		name        string
+		json        string
+		columns     []string
+		stdout      string
+		expectError bool
+	}{
+		{
+			name: "list_response_with_default_columns",
+			json: listAgentsJSON,
+			stdout: "" +
+				"ID                                   ATTESTATION_TYPE   SELECTORS           BANNED\n" +
+				"spiffe://example.org/spire/agent/a   join_token         k8s:ns:a,k8s:sa:b   false\n" +
+				"spiffe://example.org/spire/agent/b   x509pop                                true\n",
+		},
+		{
+			name:    "list_response_with_columns",
+			json:    listAgentsJSON,
+			columns: []string{"id.path", "banned", "unknown"},
+			stdout: "" +
+				"ID.PATH          BANNED   UNKNOWN\n" +
+				"/spire/agent/a   false    \n" +
+				"/spire/agent/b   true     \n",
+		},
+		{
+			name:   "empty_list_response",
+			json:   `{"agents":[],"next_page_token":""}`,
+			stdout: "",
+		},
+		{
+			name:    "empty_list_response_with_columns",
+			json:    `{"agents":[],"next_page_token":""}`,
+			columns: []string{"id"},
+			stdout:  "ID\n",
+		},
+		{
+			name:   "single_object",
+			json:   `{"count":42}`,
+			stdout: "COUNT\n42\n",
+		},
+		{
+			name:   "single_object_with_a_list",
+			json:   `{"id":"entry","dns_names":["a","b"],"admin":false,"downstream":true}`,
+			stdout: "ID      DNS_NAMES   ADMIN   DOWNSTREAM\nentry   a,b         false   true\n",
+		},
+		{
+			name:   "struct_printed_as_array_of_one",
+			json:   `[{"results":[{"name":"a"},{"name":"b"}]}]`,
+			stdout: "NAME\na\nb\n",
+		},
+		{
+			name:   "array_of_objects",
+			json:   `[{"count":1},{"count":2,"other":"x"}]`,
+			stdout: "COUNT   OTHER\n1       \n2       x\n",
+		},
+		{
+			name:   "array_of_scalars",
+			json:   `["a","b"]`,
+			stdout: "VALUE\na\nb\n",
+		},
+		{
+			name:   "nested_objects_and_nulls",
+			json:   `{"status":{"code":3,"message":"bad\tthing\nhappened"},"value":null}`,
+			stdout: "STATUS                                VALUE\n{code=3 message=bad thing happened}   \n",
+		},
+		{
+			name:        "invalid_json",
+			json:        `{"friendly":`,
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			err := Print([]byte(c.json), c.columns, stdout, stderr)
+			if c.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, c.stdout, stdout.String())
+			assert.Empty(t, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package jsontemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// Template is a Go template executed over JSON.
+type Template struct {
+	text string
+	t    *template.Template
+}
+
+// Parse parses a Go template. In addition to the built-in functions, the
+// template can use "json", which formats a value as JSON, and "join", which
+// joins the elements of an array with a separator.
+func Parse(text string) (*Template, error) {
+	t, err := template.New("output").Funcs(template.FuncMap{
+		"json": toJSON,
+		"join": join,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{text: text, t: t}, nil
+}
+
+// String returns the text of the template.
+func (t *Template) String() string {
+	return t.text
+}
+
+// Print executes the template over JSON decoded into maps, slices and
+// scalar values, the same way it is printed by the JSON format, so that
+// fields are referenced by their JSON names, e.g. {{.id.path}}. A newline is
+// printed after the output unless it already ends with one.
+func (t *Template) Print(jb []byte, stdout, _ io.Writer) error {
+	decoder := json.NewDecoder(bytes.NewReader(jb))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	// The template is executed into a buffer first so that nothing is
+	// printed if it fails.
+	out := new(bytes.Buffer)
+	if err := t.t.Execute(out, data); err != nil {
+		return err
+	}
+	if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteByte('\n')
+	}
+
+	_, err := stdout.Write(out.Bytes())
+	return err
+}
+
+func toJSON(v interface{}) (string, error) {
+	jb, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(jb), nil
+}
+
+func join(sep string, v interface{}) (string, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return "", fmt.Errorf("join: expected an array, got %T", v)
+	}
+	s := make([]string, 0, len(items))
+	for _, item := range items {
+		s = append(s, fmt.Sprint(item))
+	}
+	return strings.Join(s, sep), nil
+}
@@ -0,0 +1,97 @@
+package jsontemplate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse("{{.id}}")
+	require.NoError(t, err)
+	assert.Equal(t, "{{.id}}", tmpl.String())
+
+	_, err = Parse("{{.id")
+	require.EqualError(t, err, `template: output:1: unclosed action`)
+}
+
+func TestPrint(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		json        string
+		stdout      string
+		expectError string
+	}{
+		{
+			name:     "field",
+			template: "{{.id.path}}",
+			json:     `{"id":{"trust_domain":"example.org","path":"/workload"}}`,
+			stdout:   "/workload\n",
+		},
+		{
+			name:     "range",
+			template: `{{range .agents}}{{.id}} {{.banned}}{{"\n"}}{{end}}`,
+			json:     `{"agents":[{"id":"a","banned":false},{"id":"b","banned":true}]}`,
+			stdout:   "a false\nb true\n",
+		},
+		{
+			name:     "numbers_are_not_reformatted",
+			template: "{{.count}} {{.ratio}}",
+			json:     `{"count":12345678901,"ratio":0.5}`,
+			stdout:   "12345678901 0.5\n",
+		},
+		{
+			name:     "json_function",
+			template: "{{json .selectors}}",
+			json:     `{"selectors":[{"type":"unix","value":"uid:1000"}]}`,
+			stdout:   `[{"type":"unix","value":"uid:1000"}]` + "\n",
+		},
+		{
+			name:     "join_function",
+			template: `{{.dns_names | join ","}}`,
+			json:     `{"dns_names":["a","b"]}`,
+			stdout:   "a,b\n",
+		},
+		{
+			name:        "join_function_on_a_scalar",
+			template:    `{{.id | join ","}}`,
+			json:        `{"id":"a"}`,
+			expectError: `template: output:1:8: executing "output" at <join ",">: error calling join: join: expected an array, got string`,
+		},
+		{
+			name:     "empty_output",
+			template: "{{if .banned}}banned{{end}}",
+			json:     `{"banned":false}`,
+			stdout:   "",
+		},
+		{
+			name:        "invalid_json",
+			template:    "{{.id}}",
+			json:        `{"id":`,
+			expectError: "unexpected EOF",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl, err := Parse(c.template)
+			require.NoError(t, err)
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			err = tmpl.Print([]byte(c.json), stdout, stderr)
+			if c.expectError != "" {
+				require.EqualError(t, err, c.expectError)
+				assert.Empty(t, stdout.String())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, c.stdout, stdout.String())
+			assert.Empty(t, stderr.String())
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package jsonyaml
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Print prints JSON formatted as YAML. Object keys keep the order they
+// have in the JSON.
+func Print(jb []byte, stdout, _ io.Writer) error {
+	// JSON is a subset of YAML, so decoding it into a node keeps the order
+	// of the keys. The flow and quoting styles of the JSON are cleared so
+	// that the node is encoded in block style.
+	var node yaml.Node
+	if err := yaml.Unmarshal(jb, &node); err != nil {
+		return err
+	}
+	clearStyle(&node)
+
+	encoder := yaml.NewEncoder(stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
@@ -0,0 +1,60 @@
+package jsonyaml
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	cases := []struct {
+		name        string
+		json        string
+		stdout      string
+		expectError bool
+	}{
+		{
+			name:   "object_keeps_key_order",
+			json:   `{"count":42,"agents":[{"id":{"trust_domain":"example.org","path":"/agent"},"banned":false}],"next_page_token":""}`,
+			stdout: "count: 42\nagents:\n  - id:\n      trust_domain: example.org\n      path: /agent\n    banned: false\nnext_page_token: \"\"\n",
+		},
+		{
+			name:   "strings_that_look_like_other_types_are_quoted",
+			json:   `{"expires_at":"1234","admin":"true"}`,
+			stdout: "expires_at: \"1234\"\nadmin: \"true\"\n",
+		},
+		{
+			name:   "empty_collections",
+			json:   `{"selectors":[],"mask":{}}`,
+			stdout: "selectors: []\nmask: {}\n",
+		},
+		{
+			name:   "array",
+			json:   `[{"friendly":true},{"friendly":false}]`,
+			stdout: "- friendly: true\n- friendly: false\n",
+		},
+		{
+			name:        "invalid_json",
+			json:        `{"friendly":`,
+			expectError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			err := Print([]byte(c.json), stdout, stderr)
+			if c.expectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, c.stdout, stdout.String())
+			assert.Empty(t, stderr.String())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package protojson
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/spiffe/spire/pkg/common/cliprinter/internal/errorjson"
@@ -15,6 +16,20 @@ func Print(msgs []proto.Message, stdout, stderr io.Writer) error {
 		return nil
 	}
 
+	jb, err := Marshal(msgs)
+	if err != nil {
+		_ = errorjson.Print(err, stdout, stderr)
+		return err
+	}
+
+	_, err = fmt.Fprintln(stdout, string(jb))
+	return err
+}
+
+// Marshal marshals one or more protobuf messages to the JSON that Print
+// prints. A single message is marshaled as an object, and more than one as
+// an array.
+func Marshal(msgs []proto.Message) ([]byte, error) {
 	jms := []json.RawMessage{}
 	m := &protojson.MarshalOptions{
 		UseProtoNames:   true,
@@ -28,27 +43,21 @@ func Print(msgs []proto.Message, stdout, stderr io.Writer) error {
 	for _, msg := range msgs {
 		jb, err := m.Marshal(msg)
 		if err != nil {
-			_ = errorjson.Print(err, stdout, stderr)
-			return err
+			return nil, err
 		}
 
 		jms = append(jms, jb)
 	}
-	var err error
 
 	parsedJms, err := parseJSONMessages(jms)
 	if err != nil {
-		_ = errorjson.Print(err, stdout, stderr)
-		return err
+		return nil, err
 	}
 
 	if len(parsedJms) == 1 {
-		err = json.NewEncoder(stdout).Encode(parsedJms[0])
-	} else {
-		err = json.NewEncoder(stdout).Encode(parsedJms)
+		return json.Marshal(parsedJms[0])
 	}
-
-	return err
+	return json.Marshal(parsedJms)
 }
 
 func parseJSONMessages(jms []json.RawMessage) ([]json.RawMessage, error) {
@@ -7,18 +7,11 @@ import (
 )
 
 func Print(msgs []interface{}, stdout, stderr io.Writer) error {
-	var jb []byte
-	var err error
-
 	if len(msgs) == 0 {
 		return nil
 	}
 
-	if len(msgs) == 1 {
-		jb, err = json.Marshal(msgs[0])
-	} else {
-		jb, err = json.Marshal(msgs)
-	}
+	jb, err := Marshal(msgs)
 	if err != nil {
 		_, _ = fmt.Fprintf(stdout, "{\"error\": %q}\n", err.Error())
 		return err
@@ -27,3 +20,12 @@ func Print(msgs []interface{}, stdout, stderr io.Writer) error {
 	_, err = fmt.Fprintln(stdout, string(jb))
 	return err
 }
+
+// Marshal marshals one or more structs to the JSON that Print prints. A
+// single struct is marshaled as is, and more than one as an array.
+func Marshal(msgs []interface{}) ([]byte, error) {
+	if len(msgs) == 1 {
+		return json.Marshal(msgs[0])
+	}
+	return json.Marshal(msgs)
+}
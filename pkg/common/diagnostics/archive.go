@@ -0,0 +1,108 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ErrorsFileName is the name of the archive file listing the files that
+	// could not be collected.
+	ErrorsFileName = "errors.txt"
+
+	fileMode = 0600
+)
+
+// Archive collects diagnostic files into a gzip compressed tar archive.
+// Failures to write the archive are sticky and returned by Close.
+type Archive struct {
+	buf  bytes.Buffer
+	gz   *gzip.Writer
+	tw   *tar.Writer
+	now  time.Time
+	errs []string
+	err  error
+}
+
+// NewArchive creates an empty archive whose files are timestamped with the
+// given time.
+func NewArchive(now time.Time) *Archive {
+	a := &Archive{now: now}
+	a.gz = gzip.NewWriter(&a.buf)
+	a.tw = tar.NewWriter(a.gz)
+	return a
+}
+
+// Collect adds a file with the data returned by collect to the archive. If
+// collect fails, the error is recorded in the errors file instead, so that
+// failing to collect one file does not prevent collecting the others.
+func (a *Archive) Collect(name string, collect func() ([]byte, error)) {
+	data, err := collect()
+	if err != nil {
+		a.errs = append(a.errs, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	a.AddFile(name, data)
+}
+
+// CollectProto is like Collect, but adds the message returned by collect
+// encoded as JSON.
+func (a *Archive) CollectProto(name string, collect func() (proto.Message, error)) {
+	a.Collect(name, func() ([]byte, error) {
+		m, err := collect()
+		if err != nil {
+			return nil, err
+		}
+		return protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	})
+}
+
+// AddFile adds a file to the archive.
+func (a *Archive) AddFile(name string, data []byte) {
+	if a.err != nil {
+		return
+	}
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    fileMode,
+		Size:    int64(len(data)),
+		ModTime: a.now,
+	}); err != nil {
+		a.err = fmt.Errorf("unable to write %s header: %w", name, err)
+		return
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		a.err = fmt.Errorf("unable to write %s: %w", name, err)
+	}
+}
+
+// Errors returns the errors recorded for the files that could not be
+// collected.
+func (a *Archive) Errors() []string {
+	return a.errs
+}
+
+// Close adds the errors file, if any file could not be collected, and
+// returns the bytes of the archive.
+func (a *Archive) Close() ([]byte, error) {
+	if len(a.errs) > 0 {
+		a.AddFile(ErrorsFileName, []byte(strings.Join(a.errs, "\n")+"\n"))
+	}
+	if a.err != nil {
+		return nil, a.err
+	}
+	if err := a.tw.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close archive: %w", err)
+	}
+	if err := a.gz.Close(); err != nil {
+		return nil, fmt.Errorf("unable to compress archive: %w", err)
+	}
+	return a.buf.Bytes(), nil
+}
@@ -0,0 +1,87 @@
+package diagnostics
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestArchive(t *testing.T) {
+	now := time.Unix(1666000000, 0)
+	archive := NewArchive(now)
+
+	archive.AddFile("info.json", []byte(`{"uptime":1}`))
+	archive.Collect("metrics.json", func() ([]byte, error) {
+		return []byte(`{"Counters":[]}`), nil
+	})
+	archive.CollectProto("health.json", func() (proto.Message, error) {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+	})
+	archive.Collect("goroutines.txt", func() ([]byte, error) {
+		return nil, errors.New("oh no")
+	})
+	archive.CollectProto("ca_journal.json", func() (proto.Message, error) {
+		return nil, errors.New("oh no again")
+	})
+	assert.Equal(t, []string{"goroutines.txt: oh no", "ca_journal.json: oh no again"}, archive.Errors())
+
+	data, err := archive.Close()
+	require.NoError(t, err)
+
+	files := readArchive(t, data, now)
+	// protojson output is purposefully unstable
+	assert.JSONEq(t, `{"status":"SERVING"}`, files["health.json"])
+	delete(files, "health.json")
+	assert.Equal(t, map[string]string{
+		"info.json":    `{"uptime":1}`,
+		"metrics.json": `{"Counters":[]}`,
+		ErrorsFileName: "goroutines.txt: oh no\nca_journal.json: oh no again\n",
+	}, files)
+}
+
+func TestArchiveWithoutErrors(t *testing.T) {
+	now := time.Unix(1666000000, 0)
+	archive := NewArchive(now)
+
+	archive.AddFile("info.json", []byte(`{"uptime":1}`))
+	assert.Empty(t, archive.Errors())
+
+	data, err := archive.Close()
+	require.NoError(t, err)
+
+	files := readArchive(t, data, now)
+	assert.Equal(t, map[string]string{
+		"info.json": `{"uptime":1}`,
+	}, files)
+}
+
+func readArchive(t *testing.T, data []byte, expectModTime time.Time) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int64(0600), header.Mode)
+		assert.True(t, expectModTime.Equal(header.ModTime))
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
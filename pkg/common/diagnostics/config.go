@@ -0,0 +1,117 @@
+package diagnostics
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/hashicorp/hcl/hcl/token"
+)
+
+// RedactedValue replaces the values redacted from configurations.
+const RedactedValue = "[REDACTED]"
+
+// secretKeys are the patterns, in path.Match syntax, of the configuration
+// keys holding secrets, e.g. the agent join_token or the server ACME
+// eab_hmac_key, whose values are redacted. Only keys assigned a value are
+// matched, so that plugins named like secrets (e.g. the join_token node
+// attestor) are kept.
+var secretKeys = []string{
+	"join_token",
+	"eab_hmac_key",
+	"*_token",
+	"*passphrase*",
+	"*password*",
+	"*secret*",
+	"*connection_string",
+}
+
+// RedactConfig parses an HCL or JSON configuration and returns it in HCL
+// with the plugin_data of every plugin, since it commonly holds
+// credentials, and the values of known secret keys redacted.
+// Comments are dropped as well, since they may hold commented out secrets.
+func RedactConfig(data []byte) ([]byte, error) {
+	file, err := hcl.ParseBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	file.Comments = nil
+	ast.Walk(file.Node, func(n ast.Node) (ast.Node, bool) {
+		item, ok := n.(*ast.ObjectItem)
+		if !ok {
+			return n, true
+		}
+		item.LeadComment = nil
+		item.LineComment = nil
+
+		// JSON configurations flatten nested objects into a single item with
+		// many keys (e.g. "plugins" "DataStore" "sql" "plugin_data").
+		i := indexOfRedactedKey(item)
+		if i < 0 {
+			return n, true
+		}
+
+		assign := item.Keys[i].Pos()
+		if !assign.IsValid() {
+			// The printer only writes the assignment of items with a valid
+			// assignment position, which JSON keys lack.
+			assign = token.Pos{Line: 1, Column: 1}
+		}
+		redacted := &ast.ObjectItem{
+			Keys:   []*ast.ObjectKey{item.Keys[i]},
+			Assign: assign,
+			Val: &ast.LiteralType{
+				Token: token.Token{Type: token.STRING, Text: strconv.Quote(RedactedValue)},
+			},
+		}
+		if i == 0 {
+			return redacted, false
+		}
+		item.Keys = item.Keys[:i]
+		item.Val = &ast.ObjectType{
+			List: &ast.ObjectList{Items: []*ast.ObjectItem{redacted}},
+		}
+		return item, false
+	})
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, file); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// ReadRedactedConfig reads the configuration file at the given path and
+// redacts it with RedactConfig.
+func ReadRedactedConfig(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return RedactConfig(data)
+}
+
+func indexOfRedactedKey(item *ast.ObjectItem) int {
+	for i, key := range item.Keys {
+		name := strings.ToLower(strings.Trim(key.Token.Text, `"`))
+		if name == "plugin_data" {
+			return i
+		}
+		if _, isObject := item.Val.(*ast.ObjectType); isObject || i != len(item.Keys)-1 {
+			continue
+		}
+		for _, pattern := range secretKeys {
+			if ok, _ := path.Match(pattern, name); ok {
+				return i
+			}
+		}
+	}
+	return -1
+}
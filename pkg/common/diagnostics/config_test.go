@@ -0,0 +1,185 @@
+package diagnostics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		config    string
+		expectErr string
+		expectOut string
+	}{
+		{
+			name: "HCL",
+			config: `
+server {
+    # The trust domain
+    trust_domain = "example.org"
+}
+
+plugins {
+    DataStore "sql" {
+        plugin_data {
+            # connection_string = "old-password"
+            database_type = "postgres"
+            connection_string = "password"
+        }
+    }
+
+    KeyManager "memory" {
+        plugin_data = {}
+    }
+}
+`,
+			expectOut: `server {
+  trust_domain = "example.org"
+}
+
+plugins {
+  DataStore "sql" {
+    plugin_data = "[REDACTED]"
+  }
+
+  KeyManager "memory" {
+    plugin_data = "[REDACTED]"
+  }
+}
+`,
+		},
+		{
+			name: "secret keys",
+			config: `
+agent {
+    trust_domain = "example.org"
+    join_token = "password"
+}
+
+server {
+    ca_key_type = "ec-p256"
+    federation {
+        bundle_endpoint {
+            acme {
+                domain_name = "example.org"
+                eab_key_id = "kid"
+                eab_hmac_key = "password"
+            }
+        }
+    }
+    key_envelope {
+        passphrase_file = "/run/secrets/password"
+    }
+}
+
+plugins {
+    NodeAttestor "join_token" {
+        plugin_data {}
+    }
+
+    UpstreamAuthority "vault" {
+        plugin_data {
+            token_auth {
+                token = "password"
+            }
+        }
+    }
+}
+`,
+			expectOut: `agent {
+  trust_domain = "example.org"
+  join_token   = "[REDACTED]"
+}
+
+server {
+  ca_key_type = "ec-p256"
+
+  federation {
+    bundle_endpoint {
+      acme {
+        domain_name  = "example.org"
+        eab_key_id   = "kid"
+        eab_hmac_key = "[REDACTED]"
+      }
+    }
+  }
+
+  key_envelope {
+    passphrase_file = "[REDACTED]"
+  }
+}
+
+plugins {
+  NodeAttestor "join_token" {
+    plugin_data = "[REDACTED]"
+  }
+
+  UpstreamAuthority "vault" {
+    plugin_data = "[REDACTED]"
+  }
+}
+`,
+		},
+		{
+			name:   "JSON secret keys",
+			config: `{"agent": {"join_token": "password", "trust_domain": "example.org"}, "server": {"federation": {"bundle_endpoint": {"acme": {"eab_hmac_key": "password"}}}}}`,
+			expectOut: `"agent" = {
+  "join_token" = "[REDACTED]"
+
+  "trust_domain" = "example.org"
+}
+
+"server" "federation" "bundle_endpoint" "acme" {
+  "eab_hmac_key" = "[REDACTED]"
+}
+`,
+		},
+		{
+			name:   "JSON",
+			config: `{"plugins": {"DataStore": {"sql": {"plugin_data": {"connection_string": "password"}}}}}`,
+			expectOut: `"plugins" "DataStore" "sql" {
+  "plugin_data" = "[REDACTED]"
+}
+`,
+		},
+		{
+			name:      "malformed",
+			config:    `server {`,
+			expectErr: "At 1:10: object expected closing RBRACE got: EOF",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := RedactConfig([]byte(tt.config))
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectOut, string(out))
+			assert.NotContains(t, string(out), "password")
+		})
+	}
+}
+
+func TestReadRedactedConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.conf")
+	require.NoError(t, os.WriteFile(path, []byte(`plugins { KeyManager "disk" { plugin_data { keys_path = "keys.json" } } }`), 0600))
+
+	out, err := ReadRedactedConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, `plugins {
+  KeyManager "disk" {
+    plugin_data = "[REDACTED]"
+  }
+}
+`, string(out))
+
+	_, err = ReadRedactedConfig(filepath.Join(dir, "missing.conf"))
+	require.True(t, os.IsNotExist(err))
+}
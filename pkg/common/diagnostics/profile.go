@@ -0,0 +1,16 @@
+package diagnostics
+
+import (
+	"bytes"
+	"runtime/pprof"
+)
+
+// GoroutineProfile returns the stack traces of all current goroutines, in
+// the text format produced by the goroutine pprof profile with debug=2.
+func GoroutineProfile() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
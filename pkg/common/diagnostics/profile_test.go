@@ -0,0 +1,14 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineProfile(t *testing.T) {
+	profile, err := GoroutineProfile()
+	require.NoError(t, err)
+	assert.Contains(t, string(profile), "diagnostics.TestGoroutineProfile")
+}
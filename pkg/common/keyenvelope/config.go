@@ -0,0 +1,54 @@
+package keyenvelope
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Config is the configuration of the key file encryption. It is meant to be
+// embedded as a block in the plugin configuration. Exactly one of the key
+// encryption key sources must be configured.
+type Config struct {
+	// PassphraseFile is the path to a file containing the passphrase.
+	PassphraseFile string `hcl:"passphrase_file"`
+
+	// PassphraseEnv is the name of an environment variable containing the
+	// passphrase.
+	PassphraseEnv string `hcl:"passphrase_env"`
+
+	// KMSPlugin configures an external KMS plugin holding the key encryption
+	// key.
+	KMSPlugin *KMSPluginConfig `hcl:"kms_plugin"`
+}
+
+// NewKeyWrapper returns the KeyWrapper for the configuration.
+func (c *Config) NewKeyWrapper() (KeyWrapper, error) {
+	sources := 0
+	for _, configured := range []bool{c.PassphraseFile != "", c.PassphraseEnv != "", c.KMSPlugin != nil} {
+		if configured {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.New("exactly one of passphrase_file, passphrase_env or kms_plugin must be configured")
+	}
+
+	switch {
+	case c.PassphraseFile != "":
+		passphrase, err := os.ReadFile(c.PassphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read passphrase file: %w", err)
+		}
+		return NewPassphraseWrapper(bytes.TrimRight(passphrase, "\r\n"))
+	case c.PassphraseEnv != "":
+		passphrase, ok := os.LookupEnv(c.PassphraseEnv)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %q is not set", c.PassphraseEnv)
+		}
+		return NewPassphraseWrapper([]byte(passphrase))
+	default:
+		return NewKMSPluginWrapper(*c.KMSPlugin)
+	}
+}
@@ -0,0 +1,153 @@
+// Package keyenvelope implements envelope encryption for files holding
+// private key material (e.g. the keys file of the disk key managers).
+//
+// The file contents are encrypted with a random, per-write data encryption
+// key (DEK) using AES-256-GCM. The DEK is in turn wrapped by a KeyWrapper,
+// which holds the key encryption key (e.g. a key derived from a passphrase)
+// or delegates to an external KMS plugin holding it.
+// Only the wrapped DEK is stored alongside the ciphertext.
+package keyenvelope
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	envelopeVersion = 1
+
+	dekSize = 32
+)
+
+// KeyWrapper wraps and unwraps data encryption keys.
+type KeyWrapper interface {
+	// Name returns the name of the wrapper. It is recorded in the envelope
+	// so a file sealed by a different wrapper can be detected.
+	Name() string
+
+	// WrapKey wraps (encrypts) the given data encryption key.
+	WrapKey(ctx context.Context, dek []byte) ([]byte, error)
+
+	// UnwrapKey unwraps (decrypts) the given data encryption key.
+	UnwrapKey(ctx context.Context, wrappedDEK []byte) ([]byte, error)
+}
+
+type envelope struct {
+	Version    int    `json:"version"`
+	Wrapper    string `json:"wrapper"`
+	WrappedKey []byte `json:"wrapped_key"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// IsSealed returns true if the data is an envelope produced by Seal.
+func IsSealed(data []byte) bool {
+	var probe struct {
+		Version    int    `json:"version"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.Version != 0 && probe.Ciphertext != nil
+}
+
+// Seal encrypts the plaintext with a new data encryption key, which is
+// wrapped using the given wrapper. The returned envelope is JSON encoded.
+func Seal(ctx context.Context, wrapper KeyWrapper, plaintext []byte) ([]byte, error) {
+	dek := make([]byte, dekSize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, fmt.Errorf("unable to generate data encryption key: %w", err)
+	}
+
+	ciphertext, err := encrypt(dek, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	wrappedKey, err := wrapper.WrapKey(ctx, dek)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wrap data encryption key: %w", err)
+	}
+
+	return json.MarshalIndent(envelope{
+		Version:    envelopeVersion,
+		Wrapper:    wrapper.Name(),
+		WrappedKey: wrappedKey,
+		Ciphertext: ciphertext,
+	}, "", "\t")
+}
+
+// Open decrypts an envelope produced by Seal using the given wrapper.
+func Open(ctx context.Context, wrapper KeyWrapper, data []byte) ([]byte, error) {
+	env := new(envelope)
+	if err := json.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("unable to decode envelope: %w", err)
+	}
+
+	switch {
+	case env.Version != envelopeVersion:
+		return nil, fmt.Errorf("unsupported envelope version %d", env.Version)
+	case env.Wrapper != wrapper.Name():
+		return nil, fmt.Errorf("data was sealed using %q but %q is configured", env.Wrapper, wrapper.Name())
+	}
+
+	dek, err := wrapper.UnwrapKey(ctx, env.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unwrap data encryption key: %w", err)
+	}
+
+	plaintext, err := decrypt(dek, env.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	return plaintext, nil
+}
+
+// encrypt encrypts the plaintext using AES-GCM. The nonce is prepended to
+// the ciphertext.
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("unable to generate nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decrypt decrypts a ciphertext produced by encrypt.
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("unable to decrypt data: message authentication failed")
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
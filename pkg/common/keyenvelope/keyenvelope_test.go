@@ -0,0 +1,215 @@
+package keyenvelope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+
+	plaintext = []byte(`{"keys":{}}`)
+)
+
+func TestSealAndOpen(t *testing.T) {
+	wrapper := newPassphraseWrapper(t, "passphrase")
+
+	sealed, err := Seal(ctx, wrapper, plaintext)
+	require.NoError(t, err)
+	require.True(t, IsSealed(sealed))
+	require.False(t, bytes.Contains(sealed, plaintext))
+
+	// A new wrapper with the same passphrase can open the envelope
+	opened, err := Open(ctx, newPassphraseWrapper(t, "passphrase"), sealed)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+
+	// Sealing twice produces different envelopes
+	sealedAgain, err := Seal(ctx, wrapper, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, sealedAgain)
+}
+
+func TestOpenFailures(t *testing.T) {
+	wrapper := newPassphraseWrapper(t, "passphrase")
+	sealed, err := Seal(ctx, wrapper, plaintext)
+	require.NoError(t, err)
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := Open(ctx, newPassphraseWrapper(t, "nope"), sealed)
+		require.EqualError(t, err, "unable to unwrap data encryption key: incorrect passphrase")
+	})
+
+	t.Run("wrong wrapper", func(t *testing.T) {
+		_, err := Open(ctx, fakeWrapper{}, sealed)
+		require.EqualError(t, err, `data was sealed using "passphrase" but "fake" is configured`)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		env := new(envelope)
+		require.NoError(t, json.Unmarshal(sealed, env))
+		env.Ciphertext[len(env.Ciphertext)-1] ^= 0xff
+		_, err := Open(ctx, wrapper, jsonMarshal(t, env))
+		require.EqualError(t, err, "unable to decrypt data: message authentication failed")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		env := new(envelope)
+		require.NoError(t, json.Unmarshal(sealed, env))
+		env.Version = 2
+		_, err := Open(ctx, wrapper, jsonMarshal(t, env))
+		require.EqualError(t, err, "unsupported envelope version 2")
+	})
+
+	t.Run("malformed envelope", func(t *testing.T) {
+		_, err := Open(ctx, wrapper, []byte("{"))
+		require.ErrorContains(t, err, "unable to decode envelope")
+	})
+}
+
+func TestIsSealed(t *testing.T) {
+	assert.False(t, IsSealed(plaintext))
+	assert.False(t, IsSealed([]byte("not JSON")))
+	assert.False(t, IsSealed([]byte(`{"keys":{"id":"AAAA"}}`)))
+}
+
+func TestConfig(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("passphrase\n"), 0600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0600))
+	t.Setenv("KEYENVELOPE_TEST_PASSPHRASE", "passphrase")
+
+	for _, tt := range []struct {
+		name        string
+		hcl         string
+		expectName  string
+		expectError string
+	}{
+		{
+			name:        "nothing configured",
+			hcl:         ``,
+			expectError: "exactly one of passphrase_file, passphrase_env or kms_plugin must be configured",
+		},
+		{
+			name:        "more than one configured",
+			hcl:         `passphrase_file = "file" passphrase_env = "ENV"`,
+			expectError: "exactly one of passphrase_file, passphrase_env or kms_plugin must be configured",
+		},
+		{
+			name:       "passphrase file",
+			hcl:        `passphrase_file = "` + filepath.ToSlash(passphraseFile) + `"`,
+			expectName: "passphrase",
+		},
+		{
+			name:        "passphrase file does not exist",
+			hcl:         `passphrase_file = "` + filepath.ToSlash(filepath.Join(dir, "nope")) + `"`,
+			expectError: "unable to read passphrase file",
+		},
+		{
+			name:        "passphrase file is empty",
+			hcl:         `passphrase_file = "` + filepath.ToSlash(emptyFile) + `"`,
+			expectError: "passphrase cannot be empty",
+		},
+		{
+			name:       "passphrase env",
+			hcl:        `passphrase_env = "KEYENVELOPE_TEST_PASSPHRASE"`,
+			expectName: "passphrase",
+		},
+		{
+			name:        "passphrase env is not set",
+			hcl:         `passphrase_env = "KEYENVELOPE_TEST_NOT_SET"`,
+			expectError: `environment variable "KEYENVELOPE_TEST_NOT_SET" is not set`,
+		},
+		{
+			name:       "kms plugin",
+			hcl:        `kms_plugin { socket_path = "/run/kms.sock" key_id = "spire" }`,
+			expectName: "kms_plugin",
+		},
+		{
+			name:        "kms plugin without socket path",
+			hcl:         `kms_plugin { key_id = "spire" }`,
+			expectError: "kms_plugin socket_path is required",
+		},
+		{
+			name:        "kms plugin without key id",
+			hcl:         `kms_plugin { socket_path = "/run/kms.sock" }`,
+			expectError: "kms_plugin key_id is required",
+		},
+		{
+			name:        "kms plugin and passphrase",
+			hcl:         `passphrase_env = "ENV" kms_plugin { socket_path = "/run/kms.sock" key_id = "spire" }`,
+			expectError: "exactly one of passphrase_file, passphrase_env or kms_plugin must be configured",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := new(Config)
+			require.NoError(t, hcl.Decode(config, tt.hcl))
+
+			wrapper, err := config.NewKeyWrapper()
+			if tt.expectError != "" {
+				require.ErrorContains(t, err, tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectName, wrapper.Name())
+		})
+	}
+}
+
+func TestPassphraseFileIsTrimmed(t *testing.T) {
+	passphraseFile := filepath.Join(spiretest.TempDir(t), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("passphrase\r\n"), 0600))
+
+	config := &Config{PassphraseFile: passphraseFile}
+	wrapper, err := config.NewKeyWrapper()
+	require.NoError(t, err)
+
+	sealed, err := Seal(ctx, wrapper, plaintext)
+	require.NoError(t, err)
+
+	_, err = Open(ctx, newPassphraseWrapper(t, "passphrase"), sealed)
+	require.NoError(t, err)
+}
+
+func jsonMarshal(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func newPassphraseWrapper(t *testing.T, passphrase string) KeyWrapper {
+	wrapper, err := NewPassphraseWrapper([]byte(passphrase))
+	require.NoError(t, err)
+	return wrapper
+}
+
+// fakeWrapper "wraps" keys by prefixing them with its name
+type fakeWrapper struct{}
+
+func (fakeWrapper) Name() string {
+	return "fake"
+}
+
+func (fakeWrapper) WrapKey(ctx context.Context, dek []byte) ([]byte, error) {
+	return append([]byte("fake"), dek...), nil
+}
+
+func (fakeWrapper) UnwrapKey(ctx context.Context, wrappedDEK []byte) ([]byte, error) {
+	if !bytes.HasPrefix(wrappedDEK, []byte("fake")) {
+		return nil, errors.New("wrong key")
+	}
+	return wrappedDEK[len("fake"):], nil
+}
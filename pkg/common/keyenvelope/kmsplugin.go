@@ -0,0 +1,100 @@
+package keyenvelope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	keywrapperv1 "github.com/spiffe/spire/proto/spire/api/keywrapper/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	kmsPluginWrapperName = "kms_plugin"
+)
+
+// KMSPluginConfig configures a key wrapper backed by an external KMS plugin.
+type KMSPluginConfig struct {
+	// SocketPath is the path to the Unix domain socket the plugin serves the
+	// KeyWrapper API on.
+	SocketPath string `hcl:"socket_path"`
+
+	// KeyID identifies the key encryption key held by the plugin.
+	KeyID string `hcl:"key_id"`
+}
+
+// kmsPluginWrapper wraps data encryption keys by delegating to an external
+// KMS plugin, so the key encryption key never leaves the plugin. Keys are
+// only wrapped or unwrapped when the keys file is read or written, so a
+// connection is made for each call instead of being held open.
+type kmsPluginWrapper struct {
+	socketPath string
+	keyID      string
+}
+
+// NewKMSPluginWrapper returns a KeyWrapper that delegates to the KMS plugin
+// serving the KeyWrapper API on the given socket.
+func NewKMSPluginWrapper(config KMSPluginConfig) (KeyWrapper, error) {
+	if config.SocketPath == "" {
+		return nil, errors.New("kms_plugin socket_path is required")
+	}
+	if config.KeyID == "" {
+		return nil, errors.New("kms_plugin key_id is required")
+	}
+	return &kmsPluginWrapper{
+		socketPath: config.SocketPath,
+		keyID:      config.KeyID,
+	}, nil
+}
+
+func (w *kmsPluginWrapper) Name() string {
+	return kmsPluginWrapperName
+}
+
+func (w *kmsPluginWrapper) WrapKey(ctx context.Context, dek []byte) ([]byte, error) {
+	var wrappedDEK []byte
+	err := w.withClient(ctx, func(client keywrapperv1.KeyWrapperClient) error {
+		resp, err := client.WrapKey(ctx, &keywrapperv1.WrapKeyRequest{
+			KeyId: w.keyID,
+			Key:   dek,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to wrap key with KMS plugin: %w", err)
+		}
+		if len(resp.WrappedKey) == 0 {
+			return errors.New("KMS plugin returned an empty wrapped key")
+		}
+		wrappedDEK = resp.WrappedKey
+		return nil
+	})
+	return wrappedDEK, err
+}
+
+func (w *kmsPluginWrapper) UnwrapKey(ctx context.Context, wrappedDEK []byte) ([]byte, error) {
+	var dek []byte
+	err := w.withClient(ctx, func(client keywrapperv1.KeyWrapperClient) error {
+		resp, err := client.UnwrapKey(ctx, &keywrapperv1.UnwrapKeyRequest{
+			KeyId:      w.keyID,
+			WrappedKey: wrappedDEK,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to unwrap key with KMS plugin: %w", err)
+		}
+		if len(resp.Key) != dekSize {
+			return fmt.Errorf("KMS plugin returned a key of %d bytes; expected %d", len(resp.Key), dekSize)
+		}
+		dek = resp.Key
+		return nil
+	})
+	return dek, err
+}
+
+func (w *kmsPluginWrapper) withClient(ctx context.Context, fn func(keywrapperv1.KeyWrapperClient) error) error {
+	conn, err := grpc.DialContext(ctx, "unix:"+w.socketPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("unable to dial KMS plugin: %w", err)
+	}
+	defer conn.Close()
+	return fn(keywrapperv1.NewKeyWrapperClient(conn))
+}
@@ -0,0 +1,77 @@
+package keyenvelope
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	keywrapperv1 "github.com/spiffe/spire/proto/spire/api/keywrapper/v1"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestKMSPluginWrapper(t *testing.T) {
+	socketPath := filepath.Join(spiretest.TempDir(t), "kms.sock")
+	spiretest.StartGRPCUDSSocketServer(t, socketPath, func(s *grpc.Server) {
+		keywrapperv1.RegisterKeyWrapperServer(s, fakeKMSPlugin{})
+	})
+
+	wrapper := newKMSPluginWrapper(t, socketPath, "spire")
+
+	sealed, err := Seal(ctx, wrapper, plaintext)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(sealed, plaintext))
+
+	opened, err := Open(ctx, newKMSPluginWrapper(t, socketPath, "spire"), sealed)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, opened)
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := Open(ctx, newKMSPluginWrapper(t, socketPath, "other"), sealed)
+		require.EqualError(t, err, "unable to unwrap data encryption key: unable to unwrap key with KMS plugin: rpc error: code = InvalidArgument desc = wrong key")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := Seal(ctx, newKMSPluginWrapper(t, socketPath, "unknown"), plaintext)
+		require.ErrorContains(t, err, "unable to wrap key with KMS plugin: rpc error: code = NotFound desc = no such key")
+	})
+
+	t.Run("plugin unavailable", func(t *testing.T) {
+		wrapper := newKMSPluginWrapper(t, filepath.Join(spiretest.TempDir(t), "nope.sock"), "spire")
+		_, err := Open(ctx, wrapper, sealed)
+		require.ErrorContains(t, err, "unable to unwrap key with KMS plugin: rpc error: code = Unavailable")
+	})
+}
+
+func newKMSPluginWrapper(t *testing.T, socketPath, keyID string) KeyWrapper {
+	wrapper, err := NewKMSPluginWrapper(KMSPluginConfig{SocketPath: socketPath, KeyID: keyID})
+	require.NoError(t, err)
+	return wrapper
+}
+
+// fakeKMSPlugin "wraps" keys by prefixing them with the key ID
+type fakeKMSPlugin struct {
+	keywrapperv1.UnimplementedKeyWrapperServer
+}
+
+func (fakeKMSPlugin) WrapKey(ctx context.Context, req *keywrapperv1.WrapKeyRequest) (*keywrapperv1.WrapKeyResponse, error) {
+	if req.KeyId == "unknown" {
+		return nil, status.Error(codes.NotFound, "no such key")
+	}
+	return &keywrapperv1.WrapKeyResponse{
+		WrappedKey: append([]byte(req.KeyId), req.Key...),
+	}, nil
+}
+
+func (fakeKMSPlugin) UnwrapKey(ctx context.Context, req *keywrapperv1.UnwrapKeyRequest) (*keywrapperv1.UnwrapKeyResponse, error) {
+	if !bytes.HasPrefix(req.WrappedKey, []byte(req.KeyId)) {
+		return nil, status.Error(codes.InvalidArgument, "wrong key")
+	}
+	return &keywrapperv1.UnwrapKeyResponse{
+		Key: req.WrappedKey[len(req.KeyId):],
+	}, nil
+}
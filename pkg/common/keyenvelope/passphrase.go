@@ -0,0 +1,106 @@
+package keyenvelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	passphraseWrapperName = "passphrase"
+
+	saltSize = 16
+
+	// scrypt parameters recommended for interactive logins as of 2017
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// passphraseWrapper wraps data encryption keys with a key encryption key
+// derived from a passphrase using scrypt. The salt is prepended to the
+// wrapped key.
+type passphraseWrapper struct {
+	passphrase []byte
+
+	// The derived key is cached since key derivation is intentionally
+	// expensive. The same salt is reused for subsequent wraps.
+	mu   sync.Mutex
+	salt []byte
+	kek  []byte
+}
+
+// NewPassphraseWrapper returns a KeyWrapper that derives the key encryption
+// key from the given passphrase.
+func NewPassphraseWrapper(passphrase []byte) (KeyWrapper, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase cannot be empty")
+	}
+	return &passphraseWrapper{
+		passphrase: passphrase,
+	}, nil
+}
+
+func (w *passphraseWrapper) Name() string {
+	return passphraseWrapperName
+}
+
+func (w *passphraseWrapper) WrapKey(ctx context.Context, dek []byte) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.kek == nil {
+		salt := make([]byte, saltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return nil, fmt.Errorf("unable to generate salt: %w", err)
+		}
+		if err := w.deriveKey(salt); err != nil {
+			return nil, err
+		}
+	}
+
+	wrapped, err := encrypt(w.kek, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, w.salt...), wrapped...), nil
+}
+
+func (w *passphraseWrapper) UnwrapKey(ctx context.Context, wrappedDEK []byte) ([]byte, error) {
+	if len(wrappedDEK) < saltSize {
+		return nil, errors.New("wrapped key is too short")
+	}
+	salt, wrapped := wrappedDEK[:saltSize], wrappedDEK[saltSize:]
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !bytes.Equal(salt, w.salt) {
+		if err := w.deriveKey(salt); err != nil {
+			return nil, err
+		}
+	}
+
+	dek, err := decrypt(w.kek, wrapped)
+	if err != nil {
+		return nil, errors.New("incorrect passphrase")
+	}
+	return dek, nil
+}
+
+func (w *passphraseWrapper) deriveKey(salt []byte) error {
+	kek, err := scrypt.Key(w.passphrase, salt, scryptN, scryptR, scryptP, dekSize)
+	if err != nil {
+		return fmt.Errorf("unable to derive key from passphrase: %w", err)
+	}
+	w.salt = append([]byte{}, salt...)
+	w.kek = kek
+	return nil
+}
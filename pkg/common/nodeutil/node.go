@@ -16,6 +16,9 @@ var (
 		types.PermissionDeniedDetails_AGENT_NOT_ATTESTED:  {},
 		types.PermissionDeniedDetails_AGENT_MUST_REATTEST: {},
 	}
+	mustReattest = map[types.PermissionDeniedDetails_Reason]struct{}{
+		types.PermissionDeniedDetails_AGENT_MUST_REATTEST: {},
+	}
 	shouldShutDown = map[types.PermissionDeniedDetails_Reason]struct{}{
 		types.PermissionDeniedDetails_AGENT_BANNED: {},
 	}
@@ -32,6 +35,12 @@ func ShouldAgentReattest(err error) bool {
 	return isExpectedPermissionDenied(err, shouldReattest)
 }
 
+// MustAgentReattest returns true if the Server requires the Agent to go
+// through node attestation again before renewing its SVID
+func MustAgentReattest(err error) bool {
+	return isExpectedPermissionDenied(err, mustReattest)
+}
+
 // ShouldAgentShutdown returns true if the Server returned an error worth shutting down the Agent
 func ShouldAgentShutdown(err error) bool {
 	return isExpectedPermissionDenied(err, shouldShutDown)
@@ -48,6 +48,28 @@ func TestShouldAgentReattest(t *testing.T) {
 	require.False(t, nodeutil.ShouldAgentReattest(getError(t, codes.PermissionDenied, nil)))
 }
 
+func TestMustAgentReattest(t *testing.T) {
+	agentExpired := &types.PermissionDeniedDetails{
+		Reason: types.PermissionDeniedDetails_AGENT_EXPIRED,
+	}
+	agentMustReattest := &types.PermissionDeniedDetails{
+		Reason: types.PermissionDeniedDetails_AGENT_MUST_REATTEST,
+	}
+	agentBanned := &types.PermissionDeniedDetails{
+		Reason: types.PermissionDeniedDetails_AGENT_BANNED,
+	}
+
+	require.False(t, nodeutil.MustAgentReattest(nil))
+	require.True(t, nodeutil.MustAgentReattest(getError(t, codes.PermissionDenied, agentMustReattest)))
+	require.False(t, nodeutil.MustAgentReattest(getError(t, codes.PermissionDenied, agentExpired)))
+	require.False(t, nodeutil.MustAgentReattest(getError(t, codes.PermissionDenied, agentBanned)))
+
+	require.False(t, nodeutil.MustAgentReattest(getError(t, codes.Unknown, agentMustReattest)))
+
+	require.False(t, nodeutil.MustAgentReattest(getError(t, codes.PermissionDenied, &types.Status{})))
+	require.False(t, nodeutil.MustAgentReattest(getError(t, codes.PermissionDenied, nil)))
+}
+
 func TestShouldAgentShutdown(t *testing.T) {
 	agentExpired := &types.PermissionDeniedDetails{
 		Reason: types.PermissionDeniedDetails_AGENT_EXPIRED,
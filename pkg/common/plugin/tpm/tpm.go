@@ -0,0 +1,60 @@
+package tpm
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/spiffe/spire/pkg/common/plugin/tpmdevid"
+)
+
+const PluginName = "tpm"
+
+type AttestationRequest struct {
+	// EKCert is the endorsement certificate read from the TPM NV index. It
+	// is optional since not every TPM is provisioned with one.
+	EKCert []byte
+	EKPub  []byte
+
+	AKPub []byte
+}
+
+type ChallengeRequest struct {
+	CredActivation *tpmdevid.CredActivation
+
+	// Nonce is used as qualifying data for the PCR quote
+	Nonce []byte
+	PCRs  []int
+}
+
+type ChallengeResponse struct {
+	CredActivation []byte
+
+	Quote          []byte
+	QuoteSignature []byte
+	PCRValues      map[int][]byte
+}
+
+// EKHash returns the hex encoded SHA-256 hash of the DER encoding of the
+// endorsement public key, given in TPM wire format.
+func EKHash(ekPub []byte) (string, error) {
+	pub, err := tpm2.DecodePublic(ekPub)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode endorsement key public blob: %w", err)
+	}
+
+	key, err := pub.Key()
+	if err != nil {
+		return "", fmt.Errorf("cannot get endorsement public key: %w", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal endorsement public key: %w", err)
+	}
+
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
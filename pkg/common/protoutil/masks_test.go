@@ -44,6 +44,7 @@ func TestAllTrueMasks(t *testing.T) {
 		RootCas:        true,
 		JwtSigningKeys: true,
 		RefreshHint:    true,
+		SequenceNumber: true,
 	}, protoutil.AllTrueCommonBundleMask)
 
 	spiretest.AssertProtoEqual(t, &common.AttestedNodeMask{
@@ -53,6 +54,7 @@ func TestAllTrueMasks(t *testing.T) {
 		NewCertSerialNumber: true,
 		NewCertNotAfter:     true,
 		CanReattest:         true,
+		ReattestRequested:   true,
 	}, protoutil.AllTrueCommonAgentMask)
 
 	spiretest.AssertProtoEqual(t, &types.FederationRelationshipMask{
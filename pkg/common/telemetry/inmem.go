@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"time"
 
@@ -68,3 +69,24 @@ func (i *inmemRunner) run(ctx context.Context) error {
 func (i *inmemRunner) requiresTypePrefix() bool {
 	return false
 }
+
+// InmemMetrics returns the JSON encoded summary of the most recent interval
+// of the InMem sink of the given metrics, or nil if the InMem sink is not
+// configured.
+func InmemMetrics(m Metrics) ([]byte, error) {
+	impl, ok := m.(*MetricsImpl)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, runner := range impl.runners {
+		if inmem, ok := runner.(*inmemRunner); ok && inmem.isConfigured() {
+			summary, err := inmem.loadedSink.DisplayMetrics(nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(summary)
+		}
+	}
+	return nil, nil
+}
@@ -1,6 +1,7 @@
 package telemetry
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -75,6 +76,48 @@ func TestInMem(t *testing.T) {
 	}
 }
 
+func TestInmemMetrics(t *testing.T) {
+	t.Run("InMem sink configured", func(t *testing.T) {
+		metrics, err := NewMetrics(testInmemConfig())
+		require.NoError(t, err)
+
+		metrics.IncrCounter([]string{"some", "counter"}, 1)
+
+		data, err := InmemMetrics(metrics)
+		require.NoError(t, err)
+
+		var summary struct {
+			Counters []struct {
+				Name  string
+				Count int
+			}
+		}
+		require.NoError(t, json.Unmarshal(data, &summary))
+		require.Len(t, summary.Counters, 1)
+		assert.Equal(t, "foo.some.counter", summary.Counters[0].Name)
+		assert.Equal(t, 1, summary.Counters[0].Count)
+	})
+
+	t.Run("InMem sink not configured", func(t *testing.T) {
+		logger, _ := test.NewNullLogger()
+		metrics, err := NewMetrics(&MetricsConfig{
+			Logger:      logger,
+			ServiceName: "foo",
+		})
+		require.NoError(t, err)
+
+		data, err := InmemMetrics(metrics)
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+
+	t.Run("not a metrics implementation", func(t *testing.T) {
+		data, err := InmemMetrics(Blackhole{})
+		require.NoError(t, err)
+		assert.Nil(t, data)
+	})
+}
+
 func testInmemConfig() *MetricsConfig {
 	logger, _ := test.NewNullLogger()
 	return &MetricsConfig{
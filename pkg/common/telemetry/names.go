@@ -14,6 +14,10 @@ const (
 	// should be used with other tags to add clarity
 	Activate = "activate"
 
+	// Add functionality related to adding some element to a set (such as node
+	// selectors); should be used with other tags to add clarity
+	Add = "add"
+
 	// Append functionality related to appending some element (such as part of a bundle);
 	// should be used with other tags to add clarity
 	Append = "append"
@@ -22,6 +26,14 @@ const (
 	// to add clarity
 	Attest = "attest"
 
+	// Ban functionality related to banning some entity; should be used with other tags
+	// to add clarity
+	Ban = "ban"
+
+	// BatchDelete functionality related to deleting several entities at once; should be
+	// used with other tags to add clarity
+	BatchDelete = "batch_delete"
+
 	// Create functionality related to creating some entity; should be used with other tags
 	// to add clarity
 	Create = "create"
@@ -86,9 +98,17 @@ const (
 	// to add clarity
 	Push = "push"
 
+	// Remove functionality related to removing some element from a set (such
+	// as node selectors); should be used with other tags to add clarity
+	Remove = "remove"
+
 	// Reload functionality related to reloading of a cache
 	Reload = "reload"
 
+	// Restore functionality related to restoring some entity to a previous
+	// state; should be used with other tags to add clarity
+	Restore = "restore"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -157,9 +177,21 @@ const (
 	// BundleEndpointURL is the URL of the bundle endpoint
 	BundleEndpointURL = "bundle_endpoint_url"
 
+	// ByAgentVersion tags filtering by agent version
+	ByAgentVersion = "by_agent_version"
+
 	// ByBanned tags filtering by banned agents
 	ByBanned = "by_banned"
 
+	// ByCanReattest tags filtering by agents that can reattest
+	ByCanReattest = "by_can_reattest"
+
+	// ByExpiresBefore tags filtering by expiration time
+	ByExpiresBefore = "by_expires_before"
+
+	// ByLastSeenBefore tags filtering by last seen time
+	ByLastSeenBefore = "by_last_seen_before"
+
 	// BySelectorMatch tags Match used when filtering by Selectors
 	BySelectorMatch = "by_selector_match"
 
@@ -218,6 +250,9 @@ const (
 	// CsrSpiffeID represents the SPIFFE ID in a Certificate Signing Request.
 	CsrSpiffeID = "csr_spiffe_id"
 
+	// Cursor tags the position in a stream of events
+	Cursor = "cursor"
+
 	// DataDir is a data directory
 	DataDir = "data_dir"
 
@@ -242,6 +277,9 @@ const (
 	// Downstream tags if entry is a downstream
 	Downstream = "downstream"
 
+	// DryRun tags if an operation only reports what it would change
+	DryRun = "dry_run"
+
 	// ElapsedTime tags some duration of time.
 	ElapsedTime = "elapsed_time"
 
@@ -324,6 +362,9 @@ const (
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// LastSuccessAge tags the time elapsed since something last succeeded
+	LastSuccessAge = "last_success_age"
+
 	// Mode tags a bundle deletion mode
 	Mode = "mode"
 
@@ -333,9 +374,18 @@ const (
 	// NewSerialNumber tags a certificate new serial number
 	NewSerialNumber = "new_serial_num"
 
+	// NextRefresh tags the time until something is refreshed next
+	NextRefresh = "next_refresh"
+
 	// NodeAttestorType declares the type of node attestation.
 	NodeAttestorType = "node_attestor_type"
 
+	// NodeSelectors tags some group of node selectors
+	NodeSelectors = "node_selectors"
+
+	// OperatorSelectors tags some group of node selectors managed by operators
+	OperatorSelectors = "operator_selectors"
+
 	// Nonce tags some nonce for communication
 	Nonce = "nonce"
 
@@ -407,6 +457,9 @@ const (
 	// RegistrationEntry tags a registration entry
 	RegistrationEntry = "registration_entry"
 
+	// RegistrationEntryRevision tags a revision of a registration entry
+	RegistrationEntryRevision = "registration_entry_revision"
+
 	// RequestID tags a request identifier
 	RequestID = "request_id"
 
@@ -435,6 +488,9 @@ const (
 	// Selectors tags some group of registration selector
 	Selectors = "selectors"
 
+	// WorkloadSelectors tags some group of workload selectors
+	WorkloadSelectors = "workload_selectors"
+
 	// SelectorsAdded labels some count of selectors that have been added to an entity
 	SelectorsAdded = "selectors_added"
 
@@ -499,6 +555,9 @@ const (
 	// Type tags a type
 	Type = "type"
 
+	// AuditSink tags the name of an audit log sink
+	AuditSink = "audit_sink"
+
 	// TrustDomain tags the name of some trust domain
 	TrustDomain = "trust_domain"
 
@@ -512,6 +571,9 @@ const (
 	// with other tags to add clarity
 	Updated = "updated"
 
+	// URL tags some URL; should be used with other tags to add clarity
+	URL = "url"
+
 	// StoreSvid tags if entry is storable
 	StoreSvid = "store_svid"
 
@@ -547,6 +609,12 @@ const (
 	// Attestor tags an attestor plugin/type (eg. gcp, aws...)
 	Attestor = "attestor"
 
+	// AuditLog functionality related to the audit log
+	AuditLog = "audit_log"
+
+	// AuthPolicy functionality related to the authorization policy engine
+	AuthPolicy = "auth_policy"
+
 	// Bundle functionality related to a bundle; should be used with other tags
 	// to add clarity
 	Bundle = "bundle"
@@ -700,6 +768,9 @@ const (
 	// DelegatedIdentityAPI functionality related to delegated identity endpoints
 	DelegatedIdentityAPI = "delegated_identity_api"
 
+	// DiagnosticsAPI functionality related to diagnostics endpoints
+	DiagnosticsAPI = "diagnostics_api"
+
 	// DeleteFederatedBundle functionality related to deleting a federated bundle
 	DeleteFederatedBundle = "delete_federated_bundle"
 
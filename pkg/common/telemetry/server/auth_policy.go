@@ -0,0 +1,14 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartAuthPolicyReloadCall returns metric for server authorization policy
+// reloads
+func StartAuthPolicyReloadCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.AuthPolicy, telemetry.Reload)
+}
+
+// End Call Counters
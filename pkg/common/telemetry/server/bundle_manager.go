@@ -4,6 +4,47 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
+// Gauge (remember previous value set)
+
+// SetBundleManagerFederatedBundleErrorGauge set gauge for the bundle manager
+// failing to refresh a federated bundle, 1 if the last attempt failed or 0
+// otherwise
+func SetBundleManagerFederatedBundleErrorGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	setBundleManagerFederatedBundleGauge(m, telemetry.Error, trustDomain, val)
+}
+
+// SetBundleManagerFederatedBundleLastSuccessAgeGauge set gauge for the
+// seconds elapsed since the bundle manager last refreshed a federated bundle
+// successfully
+func SetBundleManagerFederatedBundleLastSuccessAgeGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	setBundleManagerFederatedBundleGauge(m, telemetry.LastSuccessAge, trustDomain, val)
+}
+
+// SetBundleManagerFederatedBundleNextRefreshGauge set gauge for the seconds
+// until the bundle manager refreshes a federated bundle next
+func SetBundleManagerFederatedBundleNextRefreshGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	setBundleManagerFederatedBundleGauge(m, telemetry.NextRefresh, trustDomain, val)
+}
+
+// SetBundleManagerFederatedBundleSequenceNumberGauge set gauge for the
+// sequence number of the last federated bundle downloaded by the bundle
+// manager
+func SetBundleManagerFederatedBundleSequenceNumberGauge(m telemetry.Metrics, trustDomain string, val float32) {
+	setBundleManagerFederatedBundleGauge(m, telemetry.SequenceNumber, trustDomain, val)
+}
+
+func setBundleManagerFederatedBundleGauge(m telemetry.Metrics, key string, trustDomain string, val float32) {
+	m.SetGaugeWithLabels([]string{
+		telemetry.BundleManager,
+		telemetry.FederatedBundle,
+		key,
+	}, val, []telemetry.Label{
+		{Name: telemetry.TrustDomainID, Value: trustDomain},
+	})
+}
+
+// End Gauge
+
 // Counters (literal increments, not call counters)
 
 // IncrBundleManagerUpdateFederatedBundleCounter indicate
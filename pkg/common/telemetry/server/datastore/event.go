@@ -0,0 +1,28 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartFetchLatestEventIDCall return metric
+// for server's datastore, on fetching the latest event ID.
+func StartFetchLatestEventIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Event, telemetry.Fetch)
+}
+
+// StartListEventsCall return metric
+// for server's datastore, on listing events.
+func StartListEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Event, telemetry.List)
+}
+
+// StartPruneEventsCall return metric
+// for server's datastore, on pruning events.
+func StartPruneEventsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Event, telemetry.Prune)
+}
+
+// End Call Counters
@@ -7,6 +7,12 @@ import (
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
 
+// StartBanNodesCall return metric
+// for server's datastore, on banning nodes.
+func StartBanNodesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Ban)
+}
+
 // StartCountNodeCall return metric
 // for server's datastore, on counting nodes.
 func StartCountNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -25,6 +31,12 @@ func StartDeleteNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Delete)
 }
 
+// StartDeleteNodesCall return metric
+// for server's datastore, on deleting several nodes at once.
+func StartDeleteNodesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.BatchDelete)
+}
+
 // StartFetchNodeCall return metric
 // for server's datastore, on fetching a node.
 func StartFetchNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -37,6 +49,12 @@ func StartListNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.List)
 }
 
+// StartPruneNodesCall return metric
+// for server's datastore, on pruning expired nodes.
+func StartPruneNodesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Prune)
+}
+
 // StartGetNodeSelectorsCall return metric
 // for server's datastore, on getting selectors for a node.
 func StartGetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -49,6 +67,30 @@ func StartListNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Selectors, telemetry.List)
 }
 
+// StartAddOperatorNodeSelectorsCall return metric
+// for server's datastore, on adding operator managed selectors for a node.
+func StartAddOperatorNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.OperatorSelectors, telemetry.Add)
+}
+
+// StartGetOperatorNodeSelectorsCall return metric
+// for server's datastore, on getting operator managed selectors for a node.
+func StartGetOperatorNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.OperatorSelectors, telemetry.Fetch)
+}
+
+// StartListOperatorNodeSelectorsCall return metric
+// for server's datastore, on listing operator managed selectors for nodes.
+func StartListOperatorNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.OperatorSelectors, telemetry.List)
+}
+
+// StartRemoveOperatorNodeSelectorsCall return metric
+// for server's datastore, on removing operator managed selectors for a node.
+func StartRemoveOperatorNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.OperatorSelectors, telemetry.Remove)
+}
+
 // StartSetNodeSelectorsCall return metric
 // for server's datastore, on setting selectors for a node.
 func StartSetNodeSelectorsCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -61,4 +103,10 @@ func StartUpdateNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Update)
 }
 
+// StartUpdateNodesStatusCall return metric
+// for server's datastore, on updating the status reported by nodes.
+func StartUpdateNodesStatusCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.Node, telemetry.Status, telemetry.Update)
+}
+
 // End Call Counters
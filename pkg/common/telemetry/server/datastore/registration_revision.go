@@ -0,0 +1,34 @@
+package datastore
+
+import (
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartFetchRegistrationRevisionCall return metric
+// for server's datastore, on fetching a registration revision.
+func StartFetchRegistrationRevisionCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryRevision, telemetry.Fetch)
+}
+
+// StartListRegistrationRevisionsCall return metric
+// for server's datastore, on listing registration revisions.
+func StartListRegistrationRevisionsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryRevision, telemetry.List)
+}
+
+// StartPruneRegistrationRevisionsCall return metric
+// for server's datastore, on pruning registration revisions.
+func StartPruneRegistrationRevisionsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryRevision, telemetry.Prune)
+}
+
+// StartRestoreRegistrationRevisionCall return metric
+// for server's datastore, on restoring a registration revision.
+func StartRestoreRegistrationRevisionCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Datastore, telemetry.RegistrationEntryRevision, telemetry.Restore)
+}
+
+// End Call Counters
@@ -23,12 +23,24 @@ type metricsWrapper struct {
 	m  telemetry.Metrics
 }
 
+func (w metricsWrapper) AddOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) (_ []*common.Selector, err error) {
+	callCounter := StartAddOperatorNodeSelectorsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.AddOperatorNodeSelectors(ctx, spiffeID, selectors)
+}
+
 func (w metricsWrapper) AppendBundle(ctx context.Context, bundle *common.Bundle) (_ *common.Bundle, err error) {
 	callCounter := StartAppendBundleCall(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.AppendBundle(ctx, bundle)
 }
 
+func (w metricsWrapper) BanAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (_ *datastore.ListAttestedNodesResponse, err error) {
+	callCounter := StartBanNodesCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.BanAttestedNodes(ctx, req)
+}
+
 func (w metricsWrapper) CreateAttestedNode(ctx context.Context, node *common.AttestedNode) (_ *common.AttestedNode, err error) {
 	callCounter := StartCreateNodeCall(w.m)
 	defer callCounter.Done(&err)
@@ -77,6 +89,12 @@ func (w metricsWrapper) DeleteAttestedNode(ctx context.Context, spiffeID string)
 	return w.ds.DeleteAttestedNode(ctx, spiffeID)
 }
 
+func (w metricsWrapper) DeleteAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (_ *datastore.ListAttestedNodesResponse, err error) {
+	callCounter := StartDeleteNodesCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.DeleteAttestedNodes(ctx, req)
+}
+
 func (w metricsWrapper) DeleteBundle(ctx context.Context, trustDomain string, mode datastore.DeleteMode) (err error) {
 	callCounter := StartDeleteBundleCall(w.m)
 	defer callCounter.Done(&err)
@@ -113,6 +131,12 @@ func (w metricsWrapper) FetchBundle(ctx context.Context, trustDomain string) (_
 	return w.ds.FetchBundle(ctx, trustDomain)
 }
 
+func (w metricsWrapper) GetLatestEventID(ctx context.Context) (_ uint, err error) {
+	callCounter := StartFetchLatestEventIDCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.GetLatestEventID(ctx)
+}
+
 func (w metricsWrapper) FetchJoinToken(ctx context.Context, token string) (_ *datastore.JoinToken, err error) {
 	callCounter := StartFetchJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
@@ -125,6 +149,12 @@ func (w metricsWrapper) FetchRegistrationEntry(ctx context.Context, entryID stri
 	return w.ds.FetchRegistrationEntry(ctx, entryID)
 }
 
+func (w metricsWrapper) FetchRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (_ *datastore.RegistrationEntryRevision, err error) {
+	callCounter := StartFetchRegistrationRevisionCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.FetchRegistrationEntryRevision(ctx, entryID, revision)
+}
+
 func (w metricsWrapper) FetchFederationRelationship(ctx context.Context, trustDomain spiffeid.TrustDomain) (_ *datastore.FederationRelationship, err error) {
 	callCounter := StartFetchFederationRelationshipCall(w.m)
 	defer callCounter.Done(&err)
@@ -137,6 +167,12 @@ func (w metricsWrapper) GetNodeSelectors(ctx context.Context, spiffeID string, d
 	return w.ds.GetNodeSelectors(ctx, spiffeID, dataConsistency)
 }
 
+func (w metricsWrapper) GetOperatorNodeSelectors(ctx context.Context, spiffeID string, dataConsistency datastore.DataConsistency) (_ []*common.Selector, err error) {
+	callCounter := StartGetOperatorNodeSelectorsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.GetOperatorNodeSelectors(ctx, spiffeID, dataConsistency)
+}
+
 func (w metricsWrapper) ListAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (_ *datastore.ListAttestedNodesResponse, err error) {
 	callCounter := StartListNodeCall(w.m)
 	defer callCounter.Done(&err)
@@ -155,12 +191,24 @@ func (w metricsWrapper) ListNodeSelectors(ctx context.Context, req *datastore.Li
 	return w.ds.ListNodeSelectors(ctx, req)
 }
 
+func (w metricsWrapper) ListOperatorNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (_ *datastore.ListNodeSelectorsResponse, err error) {
+	callCounter := StartListOperatorNodeSelectorsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListOperatorNodeSelectors(ctx, req)
+}
+
 func (w metricsWrapper) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (_ *datastore.ListRegistrationEntriesResponse, err error) {
 	callCounter := StartListRegistrationCall(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.ListRegistrationEntries(ctx, req)
 }
 
+func (w metricsWrapper) ListRegistrationEntryRevisions(ctx context.Context, entryID string) (_ []*datastore.RegistrationEntryRevision, err error) {
+	callCounter := StartListRegistrationRevisionsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListRegistrationEntryRevisions(ctx, entryID)
+}
+
 func (w metricsWrapper) CountAttestedNodes(ctx context.Context) (_ int32, err error) {
 	callCounter := StartCountNodeCall(w.m)
 	defer callCounter.Done(&err)
@@ -179,12 +227,30 @@ func (w metricsWrapper) CountRegistrationEntries(ctx context.Context) (_ int32,
 	return w.ds.CountRegistrationEntries(ctx)
 }
 
+func (w metricsWrapper) PruneAttestedNodes(ctx context.Context, expiredBefore time.Time, includeReattestable bool, limit int) (_ []*common.AttestedNode, err error) {
+	callCounter := StartPruneNodesCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.PruneAttestedNodes(ctx, expiredBefore, includeReattestable, limit)
+}
+
 func (w metricsWrapper) PruneBundle(ctx context.Context, trustDomainID string, expiresBefore time.Time) (_ bool, err error) {
 	callCounter := StartPruneBundleCall(w.m)
 	defer callCounter.Done(&err)
 	return w.ds.PruneBundle(ctx, trustDomainID, expiresBefore)
 }
 
+func (w metricsWrapper) ListEvents(ctx context.Context, req *datastore.ListEventsRequest) (_ *datastore.ListEventsResponse, err error) {
+	callCounter := StartListEventsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.ListEvents(ctx, req)
+}
+
+func (w metricsWrapper) PruneEvents(ctx context.Context, olderThan time.Time) (err error) {
+	callCounter := StartPruneEventsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.PruneEvents(ctx, olderThan)
+}
+
 func (w metricsWrapper) PruneJoinTokens(ctx context.Context, expiresBefore time.Time) (err error) {
 	callCounter := StartPruneJoinTokenCall(w.m)
 	defer callCounter.Done(&err)
@@ -197,6 +263,24 @@ func (w metricsWrapper) PruneRegistrationEntries(ctx context.Context, expiresBef
 	return w.ds.PruneRegistrationEntries(ctx, expiresBefore)
 }
 
+func (w metricsWrapper) PruneRegistrationEntryRevisions(ctx context.Context, olderThan time.Time) (err error) {
+	callCounter := StartPruneRegistrationRevisionsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.PruneRegistrationEntryRevisions(ctx, olderThan)
+}
+
+func (w metricsWrapper) RemoveOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) (_ []*common.Selector, err error) {
+	callCounter := StartRemoveOperatorNodeSelectorsCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.RemoveOperatorNodeSelectors(ctx, spiffeID, selectors)
+}
+
+func (w metricsWrapper) RestoreRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (_ *common.RegistrationEntry, err error) {
+	callCounter := StartRestoreRegistrationRevisionCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.RestoreRegistrationEntryRevision(ctx, entryID, revision)
+}
+
 func (w metricsWrapper) SetBundle(ctx context.Context, bundle *common.Bundle) (_ *common.Bundle, err error) {
 	callCounter := StartSetBundleCall(w.m)
 	defer callCounter.Done(&err)
@@ -215,6 +299,12 @@ func (w metricsWrapper) UpdateAttestedNode(ctx context.Context, node *common.Att
 	return w.ds.UpdateAttestedNode(ctx, node, mask)
 }
 
+func (w metricsWrapper) UpdateAttestedNodesStatus(ctx context.Context, statuses []*datastore.AttestedNodeStatus) (err error) {
+	callCounter := StartUpdateNodesStatusCall(w.m)
+	defer callCounter.Done(&err)
+	return w.ds.UpdateAttestedNodesStatus(ctx, statuses)
+}
+
 func (w metricsWrapper) UpdateBundle(ctx context.Context, bundle *common.Bundle, mask *common.BundleMask) (_ *common.Bundle, err error) {
 	callCounter := StartUpdateBundleCall(w.m)
 	defer callCounter.Done(&err)
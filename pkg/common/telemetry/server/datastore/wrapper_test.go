@@ -37,10 +37,18 @@ func TestWithMetrics(t *testing.T) {
 		key        string
 		methodName string
 	}{
+		{
+			key:        "datastore.node.operator_selectors.add",
+			methodName: "AddOperatorNodeSelectors",
+		},
 		{
 			key:        "datastore.bundle.append",
 			methodName: "AppendBundle",
 		},
+		{
+			key:        "datastore.node.ban",
+			methodName: "BanAttestedNodes",
+		},
 		{
 			key:        "datastore.node.count",
 			methodName: "CountAttestedNodes",
@@ -81,6 +89,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.node.delete",
 			methodName: "DeleteAttestedNode",
 		},
+		{
+			key:        "datastore.node.batch_delete",
+			methodName: "DeleteAttestedNodes",
+		},
 		{
 			key:        "datastore.bundle.delete",
 			methodName: "DeleteBundle",
@@ -105,6 +117,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.bundle.fetch",
 			methodName: "FetchBundle",
 		},
+		{
+			key:        "datastore.event.fetch",
+			methodName: "GetLatestEventID",
+		},
 		{
 			key:        "datastore.join_token.fetch",
 			methodName: "FetchJoinToken",
@@ -113,6 +129,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry.fetch",
 			methodName: "FetchRegistrationEntry",
 		},
+		{
+			key:        "datastore.registration_entry_revision.fetch",
+			methodName: "FetchRegistrationEntryRevision",
+		},
 		{
 			key:        "datastore.federation_relationship.fetch",
 			methodName: "FetchFederationRelationship",
@@ -121,6 +141,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.node.selectors.fetch",
 			methodName: "GetNodeSelectors",
 		},
+		{
+			key:        "datastore.node.operator_selectors.fetch",
+			methodName: "GetOperatorNodeSelectors",
+		},
 		{
 			key:        "datastore.node.list",
 			methodName: "ListAttestedNodes",
@@ -133,18 +157,38 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.node.selectors.list",
 			methodName: "ListNodeSelectors",
 		},
+		{
+			key:        "datastore.node.operator_selectors.list",
+			methodName: "ListOperatorNodeSelectors",
+		},
 		{
 			key:        "datastore.registration_entry.list",
 			methodName: "ListRegistrationEntries",
 		},
+		{
+			key:        "datastore.registration_entry_revision.list",
+			methodName: "ListRegistrationEntryRevisions",
+		},
 		{
 			key:        "datastore.federation_relationship.list",
 			methodName: "ListFederationRelationships",
 		},
+		{
+			key:        "datastore.node.prune",
+			methodName: "PruneAttestedNodes",
+		},
 		{
 			key:        "datastore.bundle.prune",
 			methodName: "PruneBundle",
 		},
+		{
+			key:        "datastore.event.list",
+			methodName: "ListEvents",
+		},
+		{
+			key:        "datastore.event.prune",
+			methodName: "PruneEvents",
+		},
 		{
 			key:        "datastore.join_token.prune",
 			methodName: "PruneJoinTokens",
@@ -153,6 +197,18 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.registration_entry.prune",
 			methodName: "PruneRegistrationEntries",
 		},
+		{
+			key:        "datastore.registration_entry_revision.prune",
+			methodName: "PruneRegistrationEntryRevisions",
+		},
+		{
+			key:        "datastore.node.operator_selectors.remove",
+			methodName: "RemoveOperatorNodeSelectors",
+		},
+		{
+			key:        "datastore.registration_entry_revision.restore",
+			methodName: "RestoreRegistrationEntryRevision",
+		},
 		{
 			key:        "datastore.bundle.set",
 			methodName: "SetBundle",
@@ -165,6 +221,10 @@ func TestWithMetrics(t *testing.T) {
 			key:        "datastore.node.update",
 			methodName: "UpdateAttestedNode",
 		},
+		{
+			key:        "datastore.node.status.update",
+			methodName: "UpdateAttestedNodesStatus",
+		},
 		{
 			key:        "datastore.bundle.update",
 			methodName: "UpdateBundle",
@@ -257,10 +317,18 @@ func (ds *fakeDataStore) SetError(err error) {
 	ds.err = err
 }
 
+func (ds *fakeDataStore) AddOperatorNodeSelectors(context.Context, string, []*common.Selector) ([]*common.Selector, error) {
+	return []*common.Selector{}, ds.err
+}
+
 func (ds *fakeDataStore) AppendBundle(context.Context, *common.Bundle) (*common.Bundle, error) {
 	return &common.Bundle{}, ds.err
 }
 
+func (ds *fakeDataStore) BanAttestedNodes(context.Context, *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	return &datastore.ListAttestedNodesResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) CountAttestedNodes(context.Context) (int32, error) {
 	return 0, ds.err
 }
@@ -305,6 +373,10 @@ func (ds *fakeDataStore) DeleteAttestedNode(context.Context, string) (*common.At
 	return &common.AttestedNode{}, ds.err
 }
 
+func (ds *fakeDataStore) DeleteAttestedNodes(context.Context, *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	return &datastore.ListAttestedNodesResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) DeleteBundle(context.Context, string, datastore.DeleteMode) error {
 	return ds.err
 }
@@ -333,6 +405,10 @@ func (ds *fakeDataStore) FetchFederationRelationship(context.Context, spiffeid.T
 	return &datastore.FederationRelationship{}, ds.err
 }
 
+func (ds *fakeDataStore) GetLatestEventID(context.Context) (uint, error) {
+	return 0, ds.err
+}
+
 func (ds *fakeDataStore) FetchJoinToken(context.Context, string) (*datastore.JoinToken, error) {
 	return &datastore.JoinToken{}, ds.err
 }
@@ -341,10 +417,18 @@ func (ds *fakeDataStore) FetchRegistrationEntry(context.Context, string) (*commo
 	return &common.RegistrationEntry{}, ds.err
 }
 
+func (ds *fakeDataStore) FetchRegistrationEntryRevision(context.Context, string, int64) (*datastore.RegistrationEntryRevision, error) {
+	return &datastore.RegistrationEntryRevision{}, ds.err
+}
+
 func (ds *fakeDataStore) GetNodeSelectors(context.Context, string, datastore.DataConsistency) ([]*common.Selector, error) {
 	return []*common.Selector{}, ds.err
 }
 
+func (ds *fakeDataStore) GetOperatorNodeSelectors(context.Context, string, datastore.DataConsistency) ([]*common.Selector, error) {
+	return []*common.Selector{}, ds.err
+}
+
 func (ds *fakeDataStore) ListAttestedNodes(context.Context, *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
 	return &datastore.ListAttestedNodesResponse{}, ds.err
 }
@@ -357,14 +441,34 @@ func (ds *fakeDataStore) ListNodeSelectors(context.Context, *datastore.ListNodeS
 	return &datastore.ListNodeSelectorsResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListOperatorNodeSelectors(context.Context, *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	return &datastore.ListNodeSelectorsResponse{}, ds.err
+}
+
 func (ds *fakeDataStore) ListRegistrationEntries(context.Context, *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	return &datastore.ListRegistrationEntriesResponse{}, ds.err
 }
 
+func (ds *fakeDataStore) ListRegistrationEntryRevisions(context.Context, string) ([]*datastore.RegistrationEntryRevision, error) {
+	return []*datastore.RegistrationEntryRevision{}, ds.err
+}
+
+func (ds *fakeDataStore) PruneAttestedNodes(context.Context, time.Time, bool, int) ([]*common.AttestedNode, error) {
+	return []*common.AttestedNode{}, ds.err
+}
+
 func (ds *fakeDataStore) PruneBundle(context.Context, string, time.Time) (bool, error) {
 	return false, ds.err
 }
 
+func (ds *fakeDataStore) ListEvents(context.Context, *datastore.ListEventsRequest) (*datastore.ListEventsResponse, error) {
+	return &datastore.ListEventsResponse{}, ds.err
+}
+
+func (ds *fakeDataStore) PruneEvents(context.Context, time.Time) error {
+	return ds.err
+}
+
 func (ds *fakeDataStore) PruneJoinTokens(context.Context, time.Time) error {
 	return ds.err
 }
@@ -373,6 +477,18 @@ func (ds *fakeDataStore) PruneRegistrationEntries(context.Context, time.Time) er
 	return ds.err
 }
 
+func (ds *fakeDataStore) PruneRegistrationEntryRevisions(context.Context, time.Time) error {
+	return ds.err
+}
+
+func (ds *fakeDataStore) RemoveOperatorNodeSelectors(context.Context, string, []*common.Selector) ([]*common.Selector, error) {
+	return []*common.Selector{}, ds.err
+}
+
+func (ds *fakeDataStore) RestoreRegistrationEntryRevision(context.Context, string, int64) (*common.RegistrationEntry, error) {
+	return &common.RegistrationEntry{}, ds.err
+}
+
 func (ds *fakeDataStore) SetBundle(context.Context, *common.Bundle) (*common.Bundle, error) {
 	return &common.Bundle{}, ds.err
 }
@@ -385,6 +501,10 @@ func (ds *fakeDataStore) UpdateAttestedNode(context.Context, *common.AttestedNod
 	return &common.AttestedNode{}, ds.err
 }
 
+func (ds *fakeDataStore) UpdateAttestedNodesStatus(context.Context, []*datastore.AttestedNodeStatus) error {
+	return ds.err
+}
+
 func (ds *fakeDataStore) UpdateBundle(context.Context, *common.Bundle, *common.BundleMask) (*common.Bundle, error) {
 	return &common.Bundle{}, ds.err
 }
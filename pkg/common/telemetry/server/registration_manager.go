@@ -11,4 +11,38 @@ func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.Call
 	return telemetry.StartCall(m, telemetry.RegistrationEntry, telemetry.Manager, telemetry.Prune)
 }
 
+// StartRegistrationManagerPruneEventCall returns metric for
+// for server registration manager event pruning
+func StartRegistrationManagerPruneEventCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Event, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneEntryRevisionCall returns metric for
+// for server registration manager entry revision pruning
+func StartRegistrationManagerPruneEntryRevisionCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationEntryRevision, telemetry.Manager, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneAttestedNodeCall returns metric for
+// for server registration manager attested node pruning
+func StartRegistrationManagerPruneAttestedNodeCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.Node, telemetry.Manager, telemetry.Prune)
+}
+
 // End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrRegistrationManagerPrunedAttestedNodeCounter indicate
+// the registration manager having pruned an attested node
+func IncrRegistrationManagerPrunedAttestedNodeCounter(m telemetry.Metrics, attestationType string) {
+	m.IncrCounterWithLabels([]string{
+		telemetry.Node,
+		telemetry.Manager,
+		telemetry.Pruned,
+	}, 1, []telemetry.Label{
+		{Name: telemetry.NodeAttestorType, Value: attestationType},
+	})
+}
+
+// End Counters
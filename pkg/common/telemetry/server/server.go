@@ -7,3 +7,11 @@ import "github.com/spiffe/spire/pkg/common/telemetry"
 func SetEntryDeletedGauge(m telemetry.Metrics, deleted int) {
 	m.SetGauge([]string{telemetry.Entry, telemetry.Deleted}, float32(deleted))
 }
+
+// SetAgentVersionGauge emits a gauge with the number of agents recently
+// seen by the server that run the given version.
+func SetAgentVersionGauge(m telemetry.Metrics, agentVersion string, count int) {
+	m.SetGaugeWithLabels([]string{telemetry.Node, telemetry.Version}, float32(count), []telemetry.Label{
+		{Name: telemetry.Version, Value: agentVersion},
+	})
+}
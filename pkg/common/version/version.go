@@ -11,6 +11,10 @@ const (
 	Base = "1.6.0"
 )
 
+// AgentVersionMetadataKey is the gRPC metadata key agents use to report
+// their version to the server.
+const AgentVersionMetadataKey = "spire-agent-version"
+
 var (
 	gittag  = ""
 	githash = "unk"
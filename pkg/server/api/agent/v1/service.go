@@ -132,6 +132,22 @@ func (s *Service) ListAgents(ctx context.Context, req *agentv1.ListAgentsRequest
 		return nil, api.MakeErr(log, codes.Internal, "failed to list agents", err)
 	}
 
+	if listReq.FetchSelectors {
+		// Selectors managed by operators are reported along with those set
+		// by node attestors and resolvers
+		operatorResp, err := s.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+			DataConsistency: datastore.RequireCurrent,
+		})
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to list operator selectors", err)
+		}
+		for _, node := range dsResp.Nodes {
+			if operatorSelectors := operatorResp.Selectors[node.SpiffeId]; len(operatorSelectors) > 0 {
+				node.Selectors = selector.Dedupe(node.Selectors, operatorSelectors)
+			}
+		}
+	}
+
 	resp := &agentv1.ListAgentsResponse{}
 
 	if dsResp.Pagination != nil {
@@ -342,6 +358,7 @@ func (s *Service) AttestAgent(stream agentv1.Agent_AttestAgentServer) error {
 			return api.MakeErr(log, codes.Internal, "failed to create attested agent", err)
 		}
 	} else {
+		// Updating every field also clears a pending reattestation request
 		node := &common.AttestedNode{
 			SpiffeId:         agentID.String(),
 			CertNotAfter:     svid[0].NotAfter.Unix(),
@@ -399,6 +416,12 @@ func (s *Service) RenewAgent(ctx context.Context, req *agentv1.RenewAgentRequest
 		return nil, errorutil.PermissionDenied(types.PermissionDeniedDetails_AGENT_MUST_REATTEST, "agent can't renew SVID, must reattest")
 	}
 
+	// An operator requested the agent to prove its identity again
+	if attestedNode.ReattestRequested {
+		log.Info("Rejecting agent SVID renewal: reattestation requested")
+		return nil, errorutil.PermissionDenied(types.PermissionDeniedDetails_AGENT_MUST_REATTEST, "agent can't renew SVID, reattestation was requested")
+	}
+
 	log.Info("Renewing agent SVID")
 
 	if req.Params == nil {
@@ -554,6 +577,14 @@ func (s *Service) getSelectorsFromAgentID(ctx context.Context, agentID string) (
 		return nil, fmt.Errorf("failed to get node selectors: %w", err)
 	}
 
+	operatorSelectors, err := s.ds.GetOperatorNodeSelectors(ctx, agentID, datastore.RequireCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator selectors: %w", err)
+	}
+	if len(operatorSelectors) > 0 {
+		selectors = selector.Dedupe(selectors, operatorSelectors)
+	}
+
 	return api.ProtoFromSelectors(selectors), nil
 }
 
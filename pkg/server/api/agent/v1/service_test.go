@@ -1596,6 +1596,43 @@ func TestGetAgent(t *testing.T) {
 	}
 }
 
+func TestAgentOperatorSelectors(t *testing.T) {
+	test := setupServiceTest(t, 0)
+	test.createTestNodes(ctx, t)
+
+	_, err := test.ds.AddOperatorNodeSelectors(ctx, agent1, []*common.Selector{
+		{Type: "operator-selector-type", Value: "operator-selector-value"},
+		testNodeSelectors[agent1][0],
+	})
+	require.NoError(t, err)
+
+	expectSelectors := []*types.Selector{
+		{Type: "node-selector-type-1", Value: "node-selector-value-1"},
+		{Type: "operator-selector-type", Value: "operator-selector-value"},
+	}
+
+	agent, err := test.client.GetAgent(ctx, &agentv1.GetAgentRequest{
+		Id:         expectedAgents[agent1].Id,
+		OutputMask: &types.AgentMask{Selectors: true},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &types.Agent{
+		Id:        expectedAgents[agent1].Id,
+		Selectors: expectSelectors,
+	}, agent)
+
+	resp, err := test.client.ListAgents(ctx, &agentv1.ListAgentsRequest{
+		OutputMask: &types.AgentMask{Selectors: true},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentv1.ListAgentsResponse{
+		Agents: []*types.Agent{
+			{Id: expectedAgents[agent1].Id, Selectors: expectSelectors},
+			{Id: expectedAgents[agent2].Id, Selectors: expectedAgents[agent2].Selectors},
+		},
+	}, resp)
+}
+
 func TestRenewAgent(t *testing.T) {
 	agentIDType := &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"}
 
@@ -1633,6 +1670,7 @@ func TestRenewAgent(t *testing.T) {
 		req            *agentv1.RenewAgentRequest
 		expectCode     codes.Code
 		expectMsg      string
+		expectReason   types.PermissionDeniedDetails_Reason
 		rateLimiterErr error
 	}{
 		{
@@ -1854,6 +1892,42 @@ func TestRenewAgent(t *testing.T) {
 			expectCode: codes.Internal,
 			expectMsg:  "failed to sign X509 SVID: X509 CA is not available for signing",
 		},
+		{
+			name: "reattestation requested",
+			createNode: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				AttestationDataType: "t",
+				CertNotAfter:        12345,
+				CertSerialNumber:    "6789",
+				CanReattest:         true,
+				ReattestRequested:   true,
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "Rejecting agent SVID renewal: reattestation requested",
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.Status:        "error",
+						telemetry.Type:          "audit",
+						telemetry.Csr:           csrHash,
+						telemetry.StatusCode:    "PermissionDenied",
+						telemetry.StatusMessage: "agent can't renew SVID, reattestation was requested",
+					},
+				},
+			},
+			req: &agentv1.RenewAgentRequest{
+				Params: &agentv1.AgentX509SVIDParams{
+					Csr: csr,
+				},
+			},
+			expectCode:   codes.PermissionDenied,
+			expectMsg:    "agent can't renew SVID, reattestation was requested",
+			expectReason: types.PermissionDeniedDetails_AGENT_MUST_REATTEST,
+		},
 		{
 			name:       "failed to fetch attested node",
 			createNode: cloneAttestedNode(defaultNode),
@@ -1921,6 +1995,12 @@ func TestRenewAgent(t *testing.T) {
 			resp, err := test.client.RenewAgent(ctx, tt.req)
 			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
 
+			if tt.expectReason != types.PermissionDeniedDetails_UNKNOWN {
+				details := status.Convert(err).Details()
+				require.Len(t, details, 1)
+				spiretest.RequireProtoEqual(t, &types.PermissionDeniedDetails{Reason: tt.expectReason}, details[0].(*types.PermissionDeniedDetails))
+			}
+
 			if tt.expectCode != codes.OK {
 				require.Nil(t, resp)
 				spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
@@ -3174,6 +3254,7 @@ func (s *serviceTest) setupNodes(ctx context.Context, t *testing.T) {
 		AttestationDataType: "test_type",
 		SpiffeId:            spiffeid.RequireFromPath(td, "/spire/agent/test_type/id_attested_before").String(),
 		CertSerialNumber:    "test_serial_number",
+		ReattestRequested:   true,
 	}
 	_, err := s.ds.CreateAttestedNode(ctx, node)
 	require.NoError(t, err)
@@ -3253,6 +3334,8 @@ func (s *serviceTest) assertAgentWasStored(t *testing.T, expectedID string, expe
 	require.NoError(t, err)
 	require.NotNil(t, attestedAgent)
 	require.Equal(t, expectedID, attestedAgent.SpiffeId)
+	// Attesting fulfills any reattestation request
+	require.False(t, attestedAgent.ReattestRequested)
 
 	agentSelectors, err := s.ds.GetNodeSelectors(ctx, expectedID, datastore.RequireCurrent)
 	require.NoError(t, err)
@@ -0,0 +1,614 @@
+package agentadmin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+)
+
+const (
+	// maxPageSize is the maximum number of agents evicted or banned per
+	// request.
+	maxPageSize = 500
+
+	// maxStatusPageSize is the maximum number of agent statuses listed per
+	// request.
+	maxStatusPageSize = 1000
+
+	// inactiveAfter is the amount of time after which an agent that has not
+	// been seen is considered inactive. Agents sync with the server every few
+	// seconds, and their status is written to the datastore every 30 seconds.
+	inactiveAfter = 5 * time.Minute
+)
+
+var (
+	errFilterEmpty       = errors.New("filter must set at least one criterion")
+	errSelectorsRequired = errors.New("selector match requires at least one selector")
+)
+
+// Config is the service configuration.
+type Config struct {
+	DataStore   datastore.DataStore
+	Clock       clock.Clock
+	TrustDomain spiffeid.TrustDomain
+}
+
+// Service implements the v1 agent admin service.
+type Service struct {
+	agentadminv1.UnsafeAgentAdminServer
+
+	ds  datastore.DataStore
+	clk clock.Clock
+	td  spiffeid.TrustDomain
+}
+
+// New creates a new agent admin service.
+func New(config Config) *Service {
+	return &Service{
+		ds:  config.DataStore,
+		clk: config.Clock,
+		td:  config.TrustDomain,
+	}
+}
+
+// RegisterService registers the agent admin service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	agentadminv1.RegisterAgentAdminServer(s, service)
+}
+
+// BatchEvictAgents evicts a page of the agents matching the filter.
+func (s *Service) BatchEvictAgents(ctx context.Context, req *agentadminv1.BatchEvictAgentsRequest) (*agentadminv1.BatchEvictAgentsResponse, error) {
+	agents, nextPageToken, err := s.batchUpdate(ctx, req.Filter, req.DryRun, req.PageSize, req.PageToken, batchOp{
+		errMsg: "failed to evict agents",
+		logMsg: "Agent evicted",
+		do:     s.ds.DeleteAttestedNodes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &agentadminv1.BatchEvictAgentsResponse{
+		Agents:        agents,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// BatchBanAgents bans a page of the agents matching the filter.
+func (s *Service) BatchBanAgents(ctx context.Context, req *agentadminv1.BatchBanAgentsRequest) (*agentadminv1.BatchBanAgentsResponse, error) {
+	agents, nextPageToken, err := s.batchUpdate(ctx, req.Filter, req.DryRun, req.PageSize, req.PageToken, batchOp{
+		errMsg: "failed to ban agents",
+		logMsg: "Agent banned",
+		// Agents that are already banned are left untouched.
+		skip: nodeutil.IsAgentBanned,
+		do:   s.ds.BanAttestedNodes,
+		dryRun: func(node *common.AttestedNode) {
+			node.CertSerialNumber = ""
+			node.NewCertSerialNumber = ""
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &agentadminv1.BatchBanAgentsResponse{
+		Agents:        agents,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// GetAgentStatus gets the status of the agent with the given SPIFFE ID.
+func (s *Service) GetAgentStatus(ctx context.Context, req *agentadminv1.GetAgentStatusRequest) (*agentadminv1.AgentStatus, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := api.TrustDomainAgentIDFromProto(ctx, s.td, req.Id)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	}
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.SPIFFEID: agentID.String()})
+
+	log = log.WithField(telemetry.SPIFFEID, agentID.String())
+	node, err := s.ds.FetchAttestedNode(ctx, agentID.String())
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to fetch agent", err)
+	}
+
+	if node == nil {
+		return nil, api.MakeErr(log, codes.NotFound, "agent not found", nil)
+	}
+
+	node.Selectors, err = s.ds.GetNodeSelectors(ctx, node.SpiffeId, datastore.RequireCurrent)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get selectors from agent", err)
+	}
+	if err := s.addOperatorSelectors(ctx, []*common.AttestedNode{node}); err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get operator selectors from agent", err)
+	}
+
+	agentStatus, err := s.statusFromNode(node)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to convert attested node to agent", err)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return agentStatus, nil
+}
+
+// ListAgentStatuses lists a page of the status of the agents matching the
+// filter, if any.
+func (s *Service) ListAgentStatuses(ctx context.Context, req *agentadminv1.ListAgentStatusesRequest) (*agentadminv1.ListAgentStatusesResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	listReq := &datastore.ListAttestedNodesRequest{}
+	if req.Filter != nil {
+		rpccontext.AddRPCAuditFields(ctx, fieldsFromFilter(req.Filter, false))
+
+		var err error
+		listReq, err = listRequestFromFilter(req.Filter)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid filter", err)
+		}
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 || pageSize > maxStatusPageSize {
+		pageSize = maxStatusPageSize
+	}
+	listReq.FetchSelectors = true
+	listReq.Pagination = &datastore.Pagination{
+		PageSize: pageSize,
+		Token:    req.PageToken,
+	}
+
+	listResp, err := s.ds.ListAttestedNodes(ctx, listReq)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list agents", err)
+	}
+	if err := s.addOperatorSelectors(ctx, listResp.Nodes); err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list operator selectors", err)
+	}
+
+	resp := &agentadminv1.ListAgentStatusesResponse{}
+	if listResp.Pagination != nil {
+		resp.NextPageToken = listResp.Pagination.Token
+	}
+
+	for _, node := range listResp.Nodes {
+		agentStatus, err := s.statusFromNode(node)
+		if err != nil {
+			log.WithError(err).WithField(telemetry.SPIFFEID, node.SpiffeId).Warn("Failed to parse agent")
+			continue
+		}
+		resp.Statuses = append(resp.Statuses, agentStatus)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return resp, nil
+}
+
+// ReattestAgent requests the agent with the given SPIFFE ID to reattest. The
+// request is fulfilled once the agent attests again.
+func (s *Service) ReattestAgent(ctx context.Context, req *agentadminv1.ReattestAgentRequest) (*emptypb.Empty, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := api.TrustDomainAgentIDFromProto(ctx, s.td, req.Id)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	}
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.SPIFFEID: agentID.String()})
+
+	log = log.WithField(telemetry.SPIFFEID, agentID.String())
+	node, err := s.ds.FetchAttestedNode(ctx, agentID.String())
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to fetch agent", err)
+	}
+
+	switch {
+	case node == nil:
+		return nil, api.MakeErr(log, codes.NotFound, "agent not found", nil)
+	case nodeutil.IsAgentBanned(node):
+		return nil, api.MakeErr(log, codes.FailedPrecondition, "agent is banned", nil)
+	case !node.CanReattest:
+		// The agent would be unable to attest again with the same ID
+		return nil, api.MakeErr(log, codes.FailedPrecondition, "agent cannot reattest", nil)
+	}
+
+	_, err = s.ds.UpdateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:          agentID.String(),
+		ReattestRequested: true,
+	}, &common.AttestedNodeMask{
+		ReattestRequested: true,
+	})
+
+	switch status.Code(err) {
+	case codes.OK:
+		log.Info("Agent reattestation requested")
+		rpccontext.AuditRPC(ctx)
+		return &emptypb.Empty{}, nil
+	case codes.NotFound:
+		return nil, api.MakeErr(log, codes.NotFound, "agent not found", err)
+	default:
+		return nil, api.MakeErr(log, codes.Internal, "failed to request agent reattestation", err)
+	}
+}
+
+func (s *Service) GetAgentSelectors(ctx context.Context, req *agentadminv1.GetAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, err := api.TrustDomainAgentIDFromProto(ctx, s.td, req.Id)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	}
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.SPIFFEID: agentID.String()})
+
+	log = log.WithField(telemetry.SPIFFEID, agentID.String())
+	if err := s.requireAgent(ctx, log, agentID); err != nil {
+		return nil, err
+	}
+
+	nodeSelectors, err := s.ds.GetNodeSelectors(ctx, agentID.String(), datastore.RequireCurrent)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get selectors from agent", err)
+	}
+
+	operatorSelectors, err := s.ds.GetOperatorNodeSelectors(ctx, agentID.String(), datastore.RequireCurrent)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get operator selectors from agent", err)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return &agentadminv1.AgentSelectors{
+		NodeSelectors:     api.ProtoFromSelectors(nodeSelectors),
+		OperatorSelectors: api.ProtoFromSelectors(operatorSelectors),
+	}, nil
+}
+
+func (s *Service) AddAgentSelectors(ctx context.Context, req *agentadminv1.AddAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, selectors, err := s.parseAgentSelectors(ctx, log, req.Id, req.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	log = log.WithField(telemetry.SPIFFEID, agentID.String())
+	if err := s.requireAgent(ctx, log, agentID); err != nil {
+		return nil, err
+	}
+
+	operatorSelectors, err := s.ds.AddOperatorNodeSelectors(ctx, agentID.String(), selectors)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to add agent selectors", err)
+	}
+	log.Info("Agent selectors added")
+
+	rpccontext.AuditRPC(ctx)
+	return &agentadminv1.AgentSelectors{
+		OperatorSelectors: api.ProtoFromSelectors(operatorSelectors),
+	}, nil
+}
+
+func (s *Service) RemoveAgentSelectors(ctx context.Context, req *agentadminv1.RemoveAgentSelectorsRequest) (*agentadminv1.AgentSelectors, error) {
+	log := rpccontext.Logger(ctx)
+
+	agentID, selectors, err := s.parseAgentSelectors(ctx, log, req.Id, req.Selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	// The agent is not required to exist, so that selectors can be cleaned
+	// up regardless of the state of the agent
+	log = log.WithField(telemetry.SPIFFEID, agentID.String())
+	operatorSelectors, err := s.ds.RemoveOperatorNodeSelectors(ctx, agentID.String(), selectors)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to remove agent selectors", err)
+	}
+	log.Info("Agent selectors removed")
+
+	rpccontext.AuditRPC(ctx)
+	return &agentadminv1.AgentSelectors{
+		OperatorSelectors: api.ProtoFromSelectors(operatorSelectors),
+	}, nil
+}
+
+func (s *Service) parseAgentSelectors(ctx context.Context, log logrus.FieldLogger, id *types.SPIFFEID, protoSelectors []*types.Selector) (spiffeid.ID, []*common.Selector, error) {
+	agentID, err := api.TrustDomainAgentIDFromProto(ctx, s.td, id)
+	if err != nil {
+		return spiffeid.ID{}, nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+	}
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{
+		telemetry.SPIFFEID:  agentID.String(),
+		telemetry.Selectors: api.SelectorFieldFromProto(protoSelectors),
+	})
+
+	if len(protoSelectors) == 0 {
+		return spiffeid.ID{}, nil, api.MakeErr(log, codes.InvalidArgument, "at least one selector is required", nil)
+	}
+	selectors, err := api.SelectorsFromProto(protoSelectors)
+	if err != nil {
+		return spiffeid.ID{}, nil, api.MakeErr(log, codes.InvalidArgument, "invalid selectors", err)
+	}
+	return agentID, selectors, nil
+}
+
+func (s *Service) requireAgent(ctx context.Context, log logrus.FieldLogger, agentID spiffeid.ID) error {
+	node, err := s.ds.FetchAttestedNode(ctx, agentID.String())
+	switch {
+	case err != nil:
+		return api.MakeErr(log, codes.Internal, "failed to fetch agent", err)
+	case node == nil:
+		return api.MakeErr(log, codes.NotFound, "agent not found", nil)
+	default:
+		return nil
+	}
+}
+
+func (s *Service) statusFromNode(node *common.AttestedNode) (*agentadminv1.AgentStatus, error) {
+	agent, err := api.ProtoFromAttestedNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &agentadminv1.AgentStatus{
+		Agent:        agent,
+		AgentVersion: node.AgentVersion,
+		LastSeenAt:   node.LastSeenAt,
+		Health:       s.healthFromNode(node),
+	}, nil
+}
+
+// addOperatorSelectors merges the selectors managed by operators into the
+// selectors set by node attestors and resolvers, as the entry cache does.
+func (s *Service) addOperatorSelectors(ctx context.Context, nodes []*common.AttestedNode) error {
+	var operatorSelectors map[string][]*common.Selector
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		selectors, err := s.ds.GetOperatorNodeSelectors(ctx, nodes[0].SpiffeId, datastore.RequireCurrent)
+		if err != nil {
+			return err
+		}
+		operatorSelectors = map[string][]*common.Selector{nodes[0].SpiffeId: selectors}
+	default:
+		resp, err := s.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+			DataConsistency: datastore.RequireCurrent,
+		})
+		if err != nil {
+			return err
+		}
+		operatorSelectors = resp.Selectors
+	}
+
+	mergeOperatorSelectors(nodes, operatorSelectors)
+	return nil
+}
+
+func mergeOperatorSelectors(nodes []*common.AttestedNode, operatorSelectors map[string][]*common.Selector) {
+	for _, node := range nodes {
+		if selectors := operatorSelectors[node.SpiffeId]; len(selectors) > 0 {
+			node.Selectors = selector.Dedupe(node.Selectors, selectors)
+		}
+	}
+}
+
+func (s *Service) healthFromNode(node *common.AttestedNode) agentadminv1.AgentHealth {
+	now := s.clk.Now()
+	switch {
+	case nodeutil.IsAgentBanned(node):
+		return agentadminv1.AgentHealth_BANNED
+	case time.Unix(node.CertNotAfter, 0).Before(now):
+		return agentadminv1.AgentHealth_EXPIRED
+	case node.LastSeenAt == 0:
+		return agentadminv1.AgentHealth_NEVER_SEEN
+	case time.Unix(node.LastSeenAt, 0).Before(now.Add(-inactiveAfter)):
+		return agentadminv1.AgentHealth_INACTIVE
+	default:
+		return agentadminv1.AgentHealth_ACTIVE
+	}
+}
+
+// batchOp is an operation applied to a page of attested nodes.
+type batchOp struct {
+	errMsg string
+	logMsg string
+	// do lists the page of nodes matching the request and applies the
+	// operation to them in a single transaction. It returns the updated
+	// nodes.
+	do func(context.Context, *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error)
+	// skip returns true for nodes the operation does not apply to, which do
+	// leaves out as well. Only used by dry runs.
+	skip func(*common.AttestedNode) bool
+	// dryRun updates a node the way the operation would.
+	dryRun func(*common.AttestedNode)
+}
+
+func (s *Service) batchUpdate(ctx context.Context, filter *agentadminv1.AgentFilter, dryRun bool, pageSize int32, pageToken string, op batchOp) ([]*types.Agent, string, error) {
+	log := rpccontext.Logger(ctx)
+
+	if filter == nil {
+		return nil, "", api.MakeErr(log, codes.InvalidArgument, "filter is required", nil)
+	}
+	rpccontext.AddRPCAuditFields(ctx, fieldsFromFilter(filter, dryRun))
+
+	listReq, err := listRequestFromFilter(filter)
+	if err == nil && isListRequestUnfiltered(listReq) {
+		err = errFilterEmpty
+	}
+	if err != nil {
+		return nil, "", api.MakeErr(log, codes.InvalidArgument, "invalid filter", err)
+	}
+
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	listReq.FetchSelectors = true
+	listReq.Pagination = &datastore.Pagination{
+		PageSize: pageSize,
+		Token:    pageToken,
+	}
+
+	var nodes []*common.AttestedNode
+	var nextPageToken string
+	if dryRun {
+		listResp, err := s.ds.ListAttestedNodes(ctx, listReq)
+		if err != nil {
+			return nil, "", api.MakeErr(log, codes.Internal, "failed to list agents", err)
+		}
+		if listResp.Pagination != nil {
+			nextPageToken = listResp.Pagination.Token
+		}
+		if err := s.addOperatorSelectors(ctx, listResp.Nodes); err != nil {
+			return nil, "", api.MakeErr(log, codes.Internal, "failed to list operator selectors", err)
+		}
+		for _, node := range listResp.Nodes {
+			if op.skip != nil && op.skip(node) {
+				continue
+			}
+			if op.dryRun != nil {
+				op.dryRun(node)
+			}
+			nodes = append(nodes, node)
+		}
+	} else {
+		// The operator selectors are listed beforehand since they are
+		// deleted along with evicted agents.
+		operatorResp, err := s.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+			DataConsistency: datastore.RequireCurrent,
+		})
+		if err != nil {
+			return nil, "", api.MakeErr(log, codes.Internal, "failed to list operator selectors", err)
+		}
+		// The page is listed and updated in a single transaction, so agents
+		// that no longer match the filter by then are left untouched.
+		resp, err := op.do(ctx, listReq)
+		if err != nil {
+			return nil, "", api.MakeErr(log, codes.Internal, op.errMsg, err)
+		}
+		if resp.Pagination != nil {
+			nextPageToken = resp.Pagination.Token
+		}
+		nodes = resp.Nodes
+		mergeOperatorSelectors(nodes, operatorResp.Selectors)
+	}
+
+	var agents []*types.Agent
+	for _, node := range nodes {
+		if !dryRun {
+			log.WithField(telemetry.SPIFFEID, node.SpiffeId).Info(op.logMsg)
+			rpccontext.AuditRPCWithTypesStatus(ctx, api.OK(), func() logrus.Fields {
+				return logrus.Fields{telemetry.SPIFFEID: node.SpiffeId}
+			})
+		}
+
+		agent, err := api.ProtoFromAttestedNode(node)
+		if err != nil {
+			log.WithError(err).WithField(telemetry.SPIFFEID, node.SpiffeId).Warn("Failed to parse agent")
+			continue
+		}
+		agents = append(agents, agent)
+	}
+
+	// Changes are audited per agent, so only requests that did not change
+	// any agent are audited as a whole.
+	if dryRun || len(nodes) == 0 {
+		rpccontext.AuditRPC(ctx)
+	}
+
+	return agents, nextPageToken, nil
+}
+
+func listRequestFromFilter(filter *agentadminv1.AgentFilter) (*datastore.ListAttestedNodesRequest, error) {
+	listReq := &datastore.ListAttestedNodesRequest{
+		ByAttestationType: filter.ByAttestationType,
+		ByAgentVersion:    filter.ByAgentVersion,
+	}
+
+	if filter.ByBanned != nil {
+		listReq.ByBanned = &filter.ByBanned.Value
+	}
+	if filter.ByCanReattest != nil {
+		listReq.ByCanReattest = &filter.ByCanReattest.Value
+	}
+	if filter.ByExpiresBefore != 0 {
+		listReq.ByExpiresBefore = time.Unix(filter.ByExpiresBefore, 0)
+	}
+	if filter.ByLastSeenBefore != 0 {
+		listReq.ByLastSeenBefore = time.Unix(filter.ByLastSeenBefore, 0)
+	}
+	if filter.BySelectorMatch != nil {
+		selectors, err := api.SelectorsFromProto(filter.BySelectorMatch.Selectors)
+		if err != nil {
+			return nil, err
+		}
+		if len(selectors) == 0 {
+			return nil, errSelectorsRequired
+		}
+		listReq.BySelectorMatch = &datastore.BySelectors{
+			Match:     datastore.MatchBehavior(filter.BySelectorMatch.Match),
+			Selectors: selectors,
+		}
+	}
+
+	return listReq, nil
+}
+
+func isListRequestUnfiltered(listReq *datastore.ListAttestedNodesRequest) bool {
+	return listReq.ByAttestationType == "" && listReq.ByBanned == nil && listReq.ByCanReattest == nil &&
+		listReq.ByExpiresBefore.IsZero() && listReq.BySelectorMatch == nil &&
+		listReq.ByAgentVersion == "" && listReq.ByLastSeenBefore.IsZero()
+}
+
+func fieldsFromFilter(filter *agentadminv1.AgentFilter, dryRun bool) logrus.Fields {
+	fields := logrus.Fields{
+		telemetry.DryRun: dryRun,
+	}
+
+	if filter.ByAttestationType != "" {
+		fields[telemetry.NodeAttestorType] = filter.ByAttestationType
+	}
+
+	if filter.ByBanned != nil {
+		fields[telemetry.ByBanned] = filter.ByBanned.Value
+	}
+
+	if filter.ByCanReattest != nil {
+		fields[telemetry.ByCanReattest] = filter.ByCanReattest.Value
+	}
+
+	if filter.ByExpiresBefore != 0 {
+		fields[telemetry.ByExpiresBefore] = filter.ByExpiresBefore
+	}
+
+	if filter.ByAgentVersion != "" {
+		fields[telemetry.ByAgentVersion] = filter.ByAgentVersion
+	}
+
+	if filter.ByLastSeenBefore != 0 {
+		fields[telemetry.ByLastSeenBefore] = filter.ByLastSeenBefore
+	}
+
+	if filter.BySelectorMatch != nil {
+		fields[telemetry.BySelectorMatch] = filter.BySelectorMatch.Match.String()
+		fields[telemetry.BySelectors] = api.SelectorFieldFromProto(filter.BySelectorMatch.Selectors)
+	}
+
+	return fields
+}
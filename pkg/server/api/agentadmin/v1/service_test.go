@@ -0,0 +1,838 @@
+package agentadmin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/agentadmin/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+)
+
+var (
+	ctx      = context.Background()
+	callerID = spiffeid.RequireFromString("spiffe://example.org/admin")
+
+	agent1 = "spiffe://example.org/spire/agent/test/agent1"
+	agent2 = "spiffe://example.org/spire/agent/test/agent2"
+	agent3 = "spiffe://example.org/spire/agent/other/agent3"
+
+	testTypeFilter = &agentadminv1.AgentFilter{ByAttestationType: "test"}
+)
+
+func TestBatchEvictAgents(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	// A dry run returns the agents without evicting them.
+	resp, err := test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+		Filter: testTypeFilter,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent1, agent2}, agentIDs(resp.Agents))
+	require.Equal(t, []string{agent1, agent2, agent3}, test.listAgents(t))
+	require.Empty(t, test.logHook.AllEntries())
+
+	resp, err = test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+		Filter: testTypeFilter,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent1, agent2}, agentIDs(resp.Agents))
+	spiretest.AssertProtoEqual(t, &types.Agent{
+		Id:                   &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+		AttestationType:      "test",
+		X509SvidSerialNumber: "1",
+		X509SvidExpiresAt:    1000,
+		Selectors:            []*types.Selector{{Type: "node", Value: "a"}},
+	}, resp.Agents[0])
+	require.Equal(t, []string{agent3}, test.listAgents(t))
+	spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Agent evicted",
+			Data:    logrus.Fields{telemetry.SPIFFEID: agent1},
+		},
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Agent evicted",
+			Data:    logrus.Fields{telemetry.SPIFFEID: agent2},
+		},
+	})
+}
+
+func TestBatchEvictAgentsPaginated(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	req := &agentadminv1.BatchEvictAgentsRequest{
+		Filter: &agentadminv1.AgentFilter{
+			ByExpiresBefore: 2000,
+		},
+		PageSize: 1,
+	}
+
+	var evicted []string
+	for i := 0; i < 3; i++ {
+		resp, err := test.client.BatchEvictAgents(ctx, req)
+		require.NoError(t, err)
+		evicted = append(evicted, agentIDs(resp.Agents)...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	require.Equal(t, []string{agent1, agent3}, evicted)
+	require.Equal(t, []string{agent2}, test.listAgents(t))
+}
+
+func TestBatchEvictAgentsReappliesFilter(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	filter := &agentadminv1.AgentFilter{ByExpiresBefore: 2000}
+	resp, err := test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+		Filter: filter,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent1, agent3}, agentIDs(resp.Agents))
+
+	// agent1 renews its SVID after the dry run, so it no longer matches the
+	// filter and is not evicted.
+	_, err = test.ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: agent1, CertNotAfter: 3000}, &common.AttestedNodeMask{
+		CertNotAfter: true,
+	})
+	require.NoError(t, err)
+
+	resp, err = test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+		Filter: filter,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent3}, agentIDs(resp.Agents))
+	require.Equal(t, []string{agent1, agent2}, test.listAgents(t))
+}
+
+func TestBatchBanAgents(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	_, err := test.ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: agent1}, &common.AttestedNodeMask{
+		CertSerialNumber:    true,
+		NewCertSerialNumber: true,
+	})
+	require.NoError(t, err)
+
+	filter := &agentadminv1.AgentFilter{
+		BySelectorMatch: &types.SelectorMatch{
+			Selectors: []*types.Selector{{Type: "node", Value: "a"}},
+			Match:     types.SelectorMatch_MATCH_ANY,
+		},
+	}
+
+	// A dry run returns the agents as they would be banned, skipping the
+	// agents that are already banned.
+	resp, err := test.client.BatchBanAgents(ctx, &agentadminv1.BatchBanAgentsRequest{
+		Filter: filter,
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent3}, agentIDs(resp.Agents))
+	require.True(t, resp.Agents[0].Banned)
+	require.Equal(t, []string{agent2, agent3}, test.listBannedAgents(t, false))
+
+	resp, err = test.client.BatchBanAgents(ctx, &agentadminv1.BatchBanAgentsRequest{
+		Filter: filter,
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoListEqual(t, []*types.Agent{
+		{
+			Id:                &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/other/agent3"},
+			AttestationType:   "other",
+			X509SvidExpiresAt: 1000,
+			Selectors:         []*types.Selector{{Type: "node", Value: "a"}, {Type: "node", Value: "c"}},
+			Banned:            true,
+		},
+	}, resp.Agents)
+	require.Equal(t, []string{agent1, agent3}, test.listBannedAgents(t, true))
+	spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "Agent banned",
+			Data:    logrus.Fields{telemetry.SPIFFEID: agent3},
+		},
+	})
+}
+
+func TestBatchAgentsErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		filter     *agentadminv1.AgentFilter
+		dryRun     bool
+		dsErrors   []error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "missing filter",
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "filter is required",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: filter is required",
+				},
+			},
+		},
+		{
+			name:       "empty filter",
+			filter:     &agentadminv1.AgentFilter{},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid filter: filter must set at least one criterion",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid filter",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "filter must set at least one criterion",
+					},
+				},
+			},
+		},
+		{
+			name: "empty selector match",
+			filter: &agentadminv1.AgentFilter{
+				BySelectorMatch: &types.SelectorMatch{},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid filter: selector match requires at least one selector",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid filter",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "selector match requires at least one selector",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid selector",
+			filter: &agentadminv1.AgentFilter{
+				BySelectorMatch: &types.SelectorMatch{
+					Selectors: []*types.Selector{{Value: "a"}},
+				},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid filter: missing selector type",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid filter",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "missing selector type",
+					},
+				},
+			},
+		},
+		{
+			name:       "failed to list agents",
+			filter:     &agentadminv1.AgentFilter{ByBanned: wrapperspb.Bool(true)},
+			dryRun:     true,
+			dsErrors:   []error{errors.New("oh no")},
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list agents: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list agents",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+		{
+			name:       "failed to list operator selectors",
+			filter:     &agentadminv1.AgentFilter{ByBanned: wrapperspb.Bool(true)},
+			dsErrors:   []error{errors.New("oh no")},
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list operator selectors: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list operator selectors",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			for _, err := range tt.dsErrors {
+				test.ds.AppendNextError(err)
+			}
+			_, err := test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{Filter: tt.filter, DryRun: tt.dryRun})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+
+			test.logHook.Reset()
+			for _, err := range tt.dsErrors {
+				test.ds.AppendNextError(err)
+			}
+			_, err = test.client.BatchBanAgents(ctx, &agentadminv1.BatchBanAgentsRequest{Filter: tt.filter, DryRun: tt.dryRun})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+		})
+	}
+
+	t.Run("failed to update agents", func(t *testing.T) {
+		test := setupServiceTest(t)
+		defer test.Cleanup()
+		filter := &agentadminv1.AgentFilter{ByBanned: wrapperspb.Bool(true)}
+
+		test.ds.AppendNextError(nil)
+		test.ds.AppendNextError(errors.New("oh no"))
+		_, err := test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{Filter: filter})
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to evict agents: oh no")
+
+		test.ds.AppendNextError(nil)
+		test.ds.AppendNextError(errors.New("oh no"))
+		_, err = test.client.BatchBanAgents(ctx, &agentadminv1.BatchBanAgentsRequest{Filter: filter})
+		spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to ban agents: oh no")
+	})
+}
+
+func TestGetAgentStatus(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+	test.reportStatus(t)
+
+	status, err := test.client.GetAgentStatus(ctx, &agentadminv1.GetAgentStatusRequest{
+		Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentadminv1.AgentStatus{
+		Agent: &types.Agent{
+			Id:                   &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+			AttestationType:      "test",
+			X509SvidSerialNumber: "1",
+			X509SvidExpiresAt:    1000,
+			Selectors:            []*types.Selector{{Type: "node", Value: "a"}},
+		},
+		AgentVersion: "1.6.0",
+		LastSeenAt:   900,
+		Health:       agentadminv1.AgentHealth_ACTIVE,
+	}, status)
+	require.Empty(t, test.logHook.AllEntries())
+
+	for _, tt := range []struct {
+		name       string
+		id         *types.SPIFFEID
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:       "invalid agent ID",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid agent ID: "spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+		},
+		{
+			name:       "agent not found",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/unknown"},
+			expectCode: codes.NotFound,
+			expectMsg:  "agent not found",
+		},
+		{
+			name:       "failed to fetch agent",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch agent: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.ds.SetNextError(tt.dsError)
+			_, err := test.client.GetAgentStatus(ctx, &agentadminv1.GetAgentStatusRequest{Id: tt.id})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+		})
+	}
+}
+
+func TestListAgentStatuses(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+	test.reportStatus(t)
+
+	listHealth := func(t *testing.T, req *agentadminv1.ListAgentStatusesRequest) map[string]agentadminv1.AgentHealth {
+		resp, err := test.client.ListAgentStatuses(ctx, req)
+		require.NoError(t, err)
+		health := make(map[string]agentadminv1.AgentHealth)
+		for _, status := range resp.Statuses {
+			health[agentIDs([]*types.Agent{status.Agent})[0]] = status.Health
+		}
+		return health
+	}
+
+	require.Equal(t, map[string]agentadminv1.AgentHealth{
+		agent1: agentadminv1.AgentHealth_ACTIVE,
+		agent2: agentadminv1.AgentHealth_INACTIVE,
+		agent3: agentadminv1.AgentHealth_NEVER_SEEN,
+	}, listHealth(t, &agentadminv1.ListAgentStatusesRequest{}))
+
+	require.Equal(t, map[string]agentadminv1.AgentHealth{
+		agent2: agentadminv1.AgentHealth_INACTIVE,
+	}, listHealth(t, &agentadminv1.ListAgentStatusesRequest{
+		Filter: &agentadminv1.AgentFilter{ByAgentVersion: "1.5.0"},
+	}))
+
+	require.Equal(t, map[string]agentadminv1.AgentHealth{
+		agent2: agentadminv1.AgentHealth_INACTIVE,
+		agent3: agentadminv1.AgentHealth_NEVER_SEEN,
+	}, listHealth(t, &agentadminv1.ListAgentStatusesRequest{
+		Filter: &agentadminv1.AgentFilter{ByLastSeenBefore: 500},
+	}))
+
+	// Banned and expired agents
+	_, err := test.ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: agent2}, &common.AttestedNodeMask{
+		CertSerialNumber:    true,
+		NewCertSerialNumber: true,
+	})
+	require.NoError(t, err)
+	test.clk.Set(time.Unix(2000, 0))
+	require.Equal(t, map[string]agentadminv1.AgentHealth{
+		agent1: agentadminv1.AgentHealth_EXPIRED,
+		agent2: agentadminv1.AgentHealth_BANNED,
+		agent3: agentadminv1.AgentHealth_EXPIRED,
+	}, listHealth(t, &agentadminv1.ListAgentStatusesRequest{}))
+
+	// Paginated
+	req := &agentadminv1.ListAgentStatusesRequest{PageSize: 2}
+	resp, err := test.client.ListAgentStatuses(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Statuses, 2)
+	require.NotEmpty(t, resp.NextPageToken)
+	req.PageToken = resp.NextPageToken
+	resp, err = test.client.ListAgentStatuses(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Statuses, 1)
+
+	// Errors
+	_, err = test.client.ListAgentStatuses(ctx, &agentadminv1.ListAgentStatusesRequest{
+		Filter: &agentadminv1.AgentFilter{BySelectorMatch: &types.SelectorMatch{}},
+	})
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "invalid filter: selector match requires at least one selector")
+
+	test.ds.SetNextError(errors.New("oh no"))
+	_, err = test.client.ListAgentStatuses(ctx, &agentadminv1.ListAgentStatusesRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Internal, "failed to list agents: oh no")
+}
+
+func TestAgentStatusOperatorSelectors(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+	test.reportStatus(t)
+
+	_, err := test.ds.AddOperatorNodeSelectors(ctx, agent1, []*common.Selector{
+		{Type: "operator", Value: "x"},
+		{Type: "node", Value: "a"},
+	})
+	require.NoError(t, err)
+
+	expectSelectors := []*types.Selector{
+		{Type: "node", Value: "a"},
+		{Type: "operator", Value: "x"},
+	}
+
+	status, err := test.client.GetAgentStatus(ctx, &agentadminv1.GetAgentStatusRequest{
+		Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoListEqual(t, expectSelectors, status.Agent.Selectors)
+
+	listResp, err := test.client.ListAgentStatuses(ctx, &agentadminv1.ListAgentStatusesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Statuses, 3)
+	spiretest.AssertProtoListEqual(t, expectSelectors, listResp.Statuses[0].Agent.Selectors)
+	spiretest.AssertProtoListEqual(t, []*types.Selector{{Type: "node", Value: "b"}}, listResp.Statuses[1].Agent.Selectors)
+
+	evictResp, err := test.client.BatchEvictAgents(ctx, &agentadminv1.BatchEvictAgentsRequest{
+		Filter: testTypeFilter,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{agent1, agent2}, agentIDs(evictResp.Agents))
+	spiretest.AssertProtoListEqual(t, expectSelectors, evictResp.Agents[0].Selectors)
+}
+
+func TestReattestAgent(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	// agent1 and agent2 are reattestable, agent2 is banned
+	for _, id := range []string{agent1, agent2} {
+		_, err := test.ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: id, CanReattest: true}, &common.AttestedNodeMask{CanReattest: true})
+		require.NoError(t, err)
+	}
+	_, err := test.ds.UpdateAttestedNode(ctx, &common.AttestedNode{SpiffeId: agent2}, &common.AttestedNodeMask{
+		CertSerialNumber:    true,
+		NewCertSerialNumber: true,
+	})
+	require.NoError(t, err)
+
+	_, err = test.client.ReattestAgent(ctx, &agentadminv1.ReattestAgentRequest{
+		Id: &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+	})
+	require.NoError(t, err)
+	node, err := test.ds.FetchAttestedNode(ctx, agent1)
+	require.NoError(t, err)
+	require.True(t, node.ReattestRequested)
+	require.True(t, node.CanReattest)
+	require.Equal(t, "1", node.CertSerialNumber)
+
+	for _, tt := range []struct {
+		name       string
+		id         *types.SPIFFEID
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:       "invalid agent ID",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid agent ID: "spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+		},
+		{
+			name:       "agent not found",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/unknown"},
+			expectCode: codes.NotFound,
+			expectMsg:  "agent not found",
+		},
+		{
+			name:       "agent is banned",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent2"},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "agent is banned",
+		},
+		{
+			name:       "agent cannot reattest",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/other/agent3"},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "agent cannot reattest",
+		},
+		{
+			name:       "failed to fetch agent",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch agent: oh no",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test.ds.SetNextError(tt.dsError)
+			_, err := test.client.ReattestAgent(ctx, &agentadminv1.ReattestAgentRequest{Id: tt.id})
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+		})
+	}
+
+	node, err = test.ds.FetchAttestedNode(ctx, agent3)
+	require.NoError(t, err)
+	require.False(t, node.ReattestRequested)
+}
+
+func TestAgentSelectors(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+	test.createAgents(t)
+
+	agent1ID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent1"}
+
+	added, err := test.client.AddAgentSelectors(ctx, &agentadminv1.AddAgentSelectorsRequest{
+		Id:        agent1ID,
+		Selectors: []*types.Selector{{Type: "rack", Value: "r1"}, {Type: "env", Value: "prod"}},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentadminv1.AgentSelectors{
+		OperatorSelectors: []*types.Selector{{Type: "rack", Value: "r1"}, {Type: "env", Value: "prod"}},
+	}, added)
+
+	// Operator selectors are kept when the agent attests again
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agent1, []*common.Selector{{Type: "node", Value: "z"}}))
+
+	selectors, err := test.client.GetAgentSelectors(ctx, &agentadminv1.GetAgentSelectorsRequest{Id: agent1ID})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentadminv1.AgentSelectors{
+		NodeSelectors:     []*types.Selector{{Type: "node", Value: "z"}},
+		OperatorSelectors: []*types.Selector{{Type: "rack", Value: "r1"}, {Type: "env", Value: "prod"}},
+	}, selectors)
+
+	removed, err := test.client.RemoveAgentSelectors(ctx, &agentadminv1.RemoveAgentSelectorsRequest{
+		Id:        agent1ID,
+		Selectors: []*types.Selector{{Type: "rack", Value: "r1"}},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentadminv1.AgentSelectors{
+		OperatorSelectors: []*types.Selector{{Type: "env", Value: "prod"}},
+	}, removed)
+
+	// Selectors of agents that no longer exist can be removed
+	_, err = test.ds.DeleteAttestedNode(ctx, agent1)
+	require.NoError(t, err)
+	removed, err = test.client.RemoveAgentSelectors(ctx, &agentadminv1.RemoveAgentSelectorsRequest{
+		Id:        agent1ID,
+		Selectors: []*types.Selector{{Type: "env", Value: "prod"}},
+	})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &agentadminv1.AgentSelectors{}, removed)
+
+	agent2ID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/test/agent2"}
+	selector := []*types.Selector{{Type: "rack", Value: "r1"}}
+	for _, tt := range []struct {
+		name       string
+		id         *types.SPIFFEID
+		selectors  []*types.Selector
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+		// skipGet, skipAdd and skipRemove skip the RPCs that don't fail
+		// for the case
+		skipGet    bool
+		skipAdd    bool
+		skipRemove bool
+	}{
+		{
+			name:       "invalid agent ID",
+			id:         &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+			selectors:  selector,
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid agent ID: "spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+		},
+		{
+			name:       "no selectors",
+			id:         agent2ID,
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "at least one selector is required",
+			skipGet:    true,
+		},
+		{
+			name:       "invalid selectors",
+			id:         agent2ID,
+			selectors:  []*types.Selector{{Type: "rack"}},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid selectors: missing selector value",
+			skipGet:    true,
+		},
+		{
+			name:       "agent not found",
+			id:         agent1ID,
+			selectors:  selector,
+			expectCode: codes.NotFound,
+			expectMsg:  "agent not found",
+			skipRemove: true,
+		},
+		{
+			name:       "failed to fetch agent",
+			id:         agent2ID,
+			selectors:  selector,
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch agent: oh no",
+			skipRemove: true,
+		},
+		{
+			name:       "failed to remove agent selectors",
+			id:         agent2ID,
+			selectors:  selector,
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to remove agent selectors: oh no",
+			skipGet:    true,
+			skipAdd:    true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.skipGet {
+				test.ds.SetNextError(tt.dsError)
+				_, err := test.client.GetAgentSelectors(ctx, &agentadminv1.GetAgentSelectorsRequest{Id: tt.id})
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			}
+			if !tt.skipAdd {
+				test.ds.SetNextError(tt.dsError)
+				_, err := test.client.AddAgentSelectors(ctx, &agentadminv1.AddAgentSelectorsRequest{Id: tt.id, Selectors: tt.selectors})
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			}
+			if !tt.skipRemove {
+				test.ds.SetNextError(tt.dsError)
+				_, err := test.client.RemoveAgentSelectors(ctx, &agentadminv1.RemoveAgentSelectorsRequest{Id: tt.id, Selectors: tt.selectors})
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			}
+		})
+	}
+}
+
+type serviceTest struct {
+	client  agentadminv1.AgentAdminClient
+	ds      *fakedatastore.DataStore
+	clk     *clock.Mock
+	logHook *test.Hook
+	done    func()
+}
+
+func (s *serviceTest) Cleanup() {
+	s.done()
+}
+
+// createAgents creates the following agents:
+//   - agent1, attested with "test", expiring at 1000, with the node:a selector
+//   - agent2, attested with "test", expiring at 3000, with the node:b selector
+//   - agent3, attested with "other", expiring at 1000, with the node:a and
+//     node:c selectors
+func (s *serviceTest) createAgents(t *testing.T) {
+	for _, agent := range []struct {
+		node      *common.AttestedNode
+		selectors []*common.Selector
+	}{
+		{
+			node: &common.AttestedNode{
+				SpiffeId:            agent1,
+				AttestationDataType: "test",
+				CertSerialNumber:    "1",
+				CertNotAfter:        1000,
+			},
+			selectors: []*common.Selector{{Type: "node", Value: "a"}},
+		},
+		{
+			node: &common.AttestedNode{
+				SpiffeId:            agent2,
+				AttestationDataType: "test",
+				CertSerialNumber:    "2",
+				CertNotAfter:        3000,
+			},
+			selectors: []*common.Selector{{Type: "node", Value: "b"}},
+		},
+		{
+			node: &common.AttestedNode{
+				SpiffeId:            agent3,
+				AttestationDataType: "other",
+				CertSerialNumber:    "3",
+				CertNotAfter:        1000,
+			},
+			selectors: []*common.Selector{{Type: "node", Value: "a"}, {Type: "node", Value: "c"}},
+		},
+	} {
+		_, err := s.ds.CreateAttestedNode(ctx, agent.node)
+		require.NoError(t, err)
+		require.NoError(t, s.ds.SetNodeSelectors(ctx, agent.node.SpiffeId, agent.selectors))
+	}
+}
+
+// reportStatus reports the status of the agents created by createAgents, at
+// 900 seconds since the Unix epoch:
+//   - agent1 was last seen at 900 running 1.6.0
+//   - agent2 was last seen at 200 running 1.5.0
+//   - agent3 was never seen
+func (s *serviceTest) reportStatus(t *testing.T) {
+	require.NoError(t, s.ds.UpdateAttestedNodesStatus(ctx, []*datastore.AttestedNodeStatus{
+		{SpiffeID: agent1, AgentVersion: "1.6.0", LastSeenAt: time.Unix(900, 0)},
+		{SpiffeID: agent2, AgentVersion: "1.5.0", LastSeenAt: time.Unix(200, 0)},
+	}))
+}
+
+func (s *serviceTest) listAgents(t *testing.T) []string {
+	return s.listAgentsWith(t, &datastore.ListAttestedNodesRequest{})
+}
+
+func (s *serviceTest) listBannedAgents(t *testing.T, banned bool) []string {
+	return s.listAgentsWith(t, &datastore.ListAttestedNodesRequest{ByBanned: &banned})
+}
+
+func (s *serviceTest) listAgentsWith(t *testing.T, req *datastore.ListAttestedNodesRequest) []string {
+	resp, err := s.ds.ListAttestedNodes(ctx, req)
+	require.NoError(t, err)
+	var ids []string
+	for _, node := range resp.Nodes {
+		ids = append(ids, node.SpiffeId)
+	}
+	return ids
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	clk := clock.NewMockAt(t, time.Unix(900, 0))
+	service := agentadmin.New(agentadmin.Config{
+		DataStore:   ds,
+		Clock:       clk,
+		TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s *grpc.Server) {
+		agentadmin.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		ctx = rpccontext.WithLogger(ctx, log)
+		ctx = rpccontext.WithCallerID(ctx, callerID)
+		return ctx, nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(ppMiddleware)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	return &serviceTest{
+		client:  agentadminv1.NewAgentAdminClient(conn),
+		ds:      ds,
+		clk:     clk,
+		logHook: logHook,
+		done:    done,
+	}
+}
+
+func agentIDs(agents []*types.Agent) []string {
+	var ids []string
+	for _, agent := range agents {
+		ids = append(ids, "spiffe://"+agent.Id.TrustDomain+agent.Id.Path)
+	}
+	return ids
+}
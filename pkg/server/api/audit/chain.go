@@ -0,0 +1,211 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	// SeqKey is the key of the record sequence number in hash chained records.
+	SeqKey = "seq"
+
+	// PrevHashKey is the key of the hash of the previous record in hash
+	// chained records. It is empty for the first record of a chain.
+	PrevHashKey = "prev_hash"
+
+	// HashKey is the key of the record hash in hash chained records.
+	HashKey = "hash"
+)
+
+// hashChain links audit records by including in each record the hash of the
+// record that precedes it. Modifying, removing or reordering records breaks
+// the chain.
+type hashChain struct {
+	seq      uint64
+	prevHash string
+}
+
+// link adds the chain fields to the record.
+func (c *hashChain) link(record map[string]interface{}) error {
+	record[SeqKey] = json.Number(strconv.FormatUint(c.seq+1, 10))
+	record[PrevHashKey] = c.prevHash
+	delete(record, HashKey)
+
+	hash, err := recordHash(record)
+	if err != nil {
+		return err
+	}
+	record[HashKey] = hash
+
+	c.seq++
+	c.prevHash = hash
+	return nil
+}
+
+// resume continues the chain after the given record.
+func (c *hashChain) resume(record map[string]interface{}) error {
+	link, err := linkOf(record)
+	if err != nil {
+		return err
+	}
+	c.seq = link.seq
+	c.prevHash = link.hash
+	return nil
+}
+
+// VerifyResult describes a verified hash chain.
+type VerifyResult struct {
+	// Records is the number of records verified.
+	Records int
+
+	// FirstSeq and LastSeq are the sequence numbers of the first and the last
+	// record.
+	FirstSeq uint64
+	LastSeq  uint64
+
+	// LastHash is the hash of the last record. Comparing it with the hash
+	// received by another sink proves that no records were removed from the
+	// end of the log.
+	LastHash string
+
+	// ChainStart is true if the first record starts the chain. Otherwise,
+	// the log continues a chain from a previous, rotated, log.
+	ChainStart bool
+}
+
+// VerifyChain verifies the hash chain of a log of audit records, one JSON
+// record per line.
+func VerifyChain(r io.Reader) (*VerifyResult, error) {
+	result := new(VerifyResult)
+
+	reader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := reader.ReadBytes('\n')
+		switch {
+		case errors.Is(err, io.EOF):
+			if len(bytes.TrimSpace(line)) == 0 {
+				if result.Records == 0 {
+					return nil, errors.New("no audit records found")
+				}
+				return result, nil
+			}
+		case err != nil:
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := result.verifyLine(line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+	}
+}
+
+func (v *VerifyResult) verifyLine(line []byte) error {
+	record, err := parseRecord(line)
+	if err != nil {
+		return err
+	}
+	link, err := linkOf(record)
+	if err != nil {
+		return err
+	}
+
+	delete(record, HashKey)
+	hash, err := recordHash(record)
+	if err != nil {
+		return err
+	}
+	if hash != link.hash {
+		return errors.New("record hash does not match its content")
+	}
+
+	if v.Records == 0 {
+		v.FirstSeq = link.seq
+		v.ChainStart = link.prevHash == ""
+	} else {
+		switch {
+		case link.prevHash != v.LastHash:
+			return fmt.Errorf("record does not chain to the previous record (seq %d)", v.LastSeq)
+		case link.seq != v.LastSeq+1:
+			return fmt.Errorf("unexpected sequence number %d; expected %d", link.seq, v.LastSeq+1)
+		}
+	}
+
+	v.Records++
+	v.LastSeq = link.seq
+	v.LastHash = link.hash
+	return nil
+}
+
+type chainLink struct {
+	seq      uint64
+	prevHash string
+	hash     string
+}
+
+func linkOf(record map[string]interface{}) (*chainLink, error) {
+	seqValue, ok := record[SeqKey].(json.Number)
+	if !ok {
+		return nil, errors.New("record is not hash chained: missing sequence number")
+	}
+	seq, err := strconv.ParseUint(seqValue.String(), 10, 64)
+	if err != nil || seq == 0 {
+		return nil, fmt.Errorf("invalid sequence number %q", seqValue)
+	}
+	prevHash, ok := record[PrevHashKey].(string)
+	if !ok {
+		return nil, errors.New("record is not hash chained: missing previous hash")
+	}
+	hash, ok := record[HashKey].(string)
+	if !ok {
+		return nil, errors.New("record is not hash chained: missing hash")
+	}
+	if prevHash == "" && seq != 1 {
+		return nil, fmt.Errorf("record with sequence number %d has no previous hash", seq)
+	}
+	return &chainLink{
+		seq:      seq,
+		prevHash: prevHash,
+		hash:     hash,
+	}, nil
+}
+
+// parseRecord parses a JSON record. Numbers are kept as json.Number so the
+// record marshals back to the same JSON it was hashed from.
+func parseRecord(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var record map[string]interface{}
+	if err := decoder.Decode(&record); err != nil {
+		return nil, fmt.Errorf("malformed audit record: %w", err)
+	}
+	if record == nil {
+		return nil, errors.New("malformed audit record: not a JSON object")
+	}
+	return record, nil
+}
+
+// recordHash returns the hex encoded SHA-256 hash of the record. Maps are
+// marshaled with sorted keys, which makes the encoding deterministic.
+func recordHash(record map[string]interface{}) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal audit record: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,233 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	fileFlags = os.O_APPEND | os.O_CREATE | os.O_WRONLY
+	fileMode  = 0600
+
+	defaultFileMaxSizeMB  = 100
+	defaultFileMaxBackups = 5
+)
+
+// FileSinkConfig configures a sink that writes audit records to a file, one
+// JSON record per line.
+type FileSinkConfig struct {
+	// Path is the path of the file.
+	Path string
+
+	// MaxSizeMB is the size, in megabytes, at which the file is rotated.
+	// Defaults to 100.
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated files to keep. Rotated files are
+	// named after the file, with a numeric suffix that increases with age
+	// (e.g. audit.log.1). Defaults to 5.
+	MaxBackups int
+}
+
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func newFileSink(config *FileSinkConfig) (*fileSink, error) {
+	if config.Path == "" {
+		return nil, errors.New("path must be configured")
+	}
+	maxSizeMB := config.MaxSizeMB
+	if maxSizeMB == 0 {
+		maxSizeMB = defaultFileMaxSizeMB
+	}
+	maxBackups := config.MaxBackups
+	if maxBackups == 0 {
+		maxBackups = defaultFileMaxBackups
+	}
+	if maxSizeMB < 0 {
+		return nil, errors.New("max size must be positive")
+	}
+	if maxBackups < 0 {
+		return nil, errors.New("max backups must be positive")
+	}
+
+	s := &fileSink{
+		path:       config.Path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) Name() string {
+	return "file"
+}
+
+func (s *fileSink) WriteRecord(record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The file is not open if it could not be reopened after a failed
+	// rotation.
+	if s.f == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	line := make([]byte, 0, len(record)+1)
+	line = append(line, record...)
+	line = append(line, '\n')
+	var rotateErr error
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		// If the rotation fails, the record is still written to the current
+		// file, which is rotated again on the next write.
+		rotateErr = s.rotate()
+		if s.f == nil {
+			return rotateErr
+		}
+	}
+
+	n, err := s.f.Write(line)
+	s.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// LastRecord returns the last record written to the file, or to the most
+// recent rotated file if the file is empty. It returns nil if no record has
+// been written.
+func (s *fileSink) LastRecord() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, path := range []string{s.path, s.backupPath(1)} {
+		record, err := lastLine(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		case record != nil:
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+// Rotate rotates the file if it is not empty.
+func (s *fileSink) Rotate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size == 0 {
+		return nil
+	}
+	return s.rotate()
+}
+
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(s.path, fileFlags, fileMode)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", s.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("unable to stat %s: %w", s.path, err)
+	}
+	s.f = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate must be called while holding the lock. The file is closed before
+// it is renamed, since open files cannot be renamed on every platform. If
+// the rotation fails, the file is reopened so records keep being appended
+// to it.
+func (s *fileSink) rotate() error {
+	err := s.f.Close()
+	s.f = nil
+	if err != nil {
+		err = fmt.Errorf("unable to close %s: %w", s.path, err)
+	} else {
+		err = s.shiftBackups()
+	}
+	if openErr := s.open(); err == nil {
+		err = openErr
+	}
+	return err
+}
+
+// shiftBackups renames the file to the most recent backup, shifting the
+// existing backups and dropping the oldest one.
+func (s *fileSink) shiftBackups() error {
+	if err := os.Remove(s.backupPath(s.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to remove oldest audit log backup: %w", err)
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to rotate audit log backup: %w", err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("unable to rotate %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func (s *fileSink) backupPath(n int) string {
+	return s.path + "." + strconv.Itoa(n)
+}
+
+func lastLine(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var last []byte
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			last = line
+		}
+		switch {
+		case errors.Is(err, io.EOF):
+			return last, nil
+		case err != nil:
+			return nil, err
+		}
+	}
+}
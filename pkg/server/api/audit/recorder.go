@@ -0,0 +1,203 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Sink writes audit records.
+type Sink interface {
+	// Name returns the name of the sink, used for logging.
+	Name() string
+
+	// WriteRecord writes a single JSON encoded audit record.
+	WriteRecord(record []byte) error
+
+	// Close releases the resources used by the sink.
+	Close() error
+}
+
+// SinksConfig configures the sinks audit records are written to.
+type SinksConfig struct {
+	File    *FileSinkConfig
+	Syslog  *SyslogSinkConfig
+	Webhook *WebhookSinkConfig
+
+	// HashChain, if true, links each record to the previous one with the
+	// hash chain fields, so that tampering with the records can be detected.
+	HashChain bool
+}
+
+// RecorderConfig is the configuration for a recorder.
+type RecorderConfig struct {
+	Sinks SinksConfig
+
+	// Log is the operational logger, used to report failures to write
+	// records.
+	Log logrus.FieldLogger
+}
+
+// Recorder writes audit records to dedicated sinks, instead of mixing them
+// with the operational logs.
+type Recorder struct {
+	log       logrus.FieldLogger
+	logger    *logrus.Logger
+	formatter logrus.Formatter
+	sinks     []Sink
+
+	mu    sync.Mutex
+	chain *hashChain
+}
+
+// NewRecorder opens the configured sinks and returns a recorder that writes
+// to them. If hash chaining is enabled and a file sink is configured, the
+// chain continues from the last record of the file.
+func NewRecorder(c RecorderConfig) (_ *Recorder, err error) {
+	r := &Recorder{
+		log: c.Log,
+		formatter: &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		},
+	}
+	defer func() {
+		if err != nil {
+			_ = r.Close()
+		}
+	}()
+
+	var file *fileSink
+	if c.Sinks.File != nil {
+		file, err = newFileSink(c.Sinks.File)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create file audit sink: %w", err)
+		}
+		r.sinks = append(r.sinks, file)
+	}
+	if c.Sinks.Syslog != nil {
+		syslog, err := newSyslogSink(c.Sinks.Syslog)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create syslog audit sink: %w", err)
+		}
+		r.sinks = append(r.sinks, syslog)
+	}
+	if c.Sinks.Webhook != nil {
+		webhook, err := newWebhookSink(c.Sinks.Webhook, c.Log)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create webhook audit sink: %w", err)
+		}
+		r.sinks = append(r.sinks, webhook)
+	}
+	if len(r.sinks) == 0 {
+		return nil, errors.New("at least one audit sink must be configured")
+	}
+
+	if c.Sinks.HashChain {
+		r.chain = new(hashChain)
+		if file != nil {
+			if err := r.resumeChain(file); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	r.logger = logrus.New()
+	r.logger.SetOutput(io.Discard)
+	r.logger.AddHook(r)
+	return r, nil
+}
+
+// New returns an audit logger that writes the records to the sinks of the
+// recorder. Records include the fields of the given logger.
+func (r *Recorder) New(log logrus.FieldLogger) Logger {
+	entry := logrus.NewEntry(r.logger)
+	if logEntry, ok := log.(*logrus.Entry); ok {
+		entry = entry.WithFields(logEntry.Data)
+	}
+	return New(entry)
+}
+
+// Close closes the sinks.
+func (r *Recorder) Close() error {
+	var closeErr error
+	for _, sink := range r.sinks {
+		if err := sink.Close(); err != nil && closeErr == nil {
+			closeErr = fmt.Errorf("unable to close %s audit sink: %w", sink.Name(), err)
+		}
+	}
+	return closeErr
+}
+
+// Levels implements the logrus.Hook interface.
+func (r *Recorder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface, writing the entry as an audit
+// record.
+func (r *Recorder) Fire(entry *logrus.Entry) error {
+	data, err := r.formatter.Format(entry)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to format audit record")
+		return nil
+	}
+	record, err := parseRecord(data)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to format audit record")
+		return nil
+	}
+
+	// Records are written while holding the lock so that all the sinks
+	// receive them in chain order.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.chain != nil {
+		if err := r.chain.link(record); err != nil {
+			r.log.WithError(err).Error("Failed to hash audit record")
+			return nil
+		}
+	}
+	data, err = json.Marshal(record)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to format audit record")
+		return nil
+	}
+
+	for _, sink := range r.sinks {
+		if err := sink.WriteRecord(data); err != nil {
+			r.log.WithError(err).WithField(telemetry.AuditSink, sink.Name()).Error("Failed to write audit record")
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) resumeChain(file *fileSink) error {
+	data, err := file.LastRecord()
+	if err != nil {
+		return fmt.Errorf("unable to read last audit record: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	record, err := parseRecord(data)
+	if err == nil {
+		err = r.chain.resume(record)
+	}
+	if err != nil {
+		// Start the new chain on a new file, so the chain of the file can
+		// be verified.
+		r.log.WithError(err).Warn("Audit log file does not end with a hash chained record; rotating it to start a new chain")
+		if err := file.Rotate(); err != nil {
+			return fmt.Errorf("unable to rotate audit log file: %w", err)
+		}
+	}
+	return nil
+}
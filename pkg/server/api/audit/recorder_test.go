@@ -0,0 +1,402 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/audit"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecorder(t *testing.T) {
+	dir := spiretest.TempDir(t)
+
+	for _, tt := range []struct {
+		name      string
+		sinks     audit.SinksConfig
+		expectErr string
+	}{
+		{
+			name:      "no sinks",
+			sinks:     audit.SinksConfig{HashChain: true},
+			expectErr: "at least one audit sink must be configured",
+		},
+		{
+			name: "file sink without path",
+			sinks: audit.SinksConfig{
+				File: &audit.FileSinkConfig{},
+			},
+			expectErr: "unable to create file audit sink: path must be configured",
+		},
+		{
+			name: "file sink in missing directory",
+			sinks: audit.SinksConfig{
+				File: &audit.FileSinkConfig{Path: filepath.Join(dir, "missing", "audit.log")},
+			},
+			expectErr: "unable to create file audit sink: unable to open",
+		},
+		{
+			name: "file sink with negative size",
+			sinks: audit.SinksConfig{
+				File: &audit.FileSinkConfig{Path: filepath.Join(dir, "audit.log"), MaxSizeMB: -1},
+			},
+			expectErr: "unable to create file audit sink: max size must be positive",
+		},
+		{
+			name: "webhook sink with invalid scheme",
+			sinks: audit.SinksConfig{
+				Webhook: &audit.WebhookSinkConfig{URL: "ftp://example.org"},
+			},
+			expectErr: `unable to create webhook audit sink: invalid URL "ftp://example.org": scheme must be http or https`,
+		},
+		{
+			name: "webhook sink without host",
+			sinks: audit.SinksConfig{
+				Webhook: &audit.WebhookSinkConfig{URL: "https:///audit"},
+			},
+			expectErr: `unable to create webhook audit sink: invalid URL "https:///audit": host is required`,
+		},
+		{
+			name: "success",
+			sinks: audit.SinksConfig{
+				File:      &audit.FileSinkConfig{Path: filepath.Join(dir, "audit.log")},
+				Webhook:   &audit.WebhookSinkConfig{URL: "https://example.org/audit"},
+				HashChain: true,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			recorder, err := audit.NewRecorder(audit.RecorderConfig{
+				Sinks: tt.sinks,
+				Log:   log,
+			})
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				require.Nil(t, recorder)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, recorder.Close())
+		})
+	}
+}
+
+func TestRecorderFileSink(t *testing.T) {
+	path := filepath.Join(spiretest.TempDir(t), "audit.log")
+	log, hook := test.NewNullLogger()
+
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path},
+		HashChain: true,
+	})
+
+	// Fields of the RPC logger are included in the records
+	rpcLog := log.WithField(telemetry.Method, "CountEntries")
+	recorder.New(rpcLog).Audit()
+	recorder.New(rpcLog).AuditWithFields(logrus.Fields{telemetry.Count: 2})
+	require.NoError(t, recorder.Close())
+
+	// Audit records are not written to the operational log
+	require.Empty(t, hook.AllEntries())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 2)
+	require.Equal(t, "API accessed", records[0]["msg"])
+	require.Equal(t, "audit", records[0][telemetry.Type])
+	require.Equal(t, "success", records[0][telemetry.Status])
+	require.Equal(t, "CountEntries", records[0][telemetry.Method])
+	require.Equal(t, json.Number("1"), records[0][audit.SeqKey])
+	require.Equal(t, "", records[0][audit.PrevHashKey])
+	require.Equal(t, json.Number("2"), records[1][telemetry.Count])
+	require.Equal(t, json.Number("2"), records[1][audit.SeqKey])
+	require.Equal(t, records[0][audit.HashKey], records[1][audit.PrevHashKey])
+
+	// The chain continues after a restart
+	recorder = newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path},
+		HashChain: true,
+	})
+	recorder.New(rpcLog).Audit()
+	require.NoError(t, recorder.Close())
+
+	result := verifyFile(t, path)
+	require.Equal(t, &audit.VerifyResult{
+		Records:    3,
+		FirstSeq:   1,
+		LastSeq:    3,
+		LastHash:   readRecords(t, path)[2][audit.HashKey].(string),
+		ChainStart: true,
+	}, result)
+}
+
+func TestRecorderStartsChainOnNewFile(t *testing.T) {
+	path := filepath.Join(spiretest.TempDir(t), "audit.log")
+	log, hook := test.NewNullLogger()
+
+	// Write a record that is not hash chained
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		File: &audit.FileSinkConfig{Path: path},
+	})
+	recorder.New(log).Audit()
+	require.NoError(t, recorder.Close())
+	_, err := audit.VerifyChain(openFile(t, path))
+	require.EqualError(t, err, "line 1: record is not hash chained: missing sequence number")
+
+	// Enabling hash chaining rotates the file
+	recorder = newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path},
+		HashChain: true,
+	})
+	recorder.New(log).Audit()
+	require.NoError(t, recorder.Close())
+
+	require.Len(t, hook.AllEntries(), 1)
+	require.Equal(t, "Audit log file does not end with a hash chained record; rotating it to start a new chain", hook.LastEntry().Message)
+	require.Len(t, readRecords(t, path+".1"), 1)
+	result := verifyFile(t, path)
+	require.Equal(t, 1, result.Records)
+	require.True(t, result.ChainStart)
+}
+
+func TestRecorderFileRotation(t *testing.T) {
+	path := filepath.Join(spiretest.TempDir(t), "audit.log")
+	log, _ := test.NewNullLogger()
+
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path, MaxSizeMB: 1, MaxBackups: 2},
+		HashChain: true,
+	})
+
+	// Each record takes more than a third of the maximum size, so every
+	// other record rotates the file.
+	large := logrus.Fields{"data": strings.Repeat("a", 400*1024)}
+	for i := 0; i < 7; i++ {
+		recorder.New(log).AuditWithFields(large)
+	}
+	require.NoError(t, recorder.Close())
+
+	require.Len(t, readRecords(t, path), 1)
+	require.Len(t, readRecords(t, path+".1"), 2)
+	require.Len(t, readRecords(t, path+".2"), 2)
+	require.NoFileExists(t, path+".3")
+
+	// The rotated files continue the chain of the previous file
+	previous := verifyFile(t, path+".2")
+	require.False(t, previous.ChainStart)
+	require.Equal(t, uint64(3), previous.FirstSeq)
+	latest := verifyFile(t, path+".1")
+	require.Equal(t, previous.LastSeq+1, latest.FirstSeq)
+	current := verifyFile(t, path)
+	require.Equal(t, uint64(7), current.LastSeq)
+}
+
+func TestRecorderFileRotationFailure(t *testing.T) {
+	path := filepath.Join(spiretest.TempDir(t), "audit.log")
+	log, hook := test.NewNullLogger()
+
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1},
+		HashChain: true,
+	})
+
+	// The oldest backup cannot be removed while it is a non-empty directory
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "blocker"), 0755))
+
+	// The third record fails to rotate the file but is still written to it
+	large := logrus.Fields{"data": strings.Repeat("a", 400*1024)}
+	for i := 0; i < 3; i++ {
+		recorder.New(log).AuditWithFields(large)
+	}
+	require.Len(t, hook.AllEntries(), 1)
+	require.Equal(t, "Failed to write audit record", hook.LastEntry().Message)
+	require.Contains(t, hook.LastEntry().Data[logrus.ErrorKey].(error).Error(), "unable to remove oldest audit log backup")
+
+	// The file is rotated once the failure is resolved
+	require.NoError(t, os.RemoveAll(path+".1"))
+	recorder.New(log).AuditWithFields(large)
+	require.NoError(t, recorder.Close())
+	require.Len(t, hook.AllEntries(), 1)
+
+	require.Len(t, readRecords(t, path), 1)
+	require.Len(t, readRecords(t, path+".1"), 3)
+	latest := verifyFile(t, path+".1")
+	require.True(t, latest.ChainStart)
+	current := verifyFile(t, path)
+	require.Equal(t, latest.LastSeq+1, current.FirstSeq)
+}
+
+func TestRecorderWebhookSink(t *testing.T) {
+	var mu sync.Mutex
+	var received [][]byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if req.Method == http.MethodPost && req.Header.Get("Content-Type") == "application/json" {
+			received = append(received, body)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	log, hook := test.NewNullLogger()
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		Webhook:   &audit.WebhookSinkConfig{URL: server.URL, Timeout: time.Minute},
+		HashChain: true,
+	})
+	recorder.New(log).Audit()
+	recorder.New(log).Audit()
+
+	// Records that fail to be delivered are logged
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, time.Minute, 10*time.Millisecond)
+	mu.Lock()
+	status = http.StatusServiceUnavailable
+	mu.Unlock()
+	recorder.New(log).Audit()
+
+	// Closing waits for queued records to be delivered
+	require.NoError(t, recorder.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 3)
+	result, err := audit.VerifyChain(bytes.NewReader(bytes.Join(received, []byte("\n"))))
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Records)
+
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.ErrorLevel,
+			Message: "Failed to deliver audit record to webhook",
+			Data: logrus.Fields{
+				telemetry.URL:   server.URL,
+				logrus.ErrorKey: "unexpected status code 503",
+			},
+		},
+	})
+}
+
+func TestVerifyChain(t *testing.T) {
+	path := filepath.Join(spiretest.TempDir(t), "audit.log")
+	log, _ := test.NewNullLogger()
+
+	recorder := newRecorder(t, log, audit.SinksConfig{
+		File:      &audit.FileSinkConfig{Path: path},
+		HashChain: true,
+	})
+	for i := 0; i < 3; i++ {
+		recorder.New(log).AuditWithFields(logrus.Fields{telemetry.Count: i})
+	}
+	require.NoError(t, recorder.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+
+	for _, tt := range []struct {
+		name      string
+		log       string
+		expectErr string
+	}{
+		{
+			name: "valid",
+			log:  string(content),
+		},
+		{
+			name:      "empty",
+			expectErr: "no audit records found",
+		},
+		{
+			name:      "malformed record",
+			log:       lines[0] + "{\n",
+			expectErr: "line 2: malformed audit record: unexpected EOF",
+		},
+		{
+			name:      "modified record",
+			log:       lines[0] + strings.Replace(lines[1], `"count":1`, `"count":5`, 1) + lines[2],
+			expectErr: "line 2: record hash does not match its content",
+		},
+		{
+			name:      "removed record",
+			log:       lines[0] + lines[2],
+			expectErr: "line 2: record does not chain to the previous record (seq 1)",
+		},
+		{
+			name:      "reordered records",
+			log:       lines[1] + lines[0],
+			expectErr: "line 2: record does not chain to the previous record (seq 2)",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := audit.VerifyChain(strings.NewReader(tt.log))
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 3, result.Records)
+			require.Equal(t, uint64(3), result.LastSeq)
+		})
+	}
+}
+
+func newRecorder(t *testing.T, log logrus.FieldLogger, sinks audit.SinksConfig) *audit.Recorder {
+	recorder, err := audit.NewRecorder(audit.RecorderConfig{
+		Sinks: sinks,
+		Log:   log,
+	})
+	require.NoError(t, err)
+	return recorder
+}
+
+func openFile(t *testing.T, path string) *os.File {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func verifyFile(t *testing.T, path string) *audit.VerifyResult {
+	result, err := audit.VerifyChain(openFile(t, path))
+	require.NoError(t, err)
+	return result
+}
+
+func readRecords(t *testing.T, path string) []map[string]interface{} {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	for decoder.More() {
+		var record map[string]interface{}
+		require.NoError(t, decoder.Decode(&record))
+		records = append(records, record)
+	}
+	return records
+}
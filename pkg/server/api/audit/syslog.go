@@ -0,0 +1,15 @@
+package audit
+
+const defaultSyslogTag = "spire-server"
+
+// SyslogSinkConfig configures a sink that sends audit records to syslog,
+// with the AUTH facility and the INFO severity.
+type SyslogSinkConfig struct {
+	// Network and Address of the syslog server. If both are empty, the
+	// local syslog server is used.
+	Network string
+	Address string
+
+	// Tag is the syslog tag of the records. Defaults to "spire-server".
+	Tag string
+}
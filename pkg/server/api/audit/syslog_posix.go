@@ -0,0 +1,37 @@
+//go:build !windows
+// +build !windows
+
+package audit
+
+import (
+	"log/syslog"
+)
+
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+func newSyslogSink(config *SyslogSinkConfig) (*syslogSink, error) {
+	tag := config.Tag
+	if tag == "" {
+		tag = defaultSyslogTag
+	}
+
+	w, err := syslog.Dial(config.Network, config.Address, syslog.LOG_AUTH|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{w: w}, nil
+}
+
+func (s *syslogSink) Name() string {
+	return "syslog"
+}
+
+func (s *syslogSink) WriteRecord(record []byte) error {
+	return s.w.Info(string(record))
+}
+
+func (s *syslogSink) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package audit
+
+import (
+	"errors"
+)
+
+type syslogSink struct {
+	Sink
+}
+
+func newSyslogSink(*SyslogSinkConfig) (*syslogSink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
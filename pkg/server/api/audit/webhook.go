@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+const (
+	defaultWebhookTimeout = 5 * time.Second
+
+	// webhookQueueSize is the number of records that can be waiting to be
+	// delivered before new records are dropped.
+	webhookQueueSize = 1024
+)
+
+// WebhookSinkConfig configures a sink that delivers each audit record to an
+// HTTP endpoint, as the JSON body of a POST request.
+type WebhookSinkConfig struct {
+	// URL of the endpoint.
+	URL string
+
+	// Timeout of each request. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// webhookSink delivers records in the background, so slow or unavailable
+// endpoints do not delay API calls. Records that fail to be delivered are
+// logged and dropped.
+type webhookSink struct {
+	log    logrus.FieldLogger
+	url    string
+	client *http.Client
+
+	records chan []byte
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newWebhookSink(config *WebhookSinkConfig, log logrus.FieldLogger) (*webhookSink, error) {
+	u, err := url.Parse(config.URL)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	case u.Scheme != "https" && u.Scheme != "http":
+		return nil, fmt.Errorf("invalid URL %q: scheme must be http or https", config.URL)
+	case u.Host == "":
+		return nil, fmt.Errorf("invalid URL %q: host is required", config.URL)
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	if timeout < 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+
+	s := &webhookSink{
+		log:     log.WithField(telemetry.URL, config.URL),
+		url:     config.URL,
+		client:  &http.Client{Timeout: timeout},
+		records: make(chan []byte, webhookQueueSize),
+	}
+	s.wg.Add(1)
+	go s.deliver()
+	return s, nil
+}
+
+func (s *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *webhookSink) WriteRecord(record []byte) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return errors.New("sink is closed")
+	}
+
+	select {
+	case s.records <- record:
+		return nil
+	default:
+		return errors.New("delivery queue is full; record dropped")
+	}
+}
+
+// Close stops accepting records and waits for the queued records to be
+// delivered.
+func (s *webhookSink) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.records)
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return nil
+}
+
+func (s *webhookSink) deliver() {
+	defer s.wg.Done()
+	for record := range s.records {
+		if err := s.post(record); err != nil {
+			s.log.WithError(err).Error("Failed to deliver audit record to webhook")
+		}
+	}
+}
+
+func (s *webhookSink) post(record []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(record))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -517,7 +517,7 @@ func (s *Service) deleteFederatedBundle(ctx context.Context, log logrus.FieldLog
 		}
 	}
 
-	err = s.ds.DeleteBundle(ctx, td.IDString(), mode)
+	err = s.ds.DeleteBundle(api.WithChangeAuthor(ctx), td.IDString(), mode)
 
 	code := status.Code(err)
 	switch code {
@@ -0,0 +1,104 @@
+package diagnostics
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/spiffe/spire/pkg/common/diagnostics"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+)
+
+// CAJournal provides the entries of the CA journal.
+type CAJournal interface {
+	JournalEntries() *ca.JournalEntries
+}
+
+// Config is the service configuration.
+type Config struct {
+	Metrics   telemetry.Metrics
+	CAJournal CAJournal
+}
+
+// Service implements the v1 diagnostics service.
+type Service struct {
+	diagnosticsv1.UnsafeDiagnosticsServer
+
+	metrics   telemetry.Metrics
+	caJournal CAJournal
+}
+
+// New creates a new diagnostics service.
+func New(config Config) *Service {
+	return &Service{
+		metrics:   config.Metrics,
+		caJournal: config.CAJournal,
+	}
+}
+
+// RegisterService registers the diagnostics service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	diagnosticsv1.RegisterDiagnosticsServer(s, service)
+}
+
+// GetDiagnostics gets the runtime diagnostics of the server.
+func (s *Service) GetDiagnostics(ctx context.Context, req *diagnosticsv1.GetDiagnosticsRequest) (*diagnosticsv1.GetDiagnosticsResponse, error) {
+	log := rpccontext.Logger(ctx)
+
+	goroutineProfile, err := diagnostics.GoroutineProfile()
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get goroutine profile", err)
+	}
+
+	inmemMetrics, err := telemetry.InmemMetrics(s.metrics)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to get in-memory metrics", err)
+	}
+
+	caJournal, err := summarizeCAJournal(s.caJournal.JournalEntries())
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to summarize CA journal", err)
+	}
+
+	return &diagnosticsv1.GetDiagnosticsResponse{
+		Version:          version.Version(),
+		GoroutineProfile: goroutineProfile,
+		InmemMetrics:     inmemMetrics,
+		CaJournal:        caJournal,
+	}, nil
+}
+
+// summarizeCAJournal summarizes the journal entries, leaving out the
+// certificates and public keys.
+func summarizeCAJournal(entries *ca.JournalEntries) (*diagnosticsv1.CAJournal, error) {
+	summary := &diagnosticsv1.CAJournal{}
+	for _, entry := range entries.X509CAs {
+		cert, err := x509.ParseCertificate(entry.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		summary.X509Cas = append(summary.X509Cas, &diagnosticsv1.X509CA{
+			SlotId:         entry.SlotId,
+			IssuedAt:       entry.IssuedAt,
+			NotAfter:       cert.NotAfter.Unix(),
+			Subject:        cert.Subject.String(),
+			UpstreamSigned: len(entry.UpstreamChain) > 0,
+		})
+	}
+	for _, entry := range entries.JwtKeys {
+		summary.JwtKeys = append(summary.JwtKeys, &diagnosticsv1.JWTKey{
+			SlotId:   entry.SlotId,
+			IssuedAt: entry.IssuedAt,
+			NotAfter: entry.NotAfter,
+			Kid:      entry.Kid,
+		})
+	}
+	return summary, nil
+}
@@ -0,0 +1,187 @@
+package diagnostics_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/pkg/server/api/diagnostics/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testca"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+)
+
+var (
+	ctx = context.Background()
+	td  = spiffeid.RequireTrustDomainFromString("example.org")
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	rootCA := testca.New(t, td)
+	intermediateCA := rootCA.ChildCA()
+	issuedAt := time.Unix(1666000000, 0)
+
+	test := setupServiceTest(t, &ca.JournalEntries{
+		X509CAs: []*ca.X509CAEntry{
+			{
+				SlotId:      "A",
+				IssuedAt:    issuedAt.Unix(),
+				Certificate: rootCA.X509Authorities()[0].Raw,
+			},
+			{
+				SlotId:        "B",
+				IssuedAt:      issuedAt.Unix() + 1,
+				Certificate:   intermediateCA.X509Authorities()[0].Raw,
+				UpstreamChain: [][]byte{rootCA.X509Authorities()[0].Raw},
+			},
+		},
+		JwtKeys: []*ca.JWTKeyEntry{
+			{
+				SlotId:    "A",
+				IssuedAt:  issuedAt.Unix(),
+				NotAfter:  issuedAt.Add(time.Hour).Unix(),
+				Kid:       "kid",
+				PublicKey: []byte("public key"),
+			},
+		},
+	})
+
+	test.metrics.IncrCounter([]string{"some", "counter"}, 1)
+
+	resp, err := test.client.GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, version.Version(), resp.Version)
+	assert.Contains(t, string(resp.GoroutineProfile), "goroutine")
+
+	var summary struct {
+		Counters []struct {
+			Name string
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.InmemMetrics, &summary))
+	require.Len(t, summary.Counters, 1)
+	assert.Equal(t, "spire_server.some.counter", summary.Counters[0].Name)
+
+	spiretest.AssertProtoEqual(t, &diagnosticsv1.CAJournal{
+		X509Cas: []*diagnosticsv1.X509CA{
+			{
+				SlotId:   "A",
+				IssuedAt: issuedAt.Unix(),
+				NotAfter: rootCA.X509Authorities()[0].NotAfter.Unix(),
+				Subject:  rootCA.X509Authorities()[0].Subject.String(),
+			},
+			{
+				SlotId:         "B",
+				IssuedAt:       issuedAt.Unix() + 1,
+				NotAfter:       intermediateCA.X509Authorities()[0].NotAfter.Unix(),
+				Subject:        intermediateCA.X509Authorities()[0].Subject.String(),
+				UpstreamSigned: true,
+			},
+		},
+		JwtKeys: []*diagnosticsv1.JWTKey{
+			{
+				SlotId:   "A",
+				IssuedAt: issuedAt.Unix(),
+				NotAfter: issuedAt.Add(time.Hour).Unix(),
+				Kid:      "kid",
+			},
+		},
+	}, resp.CaJournal)
+}
+
+func TestGetDiagnosticsWithoutInmemMetrics(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	service := diagnostics.New(diagnostics.Config{
+		Metrics:   telemetry.Blackhole{},
+		CAJournal: fakeCAJournal{entries: &ca.JournalEntries{}},
+	})
+
+	resp, err := service.GetDiagnostics(rpccontext.WithLogger(ctx, log), &diagnosticsv1.GetDiagnosticsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.InmemMetrics)
+	spiretest.AssertProtoEqual(t, &diagnosticsv1.CAJournal{}, resp.CaJournal)
+}
+
+func TestGetDiagnosticsWithInvalidJournal(t *testing.T) {
+	test := setupServiceTest(t, &ca.JournalEntries{
+		X509CAs: []*ca.X509CAEntry{
+			{
+				SlotId:      "A",
+				Certificate: []byte("not a certificate"),
+			},
+		},
+	})
+
+	resp, err := test.client.GetDiagnostics(ctx, &diagnosticsv1.GetDiagnosticsRequest{})
+	spiretest.RequireGRPCStatusHasPrefix(t, err, codes.Internal, "failed to summarize CA journal: x509: malformed certificate")
+	require.Nil(t, resp)
+	require.Len(t, test.logHook.AllEntries(), 1)
+	entry := test.logHook.LastEntry()
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "Failed to summarize CA journal", entry.Message)
+}
+
+type serviceTest struct {
+	client  diagnosticsv1.DiagnosticsClient
+	metrics *telemetry.MetricsImpl
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T, entries *ca.JournalEntries) *serviceTest {
+	log, logHook := test.NewNullLogger()
+	metrics, err := telemetry.NewMetrics(&telemetry.MetricsConfig{
+		Logger:      log,
+		ServiceName: "spire_server",
+		FileConfig:  telemetry.FileConfig{InMem: &telemetry.InMem{}},
+	})
+	require.NoError(t, err)
+
+	service := diagnostics.New(diagnostics.Config{
+		Metrics:   metrics,
+		CAJournal: fakeCAJournal{entries: entries},
+	})
+
+	registerFn := func(s *grpc.Server) {
+		diagnostics.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		return rpccontext.WithLogger(ctx, log), nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(ppMiddleware)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, _ := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	return &serviceTest{
+		client:  diagnosticsv1.NewDiagnosticsClient(conn),
+		metrics: metrics,
+		logHook: logHook,
+	}
+}
+
+type fakeCAJournal struct {
+	entries *ca.JournalEntries
+}
+
+func (j fakeCAJournal) JournalEntries() *ca.JournalEntries {
+	return j.entries
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 )
@@ -193,3 +196,63 @@ func ProtoToRegistrationEntryWithMask(ctx context.Context, td spiffeid.TrustDoma
 		JwtSvidTtl:     jwtSvidTTL,
 	}, nil
 }
+
+// CheckCallerEntryScope verifies that a caller restricted to an entry scope,
+// i.e. a caller authorized through the entry-writer role, can manage the
+// entry. Such callers cannot manage admin or downstream entries, entries
+// whose SPIFFE ID is granted privileges by the server configuration, entries
+// bound to roles, or entries sharing the SPIFFE ID of any of those, since
+// callers are authorized by the entries matching their SPIFFE ID and it would
+// allow them to obtain more privileges than the role grants.
+func CheckCallerEntryScope(ctx context.Context, ds datastore.DataStore, entry *common.RegistrationEntry) error {
+	scope, ok := rpccontext.CallerEntryScope(ctx)
+	if !ok {
+		return nil
+	}
+
+	if entry.Admin || entry.Downstream {
+		return errors.New("admin and downstream entries can only be managed by admins")
+	}
+
+	if _, ok := scope.PrivilegedEntryIDs[entry.EntryId]; ok && entry.EntryId != "" {
+		return fmt.Errorf("entry %q is bound to a role and can only be managed by admins", entry.EntryId)
+	}
+
+	id, err := spiffeid.FromString(entry.SpiffeId)
+	if err != nil {
+		return err
+	}
+	if _, ok := scope.PrivilegedIDs[id]; ok {
+		return fmt.Errorf("SPIFFE ID %q is granted privileges by the server configuration and can only be managed by admins", id)
+	}
+	if !authpolicy.PathInScope(id.Path(), scope.PathPrefixes) {
+		return fmt.Errorf("SPIFFE ID %q is outside of the caller scope", id)
+	}
+
+	resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySpiffeID: id.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list entries with SPIFFE ID %q: %w", id, err)
+	}
+	for _, sibling := range resp.Entries {
+		_, bound := scope.PrivilegedEntryIDs[sibling.EntryId]
+		if sibling.Admin || sibling.Downstream || bound {
+			return fmt.Errorf("SPIFFE ID %q is used by a privileged entry and can only be managed by admins", id)
+		}
+	}
+	return nil
+}
+
+// WithChangeAuthor returns a context that attributes the changes made to
+// registration entries in the datastore to the caller: its SPIFFE ID, or
+// "local" for callers of the local API.
+func WithChangeAuthor(ctx context.Context) context.Context {
+	if id, ok := rpccontext.CallerID(ctx); ok {
+		return datastore.WithChangeAuthor(ctx, id.String())
+	}
+	if rpccontext.CallerIsLocal(ctx) {
+		return datastore.WithChangeAuthor(ctx, "local")
+	}
+	return ctx
+}
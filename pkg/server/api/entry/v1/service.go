@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // Config defines the service configuration.
@@ -206,8 +207,14 @@ func (s *Service) createEntry(ctx context.Context, e *types.Entry, outputMask *t
 
 	log = log.WithField(telemetry.SPIFFEID, cEntry.SpiffeId)
 
+	if err := api.CheckCallerEntryScope(ctx, s.ds, cEntry); err != nil {
+		return &entryv1.BatchCreateEntryResponse_Result{
+			Status: api.MakeStatus(log, codes.PermissionDenied, "caller is not authorized to create entry", err),
+		}
+	}
+
 	resultStatus := api.OK()
-	regEntry, existing, err := s.ds.CreateOrReturnRegistrationEntry(ctx, cEntry)
+	regEntry, existing, err := s.ds.CreateOrReturnRegistrationEntry(api.WithChangeAuthor(ctx), cEntry)
 	switch {
 	case err != nil:
 		return &entryv1.BatchCreateEntryResponse_Result{
@@ -277,7 +284,29 @@ func (s *Service) deleteEntry(ctx context.Context, id string) *entryv1.BatchDele
 
 	log = log.WithField(telemetry.RegistrationID, id)
 
-	_, err := s.ds.DeleteRegistrationEntry(ctx, id)
+	if _, ok := rpccontext.CallerEntryScope(ctx); ok {
+		existing, err := s.ds.FetchRegistrationEntry(ctx, id)
+		switch {
+		case err != nil:
+			return &entryv1.BatchDeleteEntryResponse_Result{
+				Id:     id,
+				Status: api.MakeStatus(log, codes.Internal, "failed to fetch entry", err),
+			}
+		case existing == nil:
+			return &entryv1.BatchDeleteEntryResponse_Result{
+				Id:     id,
+				Status: api.MakeStatus(log, codes.NotFound, "entry not found", nil),
+			}
+		}
+		if err := api.CheckCallerEntryScope(ctx, s.ds, existing); err != nil {
+			return &entryv1.BatchDeleteEntryResponse_Result{
+				Id:     id,
+				Status: api.MakeStatus(log, codes.PermissionDenied, "caller is not authorized to delete entry", err),
+			}
+		}
+	}
+
+	_, err := s.ds.DeleteRegistrationEntry(api.WithChangeAuthor(ctx), id)
 	switch status.Code(err) {
 	case codes.OK:
 		return &entryv1.BatchDeleteEntryResponse_Result{
@@ -398,6 +427,14 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 		}
 	}
 
+	if _, ok := rpccontext.CallerEntryScope(ctx); ok {
+		if resultStatus := s.checkCallerCanUpdateEntry(ctx, log, convEntry, inputMask); resultStatus != nil {
+			return &entryv1.BatchUpdateEntryResponse_Result{
+				Status: resultStatus,
+			}
+		}
+	}
+
 	var mask *common.RegistrationEntryMask
 	if inputMask != nil {
 		mask = &common.RegistrationEntryMask{
@@ -414,7 +451,7 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 			JwtSvidTtl:    inputMask.JwtSvidTtl,
 		}
 	}
-	dsEntry, err := s.ds.UpdateRegistrationEntry(ctx, convEntry, mask)
+	dsEntry, err := s.ds.UpdateRegistrationEntry(api.WithChangeAuthor(ctx), convEntry, mask)
 	if err != nil {
 		return &entryv1.BatchUpdateEntryResponse_Result{
 			Status: api.MakeStatus(log, codes.Internal, "failed to update entry", err),
@@ -436,6 +473,37 @@ func (s *Service) updateEntry(ctx context.Context, e *types.Entry, inputMask *ty
 	}
 }
 
+// checkCallerCanUpdateEntry verifies that a caller restricted to an entry
+// scope is allowed to manage the entry both before and after the update.
+func (s *Service) checkCallerCanUpdateEntry(ctx context.Context, log logrus.FieldLogger, entry *common.RegistrationEntry, inputMask *types.EntryMask) *types.Status {
+	existing, err := s.ds.FetchRegistrationEntry(ctx, entry.EntryId)
+	switch {
+	case err != nil:
+		return api.MakeStatus(log, codes.Internal, "failed to fetch entry", err)
+	case existing == nil:
+		return api.MakeStatus(log, codes.NotFound, "entry not found", nil)
+	}
+	if err := api.CheckCallerEntryScope(ctx, s.ds, existing); err != nil {
+		return api.MakeStatus(log, codes.PermissionDenied, "caller is not authorized to update entry", err)
+	}
+
+	updated := proto.Clone(existing).(*common.RegistrationEntry)
+	if inputMask == nil || inputMask.SpiffeId {
+		updated.SpiffeId = entry.SpiffeId
+	}
+	if inputMask == nil || inputMask.Admin {
+		updated.Admin = entry.Admin
+	}
+	if inputMask == nil || inputMask.Downstream {
+		updated.Downstream = entry.Downstream
+	}
+	if err := api.CheckCallerEntryScope(ctx, s.ds, updated); err != nil {
+		return api.MakeStatus(log, codes.PermissionDenied, "caller is not authorized to update entry", err)
+	}
+
+	return nil
+}
+
 func fieldsFromEntryProto(ctx context.Context, proto *types.Entry, inputMask *types.EntryMask) logrus.Fields {
 	fields := logrus.Fields{}
 
@@ -2603,6 +2603,7 @@ type serviceTest struct {
 	ds           datastore.DataStore
 	logHook      *test.Hook
 	withCallerID bool
+	entryScope   *rpccontext.EntryScope
 }
 
 func (s *serviceTest) Cleanup() {
@@ -2633,6 +2634,9 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 		if test.withCallerID {
 			ctx = rpccontext.WithCallerID(ctx, agentID)
 		}
+		if test.entryScope != nil {
+			ctx = rpccontext.WithCallerEntryScope(ctx, *test.entryScope)
+		}
 		return ctx, nil
 	})
 
@@ -2653,6 +2657,278 @@ func setupServiceTest(t *testing.T, ds datastore.DataStore) *serviceTest {
 	return test
 }
 
+func TestBatchEntryWithCallerEntryScope(t *testing.T) {
+	parentID := spiffeid.RequireFromPath(td, "/host").String()
+	newEntry := func(path string, admin bool) *common.RegistrationEntry {
+		return &common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeid.RequireFromPath(td, path).String(),
+			Selectors: []*common.Selector{{Type: "not", Value: "relevant"}},
+			Admin:     admin,
+		}
+	}
+	newProto := func(path string, admin bool) *types.Entry {
+		return &types.Entry{
+			ParentId:  &types.SPIFFEID{TrustDomain: td.String(), Path: "/host"},
+			SpiffeId:  &types.SPIFFEID{TrustDomain: td.String(), Path: path},
+			Selectors: []*types.Selector{{Type: "not", Value: "relevant"}},
+			Admin:     admin,
+		}
+	}
+
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+	test.entryScope = &rpccontext.EntryScope{PathPrefixes: []string{"/ci"}}
+
+	entries := createTestEntries(t, ds,
+		newEntry("/ci/build", false),
+		newEntry("/ci/deploy", false),
+		newEntry("/ci/admin", true),
+		newEntry("/prod", false),
+	)
+	build := entries[spiffeid.RequireFromPath(td, "/ci/build").String()]
+	deploy := entries[spiffeid.RequireFromPath(td, "/ci/deploy").String()]
+	admin := entries[spiffeid.RequireFromPath(td, "/ci/admin").String()]
+	prod := entries[spiffeid.RequireFromPath(td, "/prod").String()]
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := test.client.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{
+			Entries: []*types.Entry{
+				newProto("/ci/test", false),
+				newProto("/cio", false),
+				newProto("/ci/test-admin", true),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, int32(codes.OK), resp.Results[0].Status.Code)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: SPIFFE ID "spiffe://example.org/cio" is outside of the caller scope`,
+		}, resp.Results[1].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: "caller is not authorized to create entry: admin and downstream entries can only be managed by admins",
+		}, resp.Results[2].Status)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: build.EntryId, DnsNames: []string{"build.ci"}},
+				{Id: deploy.EntryId, Admin: true},
+				{Id: prod.EntryId, DnsNames: []string{"prod"}},
+				{Id: admin.EntryId, DnsNames: []string{"admin.ci"}},
+				{Id: "not-found"},
+			},
+			InputMask: &types.EntryMask{DnsNames: true, Admin: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 5)
+		assert.Equal(t, int32(codes.OK), resp.Results[0].Status.Code)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: "caller is not authorized to update entry: admin and downstream entries can only be managed by admins",
+		}, resp.Results[1].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to update entry: SPIFFE ID "spiffe://example.org/prod" is outside of the caller scope`,
+		}, resp.Results[2].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: "caller is not authorized to update entry: admin and downstream entries can only be managed by admins",
+		}, resp.Results[3].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.NotFound),
+			Message: "entry not found",
+		}, resp.Results[4].Status)
+
+		resp, err = test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: build.EntryId, SpiffeId: &types.SPIFFEID{TrustDomain: td.String(), Path: "/prod/build"}},
+			},
+			InputMask: &types.EntryMask{SpiffeId: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to update entry: SPIFFE ID "spiffe://example.org/prod/build" is outside of the caller scope`,
+		}, resp.Results[0].Status)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := test.client.BatchDeleteEntry(ctx, &entryv1.BatchDeleteEntryRequest{
+			Ids: []string{deploy.EntryId, prod.EntryId, "not-found"},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, int32(codes.OK), resp.Results[0].Status.Code)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to delete entry: SPIFFE ID "spiffe://example.org/prod" is outside of the caller scope`,
+		}, resp.Results[1].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.NotFound),
+			Message: "entry not found",
+		}, resp.Results[2].Status)
+	})
+}
+
+func TestBatchEntryWithPrivilegedEntries(t *testing.T) {
+	parentID := spiffeid.RequireFromPath(td, "/host").String()
+	adminID := spiffeid.RequireFromPath(td, "/admin")
+	roleID := spiffeid.RequireFromPath(td, "/role")
+
+	ds := fakedatastore.New(t)
+	test := setupServiceTest(t, ds)
+	defer test.Cleanup()
+
+	entries := createTestEntries(t, ds,
+		&common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeid.RequireFromPath(td, "/bound").String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		&common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeid.RequireFromPath(td, "/workload").String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+		},
+		&common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  adminID.String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1002"}},
+		},
+		&common.RegistrationEntry{
+			ParentId:  parentID,
+			SpiffeId:  spiffeid.RequireFromPath(td, "/admin-entry").String(),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1003"}},
+			Admin:     true,
+		},
+	)
+	bound := entries[spiffeid.RequireFromPath(td, "/bound").String()]
+	workload := entries[spiffeid.RequireFromPath(td, "/workload").String()]
+	admin := entries[adminID.String()]
+
+	test.entryScope = &rpccontext.EntryScope{
+		PathPrefixes: []string{"/"},
+		PrivilegedIDs: map[spiffeid.ID]struct{}{
+			adminID: {},
+			roleID:  {},
+		},
+		PrivilegedEntryIDs: map[string]struct{}{
+			bound.EntryId: {},
+			"bound-later": {},
+		},
+	}
+
+	newProto := func(id, path string) *types.Entry {
+		return &types.Entry{
+			Id:        id,
+			ParentId:  &types.SPIFFEID{TrustDomain: td.String(), Path: "/host"},
+			SpiffeId:  &types.SPIFFEID{TrustDomain: td.String(), Path: path},
+			Selectors: []*types.Selector{{Type: "unix", Value: "uid:2000"}},
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := test.client.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{
+			Entries: []*types.Entry{
+				newProto("", "/admin"),
+				newProto("", "/role"),
+				newProto("bound-later", "/other"),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: SPIFFE ID "spiffe://example.org/admin" is granted privileges by the server configuration and can only be managed by admins`,
+		}, resp.Results[0].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: SPIFFE ID "spiffe://example.org/role" is granted privileges by the server configuration and can only be managed by admins`,
+		}, resp.Results[1].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: entry "bound-later" is bound to a role and can only be managed by admins`,
+		}, resp.Results[2].Status)
+	})
+
+	t.Run("create sibling of privileged entry", func(t *testing.T) {
+		resp, err := test.client.BatchCreateEntry(ctx, &entryv1.BatchCreateEntryRequest{
+			Entries: []*types.Entry{
+				newProto("", "/admin-entry"),
+				newProto("", "/bound"),
+				newProto("", "/workload"),
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: SPIFFE ID "spiffe://example.org/admin-entry" is used by a privileged entry and can only be managed by admins`,
+		}, resp.Results[0].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to create entry: SPIFFE ID "spiffe://example.org/bound" is used by a privileged entry and can only be managed by admins`,
+		}, resp.Results[1].Status)
+		assert.Equal(t, int32(codes.OK), resp.Results[2].Status.Code)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		resp, err := test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: bound.EntryId, Selectors: []*types.Selector{{Type: "unix", Value: "uid:2000"}}},
+				{Id: admin.EntryId, Selectors: []*types.Selector{{Type: "unix", Value: "uid:2000"}}},
+			},
+			InputMask: &types.EntryMask{Selectors: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: fmt.Sprintf("caller is not authorized to update entry: entry %q is bound to a role and can only be managed by admins", bound.EntryId),
+		}, resp.Results[0].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to update entry: SPIFFE ID "spiffe://example.org/admin" is granted privileges by the server configuration and can only be managed by admins`,
+		}, resp.Results[1].Status)
+
+		resp, err = test.client.BatchUpdateEntry(ctx, &entryv1.BatchUpdateEntryRequest{
+			Entries: []*types.Entry{
+				{Id: workload.EntryId, SpiffeId: &types.SPIFFEID{TrustDomain: td.String(), Path: "/role"}},
+			},
+			InputMask: &types.EntryMask{SpiffeId: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 1)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to update entry: SPIFFE ID "spiffe://example.org/role" is granted privileges by the server configuration and can only be managed by admins`,
+		}, resp.Results[0].Status)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		resp, err := test.client.BatchDeleteEntry(ctx, &entryv1.BatchDeleteEntryRequest{
+			Ids: []string{bound.EntryId, admin.EntryId, workload.EntryId},
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 3)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: fmt.Sprintf("caller is not authorized to delete entry: entry %q is bound to a role and can only be managed by admins", bound.EntryId),
+		}, resp.Results[0].Status)
+		spiretest.AssertProtoEqual(t, &types.Status{
+			Code:    int32(codes.PermissionDenied),
+			Message: `caller is not authorized to delete entry: SPIFFE ID "spiffe://example.org/admin" is granted privileges by the server configuration and can only be managed by admins`,
+		}, resp.Results[1].Status)
+		assert.Equal(t, int32(codes.OK), resp.Results[2].Status.Code)
+	})
+}
+
 func TestBatchUpdateEntry(t *testing.T) {
 	parent := &types.SPIFFEID{TrustDomain: "example.org", Path: "/parent"}
 	entry1SpiffeID := &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"}
@@ -0,0 +1,168 @@
+package entryhistory
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+)
+
+// Config is the service configuration.
+type Config struct {
+	DataStore datastore.DataStore
+}
+
+// Service implements the v1 entry history service.
+type Service struct {
+	entryhistoryv1.UnsafeEntryHistoryServer
+
+	ds datastore.DataStore
+}
+
+// New creates a new entry history service.
+func New(config Config) *Service {
+	return &Service{
+		ds: config.DataStore,
+	}
+}
+
+// RegisterService registers the entry history service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	entryhistoryv1.RegisterEntryHistoryServer(s, service)
+}
+
+// GetEntryHistory returns the revisions of a registration entry.
+func (s *Service) GetEntryHistory(ctx context.Context, req *entryhistoryv1.GetEntryHistoryRequest) (*entryhistoryv1.GetEntryHistoryResponse, error) {
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.RegistrationID: req.EntryId})
+
+	log := rpccontext.Logger(ctx)
+
+	if req.EntryId == "" {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "missing ID", nil)
+	}
+	log = log.WithField(telemetry.RegistrationID, req.EntryId)
+
+	revisions, err := s.ds.ListRegistrationEntryRevisions(ctx, req.EntryId)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list entry revisions", err)
+	}
+
+	resp := &entryhistoryv1.GetEntryHistoryResponse{
+		Revisions: make([]*entryhistoryv1.EntryRevision, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		pbRevision, err := revisionToProto(revision)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to convert entry revision", err)
+		}
+		resp.Revisions = append(resp.Revisions, pbRevision)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return resp, nil
+}
+
+// RestoreEntry restores a registration entry to the state it had at the
+// given revision.
+func (s *Service) RestoreEntry(ctx context.Context, req *entryhistoryv1.RestoreEntryRequest) (*entryhistoryv1.RestoreEntryResponse, error) {
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{
+		telemetry.RegistrationID: req.EntryId,
+		telemetry.RevisionNumber: req.Revision,
+	})
+
+	log := rpccontext.Logger(ctx)
+
+	switch {
+	case req.EntryId == "":
+		return nil, api.MakeErr(log, codes.InvalidArgument, "missing ID", nil)
+	case req.Revision <= 0:
+		return nil, api.MakeErr(log, codes.InvalidArgument, "revision must be greater than zero", nil)
+	}
+	log = log.WithFields(logrus.Fields{
+		telemetry.RegistrationID: req.EntryId,
+		telemetry.RevisionNumber: req.Revision,
+	})
+
+	revision, err := s.ds.FetchRegistrationEntryRevision(ctx, req.EntryId, req.Revision)
+	switch {
+	case err != nil:
+		return nil, api.MakeErr(log, codes.Internal, "failed to fetch entry revision", err)
+	case revision == nil:
+		return nil, api.MakeErr(log, codes.NotFound, "entry revision not found", nil)
+	}
+
+	// Callers restricted to an entry scope can only restore entries that
+	// are, and would remain, within their scope.
+	if _, ok := rpccontext.CallerEntryScope(ctx); ok {
+		if err := api.CheckCallerEntryScope(ctx, s.ds, revision.Entry); err != nil {
+			return nil, api.MakeErr(log, codes.PermissionDenied, "caller is not authorized to restore entry", err)
+		}
+		existing, err := s.ds.FetchRegistrationEntry(ctx, req.EntryId)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to fetch entry", err)
+		}
+		if existing != nil {
+			if err := api.CheckCallerEntryScope(ctx, s.ds, existing); err != nil {
+				return nil, api.MakeErr(log, codes.PermissionDenied, "caller is not authorized to restore entry", err)
+			}
+		}
+	}
+
+	entry, err := s.ds.RestoreRegistrationEntryRevision(api.WithChangeAuthor(ctx), req.EntryId, req.Revision)
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return nil, api.MakeErr(log, codes.NotFound, "entry revision not found", err)
+	case codes.AlreadyExists:
+		return nil, api.MakeErr(log, codes.AlreadyExists, "failed to restore entry", err)
+	default:
+		return nil, api.MakeErr(log, codes.Internal, "failed to restore entry", err)
+	}
+
+	pbEntry, err := api.RegistrationEntryToProto(entry)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to convert entry", err)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return &entryhistoryv1.RestoreEntryResponse{
+		Entry: pbEntry,
+	}, nil
+}
+
+func revisionToProto(revision *datastore.RegistrationEntryRevision) (*entryhistoryv1.EntryRevision, error) {
+	entry, err := api.RegistrationEntryToProto(revision.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entryhistoryv1.EntryRevision{
+		Revision:      revision.Revision,
+		ChangeType:    changeTypeToProto(revision.ChangeType),
+		ChangedBy:     revision.ChangedBy,
+		ChangedAt:     revision.ChangedAt.Unix(),
+		ChangedFields: revision.ChangedFields,
+		Entry:         entry,
+	}, nil
+}
+
+func changeTypeToProto(changeType datastore.EventType) entryhistoryv1.ChangeType {
+	switch changeType {
+	case datastore.EventCreated:
+		return entryhistoryv1.ChangeType_CREATED
+	case datastore.EventUpdated:
+		return entryhistoryv1.ChangeType_UPDATED
+	case datastore.EventDeleted:
+		return entryhistoryv1.ChangeType_DELETED
+	default:
+		return entryhistoryv1.ChangeType_CHANGE_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,336 @@
+package entryhistory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/entryhistory/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+)
+
+var (
+	ctx      = context.Background()
+	callerID = spiffeid.RequireFromString("spiffe://example.org/admin")
+)
+
+func TestGetEntryHistory(t *testing.T) {
+	test := setupServiceTest(t, nil)
+	defer test.Cleanup()
+
+	entry, err := test.ds.CreateRegistrationEntry(datastore.WithChangeAuthor(ctx, callerID.String()), &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/agent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	entry.DnsNames = []string{"example.org"}
+	_, err = test.ds.UpdateRegistrationEntry(ctx, entry, nil)
+	require.NoError(t, err)
+
+	resp, err := test.client.GetEntryHistory(ctx, &entryhistoryv1.GetEntryHistoryRequest{EntryId: entry.EntryId})
+	require.NoError(t, err)
+	require.Len(t, resp.Revisions, 2)
+	for _, revision := range resp.Revisions {
+		require.NotZero(t, revision.ChangedAt)
+		revision.ChangedAt = 0
+	}
+	spiretest.RequireProtoListEqual(t, []*entryhistoryv1.EntryRevision{
+		{
+			Revision:   1,
+			ChangeType: entryhistoryv1.ChangeType_CREATED,
+			ChangedBy:  callerID.String(),
+			Entry: &types.Entry{
+				Id:            entry.EntryId,
+				ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+				SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				DnsNames:      []string{},
+				FederatesWith: []string{},
+			},
+		},
+		{
+			Revision:      2,
+			ChangeType:    entryhistoryv1.ChangeType_UPDATED,
+			ChangedFields: []string{"dns_names"},
+			Entry: &types.Entry{
+				Id:             entry.EntryId,
+				ParentId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+				SpiffeId:       &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+				Selectors:      []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+				DnsNames:       []string{"example.org"},
+				FederatesWith:  []string{},
+				RevisionNumber: 1,
+			},
+		},
+	}, resp.Revisions)
+
+	// Entries without history have no revisions
+	resp, err = test.client.GetEntryHistory(ctx, &entryhistoryv1.GetEntryHistoryRequest{EntryId: "unknown"})
+	require.NoError(t, err)
+	require.Empty(t, resp.Revisions)
+}
+
+func TestGetEntryHistoryErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		req        *entryhistoryv1.GetEntryHistoryRequest
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "missing ID",
+			req:        &entryhistoryv1.GetEntryHistoryRequest{},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "missing ID",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: missing ID",
+				},
+			},
+		},
+		{
+			name:       "failed to list entry revisions",
+			req:        &entryhistoryv1.GetEntryHistoryRequest{EntryId: "entry-id"},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list entry revisions: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list entry revisions",
+					Data: logrus.Fields{
+						telemetry.RegistrationID: "entry-id",
+						logrus.ErrorKey:          "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t, nil)
+			defer test.Cleanup()
+
+			test.ds.SetNextError(tt.dsError)
+
+			_, err := test.client.GetEntryHistory(ctx, tt.req)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+		})
+	}
+}
+
+func TestRestoreEntry(t *testing.T) {
+	test := setupServiceTest(t, nil)
+	defer test.Cleanup()
+
+	entry, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/agent",
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	_, err = test.ds.DeleteRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+
+	resp, err := test.client.RestoreEntry(ctx, &entryhistoryv1.RestoreEntryRequest{
+		EntryId:  entry.EntryId,
+		Revision: 1,
+	})
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &types.Entry{
+		Id:            entry.EntryId,
+		ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/agent"},
+		SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		DnsNames:      []string{},
+		FederatesWith: []string{},
+	}, resp.Entry)
+
+	// The restored entry exists again and the restore is attributed to the
+	// caller
+	restored, err := test.ds.FetchRegistrationEntry(ctx, entry.EntryId)
+	require.NoError(t, err)
+	require.NotNil(t, restored)
+	revisions, err := test.ds.ListRegistrationEntryRevisions(ctx, entry.EntryId)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	require.Equal(t, datastore.EventCreated, revisions[2].ChangeType)
+	require.Equal(t, callerID.String(), revisions[2].ChangedBy)
+}
+
+func TestRestoreEntryErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		req        *entryhistoryv1.RestoreEntryRequest
+		entryScope *rpccontext.EntryScope
+		similar    bool
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+	}{
+		{
+			name:       "missing ID",
+			req:        &entryhistoryv1.RestoreEntryRequest{Revision: 1},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "missing ID",
+		},
+		{
+			name:       "invalid revision",
+			req:        &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "revision must be greater than zero",
+		},
+		{
+			name:       "revision not found",
+			req:        &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 10},
+			expectCode: codes.NotFound,
+			expectMsg:  "entry revision not found",
+		},
+		{
+			name:       "failed to fetch entry revision",
+			req:        &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch entry revision: oh no",
+		},
+		{
+			name:       "entry outside of the caller scope",
+			req:        &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			entryScope: &rpccontext.EntryScope{PathPrefixes: []string{"/team-b"}},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `caller is not authorized to restore entry: SPIFFE ID "spiffe://example.org/team-a/workload" is outside of the caller scope`,
+		},
+		{
+			name: "entry with a privileged SPIFFE ID",
+			req:  &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			entryScope: &rpccontext.EntryScope{
+				PathPrefixes:  []string{"/"},
+				PrivilegedIDs: map[spiffeid.ID]struct{}{spiffeid.RequireFromString("spiffe://example.org/team-a/workload"): {}},
+			},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  `caller is not authorized to restore entry: SPIFFE ID "spiffe://example.org/team-a/workload" is granted privileges by the server configuration and can only be managed by admins`,
+		},
+		{
+			name: "entry bound to a role",
+			req:  &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			entryScope: &rpccontext.EntryScope{
+				PathPrefixes:       []string{"/"},
+				PrivilegedEntryIDs: map[string]struct{}{"entry-id": {}},
+			},
+			expectCode: codes.PermissionDenied,
+			expectMsg:  "is bound to a role and can only be managed by admins",
+		},
+		{
+			name:       "similar entry exists",
+			req:        &entryhistoryv1.RestoreEntryRequest{EntryId: "entry-id", Revision: 1},
+			similar:    true,
+			expectCode: codes.AlreadyExists,
+			expectMsg:  "failed to restore entry: datastore-sql: cannot restore registration entry; similar entry",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// The entry ID is only known once the entry is created, so the
+			// scope is resolved when the request is made.
+			var entryScope *rpccontext.EntryScope
+			if tt.entryScope != nil {
+				entryScope = &rpccontext.EntryScope{}
+			}
+			test := setupServiceTest(t, entryScope)
+			defer test.Cleanup()
+
+			entry, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/agent",
+				SpiffeId:  "spiffe://example.org/team-a/workload",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			})
+			require.NoError(t, err)
+			if tt.req.EntryId == "entry-id" {
+				tt.req.EntryId = entry.EntryId
+			}
+			if tt.entryScope != nil {
+				*entryScope = *tt.entryScope
+				if _, ok := tt.entryScope.PrivilegedEntryIDs["entry-id"]; ok {
+					entryScope.PrivilegedEntryIDs = map[string]struct{}{entry.EntryId: {}}
+				}
+			}
+			if tt.similar {
+				_, err = test.ds.DeleteRegistrationEntry(ctx, entry.EntryId)
+				require.NoError(t, err)
+				_, err = test.ds.CreateRegistrationEntry(ctx, entry)
+				require.NoError(t, err)
+			}
+
+			test.ds.SetNextError(tt.dsError)
+
+			_, err = test.client.RestoreEntry(ctx, tt.req)
+			spiretest.RequireGRPCStatusContains(t, err, tt.expectCode, tt.expectMsg)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  entryhistoryv1.EntryHistoryClient
+	ds      *fakedatastore.DataStore
+	logHook *test.Hook
+	done    func()
+}
+
+func (s *serviceTest) Cleanup() {
+	s.done()
+}
+
+func setupServiceTest(t *testing.T, entryScope *rpccontext.EntryScope) *serviceTest {
+	ds := fakedatastore.New(t)
+	service := entryhistory.New(entryhistory.Config{
+		DataStore: ds,
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s *grpc.Server) {
+		entryhistory.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		ctx = rpccontext.WithLogger(ctx, log)
+		ctx = rpccontext.WithCallerID(ctx, callerID)
+		if entryScope != nil {
+			ctx = rpccontext.WithCallerEntryScope(ctx, *entryScope)
+		}
+		return ctx, nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(ppMiddleware)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	return &serviceTest{
+		client:  entryhistoryv1.NewEntryHistoryClient(conn),
+		ds:      ds,
+		logHook: logHook,
+		done:    done,
+	}
+}
@@ -0,0 +1,448 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+)
+
+const (
+	// defaultPollInterval is how often the datastore is polled for new events
+	// when no interval is configured.
+	defaultPollInterval = time.Second
+
+	// listEventsPageSize is the maximum number of events fetched from the
+	// datastore, and sent on the stream, at once.
+	listEventsPageSize = 500
+
+	// missedEventTimeout is how long the IDs skipped while listing events
+	// are looked up again. Event IDs are assigned before the changes are
+	// committed, so when changes are made concurrently an event can become
+	// visible after events with a greater ID have been sent. IDs that never
+	// become visible, e.g. those of rolled back transactions, are given up
+	// after this timeout.
+	missedEventTimeout = 5 * time.Minute
+
+	// maxMissedEvents bounds the number of skipped IDs that are tracked per
+	// stream, since IDs can jump arbitrarily (e.g. MySQL auto-increment
+	// values are not reused after a rollback).
+	maxMissedEvents = 10000
+)
+
+// Config is the service configuration.
+type Config struct {
+	DataStore datastore.DataStore
+
+	// Clock is used to schedule polling of the datastore. Defaults to the
+	// real clock.
+	Clock clock.Clock
+
+	// PollInterval is how often the datastore is polled for new events.
+	// Defaults to one second.
+	PollInterval time.Duration
+}
+
+// Service implements the v1 event service.
+type Service struct {
+	eventv1.UnsafeEventServer
+
+	ds           datastore.DataStore
+	clk          clock.Clock
+	pollInterval time.Duration
+}
+
+// New creates a new event service.
+func New(config Config) *Service {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	return &Service{
+		ds:           config.DataStore,
+		clk:          config.Clock,
+		pollInterval: config.PollInterval,
+	}
+}
+
+// RegisterService registers the event service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	eventv1.RegisterEventServer(s, service)
+}
+
+// Watch streams the events recorded after the cursor in the request, and then
+// polls the datastore for new events until the stream is closed.
+func (s *Service) Watch(req *eventv1.WatchRequest, stream eventv1.Event_WatchServer) error {
+	ctx := stream.Context()
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.Cursor: req.Cursor})
+
+	log := rpccontext.Logger(ctx)
+
+	resourceTypes, err := resourceTypesFromProto(req.ResourceTypes)
+	if err != nil {
+		return api.MakeErr(log, codes.InvalidArgument, "invalid resource type", err)
+	}
+
+	var afterID uint
+	if req.Cursor != "" {
+		id, err := strconv.ParseUint(req.Cursor, 10, 0)
+		if err != nil {
+			return api.MakeErr(log, codes.InvalidArgument, "malformed cursor", err)
+		}
+		afterID = uint(id)
+		if err := s.checkCursorRetained(ctx, afterID); err != nil {
+			return err
+		}
+	} else {
+		afterID, err = s.ds.GetLatestEventID(ctx)
+		if err != nil {
+			return api.MakeErr(log, codes.Internal, "failed to fetch latest event", err)
+		}
+	}
+
+	rpccontext.AuditRPC(ctx)
+
+	w := &watch{
+		afterID: afterID,
+		missed:  make(map[uint]time.Time),
+	}
+	if len(resourceTypes) > 0 {
+		w.resourceTypes = make(map[datastore.EventResourceType]bool)
+		for _, resourceType := range resourceTypes {
+			w.resourceTypes[resourceType] = true
+		}
+	}
+
+	ticker := s.clk.Ticker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sendMissedEvents(ctx, stream, w); err != nil {
+			return err
+		}
+		if err := s.sendEvents(ctx, stream, w); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// watch holds the state of a Watch stream.
+type watch struct {
+	// afterID is the greatest event ID listed so far.
+	afterID uint
+
+	// missed holds the IDs lower than afterID that were skipped while
+	// listing events, along with the time they were first skipped.
+	missed map[uint]time.Time
+
+	// resourceTypes holds the resource types of the events to send. If
+	// empty, events for all resource types are sent.
+	resourceTypes map[datastore.EventResourceType]bool
+}
+
+// cursor returns the cursor to resume watching from. Missed events are
+// expected to become visible, so the cursor does not move past them; events
+// after the cursor that were already sent are sent again on resumption.
+func (w *watch) cursor() uint {
+	cursor := w.afterID
+	for id := range w.missed {
+		if id-1 < cursor {
+			cursor = id - 1
+		}
+	}
+	return cursor
+}
+
+// checkCursorRetained verifies that the events recorded after the cursor have
+// not been pruned. The datastore always retains the latest event, so if
+// there is a gap between the cursor and the oldest retained event, the
+// events in between have been pruned.
+func (s *Service) checkCursorRetained(ctx context.Context, afterID uint) error {
+	log := rpccontext.Logger(ctx)
+
+	resp, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{Limit: 1})
+	if err != nil {
+		return api.MakeErr(log, codes.Internal, "failed to list events", err)
+	}
+	if len(resp.Events) > 0 && afterID+1 < resp.Events[0].ID {
+		return api.MakeErr(log, codes.OutOfRange, "events recorded after the cursor have been pruned", nil)
+	}
+	return nil
+}
+
+// sendMissedEvents looks up again the event IDs skipped by previous polls and
+// sends the events that have become visible.
+func (s *Service) sendMissedEvents(ctx context.Context, stream eventv1.Event_WatchServer, w *watch) error {
+	log := rpccontext.Logger(ctx)
+
+	now := s.clk.Now()
+	ids := make([]uint, 0, len(w.missed))
+	for id, missedAt := range w.missed {
+		if now.Sub(missedAt) >= missedEventTimeout {
+			delete(w.missed, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > listEventsPageSize {
+			n = listEventsPageSize
+		}
+		resp, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{ByIDs: ids[:n]})
+		if err != nil {
+			return api.MakeErr(log, codes.Internal, "failed to list events", err)
+		}
+		ids = ids[n:]
+
+		var events []*eventv1.ResourceEvent
+		for _, dsEvent := range resp.Events {
+			delete(w.missed, dsEvent.ID)
+			event, err := s.watchEventToProto(ctx, w, dsEvent)
+			if err != nil {
+				return err
+			}
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+		if err := s.send(ctx, stream, events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendEvents sends all the events recorded after the greatest event ID listed
+// so far, keeping track of the IDs skipped along the way.
+func (s *Service) sendEvents(ctx context.Context, stream eventv1.Event_WatchServer, w *watch) error {
+	log := rpccontext.Logger(ctx)
+
+	for {
+		// Events are listed for all resource types, since the IDs of the
+		// events of other types would otherwise be taken as missed.
+		resp, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{
+			AfterID: w.afterID,
+			Limit:   listEventsPageSize,
+		})
+		if err != nil {
+			return api.MakeErr(log, codes.Internal, "failed to list events", err)
+		}
+		if len(resp.Events) == 0 {
+			return nil
+		}
+
+		now := s.clk.Now()
+		var events []*eventv1.ResourceEvent
+		for _, dsEvent := range resp.Events {
+			for id := w.afterID + 1; id < dsEvent.ID && len(w.missed) < maxMissedEvents; id++ {
+				w.missed[id] = now
+			}
+			w.afterID = dsEvent.ID
+
+			event, err := s.watchEventToProto(ctx, w, dsEvent)
+			if err != nil {
+				return err
+			}
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+		if err := s.send(ctx, stream, events); err != nil {
+			return err
+		}
+
+		if len(resp.Events) < listEventsPageSize {
+			return nil
+		}
+	}
+}
+
+// watchEventToProto converts the event if its resource type is watched,
+// setting the cursor to resume watching from. It returns nil otherwise.
+func (s *Service) watchEventToProto(ctx context.Context, w *watch, dsEvent *datastore.Event) (*eventv1.ResourceEvent, error) {
+	if w.resourceTypes != nil && !w.resourceTypes[dsEvent.ResourceType] {
+		return nil, nil
+	}
+	event, err := s.eventToProto(ctx, dsEvent)
+	if err != nil {
+		return nil, api.MakeErr(rpccontext.Logger(ctx), codes.Internal, "failed to convert event", err)
+	}
+	event.Cursor = strconv.FormatUint(uint64(w.cursor()), 10)
+	return event, nil
+}
+
+func (s *Service) send(ctx context.Context, stream eventv1.Event_WatchServer, events []*eventv1.ResourceEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := stream.Send(&eventv1.WatchResponse{Events: events}); err != nil {
+		return api.MakeErr(rpccontext.Logger(ctx), codes.Internal, "failed to send response", err)
+	}
+	return nil
+}
+
+func (s *Service) eventToProto(ctx context.Context, e *datastore.Event) (*eventv1.ResourceEvent, error) {
+	event := &eventv1.ResourceEvent{
+		ResourceId: e.ResourceID,
+		CreatedAt:  e.CreatedAt.Unix(),
+	}
+
+	switch e.Type {
+	case datastore.EventCreated:
+		event.Type = eventv1.EventType_CREATED
+	case datastore.EventUpdated:
+		event.Type = eventv1.EventType_UPDATED
+	case datastore.EventDeleted:
+		event.Type = eventv1.EventType_DELETED
+	default:
+		return nil, fmt.Errorf("unknown event type %q", e.Type)
+	}
+
+	switch e.ResourceType {
+	case datastore.EntryEvent:
+		event.ResourceType = eventv1.ResourceType_ENTRY
+	case datastore.AttestedNodeEvent:
+		event.ResourceType = eventv1.ResourceType_AGENT
+	case datastore.BundleEvent:
+		event.ResourceType = eventv1.ResourceType_BUNDLE
+		// Bundles are stored by trust domain ID, but the API refers to them
+		// by trust domain name.
+		td, err := spiffeid.TrustDomainFromString(e.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle trust domain: %w", err)
+		}
+		event.ResourceId = td.String()
+	case datastore.FederationRelationshipEvent:
+		event.ResourceType = eventv1.ResourceType_FEDERATION_RELATIONSHIP
+	default:
+		return nil, fmt.Errorf("unknown event resource type %q", e.ResourceType)
+	}
+
+	if event.Type == eventv1.EventType_DELETED {
+		return event, nil
+	}
+
+	if err := s.fillResource(ctx, event, e); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// fillResource sets the current state of the resource the event refers to.
+// The resource is left unset if it no longer exists.
+func (s *Service) fillResource(ctx context.Context, event *eventv1.ResourceEvent, e *datastore.Event) error {
+	switch e.ResourceType {
+	case datastore.EntryEvent:
+		entry, err := s.ds.FetchRegistrationEntry(ctx, e.ResourceID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch entry: %w", err)
+		}
+		if entry == nil {
+			return nil
+		}
+		protoEntry, err := api.RegistrationEntryToProto(entry)
+		if err != nil {
+			return fmt.Errorf("failed to convert entry: %w", err)
+		}
+		event.Resource = &eventv1.ResourceEvent_Entry{Entry: protoEntry}
+	case datastore.AttestedNodeEvent:
+		node, err := s.ds.FetchAttestedNode(ctx, e.ResourceID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch agent: %w", err)
+		}
+		if node == nil {
+			return nil
+		}
+		selectors, err := s.ds.GetNodeSelectors(ctx, e.ResourceID, datastore.RequireCurrent)
+		if err != nil {
+			return fmt.Errorf("failed to get node selectors: %w", err)
+		}
+		operatorSelectors, err := s.ds.GetOperatorNodeSelectors(ctx, e.ResourceID, datastore.RequireCurrent)
+		if err != nil {
+			return fmt.Errorf("failed to get operator selectors: %w", err)
+		}
+		if len(operatorSelectors) > 0 {
+			selectors = selector.Dedupe(selectors, operatorSelectors)
+		}
+		agent, err := api.AttestedNodeToProto(node, api.ProtoFromSelectors(selectors))
+		if err != nil {
+			return fmt.Errorf("failed to convert agent: %w", err)
+		}
+		event.Resource = &eventv1.ResourceEvent_Agent{Agent: agent}
+	case datastore.BundleEvent:
+		bundle, err := s.ds.FetchBundle(ctx, e.ResourceID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch bundle: %w", err)
+		}
+		if bundle == nil {
+			return nil
+		}
+		protoBundle, err := api.BundleToProto(bundle)
+		if err != nil {
+			return fmt.Errorf("failed to convert bundle: %w", err)
+		}
+		event.Resource = &eventv1.ResourceEvent_Bundle{Bundle: protoBundle}
+	case datastore.FederationRelationshipEvent:
+		td, err := spiffeid.TrustDomainFromString(e.ResourceID)
+		if err != nil {
+			return fmt.Errorf("invalid federation relationship trust domain: %w", err)
+		}
+		fr, err := s.ds.FetchFederationRelationship(ctx, td)
+		if err != nil {
+			return fmt.Errorf("failed to fetch federation relationship: %w", err)
+		}
+		if fr == nil {
+			return nil
+		}
+		protoFR, err := api.FederationRelationshipToProto(fr, nil)
+		if err != nil {
+			return fmt.Errorf("failed to convert federation relationship: %w", err)
+		}
+		event.Resource = &eventv1.ResourceEvent_FederationRelationship{FederationRelationship: protoFR}
+	}
+	return nil
+}
+
+func resourceTypesFromProto(in []eventv1.ResourceType) ([]datastore.EventResourceType, error) {
+	var out []datastore.EventResourceType
+	for _, resourceType := range in {
+		switch resourceType {
+		case eventv1.ResourceType_ENTRY:
+			out = append(out, datastore.EntryEvent)
+		case eventv1.ResourceType_AGENT:
+			out = append(out, datastore.AttestedNodeEvent)
+		case eventv1.ResourceType_BUNDLE:
+			out = append(out, datastore.BundleEvent)
+		case eventv1.ResourceType_FEDERATION_RELATIONSHIP:
+			out = append(out, datastore.FederationRelationshipEvent)
+		default:
+			return nil, fmt.Errorf("unsupported resource type %q", resourceType)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,475 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/server/api/event/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+)
+
+const (
+	pollInterval = time.Second
+	agentID      = "spiffe://example.org/spire/agent/join_token/token"
+)
+
+var (
+	ctx         = context.Background()
+	federatedTD = spiffeid.RequireTrustDomainFromString("domain1.org")
+)
+
+func TestWatchFromCursor(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	entry, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  agentID,
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	require.NoError(t, err)
+	deletedEntry, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+		ParentId:  agentID,
+		SpiffeId:  "spiffe://example.org/deleted",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1001"}},
+	})
+	require.NoError(t, err)
+	_, err = test.ds.DeleteRegistrationEntry(ctx, deletedEntry.EntryId)
+	require.NoError(t, err)
+
+	_, err = test.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            agentID,
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        1000,
+	})
+	require.NoError(t, err)
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agentID, []*common.Selector{{Type: "join_token", Value: "token"}}))
+
+	_, err = test.ds.CreateBundle(ctx, &common.Bundle{
+		TrustDomainId: federatedTD.IDString(),
+		RootCas:       []*common.Certificate{{DerBytes: []byte("cert")}},
+	})
+	require.NoError(t, err)
+
+	_, err = test.ds.CreateFederationRelationship(ctx, &datastore.FederationRelationship{
+		TrustDomain:           federatedTD,
+		BundleEndpointURL:     &url.URL{Scheme: "https", Host: "domain1.org", Path: "/bundle"},
+		BundleEndpointProfile: datastore.BundleEndpointWeb,
+	})
+	require.NoError(t, err)
+
+	expectedEntry := &types.Entry{
+		Id:            entry.EntryId,
+		ParentId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/token"},
+		SpiffeId:      &types.SPIFFEID{TrustDomain: "example.org", Path: "/workload"},
+		Selectors:     []*types.Selector{{Type: "unix", Value: "uid:1000"}},
+		DnsNames:      []string{},
+		FederatesWith: []string{},
+	}
+	expectedAgent := &types.Agent{
+		Id:                   &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/token"},
+		AttestationType:      "join_token",
+		X509SvidSerialNumber: "1234",
+		X509SvidExpiresAt:    1000,
+		Selectors:            []*types.Selector{{Type: "join_token", Value: "token"}},
+	}
+	expectedBundle := &types.Bundle{
+		TrustDomain:     "domain1.org",
+		X509Authorities: []*types.X509Certificate{{Asn1: []byte("cert")}},
+		JwtAuthorities:  []*types.JWTKey{},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		resourceTypes  []eventv1.ResourceType
+		expectedEvents []*eventv1.ResourceEvent
+	}{
+		{
+			name: "all resource types",
+			expectedEvents: []*eventv1.ResourceEvent{
+				{Cursor: "1", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_ENTRY, ResourceId: entry.EntryId, Resource: &eventv1.ResourceEvent_Entry{Entry: expectedEntry}},
+				// The entry no longer exists by the time the event is sent
+				{Cursor: "2", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_ENTRY, ResourceId: deletedEntry.EntryId},
+				{Cursor: "3", Type: eventv1.EventType_DELETED, ResourceType: eventv1.ResourceType_ENTRY, ResourceId: deletedEntry.EntryId},
+				{Cursor: "4", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+				{Cursor: "5", Type: eventv1.EventType_UPDATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+				{Cursor: "6", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain1.org", Resource: &eventv1.ResourceEvent_Bundle{Bundle: expectedBundle}},
+				{Cursor: "7", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_FEDERATION_RELATIONSHIP, ResourceId: "domain1.org", Resource: &eventv1.ResourceEvent_FederationRelationship{
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain:           "domain1.org",
+						BundleEndpointUrl:     "https://domain1.org/bundle",
+						BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{HttpsWeb: &types.HTTPSWebProfile{}},
+						TrustDomainBundle:     expectedBundle,
+					},
+				}},
+			},
+		},
+		{
+			name:          "filtered by resource type",
+			resourceTypes: []eventv1.ResourceType{eventv1.ResourceType_AGENT, eventv1.ResourceType_BUNDLE},
+			expectedEvents: []*eventv1.ResourceEvent{
+				{Cursor: "4", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+				{Cursor: "5", Type: eventv1.EventType_UPDATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+				{Cursor: "6", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain1.org", Resource: &eventv1.ResourceEvent_Bundle{Bundle: expectedBundle}},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := test.client.Watch(ctx, &eventv1.WatchRequest{
+				ResourceTypes: tt.resourceTypes,
+				Cursor:        "0",
+			})
+			require.NoError(t, err)
+
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			requireEvents(t, tt.expectedEvents, resp.Events)
+		})
+	}
+}
+
+func TestWatchAgentOperatorSelectors(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	_, err := test.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            agentID,
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        1000,
+	})
+	require.NoError(t, err)
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agentID, []*common.Selector{{Type: "join_token", Value: "token"}}))
+	_, err = test.ds.AddOperatorNodeSelectors(ctx, agentID, []*common.Selector{{Type: "operator", Value: "a"}})
+	require.NoError(t, err)
+
+	// Selectors managed by operators are reported along with the node
+	// selectors
+	expectedAgent := &types.Agent{
+		Id:                   &types.SPIFFEID{TrustDomain: "example.org", Path: "/spire/agent/join_token/token"},
+		AttestationType:      "join_token",
+		X509SvidSerialNumber: "1234",
+		X509SvidExpiresAt:    1000,
+		Selectors: []*types.Selector{
+			{Type: "join_token", Value: "token"},
+			{Type: "operator", Value: "a"},
+		},
+	}
+
+	stream, err := test.client.Watch(ctx, &eventv1.WatchRequest{Cursor: "0"})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "1", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+		{Cursor: "2", Type: eventv1.EventType_UPDATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+		{Cursor: "3", Type: eventv1.EventType_UPDATED, ResourceType: eventv1.ResourceType_AGENT, ResourceId: agentID, Resource: &eventv1.ResourceEvent_Agent{Agent: expectedAgent}},
+	}, resp.Events)
+}
+
+func TestWatchNewEvents(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	// Events recorded before the call are not sent when there is no cursor
+	_, err := test.ds.CreateBundle(ctx, &common.Bundle{TrustDomainId: "spiffe://domain2.org"})
+	require.NoError(t, err)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := test.client.Watch(streamCtx, &eventv1.WatchRequest{})
+	require.NoError(t, err)
+	test.clk.WaitForTicker(time.Minute, "waiting for the watch to start polling")
+
+	require.NoError(t, test.ds.DeleteBundle(ctx, "spiffe://domain2.org", datastore.Restrict))
+	test.clk.Add(pollInterval)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "2", Type: eventv1.EventType_DELETED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain2.org"},
+	}, resp.Events)
+}
+
+func TestWatchEventCommittedOutOfOrder(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	for _, td := range []string{"spiffe://domain2.org", "spiffe://domain3.org", "spiffe://domain4.org"} {
+		_, err := test.ds.CreateBundle(ctx, &common.Bundle{TrustDomainId: td})
+		require.NoError(t, err)
+	}
+
+	// The second event is not visible yet, as if it had been recorded by a
+	// transaction that has not been committed
+	test.ds.hidden[2] = true
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := test.client.Watch(streamCtx, &eventv1.WatchRequest{Cursor: "0"})
+	require.NoError(t, err)
+
+	// The cursor does not move past the missed event
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "1", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain2.org", Resource: &eventv1.ResourceEvent_Bundle{Bundle: &types.Bundle{TrustDomain: "domain2.org", X509Authorities: []*types.X509Certificate{}, JwtAuthorities: []*types.JWTKey{}}}},
+		{Cursor: "1", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain4.org", Resource: &eventv1.ResourceEvent_Bundle{Bundle: &types.Bundle{TrustDomain: "domain4.org", X509Authorities: []*types.X509Certificate{}, JwtAuthorities: []*types.JWTKey{}}}},
+	}, resp.Events)
+	test.clk.WaitForTicker(time.Minute, "waiting for the watch to start polling")
+
+	// The missed event is sent once it becomes visible
+	delete(test.ds.hidden, 2)
+	test.clk.Add(pollInterval)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "3", Type: eventv1.EventType_CREATED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain3.org", Resource: &eventv1.ResourceEvent_Bundle{Bundle: &types.Bundle{TrustDomain: "domain3.org", X509Authorities: []*types.X509Certificate{}, JwtAuthorities: []*types.JWTKey{}}}},
+	}, resp.Events)
+
+	// Missed events that never become visible are given up after a while
+	test.ds.hidden[5] = true
+	require.NoError(t, test.ds.DeleteBundle(ctx, "spiffe://domain2.org", datastore.Restrict))
+	_, err = test.ds.CreateBundle(ctx, &common.Bundle{TrustDomainId: "spiffe://domain5.org"})
+	require.NoError(t, err)
+	require.NoError(t, test.ds.DeleteBundle(ctx, "spiffe://domain3.org", datastore.Restrict))
+	test.clk.Add(pollInterval)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "4", Type: eventv1.EventType_DELETED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain2.org"},
+		{Cursor: "4", Type: eventv1.EventType_DELETED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain3.org"},
+	}, resp.Events)
+
+	test.clk.Add(5 * time.Minute)
+	require.NoError(t, test.ds.DeleteBundle(ctx, "spiffe://domain4.org", datastore.Restrict))
+	test.clk.Add(pollInterval)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	requireEvents(t, []*eventv1.ResourceEvent{
+		{Cursor: "7", Type: eventv1.EventType_DELETED, ResourceType: eventv1.ResourceType_BUNDLE, ResourceId: "domain4.org"},
+	}, resp.Events)
+}
+
+func TestWatchFromPrunedCursor(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	for _, td := range []string{"spiffe://domain2.org", "spiffe://domain3.org", "spiffe://domain4.org"} {
+		_, err := test.ds.CreateBundle(ctx, &common.Bundle{TrustDomainId: td})
+		require.NoError(t, err)
+	}
+	// Only the latest event is retained
+	require.NoError(t, test.ds.PruneEvents(ctx, time.Now().Add(time.Hour)))
+
+	stream, err := test.client.Watch(ctx, &eventv1.WatchRequest{Cursor: "1"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	spiretest.RequireGRPCStatus(t, err, codes.OutOfRange, "events recorded after the cursor have been pruned")
+	spiretest.AssertLastLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.ErrorLevel,
+			Message: "Events recorded after the cursor have been pruned",
+		},
+	})
+
+	// Nothing was pruned after the second event
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err = test.client.Watch(streamCtx, &eventv1.WatchRequest{Cursor: "2"})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, "3", resp.Events[0].Cursor)
+}
+
+func TestWatchErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		req        *eventv1.WatchRequest
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "malformed cursor",
+			req:        &eventv1.WatchRequest{Cursor: "not-a-number"},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `malformed cursor: strconv.ParseUint: parsing "not-a-number": invalid syntax`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: malformed cursor",
+					Data: logrus.Fields{
+						logrus.ErrorKey: `strconv.ParseUint: parsing "not-a-number": invalid syntax`,
+					},
+				},
+			},
+		},
+		{
+			name:       "unsupported resource type",
+			req:        &eventv1.WatchRequest{ResourceTypes: []eventv1.ResourceType{eventv1.ResourceType_RESOURCE_TYPE_UNSPECIFIED}},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid resource type: unsupported resource type "RESOURCE_TYPE_UNSPECIFIED"`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid resource type",
+					Data: logrus.Fields{
+						logrus.ErrorKey: `unsupported resource type "RESOURCE_TYPE_UNSPECIFIED"`,
+					},
+				},
+			},
+		},
+		{
+			name:       "failed to fetch latest event",
+			req:        &eventv1.WatchRequest{},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch latest event: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch latest event",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+		{
+			name:       "failed to list events",
+			req:        &eventv1.WatchRequest{Cursor: "0"},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list events: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list events",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			test.ds.SetNextError(tt.dsError)
+
+			stream, err := test.client.Watch(ctx, tt.req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+		})
+	}
+}
+
+func requireEvents(t *testing.T, expected, actual []*eventv1.ResourceEvent) {
+	for _, event := range actual {
+		require.NotZero(t, event.CreatedAt)
+		event.CreatedAt = 0
+	}
+	spiretest.RequireProtoListEqual(t, expected, actual)
+}
+
+// hidingDataStore hides events from listings, as if they had been recorded by
+// transactions that have not been committed yet.
+type hidingDataStore struct {
+	*fakedatastore.DataStore
+
+	hidden map[uint]bool
+}
+
+func (ds *hidingDataStore) ListEvents(ctx context.Context, req *datastore.ListEventsRequest) (*datastore.ListEventsResponse, error) {
+	resp, err := ds.DataStore.ListEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var events []*datastore.Event
+	for _, event := range resp.Events {
+		if !ds.hidden[event.ID] {
+			events = append(events, event)
+		}
+	}
+	return &datastore.ListEventsResponse{Events: events}, nil
+}
+
+type serviceTest struct {
+	client  eventv1.EventClient
+	ds      *hidingDataStore
+	clk     *clock.Mock
+	logHook *test.Hook
+	done    func()
+}
+
+func (s *serviceTest) Cleanup() {
+	s.done()
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := &hidingDataStore{
+		DataStore: fakedatastore.New(t),
+		hidden:    make(map[uint]bool),
+	}
+	clk := clock.NewMock(t)
+	service := event.New(event.Config{
+		DataStore:    ds,
+		Clock:        clk,
+		PollInterval: pollInterval,
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s *grpc.Server) {
+		event.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		return rpccontext.WithLogger(ctx, log), nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(ppMiddleware)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	return &serviceTest{
+		client:  eventv1.NewEventClient(conn),
+		ds:      ds,
+		clk:     clk,
+		logHook: logHook,
+		done:    done,
+	}
+}
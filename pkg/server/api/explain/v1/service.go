@@ -0,0 +1,317 @@
+package explain
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+)
+
+const (
+	listEntriesPageSize = 500
+)
+
+// Config is the service configuration.
+type Config struct {
+	TrustDomain  spiffeid.TrustDomain
+	DataStore    datastore.DataStore
+	EntryFetcher api.AuthorizedEntryFetcher
+	Clock        clock.Clock
+}
+
+// Service implements the v1 explain service.
+type Service struct {
+	explainv1.UnsafeExplainServer
+
+	td    spiffeid.TrustDomain
+	ds    datastore.DataStore
+	ef    api.AuthorizedEntryFetcher
+	clock clock.Clock
+}
+
+// New creates a new explain service.
+func New(config Config) *Service {
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
+	return &Service{
+		td:    config.TrustDomain,
+		ds:    config.DataStore,
+		ef:    config.EntryFetcher,
+		clock: config.Clock,
+	}
+}
+
+// RegisterService registers the explain service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	explainv1.RegisterExplainServer(s, service)
+}
+
+// ExplainAuthorization explains which registration entries an agent is
+// authorized for, and which of them match the given workload selectors.
+func (s *Service) ExplainAuthorization(ctx context.Context, req *explainv1.ExplainAuthorizationRequest) (*explainv1.ExplainAuthorizationResponse, error) {
+	rpccontext.AddRPCAuditFields(ctx, buildAuditFields(req))
+
+	log := rpccontext.Logger(ctx)
+
+	if _, err := api.SelectorsFromProto(req.NodeSelectors); err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid node selectors", err)
+	}
+	if _, err := api.SelectorsFromProto(req.WorkloadSelectors); err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "invalid workload selectors", err)
+	}
+
+	// Without an agent ID, the authorization is evaluated for an agent
+	// that only has the given node selectors. The trust domain ID stands in
+	// for the agent since no entry can be parented by it.
+	agentID := s.td.ID()
+	nodeSelectors := req.NodeSelectors
+	switch {
+	case req.AgentId != nil:
+		var err error
+		agentID, err = api.TrustDomainAgentIDFromProto(ctx, s.td, req.AgentId)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.InvalidArgument, "invalid agent ID", err)
+		}
+		log = log.WithField(telemetry.AgentID, agentID.String())
+
+		// Agents that cannot sync are not authorized for any entry. Agents
+		// that have not attested yet can only be explained with the given
+		// node selectors.
+		attestedNode, err := s.ds.FetchAttestedNode(ctx, agentID.String())
+		switch {
+		case err != nil:
+			return nil, api.MakeErr(log, codes.Internal, "failed to fetch agent", err)
+		case attestedNode == nil && len(nodeSelectors) == 0:
+			return nil, api.MakeErr(log, codes.NotFound, "agent not found", nil)
+		case attestedNode == nil:
+		case nodeutil.IsAgentBanned(attestedNode):
+			return nil, api.MakeErr(log, codes.FailedPrecondition, "agent is banned", nil)
+		case time.Unix(attestedNode.CertNotAfter, 0).Before(s.clock.Now()):
+			return nil, api.MakeErr(log, codes.FailedPrecondition, "agent SVID is expired", nil)
+		}
+
+		if len(nodeSelectors) == 0 {
+			selectors, err := s.ds.GetNodeSelectors(ctx, agentID.String(), datastore.RequireCurrent)
+			if err != nil {
+				return nil, api.MakeErr(log, codes.Internal, "failed to get node selectors", err)
+			}
+			operatorSelectors, err := s.ds.GetOperatorNodeSelectors(ctx, agentID.String(), datastore.RequireCurrent)
+			if err != nil {
+				return nil, api.MakeErr(log, codes.Internal, "failed to get operator node selectors", err)
+			}
+			nodeSelectors = api.ProtoFromSelectors(selectors)
+			nodeSelectors = append(nodeSelectors, missingSelectors(api.ProtoFromSelectors(operatorSelectors), selectorSetFromProto(nodeSelectors))...)
+		}
+	case len(nodeSelectors) == 0:
+		return nil, api.MakeErr(log, codes.InvalidArgument, "an agent ID or node selectors are required", nil)
+	}
+
+	entries, err := s.listEntries(ctx)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.Internal, "failed to list entries", err)
+	}
+
+	var authorized []*types.Entry
+	if req.AgentId != nil && len(req.NodeSelectors) == 0 {
+		// The entries authorized for an attested agent with its own
+		// selectors are served by the entry cache of the server, the same
+		// way they are when the agent syncs.
+		authorized, err = s.ef.FetchAuthorizedEntries(ctx, agentID)
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to fetch authorized entries", err)
+		}
+	} else {
+		cache, err := entrycache.Build(ctx, &entryIterator{entries: entries}, &agentIterator{
+			agents: []entrycache.Agent{{ID: agentID, Selectors: nodeSelectors}},
+		})
+		if err != nil {
+			return nil, api.MakeErr(log, codes.Internal, "failed to build entry cache", err)
+		}
+		authorized = cache.GetAuthorizedEntries(agentID)
+	}
+
+	result := explain(entries, authorized, nodeSelectors, req.WorkloadSelectors)
+	result.NodeSelectors = nodeSelectors
+
+	rpccontext.AuditRPC(ctx)
+	return result, nil
+}
+
+// listEntries lists all the registration entries one page at a time. Entries
+// with invalid IDs are ignored by the entry cache and never authorized, so
+// they are left out.
+func (s *Service) listEntries(ctx context.Context) ([]*types.Entry, error) {
+	var entries []*types.Entry
+	req := &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: listEntriesPageSize,
+		},
+	}
+	for {
+		resp, err := s.ds.ListRegistrationEntries(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, registrationEntry := range resp.Entries {
+			entry, err := api.RegistrationEntryToProto(registrationEntry)
+			if err != nil {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		if resp.Pagination == nil || resp.Pagination.Token == "" {
+			return entries, nil
+		}
+		req.Pagination = resp.Pagination
+	}
+}
+
+func explain(entries, authorized []*types.Entry, nodeSelectors, workloadSelectors []*types.Selector) *explainv1.ExplainAuthorizationResponse {
+	resp := &explainv1.ExplainAuthorizationResponse{
+		AuthorizedEntries: authorized,
+	}
+
+	nodeSet := selectorSetFromProto(nodeSelectors)
+	workloadSet := selectorSetFromProto(workloadSelectors)
+
+	authorizedIDs := make(map[string]struct{}, len(authorized))
+	for _, entry := range authorized {
+		authorizedIDs[entry.Id] = struct{}{}
+	}
+
+	for _, entry := range entries {
+		_, isAuthorized := authorizedIDs[entry.Id]
+		isAlias := entry.ParentId.Path == "/spire/server"
+		switch {
+		case isAlias && isAuthorized:
+			resp.NodeAliases = append(resp.NodeAliases, entry)
+		case isAlias:
+			if missing := missingSelectors(entry.Selectors, nodeSet); len(missing) < len(entry.Selectors) {
+				resp.NearMisses = append(resp.NearMisses, &explainv1.NearMiss{
+					Entry:            entry,
+					Reason:           explainv1.NearMissReason_MISSING_NODE_SELECTORS,
+					MissingSelectors: missing,
+				})
+			}
+		case len(workloadSet) == 0:
+			// Without workload selectors there is nothing to match
+		case isAuthorized:
+			missing := missingSelectors(entry.Selectors, workloadSet)
+			switch {
+			case len(missing) == 0:
+				resp.MatchingEntries = append(resp.MatchingEntries, entry)
+			case len(missing) < len(entry.Selectors):
+				resp.NearMisses = append(resp.NearMisses, &explainv1.NearMiss{
+					Entry:            entry,
+					Reason:           explainv1.NearMissReason_MISSING_WORKLOAD_SELECTORS,
+					MissingSelectors: missing,
+				})
+			}
+		case len(entry.Selectors) > 0 && len(missingSelectors(entry.Selectors, workloadSet)) == 0:
+			resp.NearMisses = append(resp.NearMisses, &explainv1.NearMiss{
+				Entry:  entry,
+				Reason: explainv1.NearMissReason_PARENT_NOT_AUTHORIZED,
+			})
+		}
+	}
+
+	return resp
+}
+
+func buildAuditFields(req *explainv1.ExplainAuthorizationRequest) logrus.Fields {
+	fields := logrus.Fields{}
+	if req.AgentId != nil {
+		if id, err := api.IDFromProto(context.Background(), req.AgentId); err == nil {
+			fields[telemetry.AgentID] = id.String()
+		}
+	}
+	if len(req.NodeSelectors) > 0 {
+		fields[telemetry.NodeSelectors] = api.SelectorFieldFromProto(req.NodeSelectors)
+	}
+	if len(req.WorkloadSelectors) > 0 {
+		fields[telemetry.WorkloadSelectors] = api.SelectorFieldFromProto(req.WorkloadSelectors)
+	}
+	return fields
+}
+
+type selector struct {
+	Type  string
+	Value string
+}
+
+type selectorSet map[selector]struct{}
+
+func selectorSetFromProto(selectors []*types.Selector) selectorSet {
+	set := make(selectorSet, len(selectors))
+	for _, s := range selectors {
+		set[selector{Type: s.Type, Value: s.Value}] = struct{}{}
+	}
+	return set
+}
+
+// missingSelectors returns the selectors that are not in the given set.
+func missingSelectors(selectors []*types.Selector, set selectorSet) []*types.Selector {
+	var missing []*types.Selector
+	for _, s := range selectors {
+		if _, ok := set[selector{Type: s.Type, Value: s.Value}]; !ok {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+type entryIterator struct {
+	entries []*types.Entry
+	next    int
+}
+
+func (it *entryIterator) Next(context.Context) bool {
+	if it.next >= len(it.entries) {
+		return false
+	}
+	it.next++
+	return true
+}
+
+func (it *entryIterator) Entry() *types.Entry {
+	return it.entries[it.next-1]
+}
+
+func (it *entryIterator) Err() error {
+	return nil
+}
+
+type agentIterator struct {
+	agents []entrycache.Agent
+	next   int
+}
+
+func (it *agentIterator) Next(context.Context) bool {
+	if it.next >= len(it.agents) {
+		return false
+	}
+	it.next++
+	return true
+}
+
+func (it *agentIterator) Agent() entrycache.Agent {
+	return it.agents[it.next-1]
+}
+
+func (it *agentIterator) Err() error {
+	return nil
+}
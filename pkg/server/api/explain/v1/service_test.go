@@ -0,0 +1,488 @@
+package explain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/explain/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/cache/entrycache"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+)
+
+var (
+	ctx      = context.Background()
+	td       = spiffeid.RequireTrustDomainFromString("example.org")
+	callerID = spiffeid.RequireFromString("spiffe://example.org/admin")
+	agentID  = spiffeid.RequireFromString("spiffe://example.org/spire/agent/test/agent")
+
+	workloadSelectors = []*types.Selector{
+		{Type: "unix", Value: "uid:1000"},
+		{Type: "unix", Value: "user:foo"},
+	}
+)
+
+func TestExplainAuthorization(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntries(t)
+	_, err := test.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            agentID.String(),
+		AttestationDataType: "test",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        test.clock.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agentID.String(), []*common.Selector{
+		{Type: "node", Value: "a"},
+		{Type: "node", Value: "b"},
+	}))
+
+	resp, err := test.client.ExplainAuthorization(ctx, &explainv1.ExplainAuthorizationRequest{
+		AgentId:           &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+		WorkloadSelectors: workloadSelectors,
+	})
+	require.NoError(t, err)
+
+	spiretest.RequireProtoListEqual(t, []*types.Selector{
+		{Type: "node", Value: "a"},
+		{Type: "node", Value: "b"},
+	}, resp.NodeSelectors)
+	require.Equal(t, []string{"/alias1"}, entryPaths(resp.NodeAliases))
+	require.Equal(t, []string{"/alias1", "/w1", "/w2", "/w4"}, entryPaths(resp.AuthorizedEntries))
+	require.Equal(t, []string{"/w1"}, entryPaths(resp.MatchingEntries))
+	require.Equal(t, []string{
+		"/alias2 MISSING_NODE_SELECTORS [node:c]",
+		"/w2 MISSING_WORKLOAD_SELECTORS [unix:gid:0]",
+		"/w3 PARENT_NOT_AUTHORIZED []",
+	}, nearMisses(resp.NearMisses))
+}
+
+func TestExplainAuthorizationWithOperatorSelectors(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntries(t)
+	_, err := test.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            agentID.String(),
+		AttestationDataType: "test",
+		CertSerialNumber:    "1234",
+		CertNotAfter:        test.clock.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, test.ds.SetNodeSelectors(ctx, agentID.String(), []*common.Selector{
+		{Type: "node", Value: "a"},
+	}))
+	_, err = test.ds.AddOperatorNodeSelectors(ctx, agentID.String(), []*common.Selector{
+		{Type: "node", Value: "a"},
+		{Type: "node", Value: "c"},
+	})
+	require.NoError(t, err)
+
+	resp, err := test.client.ExplainAuthorization(ctx, &explainv1.ExplainAuthorizationRequest{
+		AgentId:           &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+		WorkloadSelectors: workloadSelectors,
+	})
+	require.NoError(t, err)
+
+	spiretest.RequireProtoListEqual(t, []*types.Selector{
+		{Type: "node", Value: "a"},
+		{Type: "node", Value: "c"},
+	}, resp.NodeSelectors)
+	require.Equal(t, []string{"/alias1", "/alias2"}, entryPaths(resp.NodeAliases))
+}
+
+func TestExplainAuthorizationWithNodeSelectors(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntries(t)
+
+	// The given node selectors are used even though the agent is not
+	// attested.
+	resp, err := test.client.ExplainAuthorization(ctx, &explainv1.ExplainAuthorizationRequest{
+		AgentId:       &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+		NodeSelectors: []*types.Selector{{Type: "node", Value: "a"}, {Type: "node", Value: "c"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/alias1", "/alias2"}, entryPaths(resp.NodeAliases))
+	require.Equal(t, []string{"/alias1", "/alias2", "/w1", "/w2", "/w3", "/w4"}, entryPaths(resp.AuthorizedEntries))
+	require.Empty(t, resp.MatchingEntries)
+	require.Empty(t, resp.NearMisses)
+
+	// Without an agent ID, only the entries reachable through node aliases
+	// are authorized.
+	resp, err = test.client.ExplainAuthorization(ctx, &explainv1.ExplainAuthorizationRequest{
+		NodeSelectors:     []*types.Selector{{Type: "node", Value: "a"}, {Type: "node", Value: "c"}},
+		WorkloadSelectors: workloadSelectors,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/alias1", "/alias2"}, entryPaths(resp.NodeAliases))
+	require.Equal(t, []string{"/alias1", "/alias2", "/w1", "/w3"}, entryPaths(resp.AuthorizedEntries))
+	require.Equal(t, []string{"/w1", "/w3"}, entryPaths(resp.MatchingEntries))
+	require.Empty(t, resp.NearMisses)
+}
+
+func TestExplainAuthorizationListsEntriesInPages(t *testing.T) {
+	test := setupServiceTest(t)
+	defer test.Cleanup()
+
+	test.createEntries(t)
+	for i := 0; i < 600; i++ {
+		_, err := test.ds.CreateRegistrationEntry(ctx, &common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/alias3",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/other%d", i),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		})
+		require.NoError(t, err)
+	}
+
+	resp, err := test.client.ExplainAuthorization(ctx, &explainv1.ExplainAuthorizationRequest{
+		NodeSelectors:     []*types.Selector{{Type: "node", Value: "a"}},
+		WorkloadSelectors: workloadSelectors,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/alias1", "/w1"}, entryPaths(resp.AuthorizedEntries))
+	require.Len(t, resp.NearMisses, 602)
+}
+
+func TestExplainAuthorizationErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		req        *explainv1.ExplainAuthorizationRequest
+		agent      *common.AttestedNode
+		dsError    error
+		expectCode codes.Code
+		expectMsg  string
+		expectLogs []spiretest.LogEntry
+	}{
+		{
+			name:       "missing agent ID and node selectors",
+			req:        &explainv1.ExplainAuthorizationRequest{},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "an agent ID or node selectors are required",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: an agent ID or node selectors are required",
+				},
+			},
+		},
+		{
+			name: "invalid agent ID",
+			req: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: "/workload"},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  `invalid agent ID: "spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid agent ID",
+					Data: logrus.Fields{
+						logrus.ErrorKey: `"spiffe://example.org/workload" is not an agent in trust domain "example.org"; path is not in the agent namespace`,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid node selectors",
+			req: &explainv1.ExplainAuthorizationRequest{
+				NodeSelectors: []*types.Selector{{Value: "a"}},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid node selectors: missing selector type",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid node selectors",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "missing selector type",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid workload selectors",
+			req: &explainv1.ExplainAuthorizationRequest{
+				NodeSelectors:     []*types.Selector{{Type: "node", Value: "a"}},
+				WorkloadSelectors: []*types.Selector{{Type: "unix"}},
+			},
+			expectCode: codes.InvalidArgument,
+			expectMsg:  "invalid workload selectors: missing selector value",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: invalid workload selectors",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "missing selector value",
+					},
+				},
+			},
+		},
+		{
+			name: "agent not found",
+			req: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+			},
+			expectCode: codes.NotFound,
+			expectMsg:  "agent not found",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Agent not found",
+					Data: logrus.Fields{
+						telemetry.AgentID: agentID.String(),
+					},
+				},
+			},
+		},
+		{
+			name: "agent is banned",
+			req: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+			},
+			agent: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				AttestationDataType: "test",
+				CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+			},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "agent is banned",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Agent is banned",
+					Data: logrus.Fields{
+						telemetry.AgentID: agentID.String(),
+					},
+				},
+			},
+		},
+		{
+			name: "agent SVID is expired",
+			req: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+			},
+			agent: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				AttestationDataType: "test",
+				CertSerialNumber:    "1234",
+				CertNotAfter:        time.Now().Add(-time.Hour).Unix(),
+			},
+			expectCode: codes.FailedPrecondition,
+			expectMsg:  "agent SVID is expired",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Agent SVID is expired",
+					Data: logrus.Fields{
+						telemetry.AgentID: agentID.String(),
+					},
+				},
+			},
+		},
+		{
+			name: "failed to fetch agent",
+			req: &explainv1.ExplainAuthorizationRequest{
+				AgentId: &types.SPIFFEID{TrustDomain: td.String(), Path: agentID.Path()},
+			},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to fetch agent: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to fetch agent",
+					Data: logrus.Fields{
+						telemetry.AgentID: agentID.String(),
+						logrus.ErrorKey:   "oh no",
+					},
+				},
+			},
+		},
+		{
+			name: "failed to list entries",
+			req: &explainv1.ExplainAuthorizationRequest{
+				NodeSelectors: []*types.Selector{{Type: "node", Value: "a"}},
+			},
+			dsError:    errors.New("oh no"),
+			expectCode: codes.Internal,
+			expectMsg:  "failed to list entries: oh no",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Failed to list entries",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "oh no",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			defer test.Cleanup()
+
+			if tt.agent != nil {
+				_, err := test.ds.CreateAttestedNode(ctx, tt.agent)
+				require.NoError(t, err)
+			}
+			test.ds.SetNextError(tt.dsError)
+
+			_, err := test.client.ExplainAuthorization(ctx, tt.req)
+			spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  explainv1.ExplainClient
+	ds      *fakedatastore.DataStore
+	clock   *clock.Mock
+	logHook *test.Hook
+	done    func()
+}
+
+func (s *serviceTest) Cleanup() {
+	s.done()
+}
+
+// createEntries creates the following entries:
+//   - /alias1, a node alias for agents with the node:a selector
+//   - /alias2, a node alias for agents with the node:a and node:c selectors
+//   - /alias3, a node alias for agents with the node:z selector
+//   - /w1, parented by /alias1, for workloads with the unix:uid:1000 selector
+//   - /w2, parented by the agent, for workloads with the unix:uid:1000 and
+//     unix:gid:0 selectors
+//   - /w3, parented by /alias2, for workloads with the unix:uid:1000 selector
+//   - /w4, parented by the agent, for workloads with the unix:uid:2000 selector
+func (s *serviceTest) createEntries(t *testing.T) {
+	for _, entry := range []*common.RegistrationEntry{
+		{
+			ParentId:  "spiffe://example.org/spire/server",
+			SpiffeId:  "spiffe://example.org/alias1",
+			Selectors: []*common.Selector{{Type: "node", Value: "a"}},
+		},
+		{
+			ParentId:  "spiffe://example.org/spire/server",
+			SpiffeId:  "spiffe://example.org/alias2",
+			Selectors: []*common.Selector{{Type: "node", Value: "a"}, {Type: "node", Value: "c"}},
+		},
+		{
+			ParentId:  "spiffe://example.org/spire/server",
+			SpiffeId:  "spiffe://example.org/alias3",
+			Selectors: []*common.Selector{{Type: "node", Value: "z"}},
+		},
+		{
+			ParentId:  "spiffe://example.org/alias1",
+			SpiffeId:  "spiffe://example.org/w1",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		{
+			ParentId:  agentID.String(),
+			SpiffeId:  "spiffe://example.org/w2",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}, {Type: "unix", Value: "gid:0"}},
+		},
+		{
+			ParentId:  "spiffe://example.org/alias2",
+			SpiffeId:  "spiffe://example.org/w3",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		},
+		{
+			ParentId:  agentID.String(),
+			SpiffeId:  "spiffe://example.org/w4",
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:2000"}},
+		},
+	} {
+		_, err := s.ds.CreateRegistrationEntry(ctx, entry)
+		require.NoError(t, err)
+	}
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	clk := clock.NewMock(t)
+	service := explain.New(explain.Config{
+		TrustDomain: td,
+		DataStore:   ds,
+		EntryFetcher: api.AuthorizedEntryFetcherFunc(func(ctx context.Context, id spiffeid.ID) ([]*types.Entry, error) {
+			cache, err := entrycache.BuildFromDataStore(ctx, ds)
+			if err != nil {
+				return nil, err
+			}
+			return cache.GetAuthorizedEntries(id), nil
+		}),
+		Clock: clk,
+	})
+
+	log, logHook := test.NewNullLogger()
+	registerFn := func(s *grpc.Server) {
+		explain.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		ctx = rpccontext.WithLogger(ctx, log)
+		ctx = rpccontext.WithCallerID(ctx, callerID)
+		return ctx, nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(ppMiddleware)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	return &serviceTest{
+		client:  explainv1.NewExplainClient(conn),
+		ds:      ds,
+		clock:   clk,
+		logHook: logHook,
+		done:    done,
+	}
+}
+
+func entryPaths(entries []*types.Entry) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.SpiffeId.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func nearMisses(nearMisses []*explainv1.NearMiss) []string {
+	out := make([]string, 0, len(nearMisses))
+	for _, nearMiss := range nearMisses {
+		var missing []string
+		for _, selector := range nearMiss.MissingSelectors {
+			missing = append(missing, selector.Type+":"+selector.Value)
+		}
+		out = append(out, fmt.Sprintf("%s %s %v", nearMiss.Entry.SpiffeId.Path, nearMiss.Reason, missing))
+	}
+	sort.Strings(out)
+	return out
+}
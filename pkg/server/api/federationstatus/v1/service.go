@@ -0,0 +1,100 @@
+package federationstatus
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+)
+
+// StatusProvider provides the bundle refresh status of federation
+// relationships.
+type StatusProvider interface {
+	// GetRelationshipStatus returns the status for the given trust domain. If
+	// the trust domain is not managed, false is returned.
+	GetRelationshipStatus(td spiffeid.TrustDomain) (client.RelationshipStatus, bool)
+
+	// ListRelationshipStatuses returns the status for all the managed trust
+	// domains.
+	ListRelationshipStatuses() []client.RelationshipStatus
+}
+
+// Config is the service configuration.
+type Config struct {
+	StatusProvider StatusProvider
+}
+
+// Service implements the v1 federation status service.
+type Service struct {
+	federationstatusv1.UnsafeFederationStatusServer
+
+	sp StatusProvider
+}
+
+// New creates a new federation status service.
+func New(config Config) *Service {
+	return &Service{
+		sp: config.StatusProvider,
+	}
+}
+
+// RegisterService registers the federation status service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	federationstatusv1.RegisterFederationStatusServer(s, service)
+}
+
+func (s *Service) ListFederationRelationshipStatuses(ctx context.Context, req *federationstatusv1.ListFederationRelationshipStatusesRequest) (*federationstatusv1.ListFederationRelationshipStatusesResponse, error) {
+	resp := &federationstatusv1.ListFederationRelationshipStatusesResponse{}
+	for _, status := range s.sp.ListRelationshipStatuses() {
+		resp.Statuses = append(resp.Statuses, statusToProto(status))
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return resp, nil
+}
+
+func (s *Service) GetFederationRelationshipStatus(ctx context.Context, req *federationstatusv1.GetFederationRelationshipStatusRequest) (*federationstatusv1.FederationRelationshipStatus, error) {
+	rpccontext.AddRPCAuditFields(ctx, logrus.Fields{telemetry.TrustDomainID: req.TrustDomain})
+
+	log := rpccontext.Logger(ctx)
+
+	td, err := spiffeid.TrustDomainFromString(req.TrustDomain)
+	if err != nil {
+		return nil, api.MakeErr(log, codes.InvalidArgument, "failed to parse trust domain", err)
+	}
+
+	status, ok := s.sp.GetRelationshipStatus(td)
+	if !ok {
+		return nil, api.MakeErr(log, codes.NotFound, "federation relationship is not managed by the server", nil)
+	}
+
+	rpccontext.AuditRPC(ctx)
+	return statusToProto(status), nil
+}
+
+func statusToProto(status client.RelationshipStatus) *federationstatusv1.FederationRelationshipStatus {
+	return &federationstatusv1.FederationRelationshipStatus{
+		TrustDomain:    status.TrustDomain.String(),
+		LastSuccess:    unixOrZero(status.LastSuccess),
+		LastAttempt:    unixOrZero(status.LastAttempt),
+		LastError:      status.LastError,
+		NextRefresh:    unixOrZero(status.NextRefresh),
+		SequenceNumber: status.SequenceNumber,
+	}
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,233 @@
+package federationstatus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/federationstatus/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+)
+
+var (
+	ctx = context.Background()
+	td1 = spiffeid.RequireTrustDomainFromString("domain1.test")
+	td2 = spiffeid.RequireTrustDomainFromString("domain2.test")
+
+	lastSuccess = time.Unix(1000, 0)
+	lastAttempt = time.Unix(2000, 0)
+	nextRefresh = time.Unix(3000, 0)
+
+	status1 = client.RelationshipStatus{
+		TrustDomain:    td1,
+		LastSuccess:    lastSuccess,
+		LastAttempt:    lastAttempt,
+		LastError:      "oh no",
+		NextRefresh:    nextRefresh,
+		SequenceNumber: 7,
+	}
+	status2 = client.RelationshipStatus{
+		TrustDomain: td2,
+	}
+)
+
+func TestListFederationRelationshipStatuses(t *testing.T) {
+	test := setupServiceTest(t, status1, status2)
+
+	resp, err := test.client.ListFederationRelationshipStatuses(ctx, &federationstatusv1.ListFederationRelationshipStatusesRequest{})
+	require.NoError(t, err)
+	spiretest.AssertProtoEqual(t, &federationstatusv1.ListFederationRelationshipStatusesResponse{
+		Statuses: []*federationstatusv1.FederationRelationshipStatus{
+			{
+				TrustDomain:    "domain1.test",
+				LastSuccess:    1000,
+				LastAttempt:    2000,
+				LastError:      "oh no",
+				NextRefresh:    3000,
+				SequenceNumber: 7,
+			},
+			{
+				TrustDomain: "domain2.test",
+			},
+		},
+	}, resp)
+
+	spiretest.AssertLogs(t, test.logHook.AllEntries(), []spiretest.LogEntry{
+		{
+			Level:   logrus.InfoLevel,
+			Message: "API accessed",
+			Data: logrus.Fields{
+				telemetry.Status: "success",
+				telemetry.Type:   "audit",
+			},
+		},
+	})
+}
+
+func TestGetFederationRelationshipStatus(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		trustDomain  string
+		expectCode   codes.Code
+		expectMsg    string
+		expectStatus *federationstatusv1.FederationRelationshipStatus
+		expectLogs   []spiretest.LogEntry
+	}{
+		{
+			name:        "success",
+			trustDomain: "domain1.test",
+			expectStatus: &federationstatusv1.FederationRelationshipStatus{
+				TrustDomain:    "domain1.test",
+				LastSuccess:    1000,
+				LastAttempt:    2000,
+				LastError:      "oh no",
+				NextRefresh:    3000,
+				SequenceNumber: 7,
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.Status:        "success",
+						telemetry.Type:          "audit",
+						telemetry.TrustDomainID: "domain1.test",
+					},
+				},
+			},
+		},
+		{
+			name:        "not managed",
+			trustDomain: "domain2.test",
+			expectCode:  codes.NotFound,
+			expectMsg:   "federation relationship is not managed by the server",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Federation relationship is not managed by the server",
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.Status:        "error",
+						telemetry.Type:          "audit",
+						telemetry.TrustDomainID: "domain2.test",
+						telemetry.StatusCode:    "NotFound",
+						telemetry.StatusMessage: "federation relationship is not managed by the server",
+					},
+				},
+			},
+		},
+		{
+			name:        "malformed trust domain",
+			trustDomain: "https://foot.test",
+			expectCode:  codes.InvalidArgument,
+			expectMsg:   "failed to parse trust domain: scheme is missing or invalid",
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: failed to parse trust domain",
+					Data: logrus.Fields{
+						logrus.ErrorKey: "scheme is missing or invalid",
+					},
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.Status:        "error",
+						telemetry.Type:          "audit",
+						telemetry.TrustDomainID: "https://foot.test",
+						telemetry.StatusCode:    "InvalidArgument",
+						telemetry.StatusMessage: "failed to parse trust domain: scheme is missing or invalid",
+					},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t, status1)
+
+			status, err := test.client.GetFederationRelationshipStatus(ctx, &federationstatusv1.GetFederationRelationshipStatusRequest{
+				TrustDomain: tt.trustDomain,
+			})
+			spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			if tt.expectCode != codes.OK {
+				spiretest.RequireGRPCStatus(t, err, tt.expectCode, tt.expectMsg)
+				require.Nil(t, status)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, tt.expectStatus, status)
+		})
+	}
+}
+
+type serviceTest struct {
+	client  federationstatusv1.FederationStatusClient
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T, statuses ...client.RelationshipStatus) *serviceTest {
+	service := federationstatus.New(federationstatus.Config{
+		StatusProvider: fakeStatusProvider(statuses),
+	})
+
+	log, logHook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	registerFn := func(s *grpc.Server) {
+		federationstatus.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		return rpccontext.WithLogger(ctx, log), nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(middleware.Chain(
+		ppMiddleware,
+		// Add audit log with local tracking disabled
+		middleware.WithAuditLog(false),
+	))
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	t.Cleanup(done)
+
+	return &serviceTest{
+		client:  federationstatusv1.NewFederationStatusClient(conn),
+		logHook: logHook,
+	}
+}
+
+type fakeStatusProvider []client.RelationshipStatus
+
+func (p fakeStatusProvider) GetRelationshipStatus(td spiffeid.TrustDomain) (client.RelationshipStatus, bool) {
+	for _, status := range p {
+		if status.TrustDomain == td {
+			return status, true
+		}
+	}
+	return client.RelationshipStatus{}, false
+}
+
+func (p fakeStatusProvider) ListRelationshipStatuses() []client.RelationshipStatus {
+	return p
+}
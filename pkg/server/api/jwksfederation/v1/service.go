@@ -0,0 +1,211 @@
+package jwksfederation
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/protoutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
+)
+
+// BundleRefresher is used by the service to reload the federation
+// relationships managed by the bundle client.
+type BundleRefresher interface {
+	// TriggerConfigReload triggers the refresher to reload it's configuration
+	TriggerConfigReload()
+}
+
+// Config is the service configuration.
+type Config struct {
+	DataStore       datastore.DataStore
+	TrustDomain     spiffeid.TrustDomain
+	BundleRefresher BundleRefresher
+}
+
+// Service implements the v1 JWKS federation service.
+type Service struct {
+	jwksfederationv1.UnsafeJWKSFederationServer
+
+	ds datastore.DataStore
+	td spiffeid.TrustDomain
+	br BundleRefresher
+}
+
+// New creates a new JWKS federation service.
+func New(config Config) *Service {
+	return &Service{
+		ds: config.DataStore,
+		td: config.TrustDomain,
+		br: config.BundleRefresher,
+	}
+}
+
+// RegisterService registers the JWKS federation service on the gRPC server.
+func RegisterService(s *grpc.Server, service *Service) {
+	jwksfederationv1.RegisterJWKSFederationServer(s, service)
+}
+
+func (s *Service) BatchCreateFederationRelationship(ctx context.Context, req *jwksfederationv1.BatchCreateFederationRelationshipRequest) (*jwksfederationv1.BatchCreateFederationRelationshipResponse, error) {
+	var results []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result
+	var triggerReload bool
+	for _, eachRelationship := range req.FederationRelationships {
+		r := s.createFederationRelationship(ctx, eachRelationship, req.OutputMask)
+		if r.Status.Code == 0 {
+			triggerReload = true
+		}
+		results = append(results, r)
+		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
+			return fieldsFromRelationshipProto(eachRelationship, nil)
+		})
+	}
+
+	if triggerReload {
+		s.br.TriggerConfigReload()
+	}
+
+	return &jwksfederationv1.BatchCreateFederationRelationshipResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) BatchUpdateFederationRelationship(ctx context.Context, req *jwksfederationv1.BatchUpdateFederationRelationshipRequest) (*jwksfederationv1.BatchUpdateFederationRelationshipResponse, error) {
+	var results []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result
+	var triggerReload bool
+	for _, eachFR := range req.FederationRelationships {
+		r := s.updateFederationRelationship(ctx, eachFR, req.InputMask, req.OutputMask)
+		results = append(results, r)
+		if r.Status.Code == 0 {
+			triggerReload = true
+		}
+		rpccontext.AuditRPCWithTypesStatus(ctx, r.Status, func() logrus.Fields {
+			return fieldsFromRelationshipProto(eachFR, req.InputMask)
+		})
+	}
+
+	if triggerReload {
+		s.br.TriggerConfigReload()
+	}
+
+	return &jwksfederationv1.BatchUpdateFederationRelationshipResponse{
+		Results: results,
+	}, nil
+}
+
+func (s *Service) createFederationRelationship(ctx context.Context, f *types.FederationRelationship, outputMask *types.FederationRelationshipMask) *jwksfederationv1.BatchCreateFederationRelationshipResponse_Result {
+	log := rpccontext.Logger(ctx)
+	log = log.WithField(telemetry.TrustDomainID, f.TrustDomain)
+
+	dsFederationRelationship, err := api.ProtoToHTTPSJWKSFederationRelationship(f, nil)
+	if err != nil {
+		return &jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert federation relationship", err),
+		}
+	}
+
+	if s.td.Compare(dsFederationRelationship.TrustDomain) == 0 {
+		return &jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "unable to create federation relationship for server trust domain", nil),
+		}
+	}
+
+	resp, err := s.ds.CreateFederationRelationship(ctx, dsFederationRelationship)
+	if err != nil {
+		return &jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to create federation relationship", err),
+		}
+	}
+
+	tFederationRelationship, err := api.FederationRelationshipToProto(resp, outputMask)
+	if err != nil {
+		return &jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to convert datastore response", err),
+		}
+	}
+
+	log.Debug("Federation relationship created")
+
+	return &jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+		Status:                 api.OK(),
+		FederationRelationship: tFederationRelationship,
+	}
+}
+
+func (s *Service) updateFederationRelationship(ctx context.Context, fr *types.FederationRelationship, inputMask *types.FederationRelationshipMask, outputMask *types.FederationRelationshipMask) *jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result {
+	log := rpccontext.Logger(ctx)
+	log = log.WithField(telemetry.TrustDomainID, fr.TrustDomain)
+
+	if inputMask == nil {
+		inputMask = protoutil.AllTrueFederationRelationshipMask
+	}
+
+	dFederationRelationship, err := api.ProtoToHTTPSJWKSFederationRelationship(fr, inputMask)
+	if err != nil {
+		return &jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.InvalidArgument, "failed to convert federation relationship", err),
+		}
+	}
+
+	resp, err := s.ds.UpdateFederationRelationship(ctx, dFederationRelationship, inputMask)
+	if err != nil {
+		return &jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to update federation relationship", err),
+		}
+	}
+
+	tFederationRelationship, err := api.FederationRelationshipToProto(resp, outputMask)
+	if err != nil {
+		return &jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+			Status: api.MakeStatus(log, codes.Internal, "failed to convert federation relationship to proto", err),
+		}
+	}
+	log.Debug("Federation relationship updated")
+
+	return &jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+		Status:                 api.OK(),
+		FederationRelationship: tFederationRelationship,
+	}
+}
+
+func fieldsFromRelationshipProto(proto *types.FederationRelationship, mask *types.FederationRelationshipMask) logrus.Fields {
+	fields := logrus.Fields{}
+
+	if mask == nil {
+		mask = protoutil.AllTrueFederationRelationshipMask
+	}
+
+	if proto == nil {
+		return fields
+	}
+
+	if proto.TrustDomain != "" {
+		fields[telemetry.TrustDomainID] = proto.TrustDomain
+	}
+
+	if mask.BundleEndpointUrl {
+		fields[telemetry.BundleEndpointURL] = proto.BundleEndpointUrl
+	}
+
+	if mask.BundleEndpointProfile {
+		fields[telemetry.BundleEndpointProfile] = datastore.BundleEndpointJWKS
+	}
+
+	if mask.TrustDomainBundle {
+		if proto.TrustDomainBundle != nil {
+			bundleFields := api.FieldsFromBundleProto(proto.TrustDomainBundle, nil)
+			for key, value := range bundleFields {
+				fields["bundle_"+key] = value
+			}
+		}
+	}
+
+	return fields
+}
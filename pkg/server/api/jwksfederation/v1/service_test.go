@@ -0,0 +1,428 @@
+package jwksfederation_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/jwksfederation/v1"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/api/rpccontext"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
+)
+
+var (
+	ctx         = context.Background()
+	td          = spiffeid.RequireTrustDomainFromString("example.org")
+	federatedTd = spiffeid.RequireTrustDomainFromString("domain.test")
+)
+
+func TestBatchCreateFederationRelationship(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		req           []*types.FederationRelationship
+		outputMask    *types.FederationRelationshipMask
+		expectDSErr   error
+		expectResults []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result
+		expectDS      *datastore.FederationRelationship
+		expectReloads int
+		expectLogs    []spiretest.LogEntry
+	}{
+		{
+			name: "success",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain.test",
+					BundleEndpointUrl: "https://oidc.domain.test",
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+				{
+					Status: api.OK(),
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain:       "domain.test",
+						BundleEndpointUrl: "https://oidc.domain.test",
+					},
+				},
+			},
+			expectDS: &datastore.FederationRelationship{
+				TrustDomain:           federatedTd,
+				BundleEndpointURL:     requireURL(t, "https://oidc.domain.test"),
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+			expectReloads: 1,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship created",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain.test",
+					},
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.BundleEndpointProfile: "https_jwks",
+						telemetry.BundleEndpointURL:     "https://oidc.domain.test",
+						telemetry.Status:                "success",
+						telemetry.TrustDomainID:         "domain.test",
+						telemetry.Type:                  "audit",
+					},
+				},
+			},
+		},
+		{
+			name: "success with output mask",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain.test",
+					BundleEndpointUrl: "https://oidc.domain.test",
+				},
+			},
+			outputMask: &types.FederationRelationshipMask{},
+			expectResults: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+				{
+					Status: api.OK(),
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain: "domain.test",
+					},
+				},
+			},
+			expectDS: &datastore.FederationRelationship{
+				TrustDomain:           federatedTd,
+				BundleEndpointURL:     requireURL(t, "https://oidc.domain.test"),
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+			expectReloads: 1,
+		},
+		{
+			name: "profile is set",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:           "domain.test",
+					BundleEndpointUrl:     "https://oidc.domain.test",
+					BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{},
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.InvalidArgument),
+						Message: "failed to convert federation relationship: bundle endpoint profile must be unset; got *types.FederationRelationship_HttpsWeb",
+					},
+				},
+			},
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.ErrorLevel,
+					Message: "Invalid argument: failed to convert federation relationship",
+					Data: logrus.Fields{
+						logrus.ErrorKey:         "bundle endpoint profile must be unset; got *types.FederationRelationship_HttpsWeb",
+						telemetry.TrustDomainID: "domain.test",
+					},
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.BundleEndpointProfile: "https_jwks",
+						telemetry.BundleEndpointURL:     "https://oidc.domain.test",
+						telemetry.Status:                "error",
+						telemetry.StatusCode:            "InvalidArgument",
+						telemetry.StatusMessage:         "failed to convert federation relationship: bundle endpoint profile must be unset; got *types.FederationRelationship_HttpsWeb",
+						telemetry.TrustDomainID:         "domain.test",
+						telemetry.Type:                  "audit",
+					},
+				},
+			},
+		},
+		{
+			name: "server trust domain",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "example.org",
+					BundleEndpointUrl: "https://oidc.example.org",
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.InvalidArgument),
+						Message: "unable to create federation relationship for server trust domain",
+					},
+				},
+			},
+		},
+		{
+			name: "datastore fails",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain.test",
+					BundleEndpointUrl: "https://oidc.domain.test",
+				},
+			},
+			expectDSErr: errors.New("oh no"),
+			expectResults: []*jwksfederationv1.BatchCreateFederationRelationshipResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.Internal),
+						Message: "failed to create federation relationship: oh no",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			test.ds.SetNextError(tt.expectDSErr)
+
+			resp, err := test.client.BatchCreateFederationRelationship(ctx, &jwksfederationv1.BatchCreateFederationRelationshipRequest{
+				FederationRelationships: tt.req,
+				OutputMask:              tt.outputMask,
+			})
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, &jwksfederationv1.BatchCreateFederationRelationshipResponse{
+				Results: tt.expectResults,
+			}, resp)
+			require.Equal(t, tt.expectReloads, test.br.reloads)
+			if tt.expectLogs != nil {
+				spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			}
+
+			if tt.expectDS != nil {
+				fr, err := test.ds.FetchFederationRelationship(ctx, tt.expectDS.TrustDomain)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectDS, fr)
+			}
+		})
+	}
+}
+
+func TestBatchUpdateFederationRelationship(t *testing.T) {
+	webRelationship := &datastore.FederationRelationship{
+		TrustDomain:           federatedTd,
+		BundleEndpointURL:     requireURL(t, "https://web.domain.test/bundle"),
+		BundleEndpointProfile: datastore.BundleEndpointWeb,
+	}
+
+	for _, tt := range []struct {
+		name          string
+		req           []*types.FederationRelationship
+		inputMask     *types.FederationRelationshipMask
+		expectResults []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result
+		expectDS      *datastore.FederationRelationship
+		expectReloads int
+		expectLogs    []spiretest.LogEntry
+	}{
+		{
+			name: "switch to https_jwks",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain.test",
+					BundleEndpointUrl: "https://oidc.domain.test",
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+				{
+					Status: api.OK(),
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain:       "domain.test",
+						BundleEndpointUrl: "https://oidc.domain.test",
+					},
+				},
+			},
+			expectDS: &datastore.FederationRelationship{
+				TrustDomain:           federatedTd,
+				BundleEndpointURL:     requireURL(t, "https://oidc.domain.test"),
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+			expectReloads: 1,
+			expectLogs: []spiretest.LogEntry{
+				{
+					Level:   logrus.DebugLevel,
+					Message: "Federation relationship updated",
+					Data: logrus.Fields{
+						telemetry.TrustDomainID: "domain.test",
+					},
+				},
+				{
+					Level:   logrus.InfoLevel,
+					Message: "API accessed",
+					Data: logrus.Fields{
+						telemetry.BundleEndpointProfile: "https_jwks",
+						telemetry.BundleEndpointURL:     "https://oidc.domain.test",
+						telemetry.Status:                "success",
+						telemetry.TrustDomainID:         "domain.test",
+						telemetry.Type:                  "audit",
+					},
+				},
+			},
+		},
+		{
+			name: "profile not in input mask",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "domain.test",
+					BundleEndpointUrl: "https://web.domain.test/other",
+				},
+			},
+			inputMask: &types.FederationRelationshipMask{
+				BundleEndpointUrl: true,
+			},
+			expectResults: []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+				{
+					Status: api.OK(),
+					FederationRelationship: &types.FederationRelationship{
+						TrustDomain:           "domain.test",
+						BundleEndpointUrl:     "https://web.domain.test/other",
+						BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{},
+					},
+				},
+			},
+			expectDS: &datastore.FederationRelationship{
+				TrustDomain:           federatedTd,
+				BundleEndpointURL:     requireURL(t, "https://web.domain.test/other"),
+				BundleEndpointProfile: datastore.BundleEndpointWeb,
+			},
+			expectReloads: 1,
+		},
+		{
+			name: "profile is set",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:           "domain.test",
+					BundleEndpointUrl:     "https://oidc.domain.test",
+					BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{},
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.InvalidArgument),
+						Message: "failed to convert federation relationship: bundle endpoint profile must be unset; got *types.FederationRelationship_HttpsWeb",
+					},
+				},
+			},
+			expectDS: webRelationship,
+		},
+		{
+			name: "relationship does not exist",
+			req: []*types.FederationRelationship{
+				{
+					TrustDomain:       "other.test",
+					BundleEndpointUrl: "https://oidc.other.test",
+				},
+			},
+			expectResults: []*jwksfederationv1.BatchUpdateFederationRelationshipResponse_Result{
+				{
+					Status: &types.Status{
+						Code:    int32(codes.Internal),
+						Message: "failed to update federation relationship: unable to fetch federation relationship: record not found",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test := setupServiceTest(t)
+			_, err := test.ds.CreateFederationRelationship(ctx, webRelationship)
+			require.NoError(t, err)
+
+			resp, err := test.client.BatchUpdateFederationRelationship(ctx, &jwksfederationv1.BatchUpdateFederationRelationshipRequest{
+				FederationRelationships: tt.req,
+				InputMask:               tt.inputMask,
+			})
+			require.NoError(t, err)
+			spiretest.AssertProtoEqual(t, &jwksfederationv1.BatchUpdateFederationRelationshipResponse{
+				Results: tt.expectResults,
+			}, resp)
+			require.Equal(t, tt.expectReloads, test.br.reloads)
+			if tt.expectLogs != nil {
+				spiretest.AssertLogs(t, test.logHook.AllEntries(), tt.expectLogs)
+			}
+
+			if tt.expectDS != nil {
+				fr, err := test.ds.FetchFederationRelationship(ctx, tt.expectDS.TrustDomain)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectDS, fr)
+			}
+		})
+	}
+}
+
+type serviceTest struct {
+	client  jwksfederationv1.JWKSFederationClient
+	ds      *fakedatastore.DataStore
+	br      *fakeBundleRefresher
+	logHook *test.Hook
+}
+
+func setupServiceTest(t *testing.T) *serviceTest {
+	ds := fakedatastore.New(t)
+	br := &fakeBundleRefresher{}
+	service := jwksfederation.New(jwksfederation.Config{
+		DataStore:       ds,
+		TrustDomain:     td,
+		BundleRefresher: br,
+	})
+
+	log, logHook := test.NewNullLogger()
+	log.Level = logrus.DebugLevel
+	registerFn := func(s *grpc.Server) {
+		jwksfederation.RegisterService(s, service)
+	}
+
+	ppMiddleware := middleware.Preprocess(func(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
+		return rpccontext.WithLogger(ctx, log), nil
+	})
+
+	unaryInterceptor, streamInterceptor := middleware.Interceptors(middleware.Chain(
+		ppMiddleware,
+		// Add audit log with local tracking disabled
+		middleware.WithAuditLog(false),
+	))
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
+	)
+
+	conn, done := spiretest.NewAPIServerWithMiddleware(t, registerFn, server)
+	t.Cleanup(done)
+
+	return &serviceTest{
+		client:  jwksfederationv1.NewJWKSFederationClient(conn),
+		ds:      ds,
+		br:      br,
+		logHook: logHook,
+	}
+}
+
+func requireURL(t *testing.T, s string) *url.URL {
+	u, err := url.Parse(s)
+	require.NoError(t, err)
+	return u
+}
+
+type fakeBundleRefresher struct {
+	reloads int
+}
+
+func (r *fakeBundleRefresher) TriggerConfigReload() {
+	r.reloads++
+}
@@ -14,8 +14,16 @@ import (
 )
 
 func WithAuditLog(localTrackerEnabled bool) Middleware {
+	return WithAuditRecorder(localTrackerEnabled, nil)
+}
+
+// WithAuditRecorder returns an audit log middleware that writes the audit
+// records to the sinks of the recorder. If the recorder is nil, records are
+// written to the RPC logger.
+func WithAuditRecorder(localTrackerEnabled bool, recorder *audit.Recorder) Middleware {
 	return auditLogMiddleware{
 		localTrackerEnabled: localTrackerEnabled,
+		recorder:            recorder,
 	}
 }
 
@@ -23,6 +31,7 @@ type auditLogMiddleware struct {
 	Middleware
 
 	localTrackerEnabled bool
+	recorder            *audit.Recorder
 }
 
 func (m auditLogMiddleware) Preprocess(ctx context.Context, fullMethod string, req interface{}) (context.Context, error) {
@@ -36,7 +45,12 @@ func (m auditLogMiddleware) Preprocess(ctx context.Context, fullMethod string, r
 		log = log.WithFields(fields)
 	}
 
-	auditLog := audit.New(log)
+	var auditLog audit.Logger
+	if m.recorder != nil {
+		auditLog = m.recorder.New(log)
+	} else {
+		auditLog = audit.New(log)
+	}
 
 	ctx = rpccontext.WithAuditLog(ctx, auditLog)
 
@@ -15,12 +15,15 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func WithAuthorization(authPolicyEngine *authpolicy.Engine, entryFetcher EntryFetcher, agentAuthorizer AgentAuthorizer, adminIDs []spiffeid.ID) middleware.Middleware {
+func WithAuthorization(authPolicyEngine *authpolicy.Engine, entryFetcher EntryFetcher, agentAuthorizer AgentAuthorizer, adminIDs []spiffeid.ID, roles *authpolicy.Roles) middleware.Middleware {
 	return &authorizationMiddleware{
-		authPolicyEngine: authPolicyEngine,
-		entryFetcher:     entryFetcher,
-		agentAuthorizer:  agentAuthorizer,
-		adminIDs:         adminIDSet(adminIDs),
+		authPolicyEngine:   authPolicyEngine,
+		entryFetcher:       entryFetcher,
+		agentAuthorizer:    agentAuthorizer,
+		adminIDs:           adminIDSet(adminIDs),
+		roles:              roles,
+		privilegedIDs:      adminIDSet(append(append([]spiffeid.ID{}, adminIDs...), roles.SPIFFEIDs()...)),
+		privilegedEntryIDs: entryIDSet(roles.EntryIDs()),
 	}
 }
 
@@ -29,6 +32,11 @@ type authorizationMiddleware struct {
 	entryFetcher     EntryFetcher
 	agentAuthorizer  AgentAuthorizer
 	adminIDs         map[spiffeid.ID]struct{}
+	roles            *authpolicy.Roles
+	// privilegedIDs and privilegedEntryIDs identify the entries that
+	// callers restricted to an entry scope cannot manage.
+	privilegedIDs      map[spiffeid.ID]struct{}
+	privilegedEntryIDs map[string]struct{}
 }
 
 func (m *authorizationMiddleware) Preprocess(ctx context.Context, methodName string, req interface{}) (context.Context, error) {
@@ -95,6 +103,14 @@ func adminIDSet(ids []spiffeid.ID) map[spiffeid.ID]struct{} {
 	return set
 }
 
+func entryIDSet(entryIDs []string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, entryID := range entryIDs {
+		set[entryID] = struct{}{}
+	}
+	return set
+}
+
 func deniedDetailsFromStatus(s *status.Status) *types.PermissionDeniedDetails {
 	for _, detail := range s.Details() {
 		reason, ok := detail.(*types.PermissionDeniedDetails)
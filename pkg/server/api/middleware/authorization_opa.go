@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
@@ -37,7 +38,7 @@ func (m *authorizationMiddleware) opaAuth(ctx context.Context, req interface{},
 		return ctx, false, err
 	}
 
-	ctx, allow, err := m.reconcileResult(ctx, result)
+	ctx, allow, err := m.reconcileResult(ctx, result, fullMethod)
 	if err != nil {
 		return nil, false, err
 	}
@@ -45,7 +46,7 @@ func (m *authorizationMiddleware) opaAuth(ctx context.Context, req interface{},
 	return ctx, allow, nil
 }
 
-func (m *authorizationMiddleware) reconcileResult(ctx context.Context, res authpolicy.Result) (context.Context, bool, error) {
+func (m *authorizationMiddleware) reconcileResult(ctx context.Context, res authpolicy.Result, fullMethod string) (context.Context, bool, error) {
 	ctx = setAuthorizationLogFields(ctx, "nobody", "")
 
 	// Check things in order of cost
@@ -89,6 +90,17 @@ func (m *authorizationMiddleware) reconcileResult(ctx context.Context, res authp
 		}
 	}
 
+	// Check built-in roles, which grant a subset of the admin methods
+	if res.AllowIfAdmin && !m.roles.Empty() {
+		ctx, ok, err := m.isAuthorizedViaRoles(ctx, fullMethod)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return ctx, true, nil
+		}
+	}
+
 	if res.AllowIfAgent && !rpccontext.CallerIsLocal(ctx) {
 		if ctx, err := isAgent(ctx, m.agentAuthorizer); err != nil {
 			return ctx, false, err
@@ -100,6 +112,42 @@ func (m *authorizationMiddleware) reconcileResult(ctx context.Context, res authp
 	return ctx, false, nil
 }
 
+func (m *authorizationMiddleware) isAuthorizedViaRoles(ctx context.Context, fullMethod string) (context.Context, bool, error) {
+	callerID, ok := rpccontext.CallerID(ctx)
+	if !ok {
+		return ctx, false, nil
+	}
+
+	var entryIDs []string
+	if m.roles.HasEntryBindings() {
+		var entries []*types.Entry
+		var err error
+		ctx, entries, err = WithCallerEntries(ctx, m.entryFetcher)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, entry := range entries {
+			entryIDs = append(entryIDs, entry.Id)
+		}
+	}
+
+	grant, ok := m.roles.Authorize(fullMethod, callerID, entryIDs)
+	if !ok {
+		return ctx, false, nil
+	}
+
+	ctx = rpccontext.WithCallerRoles(ctx, grant.Roles)
+	if len(grant.EntryScope) > 0 {
+		ctx = rpccontext.WithCallerEntryScope(ctx, rpccontext.EntryScope{
+			PathPrefixes:       grant.EntryScope,
+			PrivilegedIDs:      m.privilegedIDs,
+			PrivilegedEntryIDs: m.privilegedEntryIDs,
+		})
+	}
+	ctx = setAuthorizationLogFields(ctx, strings.Join(grant.Roles, ","), "roles")
+	return ctx, true, nil
+}
+
 func isAdminViaConfig(ctx context.Context, adminIDs map[spiffeid.ID]struct{}) (context.Context, bool) {
 	if callerID, ok := rpccontext.CallerID(ctx); ok {
 		if _, ok := adminIDs[callerID]; ok {
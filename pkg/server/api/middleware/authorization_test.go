@@ -109,10 +109,13 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 		agentAuthorizer middleware.AgentAuthorizer
 		entryFetcher    middleware.EntryFetcherFunc
 		adminIDs        []spiffeid.ID
+		roleBindings    []authpolicy.RoleBinding
 		authorizerErr   error
 		expectCode      codes.Code
 		expectMsg       string
 		expectDetails   []*types.PermissionDeniedDetails
+		expectRoles     []string
+		expectScope     rpccontext.EntryScope
 	}{
 		{
 			name:       "basic allow test",
@@ -181,6 +184,82 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 			expectCode: codes.PermissionDenied,
 			expectMsg:  fmt.Sprintf("authorization denied for method %s", fakeFullMethod),
 		},
+		{
+			name:       "allow_if_admin role bound to caller SPIFFE ID test",
+			fullMethod: batchCreateEntryMethod,
+			peer:       mtlsPeer,
+			adminIDs:   []spiffeid.ID{staticAdminID},
+			roleBindings: []authpolicy.RoleBinding{
+				{Role: authpolicy.RoleEntryWriter, SPIFFEID: workloadID, SPIFFEIDPathPrefix: "/ci"},
+			},
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin": true,
+			}),
+			expectCode:  codes.OK,
+			expectRoles: []string{authpolicy.RoleEntryWriter},
+			expectScope: rpccontext.EntryScope{
+				PathPrefixes:       []string{"/ci"},
+				PrivilegedIDs:      map[spiffeid.ID]struct{}{staticAdminID: {}, workloadID: {}},
+				PrivilegedEntryIDs: map[string]struct{}{},
+			},
+		},
+		{
+			name:       "allow_if_admin role bound to caller entry test",
+			fullMethod: batchCreateEntryMethod,
+			peer:       mtlsPeer,
+			roleBindings: []authpolicy.RoleBinding{
+				{Role: authpolicy.RoleEntryWriter, EntryID: "3"},
+			},
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin": true,
+			}),
+			expectCode:  codes.OK,
+			expectRoles: []string{authpolicy.RoleEntryWriter},
+			expectScope: rpccontext.EntryScope{
+				PathPrefixes:       []string{"/"},
+				PrivilegedIDs:      map[spiffeid.ID]struct{}{},
+				PrivilegedEntryIDs: map[string]struct{}{"3": {}},
+			},
+		},
+		{
+			name:       "allow_if_admin role not granting the method test",
+			fullMethod: batchCreateEntryMethod,
+			peer:       mtlsPeer,
+			roleBindings: []authpolicy.RoleBinding{
+				{Role: authpolicy.RoleBundleReader, SPIFFEID: workloadID},
+			},
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin": true,
+			}),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  fmt.Sprintf("authorization denied for method %s", batchCreateEntryMethod),
+		},
+		{
+			name:       "allow_if_admin role bound to another caller test",
+			fullMethod: batchCreateEntryMethod,
+			peer:       mtlsPeer,
+			roleBindings: []authpolicy.RoleBinding{
+				{Role: authpolicy.RoleEntryWriter, SPIFFEID: nonAdminID},
+			},
+			rego: simpleRego(map[string]bool{
+				"allow_if_admin": true,
+			}),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  fmt.Sprintf("authorization denied for method %s", batchCreateEntryMethod),
+		},
+		{
+			name:       "role does not grant methods not allowed for admins test",
+			fullMethod: batchCreateEntryMethod,
+			peer:       mtlsPeer,
+			roleBindings: []authpolicy.RoleBinding{
+				{Role: authpolicy.RoleEntryWriter, SPIFFEID: workloadID},
+			},
+			rego: simpleRego(map[string]bool{
+				"allow_if_local": true,
+			}),
+			expectCode: codes.PermissionDenied,
+			expectMsg:  fmt.Sprintf("authorization denied for method %s", batchCreateEntryMethod),
+		},
 		{
 			name:       "allow_if_downstream downstream caller test",
 			fullMethod: fakeFullMethod,
@@ -332,7 +411,10 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 				tt.agentAuthorizer = noAgentAuthorizer
 			}
 
-			m := middleware.WithAuthorization(policyEngine, entryFetcherForTest(tt.entryFetcher), tt.agentAuthorizer, tt.adminIDs)
+			roles, err := authpolicy.NewRoles(tt.roleBindings)
+			require.NoError(t, err)
+
+			m := middleware.WithAuthorization(policyEngine, entryFetcherForTest(tt.entryFetcher), tt.agentAuthorizer, tt.adminIDs, roles)
 
 			// Set up the incoming context with a logger and optionally a peer.
 			log, _ := test.NewNullLogger()
@@ -368,6 +450,11 @@ func TestWithAuthorizationPreprocess(t *testing.T) {
 				return
 			}
 			require.NotNil(t, ctxOut, "returned context should have been non-nil on success")
+
+			callerRoles, _ := rpccontext.CallerRoles(ctxOut)
+			assert.Equal(t, tt.expectRoles, callerRoles)
+			scope, _ := rpccontext.CallerEntryScope(ctxOut)
+			assert.Equal(t, tt.expectScope, scope)
 		})
 	}
 }
@@ -377,13 +464,15 @@ func TestWithAuthorizationPostprocess(t *testing.T) {
 	ctx := context.Background()
 	policyEngine, err := authpolicy.DefaultAuthPolicy(ctx)
 	require.NoError(t, err, "failed to initialize policy engine")
-	m := middleware.WithAuthorization(policyEngine, entryFetcher, yesAgentAuthorizer, nil)
+	m := middleware.WithAuthorization(policyEngine, entryFetcher, yesAgentAuthorizer, nil, nil)
 
 	m.Postprocess(context.Background(), "", false, nil)
 	m.Postprocess(context.Background(), "", true, errors.New("ohno"))
 }
 
 var (
+	batchCreateEntryMethod = "/spire.api.server.entry.v1.Entry/BatchCreateEntry"
+
 	td           = spiffeid.RequireTrustDomainFromString("example.org")
 	adminID      = spiffeid.RequireFromPath(td, "/admin")
 	adminEntries = []*types.Entry{
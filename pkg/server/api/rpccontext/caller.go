@@ -16,6 +16,8 @@ type callerDownstreamEntriesKey struct{}
 type callerAdminTagKey struct{}
 type callerLocalTagKey struct{}
 type callerAgentTagKey struct{}
+type callerRolesKey struct{}
+type callerEntryScopeKey struct{}
 
 // WithCallerAddr returns a context with the given address.
 func WithCallerAddr(ctx context.Context, addr net.Addr) context.Context {
@@ -99,3 +101,45 @@ func CallerIsAgent(ctx context.Context) bool {
 	_, ok := ctx.Value(callerAgentTagKey{}).(struct{})
 	return ok
 }
+
+// WithCallerRoles returns a context where the caller is tagged with the
+// built-in roles that authorized the call.
+func WithCallerRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, callerRolesKey{}, roles)
+}
+
+// CallerRoles returns the built-in roles that authorized the call. If the
+// caller was not authorized through roles, it returns false.
+func CallerRoles(ctx context.Context) ([]string, bool) {
+	roles, ok := ctx.Value(callerRolesKey{}).([]string)
+	return roles, ok
+}
+
+// EntryScope restricts the registration entries that a caller can manage.
+type EntryScope struct {
+	// PathPrefixes holds the SPIFFE ID path prefixes of the entries the
+	// caller can manage. A prefix of "/" grants access to all entries.
+	PathPrefixes []string
+
+	// PrivilegedIDs holds the SPIFFE IDs granted privileges by the server
+	// configuration, i.e. admin IDs and SPIFFE IDs bound to roles. Entries
+	// with these SPIFFE IDs cannot be managed by the caller.
+	PrivilegedIDs map[spiffeid.ID]struct{}
+
+	// PrivilegedEntryIDs holds the IDs of the entries bound to roles, which
+	// cannot be managed by the caller.
+	PrivilegedEntryIDs map[string]struct{}
+}
+
+// WithCallerEntryScope returns a context where the registration entries the
+// caller can manage are restricted to the given scope.
+func WithCallerEntryScope(ctx context.Context, scope EntryScope) context.Context {
+	return context.WithValue(ctx, callerEntryScopeKey{}, scope)
+}
+
+// CallerEntryScope returns the scope of the registration entries the caller
+// can manage. If the caller is not restricted, it returns false.
+func CallerEntryScope(ctx context.Context) (EntryScope, bool) {
+	scope, ok := ctx.Value(callerEntryScopeKey{}).(EntryScope)
+	return scope, ok
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/protobuf/proto"
 )
 
 // ProtoToFederationRelationship convert and validate proto to datastore federated relationship
@@ -88,6 +89,33 @@ func ProtoToFederationRelationshipWithMask(f *types.FederationRelationship, mask
 	return resp, nil
 }
 
+// ProtoToHTTPSJWKSFederationRelationship converts and validates a proto
+// federation relationship using the https_jwks bundle endpoint profile, which
+// the types proto cannot represent. The profile in the proto must be unset.
+func ProtoToHTTPSJWKSFederationRelationship(f *types.FederationRelationship, mask *types.FederationRelationshipMask) (*datastore.FederationRelationship, error) {
+	if f == nil {
+		return nil, errors.New("missing federation relationship")
+	}
+	if f.BundleEndpointProfile != nil {
+		return nil, fmt.Errorf("bundle endpoint profile must be unset; got %T", f.BundleEndpointProfile)
+	}
+
+	if mask == nil {
+		mask = protoutil.AllTrueFederationRelationshipMask
+	}
+	withoutProfile := proto.Clone(mask).(*types.FederationRelationshipMask)
+	withoutProfile.BundleEndpointProfile = false
+
+	resp, err := ProtoToFederationRelationshipWithMask(f, withoutProfile)
+	if err != nil {
+		return nil, err
+	}
+	if mask.BundleEndpointProfile {
+		resp.BundleEndpointProfile = datastore.BundleEndpointJWKS
+	}
+	return resp, nil
+}
+
 // FederationRelationshipToProto converts datastore federation relationship to types proto
 func FederationRelationshipToProto(f *datastore.FederationRelationship, mask *types.FederationRelationshipMask) (*types.FederationRelationship, error) {
 	if mask == nil {
@@ -119,6 +147,9 @@ func FederationRelationshipToProto(f *datastore.FederationRelationship, mask *ty
 			resp.BundleEndpointProfile = profile
 		case datastore.BundleEndpointWeb:
 			resp.BundleEndpointProfile = &types.FederationRelationship_HttpsWeb{}
+		case datastore.BundleEndpointJWKS:
+			// The types proto has no https_jwks profile; relationships
+			// using it are returned without a profile.
 		default:
 			return nil, fmt.Errorf("unsupported BundleEndpointProfile: %q", f.BundleEndpointProfile)
 		}
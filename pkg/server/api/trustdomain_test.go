@@ -241,6 +241,75 @@ func TestProtoToFederationRelationshipWithMask(t *testing.T) {
 	}
 }
 
+func TestProtoToHTTPSJWKSFederationRelationship(t *testing.T) {
+	expectURL, err := url.Parse("https://some.url/path")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name       string
+		proto      *types.FederationRelationship
+		mask       *types.FederationRelationshipMask
+		expectResp *datastore.FederationRelationship
+		expectErr  string
+	}{
+		{
+			name: "no mask",
+			proto: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://some.url/path",
+			},
+			expectResp: &datastore.FederationRelationship{
+				TrustDomain:           td,
+				BundleEndpointURL:     expectURL,
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+		},
+		{
+			name: "mask without profile",
+			proto: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://some.url/path",
+			},
+			mask: &types.FederationRelationshipMask{BundleEndpointUrl: true},
+			expectResp: &datastore.FederationRelationship{
+				TrustDomain:       td,
+				BundleEndpointURL: expectURL,
+			},
+		},
+		{
+			name: "profile set",
+			proto: &types.FederationRelationship{
+				TrustDomain:           "example.org",
+				BundleEndpointUrl:     "https://some.url/path",
+				BundleEndpointProfile: &types.FederationRelationship_HttpsWeb{},
+			},
+			expectErr: "bundle endpoint profile must be unset; got *types.FederationRelationship_HttpsWeb",
+		},
+		{
+			name: "invalid URL",
+			proto: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "http://some.url/path",
+			},
+			expectErr: "bundle endpoint URL must use the https scheme",
+		},
+		{
+			name:      "no proto",
+			expectErr: "missing federation relationship",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := api.ProtoToHTTPSJWKSFederationRelationship(tt.proto, tt.mask)
+			if tt.expectErr != "" {
+				spiretest.AssertErrorPrefix(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectResp, resp)
+		})
+	}
+}
+
 func TestFederationRelationshipToProto(t *testing.T) {
 	endpointURL, err := url.Parse("https://some.url/path")
 	require.NoError(t, err)
@@ -317,6 +386,18 @@ func TestFederationRelationshipToProto(t *testing.T) {
 				TrustDomain: "example.org",
 			},
 		},
+		{
+			name: "HttpsJWKS: no mask",
+			fr: &datastore.FederationRelationship{
+				TrustDomain:           td,
+				BundleEndpointURL:     endpointURL,
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+			expectProto: &types.FederationRelationship{
+				TrustDomain:       "example.org",
+				BundleEndpointUrl: "https://some.url/path",
+			},
+		},
 		{
 			name: "empty trustdomain",
 			fr: &datastore.FederationRelationship{
@@ -1,10 +1,12 @@
 package authpolicy
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage"
@@ -22,6 +24,7 @@ const (
 
 // Engine drives policy management.
 type Engine struct {
+	mu   sync.RWMutex
 	rego rego.PartialResult
 }
 
@@ -32,6 +35,10 @@ type OpaEngineConfig struct {
 type LocalOpaProviderConfig struct {
 	RegoPath       string `hcl:"rego_path"`
 	PolicyDataPath string `hcl:"policy_data_path"`
+
+	// ReloadInterval, if set, is how often the policy and policy data files
+	// are checked for changes and reloaded.
+	ReloadInterval string `hcl:"reload_interval"`
 }
 
 // Input represents context associated with an access request.
@@ -75,31 +82,50 @@ func newEngine(ctx context.Context, cfg *OpaEngineConfig) (*Engine, error) {
 		return nil, errors.New("policy engine configuration must define a provider")
 	}
 
-	module, err := os.ReadFile(cfg.LocalOpaProvider.RegoPath)
+	module, policyData, err := readLocalProvider(cfg.LocalOpaProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := storeFromPolicyData(policyData)
 	if err != nil {
 		return nil, err
 	}
 
-	var store storage.Store
-	// If permissions file is defined use it, else provide empty store
-	if cfg.LocalOpaProvider.PolicyDataPath != "" {
-		storefile, err := os.Open(cfg.LocalOpaProvider.PolicyDataPath)
+	return NewEngineFromRego(ctx, string(module), store)
+}
+
+// readLocalProvider reads the policy and the policy data, if configured, of
+// a local OPA provider.
+func readLocalProvider(cfg *LocalOpaProviderConfig) (module []byte, policyData []byte, err error) {
+	module, err = os.ReadFile(cfg.RegoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.PolicyDataPath != "" {
+		policyData, err = os.ReadFile(cfg.PolicyDataPath)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		defer storefile.Close()
+	}
 
-		d := util.NewJSONDecoder(storefile)
-		var data map[string]interface{}
-		if err := d.Decode(&data); err != nil {
-			return nil, fmt.Errorf("error decoding JSON databindings: %w", err)
-		}
-		store = inmem.NewFromObject(data)
-	} else {
-		store = inmem.NewFromObject(map[string]interface{}{})
+	return module, policyData, nil
+}
+
+// storeFromPolicyData returns a store with the policy data. If there is no
+// policy data, an empty store is provided.
+func storeFromPolicyData(policyData []byte) (storage.Store, error) {
+	if policyData == nil {
+		return inmem.NewFromObject(map[string]interface{}{}), nil
 	}
 
-	return NewEngineFromRego(ctx, string(module), store)
+	d := util.NewJSONDecoder(bytes.NewReader(policyData))
+	var data map[string]interface{}
+	if err := d.Decode(&data); err != nil {
+		return nil, fmt.Errorf("error decoding JSON databindings: %w", err)
+	}
+	return inmem.NewFromObject(data), nil
 }
 
 // NewEngineFromRego is a helper to create the Engine object
@@ -130,7 +156,11 @@ func NewEngineFromRego(ctx context.Context, regoPolicy string, dataStore storage
 
 // Eval determines whether access should be allowed on a resource.
 func (e *Engine) Eval(ctx context.Context, input Input) (result Result, err error) {
-	rs, err := e.rego.Rego(rego.Input(input)).Eval(ctx)
+	e.mu.RLock()
+	pr := e.rego
+	e.mu.RUnlock()
+
+	rs, err := pr.Rego(rego.Input(input)).Eval(ctx)
 	if err != nil {
 		return Result{}, err
 	}
@@ -175,3 +205,15 @@ func (e *Engine) Eval(ctx context.Context, input Input) (result Result, err erro
 
 	return result, nil
 }
+
+// swap atomically replaces the policy of the engine with the policy of
+// another engine.
+func (e *Engine) swap(other *Engine) {
+	other.mu.RLock()
+	pr := other.rego
+	other.mu.RUnlock()
+
+	e.mu.Lock()
+	e.rego = pr
+	e.mu.Unlock()
+}
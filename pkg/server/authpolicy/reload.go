@@ -0,0 +1,144 @@
+package authpolicy
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+)
+
+// ReloaderConfig is the configuration for a policy reloader.
+type ReloaderConfig struct {
+	// Engine is the engine whose policy is replaced on reload.
+	Engine *Engine
+
+	// Config is the policy engine configuration the engine was created with.
+	Config *OpaEngineConfig
+
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+}
+
+// Reloader periodically checks the policy and policy data files of a local
+// OPA provider and, when they change, replaces the policy of the engine. A
+// policy that fails to compile or to be validated is rejected, and the
+// engine keeps evaluating the current policy.
+type Reloader struct {
+	c        ReloaderConfig
+	provider *LocalOpaProviderConfig
+	interval time.Duration
+
+	// digest is the digest of the files last loaded, or that were rejected,
+	// so the same content is not reloaded over and over.
+	digest [sha256.Size]byte
+}
+
+// NewReloader returns a reloader for the policy engine. If the configuration
+// does not enable reloading, it returns nil.
+func NewReloader(c ReloaderConfig) (*Reloader, error) {
+	if c.Config == nil || c.Config.LocalOpaProvider == nil || c.Config.LocalOpaProvider.ReloadInterval == "" {
+		return nil, nil
+	}
+
+	interval, err := time.ParseDuration(c.Config.LocalOpaProvider.ReloadInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy reload interval: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid policy reload interval: %s must be positive", interval)
+	}
+
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+
+	r := &Reloader{
+		c:        c,
+		provider: c.Config.LocalOpaProvider,
+		interval: interval,
+	}
+
+	// Record the digest of the files the engine was created from, so they are
+	// only reloaded after they change.
+	module, policyData, err := readLocalProvider(r.provider)
+	if err != nil {
+		return nil, err
+	}
+	r.digest = digestOf(module, policyData)
+
+	return r, nil
+}
+
+// Run checks the files for changes every reload interval until the context
+// is done.
+func (r *Reloader) Run(ctx context.Context) error {
+	ticker := r.c.Clock.Ticker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.reload(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *Reloader) reload(ctx context.Context) {
+	module, policyData, err := readLocalProvider(r.provider)
+	if err != nil {
+		r.c.Log.WithError(err).Error("Failed to read authorization policy files; keeping the current policy")
+		return
+	}
+
+	digest := digestOf(module, policyData)
+	if digest == r.digest {
+		return
+	}
+	r.digest = digest
+
+	if err := r.load(ctx, module, policyData); err != nil {
+		r.c.Log.WithError(err).Error("Rejected authorization policy reload; keeping the current policy")
+		return
+	}
+	r.c.Log.Info("Authorization policy reloaded")
+}
+
+func (r *Reloader) load(ctx context.Context, module, policyData []byte) (err error) {
+	counter := telemetry_server.StartAuthPolicyReloadCall(r.c.Metrics)
+	defer counter.Done(&err)
+
+	store, err := storeFromPolicyData(policyData)
+	if err != nil {
+		return err
+	}
+
+	// NewEngineFromRego compiles the policy and validates it using sample
+	// inputs, so the current policy is only replaced by a valid one.
+	engine, err := NewEngineFromRego(ctx, string(module), store)
+	if err != nil {
+		return err
+	}
+
+	r.c.Engine.swap(engine)
+	return nil
+}
+
+func digestOf(module, policyData []byte) [sha256.Size]byte {
+	h := sha256.New()
+	_, _ = h.Write(module)
+	// Separate the files so content moving from one to the other is detected
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(policyData)
+
+	var digest [sha256.Size]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
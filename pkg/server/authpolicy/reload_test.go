@@ -0,0 +1,220 @@
+package authpolicy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	reloadTestMethod = "/spire.api.server.entry.v1.Entry/CountEntries"
+
+	reloadTestPolicyData = `{"apis": [{"full_method": "/spire.api.server.entry.v1.Entry/CountEntries", "allow_local": true}]}`
+
+	reloadTestUpdatedPolicyData = `{"apis": [{"full_method": "/spire.api.server.entry.v1.Entry/CountEntries", "allow_admin": true}]}`
+)
+
+func TestNewReloader(t *testing.T) {
+	dir := spiretest.TempDir(t)
+	regoPath := filepath.Join(dir, "policy.rego")
+	writeFile(t, regoPath, defaultRego(t))
+
+	for _, tt := range []struct {
+		name         string
+		config       *authpolicy.OpaEngineConfig
+		expectNil    bool
+		expectErr    string
+		expectPrefix string
+	}{
+		{
+			name:      "default policy",
+			expectNil: true,
+		},
+		{
+			name: "reload not configured",
+			config: &authpolicy.OpaEngineConfig{
+				LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{RegoPath: regoPath},
+			},
+			expectNil: true,
+		},
+		{
+			name: "reload configured",
+			config: &authpolicy.OpaEngineConfig{
+				LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{RegoPath: regoPath, ReloadInterval: "1m"},
+			},
+		},
+		{
+			name: "invalid interval",
+			config: &authpolicy.OpaEngineConfig{
+				LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{RegoPath: regoPath, ReloadInterval: "soon"},
+			},
+			expectErr: `invalid policy reload interval: time: invalid duration "soon"`,
+		},
+		{
+			name: "interval not positive",
+			config: &authpolicy.OpaEngineConfig{
+				LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{RegoPath: regoPath, ReloadInterval: "0s"},
+			},
+			expectErr: "invalid policy reload interval: 0s must be positive",
+		},
+		{
+			name: "policy file does not exist",
+			config: &authpolicy.OpaEngineConfig{
+				LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{RegoPath: filepath.Join(dir, "missing.rego"), ReloadInterval: "1m"},
+			},
+			expectPrefix: "open ",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			log, _ := test.NewNullLogger()
+			reloader, err := authpolicy.NewReloader(authpolicy.ReloaderConfig{
+				Config:  tt.config,
+				Log:     log,
+				Metrics: fakemetrics.New(),
+			})
+			switch {
+			case tt.expectErr != "":
+				require.EqualError(t, err, tt.expectErr)
+				return
+			case tt.expectPrefix != "":
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectPrefix)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectNil, reloader == nil)
+		})
+	}
+}
+
+func TestReloader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := spiretest.TempDir(t)
+	regoPath := filepath.Join(dir, "policy.rego")
+	policyDataPath := filepath.Join(dir, "policy_data.json")
+	writeFile(t, regoPath, defaultRego(t))
+	writeFile(t, policyDataPath, reloadTestPolicyData)
+
+	config := &authpolicy.OpaEngineConfig{
+		LocalOpaProvider: &authpolicy.LocalOpaProviderConfig{
+			RegoPath:       regoPath,
+			PolicyDataPath: policyDataPath,
+			ReloadInterval: "1m",
+		},
+	}
+	engine, err := authpolicy.NewEngineFromConfigOrDefault(ctx, config)
+	require.NoError(t, err)
+
+	log, hook := test.NewNullLogger()
+	metrics := fakemetrics.New()
+	clk := clock.NewMock(t)
+	reloader, err := authpolicy.NewReloader(authpolicy.ReloaderConfig{
+		Engine:  engine,
+		Config:  config,
+		Log:     log,
+		Metrics: metrics,
+		Clock:   clk,
+	})
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- reloader.Run(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for the reload ticker")
+
+	requireResult := func(expect authpolicy.Result) {
+		result, err := engine.Eval(ctx, authpolicy.Input{FullMethod: reloadTestMethod})
+		require.NoError(t, err)
+		require.Equal(t, expect, result)
+	}
+	reloadCalls := func(status string) int {
+		count := 0
+		for _, metric := range metrics.AllMetrics() {
+			if metric.Type != fakemetrics.IncrCounterWithLabelsType || len(metric.Key) != 2 || metric.Key[0] != telemetry.AuthPolicy || metric.Key[1] != telemetry.Reload {
+				continue
+			}
+			for _, label := range metric.Labels {
+				if label.Name == telemetry.Status && label.Value == status {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	tick := func() {
+		hook.Reset()
+		clk.Add(time.Minute)
+	}
+
+	requireResult(authpolicy.Result{AllowIfLocal: true})
+
+	// Files have not changed, so nothing is reloaded
+	tick()
+	require.Never(t, func() bool { return len(hook.AllEntries()) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+	require.Equal(t, 0, reloadCalls("OK"))
+
+	// Updated policy data is reloaded
+	writeFile(t, policyDataPath, reloadTestUpdatedPolicyData)
+	tick()
+	require.Eventually(t, func() bool { return reloadCalls("OK") == 1 }, time.Minute, 10*time.Millisecond)
+	requireResult(authpolicy.Result{AllowIfAdmin: true})
+	spiretest.AssertLogs(t, hook.AllEntries(), []spiretest.LogEntry{
+		{Level: logrus.InfoLevel, Message: "Authorization policy reloaded"},
+	})
+
+	// A policy that fails to compile is rejected
+	writeFile(t, regoPath, "package spire\nresult = {")
+	tick()
+	require.Eventually(t, func() bool { return reloadCalls("Unknown") == 1 }, time.Minute, 10*time.Millisecond)
+	requireResult(authpolicy.Result{AllowIfAdmin: true})
+	require.Eventually(t, func() bool { return len(hook.AllEntries()) == 1 }, time.Minute, 10*time.Millisecond)
+	require.Equal(t, "Rejected authorization policy reload; keeping the current policy", hook.LastEntry().Message)
+
+	// A policy that fails validation is rejected
+	writeFile(t, regoPath, "package spire\nresult = {}")
+	tick()
+	require.Eventually(t, func() bool { return reloadCalls("Unknown") == 2 }, time.Minute, 10*time.Millisecond)
+	requireResult(authpolicy.Result{AllowIfAdmin: true})
+
+	// Missing files keep the current policy
+	require.NoError(t, os.Remove(regoPath))
+	tick()
+	require.Eventually(t, func() bool { return len(hook.AllEntries()) == 1 }, time.Minute, 10*time.Millisecond)
+	require.Equal(t, "Failed to read authorization policy files; keeping the current policy", hook.LastEntry().Message)
+	requireResult(authpolicy.Result{AllowIfAdmin: true})
+
+	// Restoring a valid policy is reloaded
+	writeFile(t, regoPath, defaultRego(t))
+	writeFile(t, policyDataPath, reloadTestPolicyData)
+	tick()
+	require.Eventually(t, func() bool { return reloadCalls("OK") == 2 }, time.Minute, 10*time.Millisecond)
+	requireResult(authpolicy.Result{AllowIfLocal: true})
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func defaultRego(t *testing.T) string {
+	rego, err := os.ReadFile("policy.rego")
+	require.NoError(t, err)
+	return string(rego)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
@@ -0,0 +1,292 @@
+package authpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+const (
+	// RoleEntryWriter grants management of registration entries. The
+	// entries that can be created, updated or deleted can be scoped to a
+	// SPIFFE ID path prefix.
+	RoleEntryWriter = "entry-writer"
+
+	// RoleAgentOperator grants management of attested agents and join
+	// tokens.
+	RoleAgentOperator = "agent-operator"
+
+	// RoleBundleReader grants read access to the trust bundle and to
+	// federated bundles.
+	RoleBundleReader = "bundle-reader"
+
+	// RoleAuditor grants read-only access to all the admin APIs.
+	RoleAuditor = "auditor"
+)
+
+var (
+	bundleReadMethods = []string{
+		"/spire.api.server.bundle.v1.Bundle/GetBundle",
+		"/spire.api.server.bundle.v1.Bundle/CountBundles",
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles",
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle",
+	}
+
+	entryReadMethods = []string{
+		"/spire.api.server.entry.v1.Entry/CountEntries",
+		"/spire.api.server.entry.v1.Entry/ListEntries",
+		"/spire.api.server.entry.v1.Entry/GetEntry",
+		"/spire.api.server.entryhistory.v1.EntryHistory/GetEntryHistory",
+	}
+
+	entryWriteMethods = []string{
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry",
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry",
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry",
+		"/spire.api.server.entryhistory.v1.EntryHistory/RestoreEntry",
+	}
+
+	agentReadMethods = []string{
+		"/spire.api.server.agent.v1.Agent/CountAgents",
+		"/spire.api.server.agent.v1.Agent/ListAgents",
+		"/spire.api.server.agent.v1.Agent/GetAgent",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentStatus",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/ListAgentStatuses",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentSelectors",
+	}
+
+	agentWriteMethods = []string{
+		"/spire.api.server.agent.v1.Agent/DeleteAgent",
+		"/spire.api.server.agent.v1.Agent/BanAgent",
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/BatchEvictAgents",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/BatchBanAgents",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/ReattestAgent",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/AddAgentSelectors",
+		"/spire.api.server.agentadmin.v1.AgentAdmin/RemoveAgentSelectors",
+	}
+
+	federationReadMethods = []string{
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships",
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship",
+		"/spire.api.server.federationstatus.v1.FederationStatus/ListFederationRelationshipStatuses",
+		"/spire.api.server.federationstatus.v1.FederationStatus/GetFederationRelationshipStatus",
+	}
+
+	eventReadMethods = []string{
+		"/spire.api.server.event.v1.Event/Watch",
+	}
+
+	explainMethods = []string{
+		"/spire.api.server.explain.v1.Explain/ExplainAuthorization",
+	}
+
+	// builtinRoles maps the built-in role names to the methods they grant
+	// access to. Roles only extend access to methods that the policy
+	// allows for admins.
+	builtinRoles = map[string]map[string]struct{}{
+		RoleEntryWriter:   methodSet(entryReadMethods, entryWriteMethods),
+		RoleAgentOperator: methodSet(agentReadMethods, agentWriteMethods),
+		RoleBundleReader:  methodSet(bundleReadMethods),
+		RoleAuditor:       methodSet(bundleReadMethods, entryReadMethods, agentReadMethods, federationReadMethods, eventReadMethods, explainMethods),
+	}
+)
+
+// RoleBinding assigns a built-in role to callers.
+type RoleBinding struct {
+	// Name identifies the binding.
+	Name string
+
+	// Role is the name of the built-in role.
+	Role string
+
+	// SPIFFEID, if set, assigns the role to callers presenting this SPIFFE
+	// ID.
+	SPIFFEID spiffeid.ID
+
+	// EntryID, if set, assigns the role to callers that have a registration
+	// entry with this ID.
+	EntryID string
+
+	// SPIFFEIDPathPrefix restricts the entries that an entry writer can
+	// manage to those with a SPIFFE ID path under this prefix. Only valid
+	// for the entry-writer role.
+	SPIFFEIDPathPrefix string
+}
+
+// RoleGrant is the result of authorizing a caller through role bindings.
+type RoleGrant struct {
+	// Roles are the names of the roles granting access to the method.
+	Roles []string
+
+	// EntryScope holds the SPIFFE ID path prefixes of the entries the caller
+	// is allowed to manage. A prefix of "/" grants access to all entries.
+	EntryScope []string
+}
+
+// Roles evaluates role bindings.
+type Roles struct {
+	bindings []RoleBinding
+}
+
+// RoleNames returns the names of the built-in roles.
+func RoleNames() []string {
+	names := make([]string, 0, len(builtinRoles))
+	for name := range builtinRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRoles validates the role bindings and returns a Roles that evaluates
+// them.
+func NewRoles(bindings []RoleBinding) (*Roles, error) {
+	for _, binding := range bindings {
+		if _, ok := builtinRoles[binding.Role]; !ok {
+			return nil, fmt.Errorf("role binding %q: unknown role %q; expected one of %s", binding.Name, binding.Role, strings.Join(RoleNames(), ", "))
+		}
+
+		hasSPIFFEID := !binding.SPIFFEID.IsZero()
+		hasEntryID := binding.EntryID != ""
+		switch {
+		case hasSPIFFEID && hasEntryID:
+			return nil, fmt.Errorf("role binding %q: SPIFFE ID and entry ID are mutually exclusive", binding.Name)
+		case !hasSPIFFEID && !hasEntryID:
+			return nil, fmt.Errorf("role binding %q: either SPIFFE ID or entry ID must be set", binding.Name)
+		}
+
+		if binding.SPIFFEIDPathPrefix != "" {
+			if binding.Role != RoleEntryWriter {
+				return nil, fmt.Errorf("role binding %q: SPIFFE ID path prefix is only supported by the %q role", binding.Name, RoleEntryWriter)
+			}
+			if err := spiffeid.ValidatePath(binding.SPIFFEIDPathPrefix); err != nil {
+				return nil, fmt.Errorf("role binding %q: invalid SPIFFE ID path prefix: %w", binding.Name, err)
+			}
+		}
+	}
+
+	return &Roles{bindings: bindings}, nil
+}
+
+// Empty returns true if there are no role bindings.
+func (r *Roles) Empty() bool {
+	return r == nil || len(r.bindings) == 0
+}
+
+// SPIFFEIDs returns the SPIFFE IDs with roles bound to them.
+func (r *Roles) SPIFFEIDs() []spiffeid.ID {
+	if r == nil {
+		return nil
+	}
+	var ids []spiffeid.ID
+	for _, binding := range r.bindings {
+		if !binding.SPIFFEID.IsZero() {
+			ids = append(ids, binding.SPIFFEID)
+		}
+	}
+	return ids
+}
+
+// EntryIDs returns the IDs of the registration entries with roles bound to
+// them.
+func (r *Roles) EntryIDs() []string {
+	if r == nil {
+		return nil
+	}
+	var entryIDs []string
+	for _, binding := range r.bindings {
+		if binding.EntryID != "" {
+			entryIDs = append(entryIDs, binding.EntryID)
+		}
+	}
+	return entryIDs
+}
+
+// HasEntryBindings returns true if any role is bound to a registration entry.
+func (r *Roles) HasEntryBindings() bool {
+	if r == nil {
+		return false
+	}
+	for _, binding := range r.bindings {
+		if binding.EntryID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize returns the roles bound to the caller, identified by its SPIFFE
+// ID and the IDs of its registration entries, that grant access to the
+// method. It returns false if no role grants access.
+func (r *Roles) Authorize(fullMethod string, callerID spiffeid.ID, callerEntryIDs []string) (RoleGrant, bool) {
+	if r == nil {
+		return RoleGrant{}, false
+	}
+
+	entryIDs := make(map[string]struct{}, len(callerEntryIDs))
+	for _, entryID := range callerEntryIDs {
+		entryIDs[entryID] = struct{}{}
+	}
+
+	var grant RoleGrant
+	for _, binding := range r.bindings {
+		if _, ok := builtinRoles[binding.Role][fullMethod]; !ok {
+			continue
+		}
+
+		switch {
+		case !binding.SPIFFEID.IsZero():
+			if binding.SPIFFEID != callerID {
+				continue
+			}
+		default:
+			if _, ok := entryIDs[binding.EntryID]; !ok {
+				continue
+			}
+		}
+
+		grant.Roles = appendUnique(grant.Roles, binding.Role)
+		if binding.Role == RoleEntryWriter {
+			pathPrefix := binding.SPIFFEIDPathPrefix
+			if pathPrefix == "" {
+				pathPrefix = "/"
+			}
+			grant.EntryScope = appendUnique(grant.EntryScope, pathPrefix)
+		}
+	}
+
+	return grant, len(grant.Roles) > 0
+}
+
+// PathInScope returns true if the SPIFFE ID path is equal to, or nested
+// under, any of the path prefixes.
+func PathInScope(path string, pathPrefixes []string) bool {
+	for _, pathPrefix := range pathPrefixes {
+		if pathPrefix == "/" || path == pathPrefix || strings.HasPrefix(path, pathPrefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func methodSet(methodLists ...[]string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, methods := range methodLists {
+		for _, method := range methods {
+			set[method] = struct{}{}
+		}
+	}
+	return set
+}
+
+func appendUnique(ss []string, s string) []string {
+	for _, existing := range ss {
+		if existing == s {
+			return ss
+		}
+	}
+	return append(ss, s)
+}
@@ -0,0 +1,197 @@
+package authpolicy_test
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+)
+
+const (
+	createEntryMethod = "/spire.api.server.entry.v1.Entry/BatchCreateEntry"
+	listEntriesMethod = "/spire.api.server.entry.v1.Entry/ListEntries"
+	banAgentMethod    = "/spire.api.server.agent.v1.Agent/BanAgent"
+	mintX509Method    = "/spire.api.server.svid.v1.SVID/MintX509SVID"
+)
+
+var (
+	ciID      = spiffeid.RequireFromString("spiffe://example.org/ci")
+	opsID     = spiffeid.RequireFromString("spiffe://example.org/ops")
+	auditorID = spiffeid.RequireFromString("spiffe://otherdomain.test/auditor")
+)
+
+func TestNewRoles(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		bindings  []authpolicy.RoleBinding
+		expectErr string
+	}{
+		{
+			name: "valid bindings",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: authpolicy.RoleEntryWriter, SPIFFEID: ciID, SPIFFEIDPathPrefix: "/ci"},
+				{Name: "ops", Role: authpolicy.RoleAgentOperator, EntryID: "ops-entry"},
+			},
+		},
+		{
+			name: "no bindings",
+		},
+		{
+			name: "unknown role",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: "superuser", SPIFFEID: ciID},
+			},
+			expectErr: `role binding "ci": unknown role "superuser"; expected one of agent-operator, auditor, bundle-reader, entry-writer`,
+		},
+		{
+			name: "no caller",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: authpolicy.RoleAuditor},
+			},
+			expectErr: `role binding "ci": either SPIFFE ID or entry ID must be set`,
+		},
+		{
+			name: "both SPIFFE ID and entry ID",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: authpolicy.RoleAuditor, SPIFFEID: ciID, EntryID: "ci-entry"},
+			},
+			expectErr: `role binding "ci": SPIFFE ID and entry ID are mutually exclusive`,
+		},
+		{
+			name: "path prefix on role other than entry writer",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: authpolicy.RoleAuditor, SPIFFEID: ciID, SPIFFEIDPathPrefix: "/ci"},
+			},
+			expectErr: `role binding "ci": SPIFFE ID path prefix is only supported by the "entry-writer" role`,
+		},
+		{
+			name: "invalid path prefix",
+			bindings: []authpolicy.RoleBinding{
+				{Name: "ci", Role: authpolicy.RoleEntryWriter, SPIFFEID: ciID, SPIFFEIDPathPrefix: "ci"},
+			},
+			expectErr: `role binding "ci": invalid SPIFFE ID path prefix: path must have a leading slash`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			roles, err := authpolicy.NewRoles(tt.bindings)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				require.Nil(t, roles)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tt.bindings) == 0, roles.Empty())
+		})
+	}
+}
+
+func TestRolesAuthorize(t *testing.T) {
+	roles, err := authpolicy.NewRoles([]authpolicy.RoleBinding{
+		{Name: "ci-build", Role: authpolicy.RoleEntryWriter, SPIFFEID: ciID, SPIFFEIDPathPrefix: "/ci/build"},
+		{Name: "ci-test", Role: authpolicy.RoleEntryWriter, SPIFFEID: ciID, SPIFFEIDPathPrefix: "/ci/test"},
+		{Name: "ops", Role: authpolicy.RoleAgentOperator, EntryID: "ops-entry"},
+		{Name: "ops-entries", Role: authpolicy.RoleEntryWriter, EntryID: "ops-entry"},
+		{Name: "audit", Role: authpolicy.RoleAuditor, SPIFFEID: auditorID},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []spiffeid.ID{ciID, ciID, auditorID}, roles.SPIFFEIDs())
+	require.Equal(t, []string{"ops-entry", "ops-entry"}, roles.EntryIDs())
+	require.True(t, roles.HasEntryBindings())
+
+	for _, tt := range []struct {
+		name           string
+		method         string
+		callerID       spiffeid.ID
+		callerEntryIDs []string
+		expectOK       bool
+		expectGrant    authpolicy.RoleGrant
+	}{
+		{
+			name:     "entry writer with multiple scopes",
+			method:   createEntryMethod,
+			callerID: ciID,
+			expectOK: true,
+			expectGrant: authpolicy.RoleGrant{
+				Roles:      []string{authpolicy.RoleEntryWriter},
+				EntryScope: []string{"/ci/build", "/ci/test"},
+			},
+		},
+		{
+			name:     "entry writer calling method not granted",
+			method:   banAgentMethod,
+			callerID: ciID,
+		},
+		{
+			name:           "roles bound to caller entry",
+			method:         listEntriesMethod,
+			callerID:       opsID,
+			callerEntryIDs: []string{"other-entry", "ops-entry"},
+			expectOK:       true,
+			expectGrant: authpolicy.RoleGrant{
+				Roles:      []string{authpolicy.RoleEntryWriter},
+				EntryScope: []string{"/"},
+			},
+		},
+		{
+			name:           "agent operator bound to caller entry",
+			method:         banAgentMethod,
+			callerID:       opsID,
+			callerEntryIDs: []string{"ops-entry"},
+			expectOK:       true,
+			expectGrant: authpolicy.RoleGrant{
+				Roles: []string{authpolicy.RoleAgentOperator},
+			},
+		},
+		{
+			name:     "caller without the bound entry",
+			method:   banAgentMethod,
+			callerID: opsID,
+		},
+		{
+			name:     "auditor reading entries",
+			method:   listEntriesMethod,
+			callerID: auditorID,
+			expectOK: true,
+			expectGrant: authpolicy.RoleGrant{
+				Roles: []string{authpolicy.RoleAuditor},
+			},
+		},
+		{
+			name:     "auditor writing entries",
+			method:   createEntryMethod,
+			callerID: auditorID,
+		},
+		{
+			name:     "method not granted by any role",
+			method:   mintX509Method,
+			callerID: auditorID,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			grant, ok := roles.Authorize(tt.method, tt.callerID, tt.callerEntryIDs)
+			require.Equal(t, tt.expectOK, ok)
+			require.Equal(t, tt.expectGrant, grant)
+		})
+	}
+}
+
+func TestPathInScope(t *testing.T) {
+	for _, tt := range []struct {
+		path         string
+		pathPrefixes []string
+		expect       bool
+	}{
+		{path: "/ci", pathPrefixes: []string{"/ci"}, expect: true},
+		{path: "/ci/build", pathPrefixes: []string{"/ci"}, expect: true},
+		{path: "/cio", pathPrefixes: []string{"/ci"}, expect: false},
+		{path: "/prod", pathPrefixes: []string{"/ci", "/prod"}, expect: true},
+		{path: "/prod", pathPrefixes: []string{"/"}, expect: true},
+		{path: "/prod", pathPrefixes: nil, expect: false},
+	} {
+		require.Equal(t, tt.expect, authpolicy.PathInScope(tt.path, tt.pathPrefixes), "path=%q prefixes=%q", tt.path, tt.pathPrefixes)
+	}
+}
@@ -2,19 +2,35 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/vishnusomank/go-spiffe/v2/bundle/x509bundle"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 	"github.com/vishnusomank/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/vishnusomank/go-spiffe/v2/svid/x509svid"
 	"github.com/zeebo/errs"
 )
 
+const (
+	// oidcDiscoveryPath is the path, relative to the issuer, at which OIDC
+	// discovery documents are published.
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+)
+
 type SPIFFEAuthConfig struct {
 	// EndpointSpiffeID is the expected SPIFFE ID of the bundle endpoint server.
 	EndpointSpiffeID spiffeid.ID
@@ -22,6 +38,12 @@ type SPIFFEAuthConfig struct {
 	// RootCAs is the set of root CA certificates used to authenticate the
 	// endpoint server.
 	RootCAs []*x509.Certificate
+
+	// BootstrapFingerprint is the SHA-256 fingerprint of the certificate or
+	// the public key of the endpoint server. It is used to authenticate the
+	// endpoint server when there are no root CAs, i.e. when the bundle is
+	// fetched for the first time.
+	BootstrapFingerprint []byte
 }
 
 type ClientConfig struct { //revive:disable-line:exported name stutter is intentional
@@ -37,6 +59,15 @@ type ClientConfig struct { //revive:disable-line:exported name stutter is intent
 	// is authenticated via Web PKI.
 	SPIFFEAuth *SPIFFEAuthConfig
 
+	// JWKS indicates that the endpoint serves a standard JWKS, or an OIDC
+	// discovery document pointing to one, instead of a SPIFFE bundle. The
+	// fetched bundle only contains JWT signing keys.
+	JWKS bool
+
+	// Cache, if set, holds the last bundle downloaded from the endpoint and
+	// is used to make conditional requests.
+	Cache *ResponseCache
+
 	// mutateTransportHook is a hook to influence the transport used during
 	// tests.
 	mutateTransportHook func(*http.Transport)
@@ -47,6 +78,41 @@ type Client interface {
 	FetchBundle(context.Context) (*bundleutil.Bundle, error)
 }
 
+// ResponseCache holds the last bundle downloaded from a bundle endpoint
+// along with the validators returned by the endpoint, so that subsequent
+// fetches can be conditional. It is safe for concurrent use.
+type ResponseCache struct {
+	mtx          sync.Mutex
+	url          string
+	etag         string
+	lastModified string
+	bundle       *bundleutil.Bundle
+}
+
+func (c *ResponseCache) get(url string) (etag, lastModified string, bundle *bundleutil.Bundle) {
+	if c == nil {
+		return "", "", nil
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.url != url {
+		return "", "", nil
+	}
+	return c.etag, c.lastModified, c.bundle
+}
+
+func (c *ResponseCache) set(url, etag, lastModified string, bundle *bundleutil.Bundle) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.url = url
+	c.etag = etag
+	c.lastModified = lastModified
+	c.bundle = bundle
+}
+
 type client struct {
 	c      ClientConfig
 	client *http.Client
@@ -60,11 +126,15 @@ func NewClient(config ClientConfig) (Client, error) {
 			return nil, fmt.Errorf("no SPIFFE ID specified for federation with %q", config.TrustDomain.String())
 		}
 
-		bundle := x509bundle.FromX509Authorities(endpointID.TrustDomain(), config.SPIFFEAuth.RootCAs)
+		if len(config.SPIFFEAuth.RootCAs) == 0 && len(config.SPIFFEAuth.BootstrapFingerprint) > 0 {
+			transport.TLSClientConfig = bootstrapTLSConfig(endpointID, config.SPIFFEAuth.BootstrapFingerprint)
+		} else {
+			bundle := x509bundle.FromX509Authorities(endpointID.TrustDomain(), config.SPIFFEAuth.RootCAs)
 
-		authorizer := tlsconfig.AuthorizeID(endpointID)
+			authorizer := tlsconfig.AuthorizeID(endpointID)
 
-		transport.TLSClientConfig = tlsconfig.TLSClientConfig(bundle, authorizer)
+			transport.TLSClientConfig = tlsconfig.TLSClientConfig(bundle, authorizer)
+		}
 	}
 	if config.mutateTransportHook != nil {
 		config.mutateTransportHook(transport)
@@ -76,28 +146,213 @@ func NewClient(config ClientConfig) (Client, error) {
 }
 
 func (c *client) FetchBundle(ctx context.Context) (*bundleutil.Bundle, error) {
-	resp, err := c.client.Get(c.c.EndpointURL)
+	b, jwksURI, err := c.fetch(ctx, c.c.EndpointURL)
+	if err != nil {
+		return nil, err
+	}
+	if jwksURI == "" {
+		return b, nil
+	}
+
+	// The endpoint served an OIDC discovery document. The keys are fetched
+	// from the JWKS URI it advertises.
+	if !strings.HasPrefix(strings.ToLower(jwksURI), "https://") {
+		return nil, errs.New("JWKS URI must use the HTTPS protocol; URI found: %q", jwksURI)
+	}
+	b, jwksURI, err = c.fetch(ctx, jwksURI)
+	switch {
+	case err != nil:
+		return nil, err
+	case jwksURI != "":
+		return nil, errs.New("expected a JWKS document but got an OIDC discovery document")
+	}
+	return b, nil
+}
+
+// fetch downloads the bundle served at the given URL. If the client is
+// configured for JWKS and the URL serves an OIDC discovery document instead
+// of a JWKS, no bundle is returned but the JWKS URI of the discovery document.
+func (c *client) fetch(ctx context.Context, url string) (*bundleutil.Bundle, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", errs.New("failed to create request: %v", err)
+	}
+
+	etag, lastModified, cachedBundle := c.c.Cache.get(url)
+	if cachedBundle != nil {
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
 	if err != nil {
 		var hostnameError x509.HostnameError
 		if errors.As(err, &hostnameError) && c.c.SPIFFEAuth == nil && len(hostnameError.Certificate.URIs) > 0 {
 			if id, idErr := spiffeid.FromString(hostnameError.Certificate.URIs[0].String()); idErr == nil {
-				return nil, errs.New("failed to authenticate bundle endpoint using web authentication but the server certificate contains SPIFFE ID %q: maybe use https_spiffe instead of https_web: %v", id, err)
+				return nil, "", errs.New("failed to authenticate bundle endpoint using web authentication but the server certificate contains SPIFFE ID %q: maybe use https_spiffe instead of https_web: %v", id, err)
 			}
 		}
-		return nil, errs.New("failed to fetch bundle: %v", err)
+		return nil, "", errs.New("failed to fetch bundle: %v", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, errs.New("unexpected status %d fetching bundle: %s", resp.StatusCode, tryRead(resp.Body))
+	maxAge, noStore := parseCacheControl(resp.Header.Get("Cache-Control"))
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cachedBundle != nil:
+		return withMaxAge(cachedBundle, maxAge), "", nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", errs.New("unexpected status %d fetching bundle: %s", resp.StatusCode, tryRead(resp.Body))
+	}
+
+	var b *bundleutil.Bundle
+	if c.c.JWKS {
+		var jwksURI string
+		b, jwksURI, err = decodeJWKS(c.c.TrustDomain, url, resp.Body)
+		if err != nil || jwksURI != "" {
+			return nil, jwksURI, err
+		}
+	} else {
+		b, err = bundleutil.Decode(c.c.TrustDomain, resp.Body)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if c.isBootstrapping() {
+		// Make sure the bundle can be used to authenticate the endpoint
+		// server in subsequent refreshes.
+		bundle := x509bundle.FromX509Authorities(c.c.TrustDomain, b.RootCAs())
+		if _, _, err := x509svid.Verify(resp.TLS.PeerCertificates, bundle); err != nil {
+			return nil, "", errs.New("endpoint server certificate cannot be verified using the bootstrapped bundle: %v", err)
+		}
+	}
+
+	if noStore {
+		c.c.Cache.set("", "", "", nil)
+	} else {
+		c.c.Cache.set(url, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), b)
+	}
+
+	return withMaxAge(b, maxAge), "", nil
+}
+
+func (c *client) isBootstrapping() bool {
+	return c.c.SPIFFEAuth != nil && len(c.c.SPIFFEAuth.RootCAs) == 0 && len(c.c.SPIFFEAuth.BootstrapFingerprint) > 0
+}
+
+// ParseFingerprint parses a hex encoded SHA-256 fingerprint. Bytes can
+// optionally be separated by colons.
+func ParseFingerprint(s string) ([]byte, error) {
+	fingerprint, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil || len(fingerprint) != sha256.Size {
+		return nil, fmt.Errorf("fingerprint %q is not a hex encoded SHA-256 hash", s)
+	}
+	return fingerprint, nil
+}
+
+// bootstrapTLSConfig returns a TLS configuration that authenticates the
+// endpoint server by matching the fingerprint of its certificate, or of its
+// public key, against the pinned one, since there are no root CAs to verify
+// the certificate chain yet.
+func bootstrapTLSConfig(endpointID spiffeid.ID, fingerprint []byte) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true, //nolint: gosec // the server certificate is verified against the pinned fingerprint
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("server chain is unexpectedly empty")
+			}
+			cert, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+
+			certFingerprint := sha256.Sum256(cert.Raw)
+			keyFingerprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			if subtle.ConstantTimeCompare(certFingerprint[:], fingerprint) != 1 &&
+				subtle.ConstantTimeCompare(keyFingerprint[:], fingerprint) != 1 {
+				return errors.New("server certificate does not match the bootstrap fingerprint")
+			}
+
+			id, err := x509svid.IDFromCert(cert)
+			if err != nil {
+				return fmt.Errorf("failed to get SPIFFE ID from server certificate: %w", err)
+			}
+			if id != endpointID {
+				return fmt.Errorf("unexpected ID %q", id)
+			}
+			return nil
+		},
+		MinVersion: tls.VersionTLS12,
 	}
+}
 
-	b, err := bundleutil.Decode(c.c.TrustDomain, resp.Body)
+// decodeJWKS decodes either a JWKS or an OIDC discovery document retrieved
+// from the given URL. In the latter case, the JWKS URI of the discovery
+// document is returned instead of a bundle.
+func decodeJWKS(trustDomain spiffeid.TrustDomain, url string, r io.Reader) (*bundleutil.Bundle, string, error) {
+	data, err := io.ReadAll(r)
 	if err != nil {
-		return nil, err
+		return nil, "", errs.New("failed to read JWKS: %v", err)
 	}
 
-	return b, nil
+	discovery := new(struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	})
+	if err := json.Unmarshal(data, discovery); err != nil {
+		return nil, "", errs.New("failed to decode JWKS: %v", err)
+	}
+	if discovery.JWKSURI != "" {
+		// As required by OpenID Connect Discovery, the issuer must be the
+		// URL the discovery document is retrieved from, without the well
+		// known path, so that an endpoint cannot pass off the keys of
+		// another issuer.
+		issuer := strings.TrimSuffix(url, oidcDiscoveryPath)
+		if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+			return nil, "", errs.New("OIDC discovery document issuer %q does not match the bundle endpoint URL; expected %q", discovery.Issuer, issuer)
+		}
+		return nil, discovery.JWKSURI, nil
+	}
+
+	b, err := bundleutil.UnmarshalJWKS(trustDomain, data)
+	if err != nil {
+		return nil, "", errs.New("failed to decode JWKS: %v", err)
+	}
+	return b, "", nil
+}
+
+// parseCacheControl returns the max-age and no-store directives of a
+// Cache-Control header value.
+func parseCacheControl(value string) (maxAge time.Duration, noStore bool) {
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "max-age":
+			if seconds, err := strconv.ParseInt(strings.Trim(arg, `"`), 10, 64); err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		case "no-store":
+			noStore = true
+		}
+	}
+	return maxAge, noStore
+}
+
+// withMaxAge returns the bundle with the refresh hint set to the given
+// max-age, if the bundle does not have a refresh hint of its own, so that
+// refreshes are scheduled according to the caching policy of the endpoint.
+func withMaxAge(b *bundleutil.Bundle, maxAge time.Duration) *bundleutil.Bundle {
+	if maxAge <= 0 || b.RefreshHint() > 0 {
+		return b
+	}
+	b = b.Clone()
+	b.SetRefreshHint(maxAge)
+	return b
 }
 
 func tryRead(r io.Reader) string {
@@ -3,16 +3,20 @@ package client
 import (
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
@@ -154,6 +158,362 @@ func TestClient(t *testing.T) {
 	}
 }
 
+func TestClientConditionalFetch(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t, serverID)
+
+	var (
+		body            string
+		cacheControl    string
+		ifNoneMatch     string
+		ifModifiedSince string
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ifNoneMatch = req.Header.Get("If-None-Match")
+		ifModifiedSince = req.Header.Get("If-Modified-Since")
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 17 Oct 2022 09:46:40 GMT")
+		w.Header().Set("Cache-Control", cacheControl)
+		if ifNoneMatch == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	newClient := func(cache *ResponseCache) Client {
+		client, err := NewClient(ClientConfig{
+			TrustDomain: trustDomain,
+			EndpointURL: server.URL,
+			SPIFFEAuth: &SPIFFEAuthConfig{
+				EndpointSpiffeID: serverID,
+				RootCAs:          []*x509.Certificate{serverCert},
+			},
+			Cache: cache,
+		})
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("without cache", func(t *testing.T) {
+		body = `{"spiffe_refresh_hint": 10}`
+		cacheControl = "max-age=600"
+
+		client := newClient(nil)
+		for i := 0; i < 2; i++ {
+			bundle, err := client.FetchBundle(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, ifNoneMatch)
+			require.Empty(t, ifModifiedSince)
+			// The refresh hint in the bundle takes precedence over max-age
+			require.Equal(t, 10*time.Second, bundle.RefreshHint())
+		}
+	})
+
+	t.Run("with cache", func(t *testing.T) {
+		body = `{"spiffe_sequence": 4}`
+		cacheControl = "public, max-age=600"
+
+		cache := new(ResponseCache)
+		bundle1, err := newClient(cache).FetchBundle(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, ifNoneMatch)
+		require.Empty(t, ifModifiedSince)
+		// The bundle has no refresh hint so max-age is used instead
+		require.Equal(t, 600*time.Second, bundle1.RefreshHint())
+		require.Equal(t, uint64(4), bundle1.SequenceNumber())
+
+		// The cached bundle is returned when the endpoint reports that the
+		// bundle has not been modified
+		cacheControl = "max-age=300"
+		bundle2, err := newClient(cache).FetchBundle(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, `"v1"`, ifNoneMatch)
+		require.Equal(t, "Mon, 17 Oct 2022 09:46:40 GMT", ifModifiedSince)
+		require.Equal(t, 300*time.Second, bundle2.RefreshHint())
+		require.Equal(t, uint64(4), bundle2.SequenceNumber())
+		require.Equal(t, bundle1.TrustDomainID(), bundle2.TrustDomainID())
+	})
+
+	t.Run("with cache and no-store", func(t *testing.T) {
+		body = `{"spiffe_refresh_hint": 10}`
+		cacheControl = "no-store"
+
+		cache := new(ResponseCache)
+		for i := 0; i < 2; i++ {
+			_, err := newClient(cache).FetchBundle(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, ifNoneMatch)
+			require.Empty(t, ifModifiedSince)
+		}
+	})
+}
+
+func TestClientJWKS(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t, serverID)
+
+	const jwks = `{
+		"keys": [
+			{
+				"kid": "KID",
+				"use": "sig",
+				"kty": "EC",
+				"crv": "P-256",
+				"x": "kkEn5E2Hd_rvCRDCVMNj3deN0ADij9uJVmN-El0CJz0",
+				"y": "qNrnjhtzrtTR0bRgI2jPIC1nEgcWNX63YcZOEzyo1iA"
+			}
+		]
+	}`
+
+	var (
+		issuer      string
+		jwksURI     string
+		ifNoneMatch map[string]string
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ifNoneMatch[req.URL.Path] = req.Header.Get("If-None-Match")
+		switch req.URL.Path {
+		case "/.well-known/openid-configuration":
+			_, _ = fmt.Fprintf(w, `{"issuer": %q, "jwks_uri": %q}`, issuer, jwksURI)
+		case "/jwks":
+			w.Header().Set("ETag", `"v1"`)
+			if req.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write([]byte(jwks))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		name           string
+		path           string
+		issuer         string
+		jwksURI        string
+		fetchBundleErr string
+	}{
+		{
+			name: "JWKS",
+			path: "/jwks",
+		},
+		{
+			name:    "OIDC discovery document",
+			path:    "/.well-known/openid-configuration",
+			issuer:  server.URL,
+			jwksURI: server.URL + "/jwks",
+		},
+		{
+			name:    "OIDC discovery document with trailing slash in issuer",
+			path:    "/.well-known/openid-configuration",
+			issuer:  server.URL + "/",
+			jwksURI: server.URL + "/jwks",
+		},
+		{
+			name:           "OIDC discovery document of another issuer",
+			path:           "/.well-known/openid-configuration",
+			issuer:         "https://issuer.test",
+			jwksURI:        server.URL + "/jwks",
+			fetchBundleErr: fmt.Sprintf(`OIDC discovery document issuer "https://issuer.test" does not match the bundle endpoint URL; expected %q`, server.URL),
+		},
+		{
+			name:           "OIDC discovery document with insecure JWKS URI",
+			path:           "/.well-known/openid-configuration",
+			issuer:         server.URL,
+			jwksURI:        "http://issuer.test/jwks",
+			fetchBundleErr: `JWKS URI must use the HTTPS protocol; URI found: "http://issuer.test/jwks"`,
+		},
+		{
+			name:           "OIDC discovery document pointing to another discovery document",
+			path:           "/.well-known/openid-configuration",
+			issuer:         server.URL,
+			jwksURI:        server.URL + "/.well-known/openid-configuration",
+			fetchBundleErr: "expected a JWKS document but got an OIDC discovery document",
+		},
+		{
+			name:           "not found",
+			path:           "/missing",
+			fetchBundleErr: "unexpected status 404 fetching bundle",
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			issuer = testCase.issuer
+			jwksURI = testCase.jwksURI
+
+			client, err := NewClient(ClientConfig{
+				TrustDomain: trustDomain,
+				EndpointURL: server.URL + testCase.path,
+				SPIFFEAuth: &SPIFFEAuthConfig{
+					EndpointSpiffeID: serverID,
+					RootCAs:          []*x509.Certificate{serverCert},
+				},
+				JWKS:  true,
+				Cache: new(ResponseCache),
+			})
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				ifNoneMatch = make(map[string]string)
+				bundle, err := client.FetchBundle(context.Background())
+				if testCase.fetchBundleErr != "" {
+					require.Error(t, err)
+					require.Contains(t, err.Error(), testCase.fetchBundleErr)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, trustDomain.IDString(), bundle.TrustDomainID())
+				require.Empty(t, bundle.RootCAs())
+				require.Contains(t, bundle.JWTSigningKeys(), "KID")
+
+				// Only the request for the JWKS is conditional
+				if i == 0 {
+					require.Empty(t, ifNoneMatch["/jwks"])
+				} else {
+					require.Equal(t, `"v1"`, ifNoneMatch["/jwks"])
+				}
+				require.Empty(t, ifNoneMatch["/.well-known/openid-configuration"])
+			}
+		})
+	}
+}
+
+func TestClientBootstrap(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t, serverID)
+	certFingerprint := sha256.Sum256(serverCert.Raw)
+	keyFingerprint := sha256.Sum256(serverCert.RawSubjectPublicKeyInfo)
+
+	bundleBytes, err := bundleutil.Marshal(bundleutil.BundleFromRootCA(trustDomain, serverCert))
+	require.NoError(t, err)
+
+	var body []byte
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{
+			{
+				Certificate: [][]byte{serverCert.Raw},
+				PrivateKey:  serverKey,
+			},
+		},
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	for _, testCase := range []struct {
+		name           string
+		expectedID     spiffeid.ID
+		fingerprint    []byte
+		body           []byte
+		fetchBundleErr string
+	}{
+		{
+			name:        "certificate fingerprint",
+			expectedID:  serverID,
+			fingerprint: certFingerprint[:],
+			body:        bundleBytes,
+		},
+		{
+			name:        "public key fingerprint",
+			expectedID:  serverID,
+			fingerprint: keyFingerprint[:],
+			body:        bundleBytes,
+		},
+		{
+			name:           "fingerprint mismatch",
+			expectedID:     serverID,
+			fingerprint:    make([]byte, sha256.Size),
+			body:           bundleBytes,
+			fetchBundleErr: "server certificate does not match the bootstrap fingerprint",
+		},
+		{
+			name:           "unexpected SPIFFE ID",
+			expectedID:     spiffeid.RequireFromString("spiffe://domain.test/authorized"),
+			fingerprint:    certFingerprint[:],
+			body:           bundleBytes,
+			fetchBundleErr: fmt.Sprintf("unexpected ID %q", serverID),
+		},
+		{
+			name:           "bundle does not authenticate the endpoint server",
+			expectedID:     serverID,
+			fingerprint:    certFingerprint[:],
+			body:           []byte(`{"spiffe_refresh_hint": 10}`),
+			fetchBundleErr: "endpoint server certificate cannot be verified using the bootstrapped bundle",
+		},
+	} {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			body = testCase.body
+
+			client, err := NewClient(ClientConfig{
+				TrustDomain: trustDomain,
+				EndpointURL: server.URL,
+				SPIFFEAuth: &SPIFFEAuthConfig{
+					EndpointSpiffeID:     testCase.expectedID,
+					BootstrapFingerprint: testCase.fingerprint,
+				},
+			})
+			require.NoError(t, err)
+
+			bundle, err := client.FetchBundle(context.Background())
+			if testCase.fetchBundleErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), testCase.fetchBundleErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []*x509.Certificate{serverCert}, bundle.RootCAs())
+		})
+	}
+}
+
+func TestParseFingerprint(t *testing.T) {
+	fingerprint := sha256.Sum256([]byte("foo"))
+	encoded := hex.EncodeToString(fingerprint[:])
+
+	parsed, err := ParseFingerprint(encoded)
+	require.NoError(t, err)
+	require.Equal(t, fingerprint[:], parsed)
+
+	var withColons []string
+	for i := 0; i < len(encoded); i += 2 {
+		withColons = append(withColons, strings.ToUpper(encoded[i:i+2]))
+	}
+	parsed, err = ParseFingerprint(strings.Join(withColons, ":"))
+	require.NoError(t, err)
+	require.Equal(t, fingerprint[:], parsed)
+
+	_, err = ParseFingerprint("not hex")
+	require.EqualError(t, err, `fingerprint "not hex" is not a hex encoded SHA-256 hash`)
+
+	_, err = ParseFingerprint(encoded[:32])
+	require.EqualError(t, err, fmt.Sprintf("fingerprint %q is not a hex encoded SHA-256 hash", encoded[:32]))
+}
+
 func createServerCertificate(t *testing.T, serverID spiffeid.ID) (*x509.Certificate, crypto.Signer) {
 	return spiretest.SelfSignCertificate(t, &x509.Certificate{
 		SerialNumber: big.NewInt(0),
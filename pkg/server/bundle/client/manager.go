@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -51,12 +52,27 @@ func (p HTTPSWebProfile) Name() string {
 type HTTPSSPIFFEProfile struct {
 	// EndpointSPIFFEID is the expected SPIFFE ID of the bundle endpoint server.
 	EndpointSPIFFEID spiffeid.ID
+
+	// BootstrapFingerprint is the optional hex encoded SHA-256 fingerprint of
+	// the certificate or public key of the bundle endpoint server. If set,
+	// and there is no local copy of the bundle yet, it is used to
+	// authenticate the endpoint server to fetch the bundle for the first time.
+	BootstrapFingerprint string
 }
 
 func (p HTTPSSPIFFEProfile) Name() string {
 	return "https_spiffe"
 }
 
+// HTTPSJWKSProfile is used to federate with issuers that publish a standard
+// JWKS, or an OIDC discovery document pointing to one, instead of a SPIFFE
+// bundle. The endpoint is authenticated using Web PKI.
+type HTTPSJWKSProfile struct{}
+
+func (p HTTPSJWKSProfile) Name() string {
+	return "https_jwks"
+}
+
 type ManagerConfig struct {
 	Log       logrus.FieldLogger
 	Metrics   telemetry.Metrics
@@ -93,12 +109,71 @@ type Manager struct {
 	bundleRefreshedCh chan time.Duration
 }
 
+// RelationshipStatus is the status of the bundle refreshes for a federated
+// trust domain managed by the manager.
+type RelationshipStatus struct {
+	TrustDomain spiffeid.TrustDomain
+
+	// LastSuccess is when the bundle was last refreshed successfully.
+	LastSuccess time.Time
+
+	// LastAttempt is when the last refresh was attempted.
+	LastAttempt time.Time
+
+	// LastError is the error returned by the last refresh attempt, if any.
+	LastError string
+
+	// NextRefresh is when the next refresh is scheduled.
+	NextRefresh time.Time
+
+	// SequenceNumber is the sequence number of the current bundle of the
+	// trust domain, i.e. the last bundle downloaded from the bundle endpoint.
+	SequenceNumber uint64
+}
+
 type managedBundleUpdater struct {
 	BundleUpdater
 
 	wg     sync.WaitGroup
 	cancel context.CancelFunc
 	runCh  chan chan error
+
+	statusMtx sync.Mutex
+	status    RelationshipStatus
+}
+
+func (m *managedBundleUpdater) Status() RelationshipStatus {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	return m.status
+}
+
+func (m *managedBundleUpdater) recordAttempt(now time.Time, localBundle, endpointBundle *bundleutil.Bundle, err error) RelationshipStatus {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	m.status.LastAttempt = now
+	if err != nil {
+		m.status.LastError = err.Error()
+	} else {
+		m.status.LastError = ""
+		m.status.LastSuccess = now
+	}
+	switch {
+	case endpointBundle != nil:
+		m.status.SequenceNumber = endpointBundle.SequenceNumber()
+	case localBundle != nil:
+		// The endpoint bundle is only returned when it differs from the
+		// local bundle, which is otherwise the current one.
+		m.status.SequenceNumber = localBundle.SequenceNumber()
+	}
+	return m.status
+}
+
+func (m *managedBundleUpdater) setNextRefresh(nextRefresh time.Time) RelationshipStatus {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+	m.status.NextRefresh = nextRefresh
+	return m.status
 }
 
 func (m *managedBundleUpdater) Stop() {
@@ -174,10 +249,40 @@ func (m *Manager) RefreshBundleFor(ctx context.Context, td spiffeid.TrustDomain)
 		return false, nil
 	}
 
-	_, _, err := updater.UpdateBundle(ctx)
+	_, _, err := m.updateBundle(ctx, updater)
 	return true, err
 }
 
+// GetRelationshipStatus returns the status of the bundle refreshes for the
+// given trust domain. If the trust domain is not managed by the manager, false
+// is returned.
+func (m *Manager) GetRelationshipStatus(td spiffeid.TrustDomain) (RelationshipStatus, bool) {
+	m.updatersMtx.RLock()
+	updater, ok := m.updaters[td]
+	m.updatersMtx.RUnlock()
+
+	if !ok {
+		return RelationshipStatus{}, false
+	}
+	return updater.Status(), true
+}
+
+// ListRelationshipStatuses returns the status of the bundle refreshes for all
+// the trust domains managed by the manager, sorted by trust domain name.
+func (m *Manager) ListRelationshipStatuses() []RelationshipStatus {
+	m.updatersMtx.RLock()
+	statuses := make([]RelationshipStatus, 0, len(m.updaters))
+	for _, updater := range m.updaters {
+		statuses = append(statuses, updater.Status())
+	}
+	m.updatersMtx.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].TrustDomain.String() < statuses[j].TrustDomain.String()
+	})
+	return statuses
+}
+
 func (m *Manager) refreshConfigs(ctx context.Context) error {
 	m.configRefreshMtx.Lock()
 	defer m.configRefreshMtx.Unlock()
@@ -244,6 +349,7 @@ func (m *Manager) refreshConfigs(ctx context.Context) error {
 			}),
 			cancel: cancel,
 			runCh:  make(chan chan error),
+			status: RelationshipStatus{TrustDomain: td},
 		}
 		m.updaters[td] = updater
 		updater.wg.Add(1)
@@ -255,7 +361,7 @@ func (m *Manager) refreshConfigs(ctx context.Context) error {
 	return nil
 }
 
-func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDomain, updater BundleUpdater) {
+func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDomain, updater *managedBundleUpdater) {
 	// Initialize the timer. The initial duration does not matter since it will
 	// be reset with the actual refresh interval before first use.
 	timer := m.clock.Timer(time.Hour)
@@ -265,7 +371,7 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 	for {
 		var nextRefresh time.Duration
 		log.Debug("Polling for bundle update")
-		localBundle, endpointBundle, err := updater.UpdateBundle(ctx)
+		localBundle, endpointBundle, err := m.updateBundle(ctx, updater)
 		if err != nil {
 			log.WithError(err).Error("Error updating bundle")
 		}
@@ -287,9 +393,11 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 			nextRefresh = bundleutil.MinimumRefreshHint
 		}
 
+		nextRefreshAt := m.clock.Now().Add(nextRefresh)
 		log.WithFields(logrus.Fields{
-			"at": m.clock.Now().Add(nextRefresh).UTC().Format(time.RFC3339),
+			"at": nextRefreshAt.UTC().Format(time.RFC3339),
 		}).Debug("Scheduling next bundle refresh")
+		m.emitStatusMetrics(updater.setNextRefresh(nextRefreshAt))
 
 		// Notify the test hook
 		timer.Reset(nextRefresh)
@@ -305,6 +413,32 @@ func (m *Manager) runUpdater(ctx context.Context, trustDomain spiffeid.TrustDoma
 	}
 }
 
+// updateBundle updates the bundle using the given updater and records the
+// outcome in the updater status.
+func (m *Manager) updateBundle(ctx context.Context, updater *managedBundleUpdater) (*bundleutil.Bundle, *bundleutil.Bundle, error) {
+	localBundle, endpointBundle, err := updater.UpdateBundle(ctx)
+	m.emitStatusMetrics(updater.recordAttempt(m.clock.Now(), localBundle, endpointBundle, err))
+	return localBundle, endpointBundle, err
+}
+
+func (m *Manager) emitStatusMetrics(status RelationshipStatus) {
+	td := status.TrustDomain.String()
+	now := m.clock.Now()
+
+	var failed float32
+	if status.LastError != "" {
+		failed = 1
+	}
+	telemetry_server.SetBundleManagerFederatedBundleErrorGauge(m.metrics, td, failed)
+	telemetry_server.SetBundleManagerFederatedBundleSequenceNumberGauge(m.metrics, td, float32(status.SequenceNumber))
+	if !status.LastSuccess.IsZero() {
+		telemetry_server.SetBundleManagerFederatedBundleLastSuccessAgeGauge(m.metrics, td, float32(now.Sub(status.LastSuccess).Seconds()))
+	}
+	if !status.NextRefresh.IsZero() {
+		telemetry_server.SetBundleManagerFederatedBundleNextRefreshGauge(m.metrics, td, float32(status.NextRefresh.Sub(now).Seconds()))
+	}
+}
+
 func (m *Manager) notifyConfigRefreshed(ctx context.Context, nextRefresh time.Duration) {
 	if m.configRefreshedCh != nil {
 		select {
@@ -221,6 +221,91 @@ func TestManagerConfigManualRefresh(t *testing.T) {
 	}, test.GetTrustDomainConfigs())
 }
 
+func TestManagerRelationshipStatus(t *testing.T) {
+	endpointBundle, err := bundleutil.Unmarshal(trustDomain, []byte(`{"spiffe_sequence": 3, "spiffe_refresh_hint": 3600}`))
+	require.NoError(t, err)
+
+	source := NewTrustDomainConfigSet(TrustDomainConfigMap{
+		trustDomain: TrustDomainConfig{
+			EndpointURL:     "https://example.org/bundle",
+			EndpointProfile: HTTPSWebProfile{},
+		},
+	})
+
+	test := newManagerTest(t, source, nil,
+		func(spiffeid.TrustDomain) *bundleutil.Bundle {
+			return endpointBundle
+		},
+	)
+
+	test.WaitForConfigRefresh()
+
+	// Assert the status after a successful refresh
+	nextRefresh := calculateNextUpdate(endpointBundle)
+	test.WaitForBundleRefresh(nextRefresh)
+	refreshedAt := test.clock.Now()
+	expectStatus := RelationshipStatus{
+		TrustDomain:    trustDomain,
+		LastSuccess:    refreshedAt,
+		LastAttempt:    refreshedAt,
+		NextRefresh:    refreshedAt.Add(nextRefresh),
+		SequenceNumber: 3,
+	}
+	status, ok := test.manager.GetRelationshipStatus(trustDomain)
+	require.True(t, ok)
+	require.Equal(t, expectStatus, status)
+	require.Equal(t, []RelationshipStatus{expectStatus}, test.manager.ListRelationshipStatuses())
+
+	// Assert the status after a failed refresh keeps the last success and
+	// sequence number
+	updater, ok := test.bundleUpdaterFor(trustDomain)
+	require.True(t, ok)
+	updater.SetBundles(nil, nil)
+	test.AdvanceTime(nextRefresh)
+	test.WaitForBundleRefresh(bundleutil.MinimumRefreshHint)
+	failedAt := test.clock.Now()
+	expectStatus.LastAttempt = failedAt
+	expectStatus.LastError = "OHNO"
+	expectStatus.NextRefresh = failedAt.Add(bundleutil.MinimumRefreshHint)
+	status, ok = test.manager.GetRelationshipStatus(trustDomain)
+	require.True(t, ok)
+	require.Equal(t, expectStatus, status)
+
+	// Assert there is no status for unmanaged trust domains
+	_, ok = test.manager.GetRelationshipStatus(spiffeid.RequireTrustDomainFromString("unmanaged.test"))
+	require.False(t, ok)
+}
+
+func TestManagerRelationshipStatusFromLocalBundle(t *testing.T) {
+	localBundle, err := bundleutil.Unmarshal(trustDomain, []byte(`{"spiffe_sequence": 5, "spiffe_refresh_hint": 3600}`))
+	require.NoError(t, err)
+
+	source := NewTrustDomainConfigSet(TrustDomainConfigMap{
+		trustDomain: TrustDomainConfig{
+			EndpointURL:     "https://example.org/bundle",
+			EndpointProfile: HTTPSWebProfile{},
+		},
+	})
+
+	test := newManagerTest(t, source,
+		func(spiffeid.TrustDomain) *bundleutil.Bundle {
+			return localBundle
+		},
+		nil,
+	)
+
+	test.WaitForConfigRefresh()
+
+	// No bundle is downloaded from the endpoint, e.g. right after a restart,
+	// so the sequence number is the one of the local bundle
+	nextRefresh := calculateNextUpdate(localBundle)
+	test.WaitForBundleRefresh(nextRefresh)
+	status, ok := test.manager.GetRelationshipStatus(trustDomain)
+	require.True(t, ok)
+	require.Equal(t, "OHNO", status.LastError)
+	require.Equal(t, uint64(5), status.SequenceNumber)
+}
+
 type managerTest struct {
 	t                 *testing.T
 	clock             *clock.Mock
@@ -384,7 +469,10 @@ func (u *fakeBundleUpdater) UpdateBundle(context.Context) (*bundleutil.Bundle, *
 	u.mtx.Lock()
 	defer u.mtx.Unlock()
 	u.updateCount++
-	return u.localBundle, u.endpointBundle, errors.New("OHNO")
+	if u.endpointBundle != nil {
+		return u.localBundle, u.endpointBundle, nil
+	}
+	return u.localBundle, nil, errors.New("OHNO")
 }
 
 func (u *fakeBundleUpdater) GetTrustDomainConfig() TrustDomainConfig {
@@ -97,6 +97,8 @@ func DataStoreTrustDomainConfigSource(log logrus.FieldLogger, ds datastore.DataS
 				}
 			case datastore.BundleEndpointWeb:
 				config.EndpointProfile = HTTPSWebProfile{}
+			case datastore.BundleEndpointJWKS:
+				config.EndpointProfile = HTTPSJWKSProfile{}
 			default:
 				log.WithFields(logrus.Fields{
 					telemetry.TrustDomain:           fr.TrustDomain,
@@ -18,6 +18,7 @@ var (
 	domain1 = spiffeid.RequireTrustDomainFromString("domain1.test")
 	domain2 = spiffeid.RequireTrustDomainFromString("domain2.test")
 	domain3 = spiffeid.RequireTrustDomainFromString("domain3.test")
+	domain4 = spiffeid.RequireTrustDomainFromString("domain4.test")
 )
 
 func TestMergedTrustDomainConfigSource(t *testing.T) {
@@ -106,6 +107,11 @@ func TestDataStoreTrustDomainConfigSource(t *testing.T) {
 				BundleEndpointProfile: datastore.BundleEndpointSPIFFE,
 				EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://domain3.test/bundle-server"),
 			},
+			{
+				TrustDomain:           domain4,
+				BundleEndpointURL:     parseURL(t, "https://domain4.test/.well-known/openid-configuration"),
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
 		}}
 		source := client.DataStoreTrustDomainConfigSource(log, ds)
 		configs, err := source.GetTrustDomainConfigs(context.Background())
@@ -120,6 +126,10 @@ func TestDataStoreTrustDomainConfigSource(t *testing.T) {
 					EndpointSPIFFEID: spiffeid.RequireFromString("spiffe://domain3.test/bundle-server"),
 				},
 			},
+			domain4: {
+				EndpointURL:     "https://domain4.test/.well-known/openid-configuration",
+				EndpointProfile: client.HTTPSJWKSProfile{},
+			},
 		}, configs)
 		assert.NoError(t, err)
 	})
@@ -47,6 +47,10 @@ type bundleUpdater struct {
 
 	trustDomainConfigMtx sync.Mutex
 	trustDomainConfig    TrustDomainConfig
+
+	// cache holds the last response from the endpoint. It is replaced when
+	// the configuration changes.
+	cache *ResponseCache
 }
 
 func NewBundleUpdater(config BundleUpdaterConfig) BundleUpdater {
@@ -58,13 +62,17 @@ func NewBundleUpdater(config BundleUpdaterConfig) BundleUpdater {
 		ds:                config.DataStore,
 		newClientHook:     config.newClientHook,
 		trustDomainConfig: config.TrustDomainConfig,
+		cache:             new(ResponseCache),
 	}
 }
 
 func (u *bundleUpdater) UpdateBundle(ctx context.Context) (*bundleutil.Bundle, *bundleutil.Bundle, error) {
-	trustDomainConfig := u.GetTrustDomainConfig()
+	u.trustDomainConfigMtx.Lock()
+	trustDomainConfig := u.trustDomainConfig
+	cache := u.cache
+	u.trustDomainConfigMtx.Unlock()
 
-	client, err := u.newClient(ctx, trustDomainConfig)
+	client, err := u.newClient(ctx, trustDomainConfig, cache)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -103,15 +111,21 @@ func (u *bundleUpdater) SetTrustDomainConfig(trustDomainConfig TrustDomainConfig
 	defer u.trustDomainConfigMtx.Unlock()
 	if u.trustDomainConfig != trustDomainConfig {
 		u.trustDomainConfig = trustDomainConfig
+		u.cache = new(ResponseCache)
 		return true
 	}
 	return false
 }
 
-func (u *bundleUpdater) newClient(ctx context.Context, trustDomainConfig TrustDomainConfig) (Client, error) {
+func (u *bundleUpdater) newClient(ctx context.Context, trustDomainConfig TrustDomainConfig, cache *ResponseCache) (Client, error) {
 	clientConfig := ClientConfig{
 		TrustDomain: u.td,
 		EndpointURL: trustDomainConfig.EndpointURL,
+		Cache:       cache,
+	}
+
+	if _, ok := trustDomainConfig.EndpointProfile.(HTTPSJWKSProfile); ok {
+		clientConfig.JWKS = true
 	}
 
 	if spiffeAuth, ok := trustDomainConfig.EndpointProfile.(HTTPSSPIFFEProfile); ok {
@@ -122,7 +136,10 @@ func (u *bundleUpdater) newClient(ctx context.Context, trustDomainConfig TrustDo
 		}
 
 		if localEndpointBundle == nil {
-			return nil, errors.New("can't perform SPIFFE Authentication: local copy of bundle not found")
+			if spiffeAuth.BootstrapFingerprint == "" {
+				return nil, errors.New("can't perform SPIFFE Authentication: local copy of bundle not found")
+			}
+			return u.newBootstrapClient(clientConfig, spiffeAuth)
 		}
 		clientConfig.SPIFFEAuth = &SPIFFEAuthConfig{
 			EndpointSpiffeID: spiffeAuth.EndpointSPIFFEID,
@@ -132,6 +149,25 @@ func (u *bundleUpdater) newClient(ctx context.Context, trustDomainConfig TrustDo
 	return u.newClientHook(clientConfig)
 }
 
+// newBootstrapClient returns a client that authenticates the endpoint server
+// using the bootstrap fingerprint. Bootstrapping is only possible when the
+// endpoint server belongs to the federated trust domain, since otherwise the
+// fetched bundle cannot be used to authenticate the endpoint server later.
+func (u *bundleUpdater) newBootstrapClient(clientConfig ClientConfig, spiffeAuth HTTPSSPIFFEProfile) (Client, error) {
+	if spiffeAuth.EndpointSPIFFEID.TrustDomain() != u.td {
+		return nil, fmt.Errorf("can't bootstrap federation relationship: endpoint SPIFFE ID %q is not a member of trust domain %q", spiffeAuth.EndpointSPIFFEID, u.td)
+	}
+	fingerprint, err := ParseFingerprint(spiffeAuth.BootstrapFingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("can't bootstrap federation relationship: %w", err)
+	}
+	clientConfig.SPIFFEAuth = &SPIFFEAuthConfig{
+		EndpointSpiffeID:     spiffeAuth.EndpointSPIFFEID,
+		BootstrapFingerprint: fingerprint,
+	}
+	return u.newClientHook(clientConfig)
+}
+
 func fetchBundleIfExists(ctx context.Context, ds datastore.DataStore, trustDomain spiffeid.TrustDomain) (*bundleutil.Bundle, error) {
 	// Load the current bundle and extract the root CA certificates
 	bundle, err := ds.FetchBundle(ctx, trustDomain.IDString())
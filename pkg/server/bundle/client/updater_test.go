@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"errors"
 	"math/big"
 	"testing"
@@ -12,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/testkey"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
@@ -132,6 +135,127 @@ func TestBundleUpdaterUpdateBundle(t *testing.T) {
 	}
 }
 
+func TestBundleUpdaterJWKSProfile(t *testing.T) {
+	ds := fakedatastore.New(t)
+
+	endpointBundle := bundleutil.New(trustDomain)
+	require.NoError(t, endpointBundle.AppendJWTSigningKey("KID", testkey.NewEC256(t).Public()))
+
+	var clientConfig ClientConfig
+	updater := NewBundleUpdater(BundleUpdaterConfig{
+		DataStore:   ds,
+		TrustDomain: trustDomain,
+		TrustDomainConfig: TrustDomainConfig{
+			EndpointURL:     "ENDPOINT_ADDRESS",
+			EndpointProfile: HTTPSJWKSProfile{},
+		},
+		newClientHook: func(config ClientConfig) (Client, error) {
+			clientConfig = config
+			return fakeClient{bundle: endpointBundle}, nil
+		},
+	})
+
+	localBundle, updatedBundle, err := updater.UpdateBundle(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, localBundle)
+	spiretest.RequireProtoEqual(t, endpointBundle.Proto(), updatedBundle.Proto())
+
+	// JWKS endpoints are authenticated using Web PKI
+	require.True(t, clientConfig.JWKS)
+	require.Nil(t, clientConfig.SPIFFEAuth)
+
+	bundle, err := ds.FetchBundle(context.Background(), trustDomain.IDString())
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, endpointBundle.Proto(), bundle)
+}
+
+func TestBundleUpdaterBootstrap(t *testing.T) {
+	fingerprint := sha256.Sum256([]byte("certificate"))
+	endpointBundle := bundleutil.BundleFromRootCA(trustDomain, createCACertificate(t, "bundle"))
+
+	for _, tt := range []struct {
+		name          string
+		profile       HTTPSSPIFFEProfile
+		localBundle   bool
+		expectAuth    *SPIFFEAuthConfig
+		expectUpdated bool
+		err           string
+	}{
+		{
+			name: "bootstrap",
+			profile: HTTPSSPIFFEProfile{
+				EndpointSPIFFEID:     serverID,
+				BootstrapFingerprint: hex.EncodeToString(fingerprint[:]),
+			},
+			expectAuth: &SPIFFEAuthConfig{
+				EndpointSpiffeID:     serverID,
+				BootstrapFingerprint: fingerprint[:],
+			},
+			expectUpdated: true,
+		},
+		{
+			name: "already bootstrapped",
+			profile: HTTPSSPIFFEProfile{
+				EndpointSPIFFEID:     serverID,
+				BootstrapFingerprint: hex.EncodeToString(fingerprint[:]),
+			},
+			localBundle: true,
+			expectAuth: &SPIFFEAuthConfig{
+				EndpointSpiffeID: serverID,
+				RootCAs:          endpointBundle.RootCAs(),
+			},
+		},
+		{
+			name: "endpoint in another trust domain",
+			profile: HTTPSSPIFFEProfile{
+				EndpointSPIFFEID:     spiffeid.RequireFromString("spiffe://other.test/server"),
+				BootstrapFingerprint: hex.EncodeToString(fingerprint[:]),
+			},
+			err: `can't bootstrap federation relationship: endpoint SPIFFE ID "spiffe://other.test/server" is not a member of trust domain "domain.test"`,
+		},
+		{
+			name: "invalid fingerprint",
+			profile: HTTPSSPIFFEProfile{
+				EndpointSPIFFEID:     serverID,
+				BootstrapFingerprint: "abcd",
+			},
+			err: `can't bootstrap federation relationship: fingerprint "abcd" is not a hex encoded SHA-256 hash`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ds := fakedatastore.New(t)
+			if tt.localBundle {
+				_, err := ds.CreateBundle(context.Background(), endpointBundle.Proto())
+				require.NoError(t, err)
+			}
+
+			var clientConfig ClientConfig
+			updater := NewBundleUpdater(BundleUpdaterConfig{
+				DataStore:   ds,
+				TrustDomain: trustDomain,
+				TrustDomainConfig: TrustDomainConfig{
+					EndpointURL:     "ENDPOINT_ADDRESS",
+					EndpointProfile: tt.profile,
+				},
+				newClientHook: func(config ClientConfig) (Client, error) {
+					clientConfig = config
+					return fakeClient{bundle: endpointBundle}, nil
+				},
+			})
+
+			_, updatedBundle, err := updater.UpdateBundle(context.Background())
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectAuth, clientConfig.SPIFFEAuth)
+			require.Equal(t, tt.expectUpdated, updatedBundle != nil)
+		})
+	}
+}
+
 func TestBundleUpdaterConfiguration(t *testing.T) {
 	configs := []TrustDomainConfig{
 		{
@@ -156,11 +280,16 @@ func TestBundleUpdaterConfiguration(t *testing.T) {
 		},
 	}
 
-	updater := NewBundleUpdater(BundleUpdaterConfig{})
+	updater := NewBundleUpdater(BundleUpdaterConfig{}).(*bundleUpdater)
 
 	for _, config := range configs {
+		cache := updater.cache
 		assert.True(t, updater.SetTrustDomainConfig(config), "config should have changed")
+		assert.NotSame(t, cache, updater.cache, "response cache should have been reset")
+
+		cache = updater.cache
 		assert.False(t, updater.SetTrustDomainConfig(config), "config should not have changed")
+		assert.Same(t, cache, updater.cache, "response cache should not have been reset")
 	}
 }
 
@@ -584,6 +584,14 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 	return nil
 }
 
+// JournalEntries returns a copy of the entries recorded in the journal.
+func (m *Manager) JournalEntries() *JournalEntries {
+	if m.journal == nil {
+		return new(JournalEntries)
+	}
+	return m.journal.Entries()
+}
+
 func (m *Manager) journalPath() string {
 	return filepath.Join(m.c.Dir, "journal.pem")
 }
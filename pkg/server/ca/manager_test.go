@@ -112,6 +112,17 @@ func (s *ManagerSuite) TestPersistence() {
 	s.Require().Nil(s.nextJWTKey())
 }
 
+func (s *ManagerSuite) TestJournalEntries() {
+	s.Require().Empty(NewManager(s.selfSignedConfig()).JournalEntries().X509CAs)
+
+	s.initSelfSignedManager()
+	entries := s.m.JournalEntries()
+	s.Require().Len(entries.X509CAs, 1)
+	s.Require().Equal(s.currentX509CA().Certificate.Raw, entries.X509CAs[0].Certificate)
+	s.Require().Len(entries.JwtKeys, 1)
+	s.Require().Equal(s.currentJWTKey().Kid, entries.JwtKeys[0].Kid)
+}
+
 func (s *ManagerSuite) TestPersistenceFailsIfKeyManagerLosesKeys() {
 	s.initSelfSignedManager()
 	x509CA, jwtKey := s.currentX509CA(), s.currentJWTKey()
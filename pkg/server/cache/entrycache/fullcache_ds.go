@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/server/api"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -130,7 +131,7 @@ func (it *agentIteratorDS) Err() error {
 	return it.err
 }
 
-// Fetches all agent selectors from the datastore and stores them in the iterator.
+// Fetches all agent selectors, including those managed by operators, from the datastore and stores them in the iterator.
 func (it *agentIteratorDS) fetchAgents(ctx context.Context) ([]Agent, error) {
 	now := time.Now()
 	resp, err := it.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
@@ -141,6 +142,19 @@ func (it *agentIteratorDS) fetchAgents(ctx context.Context) ([]Agent, error) {
 		return nil, err
 	}
 
+	// Selectors managed by operators are added to those set by node
+	// attestors and resolvers
+	operatorResp, err := it.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+		DataConsistency: datastore.TolerateStale,
+		ValidAt:         now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for spiffeID, selectors := range operatorResp.Selectors {
+		resp.Selectors[spiffeID] = selector.Dedupe(resp.Selectors[spiffeID], selectors)
+	}
+
 	agents := make([]Agent, 0, len(resp.Selectors))
 	for spiffeID, selectors := range resp.Selectors {
 		agentID, err := spiffeid.FromString(spiffeID)
@@ -132,6 +132,48 @@ func TestAgentIteratorDS(t *testing.T) {
 		assert.ElementsMatch(t, expectedAgents, agents)
 	})
 
+	t.Run("with operator selectors", func(t *testing.T) {
+		// agent0 gets an extra selector, along with a duplicated one, and
+		// agent10 only has operator selectors
+		_, err := ds.AddOperatorNodeSelectors(ctx, expectedAgents[0].ID.String(), []*common.Selector{
+			{Type: "a", Value: "1"},
+			{Type: "d", Value: "4"},
+		})
+		require.NoError(t, err)
+		agentID := spiffeid.RequireFromString("spiffe://example.org/spire/agent/agent10")
+		createAttestedNode(t, ds, &common.AttestedNode{
+			SpiffeId:            agentID.String(),
+			AttestationDataType: testNodeAttestor,
+			CertSerialNumber:    "10",
+			CertNotAfter:        time.Now().Add(24 * time.Hour).Unix(),
+		})
+		_, err = ds.AddOperatorNodeSelectors(ctx, agentID.String(), []*common.Selector{{Type: "e", Value: "5"}})
+		require.NoError(t, err)
+
+		expected := append([]Agent{}, expectedAgents...)
+		expected[0] = Agent{
+			ID: expectedAgents[0].ID,
+			Selectors: api.ProtoFromSelectors([]*common.Selector{
+				{Type: "a", Value: "1"},
+				{Type: "b", Value: "2"},
+				{Type: "c", Value: "3"},
+				{Type: "d", Value: "4"},
+			}),
+		}
+		expected = append(expected, Agent{
+			ID:        agentID,
+			Selectors: api.ProtoFromSelectors([]*common.Selector{{Type: "e", Value: "5"}}),
+		})
+
+		it := makeAgentIteratorDS(ds)
+		var agents []Agent
+		for it.Next(ctx) {
+			agents = append(agents, it.Agent())
+		}
+		require.NoError(t, it.Err())
+		assert.ElementsMatch(t, expected, agents)
+	})
+
 	t.Run("datastore error", func(t *testing.T) {
 		it := makeAgentIteratorDS(ds)
 		ds.SetNextError(errors.New("some datastore error"))
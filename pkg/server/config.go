@@ -10,6 +10,7 @@ import (
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/endpoints"
@@ -30,6 +31,10 @@ type Config struct {
 	// If true enables audit logs
 	AuditLogEnabled bool
 
+	// AuditLogSinks, if set, configures the sinks audit logs are written to.
+	// Otherwise, audit logs are written to the server log.
+	AuditLogSinks *audit.SinksConfig
+
 	// Address of SPIRE server
 	BindAddress *net.TCPAddr
 
@@ -98,12 +103,33 @@ type Config struct {
 	// CacheReloadInterval controls how often the in-memory entry cache reloads
 	CacheReloadInterval time.Duration
 
+	// EventsRetention controls how long datastore events are kept before
+	// they are pruned
+	EventsRetention time.Duration
+
+	// EntryHistoryRetention controls how long registration entry revisions
+	// are kept before they are pruned
+	EntryHistoryRetention time.Duration
+
+	// PruneAttestedNodesExpiredFor controls how long the SVID of an attested
+	// node must have been expired for before the node is pruned. Attested
+	// nodes are not pruned if zero.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// PruneNonReattestableNodesOnly restricts attested node pruning to
+	// nodes that cannot reattest
+	PruneNonReattestableNodesOnly bool
+
 	// AuthPolicyEngineConfig determines the config for authz policy
 	AuthOpaPolicyEngineConfig *authpolicy.OpaEngineConfig
 
 	// AdminIDs are a list of fixed IDs that when presented by a caller in an
 	// X509-SVID, are granted admin rights.
 	AdminIDs []spiffeid.ID
+
+	// Roles holds the built-in roles bound to callers, which are granted
+	// access to a subset of the admin APIs.
+	Roles *authpolicy.Roles
 }
 
 type ExperimentalConfig struct {
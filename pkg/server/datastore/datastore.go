@@ -33,19 +33,35 @@ type DataStore interface {
 	PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) error
 	UpdateRegistrationEntry(context.Context, *common.RegistrationEntry, *common.RegistrationEntryMask) (*common.RegistrationEntry, error)
 
+	// Entry revisions
+	FetchRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (*RegistrationEntryRevision, error)
+	ListRegistrationEntryRevisions(ctx context.Context, entryID string) ([]*RegistrationEntryRevision, error)
+	PruneRegistrationEntryRevisions(ctx context.Context, olderThan time.Time) error
+	RestoreRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (*common.RegistrationEntry, error)
+
 	// Nodes
+	BanAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	CountAttestedNodes(context.Context) (int32, error)
 	CreateAttestedNode(context.Context, *common.AttestedNode) (*common.AttestedNode, error)
 	DeleteAttestedNode(ctx context.Context, spiffeID string) (*common.AttestedNode, error)
+	DeleteAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	FetchAttestedNode(ctx context.Context, spiffeID string) (*common.AttestedNode, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
+	PruneAttestedNodes(ctx context.Context, expiredBefore time.Time, includeReattestable bool, limit int) ([]*common.AttestedNode, error)
 	UpdateAttestedNode(context.Context, *common.AttestedNode, *common.AttestedNodeMask) (*common.AttestedNode, error)
+	UpdateAttestedNodesStatus(ctx context.Context, statuses []*AttestedNodeStatus) error
 
 	// Node selectors
 	GetNodeSelectors(ctx context.Context, spiffeID string, dataConsistency DataConsistency) ([]*common.Selector, error)
 	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	SetNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) error
 
+	// Operator node selectors
+	AddOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error)
+	GetOperatorNodeSelectors(ctx context.Context, spiffeID string, dataConsistency DataConsistency) ([]*common.Selector, error)
+	ListOperatorNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
+	RemoveOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error)
+
 	// Tokens
 	CreateJoinToken(context.Context, *JoinToken) error
 	DeleteJoinToken(ctx context.Context, token string) error
@@ -58,6 +74,11 @@ type DataStore interface {
 	ListFederationRelationships(context.Context, *ListFederationRelationshipsRequest) (*ListFederationRelationshipsResponse, error)
 	DeleteFederationRelationship(context.Context, spiffeid.TrustDomain) error
 	UpdateFederationRelationship(context.Context, *FederationRelationship, *types.FederationRelationshipMask) (*FederationRelationship, error)
+
+	// Events
+	GetLatestEventID(context.Context) (uint, error)
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	PruneEvents(ctx context.Context, olderThan time.Time) error
 }
 
 // DataConsistency indicates the required data consistency for a read operation.
@@ -136,6 +157,17 @@ type ListAttestedNodesRequest struct {
 	FetchSelectors    bool
 	Pagination        *Pagination
 	ByCanReattest     *bool
+	ByAgentVersion    string
+	// ByLastSeenBefore also matches nodes that were never seen
+	ByLastSeenBefore time.Time
+}
+
+// AttestedNodeStatus is the status an agent reports to the server.
+type AttestedNodeStatus struct {
+	SpiffeID string
+	// AgentVersion is left unchanged when empty
+	AgentVersion string
+	LastSeenAt   time.Time
 }
 
 type ListAttestedNodesResponse struct {
@@ -189,6 +221,7 @@ type BundleEndpointType string
 const (
 	BundleEndpointSPIFFE BundleEndpointType = "https_spiffe"
 	BundleEndpointWeb    BundleEndpointType = "https_web"
+	BundleEndpointJWKS   BundleEndpointType = "https_jwks"
 )
 
 type FederationRelationship struct {
@@ -200,3 +233,86 @@ type FederationRelationship struct {
 	// Fields only used for 'https_spiffe' bundle endpoint profile
 	EndpointSPIFFEID spiffeid.ID
 }
+
+// EventType is the type of change recorded by an event.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// EventResourceType is the type of the resource an event refers to.
+type EventResourceType string
+
+const (
+	// EntryEvent events refer to registration entries, by entry ID.
+	EntryEvent EventResourceType = "entry"
+	// AttestedNodeEvent events refer to attested nodes, by SPIFFE ID.
+	AttestedNodeEvent EventResourceType = "attested_node"
+	// BundleEvent events refer to bundles, by trust domain ID.
+	BundleEvent EventResourceType = "bundle"
+	// FederationRelationshipEvent events refer to federation relationships,
+	// by trust domain name.
+	FederationRelationshipEvent EventResourceType = "federation_relationship"
+)
+
+// Event records a change made to a resource in the datastore. Events are
+// ordered by ID, which increases monotonically. Since IDs are assigned before
+// the change is committed, an event can become visible after events with a
+// greater ID when changes are made concurrently.
+type Event struct {
+	ID           uint
+	Type         EventType
+	ResourceType EventResourceType
+	ResourceID   string
+	CreatedAt    time.Time
+}
+
+type ListEventsRequest struct {
+	// AfterID restricts the results to the events with a greater ID.
+	AfterID uint
+	// ByIDs restricts the results to the events with the given IDs.
+	ByIDs           []uint
+	ByResourceTypes []EventResourceType
+	// Limit is the maximum number of events returned. Zero means no limit.
+	Limit int32
+}
+
+type ListEventsResponse struct {
+	Events []*Event
+}
+
+// RegistrationEntryRevision records a change made to a registration entry.
+// Revisions are numbered per entry, starting at 1.
+type RegistrationEntryRevision struct {
+	EntryID    string
+	Revision   int64
+	ChangeType EventType
+	// ChangedBy identifies who made the change, as set with WithChangeAuthor.
+	// It is empty for changes made by the server itself.
+	ChangedBy string
+	ChangedAt time.Time
+	// ChangedFields lists the fields modified by an update, named after the
+	// fields of the Entry API type.
+	ChangedFields []string
+	// Entry is the state of the entry after the change. For deletions, it is
+	// the state of the entry when it was deleted.
+	Entry *common.RegistrationEntry
+}
+
+type changeAuthorKey struct{}
+
+// WithChangeAuthor returns a context that attributes the changes made to
+// registration entries with it to the given author.
+func WithChangeAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, changeAuthorKey{}, author)
+}
+
+// ChangeAuthorFromContext returns the author set with WithChangeAuthor, or an
+// empty string if there is none.
+func ChangeAuthorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(changeAuthorKey{}).(string)
+	return author
+}
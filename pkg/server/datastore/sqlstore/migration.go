@@ -167,11 +167,21 @@ import (
 // | v1.6.0  | 20     | Removes x509_svid_ttl column from registered_entries                      |
 // |         |--------|---------------------------------------------------------------------------|
 // |         | 21     | Add index in hint column from registered_entries                          |
+// |*********|********|***************************************************************************|
+// | v1.7.0  | 22     | Added events table                                                        |
+// |         |--------|---------------------------------------------------------------------------|
+// |         | 23     | Added registered_entry_revisions table                                    |
+// |         |--------|---------------------------------------------------------------------------|
+// |         | 24     | Added agent_version and last_seen_at columns to attested_node_entries     |
+// |         |--------|---------------------------------------------------------------------------|
+// |         | 25     | Added reattest_requested column to attested_node_entries                  |
+// |         |--------|---------------------------------------------------------------------------|
+// |         | 26     | Added operator_node_selectors table                                       |
 // ================================================================================================
 
 const (
 	// the latest schema version of the database in the code
-	latestSchemaVersion = 21
+	latestSchemaVersion = 26
 
 	// lastMinorReleaseSchemaVersion is the schema version supported by the
 	// last minor release. When the migrations are opportunistically pruned
@@ -327,12 +337,15 @@ func initDB(db *gorm.DB, dbType string, log logrus.FieldLogger) (err error) {
 		&Bundle{},
 		&AttestedNode{},
 		&NodeSelector{},
+		&OperatorNodeSelector{},
 		&RegisteredEntry{},
 		&JoinToken{},
 		&Selector{},
 		&Migration{},
 		&DNSName{},
 		&FederatedTrustDomain{},
+		&Event{},
+		&RegisteredEntryRevision{},
 	}
 
 	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
@@ -394,6 +407,16 @@ func migrateVersion(tx *gorm.DB, currVersion int, log logrus.FieldLogger) (versi
 	case 20:
 		// DEPRECATED: remove this migration in 1.7.0
 		err = migrateToV21(tx)
+	case 21:
+		err = migrateToV22(tx)
+	case 22:
+		err = migrateToV23(tx)
+	case 23:
+		err = migrateToV24(tx)
+	case 24:
+		err = migrateToV25(tx)
+	case 25:
+		err = migrateToV26(tx)
 	default:
 		err = sqlError.New("no migration support for unknown schema version %d", currVersion)
 	}
@@ -420,6 +443,41 @@ func migrateToV21(tx *gorm.DB) error {
 	return nil
 }
 
+func migrateToV22(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&Event{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV23(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntryRevision{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV24(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV25(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV26(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&OperatorNodeSelector{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 // dropColumnIfExists drops the column from the model's table, if it exists. All data in
 // the dropped column will be lost.
 func dropColumnIfExists(tx *gorm.DB, model interface{}, columnName string) error {
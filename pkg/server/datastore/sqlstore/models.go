@@ -26,13 +26,16 @@ type Bundle struct {
 type AttestedNode struct {
 	Model
 
-	SpiffeID        string `gorm:"unique_index"`
-	DataType        string
-	SerialNumber    string
-	ExpiresAt       time.Time `gorm:"index"`
-	NewSerialNumber string
-	NewExpiresAt    *time.Time
-	CanReattest     bool
+	SpiffeID          string `gorm:"unique_index"`
+	DataType          string
+	SerialNumber      string
+	ExpiresAt         time.Time `gorm:"index"`
+	NewSerialNumber   string
+	NewExpiresAt      *time.Time
+	CanReattest       bool
+	AgentVersion      string
+	LastSeenAt        *time.Time `gorm:"index"`
+	ReattestRequested bool
 
 	Selectors []*NodeSelector
 }
@@ -69,6 +72,21 @@ func (NodeSelector) TableName() string {
 	return "node_resolver_map_entries"
 }
 
+// OperatorNodeSelector holds a node selector managed by operators by spiffe
+// ID. Unlike NodeSelector, it is not replaced when the node attests again.
+type OperatorNodeSelector struct {
+	Model
+
+	SpiffeID string `gorm:"unique_index:idx_operator_node_selector"`
+	Type     string `gorm:"unique_index:idx_operator_node_selector"`
+	Value    string `gorm:"unique_index:idx_operator_node_selector"`
+}
+
+// TableName gets table name of OperatorNodeSelector
+func (OperatorNodeSelector) TableName() string {
+	return "operator_node_selectors"
+}
+
 // RegisteredEntry holds a registered entity entry
 type RegisteredEntry struct {
 	Model
@@ -162,6 +180,40 @@ func (FederatedTrustDomain) TableName() string {
 	return "federated_trust_domains"
 }
 
+// Event records a change made to a resource. Events are used to provide a
+// change feed and are pruned after a retention period.
+type Event struct {
+	ID           uint      `gorm:"primary_key"`
+	CreatedAt    time.Time `gorm:"index"`
+	Type         string
+	ResourceType string
+	ResourceID   string
+}
+
+// TableName gets table name of Event
+func (Event) TableName() string {
+	return "events"
+}
+
+// RegisteredEntryRevision holds a revision of a registration entry. Revisions
+// are pruned after a retention period.
+type RegisteredEntryRevision struct {
+	ID            uint      `gorm:"primary_key"`
+	CreatedAt     time.Time `gorm:"index"`
+	EntryID       string    `gorm:"unique_index:idx_entry_revision"`
+	Revision      int64     `gorm:"unique_index:idx_entry_revision"`
+	ChangeType    string
+	ChangedBy     string
+	ChangedFields string
+	// Data is the marshaled state of the entry after the change
+	Data []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 16MB) - doesn't affect PostgreSQL/SQLite
+}
+
+// TableName gets table name of RegisteredEntryRevision
+func (RegisteredEntryRevision) TableName() string {
+	return "registered_entry_revisions"
+}
+
 // Migration holds database schema version number, and
 // the SPIRE Code version number
 type Migration struct {
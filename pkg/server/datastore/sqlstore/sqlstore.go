@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/spiffe/spire-api-sdk/proto/spire/api/types"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/protoutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/datastore"
@@ -148,7 +150,7 @@ func (ds *Plugin) AppendBundle(ctx context.Context, b *common.Bundle) (bundle *c
 // DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
 func (ds *Plugin) DeleteBundle(ctx context.Context, trustDomainID string, mode datastore.DeleteMode) (err error) {
 	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		err = deleteBundle(tx, trustDomainID, mode)
+		err = deleteBundle(tx, trustDomainID, mode, datastore.ChangeAuthorFromContext(ctx))
 		return err
 	})
 }
@@ -240,7 +242,7 @@ func (ds *Plugin) CountAttestedNodes(ctx context.Context) (count int32, err erro
 func (ds *Plugin) ListAttestedNodes(ctx context.Context,
 	req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
 	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
-		resp, err = listAttestedNodes(ctx, ds.db, ds.log, req)
+		resp, err = listAttestedNodes(ctx, ds.db, ds.db.databaseType, ds.db.supportsCTE, ds.log, req)
 		return err
 	}); err != nil {
 		return nil, err
@@ -270,6 +272,61 @@ func (ds *Plugin) DeleteAttestedNode(ctx context.Context, spiffeID string) (atte
 	return attestedNode, nil
 }
 
+// DeleteAttestedNodes deletes the page of attested nodes matching the request.
+// The nodes are listed and deleted in a single transaction, so nodes that no
+// longer match the request by then are left untouched. The response holds
+// the deleted nodes and the token of the next page, if any.
+func (ds *Plugin) DeleteAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
+	if err = ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = deleteAttestedNodes(ctx, ds.db, ds.log, tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// BanAttestedNodes bans the page of attested nodes matching the request, by
+// clearing their serial numbers. The nodes are listed and banned in a single
+// transaction, so nodes that no longer match the request by then are left
+// untouched. Nodes that are already banned are skipped. The response holds
+// the banned nodes and the token of the next page, if any.
+func (ds *Plugin) BanAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (resp *datastore.ListAttestedNodesResponse, err error) {
+	if err = ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = banAttestedNodes(ctx, ds.db, ds.log, tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneAttestedNodes deletes up to limit attested nodes whose SVID expired
+// before the given time, along with their selectors, and returns the deleted
+// nodes. Nodes that can reattest are only pruned if includeReattestable is
+// true. Banned nodes are never pruned, since that would lift the ban. A limit
+// of zero prunes all the matching nodes.
+func (ds *Plugin) PruneAttestedNodes(ctx context.Context, expiredBefore time.Time, includeReattestable bool, limit int) (attestedNodes []*common.AttestedNode, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		attestedNodes, err = pruneAttestedNodes(tx, expiredBefore, includeReattestable, limit)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return attestedNodes, nil
+}
+
+// UpdateAttestedNodesStatus updates the version and last seen time reported
+// by the given attested nodes in a single transaction. SPIFFE IDs without an
+// attested node are ignored, as are statuses older than the last one recorded
+// for the node, so that a late transaction cannot roll back a newer status.
+// Since agents report their status continuously, no events are emitted.
+func (ds *Plugin) UpdateAttestedNodesStatus(ctx context.Context, statuses []*datastore.AttestedNodeStatus) (err error) {
+	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		return updateAttestedNodesStatus(tx, statuses)
+	})
+}
+
 // SetNodeSelectors sets node (agent) selectors by SPIFFE ID, deleting old selectors first
 func (ds *Plugin) SetNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) (err error) {
 	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -282,18 +339,61 @@ func (ds *Plugin) SetNodeSelectors(ctx context.Context, spiffeID string, selecto
 func (ds *Plugin) GetNodeSelectors(ctx context.Context, spiffeID string,
 	dataConsistency datastore.DataConsistency) (selectors []*common.Selector, err error) {
 	if dataConsistency == datastore.TolerateStale && ds.roDb != nil {
-		return getNodeSelectors(ctx, ds.roDb, spiffeID)
+		return getNodeSelectors(ctx, ds.roDb, NodeSelector{}.TableName(), spiffeID)
 	}
-	return getNodeSelectors(ctx, ds.db, spiffeID)
+	return getNodeSelectors(ctx, ds.db, NodeSelector{}.TableName(), spiffeID)
 }
 
 // ListNodeSelectors gets node (agent) selectors by SPIFFE ID
 func (ds *Plugin) ListNodeSelectors(ctx context.Context,
 	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
 	if req.DataConsistency == datastore.TolerateStale && ds.roDb != nil {
-		return listNodeSelectors(ctx, ds.roDb, req)
+		return listNodeSelectors(ctx, ds.roDb, NodeSelector{}.TableName(), req)
+	}
+	return listNodeSelectors(ctx, ds.db, NodeSelector{}.TableName(), req)
+}
+
+// AddOperatorNodeSelectors adds node (agent) selectors managed by operators by
+// SPIFFE ID and returns the resulting set. Unlike the selectors set with
+// SetNodeSelectors, they are kept when the node attests again.
+func (ds *Plugin) AddOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) (operatorSelectors []*common.Selector, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		operatorSelectors, err = addOperatorNodeSelectors(tx, spiffeID, selectors)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return operatorSelectors, nil
+}
+
+// RemoveOperatorNodeSelectors removes node (agent) selectors managed by
+// operators by SPIFFE ID and returns the resulting set
+func (ds *Plugin) RemoveOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) (operatorSelectors []*common.Selector, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		operatorSelectors, err = removeOperatorNodeSelectors(tx, spiffeID, selectors)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return operatorSelectors, nil
+}
+
+// GetOperatorNodeSelectors gets node (agent) selectors managed by operators by SPIFFE ID
+func (ds *Plugin) GetOperatorNodeSelectors(ctx context.Context, spiffeID string,
+	dataConsistency datastore.DataConsistency) (selectors []*common.Selector, err error) {
+	if dataConsistency == datastore.TolerateStale && ds.roDb != nil {
+		return getNodeSelectors(ctx, ds.roDb, OperatorNodeSelector{}.TableName(), spiffeID)
+	}
+	return getNodeSelectors(ctx, ds.db, OperatorNodeSelector{}.TableName(), spiffeID)
+}
+
+// ListOperatorNodeSelectors gets node (agent) selectors managed by operators by SPIFFE ID
+func (ds *Plugin) ListOperatorNodeSelectors(ctx context.Context,
+	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
+	if req.DataConsistency == datastore.TolerateStale && ds.roDb != nil {
+		return listNodeSelectors(ctx, ds.roDb, OperatorNodeSelector{}.TableName(), req)
 	}
-	return listNodeSelectors(ctx, ds.db, req)
+	return listNodeSelectors(ctx, ds.db, OperatorNodeSelector{}.TableName(), req)
 }
 
 // CreateRegistrationEntry stores the given registration entry
@@ -327,7 +427,7 @@ func (ds *Plugin) createOrReturnRegistrationEntry(ctx context.Context,
 			existing = true
 			return nil
 		}
-		registrationEntry, err = createRegistrationEntry(tx, entry)
+		registrationEntry, err = createRegistrationEntry(tx, entry, datastore.ChangeAuthorFromContext(ctx))
 		return err
 	}); err != nil {
 		return nil, false, err
@@ -365,7 +465,7 @@ func (ds *Plugin) ListRegistrationEntries(ctx context.Context,
 // UpdateRegistrationEntry updates an existing registration entry
 func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (entry *common.RegistrationEntry, err error) {
 	if err = ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		entry, err = updateRegistrationEntry(tx, e, mask)
+		entry, err = updateRegistrationEntry(tx, e, mask, datastore.ChangeAuthorFromContext(ctx))
 		return err
 	}); err != nil {
 		return nil, err
@@ -377,7 +477,7 @@ func (ds *Plugin) UpdateRegistrationEntry(ctx context.Context, e *common.Registr
 func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context,
 	entryID string) (registrationEntry *common.RegistrationEntry, err error) {
 	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		registrationEntry, err = deleteRegistrationEntry(tx, entryID)
+		registrationEntry, err = deleteRegistrationEntry(tx, entryID, datastore.ChangeAuthorFromContext(ctx))
 		return err
 	}); err != nil {
 		return nil, err
@@ -389,11 +489,57 @@ func (ds *Plugin) DeleteRegistrationEntry(ctx context.Context,
 // before the date in the message
 func (ds *Plugin) PruneRegistrationEntries(ctx context.Context, expiresBefore time.Time) (err error) {
 	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
-		err = pruneRegistrationEntries(tx, expiresBefore, ds.log)
+		err = pruneRegistrationEntries(tx, expiresBefore, datastore.ChangeAuthorFromContext(ctx), ds.log)
+		return err
+	})
+}
+
+// FetchRegistrationEntryRevision fetches a revision of a registration entry.
+// It returns nil if the revision does not exist.
+func (ds *Plugin) FetchRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (entryRevision *datastore.RegistrationEntryRevision, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		entryRevision, err = fetchRegistrationEntryRevision(tx, entryID, revision)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return entryRevision, nil
+}
+
+// ListRegistrationEntryRevisions lists the revisions of a registration entry,
+// ordered by revision number
+func (ds *Plugin) ListRegistrationEntryRevisions(ctx context.Context, entryID string) (revisions []*datastore.RegistrationEntryRevision, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		revisions, err = listRegistrationEntryRevisions(tx, entryID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// PruneRegistrationEntryRevisions deletes all registration entry revisions
+// created before the given time
+func (ds *Plugin) PruneRegistrationEntryRevisions(ctx context.Context, olderThan time.Time) (err error) {
+	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		err = pruneRegistrationEntryRevisions(tx, olderThan)
 		return err
 	})
 }
 
+// RestoreRegistrationEntryRevision restores a registration entry to the state
+// it had at the given revision. The entry is updated if it exists, or
+// recreated with the same entry ID if it was deleted.
+func (ds *Plugin) RestoreRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (entry *common.RegistrationEntry, err error) {
+	if err = ds.withReadModifyWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		entry, err = restoreRegistrationEntryRevision(ctx, ds.db, tx, entryID, revision, datastore.ChangeAuthorFromContext(ctx))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
 // CreateJoinToken takes a Token message and stores it
 func (ds *Plugin) CreateJoinToken(ctx context.Context, token *datastore.JoinToken) (err error) {
 	if token == nil || token.Token == "" || token.Expiry.IsZero() {
@@ -506,6 +652,38 @@ func (ds *Plugin) UpdateFederationRelationship(ctx context.Context, fr *datastor
 	})
 }
 
+// GetLatestEventID returns the ID of the most recent event, or zero if there
+// are no events.
+func (ds *Plugin) GetLatestEventID(ctx context.Context) (id uint, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		id, err = getLatestEventID(tx)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ListEvents lists the events recorded after the given event ID, in order
+func (ds *Plugin) ListEvents(ctx context.Context, req *datastore.ListEventsRequest) (resp *datastore.ListEventsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneEvents deletes all events created before the given time, except for
+// the latest event
+func (ds *Plugin) PruneEvents(ctx context.Context, olderThan time.Time) (err error) {
+	return ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		err = pruneEvents(tx, olderThan)
+		return err
+	})
+}
+
 // Configure parses HCL config payload into config struct, opens new DB based on the result, and
 // prunes all orphaned records
 func (ds *Plugin) Configure(ctx context.Context, hclConfiguration string) error {
@@ -762,6 +940,10 @@ func createBundle(tx *gorm.DB, bundle *common.Bundle) (*common.Bundle, error) {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventCreated, datastore.BundleEvent, model.TrustDomain); err != nil {
+		return nil, err
+	}
+
 	return bundle, nil
 }
 
@@ -785,6 +967,10 @@ func updateBundle(tx *gorm.DB, newBundle *common.Bundle, mask *common.BundleMask
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventUpdated, datastore.BundleEvent, model.TrustDomain); err != nil {
+		return nil, err
+	}
+
 	return newBundle, nil
 }
 
@@ -810,6 +996,10 @@ func applyBundleMask(model *Bundle, newBundle *common.Bundle, inputMask *common.
 		bundle.JwtSigningKeys = newBundle.JwtSigningKeys
 	}
 
+	if inputMask.SequenceNumber {
+		bundle.SequenceNumber = newBundle.SequenceNumber
+	}
+
 	newModel, err := bundleToModel(bundle)
 	if err != nil {
 		return nil, nil, err
@@ -871,6 +1061,7 @@ func appendBundle(tx *gorm.DB, b *common.Bundle) (*common.Bundle, error) {
 
 	bundle, changed := bundleutil.MergeBundles(bundle, b)
 	if changed {
+		bundle.SequenceNumber++
 		newModel, err := bundleToModel(bundle)
 		if err != nil {
 			return nil, err
@@ -879,12 +1070,15 @@ func appendBundle(tx *gorm.DB, b *common.Bundle) (*common.Bundle, error) {
 		if err := tx.Save(model).Error; err != nil {
 			return nil, sqlError.Wrap(err)
 		}
+		if err := createEvent(tx, datastore.EventUpdated, datastore.BundleEvent, model.TrustDomain); err != nil {
+			return nil, err
+		}
 	}
 
 	return bundle, nil
 }
 
-func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode) error {
+func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode, changedBy string) error {
 	model := new(Bundle)
 	if err := tx.Find(model, "trust_domain = ?", trustDomainID).Error; err != nil {
 		return sqlError.Wrap(err)
@@ -898,6 +1092,26 @@ func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode)
 	}
 
 	if entriesCount > 0 {
+		// Record an event and a revision for each of the associated entries,
+		// which are either deleted or updated depending on the mode
+		var entryModels []RegisteredEntry
+		if err := tx.
+			Joins("JOIN federated_registration_entries ON federated_registration_entries.registered_entry_id = registered_entries.id").
+			Where("federated_registration_entries.bundle_id = ?", model.ID).
+			Find(&entryModels).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+		entryIDs := make([]string, 0, len(entryModels))
+		entries := make([]*common.RegistrationEntry, 0, len(entryModels))
+		for _, entryModel := range entryModels {
+			entry, err := modelToEntry(tx, entryModel)
+			if err != nil {
+				return err
+			}
+			entryIDs = append(entryIDs, entryModel.EntryID)
+			entries = append(entries, entry)
+		}
+
 		switch mode {
 		case datastore.Delete:
 			// TODO: figure out how to do this gracefully with GORM.
@@ -910,10 +1124,30 @@ func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode)
 					bundle_id = ?)`), model.ID).Error; err != nil {
 				return sqlError.Wrap(err)
 			}
+			if err := createEvents(tx, datastore.EventDeleted, datastore.EntryEvent, entryIDs); err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if err := createEntryRevision(tx, datastore.EventDeleted, entry, nil, changedBy); err != nil {
+					return err
+				}
+			}
 		case datastore.Dissociate:
 			if err := entriesAssociation.Clear().Error; err != nil {
 				return sqlError.Wrap(err)
 			}
+			if err := createEvents(tx, datastore.EventUpdated, datastore.EntryEvent, entryIDs); err != nil {
+				return err
+			}
+			for i, entryModel := range entryModels {
+				updated, err := modelToEntry(tx, entryModel)
+				if err != nil {
+					return err
+				}
+				if err := createEntryRevision(tx, datastore.EventUpdated, updated, entryChangedFields(entries[i], updated), changedBy); err != nil {
+					return err
+				}
+			}
 		default:
 			return status.Newf(codes.FailedPrecondition, "datastore-sql: cannot delete bundle; federated with %d registration entries", entriesCount).Err()
 		}
@@ -923,7 +1157,7 @@ func deleteBundle(tx *gorm.DB, trustDomainID string, mode datastore.DeleteMode)
 		return sqlError.Wrap(err)
 	}
 
-	return nil
+	return createEvent(tx, datastore.EventDeleted, datastore.BundleEvent, model.TrustDomain)
 }
 
 // fetchBundle returns the bundle matching the specified Trust Domain.
@@ -1022,6 +1256,8 @@ func pruneBundle(tx *gorm.DB, trustDomainID string, expiry time.Time, log logrus
 
 	// Update only if bundle was modified
 	if changed {
+		newBundle.RefreshHint = currentBundle.RefreshHint
+		newBundle.SequenceNumber = currentBundle.SequenceNumber + 1
 		_, err := updateBundle(tx, newBundle, nil)
 		if err != nil {
 			return false, fmt.Errorf("unable to write new bundle: %w", err)
@@ -1033,19 +1269,26 @@ func pruneBundle(tx *gorm.DB, trustDomainID string, expiry time.Time, log logrus
 
 func createAttestedNode(tx *gorm.DB, node *common.AttestedNode) (*common.AttestedNode, error) {
 	model := AttestedNode{
-		SpiffeID:        node.SpiffeId,
-		DataType:        node.AttestationDataType,
-		SerialNumber:    node.CertSerialNumber,
-		ExpiresAt:       time.Unix(node.CertNotAfter, 0),
-		NewSerialNumber: node.NewCertSerialNumber,
-		NewExpiresAt:    nullableUnixTimeToDBTime(node.NewCertNotAfter),
-		CanReattest:     node.CanReattest,
+		SpiffeID:          node.SpiffeId,
+		DataType:          node.AttestationDataType,
+		SerialNumber:      node.CertSerialNumber,
+		ExpiresAt:         time.Unix(node.CertNotAfter, 0),
+		NewSerialNumber:   node.NewCertSerialNumber,
+		NewExpiresAt:      nullableUnixTimeToDBTime(node.NewCertNotAfter),
+		CanReattest:       node.CanReattest,
+		AgentVersion:      node.AgentVersion,
+		LastSeenAt:        nullableUnixTimeToDBTime(node.LastSeenAt),
+		ReattestRequested: node.ReattestRequested,
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventCreated, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+		return nil, err
+	}
+
 	return modelToAttestedNode(model), nil
 }
 
@@ -1070,7 +1313,7 @@ func countAttestedNodes(tx *gorm.DB) (int32, error) {
 	return int32(count), nil
 }
 
-func listAttestedNodes(ctx context.Context, db *sqlDB, log logrus.FieldLogger, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+func listAttestedNodes(ctx context.Context, db queryContext, databaseType string, supportsCTE bool, log logrus.FieldLogger, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
 	if req.Pagination != nil && req.Pagination.PageSize == 0 {
 		return nil, status.Error(codes.InvalidArgument, "cannot paginate with pagesize = 0")
 	}
@@ -1079,7 +1322,7 @@ func listAttestedNodes(ctx context.Context, db *sqlDB, log logrus.FieldLogger, r
 	}
 
 	for {
-		resp, err := listAttestedNodesOnce(ctx, db, req)
+		resp, err := listAttestedNodesOnce(ctx, db, databaseType, supportsCTE, req)
 		if err != nil {
 			return nil, err
 		}
@@ -1150,8 +1393,8 @@ func filterNodesBySelectorSet(nodes []*common.AttestedNode, selectors []*common.
 	return filtered
 }
 
-func listAttestedNodesOnce(ctx context.Context, db *sqlDB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
-	query, args, err := buildListAttestedNodesQuery(db.databaseType, db.supportsCTE, req)
+func listAttestedNodesOnce(ctx context.Context, db queryContext, databaseType string, supportsCTE bool, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	query, args, err := buildListAttestedNodesQuery(databaseType, supportsCTE, req)
 	if err != nil {
 		return nil, sqlError.Wrap(err)
 	}
@@ -1292,6 +1535,18 @@ func buildListAttestedNodesQueryCTE(req *datastore.ListAttestedNodesRequest, dbT
 		}
 	}
 
+	// Filter by agent version
+	if req.ByAgentVersion != "" {
+		builder.WriteString("\t\tAND agent_version = ?\n")
+		args = append(args, req.ByAgentVersion)
+	}
+
+	// Filter by last seen, nodes that were never seen are included
+	if !req.ByLastSeenBefore.IsZero() {
+		builder.WriteString("\t\tAND (last_seen_at IS NULL OR last_seen_at < ?)\n")
+		args = append(args, req.ByLastSeenBefore)
+	}
+
 	builder.WriteString(")")
 	// Fetch all selectors from filtered entries
 	if fetchSelectors {
@@ -1318,7 +1573,10 @@ SELECT
 	expires_at,
 	new_serial_number,
 	new_expires_at,
-	can_reattest,`)
+	can_reattest,
+	agent_version,
+	last_seen_at,
+	reattest_requested,`)
 
 	// Add "optional" fields for selectors
 	if fetchSelectors {
@@ -1452,7 +1710,10 @@ SELECT
 	N.expires_at,
 	N.new_serial_number,
 	N.new_expires_at,
-	N.can_reattest,`)
+	N.can_reattest,
+	N.agent_version,
+	N.last_seen_at,
+	N.reattest_requested,`)
 	// Add "optional" fields for selectors
 	if fetchSelectors {
 		builder.WriteString(`
@@ -1518,6 +1779,18 @@ FROM attested_node_entries N
 				builder.WriteString("\t\tAND can_reattest = false\n")
 			}
 		}
+
+		// Filter by agent version
+		if req.ByAgentVersion != "" {
+			builder.WriteString(" AND N.agent_version = ?")
+			args = append(args, req.ByAgentVersion)
+		}
+
+		// Filter by last seen, nodes that were never seen are included
+		if !req.ByLastSeenBefore.IsZero() {
+			builder.WriteString(" AND (N.last_seen_at IS NULL OR N.last_seen_at < ?)")
+			args = append(args, req.ByLastSeenBefore)
+		}
 		return nil
 	}
 
@@ -1621,10 +1894,17 @@ func updateAttestedNode(tx *gorm.DB, n *common.AttestedNode, mask *common.Attest
 	if mask.CanReattest {
 		updates["can_reattest"] = n.CanReattest
 	}
+	if mask.ReattestRequested {
+		updates["reattest_requested"] = n.ReattestRequested
+	}
 	if err := tx.Model(&model).Updates(updates).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventUpdated, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+		return nil, err
+	}
+
 	return modelToAttestedNode(model), nil
 }
 
@@ -1638,9 +1918,170 @@ func deleteAttestedNode(tx *gorm.DB, spiffeID string) (*common.AttestedNode, err
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := deleteOperatorNodeSelectors(tx, model.SpiffeID); err != nil {
+		return nil, err
+	}
+
+	if err := createEvent(tx, datastore.EventDeleted, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+		return nil, err
+	}
+
 	return modelToAttestedNode(model), nil
 }
 
+func deleteAttestedNodes(ctx context.Context, db *sqlDB, log logrus.FieldLogger, tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	resp, err := listAttestedNodes(ctx, tx.CommonDB().(queryContext), db.databaseType, db.supportsCTE, log, req)
+	if err != nil {
+		return nil, err
+	}
+
+	selectors := make(map[string][]*common.Selector, len(resp.Nodes))
+	spiffeIDs := make([]string, 0, len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		selectors[node.SpiffeId] = node.Selectors
+		spiffeIDs = append(spiffeIDs, node.SpiffeId)
+	}
+
+	models, err := findAttestedNodes(tx, spiffeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestedNodes []*common.AttestedNode
+	for _, model := range models {
+		model := model
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if err := deleteOperatorNodeSelectors(tx, model.SpiffeID); err != nil {
+			return nil, err
+		}
+		if err := createEvent(tx, datastore.EventDeleted, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+			return nil, err
+		}
+		attestedNode := modelToAttestedNode(model)
+		attestedNode.Selectors = selectors[model.SpiffeID]
+		attestedNodes = append(attestedNodes, attestedNode)
+	}
+	resp.Nodes = attestedNodes
+	return resp, nil
+}
+
+func banAttestedNodes(ctx context.Context, db *sqlDB, log logrus.FieldLogger, tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	resp, err := listAttestedNodes(ctx, tx.CommonDB().(queryContext), db.databaseType, db.supportsCTE, log, req)
+	if err != nil {
+		return nil, err
+	}
+
+	selectors := make(map[string][]*common.Selector, len(resp.Nodes))
+	spiffeIDs := make([]string, 0, len(resp.Nodes))
+	for _, node := range resp.Nodes {
+		if nodeutil.IsAgentBanned(node) {
+			continue
+		}
+		selectors[node.SpiffeId] = node.Selectors
+		spiffeIDs = append(spiffeIDs, node.SpiffeId)
+	}
+
+	models, err := findAttestedNodes(tx, spiffeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var attestedNodes []*common.AttestedNode
+	for _, model := range models {
+		model := model
+		updates := map[string]interface{}{
+			"serial_number":     "",
+			"new_serial_number": "",
+		}
+		if err := tx.Model(&model).Updates(updates).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if err := createEvent(tx, datastore.EventUpdated, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+			return nil, err
+		}
+		attestedNode := modelToAttestedNode(model)
+		attestedNode.Selectors = selectors[model.SpiffeID]
+		attestedNodes = append(attestedNodes, attestedNode)
+	}
+	resp.Nodes = attestedNodes
+	return resp, nil
+}
+
+func pruneAttestedNodes(tx *gorm.DB, expiredBefore time.Time, includeReattestable bool, limit int) ([]*common.AttestedNode, error) {
+	query := tx.Where("expires_at < ?", expiredBefore).Where("serial_number <> ''")
+	if !includeReattestable {
+		query = query.Where("can_reattest = ?", false)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var models []AttestedNode
+	if err := query.Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var attestedNodes []*common.AttestedNode
+	for _, model := range models {
+		model := model
+		// Delete the selectors by ID to avoid gap locks, see setNodeSelectors
+		var ids []int64
+		if err := tx.Model(&NodeSelector{}).Where("spiffe_id = ?", model.SpiffeID).Pluck("id", &ids).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if len(ids) > 0 {
+			if err := tx.Where("id IN (?)", ids).Delete(&NodeSelector{}).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+		}
+		if err := deleteOperatorNodeSelectors(tx, model.SpiffeID); err != nil {
+			return nil, err
+		}
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if err := createEvent(tx, datastore.EventDeleted, datastore.AttestedNodeEvent, model.SpiffeID); err != nil {
+			return nil, err
+		}
+		attestedNodes = append(attestedNodes, modelToAttestedNode(model))
+	}
+	return attestedNodes, nil
+}
+
+func updateAttestedNodesStatus(tx *gorm.DB, statuses []*datastore.AttestedNodeStatus) error {
+	for _, status := range statuses {
+		updates := map[string]interface{}{
+			"last_seen_at": status.LastSeenAt,
+		}
+		if status.AgentVersion != "" {
+			updates["agent_version"] = status.AgentVersion
+		}
+		// UpdateColumns leaves updated_at untouched, so that status reports
+		// are not mistaken for changes to the node
+		if err := tx.Model(&AttestedNode{}).
+			Where("spiffe_id = ?", status.SpiffeID).
+			Where("last_seen_at IS NULL OR last_seen_at < ?", status.LastSeenAt).
+			UpdateColumns(updates).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func findAttestedNodes(tx *gorm.DB, spiffeIDs []string) ([]AttestedNode, error) {
+	if len(spiffeIDs) == 0 {
+		return nil, nil
+	}
+
+	var models []AttestedNode
+	if err := tx.Where("spiffe_id IN (?)", spiffeIDs).Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return models, nil
+}
+
 func setNodeSelectors(tx *gorm.DB, spiffeID string, selectors []*common.Selector) error {
 	// Previously the deletion of the previous set of node selectors was
 	// implemented via query like DELETE FROM node_resolver_map_entries WHERE
@@ -1674,11 +2115,92 @@ func setNodeSelectors(tx *gorm.DB, spiffeID string, selectors []*common.Selector
 		}
 	}
 
+	return createEvent(tx, datastore.EventUpdated, datastore.AttestedNodeEvent, spiffeID)
+}
+
+func addOperatorNodeSelectors(tx *gorm.DB, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error) {
+	for _, selector := range selectors {
+		model := &OperatorNodeSelector{
+			SpiffeID: spiffeID,
+			Type:     selector.Type,
+			Value:    selector.Value,
+		}
+		// Selectors that are already set are left as they are
+		if err := tx.Where(model).FirstOrCreate(model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	if err := createEvent(tx, datastore.EventUpdated, datastore.AttestedNodeEvent, spiffeID); err != nil {
+		return nil, err
+	}
+
+	return findOperatorNodeSelectors(tx, spiffeID)
+}
+
+func removeOperatorNodeSelectors(tx *gorm.DB, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error) {
+	// Gather the IDs to delete first to avoid gap locks on the index. See
+	// setNodeSelectors.
+	var ids []int64
+	for _, selector := range selectors {
+		var selectorIDs []int64
+		if err := tx.Model(&OperatorNodeSelector{}).
+			Where("spiffe_id = ? AND type = ? AND value = ?", spiffeID, selector.Type, selector.Value).
+			Pluck("id", &selectorIDs).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		ids = append(ids, selectorIDs...)
+	}
+	if len(ids) > 0 {
+		if err := tx.Where("id IN (?)", ids).Delete(&OperatorNodeSelector{}).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	if err := createEvent(tx, datastore.EventUpdated, datastore.AttestedNodeEvent, spiffeID); err != nil {
+		return nil, err
+	}
+
+	return findOperatorNodeSelectors(tx, spiffeID)
+}
+
+// deleteOperatorNodeSelectors deletes the operator-managed selectors of a
+// node, so that they are not inherited by a different node attesting later
+// with the same SPIFFE ID. The selectors are deleted by ID to avoid gap
+// locks, see setNodeSelectors.
+func deleteOperatorNodeSelectors(tx *gorm.DB, spiffeID string) error {
+	var ids []int64
+	if err := tx.Model(&OperatorNodeSelector{}).Where("spiffe_id = ?", spiffeID).Pluck("id", &ids).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if len(ids) > 0 {
+		if err := tx.Where("id IN (?)", ids).Delete(&OperatorNodeSelector{}).Error; err != nil {
+			return sqlError.Wrap(err)
+		}
+	}
 	return nil
 }
 
-func getNodeSelectors(ctx context.Context, db *sqlDB, spiffeID string) ([]*common.Selector, error) {
-	query := maybeRebind(db.databaseType, "SELECT type, value FROM node_resolver_map_entries WHERE spiffe_id=? ORDER BY id")
+func findOperatorNodeSelectors(tx *gorm.DB, spiffeID string) ([]*common.Selector, error) {
+	var models []OperatorNodeSelector
+	if err := tx.Where("spiffe_id = ?", spiffeID).Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var selectors []*common.Selector
+	for _, model := range models {
+		selectors = append(selectors, &common.Selector{
+			Type:  model.Type,
+			Value: model.Value,
+		})
+	}
+	return selectors, nil
+}
+
+// getNodeSelectors gets the selectors of a node from the given node selectors
+// table, which is either node_resolver_map_entries or operator_node_selectors
+func getNodeSelectors(ctx context.Context, db *sqlDB, tableName string, spiffeID string) ([]*common.Selector, error) {
+	query := maybeRebind(db.databaseType, "SELECT type, value FROM "+tableName+" WHERE spiffe_id=? ORDER BY id")
 	rows, err := db.QueryContext(ctx, query, spiffeID)
 	if err != nil {
 		return nil, sqlError.Wrap(err)
@@ -1701,8 +2223,8 @@ func getNodeSelectors(ctx context.Context, db *sqlDB, spiffeID string) ([]*commo
 	return selectors, nil
 }
 
-func listNodeSelectors(ctx context.Context, db *sqlDB, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
-	rawQuery, args := buildListNodeSelectorsQuery(req)
+func listNodeSelectors(ctx context.Context, db *sqlDB, tableName string, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	rawQuery, args := buildListNodeSelectorsQuery(tableName, req)
 	query := maybeRebind(db.databaseType, rawQuery)
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -1754,9 +2276,9 @@ func listNodeSelectors(ctx context.Context, db *sqlDB, req *datastore.ListNodeSe
 	return resp, nil
 }
 
-func buildListNodeSelectorsQuery(req *datastore.ListNodeSelectorsRequest) (query string, args []interface{}) {
+func buildListNodeSelectorsQuery(tableName string, req *datastore.ListNodeSelectorsRequest) (query string, args []interface{}) {
 	var sb strings.Builder
-	sb.WriteString("SELECT nre.spiffe_id, nre.type, nre.value FROM node_resolver_map_entries nre")
+	sb.WriteString("SELECT nre.spiffe_id, nre.type, nre.value FROM " + tableName + " nre")
 	if !req.ValidAt.IsZero() {
 		sb.WriteString(" INNER JOIN attested_node_entries ane ON nre.spiffe_id=ane.spiffe_id WHERE ane.expires_at > ?")
 		args = append(args, req.ValidAt)
@@ -1764,7 +2286,7 @@ func buildListNodeSelectorsQuery(req *datastore.ListNodeSelectorsRequest) (query
 
 	// This ordering is required to make listNodeSelectors efficient but not
 	// needed for correctness. Since the query can be wholly satisfied using
-	// the node_resolver_map_entries unique index over (spiffe_id,type,value)
+	// the unique index over (spiffe_id,type,value) of both node selector tables
 	// it is unlikely to impact database performance as that index is already
 	// ordered primarily by spiffe_id.
 	sb.WriteString(" ORDER BY nre.spiffe_id ASC")
@@ -1772,12 +2294,16 @@ func buildListNodeSelectorsQuery(req *datastore.ListNodeSelectorsRequest) (query
 	return sb.String(), args
 }
 
-func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry, changedBy string) (*common.RegistrationEntry, error) {
 	entryID, err := newRegistrationEntryID()
 	if err != nil {
 		return nil, err
 	}
 
+	return createRegistrationEntryWithID(tx, entryID, entry, changedBy)
+}
+
+func createRegistrationEntryWithID(tx *gorm.DB, entryID string, entry *common.RegistrationEntry, changedBy string) (*common.RegistrationEntry, error) {
 	newRegisteredEntry := RegisteredEntry{
 		EntryID:    entryID,
 		SpiffeID:   entry.SpiffeId,
@@ -1825,11 +2351,19 @@ func createRegistrationEntry(tx *gorm.DB, entry *common.RegistrationEntry) (*com
 		}
 	}
 
+	if err := createEvent(tx, datastore.EventCreated, datastore.EntryEvent, newRegisteredEntry.EntryID); err != nil {
+		return nil, err
+	}
+
 	registrationEntry, err := modelToEntry(tx, newRegisteredEntry)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := createEntryRevision(tx, datastore.EventCreated, registrationEntry, nil, changedBy); err != nil {
+		return nil, err
+	}
+
 	return registrationEntry, nil
 }
 
@@ -2917,16 +3451,19 @@ func buildSliceArg(length int) string {
 }
 
 type nodeRow struct {
-	EId             uint64
-	SpiffeID        string
-	DataType        sql.NullString
-	SerialNumber    sql.NullString
-	ExpiresAt       sql.NullTime
-	NewSerialNumber sql.NullString
-	NewExpiresAt    sql.NullTime
-	CanReattest     sql.NullBool
-	SelectorType    sql.NullString
-	SelectorValue   sql.NullString
+	EId               uint64
+	SpiffeID          string
+	DataType          sql.NullString
+	SerialNumber      sql.NullString
+	ExpiresAt         sql.NullTime
+	NewSerialNumber   sql.NullString
+	NewExpiresAt      sql.NullTime
+	CanReattest       sql.NullBool
+	AgentVersion      sql.NullString
+	LastSeenAt        sql.NullTime
+	ReattestRequested sql.NullBool
+	SelectorType      sql.NullString
+	SelectorValue     sql.NullString
 }
 
 func scanNodeRow(rs *sql.Rows, r *nodeRow) error {
@@ -2939,6 +3476,9 @@ func scanNodeRow(rs *sql.Rows, r *nodeRow) error {
 		&r.NewSerialNumber,
 		&r.NewExpiresAt,
 		&r.CanReattest,
+		&r.AgentVersion,
+		&r.LastSeenAt,
+		&r.ReattestRequested,
 		&r.SelectorType,
 		&r.SelectorValue,
 	))
@@ -2983,6 +3523,18 @@ func fillNodeFromRow(node *common.AttestedNode, r *nodeRow) error {
 		node.CanReattest = r.CanReattest.Bool
 	}
 
+	if r.AgentVersion.Valid {
+		node.AgentVersion = r.AgentVersion.String
+	}
+
+	if r.LastSeenAt.Valid {
+		node.LastSeenAt = r.LastSeenAt.Time.Unix()
+	}
+
+	if r.ReattestRequested.Valid {
+		node.ReattestRequested = r.ReattestRequested.Bool
+	}
+
 	return nil
 }
 
@@ -3123,7 +3675,7 @@ func applyPagination(p *datastore.Pagination, entryTx *gorm.DB) (*gorm.DB, error
 	return entryTx, nil
 }
 
-func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *common.RegistrationEntryMask) (*common.RegistrationEntry, error) {
+func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *common.RegistrationEntryMask, changedBy string) (*common.RegistrationEntry, error) {
 	if err := validateRegistrationEntryForUpdate(e, mask); err != nil {
 		return nil, err
 	}
@@ -3133,6 +3685,10 @@ func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *com
 	if err := tx.Find(&entry, "entry_id = ?", e.EntryId).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
+	previousEntry, err := modelToEntry(tx, entry)
+	if err != nil {
+		return nil, err
+	}
 	if mask == nil || mask.StoreSvid {
 		entry.StoreSvid = e.StoreSvid
 	}
@@ -3217,63 +3773,76 @@ func updateRegistrationEntry(tx *gorm.DB, e *common.RegistrationEntry, mask *com
 		// The FederatesWith field in entry is filled in by the call to modelToEntry below
 	}
 
+	if err := createEvent(tx, datastore.EventUpdated, datastore.EntryEvent, entry.EntryID); err != nil {
+		return nil, err
+	}
+
 	returnEntry, err := modelToEntry(tx, entry)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := createEntryRevision(tx, datastore.EventUpdated, returnEntry, entryChangedFields(previousEntry, returnEntry), changedBy); err != nil {
+		return nil, err
+	}
+
 	return returnEntry, nil
 }
 
-func deleteRegistrationEntry(tx *gorm.DB, entryID string) (*common.RegistrationEntry, error) {
+func deleteRegistrationEntry(tx *gorm.DB, entryID string, changedBy string) (*common.RegistrationEntry, error) {
 	entry := RegisteredEntry{}
 	if err := tx.Find(&entry, "entry_id = ?", entryID).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
-	registrationEntry, err := modelToEntry(tx, entry)
-	if err != nil {
-		return nil, err
-	}
+	return deleteRegistrationEntrySupport(tx, entry, changedBy)
+}
 
-	err = deleteRegistrationEntrySupport(tx, entry)
+// deleteRegistrationEntrySupport deletes the entry and returns the state it
+// had before being deleted
+func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry, changedBy string) (*common.RegistrationEntry, error) {
+	registrationEntry, err := modelToEntry(tx, entry)
 	if err != nil {
 		return nil, err
 	}
 
-	return registrationEntry, nil
-}
-
-func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 	if err := tx.Model(&entry).Association("FederatesWith").Clear().Error; err != nil {
-		return err
+		return nil, err
 	}
 
 	if err := tx.Delete(&entry).Error; err != nil {
-		return sqlError.Wrap(err)
+		return nil, sqlError.Wrap(err)
 	}
 
 	// Delete existing selectors
 	if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
-		return sqlError.Wrap(err)
+		return nil, sqlError.Wrap(err)
 	}
 
 	// Delete existing dns_names
 	if err := tx.Exec("DELETE FROM dns_names WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
-		return sqlError.Wrap(err)
+		return nil, sqlError.Wrap(err)
 	}
 
-	return nil
+	if err := createEvent(tx, datastore.EventDeleted, datastore.EntryEvent, entry.EntryID); err != nil {
+		return nil, err
+	}
+
+	if err := createEntryRevision(tx, datastore.EventDeleted, registrationEntry, nil, changedBy); err != nil {
+		return nil, err
+	}
+
+	return registrationEntry, nil
 }
 
-func pruneRegistrationEntries(tx *gorm.DB, expiresBefore time.Time, logger logrus.FieldLogger) error {
+func pruneRegistrationEntries(tx *gorm.DB, expiresBefore time.Time, changedBy string, logger logrus.FieldLogger) error {
 	var registrationEntries []RegisteredEntry
 	if err := tx.Where("expiry != 0").Where("expiry < ?", expiresBefore.Unix()).Find(&registrationEntries).Error; err != nil {
 		return err
 	}
 
 	for _, entry := range registrationEntries {
-		if err := deleteRegistrationEntrySupport(tx, entry); err != nil {
+		if _, err := deleteRegistrationEntrySupport(tx, entry, changedBy); err != nil {
 			return err
 		}
 		logger.WithFields(logrus.Fields{
@@ -3355,6 +3924,10 @@ func createFederationRelationship(tx *gorm.DB, fr *datastore.FederationRelations
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventCreated, datastore.FederationRelationshipEvent, model.TrustDomain); err != nil {
+		return nil, err
+	}
+
 	return fr, nil
 }
 
@@ -3366,7 +3939,7 @@ func deleteFederationRelationship(tx *gorm.DB, trustDomain spiffeid.TrustDomain)
 	if err := tx.Delete(model).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
-	return nil
+	return createEvent(tx, datastore.EventDeleted, datastore.FederationRelationshipEvent, model.TrustDomain)
 }
 
 func fetchFederationRelationship(tx *gorm.DB, trustDomain spiffeid.TrustDomain) (*datastore.FederationRelationship, error) {
@@ -3459,6 +4032,10 @@ func updateFederationRelationship(tx *gorm.DB, fr *datastore.FederationRelations
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createEvent(tx, datastore.EventUpdated, datastore.FederationRelationshipEvent, model.TrustDomain); err != nil {
+		return nil, err
+	}
+
 	return modelToFederationRelationship(tx, &model)
 }
 
@@ -3477,7 +4054,7 @@ func validateFederationRelationship(fr *datastore.FederationRelationship, mask *
 
 	if mask.BundleEndpointProfile {
 		switch fr.BundleEndpointProfile {
-		case datastore.BundleEndpointWeb:
+		case datastore.BundleEndpointWeb, datastore.BundleEndpointJWKS:
 		case datastore.BundleEndpointSPIFFE:
 			if fr.EndpointSPIFFEID.IsZero() {
 				return status.Error(codes.InvalidArgument, "bundle endpoint SPIFFE ID is required")
@@ -3508,7 +4085,7 @@ func modelToFederationRelationship(tx *gorm.DB, model *FederatedTrustDomain) (*d
 	}
 
 	switch fr.BundleEndpointProfile {
-	case datastore.BundleEndpointWeb:
+	case datastore.BundleEndpointWeb, datastore.BundleEndpointJWKS:
 	case datastore.BundleEndpointSPIFFE:
 		endpointSPIFFEID, err := spiffeid.FromString(model.EndpointSPIFFEID)
 		if err != nil {
@@ -3528,6 +4105,310 @@ func modelToFederationRelationship(tx *gorm.DB, model *FederatedTrustDomain) (*d
 	return fr, nil
 }
 
+func createEvent(tx *gorm.DB, eventType datastore.EventType, resourceType datastore.EventResourceType, resourceID string) error {
+	model := Event{
+		Type:         string(eventType),
+		ResourceType: string(resourceType),
+		ResourceID:   resourceID,
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func createEvents(tx *gorm.DB, eventType datastore.EventType, resourceType datastore.EventResourceType, resourceIDs []string) error {
+	for _, resourceID := range resourceIDs {
+		if err := createEvent(tx, eventType, resourceType, resourceID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getLatestEventID(tx *gorm.DB) (uint, error) {
+	var model Event
+	err := tx.Order("id DESC").First(&model).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return 0, nil
+	case err != nil:
+		return 0, sqlError.Wrap(err)
+	}
+	return model.ID, nil
+}
+
+func listEvents(tx *gorm.DB, req *datastore.ListEventsRequest) (*datastore.ListEventsResponse, error) {
+	tx = tx.Where("id > ?", req.AfterID)
+	if len(req.ByIDs) > 0 {
+		tx = tx.Where("id IN (?)", req.ByIDs)
+	}
+	if len(req.ByResourceTypes) > 0 {
+		resourceTypes := make([]string, 0, len(req.ByResourceTypes))
+		for _, resourceType := range req.ByResourceTypes {
+			resourceTypes = append(resourceTypes, string(resourceType))
+		}
+		tx = tx.Where("resource_type IN (?)", resourceTypes)
+	}
+	if req.Limit > 0 {
+		tx = tx.Limit(req.Limit)
+	}
+
+	var models []Event
+	if err := tx.Order("id ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListEventsResponse{
+		Events: make([]*datastore.Event, 0, len(models)),
+	}
+	for _, model := range models {
+		resp.Events = append(resp.Events, &datastore.Event{
+			ID:           model.ID,
+			Type:         datastore.EventType(model.Type),
+			ResourceType: datastore.EventResourceType(model.ResourceType),
+			ResourceID:   model.ResourceID,
+			CreatedAt:    model.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func pruneEvents(tx *gorm.DB, olderThan time.Time) error {
+	// The latest event is kept so that watchers can tell whether the events
+	// recorded after their cursor have been pruned.
+	latestID, err := getLatestEventID(tx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Where("created_at < ? AND id < ?", olderThan, latestID).Delete(&Event{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+// createEntryRevision records a revision of the given entry, numbered after
+// the latest revision of the entry
+func createEntryRevision(tx *gorm.DB, changeType datastore.EventType, entry *common.RegistrationEntry, changedFields []string, changedBy string) error {
+	revision := int64(1)
+	var latest RegisteredEntryRevision
+	err := tx.Where("entry_id = ?", entry.EntryId).Order("revision DESC").First(&latest).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+	case err != nil:
+		return sqlError.Wrap(err)
+	default:
+		revision = latest.Revision + 1
+	}
+
+	// Marshal a copy so that the entry returned to the caller is left
+	// untouched by the state cached while marshaling.
+	data, err := proto.Marshal(copyRegistrationEntry(entry))
+	if err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	model := RegisteredEntryRevision{
+		EntryID:       entry.EntryId,
+		Revision:      revision,
+		ChangeType:    string(changeType),
+		ChangedBy:     changedBy,
+		ChangedFields: strings.Join(changedFields, ","),
+		Data:          data,
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func copyRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	var selectors []*common.Selector
+	for _, selector := range entry.Selectors {
+		selectors = append(selectors, &common.Selector{Type: selector.Type, Value: selector.Value})
+	}
+	return &common.RegistrationEntry{
+		Selectors:      selectors,
+		ParentId:       entry.ParentId,
+		SpiffeId:       entry.SpiffeId,
+		X509SvidTtl:    entry.X509SvidTtl,
+		FederatesWith:  entry.FederatesWith,
+		EntryId:        entry.EntryId,
+		Admin:          entry.Admin,
+		Downstream:     entry.Downstream,
+		EntryExpiry:    entry.EntryExpiry,
+		DnsNames:       entry.DnsNames,
+		RevisionNumber: entry.RevisionNumber,
+		StoreSvid:      entry.StoreSvid,
+		JwtSvidTtl:     entry.JwtSvidTtl,
+	}
+}
+
+func fetchRegistrationEntryRevision(tx *gorm.DB, entryID string, revision int64) (*datastore.RegistrationEntryRevision, error) {
+	var model RegisteredEntryRevision
+	err := tx.Find(&model, "entry_id = ? AND revision = ?", entryID, revision).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+	return modelToEntryRevision(model)
+}
+
+func listRegistrationEntryRevisions(tx *gorm.DB, entryID string) ([]*datastore.RegistrationEntryRevision, error) {
+	var models []RegisteredEntryRevision
+	if err := tx.Where("entry_id = ?", entryID).Order("revision ASC").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	revisions := make([]*datastore.RegistrationEntryRevision, 0, len(models))
+	for _, model := range models {
+		revision, err := modelToEntryRevision(model)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+func pruneRegistrationEntryRevisions(tx *gorm.DB, olderThan time.Time) error {
+	if err := tx.Where("created_at < ?", olderThan).Delete(&RegisteredEntryRevision{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func restoreRegistrationEntryRevision(ctx context.Context, db *sqlDB, tx *gorm.DB, entryID string, revision int64, changedBy string) (*common.RegistrationEntry, error) {
+	entryRevision, err := fetchRegistrationEntryRevision(tx, entryID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if entryRevision == nil {
+		return nil, status.Error(codes.NotFound, "datastore-sql: registration entry revision not found")
+	}
+	entry := entryRevision.Entry
+
+	var count int
+	if err := tx.Model(&RegisteredEntry{}).Where("entry_id = ?", entryID).Count(&count).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if count > 0 {
+		// Roll back the changes made to the entry since the revision
+		return updateRegistrationEntry(tx, entry, nil, changedBy)
+	}
+
+	// The entry was deleted. It is recreated with the same ID, unless an
+	// entry with the same (parentID, spiffeID, selector) tuple has been
+	// created since.
+	if err := validateRegistrationEntry(entry); err != nil {
+		return nil, err
+	}
+	similarEntry, err := lookupSimilarEntry(ctx, db, tx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if similarEntry != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "datastore-sql: cannot restore registration entry; similar entry %q already exists", similarEntry.EntryId)
+	}
+	return createRegistrationEntryWithID(tx, entryID, entry, changedBy)
+}
+
+func modelToEntryRevision(model RegisteredEntryRevision) (*datastore.RegistrationEntryRevision, error) {
+	entry := new(common.RegistrationEntry)
+	if err := proto.Unmarshal(model.Data, entry); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var changedFields []string
+	if model.ChangedFields != "" {
+		changedFields = strings.Split(model.ChangedFields, ",")
+	}
+
+	return &datastore.RegistrationEntryRevision{
+		EntryID:       model.EntryID,
+		Revision:      model.Revision,
+		ChangeType:    datastore.EventType(model.ChangeType),
+		ChangedBy:     model.ChangedBy,
+		ChangedAt:     model.CreatedAt,
+		ChangedFields: changedFields,
+		Entry:         entry,
+	}, nil
+}
+
+// entryChangedFields returns the fields that differ between the two states of
+// an entry, named after the fields of the Entry API type. Selectors and
+// federated trust domains are compared regardless of their order.
+func entryChangedFields(before, after *common.RegistrationEntry) []string {
+	var fields []string
+	if before.SpiffeId != after.SpiffeId {
+		fields = append(fields, "spiffe_id")
+	}
+	if before.ParentId != after.ParentId {
+		fields = append(fields, "parent_id")
+	}
+	if !sameSelectors(before.Selectors, after.Selectors) {
+		fields = append(fields, "selectors")
+	}
+	if before.X509SvidTtl != after.X509SvidTtl {
+		fields = append(fields, "x509_svid_ttl")
+	}
+	if !sameStringSet(before.FederatesWith, after.FederatesWith) {
+		fields = append(fields, "federates_with")
+	}
+	if before.Admin != after.Admin {
+		fields = append(fields, "admin")
+	}
+	if before.Downstream != after.Downstream {
+		fields = append(fields, "downstream")
+	}
+	if before.EntryExpiry != after.EntryExpiry {
+		fields = append(fields, "expires_at")
+	}
+	if !sameStrings(before.DnsNames, after.DnsNames) {
+		fields = append(fields, "dns_names")
+	}
+	if before.StoreSvid != after.StoreSvid {
+		fields = append(fields, "store_svid")
+	}
+	if before.JwtSvidTtl != after.JwtSvidTtl {
+		fields = append(fields, "jwt_svid_ttl")
+	}
+	return fields
+}
+
+func sameSelectors(a, b []*common.Selector) bool {
+	toStrings := func(selectors []*common.Selector) []string {
+		out := make([]string, 0, len(selectors))
+		for _, selector := range selectors {
+			out = append(out, selector.Type+":"+selector.Value)
+		}
+		return out
+	}
+	return sameStringSet(toStrings(a), toStrings(b))
+}
+
+func sameStringSet(a, b []string) bool {
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return sameStrings(a, b)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -3706,6 +4587,9 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 		NewCertSerialNumber: model.NewSerialNumber,
 		NewCertNotAfter:     nullableDBTimeToUnixTime(model.NewExpiresAt),
 		CanReattest:         model.CanReattest,
+		AgentVersion:        model.AgentVersion,
+		LastSeenAt:          nullableDBTimeToUnixTime(model.LastSeenAt),
+		ReattestRequested:   model.ReattestRequested,
 	}
 }
 
@@ -249,6 +249,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	bundle2 := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, s.cacert)
 	appendedBundle := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId,
 		[]*x509.Certificate{s.cert, s.cacert})
+	appendedBundle.SequenceNumber = 1
 
 	// append
 	ab, err := s.ds.AppendBundle(ctx, bundle2)
@@ -268,7 +269,8 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.Require().NoError(err)
 	s.AssertProtoEqual(bundle3, ab)
 
-	// update with mask: RootCas
+	// update with mask: RootCas (the sequence number is left alone)
+	bundle.SequenceNumber = appendedBundle.SequenceNumber
 	updatedBundle, err := s.ds.UpdateBundle(ctx, bundle, &common.BundleMask{
 		RootCas: true,
 	})
@@ -303,6 +305,18 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.Require().NoError(err)
 	assertBundlesEqual(s.T(), []*common.Bundle{bundle, bundle3}, lresp.Bundles)
 
+	// update with mask: SequenceNumber
+	bundle.SequenceNumber = 42
+	updatedBundle, err = s.ds.UpdateBundle(ctx, bundle, &common.BundleMask{
+		SequenceNumber: true,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(bundle, updatedBundle)
+
+	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
+	s.Require().NoError(err)
+	assertBundlesEqual(s.T(), []*common.Bundle{bundle, bundle3}, lresp.Bundles)
+
 	// update without mask
 	updatedBundle, err = s.ds.UpdateBundle(ctx, bundle2, nil)
 	s.Require().NoError(err)
@@ -544,6 +558,7 @@ func (s *PluginSuite) TestBundlePrune() {
 	// Setup
 	// Create new bundle with two cert (one valid and one expired)
 	bundle := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert, s.cacert})
+	bundle.RefreshHint = 30
 
 	// Add two JWT signing keys (one valid and one expired)
 	expiredKeyTime, err := time.Parse(time.RFC3339, _expiredNotAfterString)
@@ -583,12 +598,24 @@ func (s *PluginSuite) TestBundlePrune() {
 	s.NoError(err)
 	s.True(changed)
 
-	// Fetch and verify pruned bundle is the expected
+	// Fetch and verify pruned bundle is the expected, with the refresh hint
+	// preserved and the sequence number bumped
 	expectedPrunedBundle := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert})
 	expectedPrunedBundle.JwtSigningKeys = []*common.PublicKey{{NotAfter: nonExpiredKeyTime.Unix()}}
+	expectedPrunedBundle.RefreshHint = 30
+	expectedPrunedBundle.SequenceNumber = 1
 	fb, err := s.ds.FetchBundle(ctx, "spiffe://foo")
 	s.Require().NoError(err)
 	s.AssertProtoEqual(expectedPrunedBundle, fb)
+
+	// prune again has nothing to remove and leaves the sequence number alone
+	changed, err = s.ds.PruneBundle(ctx, bundle.TrustDomainId, middleTime)
+	s.NoError(err)
+	s.False(changed)
+
+	fb, err = s.ds.FetchBundle(ctx, "spiffe://foo")
+	s.Require().NoError(err)
+	s.AssertProtoEqual(expectedPrunedBundle, fb)
 }
 
 func (s *PluginSuite) TestCreateAttestedNode() {
@@ -1044,6 +1071,26 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 				NewCertSerialNumber: newSerial,
 			},
 		},
+		{
+			name: "update attested node with mask set only 'ReattestRequested'",
+			updateNode: &common.AttestedNode{
+				SpiffeId:          nodeID,
+				CertSerialNumber:  updatedSerial,
+				ReattestRequested: true,
+			},
+			updateNodeMask: &common.AttestedNodeMask{
+				ReattestRequested: true,
+			},
+			expUpdatedNode: &common.AttestedNode{
+				SpiffeId:            nodeID,
+				AttestationDataType: attestationType,
+				CertSerialNumber:    serial,
+				CertNotAfter:        expires,
+				NewCertNotAfter:     newExpires,
+				NewCertSerialNumber: newSerial,
+				ReattestRequested:   true,
+			},
+		},
 		{
 			name: "update attested node with nil mask",
 			updateNode: &common.AttestedNode{
@@ -1112,6 +1159,8 @@ func (s *PluginSuite) TestDeleteAttestedNode() {
 
 	_, err = s.ds.CreateAttestedNode(ctx, entry)
 	s.Require().NoError(err)
+	_, err = s.ds.AddOperatorNodeSelectors(ctx, entry.SpiffeId, []*common.Selector{{Type: "TYPE", Value: "VALUE"}})
+	s.Require().NoError(err)
 
 	deletedNode, err := s.ds.DeleteAttestedNode(ctx, entry.SpiffeId)
 	s.Require().NoError(err)
@@ -1120,6 +1169,319 @@ func (s *PluginSuite) TestDeleteAttestedNode() {
 	attestedNode, err := s.ds.FetchAttestedNode(ctx, entry.SpiffeId)
 	s.Require().NoError(err)
 	s.Nil(attestedNode)
+
+	// the operator selectors of the node are deleted as well
+	operatorSelectors, err := s.ds.GetOperatorNodeSelectors(ctx, entry.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.Require().Empty(operatorSelectors)
+}
+
+func (s *PluginSuite) TestDeleteAttestedNodes() {
+	foo := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	bar := &common.AttestedNode{
+		SpiffeId:            "bar",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "cafebad",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	baz := &common.AttestedNode{
+		SpiffeId:            "baz",
+		AttestationDataType: "gcp-iit",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	req := &datastore.ListAttestedNodesRequest{
+		ByAttestationType: "aws-tag",
+		FetchSelectors:    true,
+		Pagination:        &datastore.Pagination{PageSize: 1},
+	}
+
+	// nothing to delete
+	resp, err := s.ds.DeleteAttestedNodes(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Nodes)
+	s.Require().Empty(resp.Pagination.Token)
+
+	nodeSelectors := []*common.Selector{{Type: "NODE", Value: "VALUE"}}
+	operatorSelectors := []*common.Selector{{Type: "TYPE", Value: "VALUE"}}
+	for _, node := range []*common.AttestedNode{foo, bar, baz} {
+		_, err = s.ds.CreateAttestedNode(ctx, node)
+		s.Require().NoError(err)
+		s.Require().NoError(s.ds.SetNodeSelectors(ctx, node.SpiffeId, nodeSelectors))
+		_, err = s.ds.AddOperatorNodeSelectors(ctx, node.SpiffeId, operatorSelectors)
+		s.Require().NoError(err)
+	}
+
+	// the matching nodes are deleted a page at a time, and returned along
+	// with their node selectors
+	var deletedNodes []*common.AttestedNode
+	for {
+		resp, err = s.ds.DeleteAttestedNodes(ctx, req)
+		s.Require().NoError(err)
+		deletedNodes = append(deletedNodes, resp.Nodes...)
+		if resp.Pagination.Token == "" {
+			break
+		}
+		req.Pagination.Token = resp.Pagination.Token
+	}
+	expectedFoo := proto.Clone(foo).(*common.AttestedNode)
+	expectedFoo.Selectors = nodeSelectors
+	expectedBar := proto.Clone(bar).(*common.AttestedNode)
+	expectedBar.Selectors = nodeSelectors
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{expectedFoo, expectedBar}, deletedNodes)
+
+	listResp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{baz}, listResp.Nodes)
+
+	// the operator selectors of deleted nodes are deleted as well
+	selectors, err := s.ds.GetOperatorNodeSelectors(ctx, foo.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.Require().Empty(selectors)
+	selectors, err = s.ds.GetOperatorNodeSelectors(ctx, baz.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(operatorSelectors, selectors)
+}
+
+func (s *PluginSuite) TestBanAttestedNodes() {
+	foo := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+		NewCertSerialNumber: "cafebad",
+		NewCertNotAfter:     time.Now().Add(2 * time.Hour).Unix(),
+	}
+	bar := &common.AttestedNode{
+		SpiffeId:            "bar",
+		AttestationDataType: "gcp-iit",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+	req := &datastore.ListAttestedNodesRequest{
+		ByAttestationType: "aws-tag",
+	}
+
+	// nothing to ban
+	resp, err := s.ds.BanAttestedNodes(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Nodes)
+
+	for _, node := range []*common.AttestedNode{foo, bar} {
+		_, err = s.ds.CreateAttestedNode(ctx, node)
+		s.Require().NoError(err)
+	}
+
+	// only the matching nodes are banned
+	resp, err = s.ds.BanAttestedNodes(ctx, req)
+	s.Require().NoError(err)
+
+	bannedFoo := proto.Clone(foo).(*common.AttestedNode)
+	bannedFoo.CertSerialNumber = ""
+	bannedFoo.NewCertSerialNumber = ""
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{bannedFoo}, resp.Nodes)
+
+	// nodes that are already banned are skipped
+	resp, err = s.ds.BanAttestedNodes(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Nodes)
+
+	attestedNode, err := s.ds.FetchAttestedNode(ctx, foo.SpiffeId)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(bannedFoo, attestedNode)
+
+	attestedNode, err = s.ds.FetchAttestedNode(ctx, bar.SpiffeId)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(bar, attestedNode)
+}
+
+func (s *PluginSuite) TestPruneAttestedNodes() {
+	now := time.Now()
+	expired := &common.AttestedNode{
+		SpiffeId:            "expired",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        now.Add(-2 * time.Hour).Unix(),
+	}
+	expired2 := &common.AttestedNode{
+		SpiffeId:            "expired-2",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "fadedcab",
+		CertNotAfter:        now.Add(-3 * time.Hour).Unix(),
+	}
+	expiredReattestable := &common.AttestedNode{
+		SpiffeId:            "expired-reattestable",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "cafebad",
+		CertNotAfter:        now.Add(-2 * time.Hour).Unix(),
+		CanReattest:         true,
+	}
+	expiredBanned := &common.AttestedNode{
+		SpiffeId:            "expired-banned",
+		AttestationDataType: "join_token",
+		CertNotAfter:        now.Add(-2 * time.Hour).Unix(),
+	}
+	recentlyExpired := &common.AttestedNode{
+		SpiffeId:            "recently-expired",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        now.Add(-time.Minute).Unix(),
+	}
+	valid := &common.AttestedNode{
+		SpiffeId:            "valid",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "beefdead",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	selectors := []*common.Selector{{Type: "TYPE", Value: "VALUE"}}
+
+	for _, node := range []*common.AttestedNode{expired, expired2, expiredReattestable, expiredBanned, recentlyExpired, valid} {
+		_, err := s.ds.CreateAttestedNode(ctx, node)
+		s.Require().NoError(err)
+		s.Require().NoError(s.ds.SetNodeSelectors(ctx, node.SpiffeId, selectors))
+		_, err = s.ds.AddOperatorNodeSelectors(ctx, node.SpiffeId, selectors)
+		s.Require().NoError(err)
+	}
+
+	// only nodes that cannot reattest are pruned, up to the limit
+	prunedNodes, err := s.ds.PruneAttestedNodes(ctx, now.Add(-time.Hour), false, 1)
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{expired}, prunedNodes)
+
+	prunedNodes, err = s.ds.PruneAttestedNodes(ctx, now.Add(-time.Hour), false, 0)
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{expired2}, prunedNodes)
+
+	// the selectors of pruned nodes are deleted
+	nodeSelectors, err := s.ds.GetNodeSelectors(ctx, expired.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.Require().Empty(nodeSelectors)
+	nodeSelectors, err = s.ds.GetOperatorNodeSelectors(ctx, expired.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.Require().Empty(nodeSelectors)
+	nodeSelectors, err = s.ds.GetNodeSelectors(ctx, expiredReattestable.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(selectors, nodeSelectors)
+	nodeSelectors, err = s.ds.GetOperatorNodeSelectors(ctx, expiredReattestable.SpiffeId, datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(selectors, nodeSelectors)
+
+	// nodes that can reattest are pruned when included
+	prunedNodes, err = s.ds.PruneAttestedNodes(ctx, now.Add(-time.Hour), true, 0)
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{expiredReattestable}, prunedNodes)
+
+	// banned nodes are never pruned
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{})
+	s.Require().NoError(err)
+	spiretest.AssertProtoListEqual(s.T(), []*common.AttestedNode{expiredBanned, recentlyExpired, valid}, resp.Nodes)
+}
+
+func (s *PluginSuite) TestUpdateAttestedNodesStatus() {
+	now := time.Now().Truncate(time.Second)
+	nodeA := &common.AttestedNode{
+		SpiffeId:            "node-a",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	nodeB := &common.AttestedNode{
+		SpiffeId:            "node-b",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "cafebad",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	neverSeen := &common.AttestedNode{
+		SpiffeId:            "never-seen",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "deadbeef",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	}
+	for _, node := range []*common.AttestedNode{nodeA, nodeB, neverSeen} {
+		_, err := s.ds.CreateAttestedNode(ctx, node)
+		s.Require().NoError(err)
+	}
+
+	events, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{})
+	s.Require().NoError(err)
+
+	// unknown nodes are ignored
+	err = s.ds.UpdateAttestedNodesStatus(ctx, []*datastore.AttestedNodeStatus{
+		{SpiffeID: nodeA.SpiffeId, AgentVersion: "1.6.0", LastSeenAt: now.Add(-time.Hour)},
+		{SpiffeID: nodeB.SpiffeId, AgentVersion: "1.5.0", LastSeenAt: now.Add(-time.Hour)},
+		{SpiffeID: "unknown", AgentVersion: "1.6.0", LastSeenAt: now},
+	})
+	s.Require().NoError(err)
+
+	// an empty version leaves the reported version untouched
+	err = s.ds.UpdateAttestedNodesStatus(ctx, []*datastore.AttestedNodeStatus{
+		{SpiffeID: nodeA.SpiffeId, LastSeenAt: now},
+	})
+	s.Require().NoError(err)
+
+	// statuses older than the last one recorded are ignored
+	err = s.ds.UpdateAttestedNodesStatus(ctx, []*datastore.AttestedNodeStatus{
+		{SpiffeID: nodeA.SpiffeId, AgentVersion: "1.4.0", LastSeenAt: now.Add(-time.Minute)},
+		{SpiffeID: nodeB.SpiffeId, AgentVersion: "1.4.0", LastSeenAt: now.Add(-time.Hour)},
+	})
+	s.Require().NoError(err)
+
+	nodeA.AgentVersion = "1.6.0"
+	nodeA.LastSeenAt = now.Unix()
+	nodeB.AgentVersion = "1.5.0"
+	nodeB.LastSeenAt = now.Add(-time.Hour).Unix()
+
+	fetched, err := s.ds.FetchAttestedNode(ctx, nodeA.SpiffeId)
+	s.Require().NoError(err)
+	s.RequireProtoEqual(nodeA, fetched)
+
+	// status updates do not emit events
+	afterEvents, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(events.Events, afterEvents.Events)
+
+	for _, tt := range []struct {
+		name        string
+		req         *datastore.ListAttestedNodesRequest
+		expectNodes []*common.AttestedNode
+	}{
+		{
+			name:        "all",
+			req:         &datastore.ListAttestedNodesRequest{},
+			expectNodes: []*common.AttestedNode{nodeA, nodeB, neverSeen},
+		},
+		{
+			name:        "by agent version",
+			req:         &datastore.ListAttestedNodesRequest{ByAgentVersion: "1.5.0"},
+			expectNodes: []*common.AttestedNode{nodeB},
+		},
+		{
+			name:        "by last seen before",
+			req:         &datastore.ListAttestedNodesRequest{ByLastSeenBefore: now.Add(-time.Minute)},
+			expectNodes: []*common.AttestedNode{nodeB, neverSeen},
+		},
+		{
+			name: "by last seen before and agent version with selectors",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAgentVersion:   "1.5.0",
+				ByLastSeenBefore: now.Add(-time.Minute),
+				FetchSelectors:   true,
+			},
+			expectNodes: []*common.AttestedNode{nodeB},
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.AssertProtoListEqual(t, tt.expectNodes, resp.Nodes)
+		})
+	}
 }
 
 func (s *PluginSuite) TestNodeSelectors() {
@@ -1270,6 +1632,102 @@ func (s *PluginSuite) TestListNodeSelectorsGroupsBySpiffeID() {
 	}, resp.Selectors)
 }
 
+func (s *PluginSuite) TestOperatorNodeSelectors() {
+	a := &common.Selector{Type: "A", Value: "a"}
+	b := &common.Selector{Type: "B", Value: "b"}
+	c := &common.Selector{Type: "C", Value: "c"}
+	attestor := []*common.Selector{{Type: "ATTESTOR", Value: "1"}}
+
+	// assert there are no operator selectors for foo
+	selectors, err := s.ds.GetOperatorNodeSelectors(ctx, "foo", datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.Require().Empty(selectors)
+
+	// add selectors, which are deduplicated
+	selectors, err = s.ds.AddOperatorNodeSelectors(ctx, "foo", []*common.Selector{a, b})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{a, b}, selectors)
+	selectors, err = s.ds.AddOperatorNodeSelectors(ctx, "foo", []*common.Selector{b, c})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{a, b, c}, selectors)
+	selectors, err = s.ds.AddOperatorNodeSelectors(ctx, "bar", []*common.Selector{c})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{c}, selectors)
+
+	// operator selectors are kept apart from the attestor selectors
+	s.setNodeSelectors("foo", attestor)
+	s.RequireProtoListEqual(attestor, s.getNodeSelectors("foo", datastore.RequireCurrent))
+	s.setNodeSelectors("foo", nil)
+	selectors, err = s.ds.GetOperatorNodeSelectors(ctx, "foo", datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{a, b, c}, selectors)
+	if TestReadOnlyDelay != "" {
+		time.Sleep(s.readOnlyDelay)
+	}
+	selectors, err = s.ds.GetOperatorNodeSelectors(ctx, "foo", datastore.TolerateStale)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{a, b, c}, selectors)
+
+	// remove selectors, ignoring those that are not set
+	selectors, err = s.ds.RemoveOperatorNodeSelectors(ctx, "foo", []*common.Selector{a, {Type: "D", Value: "d"}})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{b, c}, selectors)
+	selectors, err = s.ds.RemoveOperatorNodeSelectors(ctx, "foo", []*common.Selector{b, c})
+	s.Require().NoError(err)
+	s.Require().Empty(selectors)
+
+	// bar selectors were not impacted
+	selectors, err = s.ds.GetOperatorNodeSelectors(ctx, "bar", datastore.RequireCurrent)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.Selector{c}, selectors)
+}
+
+func (s *PluginSuite) TestListOperatorNodeSelectors() {
+	now := time.Now()
+	_, err := s.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/non-expired-node",
+		AttestationDataType: "fake_nodeattestor",
+		CertSerialNumber:    "non-expired serial",
+		CertNotAfter:        now.Add(time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	_, err = s.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/expired-node",
+		AttestationDataType: "fake_nodeattestor",
+		CertSerialNumber:    "expired serial",
+		CertNotAfter:        now.Add(-time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+
+	selectorMap := map[string][]*common.Selector{
+		"spiffe://example.org/non-expired-node": {{Type: "A", Value: "a"}, {Type: "B", Value: "b"}},
+		"spiffe://example.org/expired-node":     {{Type: "C", Value: "c"}},
+		"spiffe://example.org/unattested-node":  {{Type: "D", Value: "d"}},
+	}
+	for spiffeID, selectors := range selectorMap {
+		_, err := s.ds.AddOperatorNodeSelectors(ctx, spiffeID, selectors)
+		s.Require().NoError(err)
+	}
+	// attestor selectors are not listed
+	s.setNodeSelectors("spiffe://example.org/non-expired-node", []*common.Selector{{Type: "E", Value: "e"}})
+
+	s.T().Run("list all", func(t *testing.T) {
+		resp, err := s.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+		require.NoError(t, err)
+		assertSelectorsEqual(t, selectorMap, resp.Selectors)
+	})
+
+	s.T().Run("list unexpired", func(t *testing.T) {
+		resp, err := s.ds.ListOperatorNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+			ValidAt: now,
+		})
+		require.NoError(t, err)
+		assertSelectorsEqual(t, map[string][]*common.Selector{
+			"spiffe://example.org/non-expired-node": selectorMap["spiffe://example.org/non-expired-node"],
+		}, resp.Selectors)
+	})
+}
+
 func (s *PluginSuite) TestSetNodeSelectorsUnderLoad() {
 	selectors := []*common.Selector{
 		{Type: "TYPE", Value: "VALUE"},
@@ -3464,6 +3922,14 @@ func (s *PluginSuite) TestCreateFederationRelationship() {
 				EndpointSPIFFEID:      spiffeid.RequireFromString("spiffe://federated-td-spiffe.org/federated-server"),
 			},
 		},
+		{
+			name: "creating a new federation relationship succeeds for jwks profile",
+			fr: &datastore.FederationRelationship{
+				TrustDomain:           spiffeid.RequireTrustDomainFromString("federated-td-jwks.org"),
+				BundleEndpointURL:     requireURLFromString(s.T(), "federated-td-jwks.org/.well-known/openid-configuration"),
+				BundleEndpointProfile: datastore.BundleEndpointJWKS,
+			},
+		},
 		{
 			name: "creating a new federation relationship succeeds for web profile and new bundle",
 			fr: &datastore.FederationRelationship{
@@ -3558,7 +4024,7 @@ func (s *PluginSuite) TestCreateFederationRelationship() {
 			// TODO: when FetchFederationRelationship is implemented, assert if entry was created
 
 			switch fr.BundleEndpointProfile {
-			case datastore.BundleEndpointWeb:
+			case datastore.BundleEndpointWeb, datastore.BundleEndpointJWKS:
 			case datastore.BundleEndpointSPIFFE:
 			default:
 				require.FailNowf(t, "unexpected bundle endpoint profile type: %q", string(fr.BundleEndpointProfile))
@@ -3911,6 +4377,226 @@ func (s *PluginSuite) TestUpdateFederationRelationship() {
 	}
 }
 
+func (s *PluginSuite) TestEvents() {
+	latestID, err := s.ds.GetLatestEventID(ctx)
+	s.Require().NoError(err)
+	s.Require().Zero(latestID)
+
+	// Create, update and delete a bundle, an entry and an attested node
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	_, err = s.ds.UpdateRegistrationEntry(ctx, entry, nil)
+	s.Require().NoError(err)
+
+	_, err = s.ds.CreateAttestedNode(ctx, &common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/agent",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	})
+	s.Require().NoError(err)
+	err = s.ds.SetNodeSelectors(ctx, "spiffe://example.org/agent", []*common.Selector{{Type: "TYPE", Value: "VALUE"}})
+	s.Require().NoError(err)
+	_, err = s.ds.DeleteAttestedNode(ctx, "spiffe://example.org/agent")
+	s.Require().NoError(err)
+
+	err = s.ds.DeleteBundle(ctx, "spiffe://otherdomain.org", datastore.Delete)
+	s.Require().NoError(err)
+
+	type event struct {
+		Type         datastore.EventType
+		ResourceType datastore.EventResourceType
+		ResourceID   string
+	}
+	listEvents := func(req *datastore.ListEventsRequest) []event {
+		resp, err := s.ds.ListEvents(ctx, req)
+		s.Require().NoError(err)
+		var events []event
+		for i, e := range resp.Events {
+			if i > 0 {
+				s.Require().Greater(e.ID, resp.Events[i-1].ID)
+			}
+			s.Require().False(e.CreatedAt.IsZero())
+			events = append(events, event{Type: e.Type, ResourceType: e.ResourceType, ResourceID: e.ResourceID})
+		}
+		return events
+	}
+
+	allEvents := []event{
+		{Type: datastore.EventCreated, ResourceType: datastore.BundleEvent, ResourceID: "spiffe://otherdomain.org"},
+		{Type: datastore.EventCreated, ResourceType: datastore.EntryEvent, ResourceID: entry.EntryId},
+		{Type: datastore.EventUpdated, ResourceType: datastore.EntryEvent, ResourceID: entry.EntryId},
+		{Type: datastore.EventCreated, ResourceType: datastore.AttestedNodeEvent, ResourceID: "spiffe://example.org/agent"},
+		{Type: datastore.EventUpdated, ResourceType: datastore.AttestedNodeEvent, ResourceID: "spiffe://example.org/agent"},
+		{Type: datastore.EventDeleted, ResourceType: datastore.AttestedNodeEvent, ResourceID: "spiffe://example.org/agent"},
+		{Type: datastore.EventDeleted, ResourceType: datastore.EntryEvent, ResourceID: entry.EntryId},
+		{Type: datastore.EventDeleted, ResourceType: datastore.BundleEvent, ResourceID: "spiffe://otherdomain.org"},
+	}
+	s.Require().Equal(allEvents, listEvents(&datastore.ListEventsRequest{}))
+
+	// Filter by resource type
+	s.Require().Equal([]event{allEvents[1], allEvents[2], allEvents[6]}, listEvents(&datastore.ListEventsRequest{
+		ByResourceTypes: []datastore.EventResourceType{datastore.EntryEvent},
+	}))
+
+	// Page through the events using the last seen event ID
+	resp, err := s.ds.ListEvents(ctx, &datastore.ListEventsRequest{Limit: 3})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Events, 3)
+	s.Require().Equal(allEvents[3:], listEvents(&datastore.ListEventsRequest{AfterID: resp.Events[2].ID}))
+
+	latestID, err = s.ds.GetLatestEventID(ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(listEvents(&datastore.ListEventsRequest{AfterID: latestID}))
+
+	// Events created after the prune time are kept
+	err = s.ds.PruneEvents(ctx, time.Now().Add(-time.Hour))
+	s.Require().NoError(err)
+	s.Require().Equal(allEvents, listEvents(&datastore.ListEventsRequest{}))
+
+	// Events can be listed by ID
+	s.Require().Equal([]event{allEvents[0], allEvents[7]}, listEvents(&datastore.ListEventsRequest{
+		ByIDs: []uint{resp.Events[0].ID, latestID, latestID + 1},
+	}))
+
+	// The latest event is kept when pruning
+	err = s.ds.PruneEvents(ctx, time.Now().Add(time.Hour))
+	s.Require().NoError(err)
+	s.Require().Equal(allEvents[7:], listEvents(&datastore.ListEventsRequest{}))
+
+	// The latest event ID is not reset by pruning
+	_ = s.createBundle("spiffe://otherdomain.org")
+	newLatestID, err := s.ds.GetLatestEventID(ctx)
+	s.Require().NoError(err)
+	s.Require().Greater(newLatestID, latestID)
+}
+
+func (s *PluginSuite) TestEntryRevisions() {
+	authorCtx := datastore.WithChangeAuthor(ctx, "spiffe://example.org/admin")
+
+	// Create, update and delete an entry
+	s.createBundle("spiffe://otherdomain.org")
+	entry, err := s.ds.CreateRegistrationEntry(authorCtx, makeFederatedRegistrationEntry())
+	s.Require().NoError(err)
+	created := proto.Clone(entry).(*common.RegistrationEntry)
+
+	entry.Selectors = []*common.Selector{{Type: "Type2", Value: "Value2"}}
+	entry.DnsNames = []string{"example.org"}
+	updated, err := s.ds.UpdateRegistrationEntry(ctx, entry, nil)
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteRegistrationEntry(authorCtx, entry.EntryId)
+	s.Require().NoError(err)
+
+	type revision struct {
+		Revision      int64
+		ChangeType    datastore.EventType
+		ChangedBy     string
+		ChangedFields []string
+		Entry         *common.RegistrationEntry
+	}
+	listRevisions := func(entryID string) []revision {
+		revisions, err := s.ds.ListRegistrationEntryRevisions(ctx, entryID)
+		s.Require().NoError(err)
+		var out []revision
+		for _, r := range revisions {
+			s.Require().Equal(entryID, r.EntryID)
+			s.Require().False(r.ChangedAt.IsZero())
+			out = append(out, revision{
+				Revision:      r.Revision,
+				ChangeType:    r.ChangeType,
+				ChangedBy:     r.ChangedBy,
+				ChangedFields: r.ChangedFields,
+				Entry:         r.Entry,
+			})
+		}
+		return out
+	}
+
+	revisions := listRevisions(entry.EntryId)
+	s.Require().Len(revisions, 3)
+	s.Require().Equal(int64(1), revisions[0].Revision)
+	s.Require().Equal(datastore.EventCreated, revisions[0].ChangeType)
+	s.Require().Equal("spiffe://example.org/admin", revisions[0].ChangedBy)
+	s.Require().Empty(revisions[0].ChangedFields)
+	s.AssertProtoEqual(created, revisions[0].Entry)
+	s.Require().Equal(int64(2), revisions[1].Revision)
+	s.Require().Equal(datastore.EventUpdated, revisions[1].ChangeType)
+	s.Require().Empty(revisions[1].ChangedBy)
+	s.Require().Equal([]string{"selectors", "dns_names"}, revisions[1].ChangedFields)
+	s.AssertProtoEqual(updated, revisions[1].Entry)
+	s.Require().Equal(int64(3), revisions[2].Revision)
+	s.Require().Equal(datastore.EventDeleted, revisions[2].ChangeType)
+	s.Require().Equal("spiffe://example.org/admin", revisions[2].ChangedBy)
+	s.AssertProtoEqual(updated, revisions[2].Entry)
+
+	// Fetch a single revision
+	fetched, err := s.ds.FetchRegistrationEntryRevision(ctx, entry.EntryId, 1)
+	s.Require().NoError(err)
+	s.AssertProtoEqual(created, fetched.Entry)
+
+	fetched, err = s.ds.FetchRegistrationEntryRevision(ctx, entry.EntryId, 4)
+	s.Require().NoError(err)
+	s.Require().Nil(fetched)
+
+	// Restoring a missing revision fails
+	_, err = s.ds.RestoreRegistrationEntryRevision(ctx, entry.EntryId, 4)
+	s.RequireGRPCStatus(err, codes.NotFound, "datastore-sql: registration entry revision not found")
+
+	// Restoring a deleted entry recreates it with the same ID
+	restored, err := s.ds.RestoreRegistrationEntryRevision(authorCtx, entry.EntryId, 1)
+	s.Require().NoError(err)
+	s.Require().Equal(entry.EntryId, restored.EntryId)
+	s.RequireProtoListEqual(created.Selectors, restored.Selectors)
+	s.Require().Equal(created.FederatesWith, restored.FederatesWith)
+	s.Require().Empty(restored.DnsNames)
+	s.AssertProtoEqual(restored, s.fetchRegistrationEntry(entry.EntryId))
+
+	// Restoring an existing entry rolls back the changes made to it
+	restored, err = s.ds.RestoreRegistrationEntryRevision(ctx, entry.EntryId, 2)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(updated.Selectors, restored.Selectors)
+	s.Require().Equal(updated.DnsNames, restored.DnsNames)
+	s.AssertProtoEqual(restored, s.fetchRegistrationEntry(entry.EntryId))
+
+	revisions = listRevisions(entry.EntryId)
+	s.Require().Len(revisions, 5)
+	s.Require().Equal(datastore.EventCreated, revisions[3].ChangeType)
+	s.Require().Equal(datastore.EventUpdated, revisions[4].ChangeType)
+	s.Require().Equal([]string{"selectors", "dns_names"}, revisions[4].ChangedFields)
+
+	// A deleted entry is not restored over a similar entry created since
+	_, err = s.ds.DeleteRegistrationEntry(ctx, entry.EntryId)
+	s.Require().NoError(err)
+	similar := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  updated.SpiffeId,
+		ParentId:  updated.ParentId,
+		Selectors: updated.Selectors,
+	})
+	_, err = s.ds.RestoreRegistrationEntryRevision(ctx, entry.EntryId, 2)
+	s.RequireGRPCStatus(err, codes.AlreadyExists, fmt.Sprintf("datastore-sql: cannot restore registration entry; similar entry %q already exists", similar.EntryId))
+
+	// Deleting a bundle records the changes to the federated entries
+	federated := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	err = s.ds.DeleteBundle(ctx, "spiffe://otherdomain.org", datastore.Dissociate)
+	s.Require().NoError(err)
+	revisions = listRevisions(federated.EntryId)
+	s.Require().Len(revisions, 2)
+	s.Require().Equal(datastore.EventUpdated, revisions[1].ChangeType)
+	s.Require().Equal([]string{"federates_with"}, revisions[1].ChangedFields)
+	s.Require().Empty(revisions[1].Entry.FederatesWith)
+
+	// Revisions created after the prune time are kept
+	err = s.ds.PruneRegistrationEntryRevisions(ctx, time.Now().Add(-time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(listRevisions(entry.EntryId), 6)
+
+	err = s.ds.PruneRegistrationEntryRevisions(ctx, time.Now().Add(time.Hour))
+	s.Require().NoError(err)
+	s.Require().Empty(listRevisions(entry.EntryId))
+	s.Require().Empty(listRevisions(federated.EntryId))
+}
+
 func (s *PluginSuite) TestMigration() {
 	for schemaVersion := 0; schemaVersion < latestSchemaVersion; schemaVersion++ {
 		s.T().Run(fmt.Sprintf("migration_from_schema_version_%d", schemaVersion), func(t *testing.T) {
@@ -3966,6 +4652,23 @@ func (s *PluginSuite) TestMigration() {
 			case 20:
 				prepareDB(true)
 				require.True(s.ds.db.Dialect().HasIndex("registered_entries", "idx_registered_entries_hint"))
+			case 21:
+				prepareDB(true)
+				require.True(s.ds.db.HasTable("events"))
+			case 22:
+				prepareDB(true)
+				require.True(s.ds.db.HasTable("registered_entry_revisions"))
+			case 23:
+				prepareDB(true)
+				require.True(s.ds.db.Dialect().HasColumn("attested_node_entries", "agent_version"))
+				require.True(s.ds.db.Dialect().HasColumn("attested_node_entries", "last_seen_at"))
+				require.True(s.ds.db.Dialect().HasIndex("attested_node_entries", "idx_attested_node_entries_last_seen_at"))
+			case 24:
+				prepareDB(true)
+				require.True(s.ds.db.Dialect().HasColumn("attested_node_entries", "reattest_requested"))
+			case 25:
+				prepareDB(true)
+				require.True(s.ds.db.HasTable("operator_node_selectors"))
 			default:
 				t.Fatalf("no migration test added for schema version %d", schemaVersion)
 			}
@@ -0,0 +1,175 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	server_telemetry "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// agentStatusFlushInterval is the amount of time between two writes of
+	// the status reported by agents to the datastore.
+	agentStatusFlushInterval = 30 * time.Second
+
+	// agentStatusActiveWindow is the amount of time an agent is counted in
+	// the agent version gauge after it was last seen.
+	agentStatusActiveWindow = 5 * time.Minute
+)
+
+type seenAgent struct {
+	version  string
+	lastSeen time.Time
+}
+
+// AgentStatusTracker tracks the version and last seen time of the agents
+// calling the server. Reports are kept in memory and periodically written to
+// the datastore in a single batch, so agents calling the server frequently
+// do not translate into as many datastore writes.
+type AgentStatusTracker struct {
+	ds      datastore.DataStore
+	log     logrus.FieldLogger
+	metrics telemetry.Metrics
+	clk     clock.Clock
+
+	mu      sync.Mutex
+	pending map[string]*datastore.AttestedNodeStatus
+	seen    map[string]seenAgent
+	// versions are the versions emitted by the last gauge update
+	versions map[string]struct{}
+}
+
+// NewAgentStatusTracker creates a new agent status tracker.
+func NewAgentStatusTracker(ds datastore.DataStore, log logrus.FieldLogger, metrics telemetry.Metrics, clk clock.Clock) *AgentStatusTracker {
+	return &AgentStatusTracker{
+		ds:       ds,
+		log:      log,
+		metrics:  metrics,
+		clk:      clk,
+		pending:  make(map[string]*datastore.AttestedNodeStatus),
+		seen:     make(map[string]seenAgent),
+		versions: make(map[string]struct{}),
+	}
+}
+
+// Report records that the agent has been seen. The agent version is read
+// from the incoming gRPC metadata, if reported.
+func (t *AgentStatusTracker) Report(ctx context.Context, agentID spiffeid.ID) {
+	agentVersion := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(version.AgentVersionMetadataKey); len(values) > 0 {
+			agentVersion = values[0]
+		}
+	}
+
+	now := t.clk.Now()
+	id := agentID.String()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if status, ok := t.pending[id]; ok && agentVersion == "" {
+		// Keep the version reported earlier in this batch
+		agentVersion = status.AgentVersion
+	}
+	t.pending[id] = &datastore.AttestedNodeStatus{
+		SpiffeID:     id,
+		AgentVersion: agentVersion,
+		LastSeenAt:   now,
+	}
+	t.seen[id] = seenAgent{
+		version:  agentVersion,
+		lastSeen: now,
+	}
+}
+
+// Run periodically writes the reported status to the datastore until the
+// context is canceled. The pending reports are written before returning.
+func (t *AgentStatusTracker) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			// Use a fresh context so the last batch is not lost on shutdown
+			t.flush(context.Background())
+			return nil
+		case <-t.clk.After(agentStatusFlushInterval):
+			t.flush(ctx)
+		}
+	}
+}
+
+func (t *AgentStatusTracker) flush(ctx context.Context) {
+	t.mu.Lock()
+	statuses := make([]*datastore.AttestedNodeStatus, 0, len(t.pending))
+	for _, status := range t.pending {
+		statuses = append(statuses, status)
+	}
+	t.pending = make(map[string]*datastore.AttestedNodeStatus)
+	counts := t.countVersions()
+	t.mu.Unlock()
+
+	for agentVersion, count := range counts {
+		server_telemetry.SetAgentVersionGauge(t.metrics, agentVersion, count)
+	}
+
+	if len(statuses) == 0 {
+		return
+	}
+	if err := t.ds.UpdateAttestedNodesStatus(ctx, statuses); err != nil {
+		t.log.WithError(err).WithField(telemetry.Count, len(statuses)).Error("Failed to update the status reported by agents")
+	}
+}
+
+// countVersions counts the agents seen within the active window by version,
+// forgetting the agents seen before it. Versions that are no longer run by
+// any agent are counted once as zero so their gauge is reset. The lock must
+// be held by the caller.
+func (t *AgentStatusTracker) countVersions() map[string]int {
+	activeAfter := t.clk.Now().Add(-agentStatusActiveWindow)
+
+	counts := make(map[string]int)
+	for agentVersion := range t.versions {
+		counts[agentVersion] = 0
+	}
+	for id, agent := range t.seen {
+		if agent.lastSeen.Before(activeAfter) {
+			delete(t.seen, id)
+			continue
+		}
+		agentVersion := agent.version
+		if agentVersion == "" {
+			agentVersion = telemetry.Unknown
+		}
+		counts[agentVersion]++
+	}
+
+	t.versions = make(map[string]struct{})
+	for agentVersion, count := range counts {
+		if count > 0 {
+			t.versions[agentVersion] = struct{}{}
+		}
+	}
+	return counts
+}
+
+// reportAgentStatus wraps the agent authorizer so that the agents that are
+// successfully authorized are reported to the tracker.
+func reportAgentStatus(authorizer middleware.AgentAuthorizer, tracker *AgentStatusTracker) middleware.AgentAuthorizer {
+	return middleware.AgentAuthorizerFunc(func(ctx context.Context, agentID spiffeid.ID, agentSVID *x509.Certificate) error {
+		if err := authorizer.AuthorizeAgent(ctx, agentID, agentSVID); err != nil {
+			return err
+		}
+		tracker.Report(ctx, agentID)
+		return nil
+	})
+}
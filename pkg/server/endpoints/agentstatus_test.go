@@ -0,0 +1,146 @@
+package endpoints
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/version"
+	"github.com/spiffe/spire/pkg/server/api/middleware"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAgentStatusTracker(t *testing.T) {
+	ctx := context.Background()
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New(t)
+	metrics := fakemetrics.New()
+	clk := clock.NewMock(t)
+
+	oldAgentID := spiffeid.RequireFromPath(testTD, "/spire/agent/old")
+	unknownAgentID := spiffeid.RequireFromPath(testTD, "/spire/agent/unknown")
+	for _, id := range []spiffeid.ID{agentID, oldAgentID, unknownAgentID} {
+		_, err := ds.CreateAttestedNode(ctx, &common.AttestedNode{
+			SpiffeId:            id.String(),
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "badcafe",
+			CertNotAfter:        clk.Now().Add(time.Hour).Unix(),
+		})
+		require.NoError(t, err)
+	}
+
+	withVersion := func(agentVersion string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(version.AgentVersionMetadataKey, agentVersion))
+	}
+
+	tracker := NewAgentStatusTracker(ds, log, metrics, clk)
+	tracker.Report(withVersion("1.6.0"), agentID)
+	tracker.Report(withVersion("1.5.0"), oldAgentID)
+	tracker.Report(ctx, unknownAgentID)
+	tracker.flush(ctx)
+
+	requireStatus := func(id spiffeid.ID, agentVersion string, lastSeenAt time.Time) {
+		node, err := ds.FetchAttestedNode(ctx, id.String())
+		require.NoError(t, err)
+		assert.Equal(t, agentVersion, node.AgentVersion)
+		assert.Equal(t, lastSeenAt.Unix(), node.LastSeenAt)
+	}
+	requireStatus(agentID, "1.6.0", clk.Now())
+	requireStatus(oldAgentID, "1.5.0", clk.Now())
+	requireStatus(unknownAgentID, "", clk.Now())
+
+	assert.ElementsMatch(t, []fakemetrics.MetricItem{
+		agentVersionGauge("1.6.0", 1),
+		agentVersionGauge("1.5.0", 1),
+		agentVersionGauge(telemetry.Unknown, 1),
+	}, metrics.AllMetrics())
+
+	// The old agent is upgraded, while the others are no longer seen and are
+	// forgotten once the active window elapses
+	firstSeen := clk.Now()
+	clk.Add(agentStatusActiveWindow + time.Second)
+	tracker.Report(withVersion("1.6.0"), oldAgentID)
+	metrics.Reset()
+	tracker.flush(ctx)
+
+	requireStatus(agentID, "1.6.0", firstSeen)
+	requireStatus(oldAgentID, "1.6.0", clk.Now())
+
+	assert.ElementsMatch(t, []fakemetrics.MetricItem{
+		agentVersionGauge("1.6.0", 1),
+		agentVersionGauge("1.5.0", 0),
+		agentVersionGauge(telemetry.Unknown, 0),
+	}, metrics.AllMetrics())
+
+	// Versions are reset only once
+	metrics.Reset()
+	tracker.flush(ctx)
+	assert.Equal(t, []fakemetrics.MetricItem{
+		agentVersionGauge("1.6.0", 1),
+	}, metrics.AllMetrics())
+}
+
+func TestAgentStatusTrackerFlushesOnShutdown(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New(t)
+	clk := clock.NewMock(t)
+
+	_, err := ds.CreateAttestedNode(context.Background(), &common.AttestedNode{
+		SpiffeId:            agentID.String(),
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        clk.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	tracker := NewAgentStatusTracker(ds, log, fakemetrics.New(), clk)
+	tracker.Report(context.Background(), agentID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, tracker.Run(ctx))
+
+	node, err := ds.FetchAttestedNode(context.Background(), agentID.String())
+	require.NoError(t, err)
+	assert.Equal(t, clk.Now().Unix(), node.LastSeenAt)
+}
+
+func TestReportAgentStatus(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New(t)
+	tracker := NewAgentStatusTracker(ds, log, fakemetrics.New(), clock.NewMock(t))
+
+	var authorizeErr error
+	authorizer := reportAgentStatus(middleware.AgentAuthorizerFunc(func(context.Context, spiffeid.ID, *x509.Certificate) error {
+		return authorizeErr
+	}), tracker)
+
+	// Agents failing authorization are not reported
+	authorizeErr = errors.New("denied")
+	require.EqualError(t, authorizer.AuthorizeAgent(context.Background(), agentID, nil), "denied")
+	assert.Empty(t, tracker.pending)
+
+	authorizeErr = nil
+	require.NoError(t, authorizer.AuthorizeAgent(context.Background(), agentID, nil))
+	assert.Contains(t, tracker.pending, agentID.String())
+}
+
+func agentVersionGauge(agentVersion string, count float32) fakemetrics.MetricItem {
+	return fakemetrics.MetricItem{
+		Type:   fakemetrics.SetGaugeWithLabelsType,
+		Key:    []string{telemetry.Node, telemetry.Version},
+		Val:    count,
+		Labels: telemetry.SanitizeLabels([]telemetry.Label{{Name: telemetry.Version, Value: agentVersion}}),
+	}
+}
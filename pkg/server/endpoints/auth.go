@@ -67,7 +67,7 @@ func (e *Endpoints) bundleGetter(ctx context.Context, td spiffeid.TrustDomain) (
 func (e *Endpoints) serverSpiffeVerificationFunc(bundleSource x509bundle.Source) func(_ [][]byte, _ [][]*x509.Certificate) error {
 	verifyPeerCertificate := tlsconfig.VerifyPeerCertificate(
 		bundleSource,
-		tlsconfig.AdaptMatcher(matchMemberOrOneOf(e.TrustDomain, e.AdminIDs...)),
+		tlsconfig.AdaptMatcher(matchMemberOrOneOf(e.TrustDomain, append(e.AdminIDs, e.Roles.SPIFFEIDs()...)...)),
 	)
 
 	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
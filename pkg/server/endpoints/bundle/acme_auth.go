@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto"
 	"crypto/tls"
+	"crypto/x509"
+	"net/http"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/version"
@@ -42,6 +44,17 @@ type ACMEConfig struct {
 	// not true, and the provider requires acceptance, then certificate
 	// retrieval will fail.
 	ToSAccepted bool
+
+	// EABKeyID is the key identifier of the External Account Binding used to
+	// register the account, if required by the ACME server.
+	EABKeyID string
+
+	// EABHMACKey is the MAC key of the External Account Binding.
+	EABHMACKey []byte
+
+	// DirectoryRootCAs, if set, are the root CAs used to authenticate the
+	// ACME server instead of the system roots.
+	DirectoryRootCAs []*x509.Certificate
 }
 
 func ACMEAuth(log logrus.FieldLogger, km keymanager.KeyManager, config ACMEConfig) ServerAuth {
@@ -55,6 +68,28 @@ func ACMEAuth(log logrus.FieldLogger, km keymanager.KeyManager, config ACMEConfi
 		log.Warn("ACME Terms of Service have not been accepted. See the `tos_accepted` configurable")
 	}
 
+	var eab *acme.ExternalAccountBinding
+	if config.EABKeyID != "" {
+		eab = &acme.ExternalAccountBinding{
+			KID: config.EABKeyID,
+			Key: config.EABHMACKey,
+		}
+	}
+
+	var httpClient *http.Client
+	if len(config.DirectoryRootCAs) > 0 {
+		rootCAs := x509.NewCertPool()
+		for _, rootCA := range config.DirectoryRootCAs {
+			rootCAs.AddCert(rootCA)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+
 	return &acmeAuth{
 		m: &autocert.Manager{
 			Prompt: func(tosURL string) bool {
@@ -70,12 +105,14 @@ func ACMEAuth(log logrus.FieldLogger, km keymanager.KeyManager, config ACMEConfi
 				tosLog.Warn("ACME Terms of Service have not been accepted. See the `tos_accepted` configurable")
 				return false
 			},
-			Email:      config.Email,
-			Cache:      autocert.DirCache(config.CacheDir),
-			HostPolicy: autocert.HostWhitelist(config.DomainName),
+			Email:                  config.Email,
+			Cache:                  autocert.DirCache(config.CacheDir),
+			HostPolicy:             autocert.HostWhitelist(config.DomainName),
+			ExternalAccountBinding: eab,
 			Client: &acme.Client{
 				DirectoryURL: config.DirectoryURL,
 				UserAgent:    "SPIRE-" + version.Version(),
+				HTTPClient:   httpClient,
 			},
 			KeyStore: &acmeKeyStore{
 				log: log,
@@ -32,6 +32,8 @@
 // - Verifies signatures on incoming requests to ensure requests are signed
 //   appropriately by the SPIRE KeyManager signers.
 // - Fails new-reg requests if the terms-of-service has not been accepted
+// - Optionally requires an External Account Binding on new-reg requests
+// - Optionally serves the ACME directory over TLS
 
 //nolint // forked code
 package acmetest
@@ -88,6 +90,10 @@ type CAServer struct {
 
 	accountKeysMu sync.Mutex
 	accountKeys   map[string]interface{}
+
+	eabMu  sync.Mutex
+	eabKID string
+	eabKey []byte
 }
 
 // NewCAServer creates a new ACME test server and starts serving requests.
@@ -99,6 +105,38 @@ type CAServer struct {
 // If domainsWhitelist is non-empty, the certs will be issued only for the specified
 // list of domains. Otherwise, any domain name is allowed.
 func NewCAServer(challengeTypes []string, domainsWhitelist []string) *CAServer {
+	ca := newCAServer(challengeTypes, domainsWhitelist)
+	ca.server = httptest.NewServer(http.HandlerFunc(ca.handle))
+	ca.URL = ca.server.URL
+	return ca
+}
+
+// NewTLSCAServer is like NewCAServer but serves the ACME directory over TLS
+// using a certificate signed by a test root that is not trusted by the
+// system. The certificate is available through the ServerCertificate method.
+func NewTLSCAServer(challengeTypes []string, domainsWhitelist []string) *CAServer {
+	ca := newCAServer(challengeTypes, domainsWhitelist)
+	ca.server = httptest.NewTLSServer(http.HandlerFunc(ca.handle))
+	ca.URL = ca.server.URL
+	return ca
+}
+
+// ServerCertificate returns the certificate used by the server to serve the
+// ACME directory over TLS, or nil if it is not served over TLS.
+func (ca *CAServer) ServerCertificate() *x509.Certificate {
+	return ca.server.Certificate()
+}
+
+// RequireExternalAccountBinding makes the server fail new-reg requests that
+// are not bound to the external account with the given key ID and MAC key.
+func (ca *CAServer) RequireExternalAccountBinding(kid string, key []byte) {
+	ca.eabMu.Lock()
+	defer ca.eabMu.Unlock()
+	ca.eabKID = kid
+	ca.eabKey = key
+}
+
+func newCAServer(challengeTypes []string, domainsWhitelist []string) *CAServer {
 	var whitelist []string
 	for _, name := range domainsWhitelist {
 		whitelist = append(whitelist, name)
@@ -141,9 +179,6 @@ func NewCAServer(challengeTypes []string, domainsWhitelist []string) *CAServer {
 	ca.rootKey = key
 	ca.rootCert = der
 	ca.rootTemplate = tmpl
-
-	ca.server = httptest.NewServer(http.HandlerFunc(ca.handle))
-	ca.URL = ca.server.URL
 	return ca
 }
 
@@ -248,7 +283,8 @@ func (ca *CAServer) handle(w http.ResponseWriter, r *http.Request) {
 	case r.URL.Path == "/new-reg":
 		// Fail the request unless terms are accepted
 		var req struct {
-			TermsOfServiceAgreed bool `json:"termsOfServiceAgreed"`
+			TermsOfServiceAgreed   bool            `json:"termsOfServiceAgreed"`
+			ExternalAccountBinding json.RawMessage `json:"externalAccountBinding"`
 		}
 		if err := ca.decodePayload(&req, r.Body); err != nil {
 			ca.httpErrorf(w, http.StatusBadRequest, err.Error())
@@ -258,6 +294,10 @@ func (ca *CAServer) handle(w http.ResponseWriter, r *http.Request) {
 			ca.httpErrorf(w, http.StatusBadRequest, "must agree to terms of service")
 			return
 		}
+		if err := ca.verifyExternalAccountBinding(req.ExternalAccountBinding); err != nil {
+			ca.httpErrorf(w, http.StatusUnauthorized, err.Error())
+			return
+		}
 
 		// TODO: Check the user account key against a ca.accountKeys?
 		w.Header().Set("Location", ca.serverURL("/accounts/1"))
@@ -630,6 +670,30 @@ func (ca *CAServer) decodePayload(v interface{}, r io.Reader) error {
 	return nil
 }
 
+func (ca *CAServer) verifyExternalAccountBinding(eab json.RawMessage) error {
+	ca.eabMu.Lock()
+	kid, key := ca.eabKID, ca.eabKey
+	ca.eabMu.Unlock()
+
+	if kid == "" {
+		return nil
+	}
+	if len(eab) == 0 {
+		return errors.New("external account binding required")
+	}
+	jws, err := jose.ParseSigned(string(eab))
+	if err != nil {
+		return errors.New("malformed external account binding")
+	}
+	if len(jws.Signatures) != 1 || jws.Signatures[0].Protected.KeyID != kid {
+		return errors.New("external account binding is not for a known external account")
+	}
+	if _, err := jws.Verify(key); err != nil {
+		return fmt.Errorf("invalid external account binding signature: %v", err)
+	}
+	return nil
+}
+
 func (ca *CAServer) lookupAccountKey(kid string) interface{} {
 	ca.accountKeysMu.Lock()
 	defer ca.accountKeysMu.Unlock()
@@ -196,6 +196,11 @@ type Manager struct {
 	// on what each client supports.
 	ForceRSA bool
 
+	// ExternalAccountBinding optionally represents an arbitrary binding to an
+	// account of the CA to which the ACME server is tied.
+	// See RFC 8555, Section 7.3.4 for more details.
+	ExternalAccountBinding *acme.ExternalAccountBinding
+
 	// ExtraExtensions are used when generating a new CSR (Certificate Request),
 	// thus allowing customization of the resulting certificate.
 	// For instance, TLS Feature Extension (RFC 7633) can be used
@@ -1058,7 +1063,7 @@ func (m *Manager) acmeClient(ctx context.Context) (*acme.Client, error) {
 	if m.Email != "" {
 		contact = []string{"mailto:" + m.Email}
 	}
-	a := &acme.Account{Contact: contact}
+	a := &acme.Account{Contact: contact, ExternalAccountBinding: m.ExternalAccountBinding}
 	_, err := client.Register(ctx, a, m.Prompt)
 	if err == nil || isAccountAlreadyExist(err) {
 		m.client = client
@@ -1,13 +1,17 @@
 package bundle
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/zeebo/errs"
@@ -32,6 +36,7 @@ type ServerConfig struct {
 	Address    string
 	Getter     Getter
 	ServerAuth ServerAuth
+	Clock      clock.Clock
 
 	// test hooks
 	listen func(network, address string) (net.Listener, error)
@@ -39,12 +44,21 @@ type ServerConfig struct {
 
 type Server struct {
 	c ServerConfig
+
+	// etag is the entity tag of the last bundle document served and
+	// lastModified is when a document with that tag was first served.
+	mtx          sync.Mutex
+	etag         string
+	lastModified time.Time
 }
 
 func NewServer(config ServerConfig) *Server {
 	if config.listen == nil {
 		config.listen = net.Listen
 	}
+	if config.Clock == nil {
+		config.Clock = clock.New()
+	}
 	return &Server{
 		c: config,
 	}
@@ -101,7 +115,6 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 
 	refreshHint := bundleutil.CalculateRefreshHint(b)
 
-	// TODO: bundle sequence number?
 	opts := []bundleutil.MarshalOption{
 		bundleutil.OverrideRefreshHint(refreshHint),
 	}
@@ -113,8 +126,32 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// The datastore bumps the bundle sequence number every time the bundle
+	// content changes, so the entity tag is derived from it and from the
+	// refresh hint, which is the only other part of the document. Clients
+	// are told to cache the document for the refresh hint period, and can
+	// revalidate it using conditional requests, which ServeContent takes care
+	// of.
+	etag, lastModified := s.cacheValidators(b.SequenceNumber(), refreshHint)
 	w.Header().Set("Content-Type", "application/json")
-	_, _ = w.Write(jsonBytes)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int64(refreshHint/time.Second)))
+	http.ServeContent(w, req, "", lastModified, bytes.NewReader(jsonBytes))
+}
+
+// cacheValidators returns the entity tag of the bundle document with the
+// given sequence number and refresh hint, and when a document with that tag
+// was first served.
+func (s *Server) cacheValidators(sequenceNumber uint64, refreshHint time.Duration) (string, time.Time) {
+	etag := fmt.Sprintf(`"%d-%d"`, sequenceNumber, int64(refreshHint/time.Second))
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if etag != s.etag {
+		s.etag = etag
+		s.lastModified = s.c.Clock.Now().UTC().Truncate(time.Second)
+	}
+	return s.etag, s.lastModified
 }
 
 func chainDER(chain []*x509.Certificate) [][]byte {
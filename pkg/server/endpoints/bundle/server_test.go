@@ -13,6 +13,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle/internal/acmetest"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeserverkeymanager"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/assert"
@@ -152,6 +154,111 @@ func TestServer(t *testing.T) {
 	}
 }
 
+func TestServerConditionalRequests(t *testing.T) {
+	serverCert, serverKey := createServerCertificate(t)
+
+	trustDomain := spiffeid.RequireTrustDomainFromString("domain.test")
+	bundle1 := bundleutil.New(trustDomain)
+	bundle1.AppendRootCA(serverCert)
+	bundle1.SetSequenceNumber(1)
+	bundle2 := bundle1.Clone()
+	bundle2.SetSequenceNumber(2)
+	bundle3 := bundle2.Clone()
+	bundle3.SetRefreshHint(time.Hour)
+
+	var bundleMtx sync.Mutex
+	bundle := bundle1
+	getter := GetterFunc(func(ctx context.Context) (*bundleutil.Bundle, error) {
+		bundleMtx.Lock()
+		defer bundleMtx.Unlock()
+		return bundle, nil
+	})
+
+	clk := clock.NewMock(t)
+	addr, done := newTestServerWithClock(t, getter, testSPIFFEAuth(serverCert, serverKey), clk)
+	defer done()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCert)
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	get := func(header http.Header) (*http.Response, string) {
+		req, err := http.NewRequest("GET", fmt.Sprintf("https://%s/", addr), nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	// The first request returns the document along with the cache
+	// validators and the refresh hint as the max age.
+	resp, body := get(nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, body)
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	require.Equal(t, `"1-360"`, etag)
+	require.Equal(t, clk.Now().UTC().Format(http.TimeFormat), lastModified)
+	require.Equal(t, "max-age=360", resp.Header.Get("Cache-Control"))
+
+	// Requests with matching validators are not served the document
+	resp, body = get(http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	require.Empty(t, body)
+	require.Equal(t, etag, resp.Header.Get("ETag"))
+
+	resp, body = get(http.Header{"If-Modified-Since": {lastModified}})
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	require.Empty(t, body)
+
+	// Requests with stale validators are served the document
+	resp, body = get(http.Header{"If-None-Match": {`"stale"`}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, body)
+
+	// The last modified time only moves when the entity tag changes
+	clk.Add(time.Minute)
+	resp, _ = get(nil)
+	require.Equal(t, etag, resp.Header.Get("ETag"))
+	require.Equal(t, lastModified, resp.Header.Get("Last-Modified"))
+
+	// Once the bundle sequence number changes, the entity tag and the last
+	// modified time change as well
+	bundleMtx.Lock()
+	bundle = bundle2
+	bundleMtx.Unlock()
+
+	resp, body = get(http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, body)
+	require.Equal(t, `"2-360"`, resp.Header.Get("ETag"))
+	require.Equal(t, clk.Now().UTC().Format(http.TimeFormat), resp.Header.Get("Last-Modified"))
+
+	// The same goes for the refresh hint
+	bundleMtx.Lock()
+	bundle = bundle3
+	bundleMtx.Unlock()
+
+	resp, body = get(http.Header{"If-None-Match": {`"2-360"`}})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotEmpty(t, body)
+	require.Equal(t, `"2-3600"`, resp.Header.Get("ETag"))
+	require.Equal(t, "max-age=3600", resp.Header.Get("Cache-Control"))
+}
+
 func TestACMEAuth(t *testing.T) {
 	dir := spiretest.TempDir(t)
 
@@ -275,7 +382,95 @@ func TestACMEAuth(t *testing.T) {
 	})
 }
 
+func TestACMEAuthWithExternalAccountBinding(t *testing.T) {
+	trustDomain := spiffeid.RequireTrustDomainFromString("domain.test")
+	bundle := bundleutil.New(trustDomain)
+
+	ca := acmetest.NewTLSCAServer([]string{"tls-alpn-01"}, []string{"domain.test"})
+	defer ca.Close()
+	ca.RequireExternalAccountBinding("kid-1", []byte("hmac-key"))
+
+	client := http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    ca.Roots,
+				ServerName: "domain.test",
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		config    ACMEConfig
+		expectErr bool
+	}{
+		{
+			name: "directory CA not trusted",
+			config: ACMEConfig{
+				EABKeyID:   "kid-1",
+				EABHMACKey: []byte("hmac-key"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "missing external account binding",
+			config: ACMEConfig{
+				DirectoryRootCAs: []*x509.Certificate{ca.ServerCertificate()},
+			},
+			expectErr: true,
+		},
+		{
+			name: "wrong external account binding key",
+			config: ACMEConfig{
+				EABKeyID:         "kid-1",
+				EABHMACKey:       []byte("wrong-key"),
+				DirectoryRootCAs: []*x509.Certificate{ca.ServerCertificate()},
+			},
+			expectErr: true,
+		},
+		{
+			name: "success",
+			config: ACMEConfig{
+				EABKeyID:         "kid-1",
+				EABHMACKey:       []byte("hmac-key"),
+				DirectoryRootCAs: []*x509.Certificate{ca.ServerCertificate()},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.DirectoryURL = ca.URL
+			config.DomainName = "domain.test"
+			config.CacheDir = spiretest.TempDir(t)
+			config.Email = "admin@domain.test"
+			config.ToSAccepted = true
+
+			log, _ := test.NewNullLogger()
+			addr, done := newTestServer(t, testGetter(bundle),
+				ACMEAuth(log, fakeserverkeymanager.New(t), config),
+			)
+			defer done()
+
+			ca.Resolve("domain.test", addr.String())
+
+			resp, err := client.Get(fmt.Sprintf("https://%s", addr))
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
+
 func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth) (net.Addr, func()) {
+	return newTestServerWithClock(t, getter, serverAuth, clock.NewMock(t))
+}
+
+func newTestServerWithClock(t *testing.T, getter Getter, serverAuth ServerAuth, clk *clock.Mock) (net.Addr, func()) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	addrCh := make(chan net.Addr, 1)
@@ -294,6 +489,7 @@ func newTestServer(t *testing.T, getter Getter, serverAuth ServerAuth) (net.Addr
 		Address:    "localhost:0",
 		Getter:     getter,
 		ServerAuth: serverAuth,
+		Clock:      clk,
 		listen:     listen,
 	})
 
@@ -13,10 +13,18 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
 	agentv1 "github.com/spiffe/spire/pkg/server/api/agent/v1"
+	agentadminv1 "github.com/spiffe/spire/pkg/server/api/agentadmin/v1"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	bundlev1 "github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	debugv1 "github.com/spiffe/spire/pkg/server/api/debug/v1"
+	diagnosticsv1 "github.com/spiffe/spire/pkg/server/api/diagnostics/v1"
 	entryv1 "github.com/spiffe/spire/pkg/server/api/entry/v1"
+	entryhistoryv1 "github.com/spiffe/spire/pkg/server/api/entryhistory/v1"
+	eventv1 "github.com/spiffe/spire/pkg/server/api/event/v1"
+	explainv1 "github.com/spiffe/spire/pkg/server/api/explain/v1"
+	federationstatusv1 "github.com/spiffe/spire/pkg/server/api/federationstatus/v1"
 	healthv1 "github.com/spiffe/spire/pkg/server/api/health/v1"
+	jwksfederationv1 "github.com/spiffe/spire/pkg/server/api/jwksfederation/v1"
 	svidv1 "github.com/spiffe/spire/pkg/server/api/svid/v1"
 	trustdomainv1 "github.com/spiffe/spire/pkg/server/api/trustdomain/v1"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
@@ -77,10 +85,18 @@ type Config struct {
 
 	AuditLogEnabled bool
 
+	// AuditRecorder, if set, writes the audit records to dedicated sinks
+	// instead of the logger.
+	AuditRecorder *audit.Recorder
+
 	// AdminIDs are a list of fixed IDs that when presented by a caller in an
 	// X509-SVID, are granted admin rights.
 	AdminIDs []spiffeid.ID
 
+	// Roles holds the built-in roles bound to callers, which are granted
+	// access to a subset of the admin APIs.
+	Roles *authpolicy.Roles
+
 	BundleManager *bundle_client.Manager
 }
 
@@ -115,6 +131,7 @@ func (c *Config) maybeMakeBundleEndpointServer() Server {
 			return bundleutil.BundleFromProto(commonBundle)
 		}),
 		ServerAuth: serverAuth,
+		Clock:      c.Clock,
 	})
 }
 
@@ -131,6 +148,11 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			Catalog:     c.Catalog,
 			Clock:       c.Clock,
 		}),
+		AgentAdminServer: agentadminv1.New(agentadminv1.Config{
+			DataStore:   ds,
+			Clock:       c.Clock,
+			TrustDomain: c.TrustDomain,
+		}),
 		BundleServer: bundlev1.New(bundlev1.Config{
 			TrustDomain:       c.TrustDomain,
 			DataStore:         ds,
@@ -143,15 +165,40 @@ func (c *Config) makeAPIServers(entryFetcher api.AuthorizedEntryFetcher) APIServ
 			SVIDObserver: c.SVIDObserver,
 			Uptime:       c.Uptime,
 		}),
+		DiagnosticsServer: diagnosticsv1.New(diagnosticsv1.Config{
+			Metrics:   c.Metrics,
+			CAJournal: c.Manager,
+		}),
 		EntryServer: entryv1.New(entryv1.Config{
 			TrustDomain:  c.TrustDomain,
 			DataStore:    ds,
 			EntryFetcher: entryFetcher,
 		}),
+		EntryHistoryServer: entryhistoryv1.New(entryhistoryv1.Config{
+			DataStore: ds,
+		}),
+		EventServer: eventv1.New(eventv1.Config{
+			DataStore: ds,
+			Clock:     c.Clock,
+		}),
+		ExplainServer: explainv1.New(explainv1.Config{
+			TrustDomain:  c.TrustDomain,
+			DataStore:    ds,
+			EntryFetcher: entryFetcher,
+			Clock:        c.Clock,
+		}),
+		FederationStatusServer: federationstatusv1.New(federationstatusv1.Config{
+			StatusProvider: c.BundleManager,
+		}),
 		HealthServer: healthv1.New(healthv1.Config{
 			TrustDomain: c.TrustDomain,
 			DataStore:   ds,
 		}),
+		JWKSFederationServer: jwksfederationv1.New(jwksfederationv1.Config{
+			TrustDomain:     c.TrustDomain,
+			DataStore:       ds,
+			BundleRefresher: c.BundleManager,
+		}),
 		SVIDServer: svidv1.New(svidv1.Config{
 			TrustDomain:  c.TrustDomain,
 			EntryFetcher: entryFetcher,
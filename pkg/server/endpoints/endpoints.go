@@ -30,10 +30,18 @@ import (
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	"github.com/spiffe/spire/pkg/server/api/middleware"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/pkg/server/svid"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
 )
 
@@ -71,18 +79,28 @@ type Endpoints struct {
 	RateLimit                    RateLimitConfig
 	EntryFetcherCacheRebuildTask func(context.Context) error
 	AuditLogEnabled              bool
+	AuditRecorder                *audit.Recorder
 	AuthPolicyEngine             *authpolicy.Engine
 	AdminIDs                     []spiffeid.ID
+	Roles                        *authpolicy.Roles
+	AgentStatusTracker           *AgentStatusTracker
 }
 
 type APIServers struct {
-	AgentServer       agentv1.AgentServer
-	BundleServer      bundlev1.BundleServer
-	DebugServer       debugv1_pb.DebugServer
-	EntryServer       entryv1.EntryServer
-	HealthServer      grpc_health_v1.HealthServer
-	SVIDServer        svidv1.SVIDServer
-	TrustDomainServer trustdomainv1.TrustDomainServer
+	AgentServer            agentv1.AgentServer
+	AgentAdminServer       agentadminv1.AgentAdminServer
+	BundleServer           bundlev1.BundleServer
+	DebugServer            debugv1_pb.DebugServer
+	DiagnosticsServer      diagnosticsv1.DiagnosticsServer
+	EntryServer            entryv1.EntryServer
+	EntryHistoryServer     entryhistoryv1.EntryHistoryServer
+	EventServer            eventv1.EventServer
+	ExplainServer          explainv1.ExplainServer
+	FederationStatusServer federationstatusv1.FederationStatusServer
+	HealthServer           grpc_health_v1.HealthServer
+	JWKSFederationServer   jwksfederationv1.JWKSFederationServer
+	SVIDServer             svidv1.SVIDServer
+	TrustDomainServer      trustdomainv1.TrustDomainServer
 }
 
 // RateLimitConfig holds rate limiting configurations.
@@ -135,8 +153,11 @@ func New(ctx context.Context, c Config) (*Endpoints, error) {
 		RateLimit:                    c.RateLimit,
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
 		AuditLogEnabled:              c.AuditLogEnabled,
+		AuditRecorder:                c.AuditRecorder,
 		AuthPolicyEngine:             c.AuthPolicyEngine,
 		AdminIDs:                     c.AdminIDs,
+		Roles:                        c.Roles,
+		AgentStatusTracker:           NewAgentStatusTracker(ds, c.Log, c.Metrics, c.Clock),
 	}, nil
 }
 
@@ -154,18 +175,31 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 	// New APIs
 	agentv1.RegisterAgentServer(tcpServer, e.APIServers.AgentServer)
 	agentv1.RegisterAgentServer(udsServer, e.APIServers.AgentServer)
+	agentadminv1.RegisterAgentAdminServer(tcpServer, e.APIServers.AgentAdminServer)
+	agentadminv1.RegisterAgentAdminServer(udsServer, e.APIServers.AgentAdminServer)
 	bundlev1.RegisterBundleServer(tcpServer, e.APIServers.BundleServer)
 	bundlev1.RegisterBundleServer(udsServer, e.APIServers.BundleServer)
 	entryv1.RegisterEntryServer(tcpServer, e.APIServers.EntryServer)
 	entryv1.RegisterEntryServer(udsServer, e.APIServers.EntryServer)
+	entryhistoryv1.RegisterEntryHistoryServer(tcpServer, e.APIServers.EntryHistoryServer)
+	entryhistoryv1.RegisterEntryHistoryServer(udsServer, e.APIServers.EntryHistoryServer)
+	eventv1.RegisterEventServer(tcpServer, e.APIServers.EventServer)
+	eventv1.RegisterEventServer(udsServer, e.APIServers.EventServer)
+	explainv1.RegisterExplainServer(tcpServer, e.APIServers.ExplainServer)
+	explainv1.RegisterExplainServer(udsServer, e.APIServers.ExplainServer)
+	federationstatusv1.RegisterFederationStatusServer(tcpServer, e.APIServers.FederationStatusServer)
+	federationstatusv1.RegisterFederationStatusServer(udsServer, e.APIServers.FederationStatusServer)
+	jwksfederationv1.RegisterJWKSFederationServer(tcpServer, e.APIServers.JWKSFederationServer)
+	jwksfederationv1.RegisterJWKSFederationServer(udsServer, e.APIServers.JWKSFederationServer)
 	svidv1.RegisterSVIDServer(tcpServer, e.APIServers.SVIDServer)
 	svidv1.RegisterSVIDServer(udsServer, e.APIServers.SVIDServer)
 	trustdomainv1.RegisterTrustDomainServer(tcpServer, e.APIServers.TrustDomainServer)
 	trustdomainv1.RegisterTrustDomainServer(udsServer, e.APIServers.TrustDomainServer)
 
-	// Register Health and Debug only on UDS server
+	// Register Health, Debug and Diagnostics only on UDS server
 	grpc_health_v1.RegisterHealthServer(udsServer, e.APIServers.HealthServer)
 	debugv1_pb.RegisterDebugServer(udsServer, e.APIServers.DebugServer)
+	diagnosticsv1.RegisterDiagnosticsServer(udsServer, e.APIServers.DiagnosticsServer)
 
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
@@ -175,6 +209,7 @@ func (e *Endpoints) ListenAndServe(ctx context.Context) error {
 			return e.runLocalAccess(ctx, udsServer)
 		},
 		e.EntryFetcherCacheRebuildTask,
+		e.AgentStatusTracker.Run,
 	}
 
 	if e.BundleEndpointServer != nil {
@@ -314,5 +349,5 @@ func (e *Endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 func (e *Endpoints) makeInterceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
 	log := e.Log.WithField(telemetry.SubsystemName, "api")
 
-	return middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, clock.New(), e.RateLimit, e.AuthPolicyEngine, e.AuditLogEnabled, e.AdminIDs))
+	return middleware.Interceptors(Middleware(log, e.Metrics, e.DataStore, clock.New(), e.RateLimit, e.AuthPolicyEngine, e.AuditLogEnabled, e.AuditRecorder, e.AdminIDs, e.Roles, e.AgentStatusTracker))
 }
@@ -25,6 +25,13 @@ import (
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
 	"github.com/spiffe/spire/pkg/server/svid"
+	agentadminv1 "github.com/spiffe/spire/proto/spire/api/server/agentadmin/v1"
+	diagnosticsv1 "github.com/spiffe/spire/proto/spire/api/server/diagnostics/v1"
+	entryhistoryv1 "github.com/spiffe/spire/proto/spire/api/server/entryhistory/v1"
+	eventv1 "github.com/spiffe/spire/proto/spire/api/server/event/v1"
+	explainv1 "github.com/spiffe/spire/proto/spire/api/server/explain/v1"
+	federationstatusv1 "github.com/spiffe/spire/proto/spire/api/server/federationstatus/v1"
+	jwksfederationv1 "github.com/spiffe/spire/proto/spire/api/server/jwksfederation/v1"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
@@ -119,6 +126,7 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, endpoints.APIServers.AgentServer)
 	assert.NotNil(t, endpoints.APIServers.BundleServer)
 	assert.NotNil(t, endpoints.APIServers.DebugServer)
+	assert.NotNil(t, endpoints.APIServers.DiagnosticsServer)
 	assert.NotNil(t, endpoints.APIServers.EntryServer)
 	assert.NotNil(t, endpoints.APIServers.HealthServer)
 	assert.NotNil(t, endpoints.APIServers.SVIDServer)
@@ -126,6 +134,7 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, cat.GetDataStore(), endpoints.DataStore)
 	assert.Equal(t, log, endpoints.Log)
 	assert.Equal(t, metrics, endpoints.Metrics)
+	assert.NotNil(t, endpoints.AgentStatusTracker)
 }
 
 func TestNewErrorCreatingAuthorizedEntryFetcher(t *testing.T) {
@@ -224,13 +233,20 @@ func TestListenAndServe(t *testing.T) {
 		DataStore:    ds,
 		BundleCache:  bundle.NewCache(ds, clk),
 		APIServers: APIServers{
-			AgentServer:       &agentv1.UnimplementedAgentServer{},
-			BundleServer:      &bundlev1.UnimplementedBundleServer{},
-			DebugServer:       &debugv1.UnimplementedDebugServer{},
-			EntryServer:       &entryv1.UnimplementedEntryServer{},
-			HealthServer:      &grpc_health_v1.UnimplementedHealthServer{},
-			SVIDServer:        &svidv1.UnimplementedSVIDServer{},
-			TrustDomainServer: &trustdomainv1.UnimplementedTrustDomainServer{},
+			AgentServer:            &agentv1.UnimplementedAgentServer{},
+			AgentAdminServer:       &agentadminv1.UnimplementedAgentAdminServer{},
+			BundleServer:           &bundlev1.UnimplementedBundleServer{},
+			DebugServer:            &debugv1.UnimplementedDebugServer{},
+			DiagnosticsServer:      &diagnosticsv1.UnimplementedDiagnosticsServer{},
+			EntryServer:            &entryv1.UnimplementedEntryServer{},
+			EntryHistoryServer:     &entryhistoryv1.UnimplementedEntryHistoryServer{},
+			EventServer:            &eventv1.UnimplementedEventServer{},
+			ExplainServer:          &explainv1.UnimplementedExplainServer{},
+			FederationStatusServer: &federationstatusv1.UnimplementedFederationStatusServer{},
+			HealthServer:           &grpc_health_v1.UnimplementedHealthServer{},
+			JWKSFederationServer:   &jwksfederationv1.UnimplementedJWKSFederationServer{},
+			SVIDServer:             &svidv1.UnimplementedSVIDServer{},
+			TrustDomainServer:      &trustdomainv1.UnimplementedTrustDomainServer{},
 		},
 		BundleEndpointServer:         bundleEndpointServer,
 		Log:                          log,
@@ -239,6 +255,7 @@ func TestListenAndServe(t *testing.T) {
 		EntryFetcherCacheRebuildTask: ef.RunRebuildCacheTask,
 		AuthPolicyEngine:             pe,
 		AdminIDs:                     []spiffeid.ID{foreignAdminSVID.ID},
+		AgentStatusTracker:           NewAgentStatusTracker(ds, log, metrics, clk),
 	}
 
 	// Prime the datastore with the:
@@ -306,9 +323,15 @@ func TestListenAndServe(t *testing.T) {
 	t.Run("Agent", func(t *testing.T) {
 		testAgentAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
 	})
+	t.Run("AgentAdmin", func(t *testing.T) {
+		testAgentAdminAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
 	t.Run("Debug", func(t *testing.T) {
 		testDebugAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
 	})
+	t.Run("Diagnostics", func(t *testing.T) {
+		testDiagnosticsAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
 	t.Run("Health", func(t *testing.T) {
 		testHealthAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
 	})
@@ -318,6 +341,22 @@ func TestListenAndServe(t *testing.T) {
 	t.Run("Entry", func(t *testing.T) {
 		testEntryAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
 	})
+	t.Run("EntryHistory", func(t *testing.T) {
+		testEntryHistoryAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
+	t.Run("Event", func(t *testing.T) {
+		testEventAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
+	t.Run("Explain", func(t *testing.T) {
+		testExplainAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
+
+	t.Run("FederationStatus", func(t *testing.T) {
+		testFederationStatusAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
+	t.Run("JWKSFederation", func(t *testing.T) {
+		testJWKSFederationAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
+	})
 	t.Run("SVID", func(t *testing.T) {
 		testSVIDAPI(ctx, t, localConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn)
 	})
@@ -528,6 +567,86 @@ func testHealthAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agent
 	})
 }
 
+func testAgentAdminAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(udsConn), map[string]bool{
+			"BatchEvictAgents":     true,
+			"BatchBanAgents":       true,
+			"GetAgentStatus":       true,
+			"ListAgentStatuses":    true,
+			"ReattestAgent":        true,
+			"GetAgentSelectors":    true,
+			"AddAgentSelectors":    true,
+			"RemoveAgentSelectors": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(noauthConn), map[string]bool{
+			"BatchEvictAgents":     false,
+			"BatchBanAgents":       false,
+			"GetAgentStatus":       false,
+			"ListAgentStatuses":    false,
+			"ReattestAgent":        false,
+			"GetAgentSelectors":    false,
+			"AddAgentSelectors":    false,
+			"RemoveAgentSelectors": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(agentConn), map[string]bool{
+			"BatchEvictAgents":     false,
+			"BatchBanAgents":       false,
+			"GetAgentStatus":       false,
+			"ListAgentStatuses":    false,
+			"ReattestAgent":        false,
+			"GetAgentSelectors":    false,
+			"AddAgentSelectors":    false,
+			"RemoveAgentSelectors": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(adminConn), map[string]bool{
+			"BatchEvictAgents":     true,
+			"BatchBanAgents":       true,
+			"GetAgentStatus":       true,
+			"ListAgentStatuses":    true,
+			"ReattestAgent":        true,
+			"GetAgentSelectors":    true,
+			"AddAgentSelectors":    true,
+			"RemoveAgentSelectors": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(federatedAdminConn), map[string]bool{
+			"BatchEvictAgents":     true,
+			"BatchBanAgents":       true,
+			"GetAgentStatus":       true,
+			"ListAgentStatuses":    true,
+			"ReattestAgent":        true,
+			"GetAgentSelectors":    true,
+			"AddAgentSelectors":    true,
+			"RemoveAgentSelectors": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, agentadminv1.NewAgentAdminClient(downstreamConn), map[string]bool{
+			"BatchEvictAgents":     false,
+			"BatchBanAgents":       false,
+			"GetAgentStatus":       false,
+			"ListAgentStatuses":    false,
+			"ReattestAgent":        false,
+			"GetAgentSelectors":    false,
+			"AddAgentSelectors":    false,
+			"RemoveAgentSelectors": false,
+		})
+	})
+}
+
 func testDebugAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, debugv1.NewDebugClient(udsConn), map[string]bool{
@@ -566,6 +685,44 @@ func testDebugAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentC
 	})
 }
 
+func testDiagnosticsAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(udsConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(noauthConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(agentConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(adminConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(federatedAdminConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, diagnosticsv1.NewDiagnosticsClient(downstreamConn), map[string]bool{
+			"GetDiagnostics": true,
+		})
+	})
+}
+
 func testBundleAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, bundlev1.NewBundleClient(udsConn), map[string]bool{
@@ -794,6 +951,214 @@ func testSVIDAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentCo
 	})
 }
 
+func testEntryHistoryAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(udsConn), map[string]bool{
+			"GetEntryHistory": true,
+			"RestoreEntry":    true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(noauthConn), map[string]bool{
+			"GetEntryHistory": false,
+			"RestoreEntry":    false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(agentConn), map[string]bool{
+			"GetEntryHistory": false,
+			"RestoreEntry":    false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(adminConn), map[string]bool{
+			"GetEntryHistory": true,
+			"RestoreEntry":    true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(federatedAdminConn), map[string]bool{
+			"GetEntryHistory": true,
+			"RestoreEntry":    true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, entryhistoryv1.NewEntryHistoryClient(downstreamConn), map[string]bool{
+			"GetEntryHistory": false,
+			"RestoreEntry":    false,
+		})
+	})
+}
+
+func testEventAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(udsConn), map[string]bool{
+			"Watch": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(noauthConn), map[string]bool{
+			"Watch": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(agentConn), map[string]bool{
+			"Watch": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(adminConn), map[string]bool{
+			"Watch": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(federatedAdminConn), map[string]bool{
+			"Watch": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, eventv1.NewEventClient(downstreamConn), map[string]bool{
+			"Watch": false,
+		})
+	})
+}
+
+func testExplainAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(udsConn), map[string]bool{
+			"ExplainAuthorization": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(noauthConn), map[string]bool{
+			"ExplainAuthorization": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(agentConn), map[string]bool{
+			"ExplainAuthorization": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(adminConn), map[string]bool{
+			"ExplainAuthorization": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(federatedAdminConn), map[string]bool{
+			"ExplainAuthorization": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, explainv1.NewExplainClient(downstreamConn), map[string]bool{
+			"ExplainAuthorization": false,
+		})
+	})
+}
+
+func testFederationStatusAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(udsConn), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(noauthConn), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(agentConn), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(adminConn), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(federatedAdminConn), map[string]bool{
+			"ListFederationRelationshipStatuses": true,
+			"GetFederationRelationshipStatus":    true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, federationstatusv1.NewFederationStatusClient(downstreamConn), map[string]bool{
+			"ListFederationRelationshipStatuses": false,
+			"GetFederationRelationshipStatus":    false,
+		})
+	})
+}
+
+func testJWKSFederationAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
+	t.Run("UDS", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(udsConn), map[string]bool{
+			"BatchCreateFederationRelationship": true,
+			"BatchUpdateFederationRelationship": true,
+		})
+	})
+
+	t.Run("NoAuth", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(noauthConn), map[string]bool{
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+		})
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(agentConn), map[string]bool{
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+		})
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(adminConn), map[string]bool{
+			"BatchCreateFederationRelationship": true,
+			"BatchUpdateFederationRelationship": true,
+		})
+	})
+
+	t.Run("Federated Admin", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(federatedAdminConn), map[string]bool{
+			"BatchCreateFederationRelationship": true,
+			"BatchUpdateFederationRelationship": true,
+		})
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		testAuthorization(ctx, t, jwksfederationv1.NewJWKSFederationClient(downstreamConn), map[string]bool{
+			"BatchCreateFederationRelationship": false,
+			"BatchUpdateFederationRelationship": false,
+		})
+	})
+}
+
 func testTrustDomainAPI(ctx context.Context, t *testing.T, udsConn, noauthConn, agentConn, adminConn, federatedAdminConn, downstreamConn *grpc.ClientConn) {
 	t.Run("UDS", func(t *testing.T) {
 		testAuthorization(ctx, t, trustdomainv1.NewTrustDomainClient(udsConn), map[string]bool{
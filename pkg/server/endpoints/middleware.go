@@ -8,6 +8,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/errorutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/api"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	"github.com/spiffe/spire/pkg/server/api/bundle/v1"
 	"github.com/spiffe/spire/pkg/server/api/limits"
 	"github.com/spiffe/spire/pkg/server/api/middleware"
@@ -23,17 +24,17 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, clk clock.Clock, rlConf RateLimitConfig, policyEngine *authpolicy.Engine, auditLogEnabled bool, adminIDs []spiffeid.ID) middleware.Middleware {
+func Middleware(log logrus.FieldLogger, metrics telemetry.Metrics, ds datastore.DataStore, clk clock.Clock, rlConf RateLimitConfig, policyEngine *authpolicy.Engine, auditLogEnabled bool, auditRecorder *audit.Recorder, adminIDs []spiffeid.ID, roles *authpolicy.Roles, agentStatusTracker *AgentStatusTracker) middleware.Middleware {
 	chain := []middleware.Middleware{
 		middleware.WithLogger(log),
 		middleware.WithMetrics(metrics),
-		middleware.WithAuthorization(policyEngine, EntryFetcher(ds), AgentAuthorizer(log, ds, clk), adminIDs),
+		middleware.WithAuthorization(policyEngine, EntryFetcher(ds), reportAgentStatus(AgentAuthorizer(log, ds, clk), agentStatusTracker), adminIDs, roles),
 		middleware.WithRateLimits(RateLimits(rlConf), metrics),
 	}
 
 	if auditLogEnabled {
 		// Add audit log with local tracking enabled
-		chain = append(chain, middleware.WithAuditLog(true))
+		chain = append(chain, middleware.WithAuditRecorder(true, auditRecorder))
 	}
 
 	return middleware.Chain(
@@ -83,13 +84,20 @@ func AgentAuthorizer(log logrus.FieldLogger, ds datastore.DataStore, clk clock.C
 			return nil
 		case attestedNode.NewCertSerialNumber == agentSVID.SerialNumber.String():
 			// AgentSVID matches the new serial number, access granted
-			// Also update the attested node agent serial number from 'new' to 'current'
+			// Also update the attested node agent serial number from 'new' to 'current'.
+			// Only the serial number and expiry fields are updated, so that
+			// fields changed since the node was fetched (e.g. a reattestation
+			// request) are not overwritten with stale values.
 			_, err := ds.UpdateAttestedNode(ctx, &common.AttestedNode{
 				SpiffeId:         attestedNode.SpiffeId,
 				CertNotAfter:     attestedNode.NewCertNotAfter,
 				CertSerialNumber: attestedNode.NewCertSerialNumber,
-				CanReattest:      attestedNode.CanReattest,
-			}, nil)
+			}, &common.AttestedNodeMask{
+				CertNotAfter:        true,
+				CertSerialNumber:    true,
+				NewCertNotAfter:     true,
+				NewCertSerialNumber: true,
+			})
 			if err != nil {
 				log.WithFields(logrus.Fields{
 					telemetry.SVIDSerialNumber: agentSVID.SerialNumber.String(),
@@ -129,44 +137,61 @@ func RateLimits(config RateLimitConfig) map[string]api.RateLimiter {
 	pushJWTKeyLimit := middleware.PerIPLimit(limits.PushJWTKeyLimitPerIP)
 
 	return map[string]api.RateLimiter{
-		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                    noLimit,
-		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                     noLimit,
-		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                csrLimit,
-		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                      jsrLimit,
-		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                             csrLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                   noLimit,
-		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                         pushJWTKeyLimit,
-		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                noLimit,
-		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                        noLimit,
-		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                          noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                  noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                     noLimit,
-		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                  noLimit,
-		"/spire.api.server.debug.v1.Debug/GetInfo":                                       noLimit,
-		"/spire.api.server.entry.v1.Entry/CountEntries":                                  noLimit,
-		"/spire.api.server.entry.v1.Entry/ListEntries":                                   noLimit,
-		"/spire.api.server.entry.v1.Entry/GetEntry":                                      noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                              noLimit,
-		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                          noLimit,
-		"/spire.api.server.agent.v1.Agent/CountAgents":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/ListAgents":                                    noLimit,
-		"/spire.api.server.agent.v1.Agent/GetAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                   noLimit,
-		"/spire.api.server.agent.v1.Agent/BanAgent":                                      noLimit,
-		"/spire.api.server.agent.v1.Agent/AttestAgent":                                   attestLimit,
-		"/spire.api.server.agent.v1.Agent/RenewAgent":                                    csrLimit,
-		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                               noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":       noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":         noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship": noLimit,
-		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                     noLimit,
-		"/grpc.health.v1.Health/Check":                                                   noLimit,
-		"/grpc.health.v1.Health/Watch":                                                   noLimit,
+		"/spire.api.server.svid.v1.SVID/MintX509SVID":                                               noLimit,
+		"/spire.api.server.svid.v1.SVID/MintJWTSVID":                                                noLimit,
+		"/spire.api.server.svid.v1.SVID/BatchNewX509SVID":                                           csrLimit,
+		"/spire.api.server.svid.v1.SVID/NewJWTSVID":                                                 jsrLimit,
+		"/spire.api.server.svid.v1.SVID/NewDownstreamX509CA":                                        csrLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetBundle":                                              noLimit,
+		"/spire.api.server.bundle.v1.Bundle/AppendBundle":                                           noLimit,
+		"/spire.api.server.bundle.v1.Bundle/PublishJWTAuthority":                                    pushJWTKeyLimit,
+		"/spire.api.server.bundle.v1.Bundle/CountBundles":                                           noLimit,
+		"/spire.api.server.bundle.v1.Bundle/ListFederatedBundles":                                   noLimit,
+		"/spire.api.server.bundle.v1.Bundle/GetFederatedBundle":                                     noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchCreateFederatedBundle":                             noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchUpdateFederatedBundle":                             noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchSetFederatedBundle":                                noLimit,
+		"/spire.api.server.bundle.v1.Bundle/BatchDeleteFederatedBundle":                             noLimit,
+		"/spire.api.server.debug.v1.Debug/GetInfo":                                                  noLimit,
+		"/spire.api.server.diagnostics.v1.Diagnostics/GetDiagnostics":                               noLimit,
+		"/spire.api.server.entry.v1.Entry/CountEntries":                                             noLimit,
+		"/spire.api.server.entry.v1.Entry/ListEntries":                                              noLimit,
+		"/spire.api.server.entry.v1.Entry/GetEntry":                                                 noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchCreateEntry":                                         noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchUpdateEntry":                                         noLimit,
+		"/spire.api.server.entry.v1.Entry/BatchDeleteEntry":                                         noLimit,
+		"/spire.api.server.entry.v1.Entry/GetAuthorizedEntries":                                     noLimit,
+		"/spire.api.server.entryhistory.v1.EntryHistory/GetEntryHistory":                            noLimit,
+		"/spire.api.server.entryhistory.v1.EntryHistory/RestoreEntry":                               noLimit,
+		"/spire.api.server.event.v1.Event/Watch":                                                    noLimit,
+		"/spire.api.server.explain.v1.Explain/ExplainAuthorization":                                 noLimit,
+		"/spire.api.server.federationstatus.v1.FederationStatus/ListFederationRelationshipStatuses": noLimit,
+		"/spire.api.server.federationstatus.v1.FederationStatus/GetFederationRelationshipStatus":    noLimit,
+		"/spire.api.server.jwksfederation.v1.JWKSFederation/BatchCreateFederationRelationship":      noLimit,
+		"/spire.api.server.jwksfederation.v1.JWKSFederation/BatchUpdateFederationRelationship":      noLimit,
+		"/spire.api.server.agent.v1.Agent/CountAgents":                                              noLimit,
+		"/spire.api.server.agent.v1.Agent/ListAgents":                                               noLimit,
+		"/spire.api.server.agent.v1.Agent/GetAgent":                                                 noLimit,
+		"/spire.api.server.agent.v1.Agent/DeleteAgent":                                              noLimit,
+		"/spire.api.server.agent.v1.Agent/BanAgent":                                                 noLimit,
+		"/spire.api.server.agent.v1.Agent/AttestAgent":                                              attestLimit,
+		"/spire.api.server.agent.v1.Agent/RenewAgent":                                               csrLimit,
+		"/spire.api.server.agent.v1.Agent/CreateJoinToken":                                          noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/BatchEvictAgents":                               noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/BatchBanAgents":                                 noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentStatus":                                 noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/ListAgentStatuses":                              noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/ReattestAgent":                                  noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentSelectors":                              noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/AddAgentSelectors":                              noLimit,
+		"/spire.api.server.agentadmin.v1.AgentAdmin/RemoveAgentSelectors":                           noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/ListFederationRelationships":                  noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/GetFederationRelationship":                    noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchCreateFederationRelationship":            noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchUpdateFederationRelationship":            noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/BatchDeleteFederationRelationship":            noLimit,
+		"/spire.api.server.trustdomain.v1.TrustDomain/RefreshBundle":                                noLimit,
+		"/grpc.health.v1.Health/Check":                                                              noLimit,
+		"/grpc.health.v1.Health/Watch":                                                              noLimit,
 	}
 }
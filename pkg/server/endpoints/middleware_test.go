@@ -201,6 +201,24 @@ func TestAgentAuthorizer(t *testing.T) {
 				CanReattest:         true,
 			},
 		},
+		{
+			name: "activates new SVID keeping reattestation request",
+			node: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				CertSerialNumber:    "CURRENT",
+				NewCertSerialNumber: agentSVID.SerialNumber.String(),
+				CanReattest:         true,
+				ReattestRequested:   true,
+			},
+			expectedCode: codes.OK,
+			expectedNode: &common.AttestedNode{
+				SpiffeId:            agentID.String(),
+				CertSerialNumber:    agentSVID.SerialNumber.String(),
+				NewCertSerialNumber: "",
+				CanReattest:         true,
+				ReattestRequested:   true,
+			},
+		},
 		{
 			name: "failed to activate new SVID",
 			node: &common.AttestedNode{
@@ -290,6 +308,52 @@ func TestAgentAuthorizer(t *testing.T) {
 	}
 }
 
+func TestAgentAuthorizerDoesNotOverwriteConcurrentChanges(t *testing.T) {
+	ca := testca.New(t, testTD)
+	agentSVID := ca.CreateX509SVID(agentID).Certificates[0]
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New(t)
+
+	node := &common.AttestedNode{
+		SpiffeId:            agentID.String(),
+		CertSerialNumber:    "CURRENT",
+		NewCertSerialNumber: agentSVID.SerialNumber.String(),
+		CanReattest:         true,
+	}
+	_, err := ds.CreateAttestedNode(context.Background(), node)
+	require.NoError(t, err)
+
+	// Reattestation is requested after the authorizer fetched the node
+	_, err = ds.UpdateAttestedNode(context.Background(), &common.AttestedNode{
+		SpiffeId:          agentID.String(),
+		ReattestRequested: true,
+	}, &common.AttestedNodeMask{ReattestRequested: true})
+	require.NoError(t, err)
+
+	authorizer := AgentAuthorizer(log, staleNodeDataStore{DataStore: ds, node: node}, clock.NewMock(t))
+	ctx := rpccontext.WithLogger(context.Background(), log)
+	require.NoError(t, authorizer.AuthorizeAgent(ctx, agentID, agentSVID))
+
+	attestedNode, err := ds.FetchAttestedNode(context.Background(), agentID.String())
+	require.NoError(t, err)
+	spiretest.RequireProtoEqual(t, &common.AttestedNode{
+		SpiffeId:          agentID.String(),
+		CertSerialNumber:  agentSVID.SerialNumber.String(),
+		CanReattest:       true,
+		ReattestRequested: true,
+	}, attestedNode)
+}
+
+// staleNodeDataStore returns the given node instead of the stored one.
+type staleNodeDataStore struct {
+	datastore.DataStore
+	node *common.AttestedNode
+}
+
+func (ds staleNodeDataStore) FetchAttestedNode(context.Context, string) (*common.AttestedNode, error) {
+	return proto.Clone(ds.node).(*common.AttestedNode), nil
+}
+
 func createEntry(t testing.TB, ds datastore.DataStore, entryIn *common.RegistrationEntry) *types.Entry {
 	registrationEntry, err := ds.CreateRegistrationEntry(context.Background(), entryIn)
 	require.NoError(t, err)
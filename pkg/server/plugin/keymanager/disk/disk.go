@@ -7,9 +7,11 @@ import (
 	"os"
 	"sync"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	catalog "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/keyenvelope"
 	keymanagerbase "github.com/spiffe/spire/pkg/server/plugin/keymanager/base"
 	keymanagerv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/server/keymanager/v1"
 	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
@@ -34,15 +36,19 @@ func asBuiltIn(p *KeyManager) catalog.BuiltIn {
 }
 
 type configuration struct {
-	KeysPath string `hcl:"keys_path"`
+	KeysPath   string              `hcl:"keys_path"`
+	Encryption *keyenvelope.Config `hcl:"encryption"`
 }
 
 type KeyManager struct {
 	*keymanagerbase.Base
 	configv1.UnimplementedConfigServer
 
-	mu     sync.Mutex
-	config *configuration
+	log hclog.Logger
+
+	mu      sync.Mutex
+	config  *configuration
+	wrapper keyenvelope.KeyWrapper
 }
 
 func newKeyManager(generator Generator) *KeyManager {
@@ -54,6 +60,10 @@ func newKeyManager(generator Generator) *KeyManager {
 	return m
 }
 
+func (m *KeyManager) SetLogger(log hclog.Logger) {
+	m.log = log
+}
+
 func (m *KeyManager) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
 	config := new(configuration)
 	if err := hcl.Decode(config, req.HclConfiguration); err != nil {
@@ -64,76 +74,110 @@ func (m *KeyManager) Configure(ctx context.Context, req *configv1.ConfigureReque
 		return nil, status.Error(codes.InvalidArgument, "keys_path is required")
 	}
 
+	var wrapper keyenvelope.KeyWrapper
+	if config.Encryption != nil {
+		var err error
+		wrapper, err = config.Encryption.NewKeyWrapper()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid encryption configuration: %v", err)
+		}
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if err := m.configure(config); err != nil {
+	if err := m.configure(ctx, config, wrapper); err != nil {
 		return nil, err
 	}
 
 	return &configv1.ConfigureResponse{}, nil
 }
 
-func (m *KeyManager) configure(config *configuration) error {
+func (m *KeyManager) configure(ctx context.Context, config *configuration, wrapper keyenvelope.KeyWrapper) error {
 	// only load entry information on first configure
 	if m.config == nil {
-		entries, err := loadEntries(config.KeysPath)
+		entries, plaintext, err := loadEntries(ctx, config.KeysPath, wrapper)
 		if err != nil {
 			return err
 		}
+		if plaintext && wrapper != nil {
+			// Encrypt the existing plaintext keys file in place
+			if err := writeEntries(ctx, config.KeysPath, entries, wrapper); err != nil {
+				return err
+			}
+			m.log.Info("Encrypted plaintext keys file", "keys_path", config.KeysPath)
+		}
 		m.Base.SetEntries(entries)
 	}
 
 	m.config = config
+	m.wrapper = wrapper
 	return nil
 }
 
 func (m *KeyManager) writeEntries(ctx context.Context, entries []*keymanagerbase.KeyEntry) error {
 	m.mu.Lock()
 	config := m.config
+	wrapper := m.wrapper
 	m.mu.Unlock()
 
 	if config == nil {
 		return status.Error(codes.FailedPrecondition, "not configured")
 	}
 
-	return writeEntries(config.KeysPath, entries)
+	return writeEntries(ctx, config.KeysPath, entries, wrapper)
 }
 
 type entriesData struct {
 	Keys map[string][]byte `json:"keys"`
 }
 
-func loadEntries(path string) ([]*keymanagerbase.KeyEntry, error) {
+// loadEntries loads the entries from the keys file. If the keys file is
+// encrypted, it is decrypted using the wrapper. It also returns whether or not
+// the keys file was stored in plaintext.
+func loadEntries(ctx context.Context, path string, wrapper keyenvelope.KeyWrapper) ([]*keymanagerbase.KeyEntry, bool, error) {
 	jsonBytes, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	plaintext := !keyenvelope.IsSealed(jsonBytes)
+	if !plaintext {
+		if wrapper == nil {
+			return nil, false, status.Error(codes.FailedPrecondition, "keys file is encrypted but encryption is not configured")
+		}
+		jsonBytes, err = keyenvelope.Open(ctx, wrapper, jsonBytes)
+		if err != nil {
+			return nil, false, status.Errorf(codes.FailedPrecondition, "unable to decrypt keys file: %v", err)
 		}
-		return nil, err
 	}
 
 	data := new(entriesData)
 	if err := json.Unmarshal(jsonBytes, data); err != nil {
-		return nil, status.Errorf(codes.Internal, "unable to decode keys JSON: %v", err)
+		return nil, false, status.Errorf(codes.Internal, "unable to decode keys JSON: %v", err)
 	}
 
 	var entries []*keymanagerbase.KeyEntry
 	for id, keyBytes := range data.Keys {
 		key, err := x509.ParsePKCS8PrivateKey(keyBytes)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to parse key %q: %v", id, err)
+			return nil, false, status.Errorf(codes.Internal, "unable to parse key %q: %v", id, err)
 		}
 		entry, err := keymanagerbase.MakeKeyEntryFromKey(id, key)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "unable to make entry %q: %v", id, err)
+			return nil, false, status.Errorf(codes.Internal, "unable to make entry %q: %v", id, err)
 		}
 		entries = append(entries, entry)
 	}
-	return entries, nil
+	return entries, plaintext, nil
 }
 
-func writeEntries(path string, entries []*keymanagerbase.KeyEntry) error {
+// writeEntries writes the entries to the keys file. If a wrapper is provided,
+// the keys file is encrypted.
+func writeEntries(ctx context.Context, path string, entries []*keymanagerbase.KeyEntry, wrapper keyenvelope.KeyWrapper) error {
 	data := &entriesData{
 		Keys: make(map[string][]byte),
 	}
@@ -150,6 +194,13 @@ func writeEntries(path string, entries []*keymanagerbase.KeyEntry) error {
 		return status.Errorf(codes.Internal, "unable to marshal entries: %v", err)
 	}
 
+	if wrapper != nil {
+		jsonBytes, err = keyenvelope.Seal(ctx, wrapper, jsonBytes)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to encrypt entries: %v", err)
+		}
+	}
+
 	if err := diskutil.AtomicWritePrivateFile(path, jsonBytes); err != nil {
 		return status.Errorf(codes.Internal, "unable to write entries: %v", err)
 	}
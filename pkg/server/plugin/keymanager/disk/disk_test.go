@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spiffe/spire/pkg/common/keyenvelope"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/disk"
 	keymanagertest "github.com/spiffe/spire/pkg/server/plugin/keymanager/test"
@@ -16,6 +17,8 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
+const passphraseEnv = "DISK_KEYMANAGER_TEST_PASSPHRASE"
+
 func TestKeyManagerContract(t *testing.T) {
 	keymanagertest.Test(t, keymanagertest.Config{
 		Create: func(t *testing.T) keymanager.KeyManager {
@@ -27,6 +30,19 @@ func TestKeyManagerContract(t *testing.T) {
 	})
 }
 
+func TestKeyManagerContractWithEncryption(t *testing.T) {
+	t.Setenv(passphraseEnv, "passphrase")
+
+	keymanagertest.Test(t, keymanagertest.Config{
+		Create: func(t *testing.T) keymanager.KeyManager {
+			dir := spiretest.TempDir(t)
+			km, err := loadPlugin(t, `keys_path = %q encryption { passphrase_env = %q }`, filepath.Join(dir, "keys.json"), passphraseEnv)
+			require.NoError(t, err)
+			return km
+		},
+	})
+}
+
 func TestConfigure(t *testing.T) {
 	t.Run("missing keys path", func(t *testing.T) {
 		_, err := loadPlugin(t, "")
@@ -80,6 +96,58 @@ func TestGenerateKeyPersistence(t *testing.T) {
 	)
 }
 
+func TestEncryption(t *testing.T) {
+	t.Setenv(passphraseEnv, "passphrase")
+	dir := spiretest.TempDir(t)
+	keysPath := filepath.Join(dir, "keys.json")
+
+	// generate a key without encryption
+	km, err := loadPlugin(t, "keys_path = %q", keysPath)
+	require.NoError(t, err)
+	keyIn, err := km.GenerateKey(context.Background(), "id", keymanager.ECP256)
+	require.NoError(t, err)
+	require.False(t, keyenvelope.IsSealed(readFile(t, keysPath)))
+
+	// enabling encryption migrates the plaintext keys file
+	km, err = loadPlugin(t, `keys_path = %q encryption { passphrase_env = %q }`, keysPath, passphraseEnv)
+	require.NoError(t, err)
+	require.True(t, keyenvelope.IsSealed(readFile(t, keysPath)))
+	keyOut, err := km.GetKey(context.Background(), "id")
+	require.NoError(t, err)
+	require.Equal(t,
+		publicKeyBytes(t, keyIn),
+		publicKeyBytes(t, keyOut),
+	)
+
+	// new keys are persisted encrypted
+	keyIn, err = km.GenerateKey(context.Background(), "id", keymanager.ECP384)
+	require.NoError(t, err)
+	require.True(t, keyenvelope.IsSealed(readFile(t, keysPath)))
+
+	// reload the plugin. the key should have persisted.
+	km, err = loadPlugin(t, `keys_path = %q encryption { passphrase_env = %q }`, keysPath, passphraseEnv)
+	require.NoError(t, err)
+	keyOut, err = km.GetKey(context.Background(), "id")
+	require.NoError(t, err)
+	require.Equal(t,
+		publicKeyBytes(t, keyIn),
+		publicKeyBytes(t, keyOut),
+	)
+
+	// refuse to start when the keys file cannot be decrypted
+	t.Setenv(passphraseEnv, "nope")
+	_, err = loadPlugin(t, `keys_path = %q encryption { passphrase_env = %q }`, keysPath, passphraseEnv)
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "unable to decrypt keys file: unable to unwrap data encryption key: incorrect passphrase")
+
+	// refuse to start when encryption is not configured
+	_, err = loadPlugin(t, "keys_path = %q", keysPath)
+	spiretest.RequireGRPCStatus(t, err, codes.FailedPrecondition, "keys file is encrypted but encryption is not configured")
+
+	// invalid encryption configuration
+	_, err = loadPlugin(t, `keys_path = %q encryption {}`, keysPath)
+	spiretest.RequireGRPCStatus(t, err, codes.InvalidArgument, "invalid encryption configuration: exactly one of passphrase_file, passphrase_env or kms_plugin must be configured")
+}
+
 func loadPlugin(t *testing.T, configFmt string, configArgs ...interface{}) (keymanager.KeyManager, error) {
 	km := new(keymanager.V1)
 	var configErr error
@@ -90,6 +158,12 @@ func loadPlugin(t *testing.T, configFmt string, configArgs ...interface{}) (keym
 	return km, configErr
 }
 
+func readFile(t *testing.T, path string) []byte {
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return b
+}
+
 func mkdir(t *testing.T, dir string) {
 	require.NoError(t, os.Mkdir(dir, 0755))
 }
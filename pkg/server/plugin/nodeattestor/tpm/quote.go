@@ -0,0 +1,79 @@
+package tpm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
+)
+
+// VerifyQuote verifies that the quote was signed by the attestation key, that
+// it includes the expected nonce and that it covers exactly the expected PCRs
+// (SHA-256 bank) with the reported values.
+func VerifyQuote(akPub *tpm2.Public, nonce []byte, pcrs []int, pcrValues map[int][]byte, quote, signature []byte) error {
+	if err := tpmdevid.CheckSignature(akPub, quote, signature); err != nil {
+		return fmt.Errorf("invalid quote signature: %w", err)
+	}
+
+	data, err := tpm2.DecodeAttestationData(quote)
+	if err != nil {
+		return fmt.Errorf("cannot decode quote: %w", err)
+	}
+
+	if data.Type != tpm2.TagAttestQuote || data.AttestedQuoteInfo == nil {
+		return errors.New("attestation data is not a quote")
+	}
+
+	if !bytes.Equal(data.ExtraData, nonce) {
+		return errors.New("nonce mismatch")
+	}
+
+	sel := data.AttestedQuoteInfo.PCRSelection
+	if sel.Hash != tpm2.AlgSHA256 {
+		return fmt.Errorf("unexpected PCR bank 0x%04x", sel.Hash)
+	}
+
+	expected := sortedPCRs(pcrs)
+	if !equalPCRs(sortedPCRs(sel.PCRs), expected) {
+		return fmt.Errorf("quoted PCRs %v differ from requested PCRs %v", sel.PCRs, pcrs)
+	}
+
+	// The PCR digest is the hash of the concatenation of the selected PCR
+	// values in ascending index order.
+	h := sha256.New()
+	for _, pcr := range expected {
+		value, ok := pcrValues[pcr]
+		if !ok {
+			return fmt.Errorf("missing value for PCR %d", pcr)
+		}
+		h.Write(value)
+	}
+
+	if !bytes.Equal(h.Sum(nil), data.AttestedQuoteInfo.PCRDigest) {
+		return errors.New("PCR values do not match quoted digest")
+	}
+
+	return nil
+}
+
+func sortedPCRs(pcrs []int) []int {
+	sorted := append([]int(nil), pcrs...)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func equalPCRs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
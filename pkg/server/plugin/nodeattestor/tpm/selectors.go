@@ -0,0 +1,30 @@
+package tpm
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
+)
+
+func buildSelectorValues(ekHash string, ekCert *x509.Certificate, pcrs []int, pcrValues map[int][]byte) []string {
+	selectorValues := []string{"ek:hash:" + ekHash}
+
+	// Issuer selectors are only created for verified certificates
+	if ekCert != nil {
+		if ekCert.Issuer.CommonName != "" {
+			selectorValues = append(selectorValues, "ek:issuer:cn:"+ekCert.Issuer.CommonName)
+		}
+		if len(ekCert.AuthorityKeyId) > 0 {
+			selectorValues = append(selectorValues, "ek:issuer:akid:"+hex.EncodeToString(ekCert.AuthorityKeyId))
+		}
+		selectorValues = append(selectorValues, "ek:fingerprint:"+tpmdevid.Fingerprint(ekCert))
+	}
+
+	for _, pcr := range sortedPCRs(pcrs) {
+		selectorValues = append(selectorValues, fmt.Sprintf("pcr:%d:%s", pcr, hex.EncodeToString(pcrValues[pcr])))
+	}
+
+	return selectorValues
+}
@@ -0,0 +1,366 @@
+package tpm
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	common_tpm "github.com/spiffe/spire/pkg/common/plugin/tpm"
+	common_devid "github.com/spiffe/spire/pkg/common/plugin/tpmdevid"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	nodeattestorv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/plugin/server/nodeattestor/v1"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// We use a 32 bytes nonce to provide enough cryptographical randomness and to be
+	// consistent with other nonces sizes around the project.
+	quoteNonceSize = 32
+
+	// maxPCR is the highest PCR index defined by the PC Client Platform TPM Profile
+	maxPCR = 23
+)
+
+// akAttributes are the attributes an attestation key must have. They
+// guarantee that the key was created by the TPM, cannot leave it and can
+// only sign data generated by the TPM itself (e.g. quotes).
+const akAttributes = tpm2.FlagFixedTPM |
+	tpm2.FlagFixedParent |
+	tpm2.FlagSensitiveDataOrigin |
+	tpm2.FlagRestricted |
+	tpm2.FlagSign
+
+func BuiltIn() catalog.BuiltIn {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.BuiltIn {
+	return catalog.MakeBuiltIn(common_tpm.PluginName,
+		nodeattestorv1.NodeAttestorPluginServer(p),
+		configv1.ConfigServiceServer(p),
+	)
+}
+
+type config struct {
+	trustDomain spiffeid.TrustDomain
+
+	ekRoots  *x509.CertPool
+	ekHashes map[string]struct{}
+	pcrs     []int
+}
+
+type Config struct {
+	EndorsementBundlePath string   `hcl:"endorsement_ca_path"`
+	EKHashes              []string `hcl:"ek_hashes"`
+	PCRs                  []int    `hcl:"pcrs"`
+}
+
+type Plugin struct {
+	nodeattestorv1.UnsafeNodeAttestorServer
+	configv1.UnsafeConfigServer
+
+	m sync.Mutex
+	c *config
+}
+
+func New() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Attest(stream nodeattestorv1.NodeAttestor_AttestServer) error {
+	// Receive attestation request
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	conf := p.getConfiguration()
+	if conf == nil {
+		return status.Error(codes.FailedPrecondition, "not configured")
+	}
+
+	payload := req.GetPayload()
+	if payload == nil {
+		return status.Error(codes.InvalidArgument, "missing attestation payload")
+	}
+
+	// Unmarshall received attestation data
+	attData := new(common_tpm.AttestationRequest)
+	err = json.Unmarshal(payload, attData)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "unable to unmarshall attestation data: %v", err)
+	}
+
+	if len(attData.EKPub) == 0 {
+		return status.Error(codes.InvalidArgument, "missing endorsement key public blob")
+	}
+
+	if len(attData.AKPub) == 0 {
+		return status.Error(codes.InvalidArgument, "missing attestation key public blob")
+	}
+
+	ekPub, err := tpm2.DecodePublic(attData.EKPub)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot decode endorsement key public blob: %v", err)
+	}
+
+	akPub, err := tpm2.DecodePublic(attData.AKPub)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot decode attestation key public blob: %v", err)
+	}
+
+	if akPub.Attributes&akAttributes != akAttributes {
+		return status.Errorf(codes.InvalidArgument, "attestation key has invalid attributes: 0x%08x", uint32(akPub.Attributes))
+	}
+
+	ekHash, err := common_tpm.EKHash(attData.EKPub)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "unable to compute endorsement key hash: %v", err)
+	}
+
+	// Verify the endorsement key is trusted, either because its certificate
+	// is rooted to a trusted manufacturer CA or because its hash is allowed.
+	ekCert, err := verifyEndorsementKey(conf, attData.EKCert, ekPub, ekHash)
+	if err != nil {
+		return err
+	}
+
+	// Issue a credential activation challenge (to verify AK is in the same TPM than EK)
+	credActivationChallenge, credActivationNonce, err := tpmdevid.NewCredActivationChallenge(akPub, ekPub)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to generate credential activation challenge: %v", err)
+	}
+
+	// Issue a quote challenge (to get a fresh quote of the configured PCRs)
+	quoteNonce, err := common_devid.GetRandomBytes(quoteNonceSize)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to generate challenge: %v", err)
+	}
+
+	// Marshal challenges
+	challenge, err := json.Marshal(common_tpm.ChallengeRequest{
+		CredActivation: credActivationChallenge,
+		Nonce:          quoteNonce,
+		PCRs:           conf.pcrs,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to marshal challenges data: %v", err)
+	}
+
+	// Send challenges to the agent
+	err = stream.Send(&nodeattestorv1.AttestResponse{
+		Response: &nodeattestorv1.AttestResponse_Challenge{
+			Challenge: challenge,
+		},
+	})
+	if err != nil {
+		return status.Errorf(status.Code(err), "unable to send challenges: %v", err)
+	}
+
+	// Receive challenges response
+	responseReq, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(status.Code(err), "unable to receive challenges response: %v", err)
+	}
+
+	// Unmarshal challenges response
+	challengeResponse := &common_tpm.ChallengeResponse{}
+	if err = json.Unmarshal(responseReq.GetChallengeResponse(), challengeResponse); err != nil {
+		return status.Errorf(codes.InvalidArgument, "unable to unmarshall challenges response: %v", err)
+	}
+
+	// Verify credential activation challenge
+	err = tpmdevid.VerifyCredActivationChallenge(credActivationNonce, challengeResponse.CredActivation)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "credential activation failed: %v", err)
+	}
+
+	// Verify the PCR quote
+	if len(conf.pcrs) > 0 {
+		err = VerifyQuote(&akPub, quoteNonce, conf.pcrs, challengeResponse.PCRValues, challengeResponse.Quote, challengeResponse.QuoteSignature)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "quote verification failed: %v", err)
+		}
+	}
+
+	// Create SPIFFE ID and selectors
+	spiffeID, err := idutil.AgentID(conf.trustDomain, fmt.Sprintf("/%s/%s", common_tpm.PluginName, ekHash))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create agent ID: %v", err)
+	}
+
+	return stream.Send(&nodeattestorv1.AttestResponse{
+		Response: &nodeattestorv1.AttestResponse_AgentAttributes{
+			AgentAttributes: &nodeattestorv1.AgentAttributes{
+				CanReattest:    true,
+				SpiffeId:       spiffeID.String(),
+				SelectorValues: buildSelectorValues(ekHash, ekCert, conf.pcrs, challengeResponse.PCRValues),
+			},
+		},
+	})
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *configv1.ConfigureRequest) (*configv1.ConfigureResponse, error) {
+	trustDomain, err := parseCoreConfig(req.CoreConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	extConf, err := decodePluginConfig(req.HclConfiguration)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to decode configuration: %v", err)
+	}
+
+	err = validatePluginConfig(extConf)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid configuration: %v", err)
+	}
+
+	// Create initial internal configuration
+	intConf := &config{
+		trustDomain: trustDomain,
+		ekHashes:    make(map[string]struct{}, len(extConf.EKHashes)),
+		pcrs:        extConf.PCRs,
+	}
+
+	for _, ekHash := range extConf.EKHashes {
+		intConf.ekHashes[strings.ToLower(ekHash)] = struct{}{}
+	}
+
+	// Load endorsement bundle if configured
+	if extConf.EndorsementBundlePath != "" {
+		intConf.ekRoots, err = util.LoadCertPool(extConf.EndorsementBundlePath)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to load endorsement trust bundle: %v", err)
+		}
+	}
+
+	p.setConfiguration(intConf)
+
+	return &configv1.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) getConfiguration() *config {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
+
+func (p *Plugin) setConfiguration(c *config) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+}
+
+func decodePluginConfig(hclConf string) (*Config, error) {
+	extConfig := new(Config)
+	if err := hcl.Decode(extConfig, hclConf); err != nil {
+		return nil, err
+	}
+
+	return extConfig, nil
+}
+
+func parseCoreConfig(c *configv1.CoreConfiguration) (spiffeid.TrustDomain, error) {
+	if c == nil {
+		return spiffeid.TrustDomain{}, status.Error(codes.InvalidArgument, "core configuration is missing")
+	}
+
+	if c.TrustDomain == "" {
+		return spiffeid.TrustDomain{}, status.Error(codes.InvalidArgument, "trust_domain is required")
+	}
+
+	trustDomain, err := spiffeid.TrustDomainFromString(c.TrustDomain)
+	if err != nil {
+		return spiffeid.TrustDomain{}, status.Errorf(codes.InvalidArgument, "trust_domain is invalid: %v", err)
+	}
+
+	return trustDomain, nil
+}
+
+func validatePluginConfig(extConf *Config) error {
+	if extConf.EndorsementBundlePath == "" && len(extConf.EKHashes) == 0 {
+		return errors.New("at least one of endorsement_ca_path or ek_hashes is required")
+	}
+
+	for _, ekHash := range extConf.EKHashes {
+		if b, err := hex.DecodeString(ekHash); err != nil || len(b) != 32 {
+			return fmt.Errorf("invalid EK hash %q: must be a hex encoded SHA-256 hash", ekHash)
+		}
+	}
+
+	seen := make(map[int]bool, len(extConf.PCRs))
+	for _, pcr := range extConf.PCRs {
+		if pcr < 0 || pcr > maxPCR {
+			return fmt.Errorf("invalid PCR %d: must be between 0 and %d", pcr, maxPCR)
+		}
+		if seen[pcr] {
+			return fmt.Errorf("duplicated PCR %d", pcr)
+		}
+		seen[pcr] = true
+	}
+
+	return nil
+}
+
+// verifyEndorsementKey checks that the endorsement key is trusted. If an
+// endorsement certificate is provided and an endorsement bundle is configured,
+// the certificate chain of trust is verified and the certificate is returned
+// so it can be used to build selectors. Otherwise the EK hash must be in the
+// allowed list.
+func verifyEndorsementKey(conf *config, ekCertBytes []byte, ekPub tpm2.Public, ekHash string) (*x509.Certificate, error) {
+	_, hashAllowed := conf.ekHashes[ekHash]
+
+	var certErr error
+	switch {
+	case conf.ekRoots == nil:
+		certErr = errors.New("no endorsement bundle configured")
+	case len(ekCertBytes) == 0:
+		certErr = errors.New("missing endorsement certificate")
+	default:
+		ekCert, err := verifyEKCert(ekCertBytes, ekPub, conf.ekRoots)
+		if err == nil {
+			return ekCert, nil
+		}
+		certErr = err
+	}
+
+	if !hashAllowed {
+		return nil, status.Errorf(codes.PermissionDenied, "endorsement key is not trusted: %v", certErr)
+	}
+
+	return nil, nil
+}
+
+func verifyEKCert(ekCertBytes []byte, ekPub tpm2.Public, roots *x509.CertPool) (*x509.Certificate, error) {
+	ekCert, err := x509.ParseCertificate(ekCertBytes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse endorsement certificate: %w", err)
+	}
+
+	// Verify the public part of the EK generated from the template is the same
+	// than the one in the EK certificate.
+	if err := tpmdevid.VerifyEKsMatch(ekCert, ekPub); err != nil {
+		return nil, fmt.Errorf("public key in EK certificate differs from public key created via EK template: %w", err)
+	}
+
+	// Verify EK chain of trust using the provided manufacturer roots.
+	if err := tpmdevid.VerifyEKSignature(ekCert, roots); err != nil {
+		return nil, err
+	}
+
+	return ekCert, nil
+}
@@ -0,0 +1,500 @@
+//go:build !darwin
+// +build !darwin
+
+package tpm_test
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpmdevid/tpmutil"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	common_tpm "github.com/spiffe/spire/pkg/common/plugin/tpm"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpm"
+	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpmdevid"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/plugintest"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishnusomank/go-spiffe/v2/spiffeid"
+	configv1 "github.com/vishnusomank/spire-plugin-sdk/proto/spire/service/common/config/v1"
+)
+
+var (
+	endorsementBundlePath string
+
+	isWindows = runtime.GOOS == "windows"
+
+	tpmPasswords = tpmutil.TPMPasswords{
+		EndorsementHierarchy: "endorsement-hierarchy-pass",
+		OwnerHierarchy:       "owner-hierarchy-pass",
+	}
+)
+
+func setupSimulator(t *testing.T) *tpmsimulator.TPMSimulator {
+	// Creates a new global TPM simulator
+	sim, err := tpmsimulator.New(tpmPasswords.EndorsementHierarchy, tpmPasswords.OwnerHierarchy)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, sim.Close(), "unexpected error encountered closing simulator")
+	})
+	tpmutil.OpenTPM = sim.OpenTPM
+
+	// Write endorsement root certificate into temp directory
+	endorsementBundlePath = path.Join(t.TempDir(), "endorsement-ca.pem")
+	require.NoError(t, os.WriteFile(
+		endorsementBundlePath,
+		pemutil.EncodeCertificate(sim.GetEKRoot()),
+		0600),
+	)
+	return sim
+}
+
+func newSession(t *testing.T) *tpmutil.Session {
+	devicePath := "/dev/tpmrm0"
+	if isWindows {
+		devicePath = ""
+	}
+
+	session, err := tpmutil.NewSession(&tpmutil.SessionConfig{
+		DevicePath: devicePath,
+		Passwords:  tpmPasswords,
+		Log:        hclog.NewNullLogger(),
+	})
+	require.NoError(t, err)
+	t.Cleanup(session.Close)
+	return session
+}
+
+func TestConfigure(t *testing.T) {
+	setupSimulator(t)
+
+	validHash := hex.EncodeToString(make([]byte, 32))
+
+	tests := []struct {
+		name     string
+		hclConf  string
+		coreConf *configv1.CoreConfiguration
+		expErr   string
+	}{
+		{
+			name:   "Configure fails if core config is not provided",
+			expErr: "rpc error: code = InvalidArgument desc = core configuration is missing",
+		},
+		{
+			name:     "Configure fails if trust domain is empty",
+			expErr:   "rpc error: code = InvalidArgument desc = trust_domain is required",
+			coreConf: &configv1.CoreConfiguration{},
+		},
+		{
+			name:     "Configure fails if HCL config cannot be decoded",
+			expErr:   "rpc error: code = InvalidArgument desc = unable to decode configuration",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  "not an HCL configuration",
+		},
+		{
+			name:     "Configure fails if neither endorsement_ca_path nor ek_hashes are provided",
+			expErr:   "rpc error: code = InvalidArgument desc = invalid configuration: at least one of endorsement_ca_path or ek_hashes is required",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+		},
+		{
+			name:     "Configure fails if an EK hash is invalid",
+			expErr:   `rpc error: code = InvalidArgument desc = invalid configuration: invalid EK hash "not-a-hash": must be a hex encoded SHA-256 hash`,
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  `ek_hashes = ["not-a-hash"]`,
+		},
+		{
+			name:     "Configure fails if a PCR is out of range",
+			expErr:   "rpc error: code = InvalidArgument desc = invalid configuration: invalid PCR 24: must be between 0 and 23",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  fmt.Sprintf(`ek_hashes = [%q], pcrs = [0, 24]`, validHash),
+		},
+		{
+			name:     "Configure fails if a PCR is duplicated",
+			expErr:   "rpc error: code = InvalidArgument desc = invalid configuration: duplicated PCR 7",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  fmt.Sprintf(`ek_hashes = [%q], pcrs = [7, 7]`, validHash),
+		},
+		{
+			name:     "Configure fails if endorsement trust bundle cannot be opened",
+			expErr:   "rpc error: code = Internal desc = unable to load endorsement trust bundle: open non-existent/endorsement/bundle/path:",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  `endorsement_ca_path = "non-existent/endorsement/bundle/path"`,
+		},
+		{
+			name:     "Configure succeeds with endorsement bundle",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  fmt.Sprintf(`endorsement_ca_path = %q`, endorsementBundlePath),
+		},
+		{
+			name:     "Configure succeeds with EK hashes",
+			coreConf: &configv1.CoreConfiguration{TrustDomain: "example.org"},
+			hclConf:  fmt.Sprintf(`ek_hashes = [%q], pcrs = [0, 7]`, validHash),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := tpm.New()
+			resp, err := plugin.Configure(context.Background(), &configv1.ConfigureRequest{
+				HclConfiguration:  tt.hclConf,
+				CoreConfiguration: tt.coreConf,
+			})
+			if tt.expErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expErr)
+				require.Nil(t, resp)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+		})
+	}
+}
+
+func TestAttestFailures(t *testing.T) {
+	setupSimulator(t)
+	session := newSession(t)
+
+	ekCert, err := session.GetEKCert()
+	require.NoError(t, err)
+	ekPub, err := session.GetEKPublic()
+	require.NoError(t, err)
+	akPub := session.GetAKPublic()
+
+	goodConf := fmt.Sprintf(`endorsement_ca_path = %q, pcrs = [0]`, endorsementBundlePath)
+
+	challengeFnNil := func(ctx context.Context, challenge []byte) ([]byte, error) {
+		return nil, nil
+	}
+
+	// solveCredActivation solves the credential activation challenge but
+	// leaves the quote to the given function
+	solveCredActivation := func(quote func(*common_tpm.ChallengeRequest, *common_tpm.ChallengeResponse)) func(ctx context.Context, challenge []byte) ([]byte, error) {
+		return func(ctx context.Context, challenge []byte) ([]byte, error) {
+			var req common_tpm.ChallengeRequest
+			require.NoError(t, json.Unmarshal(challenge, &req))
+
+			credActivation, err := session.SolveCredActivationChallenge(req.CredActivation.Credential, req.CredActivation.Secret)
+			require.NoError(t, err)
+
+			resp := &common_tpm.ChallengeResponse{CredActivation: credActivation}
+			quote(&req, resp)
+			return json.Marshal(resp)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		hclConf     string
+		expErr      string
+		payload     []byte
+		challengeFn func(ctx context.Context, challenge []byte) ([]byte, error)
+	}{
+		{
+			name:        "Attest fails if payload cannot be unmarshalled",
+			expErr:      "rpc error: code = InvalidArgument desc = nodeattestor(tpm): unable to unmarshall attestation data",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload:     []byte("not a payload"),
+		},
+		{
+			name:        "Attest fails if payload is missing the endorsement key",
+			expErr:      "rpc error: code = InvalidArgument desc = nodeattestor(tpm): missing endorsement key public blob",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{AKPub: akPub}),
+		},
+		{
+			name:        "Attest fails if payload is missing the attestation key",
+			expErr:      "rpc error: code = InvalidArgument desc = nodeattestor(tpm): missing attestation key public blob",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{EKPub: ekPub}),
+		},
+		{
+			name:        "Attest fails if attestation key is not restricted",
+			expErr:      "rpc error: code = InvalidArgument desc = nodeattestor(tpm): attestation key has invalid attributes",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{
+				EKCert: ekCert,
+				EKPub:  ekPub,
+				AKPub: func() []byte {
+					ak, err := tpm2.DecodePublic(akPub)
+					require.NoError(t, err)
+					ak.Attributes &^= tpm2.FlagRestricted
+					b, err := ak.Encode()
+					require.NoError(t, err)
+					return b
+				}(),
+			}),
+		},
+		{
+			name:        "Attest fails if endorsement certificate is missing and EK hash is not allowed",
+			expErr:      "rpc error: code = PermissionDenied desc = nodeattestor(tpm): endorsement key is not trusted: missing endorsement certificate",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{EKPub: ekPub, AKPub: akPub}),
+		},
+		{
+			name:        "Attest fails if endorsement certificate does not match endorsement key",
+			expErr:      "rpc error: code = PermissionDenied desc = nodeattestor(tpm): endorsement key is not trusted: public key in EK certificate differs from public key created via EK template",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{
+				EKCert: ekCert,
+				EKPub:  akPub, // Use AK public blob (instead of EK) to induce a key missmatch error
+				AKPub:  akPub,
+			}),
+		},
+		{
+			name:        "Attest fails if endorsement certificate cannot be chained up to the endorsement root",
+			expErr:      "rpc error: code = PermissionDenied desc = nodeattestor(tpm): endorsement key is not trusted: endorsement certificate verification failed",
+			hclConf:     fmt.Sprintf(`endorsement_ca_path = %q`, writeOtherBundle(t)),
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+		},
+		{
+			name:        "Attest fails if EK hash is not allowed",
+			expErr:      "rpc error: code = PermissionDenied desc = nodeattestor(tpm): endorsement key is not trusted: no endorsement bundle configured",
+			hclConf:     fmt.Sprintf(`ek_hashes = [%q]`, hex.EncodeToString(make([]byte, 32))),
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+		},
+		{
+			name:    "Attest fails if server fails to receive challenge response",
+			expErr:  "unable to respond to challenge",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: func(ctx context.Context, challenge []byte) ([]byte, error) {
+				return nil, errors.New("unable to respond to challenge")
+			},
+		},
+		{
+			name:        "Attest fails if agent sends corrupted challenge response",
+			expErr:      "rpc error: code = InvalidArgument desc = nodeattestor(tpm): unable to unmarshall challenges response:",
+			hclConf:     goodConf,
+			challengeFn: challengeFnNil,
+			payload:     marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+		},
+		{
+			name:    "Attest fails if agent does not solve proof of residency challenge",
+			expErr:  "rpc error: code = InvalidArgument desc = nodeattestor(tpm): credential activation failed",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: func(ctx context.Context, challenge []byte) ([]byte, error) {
+				return json.Marshal(common_tpm.ChallengeResponse{})
+			},
+		},
+		{
+			name:    "Attest fails if quote is missing",
+			expErr:  "rpc error: code = InvalidArgument desc = nodeattestor(tpm): quote verification failed: invalid quote signature",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: solveCredActivation(func(req *common_tpm.ChallengeRequest, resp *common_tpm.ChallengeResponse) {
+			}),
+		},
+		{
+			name:    "Attest fails if quote nonce does not match",
+			expErr:  "rpc error: code = InvalidArgument desc = nodeattestor(tpm): quote verification failed: nonce mismatch",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: solveCredActivation(func(req *common_tpm.ChallengeRequest, resp *common_tpm.ChallengeResponse) {
+				var err error
+				resp.PCRValues, err = session.ReadPCRs(req.PCRs)
+				require.NoError(t, err)
+				resp.Quote, resp.QuoteSignature, err = session.Quote([]byte("another nonce"), req.PCRs)
+				require.NoError(t, err)
+			}),
+		},
+		{
+			name:    "Attest fails if quoted PCRs differ from requested PCRs",
+			expErr:  "rpc error: code = InvalidArgument desc = nodeattestor(tpm): quote verification failed: quoted PCRs [1] differ from requested PCRs [0]",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: solveCredActivation(func(req *common_tpm.ChallengeRequest, resp *common_tpm.ChallengeResponse) {
+				var err error
+				resp.PCRValues, err = session.ReadPCRs([]int{1})
+				require.NoError(t, err)
+				resp.Quote, resp.QuoteSignature, err = session.Quote(req.Nonce, []int{1})
+				require.NoError(t, err)
+			}),
+		},
+		{
+			name:    "Attest fails if reported PCR values do not match the quote",
+			expErr:  "rpc error: code = InvalidArgument desc = nodeattestor(tpm): quote verification failed: PCR values do not match quoted digest",
+			hclConf: goodConf,
+			payload: marshalPayload(t, &common_tpm.AttestationRequest{EKCert: ekCert, EKPub: ekPub, AKPub: akPub}),
+			challengeFn: solveCredActivation(func(req *common_tpm.ChallengeRequest, resp *common_tpm.ChallengeResponse) {
+				var err error
+				resp.Quote, resp.QuoteSignature, err = session.Quote(req.Nonce, req.PCRs)
+				require.NoError(t, err)
+				resp.PCRValues = map[int][]byte{0: []byte("forged value")}
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := loadPlugin(t, tt.hclConf)
+			result, err := plugin.Attest(context.Background(), tt.payload, tt.challengeFn)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expErr)
+			require.Nil(t, result)
+		})
+	}
+}
+
+func TestAttestSucceeds(t *testing.T) {
+	sim := setupSimulator(t)
+	session := newSession(t)
+
+	ekCertBytes, err := session.GetEKCert()
+	require.NoError(t, err)
+	ekPub, err := session.GetEKPublic()
+	require.NoError(t, err)
+	ekHash, err := common_tpm.EKHash(ekPub)
+	require.NoError(t, err)
+
+	pcrValues, err := session.ReadPCRs([]int{0, 7})
+	require.NoError(t, err)
+
+	ekRootSKID := hex.EncodeToString(sim.GetEKRoot().SubjectKeyId)
+	ekCertFingerprint := tpmdevid.Fingerprint(mustParseCert(t, ekCertBytes))
+
+	tests := []struct {
+		name              string
+		hclConf           string
+		ekCert            []byte
+		expectedSelectors []string
+	}{
+		{
+			name:    "Attest succeeds with endorsement certificate",
+			hclConf: fmt.Sprintf(`endorsement_ca_path = %q`, endorsementBundlePath),
+			ekCert:  ekCertBytes,
+			expectedSelectors: []string{
+				"ek:hash:" + ekHash,
+				"ek:issuer:akid:" + ekRootSKID,
+				"ek:fingerprint:" + ekCertFingerprint,
+			},
+		},
+		{
+			name:    "Attest succeeds with allowed EK hash and no endorsement certificate",
+			hclConf: fmt.Sprintf(`ek_hashes = [%q]`, ekHash),
+			expectedSelectors: []string{
+				"ek:hash:" + ekHash,
+			},
+		},
+		{
+			name:    "Attest succeeds with allowed EK hash and untrusted endorsement certificate",
+			hclConf: fmt.Sprintf(`endorsement_ca_path = %q, ek_hashes = [%q]`, writeOtherBundle(t), ekHash),
+			ekCert:  ekCertBytes,
+			expectedSelectors: []string{
+				"ek:hash:" + ekHash,
+			},
+		},
+		{
+			name:    "Attest succeeds with PCR quote",
+			hclConf: fmt.Sprintf(`endorsement_ca_path = %q, pcrs = [7, 0]`, endorsementBundlePath),
+			ekCert:  ekCertBytes,
+			expectedSelectors: []string{
+				"ek:hash:" + ekHash,
+				"ek:issuer:akid:" + ekRootSKID,
+				"ek:fingerprint:" + ekCertFingerprint,
+				"pcr:0:" + hex.EncodeToString(pcrValues[0]),
+				"pcr:7:" + hex.EncodeToString(pcrValues[7]),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			payload := marshalPayload(t, &common_tpm.AttestationRequest{
+				EKCert: tt.ekCert,
+				EKPub:  ekPub,
+				AKPub:  session.GetAKPublic(),
+			})
+
+			challengeFn := func(ctx context.Context, challenge []byte) ([]byte, error) {
+				var req common_tpm.ChallengeRequest
+				require.NoError(t, json.Unmarshal(challenge, &req))
+
+				credActivation, err := session.SolveCredActivationChallenge(req.CredActivation.Credential, req.CredActivation.Secret)
+				require.NoError(t, err)
+
+				resp := common_tpm.ChallengeResponse{CredActivation: credActivation}
+				if len(req.PCRs) > 0 {
+					resp.PCRValues, err = session.ReadPCRs(req.PCRs)
+					require.NoError(t, err)
+					resp.Quote, resp.QuoteSignature, err = session.Quote(req.Nonce, req.PCRs)
+					require.NoError(t, err)
+				}
+				return json.Marshal(resp)
+			}
+
+			plugin := loadPlugin(t, tt.hclConf)
+			result, err := plugin.Attest(context.Background(), payload, challengeFn)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			require.Equal(t, "spiffe://example.org/spire/agent/tpm/"+ekHash, result.AgentID)
+
+			var expected []*common.Selector
+			for _, value := range tt.expectedSelectors {
+				expected = append(expected, &common.Selector{Type: "tpm", Value: value})
+			}
+			require.Equal(t, expected, result.Selectors)
+		})
+	}
+}
+
+func loadPlugin(t *testing.T, config string) nodeattestor.NodeAttestor {
+	v1 := new(nodeattestor.V1)
+	plugintest.Load(t, tpm.BuiltIn(), v1,
+		plugintest.CoreConfig(catalog.CoreConfig{
+			TrustDomain: spiffeid.RequireTrustDomainFromString("example.org"),
+		}),
+		plugintest.Configure(config),
+	)
+	return v1
+}
+
+func marshalPayload(t *testing.T, attReq *common_tpm.AttestationRequest) []byte {
+	attReqBytes, err := json.Marshal(attReq)
+	require.NoError(t, err)
+	return attReqBytes
+}
+
+// writeOtherBundle writes a bundle with a CA that did not sign the
+// simulator endorsement certificate.
+func writeOtherBundle(t *testing.T) string {
+	ca, err := tpmsimulator.NewProvisioningCA(&tpmsimulator.ProvisioningConf{NoIntermediates: true})
+	require.NoError(t, err)
+
+	bundlePath := path.Join(t.TempDir(), "other-ca.pem")
+	require.NoError(t, os.WriteFile(bundlePath, pemutil.EncodeCertificate(ca.RootCert), 0600))
+	return bundlePath
+}
+
+func mustParseCert(t *testing.T, der []byte) *x509.Certificate {
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
@@ -324,13 +324,13 @@ func verifyDevIDResidency(attData *common_devid.AttestationRequest, ekRoots *x50
 
 	// Verify the public part of the EK generated from the template is the same
 	// than the one in the EK certificate.
-	err = verifyEKsMatch(ekCert, ekPub)
+	err = VerifyEKsMatch(ekCert, ekPub)
 	if err != nil {
 		return nil, nil, status.Errorf(codes.InvalidArgument, "public key in EK certificate differs from public key created via EK template: %v", err)
 	}
 
 	// Verify EK chain of trust using the provided manufacturer roots.
-	err = verifyEKSignature(ekCert, ekRoots)
+	err = VerifyEKSignature(ekCert, ekRoots)
 	if err != nil {
 		return nil, nil, status.Errorf(codes.InvalidArgument, "cannot verify EK signature: %v", err)
 	}
@@ -370,7 +370,9 @@ func isDevIDResidencyInfoComplete(attReq *common_devid.AttestationRequest) error
 	return nil
 }
 
-func verifyEKSignature(ekCert *x509.Certificate, roots *x509.CertPool) error {
+// VerifyEKSignature verifies the endorsement certificate chain of trust using
+// the given manufacturer roots.
+func VerifyEKSignature(ekCert *x509.Certificate, roots *x509.CertPool) error {
 	// Check UnhandledCriticalExtensions for OIDs that we know what to do about
 	// it (e.g. it's safe to ignore)
 	subjectAlternativeNameOID := asn1.ObjectIdentifier{2, 5, 29, 17}
@@ -395,9 +397,9 @@ func verifyEKSignature(ekCert *x509.Certificate, roots *x509.CertPool) error {
 	return nil
 }
 
-// verifyEKsMatch checks that the public key generated using the EK template
+// VerifyEKsMatch checks that the public key generated using the EK template
 // matches the public key included in the Endorsement Certificate.
-func verifyEKsMatch(ekCert *x509.Certificate, ekPub tpm2.Public) error {
+func VerifyEKsMatch(ekCert *x509.Certificate, ekPub tpm2.Public) error {
 	keyFromCert, ok := ekCert.PublicKey.(*rsa.PublicKey)
 	if !ok {
 		return errors.New("key from certificate is not an RSA key")
@@ -425,7 +427,7 @@ func verifyEKsMatch(ekCert *x509.Certificate, ekPub tpm2.Public) error {
 }
 
 func VerifyDevIDCertification(pubAK, pubDevID *tpm2.Public, attestData, attestSig []byte) error {
-	err := checkSignature(pubAK, attestData, attestSig)
+	err := CheckSignature(pubAK, attestData, attestSig)
 	if err != nil {
 		return err
 	}
@@ -451,7 +453,9 @@ func VerifyDevIDCertification(pubAK, pubDevID *tpm2.Public, attestData, attestSi
 	return nil
 }
 
-func checkSignature(pub *tpm2.Public, data, sigRaw []byte) error {
+// CheckSignature verifies that sigRaw is a valid signature of data made with
+// the given TPM public key.
+func CheckSignature(pub *tpm2.Public, data, sigRaw []byte) error {
 	key, err := pub.Key()
 	if err != nil {
 		return err
@@ -9,16 +9,46 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/datastore"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 const (
 	_pruningCadence = 5 * time.Minute
+
+	// _pruneAttestedNodesBatchSize is how many attested nodes are pruned in
+	// a single datastore transaction
+	_pruneAttestedNodesBatchSize = 100
+
+	// DefaultEventsRetention is how long events are kept when no retention
+	// is configured
+	DefaultEventsRetention = 24 * time.Hour
+
+	// DefaultEntryHistoryRetention is how long registration entry revisions
+	// are kept when no retention is configured
+	DefaultEntryHistoryRetention = 30 * 24 * time.Hour
 )
 
 // ManagerConfig is the config for the registration manager
 type ManagerConfig struct {
 	DataStore datastore.DataStore
 
+	// EventsRetention is how long datastore events are kept before they are
+	// pruned
+	EventsRetention time.Duration
+
+	// EntryHistoryRetention is how long registration entry revisions are
+	// kept before they are pruned
+	EntryHistoryRetention time.Duration
+
+	// PruneAttestedNodesExpiredFor is how long the SVID of an attested node
+	// must have been expired for before the node is pruned. Attested nodes
+	// are not pruned if zero.
+	PruneAttestedNodesExpiredFor time.Duration
+
+	// PruneNonReattestableNodesOnly restricts pruning to attested nodes that
+	// cannot reattest
+	PruneNonReattestableNodesOnly bool
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 
@@ -37,6 +67,12 @@ func NewManager(c ManagerConfig) *Manager {
 	if c.Clock == nil {
 		c.Clock = clock.New()
 	}
+	if c.EventsRetention <= 0 {
+		c.EventsRetention = DefaultEventsRetention
+	}
+	if c.EntryHistoryRetention <= 0 {
+		c.EntryHistoryRetention = DefaultEntryHistoryRetention
+	}
 
 	return &Manager{
 		c:       c,
@@ -61,6 +97,17 @@ func (m *Manager) pruneEvery(ctx context.Context) error {
 			if err := m.prune(ctx); err != nil && ctx.Err() == nil {
 				m.log.WithError(err).Error("Failed pruning registration entries")
 			}
+			if err := m.pruneEvents(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning events")
+			}
+			if err := m.pruneEntryRevisions(ctx); err != nil && ctx.Err() == nil {
+				m.log.WithError(err).Error("Failed pruning registration entry revisions")
+			}
+			if m.c.PruneAttestedNodesExpiredFor > 0 {
+				if err := m.pruneAttestedNodes(ctx); err != nil && ctx.Err() == nil {
+					m.log.WithError(err).Error("Failed pruning attested nodes")
+				}
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -74,3 +121,51 @@ func (m *Manager) prune(ctx context.Context) (err error) {
 	err = m.c.DataStore.PruneRegistrationEntries(ctx, m.c.Clock.Now())
 	return err
 }
+
+func (m *Manager) pruneEvents(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEventCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	err = m.c.DataStore.PruneEvents(ctx, m.c.Clock.Now().Add(-m.c.EventsRetention))
+	return err
+}
+
+func (m *Manager) pruneEntryRevisions(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEntryRevisionCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	err = m.c.DataStore.PruneRegistrationEntryRevisions(ctx, m.c.Clock.Now().Add(-m.c.EntryHistoryRetention))
+	return err
+}
+
+func (m *Manager) pruneAttestedNodes(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneAttestedNodeCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	expiredBefore := m.c.Clock.Now().Add(-m.c.PruneAttestedNodesExpiredFor)
+
+	// Prune in batches so that a large backlog of expired nodes does not
+	// hold a single long-running transaction. Every batch is committed on
+	// its own, so the nodes pruned before a failure are still reported.
+	var pruned int
+	for {
+		var prunedNodes []*common.AttestedNode
+		prunedNodes, err = m.c.DataStore.PruneAttestedNodes(ctx, expiredBefore, !m.c.PruneNonReattestableNodesOnly, _pruneAttestedNodesBatchSize)
+		for _, node := range prunedNodes {
+			telemetry_server.IncrRegistrationManagerPrunedAttestedNodeCounter(m.c.Metrics, node.AttestationDataType)
+			m.log.WithFields(logrus.Fields{
+				telemetry.SPIFFEID:         node.SpiffeId,
+				telemetry.NodeAttestorType: node.AttestationDataType,
+				telemetry.Expiration:       time.Unix(node.CertNotAfter, 0).UTC(),
+			}).Info("Pruned an expired attested node")
+		}
+		pruned += len(prunedNodes)
+		if err != nil || len(prunedNodes) < _pruneAttestedNodesBatchSize {
+			break
+		}
+	}
+	if pruned > 0 {
+		m.log.WithField(telemetry.Count, pruned).Info("Pruned expired attested nodes")
+	}
+	return err
+}
@@ -2,11 +2,15 @@ package registration
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/datastore"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/clock"
@@ -126,6 +130,182 @@ func (s *ManagerSuite) TestPruning() {
 	s.Empty(listResp.Entries)
 }
 
+func (s *ManagerSuite) TestPruningEvents() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	entry, err := s.ds.CreateRegistrationEntry(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://test.test/testA",
+		SpiffeId:  "spiffe://test.test/testA/test1",
+		Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+	})
+	s.NoError(err)
+	_, err = s.ds.DeleteRegistrationEntry(context.Background(), entry.EntryId)
+	s.NoError(err)
+
+	// events within the retention period are kept
+	s.NoError(s.m.pruneEvents(context.Background()))
+	listResp, err := s.ds.ListEvents(context.Background(), &datastore.ListEventsRequest{})
+	s.NoError(err)
+	s.Len(listResp.Events, 2)
+
+	// events older than the retention period are pruned, except for the
+	// latest event
+	s.clock.Add(DefaultEventsRetention + time.Minute)
+	s.NoError(s.m.pruneEvents(context.Background()))
+	listResp, err = s.ds.ListEvents(context.Background(), &datastore.ListEventsRequest{})
+	s.NoError(err)
+	s.Len(listResp.Events, 1)
+	s.Equal(datastore.EventDeleted, listResp.Events[0].Type)
+}
+
+func (s *ManagerSuite) TestPruningEntryRevisions() {
+	done := s.setupAndRunManager()
+	defer done()
+
+	entry, err := s.ds.CreateRegistrationEntry(context.Background(), &common.RegistrationEntry{
+		ParentId:  "spiffe://test.test/testA",
+		SpiffeId:  "spiffe://test.test/testA/test1",
+		Selectors: []*common.Selector{{Type: "type", Value: "value"}},
+	})
+	s.NoError(err)
+
+	// revisions within the retention period are kept
+	s.NoError(s.m.pruneEntryRevisions(context.Background()))
+	revisions, err := s.ds.ListRegistrationEntryRevisions(context.Background(), entry.EntryId)
+	s.NoError(err)
+	s.Len(revisions, 1)
+
+	// revisions older than the retention period are pruned
+	s.clock.Add(DefaultEntryHistoryRetention + time.Minute)
+	s.NoError(s.m.pruneEntryRevisions(context.Background()))
+	revisions, err = s.ds.ListRegistrationEntryRevisions(context.Background(), entry.EntryId)
+	s.NoError(err)
+	s.Empty(revisions)
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodes() {
+	for _, tt := range []struct {
+		name                          string
+		pruneNonReattestableNodesOnly bool
+		expectPruned                  []string
+	}{
+		{
+			name:         "all node types",
+			expectPruned: []string{"spiffe://test.test/spire/agent/join_token/expired", "spiffe://test.test/spire/agent/aws_iid/expired"},
+		},
+		{
+			name:                          "non-reattestable nodes only",
+			pruneNonReattestableNodesOnly: true,
+			expectPruned:                  []string{"spiffe://test.test/spire/agent/join_token/expired"},
+		},
+	} {
+		tt := tt
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.m = NewManager(ManagerConfig{
+				Clock:                         s.clock,
+				DataStore:                     s.ds,
+				Log:                           s.log,
+				Metrics:                       s.metrics,
+				PruneAttestedNodesExpiredFor:  time.Hour,
+				PruneNonReattestableNodesOnly: tt.pruneNonReattestableNodesOnly,
+			})
+
+			for _, node := range []*common.AttestedNode{
+				{
+					SpiffeId:            "spiffe://test.test/spire/agent/join_token/expired",
+					AttestationDataType: "join_token",
+					CertSerialNumber:    "1",
+					CertNotAfter:        s.clock.Now().Add(-2 * time.Hour).Unix(),
+				},
+				{
+					SpiffeId:            "spiffe://test.test/spire/agent/aws_iid/expired",
+					AttestationDataType: "aws_iid",
+					CertSerialNumber:    "2",
+					CertNotAfter:        s.clock.Now().Add(-2 * time.Hour).Unix(),
+					CanReattest:         true,
+				},
+				{
+					SpiffeId:            "spiffe://test.test/spire/agent/join_token/within-grace-period",
+					AttestationDataType: "join_token",
+					CertSerialNumber:    "3",
+					CertNotAfter:        s.clock.Now().Add(-time.Minute).Unix(),
+				},
+			} {
+				_, err := s.ds.CreateAttestedNode(context.Background(), node)
+				s.Require().NoError(err)
+			}
+
+			s.NoError(s.m.pruneAttestedNodes(context.Background()))
+
+			var pruned []string
+			for _, entry := range s.logHook.AllEntries() {
+				if entry.Message == "Pruned an expired attested node" {
+					pruned = append(pruned, entry.Data[telemetry.SPIFFEID].(string))
+				}
+			}
+			s.ElementsMatch(tt.expectPruned, pruned)
+
+			var prunedCount int
+			for _, metric := range s.metrics.AllMetrics() {
+				if metric.Type == fakemetrics.IncrCounterWithLabelsType && metric.Key[0] == telemetry.Node && metric.Key[2] == telemetry.Pruned {
+					prunedCount++
+				}
+			}
+			s.Equal(len(tt.expectPruned), prunedCount)
+			s.Equal("Pruned expired attested nodes", s.logHook.LastEntry().Message)
+			s.Equal(len(tt.expectPruned), s.logHook.LastEntry().Data[telemetry.Count])
+
+			for _, id := range pruned {
+				node, err := s.ds.FetchAttestedNode(context.Background(), id)
+				s.Require().NoError(err)
+				s.Nil(node)
+			}
+			node, err := s.ds.FetchAttestedNode(context.Background(), "spiffe://test.test/spire/agent/join_token/within-grace-period")
+			s.Require().NoError(err)
+			s.NotNil(node)
+		})
+	}
+}
+
+func (s *ManagerSuite) TestPruningAttestedNodesInBatches() {
+	s.m = NewManager(ManagerConfig{
+		Clock:                        s.clock,
+		DataStore:                    s.ds,
+		Log:                          s.log,
+		Metrics:                      s.metrics,
+		PruneAttestedNodesExpiredFor: time.Hour,
+	})
+
+	for i := 0; i <= _pruneAttestedNodesBatchSize; i++ {
+		_, err := s.ds.CreateAttestedNode(context.Background(), &common.AttestedNode{
+			SpiffeId:            fmt.Sprintf("spiffe://test.test/spire/agent/join_token/expired-%d", i),
+			AttestationDataType: "join_token",
+			CertSerialNumber:    strconv.Itoa(i),
+			CertNotAfter:        s.clock.Now().Add(-2 * time.Hour).Unix(),
+		})
+		s.Require().NoError(err)
+	}
+
+	// The nodes pruned before a batch fails are still reported
+	s.ds.AppendNextError(nil)
+	s.ds.AppendNextError(errors.New("oh no"))
+	s.EqualError(s.m.pruneAttestedNodes(context.Background()), "oh no")
+	s.Equal("Pruned expired attested nodes", s.logHook.LastEntry().Message)
+	s.Equal(_pruneAttestedNodesBatchSize, s.logHook.LastEntry().Data[telemetry.Count])
+
+	// The remaining nodes are pruned on the next run
+	s.logHook.Reset()
+	s.NoError(s.m.pruneAttestedNodes(context.Background()))
+	s.Equal("Pruned expired attested nodes", s.logHook.LastEntry().Message)
+	s.Equal(1, s.logHook.LastEntry().Data[telemetry.Count])
+
+	count, err := s.ds.CountAttestedNodes(context.Background())
+	s.Require().NoError(err)
+	s.Zero(count)
+}
+
 func (s *ManagerSuite) setupAndRunManager() func() {
 	s.m = NewManager(ManagerConfig{
 		Clock:     s.clock,
@@ -21,6 +21,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/uptime"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/api/audit"
 	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
@@ -135,9 +136,31 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return fmt.Errorf("unable to obtain authpolicy engine: %w", err)
 	}
 
+	authPolicyReloader, err := authpolicy.NewReloader(authpolicy.ReloaderConfig{
+		Engine:  authPolicyEngine,
+		Config:  s.config.AuthOpaPolicyEngineConfig,
+		Log:     s.config.Log.WithField(telemetry.SubsystemName, telemetry.AuthPolicy),
+		Metrics: metrics,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to configure authpolicy reloading: %w", err)
+	}
+
+	auditRecorder, err := s.newAuditRecorder()
+	if err != nil {
+		return err
+	}
+	if auditRecorder != nil {
+		defer func() {
+			if err := auditRecorder.Close(); err != nil {
+				s.config.Log.WithError(err).Error("Failed to close audit log sinks")
+			}
+		}()
+	}
+
 	bundleManager := s.newBundleManager(cat, metrics)
 
-	endpointsServer, err := s.newEndpointsServer(ctx, cat, svidRotator, serverCA, metrics, caManager, authPolicyEngine, bundleManager)
+	endpointsServer, err := s.newEndpointsServer(ctx, cat, svidRotator, serverCA, metrics, caManager, authPolicyEngine, bundleManager, auditRecorder)
 	if err != nil {
 		return err
 	}
@@ -185,6 +208,10 @@ func (s *Server) run(ctx context.Context) (err error) {
 		tasks = append(tasks, s.config.LogReopener)
 	}
 
+	if authPolicyReloader != nil {
+		tasks = append(tasks, authPolicyReloader.Run)
+	}
+
 	err = util.RunTasks(ctx, tasks...)
 	if errors.Is(err, context.Canceled) {
 		err = nil
@@ -296,9 +323,13 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 
 func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
 	registrationManager := registration.NewManager(registration.ManagerConfig{
-		DataStore: cat.GetDataStore(),
-		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
-		Metrics:   metrics,
+		DataStore:                     cat.GetDataStore(),
+		EventsRetention:               s.config.EventsRetention,
+		EntryHistoryRetention:         s.config.EntryHistoryRetention,
+		PruneAttestedNodesExpiredFor:  s.config.PruneAttestedNodesExpiredFor,
+		PruneNonReattestableNodesOnly: s.config.PruneNonReattestableNodesOnly,
+		Log:                           s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:                       metrics,
 	})
 	return registrationManager
 }
@@ -317,7 +348,7 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager, authPolicyEngine *authpolicy.Engine, bundleManager *bundle_client.Manager) (endpoints.Server, error) {
+func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics, caManager *ca.Manager, authPolicyEngine *authpolicy.Engine, bundleManager *bundle_client.Manager, auditRecorder *audit.Recorder) (endpoints.Server, error) {
 	config := endpoints.Config{
 		TCPAddr:             s.config.BindAddress,
 		LocalAddr:           s.config.BindLocalAddress,
@@ -334,9 +365,11 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 		Clock:               clock.New(),
 		CacheReloadInterval: s.config.CacheReloadInterval,
 		AuditLogEnabled:     s.config.AuditLogEnabled,
+		AuditRecorder:       auditRecorder,
 		AuthPolicyEngine:    authPolicyEngine,
 		BundleManager:       bundleManager,
 		AdminIDs:            s.config.AdminIDs,
+		Roles:               s.config.Roles,
 	}
 	if s.config.Federation.BundleEndpoint != nil {
 		config.BundleEndpoint.Address = s.config.Federation.BundleEndpoint.Address
@@ -345,6 +378,21 @@ func (s *Server) newEndpointsServer(ctx context.Context, catalog catalog.Catalog
 	return endpoints.New(ctx, config)
 }
 
+func (s *Server) newAuditRecorder() (*audit.Recorder, error) {
+	if !s.config.AuditLogEnabled || s.config.AuditLogSinks == nil {
+		return nil, nil
+	}
+
+	recorder, err := audit.NewRecorder(audit.RecorderConfig{
+		Sinks: *s.config.AuditLogSinks,
+		Log:   s.config.Log.WithField(telemetry.SubsystemName, telemetry.AuditLog),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure audit log sinks: %w", err)
+	}
+	return recorder, nil
+}
+
 func (s *Server) newBundleManager(cat catalog.Catalog, metrics telemetry.Metrics) *bundle_client.Manager {
 	log := s.config.Log.WithField(telemetry.SubsystemName, "bundle_client")
 	return bundle_client.NewManager(bundle_client.ManagerConfig{
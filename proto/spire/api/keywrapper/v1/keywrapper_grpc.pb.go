@@ -0,0 +1,143 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package keywrapperv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// KeyWrapperClient is the client API for KeyWrapper service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeyWrapperClient interface {
+	// Wraps (encrypts) a data encryption key.
+	WrapKey(ctx context.Context, in *WrapKeyRequest, opts ...grpc.CallOption) (*WrapKeyResponse, error)
+	// Unwraps (decrypts) a data encryption key previously wrapped by
+	// WrapKey.
+	UnwrapKey(ctx context.Context, in *UnwrapKeyRequest, opts ...grpc.CallOption) (*UnwrapKeyResponse, error)
+}
+
+type keyWrapperClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyWrapperClient(cc grpc.ClientConnInterface) KeyWrapperClient {
+	return &keyWrapperClient{cc}
+}
+
+func (c *keyWrapperClient) WrapKey(ctx context.Context, in *WrapKeyRequest, opts ...grpc.CallOption) (*WrapKeyResponse, error) {
+	out := new(WrapKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.keywrapper.v1.KeyWrapper/WrapKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyWrapperClient) UnwrapKey(ctx context.Context, in *UnwrapKeyRequest, opts ...grpc.CallOption) (*UnwrapKeyResponse, error) {
+	out := new(UnwrapKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.keywrapper.v1.KeyWrapper/UnwrapKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeyWrapperServer is the server API for KeyWrapper service.
+// All implementations must embed UnimplementedKeyWrapperServer
+// for forward compatibility
+type KeyWrapperServer interface {
+	// Wraps (encrypts) a data encryption key.
+	WrapKey(context.Context, *WrapKeyRequest) (*WrapKeyResponse, error)
+	// Unwraps (decrypts) a data encryption key previously wrapped by
+	// WrapKey.
+	UnwrapKey(context.Context, *UnwrapKeyRequest) (*UnwrapKeyResponse, error)
+	mustEmbedUnimplementedKeyWrapperServer()
+}
+
+// UnimplementedKeyWrapperServer must be embedded to have forward compatible implementations.
+type UnimplementedKeyWrapperServer struct {
+}
+
+func (UnimplementedKeyWrapperServer) WrapKey(context.Context, *WrapKeyRequest) (*WrapKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WrapKey not implemented")
+}
+func (UnimplementedKeyWrapperServer) UnwrapKey(context.Context, *UnwrapKeyRequest) (*UnwrapKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnwrapKey not implemented")
+}
+func (UnimplementedKeyWrapperServer) mustEmbedUnimplementedKeyWrapperServer() {}
+
+// UnsafeKeyWrapperServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyWrapperServer will
+// result in compilation errors.
+type UnsafeKeyWrapperServer interface {
+	mustEmbedUnimplementedKeyWrapperServer()
+}
+
+func RegisterKeyWrapperServer(s grpc.ServiceRegistrar, srv KeyWrapperServer) {
+	s.RegisterService(&KeyWrapper_ServiceDesc, srv)
+}
+
+func _KeyWrapper_WrapKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WrapKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyWrapperServer).WrapKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.keywrapper.v1.KeyWrapper/WrapKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyWrapperServer).WrapKey(ctx, req.(*WrapKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyWrapper_UnwrapKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnwrapKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyWrapperServer).UnwrapKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.keywrapper.v1.KeyWrapper/UnwrapKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyWrapperServer).UnwrapKey(ctx, req.(*UnwrapKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeyWrapper_ServiceDesc is the grpc.ServiceDesc for KeyWrapper service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeyWrapper_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.keywrapper.v1.KeyWrapper",
+	HandlerType: (*KeyWrapperServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "WrapKey",
+			Handler:    _KeyWrapper_WrapKey_Handler,
+		},
+		{
+			MethodName: "UnwrapKey",
+			Handler:    _KeyWrapper_UnwrapKey_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/keywrapper/v1/keywrapper.proto",
+}
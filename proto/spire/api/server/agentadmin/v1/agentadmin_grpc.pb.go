@@ -0,0 +1,444 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package agentadminv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AgentAdminClient is the client API for AgentAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AgentAdminClient interface {
+	// Evicts the agents matching a filter, one page at a time. The agents of
+	// a page are evicted atomically. Evicted agents can attest again.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	BatchEvictAgents(ctx context.Context, in *BatchEvictAgentsRequest, opts ...grpc.CallOption) (*BatchEvictAgentsResponse, error)
+	// Bans the agents matching a filter, one page at a time. The agents of a
+	// page are banned atomically. Agents that are already banned are
+	// skipped.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	BatchBanAgents(ctx context.Context, in *BatchBanAgentsRequest, opts ...grpc.CallOption) (*BatchBanAgentsResponse, error)
+	// Gets the status of an agent, as reported by the agent to the servers.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	GetAgentStatus(ctx context.Context, in *GetAgentStatusRequest, opts ...grpc.CallOption) (*AgentStatus, error)
+	// Lists the status of the agents, as reported by the agents to the
+	// servers, optionally matching a filter.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	ListAgentStatuses(ctx context.Context, in *ListAgentStatusesRequest, opts ...grpc.CallOption) (*ListAgentStatusesResponse, error)
+	// Requests an agent to reattest. The next SVID renewal of the agent is
+	// rejected, so that the agent proves its identity again through node
+	// attestation while keeping its agent ID. Only agents that can reattest
+	// can be requested to.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	ReattestAgent(ctx context.Context, in *ReattestAgentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Gets the selectors of an agent, both those set by node attestation and
+	// those managed by operators.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	GetAgentSelectors(ctx context.Context, in *GetAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error)
+	// Adds selectors managed by operators to an agent. Unlike the selectors
+	// set by node attestation, they are kept when the agent attests again.
+	// Both sets of selectors are used to authorize registration entries.
+	// Selectors that are already set are ignored.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	AddAgentSelectors(ctx context.Context, in *AddAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error)
+	// Removes selectors managed by operators from an agent. Selectors that
+	// are not set are ignored. Selectors can be removed even if the agent no
+	// longer exists.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	RemoveAgentSelectors(ctx context.Context, in *RemoveAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error)
+}
+
+type agentAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentAdminClient(cc grpc.ClientConnInterface) AgentAdminClient {
+	return &agentAdminClient{cc}
+}
+
+func (c *agentAdminClient) BatchEvictAgents(ctx context.Context, in *BatchEvictAgentsRequest, opts ...grpc.CallOption) (*BatchEvictAgentsResponse, error) {
+	out := new(BatchEvictAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/BatchEvictAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) BatchBanAgents(ctx context.Context, in *BatchBanAgentsRequest, opts ...grpc.CallOption) (*BatchBanAgentsResponse, error) {
+	out := new(BatchBanAgentsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/BatchBanAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) GetAgentStatus(ctx context.Context, in *GetAgentStatusRequest, opts ...grpc.CallOption) (*AgentStatus, error) {
+	out := new(AgentStatus)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) ListAgentStatuses(ctx context.Context, in *ListAgentStatusesRequest, opts ...grpc.CallOption) (*ListAgentStatusesResponse, error) {
+	out := new(ListAgentStatusesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/ListAgentStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) ReattestAgent(ctx context.Context, in *ReattestAgentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/ReattestAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) GetAgentSelectors(ctx context.Context, in *GetAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error) {
+	out := new(AgentSelectors)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) AddAgentSelectors(ctx context.Context, in *AddAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error) {
+	out := new(AgentSelectors)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/AddAgentSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentAdminClient) RemoveAgentSelectors(ctx context.Context, in *RemoveAgentSelectorsRequest, opts ...grpc.CallOption) (*AgentSelectors, error) {
+	out := new(AgentSelectors)
+	err := c.cc.Invoke(ctx, "/spire.api.server.agentadmin.v1.AgentAdmin/RemoveAgentSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentAdminServer is the server API for AgentAdmin service.
+// All implementations must embed UnimplementedAgentAdminServer
+// for forward compatibility
+type AgentAdminServer interface {
+	// Evicts the agents matching a filter, one page at a time. The agents of
+	// a page are evicted atomically. Evicted agents can attest again.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	BatchEvictAgents(context.Context, *BatchEvictAgentsRequest) (*BatchEvictAgentsResponse, error)
+	// Bans the agents matching a filter, one page at a time. The agents of a
+	// page are banned atomically. Agents that are already banned are
+	// skipped.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	BatchBanAgents(context.Context, *BatchBanAgentsRequest) (*BatchBanAgentsResponse, error)
+	// Gets the status of an agent, as reported by the agent to the servers.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	GetAgentStatus(context.Context, *GetAgentStatusRequest) (*AgentStatus, error)
+	// Lists the status of the agents, as reported by the agents to the
+	// servers, optionally matching a filter.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	ListAgentStatuses(context.Context, *ListAgentStatusesRequest) (*ListAgentStatusesResponse, error)
+	// Requests an agent to reattest. The next SVID renewal of the agent is
+	// rejected, so that the agent proves its identity again through node
+	// attestation while keeping its agent ID. Only agents that can reattest
+	// can be requested to.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	ReattestAgent(context.Context, *ReattestAgentRequest) (*emptypb.Empty, error)
+	// Gets the selectors of an agent, both those set by node attestation and
+	// those managed by operators.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator or auditor role.
+	GetAgentSelectors(context.Context, *GetAgentSelectorsRequest) (*AgentSelectors, error)
+	// Adds selectors managed by operators to an agent. Unlike the selectors
+	// set by node attestation, they are kept when the agent attests again.
+	// Both sets of selectors are used to authorize registration entries.
+	// Selectors that are already set are ignored.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	AddAgentSelectors(context.Context, *AddAgentSelectorsRequest) (*AgentSelectors, error)
+	// Removes selectors managed by operators from an agent. Selectors that
+	// are not set are ignored. Selectors can be removed even if the agent no
+	// longer exists.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the agent-operator role.
+	RemoveAgentSelectors(context.Context, *RemoveAgentSelectorsRequest) (*AgentSelectors, error)
+	mustEmbedUnimplementedAgentAdminServer()
+}
+
+// UnimplementedAgentAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAgentAdminServer struct {
+}
+
+func (UnimplementedAgentAdminServer) BatchEvictAgents(context.Context, *BatchEvictAgentsRequest) (*BatchEvictAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchEvictAgents not implemented")
+}
+func (UnimplementedAgentAdminServer) BatchBanAgents(context.Context, *BatchBanAgentsRequest) (*BatchBanAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchBanAgents not implemented")
+}
+func (UnimplementedAgentAdminServer) GetAgentStatus(context.Context, *GetAgentStatusRequest) (*AgentStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentStatus not implemented")
+}
+func (UnimplementedAgentAdminServer) ListAgentStatuses(context.Context, *ListAgentStatusesRequest) (*ListAgentStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgentStatuses not implemented")
+}
+func (UnimplementedAgentAdminServer) ReattestAgent(context.Context, *ReattestAgentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReattestAgent not implemented")
+}
+func (UnimplementedAgentAdminServer) GetAgentSelectors(context.Context, *GetAgentSelectorsRequest) (*AgentSelectors, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentSelectors not implemented")
+}
+func (UnimplementedAgentAdminServer) AddAgentSelectors(context.Context, *AddAgentSelectorsRequest) (*AgentSelectors, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAgentSelectors not implemented")
+}
+func (UnimplementedAgentAdminServer) RemoveAgentSelectors(context.Context, *RemoveAgentSelectorsRequest) (*AgentSelectors, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveAgentSelectors not implemented")
+}
+func (UnimplementedAgentAdminServer) mustEmbedUnimplementedAgentAdminServer() {}
+
+// UnsafeAgentAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentAdminServer will
+// result in compilation errors.
+type UnsafeAgentAdminServer interface {
+	mustEmbedUnimplementedAgentAdminServer()
+}
+
+func RegisterAgentAdminServer(s grpc.ServiceRegistrar, srv AgentAdminServer) {
+	s.RegisterService(&AgentAdmin_ServiceDesc, srv)
+}
+
+func _AgentAdmin_BatchEvictAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchEvictAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).BatchEvictAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/BatchEvictAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).BatchEvictAgents(ctx, req.(*BatchEvictAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_BatchBanAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchBanAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).BatchBanAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/BatchBanAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).BatchBanAgents(ctx, req.(*BatchBanAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_GetAgentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).GetAgentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).GetAgentStatus(ctx, req.(*GetAgentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_ListAgentStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).ListAgentStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/ListAgentStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).ListAgentStatuses(ctx, req.(*ListAgentStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_ReattestAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReattestAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).ReattestAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/ReattestAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).ReattestAgent(ctx, req.(*ReattestAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_GetAgentSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).GetAgentSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/GetAgentSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).GetAgentSelectors(ctx, req.(*GetAgentSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_AddAgentSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddAgentSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).AddAgentSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/AddAgentSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).AddAgentSelectors(ctx, req.(*AddAgentSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentAdmin_RemoveAgentSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveAgentSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentAdminServer).RemoveAgentSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.agentadmin.v1.AgentAdmin/RemoveAgentSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentAdminServer).RemoveAgentSelectors(ctx, req.(*RemoveAgentSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AgentAdmin_ServiceDesc is the grpc.ServiceDesc for AgentAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.agentadmin.v1.AgentAdmin",
+	HandlerType: (*AgentAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BatchEvictAgents",
+			Handler:    _AgentAdmin_BatchEvictAgents_Handler,
+		},
+		{
+			MethodName: "BatchBanAgents",
+			Handler:    _AgentAdmin_BatchBanAgents_Handler,
+		},
+		{
+			MethodName: "GetAgentStatus",
+			Handler:    _AgentAdmin_GetAgentStatus_Handler,
+		},
+		{
+			MethodName: "ListAgentStatuses",
+			Handler:    _AgentAdmin_ListAgentStatuses_Handler,
+		},
+		{
+			MethodName: "ReattestAgent",
+			Handler:    _AgentAdmin_ReattestAgent_Handler,
+		},
+		{
+			MethodName: "GetAgentSelectors",
+			Handler:    _AgentAdmin_GetAgentSelectors_Handler,
+		},
+		{
+			MethodName: "AddAgentSelectors",
+			Handler:    _AgentAdmin_AddAgentSelectors_Handler,
+		},
+		{
+			MethodName: "RemoveAgentSelectors",
+			Handler:    _AgentAdmin_RemoveAgentSelectors_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/agentadmin/v1/agentadmin.proto",
+}
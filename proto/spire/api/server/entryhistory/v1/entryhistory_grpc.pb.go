@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package entryhistoryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EntryHistoryClient is the client API for EntryHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EntryHistoryClient interface {
+	// Gets the revisions of a registration entry, including the revisions of
+	// entries that have been deleted. Revisions are retained by the server for
+	// a limited time.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the entry-writer or auditor role.
+	GetEntryHistory(ctx context.Context, in *GetEntryHistoryRequest, opts ...grpc.CallOption) (*GetEntryHistoryResponse, error)
+	// Restores a registration entry to the state it had at the given
+	// revision. If the entry exists, the changes made since the revision are
+	// rolled back. If the entry was deleted, it is recreated with the same
+	// entry ID.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the entry-writer role. Entry writers can only restore entries within
+	// the SPIFFE ID path prefix of their role binding.
+	RestoreEntry(ctx context.Context, in *RestoreEntryRequest, opts ...grpc.CallOption) (*RestoreEntryResponse, error)
+}
+
+type entryHistoryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEntryHistoryClient(cc grpc.ClientConnInterface) EntryHistoryClient {
+	return &entryHistoryClient{cc}
+}
+
+func (c *entryHistoryClient) GetEntryHistory(ctx context.Context, in *GetEntryHistoryRequest, opts ...grpc.CallOption) (*GetEntryHistoryResponse, error) {
+	out := new(GetEntryHistoryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entryhistory.v1.EntryHistory/GetEntryHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryHistoryClient) RestoreEntry(ctx context.Context, in *RestoreEntryRequest, opts ...grpc.CallOption) (*RestoreEntryResponse, error) {
+	out := new(RestoreEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.entryhistory.v1.EntryHistory/RestoreEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EntryHistoryServer is the server API for EntryHistory service.
+// All implementations must embed UnimplementedEntryHistoryServer
+// for forward compatibility
+type EntryHistoryServer interface {
+	// Gets the revisions of a registration entry, including the revisions of
+	// entries that have been deleted. Revisions are retained by the server for
+	// a limited time.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the entry-writer or auditor role.
+	GetEntryHistory(context.Context, *GetEntryHistoryRequest) (*GetEntryHistoryResponse, error)
+	// Restores a registration entry to the state it had at the given
+	// revision. If the entry exists, the changes made since the revision are
+	// rolled back. If the entry was deleted, it is recreated with the same
+	// entry ID.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the entry-writer role. Entry writers can only restore entries within
+	// the SPIFFE ID path prefix of their role binding.
+	RestoreEntry(context.Context, *RestoreEntryRequest) (*RestoreEntryResponse, error)
+	mustEmbedUnimplementedEntryHistoryServer()
+}
+
+// UnimplementedEntryHistoryServer must be embedded to have forward compatible implementations.
+type UnimplementedEntryHistoryServer struct {
+}
+
+func (UnimplementedEntryHistoryServer) GetEntryHistory(context.Context, *GetEntryHistoryRequest) (*GetEntryHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntryHistory not implemented")
+}
+func (UnimplementedEntryHistoryServer) RestoreEntry(context.Context, *RestoreEntryRequest) (*RestoreEntryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEntry not implemented")
+}
+func (UnimplementedEntryHistoryServer) mustEmbedUnimplementedEntryHistoryServer() {}
+
+// UnsafeEntryHistoryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EntryHistoryServer will
+// result in compilation errors.
+type UnsafeEntryHistoryServer interface {
+	mustEmbedUnimplementedEntryHistoryServer()
+}
+
+func RegisterEntryHistoryServer(s grpc.ServiceRegistrar, srv EntryHistoryServer) {
+	s.RegisterService(&EntryHistory_ServiceDesc, srv)
+}
+
+func _EntryHistory_GetEntryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryHistoryServer).GetEntryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entryhistory.v1.EntryHistory/GetEntryHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryHistoryServer).GetEntryHistory(ctx, req.(*GetEntryHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EntryHistory_RestoreEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryHistoryServer).RestoreEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.entryhistory.v1.EntryHistory/RestoreEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryHistoryServer).RestoreEntry(ctx, req.(*RestoreEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EntryHistory_ServiceDesc is the grpc.ServiceDesc for EntryHistory service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EntryHistory_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.entryhistory.v1.EntryHistory",
+	HandlerType: (*EntryHistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEntryHistory",
+			Handler:    _EntryHistory_GetEntryHistory_Handler,
+		},
+		{
+			MethodName: "RestoreEntry",
+			Handler:    _EntryHistory_RestoreEntry_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/entryhistory/v1/entryhistory.proto",
+}
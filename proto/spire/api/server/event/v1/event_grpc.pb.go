@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package eventv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EventClient is the client API for Event service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventClient interface {
+	// Watches for changes to registration entries, agents, bundles and
+	// federation relationships. Events recorded after the cursor in the
+	// request are streamed, followed by new events as they are recorded.
+	// Events are streamed in the order in which they happened, except for
+	// events of concurrent changes, which are streamed in the order in which
+	// the changes are committed.
+	//
+	// If the events recorded after the cursor have been pruned, the call
+	// fails with OUT_OF_RANGE.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Event_WatchClient, error)
+}
+
+type eventClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventClient(cc grpc.ClientConnInterface) EventClient {
+	return &eventClient{cc}
+}
+
+func (c *eventClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Event_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Event_ServiceDesc.Streams[0], "/spire.api.server.event.v1.Event/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Event_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type eventWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventServer is the server API for Event service.
+// All implementations must embed UnimplementedEventServer
+// for forward compatibility
+type EventServer interface {
+	// Watches for changes to registration entries, agents, bundles and
+	// federation relationships. Events recorded after the cursor in the
+	// request are streamed, followed by new events as they are recorded.
+	// Events are streamed in the order in which they happened, except for
+	// events of concurrent changes, which are streamed in the order in which
+	// the changes are committed.
+	//
+	// If the events recorded after the cursor have been pruned, the call
+	// fails with OUT_OF_RANGE.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	Watch(*WatchRequest, Event_WatchServer) error
+	mustEmbedUnimplementedEventServer()
+}
+
+// UnimplementedEventServer must be embedded to have forward compatible implementations.
+type UnimplementedEventServer struct {
+}
+
+func (UnimplementedEventServer) Watch(*WatchRequest, Event_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedEventServer) mustEmbedUnimplementedEventServer() {}
+
+// UnsafeEventServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServer will
+// result in compilation errors.
+type UnsafeEventServer interface {
+	mustEmbedUnimplementedEventServer()
+}
+
+func RegisterEventServer(s grpc.ServiceRegistrar, srv EventServer) {
+	s.RegisterService(&Event_ServiceDesc, srv)
+}
+
+func _Event_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventServer).Watch(m, &eventWatchServer{stream})
+}
+
+type Event_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type eventWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Event_ServiceDesc is the grpc.ServiceDesc for Event service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Event_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.event.v1.Event",
+	HandlerType: (*EventServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Event_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spire/api/server/event/v1/event.proto",
+}
@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.20.1
+// source: spire/api/server/explain/v1/explain.proto
+
+package explainv1
+
+import (
+	types "github.com/spiffe/spire-api-sdk/proto/spire/api/types"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NearMissReason int32
+
+const (
+	NearMissReason_NEAR_MISS_REASON_UNSPECIFIED NearMissReason = 0
+	// The entry is a node alias that the agent does not match because the
+	// agent lacks some of the node selectors of the entry.
+	NearMissReason_MISSING_NODE_SELECTORS NearMissReason = 1
+	// The entry is authorized for the agent but does not match the workload
+	// because the workload lacks some of the selectors of the entry.
+	NearMissReason_MISSING_WORKLOAD_SELECTORS NearMissReason = 2
+	// The entry matches the workload selectors but is not authorized for the
+	// agent, because its parent is neither the agent, a node alias matched by
+	// the agent, nor an entry authorized for the agent.
+	NearMissReason_PARENT_NOT_AUTHORIZED NearMissReason = 3
+)
+
+// Enum value maps for NearMissReason.
+var (
+	NearMissReason_name = map[int32]string{
+		0: "NEAR_MISS_REASON_UNSPECIFIED",
+		1: "MISSING_NODE_SELECTORS",
+		2: "MISSING_WORKLOAD_SELECTORS",
+		3: "PARENT_NOT_AUTHORIZED",
+	}
+	NearMissReason_value = map[string]int32{
+		"NEAR_MISS_REASON_UNSPECIFIED": 0,
+		"MISSING_NODE_SELECTORS":       1,
+		"MISSING_WORKLOAD_SELECTORS":   2,
+		"PARENT_NOT_AUTHORIZED":        3,
+	}
+)
+
+func (x NearMissReason) Enum() *NearMissReason {
+	p := new(NearMissReason)
+	*p = x
+	return p
+}
+
+func (x NearMissReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NearMissReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_spire_api_server_explain_v1_explain_proto_enumTypes[0].Descriptor()
+}
+
+func (NearMissReason) Type() protoreflect.EnumType {
+	return &file_spire_api_server_explain_v1_explain_proto_enumTypes[0]
+}
+
+func (x NearMissReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NearMissReason.Descriptor instead.
+func (NearMissReason) EnumDescriptor() ([]byte, []int) {
+	return file_spire_api_server_explain_v1_explain_proto_rawDescGZIP(), []int{0}
+}
+
+type ExplainAuthorizationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The SPIFFE ID of the agent. Required unless node selectors are
+	// provided.
+	AgentId *types.SPIFFEID `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// The node selectors of the agent. If unset, the selectors of the
+	// attested agent are used. If set, they are used instead, to explain the
+	// authorization of an agent that has not attested yet or whose selectors
+	// would change.
+	NodeSelectors []*types.Selector `protobuf:"bytes,2,rep,name=node_selectors,json=nodeSelectors,proto3" json:"node_selectors,omitempty"`
+	// Optional. The selectors of a workload. If set, the entries matching
+	// the workload are explained.
+	WorkloadSelectors []*types.Selector `protobuf:"bytes,3,rep,name=workload_selectors,json=workloadSelectors,proto3" json:"workload_selectors,omitempty"`
+}
+
+func (x *ExplainAuthorizationRequest) Reset() {
+	*x = ExplainAuthorizationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAuthorizationRequest) ProtoMessage() {}
+
+func (x *ExplainAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*ExplainAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_explain_v1_explain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExplainAuthorizationRequest) GetAgentId() *types.SPIFFEID {
+	if x != nil {
+		return x.AgentId
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationRequest) GetNodeSelectors() []*types.Selector {
+	if x != nil {
+		return x.NodeSelectors
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationRequest) GetWorkloadSelectors() []*types.Selector {
+	if x != nil {
+		return x.WorkloadSelectors
+	}
+	return nil
+}
+
+type ExplainAuthorizationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The node selectors the authorization was evaluated with.
+	NodeSelectors []*types.Selector `protobuf:"bytes,1,rep,name=node_selectors,json=nodeSelectors,proto3" json:"node_selectors,omitempty"`
+	// The node alias entries matched by the node selectors, which contribute
+	// the entries parented by their SPIFFE ID.
+	NodeAliases []*types.Entry `protobuf:"bytes,2,rep,name=node_aliases,json=nodeAliases,proto3" json:"node_aliases,omitempty"`
+	// All the entries authorized for the agent, including node aliases.
+	AuthorizedEntries []*types.Entry `protobuf:"bytes,3,rep,name=authorized_entries,json=authorizedEntries,proto3" json:"authorized_entries,omitempty"`
+	// The authorized entries matching the workload selectors. Empty unless
+	// workload selectors are provided.
+	MatchingEntries []*types.Entry `protobuf:"bytes,4,rep,name=matching_entries,json=matchingEntries,proto3" json:"matching_entries,omitempty"`
+	// The entries that almost contributed to the authorization of the agent
+	// or to matching the workload, and why they did not.
+	NearMisses []*NearMiss `protobuf:"bytes,5,rep,name=near_misses,json=nearMisses,proto3" json:"near_misses,omitempty"`
+}
+
+func (x *ExplainAuthorizationResponse) Reset() {
+	*x = ExplainAuthorizationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainAuthorizationResponse) ProtoMessage() {}
+
+func (x *ExplainAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*ExplainAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_explain_v1_explain_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExplainAuthorizationResponse) GetNodeSelectors() []*types.Selector {
+	if x != nil {
+		return x.NodeSelectors
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationResponse) GetNodeAliases() []*types.Entry {
+	if x != nil {
+		return x.NodeAliases
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationResponse) GetAuthorizedEntries() []*types.Entry {
+	if x != nil {
+		return x.AuthorizedEntries
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationResponse) GetMatchingEntries() []*types.Entry {
+	if x != nil {
+		return x.MatchingEntries
+	}
+	return nil
+}
+
+func (x *ExplainAuthorizationResponse) GetNearMisses() []*NearMiss {
+	if x != nil {
+		return x.NearMisses
+	}
+	return nil
+}
+
+type NearMiss struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The entry.
+	Entry *types.Entry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Why the entry did not contribute.
+	Reason NearMissReason `protobuf:"varint,2,opt,name=reason,proto3,enum=spire.api.server.explain.v1.NearMissReason" json:"reason,omitempty"`
+	// The selectors of the entry missing from the node or workload
+	// selectors, for the MISSING_NODE_SELECTORS and
+	// MISSING_WORKLOAD_SELECTORS reasons.
+	MissingSelectors []*types.Selector `protobuf:"bytes,3,rep,name=missing_selectors,json=missingSelectors,proto3" json:"missing_selectors,omitempty"`
+}
+
+func (x *NearMiss) Reset() {
+	*x = NearMiss{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NearMiss) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearMiss) ProtoMessage() {}
+
+func (x *NearMiss) ProtoReflect() protoreflect.Message {
+	mi := &file_spire_api_server_explain_v1_explain_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearMiss.ProtoReflect.Descriptor instead.
+func (*NearMiss) Descriptor() ([]byte, []int) {
+	return file_spire_api_server_explain_v1_explain_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NearMiss) GetEntry() *types.Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *NearMiss) GetReason() NearMissReason {
+	if x != nil {
+		return x.Reason
+	}
+	return NearMissReason_NEAR_MISS_REASON_UNSPECIFIED
+}
+
+func (x *NearMiss) GetMissingSelectors() []*types.Selector {
+	if x != nil {
+		return x.MissingSelectors
+	}
+	return nil
+}
+
+var File_spire_api_server_explain_v1_explain_proto protoreflect.FileDescriptor
+
+var file_spire_api_server_explain_v1_explain_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78,
+	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x69, 0x64, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x01, 0x0a, 0x1b, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45,
+	0x49, 0x44, 0x52, 0x07, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0e, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0d,
+	0x6e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x48, 0x0a,
+	0x12, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x69, 0x72,
+	0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xed, 0x02, 0x0a, 0x1c, 0x45, 0x78, 0x70, 0x6c,
+	0x61, 0x69, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x6e, 0x6f, 0x64, 0x65,
+	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x0d, 0x6e, 0x6f, 0x64,
+	0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x0c, 0x6e, 0x6f,
+	0x64, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x12, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69,
+	0x7a, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x10,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x46, 0x0a, 0x0b, 0x6e, 0x65, 0x61, 0x72, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x65, 0x61, 0x72, 0x4d, 0x69, 0x73, 0x73, 0x52, 0x0a, 0x6e, 0x65, 0x61,
+	0x72, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x08, 0x4e, 0x65, 0x61, 0x72,
+	0x4d, 0x69, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x65, 0x61, 0x72, 0x4d, 0x69, 0x73, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x11, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x10, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x2a,
+	0x89, 0x01, 0x0a, 0x0e, 0x4e, 0x65, 0x61, 0x72, 0x4d, 0x69, 0x73, 0x73, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x4e, 0x45, 0x41, 0x52, 0x5f, 0x4d, 0x49, 0x53, 0x53, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f,
+	0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x53, 0x10, 0x01,
+	0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x5f, 0x57, 0x4f, 0x52, 0x4b,
+	0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x53, 0x45, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x53, 0x10, 0x02,
+	0x12, 0x19, 0x0a, 0x15, 0x50, 0x41, 0x52, 0x45, 0x4e, 0x54, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x41,
+	0x55, 0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x03, 0x32, 0x97, 0x01, 0x0a, 0x07,
+	0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x12, 0x8b, 0x01, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x6c,
+	0x61, 0x69, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x38, 0x2e, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x39, 0x2e, 0x73, 0x70, 0x69,
+	0x72, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x70, 0x69, 0x72, 0x65, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x2f,
+	0x76, 0x31, 0x3b, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_spire_api_server_explain_v1_explain_proto_rawDescOnce sync.Once
+	file_spire_api_server_explain_v1_explain_proto_rawDescData = file_spire_api_server_explain_v1_explain_proto_rawDesc
+)
+
+func file_spire_api_server_explain_v1_explain_proto_rawDescGZIP() []byte {
+	file_spire_api_server_explain_v1_explain_proto_rawDescOnce.Do(func() {
+		file_spire_api_server_explain_v1_explain_proto_rawDescData = protoimpl.X.CompressGZIP(file_spire_api_server_explain_v1_explain_proto_rawDescData)
+	})
+	return file_spire_api_server_explain_v1_explain_proto_rawDescData
+}
+
+var file_spire_api_server_explain_v1_explain_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_spire_api_server_explain_v1_explain_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_spire_api_server_explain_v1_explain_proto_goTypes = []interface{}{
+	(NearMissReason)(0),                  // 0: spire.api.server.explain.v1.NearMissReason
+	(*ExplainAuthorizationRequest)(nil),  // 1: spire.api.server.explain.v1.ExplainAuthorizationRequest
+	(*ExplainAuthorizationResponse)(nil), // 2: spire.api.server.explain.v1.ExplainAuthorizationResponse
+	(*NearMiss)(nil),                     // 3: spire.api.server.explain.v1.NearMiss
+	(*types.SPIFFEID)(nil),               // 4: spire.api.types.SPIFFEID
+	(*types.Selector)(nil),               // 5: spire.api.types.Selector
+	(*types.Entry)(nil),                  // 6: spire.api.types.Entry
+}
+var file_spire_api_server_explain_v1_explain_proto_depIdxs = []int32{
+	4,  // 0: spire.api.server.explain.v1.ExplainAuthorizationRequest.agent_id:type_name -> spire.api.types.SPIFFEID
+	5,  // 1: spire.api.server.explain.v1.ExplainAuthorizationRequest.node_selectors:type_name -> spire.api.types.Selector
+	5,  // 2: spire.api.server.explain.v1.ExplainAuthorizationRequest.workload_selectors:type_name -> spire.api.types.Selector
+	5,  // 3: spire.api.server.explain.v1.ExplainAuthorizationResponse.node_selectors:type_name -> spire.api.types.Selector
+	6,  // 4: spire.api.server.explain.v1.ExplainAuthorizationResponse.node_aliases:type_name -> spire.api.types.Entry
+	6,  // 5: spire.api.server.explain.v1.ExplainAuthorizationResponse.authorized_entries:type_name -> spire.api.types.Entry
+	6,  // 6: spire.api.server.explain.v1.ExplainAuthorizationResponse.matching_entries:type_name -> spire.api.types.Entry
+	3,  // 7: spire.api.server.explain.v1.ExplainAuthorizationResponse.near_misses:type_name -> spire.api.server.explain.v1.NearMiss
+	6,  // 8: spire.api.server.explain.v1.NearMiss.entry:type_name -> spire.api.types.Entry
+	0,  // 9: spire.api.server.explain.v1.NearMiss.reason:type_name -> spire.api.server.explain.v1.NearMissReason
+	5,  // 10: spire.api.server.explain.v1.NearMiss.missing_selectors:type_name -> spire.api.types.Selector
+	1,  // 11: spire.api.server.explain.v1.Explain.ExplainAuthorization:input_type -> spire.api.server.explain.v1.ExplainAuthorizationRequest
+	2,  // 12: spire.api.server.explain.v1.Explain.ExplainAuthorization:output_type -> spire.api.server.explain.v1.ExplainAuthorizationResponse
+	12, // [12:13] is the sub-list for method output_type
+	11, // [11:12] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_spire_api_server_explain_v1_explain_proto_init() }
+func file_spire_api_server_explain_v1_explain_proto_init() {
+	if File_spire_api_server_explain_v1_explain_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_spire_api_server_explain_v1_explain_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainAuthorizationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_explain_v1_explain_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainAuthorizationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_spire_api_server_explain_v1_explain_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NearMiss); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_spire_api_server_explain_v1_explain_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spire_api_server_explain_v1_explain_proto_goTypes,
+		DependencyIndexes: file_spire_api_server_explain_v1_explain_proto_depIdxs,
+		EnumInfos:         file_spire_api_server_explain_v1_explain_proto_enumTypes,
+		MessageInfos:      file_spire_api_server_explain_v1_explain_proto_msgTypes,
+	}.Build()
+	File_spire_api_server_explain_v1_explain_proto = out.File
+	file_spire_api_server_explain_v1_explain_proto_rawDesc = nil
+	file_spire_api_server_explain_v1_explain_proto_goTypes = nil
+	file_spire_api_server_explain_v1_explain_proto_depIdxs = nil
+}
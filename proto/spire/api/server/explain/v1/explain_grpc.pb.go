@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package explainv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExplainClient is the client API for Explain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExplainClient interface {
+	// Explains which registration entries an agent is authorized for, the
+	// same way the server evaluates them when the agent syncs, and which of
+	// those entries match a workload.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error)
+}
+
+type explainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExplainClient(cc grpc.ClientConnInterface) ExplainClient {
+	return &explainClient{cc}
+}
+
+func (c *explainClient) ExplainAuthorization(ctx context.Context, in *ExplainAuthorizationRequest, opts ...grpc.CallOption) (*ExplainAuthorizationResponse, error) {
+	out := new(ExplainAuthorizationResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.explain.v1.Explain/ExplainAuthorization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExplainServer is the server API for Explain service.
+// All implementations must embed UnimplementedExplainServer
+// for forward compatibility
+type ExplainServer interface {
+	// Explains which registration entries an agent is authorized for, the
+	// same way the server evaluates them when the agent syncs, and which of
+	// those entries match a workload.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error)
+	mustEmbedUnimplementedExplainServer()
+}
+
+// UnimplementedExplainServer must be embedded to have forward compatible implementations.
+type UnimplementedExplainServer struct {
+}
+
+func (UnimplementedExplainServer) ExplainAuthorization(context.Context, *ExplainAuthorizationRequest) (*ExplainAuthorizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainAuthorization not implemented")
+}
+func (UnimplementedExplainServer) mustEmbedUnimplementedExplainServer() {}
+
+// UnsafeExplainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExplainServer will
+// result in compilation errors.
+type UnsafeExplainServer interface {
+	mustEmbedUnimplementedExplainServer()
+}
+
+func RegisterExplainServer(s grpc.ServiceRegistrar, srv ExplainServer) {
+	s.RegisterService(&Explain_ServiceDesc, srv)
+}
+
+func _Explain_ExplainAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplainServer).ExplainAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.explain.v1.Explain/ExplainAuthorization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplainServer).ExplainAuthorization(ctx, req.(*ExplainAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Explain_ServiceDesc is the grpc.ServiceDesc for Explain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Explain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.explain.v1.Explain",
+	HandlerType: (*ExplainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExplainAuthorization",
+			Handler:    _Explain_ExplainAuthorization_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/explain/v1/explain.proto",
+}
@@ -0,0 +1,159 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package federationstatusv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FederationStatusClient is the client API for FederationStatus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FederationStatusClient interface {
+	// Lists the bundle refresh status of the federation relationships
+	// managed by the server.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	ListFederationRelationshipStatuses(ctx context.Context, in *ListFederationRelationshipStatusesRequest, opts ...grpc.CallOption) (*ListFederationRelationshipStatusesResponse, error)
+	// Gets the bundle refresh status of the federation relationship with the
+	// given trust domain. If the relationship is not managed by the server,
+	// NOT_FOUND is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	GetFederationRelationshipStatus(ctx context.Context, in *GetFederationRelationshipStatusRequest, opts ...grpc.CallOption) (*FederationRelationshipStatus, error)
+}
+
+type federationStatusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFederationStatusClient(cc grpc.ClientConnInterface) FederationStatusClient {
+	return &federationStatusClient{cc}
+}
+
+func (c *federationStatusClient) ListFederationRelationshipStatuses(ctx context.Context, in *ListFederationRelationshipStatusesRequest, opts ...grpc.CallOption) (*ListFederationRelationshipStatusesResponse, error) {
+	out := new(ListFederationRelationshipStatusesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.federationstatus.v1.FederationStatus/ListFederationRelationshipStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationStatusClient) GetFederationRelationshipStatus(ctx context.Context, in *GetFederationRelationshipStatusRequest, opts ...grpc.CallOption) (*FederationRelationshipStatus, error) {
+	out := new(FederationRelationshipStatus)
+	err := c.cc.Invoke(ctx, "/spire.api.server.federationstatus.v1.FederationStatus/GetFederationRelationshipStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FederationStatusServer is the server API for FederationStatus service.
+// All implementations must embed UnimplementedFederationStatusServer
+// for forward compatibility
+type FederationStatusServer interface {
+	// Lists the bundle refresh status of the federation relationships
+	// managed by the server.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	ListFederationRelationshipStatuses(context.Context, *ListFederationRelationshipStatusesRequest) (*ListFederationRelationshipStatusesResponse, error)
+	// Gets the bundle refresh status of the federation relationship with the
+	// given trust domain. If the relationship is not managed by the server,
+	// NOT_FOUND is returned.
+	//
+	// The caller must be local, present an admin X509-SVID, or be granted
+	// the auditor role.
+	GetFederationRelationshipStatus(context.Context, *GetFederationRelationshipStatusRequest) (*FederationRelationshipStatus, error)
+	mustEmbedUnimplementedFederationStatusServer()
+}
+
+// UnimplementedFederationStatusServer must be embedded to have forward compatible implementations.
+type UnimplementedFederationStatusServer struct {
+}
+
+func (UnimplementedFederationStatusServer) ListFederationRelationshipStatuses(context.Context, *ListFederationRelationshipStatusesRequest) (*ListFederationRelationshipStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederationRelationshipStatuses not implemented")
+}
+func (UnimplementedFederationStatusServer) GetFederationRelationshipStatus(context.Context, *GetFederationRelationshipStatusRequest) (*FederationRelationshipStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFederationRelationshipStatus not implemented")
+}
+func (UnimplementedFederationStatusServer) mustEmbedUnimplementedFederationStatusServer() {}
+
+// UnsafeFederationStatusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FederationStatusServer will
+// result in compilation errors.
+type UnsafeFederationStatusServer interface {
+	mustEmbedUnimplementedFederationStatusServer()
+}
+
+func RegisterFederationStatusServer(s grpc.ServiceRegistrar, srv FederationStatusServer) {
+	s.RegisterService(&FederationStatus_ServiceDesc, srv)
+}
+
+func _FederationStatus_ListFederationRelationshipStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationRelationshipStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationStatusServer).ListFederationRelationshipStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.federationstatus.v1.FederationStatus/ListFederationRelationshipStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationStatusServer).ListFederationRelationshipStatuses(ctx, req.(*ListFederationRelationshipStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationStatus_GetFederationRelationshipStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFederationRelationshipStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationStatusServer).GetFederationRelationshipStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.federationstatus.v1.FederationStatus/GetFederationRelationshipStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationStatusServer).GetFederationRelationshipStatus(ctx, req.(*GetFederationRelationshipStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FederationStatus_ServiceDesc is the grpc.ServiceDesc for FederationStatus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FederationStatus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.federationstatus.v1.FederationStatus",
+	HandlerType: (*FederationStatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFederationRelationshipStatuses",
+			Handler:    _FederationStatus_ListFederationRelationshipStatuses_Handler,
+		},
+		{
+			MethodName: "GetFederationRelationshipStatus",
+			Handler:    _FederationStatus_GetFederationRelationshipStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/federationstatus/v1/federationstatus.proto",
+}
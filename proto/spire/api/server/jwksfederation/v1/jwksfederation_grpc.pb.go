@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package jwksfederationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// JWKSFederationClient is the client API for JWKSFederation service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JWKSFederationClient interface {
+	// Batch creates one or more federation relationships using the
+	// https_jwks bundle endpoint profile. The bundle endpoint profile of the
+	// relationships must be unset.
+	//
+	// The caller must be local or present an admin X509-SVID. No role grants
+	// access to this method.
+	BatchCreateFederationRelationship(ctx context.Context, in *BatchCreateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchCreateFederationRelationshipResponse, error)
+	// Batch updates one or more federation relationships, switching them to
+	// the https_jwks bundle endpoint profile when the profile is included in
+	// the input mask. The bundle endpoint profile of the relationships must
+	// be unset.
+	//
+	// The caller must be local or present an admin X509-SVID. No role grants
+	// access to this method.
+	BatchUpdateFederationRelationship(ctx context.Context, in *BatchUpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchUpdateFederationRelationshipResponse, error)
+}
+
+type jWKSFederationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJWKSFederationClient(cc grpc.ClientConnInterface) JWKSFederationClient {
+	return &jWKSFederationClient{cc}
+}
+
+func (c *jWKSFederationClient) BatchCreateFederationRelationship(ctx context.Context, in *BatchCreateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchCreateFederationRelationshipResponse, error) {
+	out := new(BatchCreateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.jwksfederation.v1.JWKSFederation/BatchCreateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jWKSFederationClient) BatchUpdateFederationRelationship(ctx context.Context, in *BatchUpdateFederationRelationshipRequest, opts ...grpc.CallOption) (*BatchUpdateFederationRelationshipResponse, error) {
+	out := new(BatchUpdateFederationRelationshipResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.server.jwksfederation.v1.JWKSFederation/BatchUpdateFederationRelationship", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JWKSFederationServer is the server API for JWKSFederation service.
+// All implementations must embed UnimplementedJWKSFederationServer
+// for forward compatibility
+type JWKSFederationServer interface {
+	// Batch creates one or more federation relationships using the
+	// https_jwks bundle endpoint profile. The bundle endpoint profile of the
+	// relationships must be unset.
+	//
+	// The caller must be local or present an admin X509-SVID. No role grants
+	// access to this method.
+	BatchCreateFederationRelationship(context.Context, *BatchCreateFederationRelationshipRequest) (*BatchCreateFederationRelationshipResponse, error)
+	// Batch updates one or more federation relationships, switching them to
+	// the https_jwks bundle endpoint profile when the profile is included in
+	// the input mask. The bundle endpoint profile of the relationships must
+	// be unset.
+	//
+	// The caller must be local or present an admin X509-SVID. No role grants
+	// access to this method.
+	BatchUpdateFederationRelationship(context.Context, *BatchUpdateFederationRelationshipRequest) (*BatchUpdateFederationRelationshipResponse, error)
+	mustEmbedUnimplementedJWKSFederationServer()
+}
+
+// UnimplementedJWKSFederationServer must be embedded to have forward compatible implementations.
+type UnimplementedJWKSFederationServer struct {
+}
+
+func (UnimplementedJWKSFederationServer) BatchCreateFederationRelationship(context.Context, *BatchCreateFederationRelationshipRequest) (*BatchCreateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateFederationRelationship not implemented")
+}
+func (UnimplementedJWKSFederationServer) BatchUpdateFederationRelationship(context.Context, *BatchUpdateFederationRelationshipRequest) (*BatchUpdateFederationRelationshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateFederationRelationship not implemented")
+}
+func (UnimplementedJWKSFederationServer) mustEmbedUnimplementedJWKSFederationServer() {}
+
+// UnsafeJWKSFederationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JWKSFederationServer will
+// result in compilation errors.
+type UnsafeJWKSFederationServer interface {
+	mustEmbedUnimplementedJWKSFederationServer()
+}
+
+func RegisterJWKSFederationServer(s grpc.ServiceRegistrar, srv JWKSFederationServer) {
+	s.RegisterService(&JWKSFederation_ServiceDesc, srv)
+}
+
+func _JWKSFederation_BatchCreateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JWKSFederationServer).BatchCreateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.jwksfederation.v1.JWKSFederation/BatchCreateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JWKSFederationServer).BatchCreateFederationRelationship(ctx, req.(*BatchCreateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JWKSFederation_BatchUpdateFederationRelationship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateFederationRelationshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JWKSFederationServer).BatchUpdateFederationRelationship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.server.jwksfederation.v1.JWKSFederation/BatchUpdateFederationRelationship",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JWKSFederationServer).BatchUpdateFederationRelationship(ctx, req.(*BatchUpdateFederationRelationshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JWKSFederation_ServiceDesc is the grpc.ServiceDesc for JWKSFederation service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JWKSFederation_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.server.jwksfederation.v1.JWKSFederation",
+	HandlerType: (*JWKSFederationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BatchCreateFederationRelationship",
+			Handler:    _JWKSFederation_BatchCreateFederationRelationship_Handler,
+		},
+		{
+			MethodName: "BatchUpdateFederationRelationship",
+			Handler:    _JWKSFederation_BatchUpdateFederationRelationship_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spire/api/server/jwksfederation/v1/jwksfederation.proto",
+}
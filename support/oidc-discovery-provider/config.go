@@ -1,10 +1,14 @@
 package main
 
 import (
+	"crypto/x509"
+	"encoding/base64"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/zeebo/errs"
 )
 
@@ -89,6 +93,28 @@ type ACMEConfig struct {
 	// RawCacheDir is used to determine whether the cache was explicitly disabled
 	// (by setting to an empty) string. Consumers should use CacheDir instead.
 	RawCacheDir *string `hcl:"cache_dir"`
+
+	// DirectoryCABundlePath is the path to a PEM file containing the root CAs
+	// used to authenticate the ACME server. If unset, the system roots are
+	// used.
+	DirectoryCABundlePath string `hcl:"directory_ca_bundle_path"`
+
+	// DirectoryRootCAs are the root CAs used to authenticate the ACME server.
+	// This value is calculated in LoadConfig()/ParseConfig() from
+	// DirectoryCABundlePath.
+	DirectoryRootCAs []*x509.Certificate `hcl:"-"`
+
+	// EABKeyID is the key identifier of the External Account Binding used to
+	// register the account, if required by the ACME server.
+	EABKeyID string `hcl:"eab_key_id"`
+
+	// RawEABHMACKey is the base64url encoded MAC key of the External Account
+	// Binding. Consumers should use EABHMACKey instead.
+	RawEABHMACKey string `hcl:"eab_hmac_key"`
+
+	// EABHMACKey is the MAC key of the External Account Binding. This value
+	// is calculated in LoadConfig()/ParseConfig() from RawEABHMACKey.
+	EABHMACKey []byte `hcl:"-"`
 }
 
 type ServerAPIConfig struct {
@@ -191,6 +217,20 @@ func ParseConfig(hclConfig string) (_ *Config, err error) {
 			return nil, errs.New("tos_accepted must be set to true in the acme configuration section")
 		case c.ACME.Email == "":
 			return nil, errs.New("email must be configured in the acme configuration section")
+		case (c.ACME.EABKeyID == "") != (c.ACME.RawEABHMACKey == ""):
+			return nil, errs.New("eab_key_id and eab_hmac_key must be configured together in the acme configuration section")
+		}
+		if c.ACME.RawEABHMACKey != "" {
+			c.ACME.EABHMACKey, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(c.ACME.RawEABHMACKey, "="))
+			if err != nil {
+				return nil, errs.New("invalid eab_hmac_key in the acme configuration section: %v", err)
+			}
+		}
+		if c.ACME.DirectoryCABundlePath != "" {
+			c.ACME.DirectoryRootCAs, err = pemutil.LoadCertificates(c.ACME.DirectoryCABundlePath)
+			if err != nil {
+				return nil, errs.New("unable to load directory_ca_bundle_path in the acme configuration section: %v", err)
+			}
 		}
 	}
 
@@ -3,8 +3,10 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 )
@@ -81,6 +83,77 @@ func TestParseConfig(t *testing.T) {
 	}
 }
 
+func TestParseConfigACMEOptions(t *testing.T) {
+	caPath := filepath.Join("..", "..", "test", "fixture", "certs", "ca.pem")
+	rootCAs, err := pemutil.LoadCertificates(caPath)
+	require.NoError(t, err)
+
+	withACMEOptions := func(options string) string {
+		return strings.Replace(minimalServerAPIConfig, "tos_accepted = true", "tos_accepted = true\n"+options, 1)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		options  string
+		expected *ACMEConfig
+		err      string
+	}{
+		{
+			name: "external account binding and directory CA bundle",
+			options: `
+				eab_key_id = "kid"
+				eab_hmac_key = "aG1hYy1rZXk"
+				directory_ca_bundle_path = "` + filepath.ToSlash(caPath) + `"
+			`,
+			expected: &ACMEConfig{
+				CacheDir:              defaultCacheDir,
+				Email:                 "admin@domain.test",
+				ToSAccepted:           true,
+				DirectoryCABundlePath: filepath.ToSlash(caPath),
+				DirectoryRootCAs:      rootCAs,
+				EABKeyID:              "kid",
+				RawEABHMACKey:         "aG1hYy1rZXk",
+				EABHMACKey:            []byte("hmac-key"),
+			},
+		},
+		{
+			name:    "external account binding without MAC key",
+			options: `eab_key_id = "kid"`,
+			err:     "eab_key_id and eab_hmac_key must be configured together in the acme configuration section",
+		},
+		{
+			name:    "external account binding without key ID",
+			options: `eab_hmac_key = "aG1hYy1rZXk"`,
+			err:     "eab_key_id and eab_hmac_key must be configured together in the acme configuration section",
+		},
+		{
+			name: "invalid external account binding MAC key",
+			options: `
+				eab_key_id = "kid"
+				eab_hmac_key = "not base64!"
+			`,
+			err: "invalid eab_hmac_key in the acme configuration section",
+		},
+		{
+			name:    "directory CA bundle not found",
+			options: `directory_ca_bundle_path = "does-not-exist.pem"`,
+			err:     "unable to load directory_ca_bundle_path in the acme configuration section",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseConfig(withACMEOptions(tt.options))
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual.ACME)
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
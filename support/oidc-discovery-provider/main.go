@@ -2,6 +2,7 @@ package main
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"net"
@@ -146,14 +147,38 @@ func newACMEListener(log logrus.FieldLogger, config *Config) (net.Listener, erro
 		cache = autocert.DirCache(config.ACME.CacheDir)
 	}
 
+	var eab *acme.ExternalAccountBinding
+	if config.ACME.EABKeyID != "" {
+		eab = &acme.ExternalAccountBinding{
+			KID: config.ACME.EABKeyID,
+			Key: config.ACME.EABHMACKey,
+		}
+	}
+
+	var httpClient *http.Client
+	if len(config.ACME.DirectoryRootCAs) > 0 {
+		rootCAs := x509.NewCertPool()
+		for _, rootCA := range config.ACME.DirectoryRootCAs {
+			rootCAs.AddCert(rootCA)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+
 	m := autocert.Manager{
 		Cache: cache,
 		Client: &acme.Client{
 			UserAgent:    "SPIRE OIDC Discovery Provider",
 			DirectoryURL: config.ACME.DirectoryURL,
+			HTTPClient:   httpClient,
 		},
-		Email:      config.ACME.Email,
-		HostPolicy: autocert.HostWhitelist(config.Domains...),
+		ExternalAccountBinding: eab,
+		Email:                  config.ACME.Email,
+		HostPolicy:             autocert.HostWhitelist(config.Domains...),
 		Prompt: func(tosURL string) bool {
 			log.WithField("url", tosURL).Info("ACME Terms Of Service accepted")
 			return config.ACME.ToSAccepted
@@ -154,6 +154,13 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context, node *common.Atteste
 	return s.ds.UpdateAttestedNode(ctx, node, mask)
 }
 
+func (s *DataStore) UpdateAttestedNodesStatus(ctx context.Context, statuses []*datastore.AttestedNodeStatus) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.UpdateAttestedNodesStatus(ctx, statuses)
+}
+
 func (s *DataStore) DeleteAttestedNode(ctx context.Context, spiffeID string) (*common.AttestedNode, error) {
 	if err := s.getNextError(); err != nil {
 		return nil, err
@@ -161,6 +168,27 @@ func (s *DataStore) DeleteAttestedNode(ctx context.Context, spiffeID string) (*c
 	return s.ds.DeleteAttestedNode(ctx, spiffeID)
 }
 
+func (s *DataStore) DeleteAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.DeleteAttestedNodes(ctx, req)
+}
+
+func (s *DataStore) PruneAttestedNodes(ctx context.Context, expiredBefore time.Time, includeReattestable bool, limit int) ([]*common.AttestedNode, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.PruneAttestedNodes(ctx, expiredBefore, includeReattestable, limit)
+}
+
+func (s *DataStore) BanAttestedNodes(ctx context.Context, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.BanAttestedNodes(ctx, req)
+}
+
 func (s *DataStore) SetNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) error {
 	if err := s.getNextError(); err != nil {
 		return err
@@ -187,6 +215,34 @@ func (s *DataStore) GetNodeSelectors(ctx context.Context, spiffeID string, dataC
 	return selectors, err
 }
 
+func (s *DataStore) AddOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.AddOperatorNodeSelectors(ctx, spiffeID, selectors)
+}
+
+func (s *DataStore) RemoveOperatorNodeSelectors(ctx context.Context, spiffeID string, selectors []*common.Selector) ([]*common.Selector, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.RemoveOperatorNodeSelectors(ctx, spiffeID, selectors)
+}
+
+func (s *DataStore) ListOperatorNodeSelectors(ctx context.Context, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListOperatorNodeSelectors(ctx, req)
+}
+
+func (s *DataStore) GetOperatorNodeSelectors(ctx context.Context, spiffeID string, dataConsistency datastore.DataConsistency) ([]*common.Selector, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.GetOperatorNodeSelectors(ctx, spiffeID, dataConsistency)
+}
+
 func (s *DataStore) CountRegistrationEntries(ctx context.Context) (int32, error) {
 	if err := s.getNextError(); err != nil {
 		return 0, err
@@ -311,6 +367,55 @@ func (s *DataStore) UpdateFederationRelationship(ctx context.Context, fr *datast
 	return s.ds.UpdateFederationRelationship(ctx, fr, mask)
 }
 
+func (s *DataStore) GetLatestEventID(ctx context.Context) (uint, error) {
+	if err := s.getNextError(); err != nil {
+		return 0, err
+	}
+	return s.ds.GetLatestEventID(ctx)
+}
+
+func (s *DataStore) ListEvents(ctx context.Context, req *datastore.ListEventsRequest) (*datastore.ListEventsResponse, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListEvents(ctx, req)
+}
+
+func (s *DataStore) PruneEvents(ctx context.Context, olderThan time.Time) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.PruneEvents(ctx, olderThan)
+}
+
+func (s *DataStore) FetchRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (*datastore.RegistrationEntryRevision, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.FetchRegistrationEntryRevision(ctx, entryID, revision)
+}
+
+func (s *DataStore) ListRegistrationEntryRevisions(ctx context.Context, entryID string) ([]*datastore.RegistrationEntryRevision, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.ListRegistrationEntryRevisions(ctx, entryID)
+}
+
+func (s *DataStore) PruneRegistrationEntryRevisions(ctx context.Context, olderThan time.Time) error {
+	if err := s.getNextError(); err != nil {
+		return err
+	}
+	return s.ds.PruneRegistrationEntryRevisions(ctx, olderThan)
+}
+
+func (s *DataStore) RestoreRegistrationEntryRevision(ctx context.Context, entryID string, revision int64) (*common.RegistrationEntry, error) {
+	if err := s.getNextError(); err != nil {
+		return nil, err
+	}
+	return s.ds.RestoreRegistrationEntryRevision(ctx, entryID, revision)
+}
+
 func (s *DataStore) SetNextError(err error) {
 	s.errs = []error{err}
 }